@@ -14,6 +14,8 @@ type Config struct {
 	Database DatabaseConfig
 	// Log ログ設定
 	Log LogConfig
+	// Report レポート設定
+	Report ReportConfig
 }
 
 // ServerConfig サーバー設定
@@ -36,6 +38,12 @@ type LogConfig struct {
 	Level string
 }
 
+// ReportConfig レポート設定
+type ReportConfig struct {
+	// StorageDir 生成ファイルの保存先ディレクトリ
+	StorageDir string
+}
+
 // Load 環境変数から設定を読み込む
 func Load() *Config {
 	return &Config{
@@ -49,6 +57,9 @@ func Load() *Config {
 		Log: LogConfig{
 			Level: getEnv("LOG_LEVEL", "info"),
 		},
+		Report: ReportConfig{
+			StorageDir: getEnv("REPORT_STORAGE_DIR", "storage/reports"),
+		},
 	}
 }
 
@@ -17,6 +17,7 @@ func TestLoad(t *testing.T) {
 		_ = os.Unsetenv("SERVER_PORT")
 		_ = os.Unsetenv("DATABASE_URL")
 		_ = os.Unsetenv("LOG_LEVEL")
+		_ = os.Unsetenv("REPORT_STORAGE_DIR")
 
 		cfg := Load()
 
@@ -32,6 +33,9 @@ func TestLoad(t *testing.T) {
 		if cfg.Log.Level != "info" {
 			t.Errorf("Log.Level = %v, want %v", cfg.Log.Level, "info")
 		}
+		if cfg.Report.StorageDir != "storage/reports" {
+			t.Errorf("Report.StorageDir = %v, want %v", cfg.Report.StorageDir, "storage/reports")
+		}
 	})
 
 	t.Run("環境変数からの読み込み", func(t *testing.T) {
@@ -7,11 +7,20 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"time"
 
 	"shiftmaster/internal/config"
 	authApp "shiftmaster/internal/modules/auth/application"
 	authInfra "shiftmaster/internal/modules/auth/infrastructure"
 	authPres "shiftmaster/internal/modules/auth/presentation"
+	leaveApp "shiftmaster/internal/modules/leave/application"
+	leaveDomain "shiftmaster/internal/modules/leave/domain"
+	leaveInfra "shiftmaster/internal/modules/leave/infrastructure"
+	leavePres "shiftmaster/internal/modules/leave/presentation"
+	reportApp "shiftmaster/internal/modules/report/application"
+	reportDomain "shiftmaster/internal/modules/report/domain"
+	reportInfra "shiftmaster/internal/modules/report/infrastructure"
+	reportPres "shiftmaster/internal/modules/report/presentation"
 	requestApp "shiftmaster/internal/modules/request/application"
 	requestDomain "shiftmaster/internal/modules/request/domain"
 	requestInfra "shiftmaster/internal/modules/request/infrastructure"
@@ -57,35 +66,63 @@ type Container struct {
 	TokenService *authInfra.JWTTokenService
 
 	// Repositories
-	StaffRepo         staffDomain.StaffRepository
-	TeamRepo          staffDomain.TeamRepository
-	DepartmentRepo    staffDomain.DepartmentRepository
-	OrganizationRepo  staffDomain.OrganizationRepository
-	UserRepo          userDomain.UserRepository
-	RefreshTokenRepo  userDomain.RefreshTokenRepository
-	ShiftTypeRepo     shiftDomain.ShiftTypeRepository
-	ScheduleRepo      scheduleDomain.ScheduleRepository
-	ScheduleEntryRepo scheduleDomain.ScheduleEntryRepository
-	RequestPeriodRepo requestDomain.RequestPeriodRepository
-	ShiftRequestRepo  requestDomain.ShiftRequestRepository
+	StaffRepo             staffDomain.StaffRepository
+	StaffAvailabilityRepo staffDomain.StaffAvailabilityRepository
+	TeamRepo              staffDomain.TeamRepository
+	DepartmentRepo        staffDomain.DepartmentRepository
+	OrganizationRepo      staffDomain.OrganizationRepository
+	UserRepo              userDomain.UserRepository
+	RefreshTokenRepo      userDomain.RefreshTokenRepository
+	ShiftTypeRepo         shiftDomain.ShiftTypeRepository
+	ShiftRuleRepo         shiftDomain.ShiftRuleRepository
+	ScheduleRepo          scheduleDomain.ScheduleRepository
+	ScheduleEntryRepo     scheduleDomain.ScheduleEntryRepository
+	ActualRecordRepo      scheduleDomain.ActualRecordRepository
+	OvertimeAgreementRepo scheduleDomain.OvertimeAgreementRepository
+	CalendarTokenRepo     scheduleDomain.CalendarTokenRepository
+	ShiftSwapRepo         scheduleDomain.ShiftSwapRepository
+	OpenShiftRepo         scheduleDomain.OpenShiftRepository
+	RequestPeriodRepo     requestDomain.RequestPeriodRepository
+	ShiftRequestRepo      requestDomain.ShiftRequestRepository
+	ReportRepo            reportDomain.ReportRepository
+	LeaveGrantRepo        leaveDomain.LeaveGrantRepository
+	LeaveRequestRepo      leaveDomain.LeaveRequestRepository
 
 	// UseCases
-	StaffUseCase         *staffApp.StaffUseCase
-	UserUseCase          *userApp.UserUseCase
-	AuthUseCase          *authApp.AuthUseCase
-	ShiftTypeUseCase     *shiftApp.ShiftTypeUseCase
-	ScheduleUseCase      *scheduleApp.ScheduleUseCase
-	RequestPeriodUseCase *requestApp.RequestPeriodUseCase
-	ShiftRequestUseCase  *requestApp.ShiftRequestUseCase
+	StaffUseCase              *staffApp.StaffUseCase
+	StaffAvailabilityUseCase  *staffApp.StaffAvailabilityUseCase
+	UserUseCase               *userApp.UserUseCase
+	AuthUseCase               *authApp.AuthUseCase
+	ShiftTypeUseCase          *shiftApp.ShiftTypeUseCase
+	ShiftRuleUseCase          *shiftApp.ShiftRuleUseCase
+	ScheduleUseCase           *scheduleApp.ScheduleUseCase
+	ActualRecordUseCase       *scheduleApp.ActualRecordUseCase
+	OvertimeComplianceService *scheduleApp.OvertimeComplianceService
+	CalendarUseCase           *scheduleApp.CalendarUseCase
+	ShiftSwapUseCase          *scheduleApp.ShiftSwapUseCase
+	OpenShiftUseCase          *scheduleApp.OpenShiftUseCase
+	RequestPeriodUseCase      *requestApp.RequestPeriodUseCase
+	ShiftRequestUseCase       *requestApp.ShiftRequestUseCase
+	ReportUseCase             *reportApp.ReportUseCase
+	LeaveUseCase              *leaveApp.LeaveUseCase
 
 	// Handlers
-	StaffHandler     *staffPres.StaffHandler
-	TeamHandler      *staffPres.TeamHandler
-	UserHandler      *userPres.UserHandler
-	AuthHandler      *authPres.AuthHandler
-	ShiftTypeHandler *shiftPres.ShiftTypeHandler
-	ScheduleHandler  *schedulePres.ScheduleHandler
-	RequestHandler   *requestPres.RequestHandler
+	StaffHandler             *staffPres.StaffHandler
+	StaffAvailabilityHandler *staffPres.AvailabilityHandler
+	TeamHandler              *staffPres.TeamHandler
+	UserHandler              *userPres.UserHandler
+	AuthHandler              *authPres.AuthHandler
+	ShiftTypeHandler         *shiftPres.ShiftTypeHandler
+	ShiftRuleHandler         *shiftPres.ShiftRuleHandler
+	ScheduleHandler          *schedulePres.ScheduleHandler
+	ActualRecordHandler      *schedulePres.ActualRecordHandler
+	OvertimeAgreementHandler *schedulePres.OvertimeAgreementHandler
+	CalendarHandler          *schedulePres.CalendarHandler
+	ShiftSwapHandler         *schedulePres.ShiftSwapHandler
+	OpenShiftHandler         *schedulePres.OpenShiftHandler
+	RequestHandler           *requestPres.RequestHandler
+	ReportHandler            *reportPres.ReportHandler
+	LeaveHandler             *leavePres.LeaveHandler
 }
 
 // NewContainer コンテナ生成
@@ -115,48 +152,122 @@ func NewContainer(cfg *config.Config, logger *slog.Logger) (*Container, error) {
 	teamRepo := staffInfra.NewPostgresTeamRepository(db)
 	departmentRepo := staffInfra.NewPostgresDepartmentRepository(db)
 	organizationRepo := staffInfra.NewPostgresOrganizationRepository(db)
+	skillRepo := staffInfra.NewPostgresSkillRepository(db)
+	jobTypeRepo := staffInfra.NewPostgresJobTypeRepository(db)
+	staffAssignmentRepo := staffInfra.NewPostgresStaffAssignmentRepository(db)
+	positionRepo := staffInfra.NewPostgresPositionRepository(db)
+	staffAvailabilityRepo := staffInfra.NewPostgresStaffAvailabilityRepository(db)
 	userRepo := userInfra.NewBunUserRepository(db)
 	refreshTokenRepo := userInfra.NewBunRefreshTokenRepository(db)
 	shiftTypeRepo := shiftInfra.NewPostgresShiftTypeRepository(db)
+	shiftRuleRepo := shiftInfra.NewPostgresShiftRuleRepository(db)
 	scheduleRepo := scheduleInfra.NewPostgresScheduleRepository(db)
 	scheduleEntryRepo := scheduleInfra.NewPostgresScheduleEntryRepository(db)
+	actualRecordRepo := scheduleInfra.NewPostgresActualRecordRepository(db)
+	overtimeAgreementRepo := scheduleInfra.NewPostgresOvertimeAgreementRepository(db)
+	calendarTokenRepo := scheduleInfra.NewPostgresCalendarTokenRepository(db)
+	shiftSwapRepo := scheduleInfra.NewPostgresShiftSwapRepository(db)
+	openShiftRepo := scheduleInfra.NewPostgresOpenShiftRepository(db)
 	requestPeriodRepo := requestInfra.NewPostgresRequestPeriodRepository(db)
 	shiftRequestRepo := requestInfra.NewPostgresShiftRequestRepository(db)
+	reportRepo := reportInfra.NewPostgresReportRepository(db)
+	leaveGrantRepo := leaveInfra.NewPostgresLeaveGrantRepository(db)
+	leaveRequestRepo := leaveInfra.NewPostgresLeaveRequestRepository(db)
 
 	// ユースケース初期化
 	staffUseCase := staffApp.NewStaffUseCase(staffRepo, teamRepo, departmentRepo, logger)
+	staffImportUseCase := staffApp.NewStaffImportUseCase(staffInfra.NewPostgresStaffImportRepository(db), teamRepo, jobTypeRepo, positionRepo, logger)
+	staffAvailabilityUseCase := staffApp.NewStaffAvailabilityUseCase(
+		staffAvailabilityRepo,
+		staffRepo,
+		teamRepo,
+		departmentRepo,
+		&staffShiftTypeOptionFinderAdapter{repo: shiftTypeRepo},
+		logger,
+	)
 	userUseCase := userApp.NewUserUseCase(userRepo, refreshTokenRepo, logger)
 	authUseCase := authApp.NewAuthUseCase(userRepo, refreshTokenRepo, tokenService, logger)
 	shiftTypeUseCase := shiftApp.NewShiftTypeUseCase(shiftTypeRepo, logger)
-	scheduleUseCase := scheduleApp.NewScheduleUseCase(scheduleRepo, scheduleEntryRepo, shiftTypeRepo, staffRepo, nil, logger)
+	shiftRuleUseCase := shiftApp.NewShiftRuleUseCase(shiftRuleRepo, logger)
+	scheduleProposalRepo := scheduleInfra.NewPostgresScheduleProposalRepository(db)
+	qualificationFinder := &staffQualificationFinderAdapter{
+		staffRepo:        staffRepo,
+		skillRepo:        skillRepo,
+		jobTypeRepo:      jobTypeRepo,
+		positionRepo:     positionRepo,
+		assignmentRepo:   staffAssignmentRepo,
+		availabilityRepo: staffAvailabilityRepo,
+	}
+	shiftRequestFinder := &shiftRequestFinderAdapter{periodRepo: requestPeriodRepo, requestRepo: shiftRequestRepo}
+	scheduleOptimizer := scheduleInfra.NewLocalSearchOptimizer(scheduleRepo, staffRepo, shiftTypeRepo, shiftRuleRepo, shiftRequestFinder, qualificationFinder, logger)
+	overtimeComplianceService := scheduleApp.NewOvertimeComplianceService(overtimeAgreementRepo, scheduleRepo, scheduleEntryRepo, actualRecordRepo, shiftTypeRepo, staffRepo, logger)
+	scheduleUseCase := scheduleApp.NewScheduleUseCase(scheduleRepo, scheduleEntryRepo, scheduleProposalRepo, shiftTypeRepo, staffRepo, shiftRuleRepo, qualificationFinder, shiftRequestFinder, scheduleOptimizer, overtimeComplianceService, logger)
+	actualRecordUseCase := scheduleApp.NewActualRecordUseCase(scheduleRepo, scheduleEntryRepo, actualRecordRepo, shiftTypeRepo, staffRepo, logger)
+	calendarUseCase := scheduleApp.NewCalendarUseCase(scheduleRepo, scheduleEntryRepo, shiftTypeRepo, staffRepo, calendarTokenRepo, logger)
+	shiftSwapUseCase := scheduleApp.NewShiftSwapUseCase(shiftSwapRepo, scheduleRepo, scheduleEntryRepo, shiftTypeRepo, staffRepo, shiftRuleRepo, qualificationFinder, shiftRequestFinder, logger)
+	openShiftUseCase := scheduleApp.NewOpenShiftUseCase(openShiftRepo, scheduleRepo, scheduleEntryRepo, shiftTypeRepo, staffRepo, shiftRuleRepo, qualificationFinder, shiftRequestFinder, logger)
 	requestPeriodUseCase := requestApp.NewRequestPeriodUseCase(requestPeriodRepo, shiftRequestRepo, logger)
 	shiftRequestUseCase := requestApp.NewShiftRequestUseCase(shiftRequestRepo, requestPeriodRepo, logger)
+	reportUseCase := reportApp.NewReportUseCase(
+		reportRepo,
+		reportInfra.NewPostgresSummaryRepository(db),
+		reportInfra.NewXLSXReportGenerator(db),
+		reportInfra.NewLocalReportStorage(cfg.Report.StorageDir),
+		logger,
+	)
+	leaveUseCase := leaveApp.NewLeaveUseCase(
+		leaveGrantRepo,
+		leaveRequestRepo,
+		staffRepo,
+		shiftTypeRepo,
+		&leaveSchedulePlacerAdapter{scheduleRepo: scheduleRepo, entryRepo: scheduleEntryRepo},
+		logger,
+	)
 
 	// コンテナ生成 ルーターは後で設定
 	container := &Container{
-		Config:               cfg,
-		Logger:               logger,
-		DB:                   db,
-		Templates:            templates,
-		TokenService:         tokenService,
-		StaffRepo:            staffRepo,
-		TeamRepo:             teamRepo,
-		DepartmentRepo:       departmentRepo,
-		OrganizationRepo:     organizationRepo,
-		UserRepo:             userRepo,
-		RefreshTokenRepo:     refreshTokenRepo,
-		ShiftTypeRepo:        shiftTypeRepo,
-		ScheduleRepo:         scheduleRepo,
-		ScheduleEntryRepo:    scheduleEntryRepo,
-		RequestPeriodRepo:    requestPeriodRepo,
-		ShiftRequestRepo:     shiftRequestRepo,
-		StaffUseCase:         staffUseCase,
-		UserUseCase:          userUseCase,
-		AuthUseCase:          authUseCase,
-		ShiftTypeUseCase:     shiftTypeUseCase,
-		ScheduleUseCase:      scheduleUseCase,
-		RequestPeriodUseCase: requestPeriodUseCase,
-		ShiftRequestUseCase:  shiftRequestUseCase,
+		Config:                    cfg,
+		Logger:                    logger,
+		DB:                        db,
+		Templates:                 templates,
+		TokenService:              tokenService,
+		StaffRepo:                 staffRepo,
+		StaffAvailabilityRepo:     staffAvailabilityRepo,
+		TeamRepo:                  teamRepo,
+		DepartmentRepo:            departmentRepo,
+		OrganizationRepo:          organizationRepo,
+		UserRepo:                  userRepo,
+		RefreshTokenRepo:          refreshTokenRepo,
+		ShiftTypeRepo:             shiftTypeRepo,
+		ShiftRuleRepo:             shiftRuleRepo,
+		ScheduleRepo:              scheduleRepo,
+		ScheduleEntryRepo:         scheduleEntryRepo,
+		ActualRecordRepo:          actualRecordRepo,
+		OvertimeAgreementRepo:     overtimeAgreementRepo,
+		CalendarTokenRepo:         calendarTokenRepo,
+		ShiftSwapRepo:             shiftSwapRepo,
+		OpenShiftRepo:             openShiftRepo,
+		RequestPeriodRepo:         requestPeriodRepo,
+		ShiftRequestRepo:          shiftRequestRepo,
+		ReportRepo:                reportRepo,
+		LeaveGrantRepo:            leaveGrantRepo,
+		LeaveRequestRepo:          leaveRequestRepo,
+		StaffUseCase:              staffUseCase,
+		StaffAvailabilityUseCase:  staffAvailabilityUseCase,
+		UserUseCase:               userUseCase,
+		AuthUseCase:               authUseCase,
+		ShiftTypeUseCase:          shiftTypeUseCase,
+		ShiftRuleUseCase:          shiftRuleUseCase,
+		ScheduleUseCase:           scheduleUseCase,
+		ActualRecordUseCase:       actualRecordUseCase,
+		OvertimeComplianceService: overtimeComplianceService,
+		CalendarUseCase:           calendarUseCase,
+		ShiftSwapUseCase:          shiftSwapUseCase,
+		OpenShiftUseCase:          openShiftUseCase,
+		RequestPeriodUseCase:      requestPeriodUseCase,
+		ShiftRequestUseCase:       shiftRequestUseCase,
+		ReportUseCase:             reportUseCase,
+		LeaveUseCase:              leaveUseCase,
 	}
 
 	// 組織ファインダーアダプター
@@ -165,17 +276,20 @@ func NewContainer(cfg *config.Config, logger *slog.Logger) (*Container, error) {
 	// ルーター初期化
 	mux := http.NewServeMux()
 	router := web.NewRouter(web.RouterDeps{
-		Logger:        logger,
-		Templates:     templates,
-		HealthChecker: container,
-		OrgFinder:     orgFinder,
-		Mux:           mux,
+		Logger:              logger,
+		Templates:           templates,
+		HealthChecker:       container,
+		OrgFinder:           orgFinder,
+		OvertimeAlertFinder: &overtimeAlertFinderAdapter{service: overtimeComplianceService},
+		LeaveAlertFinder:    &leaveAlertFinderAdapter{useCase: leaveUseCase},
+		Mux:                 mux,
 	})
 	container.Router = router
 
 	// ハンドラー初期化
-	staffHandler := staffPres.NewStaffHandler(staffUseCase, teamRepo, templates, logger)
+	staffHandler := staffPres.NewStaffHandler(staffUseCase, staffImportUseCase, teamRepo, templates, logger)
 	container.StaffHandler = staffHandler
+	container.StaffAvailabilityHandler = staffPres.NewAvailabilityHandler(staffAvailabilityUseCase, templates, logger)
 
 	teamHandler := staffPres.NewTeamHandler(teamRepo, departmentRepo, templates, logger)
 	container.TeamHandler = teamHandler
@@ -183,16 +297,27 @@ func NewContainer(cfg *config.Config, logger *slog.Logger) (*Container, error) {
 	shiftTypeHandler := shiftPres.NewShiftTypeHandler(shiftTypeUseCase, templates, logger)
 	container.ShiftTypeHandler = shiftTypeHandler
 
+	shiftRuleHandler := shiftPres.NewShiftRuleHandler(shiftRuleUseCase, templates, logger)
+	container.ShiftRuleHandler = shiftRuleHandler
+
 	// スタッフ・シフト種別検索アダプター（勤務表用）
 	scheduleStaffFinder := &scheduleStaffFinderAdapter{repo: staffRepo}
 	shiftTypeFinder := &shiftTypeFinderAdapter{repo: shiftTypeRepo}
 	scheduleHandler := schedulePres.NewScheduleHandler(scheduleUseCase, scheduleStaffFinder, shiftTypeFinder, templates, logger)
 	container.ScheduleHandler = scheduleHandler
+	container.ActualRecordHandler = schedulePres.NewActualRecordHandler(actualRecordUseCase, scheduleStaffFinder, templates, logger)
+	container.OvertimeAgreementHandler = schedulePres.NewOvertimeAgreementHandler(overtimeComplianceService, templates, logger)
+	container.CalendarHandler = schedulePres.NewCalendarHandler(calendarUseCase, templates, logger)
+	container.ShiftSwapHandler = schedulePres.NewShiftSwapHandler(shiftSwapUseCase, scheduleStaffFinder, templates, logger)
+	container.OpenShiftHandler = schedulePres.NewOpenShiftHandler(openShiftUseCase, scheduleStaffFinder, templates, logger)
 
 	// スタッフ検索アダプター（勤務希望用）
 	staffFinder := &staffFinderAdapter{repo: staffRepo}
 	requestHandler := requestPres.NewRequestHandler(requestPeriodUseCase, shiftRequestUseCase, staffFinder, templates, logger)
 	container.RequestHandler = requestHandler
+	rosterExportUseCase := reportApp.NewRosterExportUseCase(reportInfra.NewPostgresRosterRepository(db), reportInfra.NewPDFRosterRenderer(), logger)
+	container.ReportHandler = reportPres.NewReportHandler(reportUseCase, rosterExportUseCase, templates, logger)
+	container.LeaveHandler = leavePres.NewLeaveHandler(leaveUseCase, templates, logger)
 
 	userHandler := userPres.NewUserHandler(userUseCase, templates, logger)
 	container.UserHandler = userHandler
@@ -229,6 +354,9 @@ func (c *Container) registerAuthRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("POST /logout", c.AuthHandler.Logout)
 	mux.HandleFunc("POST /api/auth/logout", c.AuthHandler.Logout)
 
+	// 勤務予定カレンダー購読 カレンダーアプリから取得するためトークンで認可
+	mux.HandleFunc("GET /calendar/{token}/shifts.ics", c.CalendarHandler.Feed)
+
 	// 現在のユーザー情報
 	mux.Handle("GET /api/auth/me", web.Chain(
 		http.HandlerFunc(c.AuthHandler.Me),
@@ -272,6 +400,10 @@ func (c *Container) registerProtectedRoutes(mux *http.ServeMux) {
 	auth := func(h http.Handler) http.Handler {
 		return web.Chain(h, web.Auth(c.TokenService, c.Logger))
 	}
+	// マネージャー以上のみ許可する操作用
+	managerAuth := func(h http.Handler) http.Handler {
+		return web.Chain(h, web.Auth(c.TokenService, c.Logger), web.RequireManager())
+	}
 
 	// ダッシュボード（認証必須）
 	mux.Handle("GET /{$}", auth(http.HandlerFunc(c.Router.DashboardHandler)))
@@ -279,11 +411,21 @@ func (c *Container) registerProtectedRoutes(mux *http.ServeMux) {
 	// スタッフ管理
 	mux.Handle("GET /staffs", auth(http.HandlerFunc(c.StaffHandler.List)))
 	mux.Handle("GET /staffs/new", auth(http.HandlerFunc(c.StaffHandler.New)))
+	mux.Handle("GET /staffs/import", managerAuth(http.HandlerFunc(c.StaffHandler.ImportPage)))
+	mux.Handle("GET /staffs/import/template.csv", managerAuth(http.HandlerFunc(c.StaffHandler.ImportTemplate)))
+	mux.Handle("POST /staffs/import", managerAuth(http.HandlerFunc(c.StaffHandler.Import)))
 	mux.Handle("POST /staffs", auth(http.HandlerFunc(c.StaffHandler.Create)))
 	mux.Handle("GET /staffs/{id}", auth(http.HandlerFunc(c.StaffHandler.Show)))
 	mux.Handle("GET /staffs/{id}/edit", auth(http.HandlerFunc(c.StaffHandler.Edit)))
 	mux.Handle("PUT /staffs/{id}", auth(http.HandlerFunc(c.StaffHandler.Update)))
 	mux.Handle("DELETE /staffs/{id}", auth(http.HandlerFunc(c.StaffHandler.Delete)))
+	mux.Handle("GET /staffs/{id}/calendar", managerAuth(http.HandlerFunc(c.CalendarHandler.Show)))
+	mux.Handle("POST /staffs/{id}/calendar", managerAuth(http.HandlerFunc(c.CalendarHandler.Issue)))
+	mux.Handle("DELETE /staffs/{id}/calendar", managerAuth(http.HandlerFunc(c.CalendarHandler.Revoke)))
+	mux.Handle("GET /staffs/{id}/availability", auth(http.HandlerFunc(c.StaffAvailabilityHandler.Show)))
+	mux.Handle("POST /staffs/{id}/availability", auth(http.HandlerFunc(c.StaffAvailabilityHandler.Update)))
+	mux.Handle("POST /staffs/{id}/availability/exceptions", auth(http.HandlerFunc(c.StaffAvailabilityHandler.AddException)))
+	mux.Handle("DELETE /staffs/{id}/availability/exceptions/{exception_id}", auth(http.HandlerFunc(c.StaffAvailabilityHandler.RemoveException)))
 
 	// チーム管理
 	mux.Handle("GET /teams", auth(http.HandlerFunc(c.TeamHandler.List)))
@@ -302,6 +444,14 @@ func (c *Container) registerProtectedRoutes(mux *http.ServeMux) {
 	mux.Handle("PUT /shifts/{id}", auth(http.HandlerFunc(c.ShiftTypeHandler.Update)))
 	mux.Handle("DELETE /shifts/{id}", auth(http.HandlerFunc(c.ShiftTypeHandler.Delete)))
 
+	// シフトルール管理
+	mux.Handle("GET /shifts/rules", auth(http.HandlerFunc(c.ShiftRuleHandler.List)))
+	mux.Handle("GET /shifts/rules/new", managerAuth(http.HandlerFunc(c.ShiftRuleHandler.New)))
+	mux.Handle("POST /shifts/rules", managerAuth(http.HandlerFunc(c.ShiftRuleHandler.Create)))
+	mux.Handle("GET /shifts/rules/{id}/edit", managerAuth(http.HandlerFunc(c.ShiftRuleHandler.Edit)))
+	mux.Handle("PUT /shifts/rules/{id}", managerAuth(http.HandlerFunc(c.ShiftRuleHandler.Update)))
+	mux.Handle("DELETE /shifts/rules/{id}", managerAuth(http.HandlerFunc(c.ShiftRuleHandler.Delete)))
+
 	// 勤務表管理
 	mux.Handle("GET /schedules", auth(http.HandlerFunc(c.ScheduleHandler.List)))
 	mux.Handle("GET /schedules/new", auth(http.HandlerFunc(c.ScheduleHandler.New)))
@@ -310,6 +460,65 @@ func (c *Container) registerProtectedRoutes(mux *http.ServeMux) {
 	mux.Handle("POST /schedules/{id}/entries", auth(http.HandlerFunc(c.ScheduleHandler.CreateEntry)))
 	mux.Handle("POST /schedules/{id}/publish", auth(http.HandlerFunc(c.ScheduleHandler.Publish)))
 	mux.Handle("DELETE /schedules/{id}", auth(http.HandlerFunc(c.ScheduleHandler.Delete)))
+	mux.Handle("POST /schedules/{id}/generate", managerAuth(http.HandlerFunc(c.ScheduleHandler.Generate)))
+	mux.Handle("GET /schedules/{id}/proposals/{proposal_id}", auth(http.HandlerFunc(c.ScheduleHandler.ShowProposal)))
+	mux.Handle("POST /schedules/{id}/proposals/{proposal_id}/apply", managerAuth(http.HandlerFunc(c.ScheduleHandler.ApplyProposal)))
+	mux.Handle("POST /schedules/{id}/proposals/{proposal_id}/discard", managerAuth(http.HandlerFunc(c.ScheduleHandler.DiscardProposal)))
+
+	// 掲示用勤務表PDF
+	mux.Handle("GET /schedules/{id}/roster.pdf", auth(http.HandlerFunc(c.ReportHandler.RosterPDF)))
+
+	// 勤務実績
+	mux.Handle("GET /schedules/{id}/timesheet", auth(http.HandlerFunc(c.ActualRecordHandler.Timesheet)))
+	mux.Handle("POST /schedules/{id}/entries/{entry_id}/actual", auth(http.HandlerFunc(c.ActualRecordHandler.Record)))
+	mux.Handle("DELETE /schedules/{id}/entries/{entry_id}/actual", auth(http.HandlerFunc(c.ActualRecordHandler.Delete)))
+
+	// 勤務交換 承認・却下はマネージャー以上
+	mux.Handle("GET /swaps", auth(http.HandlerFunc(c.ShiftSwapHandler.List)))
+	mux.Handle("GET /swaps/new", auth(http.HandlerFunc(c.ShiftSwapHandler.New)))
+	mux.Handle("POST /swaps", auth(http.HandlerFunc(c.ShiftSwapHandler.Create)))
+	mux.Handle("GET /swaps/{id}", auth(http.HandlerFunc(c.ShiftSwapHandler.Show)))
+	mux.Handle("POST /swaps/{id}/accept", auth(http.HandlerFunc(c.ShiftSwapHandler.Accept)))
+	mux.Handle("POST /swaps/{id}/decline", auth(http.HandlerFunc(c.ShiftSwapHandler.Decline)))
+	mux.Handle("POST /swaps/{id}/cancel", auth(http.HandlerFunc(c.ShiftSwapHandler.Cancel)))
+	mux.Handle("POST /swaps/{id}/approve", managerAuth(http.HandlerFunc(c.ShiftSwapHandler.Approve)))
+	mux.Handle("POST /swaps/{id}/reject", managerAuth(http.HandlerFunc(c.ShiftSwapHandler.Reject)))
+
+	// 募集シフト 登録・決定・取り消しはマネージャー以上
+	mux.Handle("GET /open-shifts", auth(http.HandlerFunc(c.OpenShiftHandler.List)))
+	mux.Handle("GET /open-shifts/new", managerAuth(http.HandlerFunc(c.OpenShiftHandler.New)))
+	mux.Handle("POST /open-shifts", managerAuth(http.HandlerFunc(c.OpenShiftHandler.Create)))
+	mux.Handle("GET /open-shifts/{id}", auth(http.HandlerFunc(c.OpenShiftHandler.Show)))
+	mux.Handle("POST /open-shifts/{id}/claim", auth(http.HandlerFunc(c.OpenShiftHandler.Claim)))
+	mux.Handle("POST /open-shifts/{id}/assign", managerAuth(http.HandlerFunc(c.OpenShiftHandler.Assign)))
+	mux.Handle("POST /open-shifts/{id}/cancel", managerAuth(http.HandlerFunc(c.OpenShiftHandler.Cancel)))
+
+	// 休暇管理 付与・承認・却下・配置はマネージャー以上
+	mux.Handle("GET /leave", auth(http.HandlerFunc(c.LeaveHandler.Balances)))
+	mux.Handle("POST /leave/accrue", managerAuth(http.HandlerFunc(c.LeaveHandler.Accrue)))
+	mux.Handle("GET /leave/mandatory", auth(http.HandlerFunc(c.LeaveHandler.MandatoryLeave)))
+	mux.Handle("GET /leave/staffs/{staff_id}", auth(http.HandlerFunc(c.LeaveHandler.Ledger)))
+	mux.Handle("POST /leave/staffs/{staff_id}/grants", managerAuth(http.HandlerFunc(c.LeaveHandler.AddGrant)))
+	mux.Handle("GET /leave/requests", auth(http.HandlerFunc(c.LeaveHandler.Requests)))
+	mux.Handle("GET /leave/requests/new", auth(http.HandlerFunc(c.LeaveHandler.NewRequest)))
+	mux.Handle("POST /leave/requests", auth(http.HandlerFunc(c.LeaveHandler.CreateRequest)))
+	mux.Handle("GET /leave/requests/{id}", auth(http.HandlerFunc(c.LeaveHandler.ShowRequest)))
+	mux.Handle("POST /leave/requests/{id}/approve", managerAuth(http.HandlerFunc(c.LeaveHandler.Approve)))
+	mux.Handle("POST /leave/requests/{id}/reject", managerAuth(http.HandlerFunc(c.LeaveHandler.Reject)))
+	mux.Handle("POST /leave/requests/{id}/cancel", auth(http.HandlerFunc(c.LeaveHandler.Cancel)))
+	mux.Handle("POST /leave/requests/{id}/place", managerAuth(http.HandlerFunc(c.LeaveHandler.Place)))
+
+	// 36協定
+	mux.Handle("GET /overtime-agreement", auth(http.HandlerFunc(c.OvertimeAgreementHandler.Show)))
+	mux.Handle("PUT /overtime-agreement", managerAuth(http.HandlerFunc(c.OvertimeAgreementHandler.Update)))
+
+	// レポート
+	mux.Handle("GET /reports", managerAuth(http.HandlerFunc(c.ReportHandler.List)))
+	mux.Handle("POST /reports", managerAuth(http.HandlerFunc(c.ReportHandler.Create)))
+	mux.Handle("GET /reports/summary", managerAuth(http.HandlerFunc(c.ReportHandler.Summary)))
+	mux.Handle("GET /reports/{id}/row", managerAuth(http.HandlerFunc(c.ReportHandler.Row)))
+	mux.Handle("GET /reports/{id}/download", managerAuth(http.HandlerFunc(c.ReportHandler.Download)))
+	mux.Handle("DELETE /reports/{id}", managerAuth(http.HandlerFunc(c.ReportHandler.Delete)))
 
 	// 勤務希望管理
 	mux.Handle("GET /requests", auth(http.HandlerFunc(c.RequestHandler.ListPeriods)))
@@ -323,12 +532,93 @@ func (c *Container) registerProtectedRoutes(mux *http.ServeMux) {
 	mux.Handle("POST /requests/{period_id}/entries", auth(http.HandlerFunc(c.RequestHandler.CreateRequest)))
 	mux.Handle("DELETE /requests/entries/{id}", auth(http.HandlerFunc(c.RequestHandler.DeleteRequest)))
 
+	// API スタッフ一括登録
+	mux.Handle("POST /api/staffs/import", managerAuth(http.HandlerFunc(c.StaffHandler.ImportJSON)))
+
+	// API 勤務予定カレンダー購読URL
+	mux.Handle("GET /api/staffs/{id}/calendar-token", managerAuth(http.HandlerFunc(c.CalendarHandler.ShowJSON)))
+	mux.Handle("POST /api/staffs/{id}/calendar-token", managerAuth(http.HandlerFunc(c.CalendarHandler.IssueJSON)))
+	mux.Handle("DELETE /api/staffs/{id}/calendar-token", managerAuth(http.HandlerFunc(c.CalendarHandler.RevokeJSON)))
+
+	// API 勤務可能条件
+	mux.Handle("GET /api/staffs/{id}/availability", auth(http.HandlerFunc(c.StaffAvailabilityHandler.ShowJSON)))
+	mux.Handle("PUT /api/staffs/{id}/availability", auth(http.HandlerFunc(c.StaffAvailabilityHandler.UpdateJSON)))
+	mux.Handle("POST /api/staffs/{id}/availability/exceptions", auth(http.HandlerFunc(c.StaffAvailabilityHandler.AddExceptionJSON)))
+	mux.Handle("DELETE /api/staffs/{id}/availability/exceptions/{exception_id}", auth(http.HandlerFunc(c.StaffAvailabilityHandler.RemoveExceptionJSON)))
+
 	// API シフト種別
 	mux.Handle("GET /api/shifts", auth(http.HandlerFunc(c.ShiftTypeHandler.ListJSON)))
 	mux.Handle("GET /api/shifts/{id}", auth(http.HandlerFunc(c.ShiftTypeHandler.ShowJSON)))
 	mux.Handle("POST /api/shifts", auth(http.HandlerFunc(c.ShiftTypeHandler.CreateJSON)))
 	mux.Handle("PUT /api/shifts/{id}", auth(http.HandlerFunc(c.ShiftTypeHandler.UpdateJSON)))
 	mux.Handle("DELETE /api/shifts/{id}", auth(http.HandlerFunc(c.ShiftTypeHandler.DeleteJSON)))
+
+	// API シフトルール
+	mux.Handle("GET /api/shift-rules", auth(http.HandlerFunc(c.ShiftRuleHandler.ListJSON)))
+	mux.Handle("GET /api/shift-rules/{id}", auth(http.HandlerFunc(c.ShiftRuleHandler.ShowJSON)))
+	mux.Handle("POST /api/shift-rules", managerAuth(http.HandlerFunc(c.ShiftRuleHandler.CreateJSON)))
+	mux.Handle("PUT /api/shift-rules/{id}", managerAuth(http.HandlerFunc(c.ShiftRuleHandler.UpdateJSON)))
+	mux.Handle("DELETE /api/shift-rules/{id}", managerAuth(http.HandlerFunc(c.ShiftRuleHandler.DeleteJSON)))
+
+	// API 勤務表自動作成
+	mux.Handle("POST /api/schedules/{id}/generate", managerAuth(http.HandlerFunc(c.ScheduleHandler.GenerateJSON)))
+	mux.Handle("GET /api/schedules/{id}/proposals/{proposal_id}", auth(http.HandlerFunc(c.ScheduleHandler.ShowProposalJSON)))
+	mux.Handle("POST /api/schedules/{id}/proposals/{proposal_id}/apply", managerAuth(http.HandlerFunc(c.ScheduleHandler.ApplyProposalJSON)))
+	mux.Handle("POST /api/schedules/{id}/proposals/{proposal_id}/discard", managerAuth(http.HandlerFunc(c.ScheduleHandler.DiscardProposalJSON)))
+
+	// API 勤務表CSV連携
+	mux.Handle("GET /api/schedules/{id}/export.csv", auth(http.HandlerFunc(c.ScheduleHandler.ExportCSV)))
+	mux.Handle("POST /api/schedules/{id}/import.csv", managerAuth(http.HandlerFunc(c.ScheduleHandler.ImportCSV)))
+
+	// API 勤務実績
+	mux.Handle("GET /api/schedules/{id}/timesheets/{staff_id}", auth(http.HandlerFunc(c.ActualRecordHandler.TimesheetJSON)))
+	mux.Handle("PUT /api/schedules/{id}/entries/{entry_id}/actual", auth(http.HandlerFunc(c.ActualRecordHandler.RecordJSON)))
+	mux.Handle("DELETE /api/schedules/{id}/entries/{entry_id}/actual", auth(http.HandlerFunc(c.ActualRecordHandler.DeleteJSON)))
+
+	// API 勤務交換
+	mux.Handle("GET /api/swaps", auth(http.HandlerFunc(c.ShiftSwapHandler.ListJSON)))
+	mux.Handle("POST /api/swaps", auth(http.HandlerFunc(c.ShiftSwapHandler.CreateJSON)))
+	mux.Handle("GET /api/swaps/{id}", auth(http.HandlerFunc(c.ShiftSwapHandler.ShowJSON)))
+	mux.Handle("POST /api/swaps/{id}/accept", auth(http.HandlerFunc(c.ShiftSwapHandler.AcceptJSON)))
+	mux.Handle("POST /api/swaps/{id}/decline", auth(http.HandlerFunc(c.ShiftSwapHandler.DeclineJSON)))
+	mux.Handle("POST /api/swaps/{id}/cancel", auth(http.HandlerFunc(c.ShiftSwapHandler.CancelJSON)))
+	mux.Handle("POST /api/swaps/{id}/approve", managerAuth(http.HandlerFunc(c.ShiftSwapHandler.ApproveJSON)))
+	mux.Handle("POST /api/swaps/{id}/reject", managerAuth(http.HandlerFunc(c.ShiftSwapHandler.RejectJSON)))
+
+	// API 募集シフト
+	mux.Handle("GET /api/open-shifts", auth(http.HandlerFunc(c.OpenShiftHandler.ListJSON)))
+	mux.Handle("POST /api/open-shifts", managerAuth(http.HandlerFunc(c.OpenShiftHandler.CreateJSON)))
+	mux.Handle("GET /api/open-shifts/{id}", auth(http.HandlerFunc(c.OpenShiftHandler.ShowJSON)))
+	mux.Handle("POST /api/open-shifts/{id}/claim", auth(http.HandlerFunc(c.OpenShiftHandler.ClaimJSON)))
+	mux.Handle("POST /api/open-shifts/{id}/assign", managerAuth(http.HandlerFunc(c.OpenShiftHandler.AssignJSON)))
+	mux.Handle("POST /api/open-shifts/{id}/cancel", managerAuth(http.HandlerFunc(c.OpenShiftHandler.CancelJSON)))
+
+	// API 休暇管理
+	mux.Handle("GET /api/leave/balances", auth(http.HandlerFunc(c.LeaveHandler.BalancesJSON)))
+	mux.Handle("POST /api/leave/accrue", managerAuth(http.HandlerFunc(c.LeaveHandler.AccrueJSON)))
+	mux.Handle("GET /api/leave/mandatory", auth(http.HandlerFunc(c.LeaveHandler.MandatoryLeaveJSON)))
+	mux.Handle("GET /api/leave/staffs/{staff_id}", auth(http.HandlerFunc(c.LeaveHandler.LedgerJSON)))
+	mux.Handle("POST /api/leave/staffs/{staff_id}/grants", managerAuth(http.HandlerFunc(c.LeaveHandler.AddGrantJSON)))
+	mux.Handle("GET /api/leave/requests", auth(http.HandlerFunc(c.LeaveHandler.RequestsJSON)))
+	mux.Handle("POST /api/leave/requests", auth(http.HandlerFunc(c.LeaveHandler.CreateRequestJSON)))
+	mux.Handle("GET /api/leave/requests/{id}", auth(http.HandlerFunc(c.LeaveHandler.ShowRequestJSON)))
+	mux.Handle("POST /api/leave/requests/{id}/approve", managerAuth(http.HandlerFunc(c.LeaveHandler.ApproveJSON)))
+	mux.Handle("POST /api/leave/requests/{id}/reject", managerAuth(http.HandlerFunc(c.LeaveHandler.RejectJSON)))
+	mux.Handle("POST /api/leave/requests/{id}/cancel", auth(http.HandlerFunc(c.LeaveHandler.CancelJSON)))
+	mux.Handle("POST /api/leave/requests/{id}/place", managerAuth(http.HandlerFunc(c.LeaveHandler.PlaceJSON)))
+
+	// 36協定API
+	mux.Handle("GET /api/overtime-agreement", auth(http.HandlerFunc(c.OvertimeAgreementHandler.ShowJSON)))
+	mux.Handle("PUT /api/overtime-agreement", managerAuth(http.HandlerFunc(c.OvertimeAgreementHandler.UpdateJSON)))
+	mux.Handle("GET /api/overtime-alerts", auth(http.HandlerFunc(c.OvertimeAgreementHandler.AlertsJSON)))
+
+	// API レポート
+	mux.Handle("GET /api/reports", managerAuth(http.HandlerFunc(c.ReportHandler.ListJSON)))
+	mux.Handle("POST /api/reports", managerAuth(http.HandlerFunc(c.ReportHandler.CreateJSON)))
+	mux.Handle("GET /api/reports/summary", managerAuth(http.HandlerFunc(c.ReportHandler.SummaryJSON)))
+	mux.Handle("GET /api/reports/{id}", managerAuth(http.HandlerFunc(c.ReportHandler.ShowJSON)))
+	mux.Handle("GET /api/reports/{id}/download", managerAuth(http.HandlerFunc(c.ReportHandler.Download)))
+	mux.Handle("DELETE /api/reports/{id}", managerAuth(http.HandlerFunc(c.ReportHandler.DeleteJSON)))
 }
 
 // Close リソース解放
@@ -461,6 +751,124 @@ func (a *scheduleStaffFinderAdapter) FindActiveByOrganizationID(ctx context.Cont
 	return result, nil
 }
 
+// shiftRequestFinderAdapter 勤務希望取得アダプター（勤務表検証・自動作成用）
+type shiftRequestFinderAdapter struct {
+	periodRepo  requestDomain.RequestPeriodRepository
+	requestRepo requestDomain.ShiftRequestRepository
+}
+
+// FindByTargetMonth 対象年月の受付期間に登録された勤務希望を取得
+func (a *shiftRequestFinderAdapter) FindByTargetMonth(ctx context.Context, orgID sharedDomain.ID, year, month int) ([]requestDomain.ShiftRequest, error) {
+	period, err := a.periodRepo.FindByTargetMonth(ctx, orgID, year, month)
+	if err != nil {
+		return nil, err
+	}
+	if period == nil {
+		return nil, nil
+	}
+	return a.requestRepo.FindByPeriodID(ctx, period.ID)
+}
+
+// staffQualificationFinderAdapter スタッフ資格情報取得アダプター（制約評価用）
+type staffQualificationFinderAdapter struct {
+	staffRepo        staffDomain.StaffRepository
+	skillRepo        staffDomain.SkillRepository
+	jobTypeRepo      staffDomain.JobTypeRepository
+	positionRepo     staffDomain.PositionRepository
+	assignmentRepo   staffDomain.StaffAssignmentRepository
+	availabilityRepo staffDomain.StaffAvailabilityRepository
+}
+
+// FindByOrganizationID 組織の有効スタッフの保有スキル・所属・職位・勤務可能条件を取得
+func (a *staffQualificationFinderAdapter) FindByOrganizationID(ctx context.Context, orgID sharedDomain.ID) (*scheduleDomain.StaffQualifications, error) {
+	staffs, err := a.staffRepo.FindActiveByOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &scheduleDomain.StaffQualifications{
+		Staffs:         make(map[string]*staffDomain.Staff, len(staffs)),
+		Assignments:    make(map[string][]staffDomain.StaffAssignment),
+		SkillNames:     make(map[string]string),
+		JobTypeNames:   make(map[string]string),
+		Positions:      make(map[string]*staffDomain.Position),
+		Availabilities: make(map[string]*staffDomain.StaffAvailability),
+	}
+	staffIDs := make([]sharedDomain.ID, len(staffs))
+	for i := range staffs {
+		result.Staffs[staffs[i].ID.String()] = &staffs[i]
+		staffIDs[i] = staffs[i].ID
+	}
+
+	assignments, err := a.assignmentRepo.FindByStaffIDs(ctx, staffIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, as := range assignments {
+		staffID := as.StaffID.String()
+		result.Assignments[staffID] = append(result.Assignments[staffID], as)
+	}
+
+	skills, err := a.skillRepo.FindByOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range skills {
+		result.SkillNames[s.ID.String()] = s.Name
+	}
+
+	jobTypes, err := a.jobTypeRepo.FindByOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, j := range jobTypes {
+		result.JobTypeNames[j.ID.String()] = j.Name
+	}
+
+	positions, err := a.positionRepo.FindByOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range positions {
+		result.Positions[positions[i].ID.String()] = &positions[i]
+	}
+
+	availabilities, err := a.availabilityRepo.FindByStaffIDs(ctx, staffIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range availabilities {
+		result.Availabilities[availabilities[i].StaffID.String()] = &availabilities[i]
+	}
+
+	return result, nil
+}
+
+// staffShiftTypeOptionFinderAdapter シフト種別選択肢検索アダプター（勤務可能条件用）
+type staffShiftTypeOptionFinderAdapter struct {
+	repo shiftDomain.ShiftTypeRepository
+}
+
+// FindByOrganizationID 組織の勤務シフト種別を取得 休日シフトは含まない
+func (a *staffShiftTypeOptionFinderAdapter) FindByOrganizationID(ctx context.Context, orgID sharedDomain.ID) ([]staffDomain.ShiftTypeOption, error) {
+	shiftTypes, err := a.repo.FindByOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]staffDomain.ShiftTypeOption, 0, len(shiftTypes))
+	for i := range shiftTypes {
+		if shiftTypes[i].IsHoliday {
+			continue
+		}
+		result = append(result, staffDomain.ShiftTypeOption{
+			ID:        shiftTypes[i].ID,
+			Name:      shiftTypes[i].Name,
+			TimeRange: shiftTypes[i].StartTimeString() + "-" + shiftTypes[i].EndTimeString(),
+		})
+	}
+	return result, nil
+}
+
 // shiftTypeFinderAdapter シフト種別検索アダプター
 type shiftTypeFinderAdapter struct {
 	repo shiftDomain.ShiftTypeRepository
@@ -482,3 +890,146 @@ func (a *shiftTypeFinderAdapter) FindByOrganizationID(ctx context.Context, orgID
 	}
 	return result, nil
 }
+
+// overtimeAlertFinderAdapter 36協定警告検索アダプター（ダッシュボード用）
+type overtimeAlertFinderAdapter struct {
+	service *scheduleApp.OvertimeComplianceService
+}
+
+// FindCurrentByOrganizationID 当月の36協定警告取得
+func (a *overtimeAlertFinderAdapter) FindCurrentByOrganizationID(ctx context.Context, orgID sharedDomain.ID) ([]web.OvertimeAlertInfo, error) {
+	now := time.Now()
+	alerts, err := a.service.ListAlerts(ctx, orgID.String(), now.Year(), int(now.Month()))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]web.OvertimeAlertInfo, len(alerts))
+	for i, alert := range alerts {
+		result[i] = web.OvertimeAlertInfo{
+			StaffName:  alert.StaffName,
+			Message:    alert.Message,
+			IsExceeded: alert.Level == string(scheduleDomain.OvertimeAlertExceeded),
+		}
+	}
+	return result, nil
+}
+
+// leaveAlertFinderAdapter 年5日の取得義務の警告検索アダプター（ダッシュボード用）
+type leaveAlertFinderAdapter struct {
+	useCase *leaveApp.LeaveUseCase
+}
+
+// FindByOrganizationID 取得日数が足りないまま基準期間の終了が近い・終了したスタッフの警告を取得
+func (a *leaveAlertFinderAdapter) FindByOrganizationID(ctx context.Context, orgID sharedDomain.ID) ([]web.LeaveAlertInfo, error) {
+	items, err := a.useCase.ListMandatoryLeave(ctx, orgID.String(), true)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]web.LeaveAlertInfo, len(items))
+	for i, item := range items {
+		result[i] = web.LeaveAlertInfo{
+			StaffName: item.StaffName,
+			Message:   item.Message,
+			IsMissed:  item.Status == leaveDomain.MandatoryLeaveMissed.String(),
+		}
+	}
+	return result, nil
+}
+
+// leaveSchedulePlacerAdapter 休暇配置アダプター（休暇管理用）
+type leaveSchedulePlacerAdapter struct {
+	scheduleRepo scheduleDomain.ScheduleRepository
+	entryRepo    scheduleDomain.ScheduleEntryRepository
+}
+
+// Place 勤務表がある月の指定日のエントリを休暇のシフト種別にする エントリがなければ作成する
+func (a *leaveSchedulePlacerAdapter) Place(ctx context.Context, orgID, staffID sharedDomain.ID, dates []time.Time, shiftTypeID sharedDomain.ID) ([]time.Time, error) {
+	now := time.Now()
+	var placed []time.Time
+	var changed []scheduleDomain.ScheduleEntry
+	err := a.eachMonth(ctx, orgID, staffID, dates, func(schedule *scheduleDomain.Schedule, entries map[string]*scheduleDomain.ScheduleEntry, date time.Time) {
+		entry, ok := entries[date.Format("2006-01-02")]
+		if !ok {
+			entry = &scheduleDomain.ScheduleEntry{
+				ID:         sharedDomain.NewID(),
+				ScheduleID: schedule.ID,
+				StaffID:    staffID,
+				TargetDate: date,
+				CreatedAt:  now,
+			}
+		}
+		id := shiftTypeID
+		entry.ShiftTypeID = &id
+		entry.UpdatedAt = now
+		changed = append(changed, *entry)
+		placed = append(placed, date)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(changed) > 0 {
+		if err := a.entryRepo.SaveBatch(ctx, changed); err != nil {
+			return nil, err
+		}
+	}
+	return placed, nil
+}
+
+// Remove 休暇のシフト種別のままのエントリを未割当に戻す
+func (a *leaveSchedulePlacerAdapter) Remove(ctx context.Context, orgID, staffID sharedDomain.ID, dates []time.Time, shiftTypeID sharedDomain.ID) error {
+	now := time.Now()
+	var changed []scheduleDomain.ScheduleEntry
+	err := a.eachMonth(ctx, orgID, staffID, dates, func(_ *scheduleDomain.Schedule, entries map[string]*scheduleDomain.ScheduleEntry, date time.Time) {
+		entry, ok := entries[date.Format("2006-01-02")]
+		if !ok || entry.ShiftTypeID == nil || *entry.ShiftTypeID != shiftTypeID {
+			return
+		}
+		entry.ShiftTypeID = nil
+		entry.UpdatedAt = now
+		changed = append(changed, *entry)
+	})
+	if err != nil || len(changed) == 0 {
+		return err
+	}
+	return a.entryRepo.SaveBatch(ctx, changed)
+}
+
+// eachMonth 勤務表がある月の日ごとにスタッフのエントリを渡す 勤務表がない月は飛ばす
+func (a *leaveSchedulePlacerAdapter) eachMonth(
+	ctx context.Context,
+	orgID, staffID sharedDomain.ID,
+	dates []time.Time,
+	fn func(schedule *scheduleDomain.Schedule, entries map[string]*scheduleDomain.ScheduleEntry, date time.Time),
+) error {
+	byMonth := make(map[[2]int][]time.Time)
+	var months [][2]int
+	for _, d := range dates {
+		key := [2]int{d.Year(), int(d.Month())}
+		if _, ok := byMonth[key]; !ok {
+			months = append(months, key)
+		}
+		byMonth[key] = append(byMonth[key], d)
+	}
+
+	for _, key := range months {
+		schedule, err := a.scheduleRepo.FindByTargetMonth(ctx, orgID, key[0], key[1])
+		if err != nil {
+			return err
+		}
+		if schedule == nil {
+			continue
+		}
+		entries, err := a.entryRepo.FindByScheduleAndStaff(ctx, schedule.ID, staffID)
+		if err != nil {
+			return err
+		}
+		byDate := make(map[string]*scheduleDomain.ScheduleEntry, len(entries))
+		for i := range entries {
+			byDate[entries[i].TargetDate.Format("2006-01-02")] = &entries[i]
+		}
+		for _, d := range byMonth[key] {
+			fn(schedule, byDate, d)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+// Package application 休暇管理アプリケーション層
+package application
+
+import (
+	"strconv"
+
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// AddLeaveGrantInput 手動付与入力
+type AddLeaveGrantInput struct {
+	// OrganizationID 組織ID
+	OrganizationID string `json:"-"`
+	// StaffID スタッフID
+	StaffID string `json:"-"`
+	// GrantDate 付与日（YYYY-MM-DD形式）
+	GrantDate string `json:"grant_date"`
+	// Days 付与日数 0.5日単位
+	Days string `json:"days"`
+	// Note 備考
+	Note string `json:"note"`
+	// UserEmail 操作ユーザーのメールアドレス
+	UserEmail string `json:"-"`
+}
+
+// Validate 入力検証
+func (i *AddLeaveGrantInput) Validate() error {
+	if i.GrantDate == "" {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "付与日は必須です")
+	}
+	if _, err := strconv.ParseFloat(i.Days, 64); err != nil {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "付与日数は数値で入力してください")
+	}
+	return nil
+}
+
+// CreateLeaveRequestInput 休暇申請入力
+type CreateLeaveRequestInput struct {
+	// OrganizationID 組織ID
+	OrganizationID string `json:"-"`
+	// StaffID スタッフID
+	StaffID string `json:"staff_id"`
+	// StartDate 開始日（YYYY-MM-DD形式）
+	StartDate string `json:"start_date"`
+	// EndDate 終了日（YYYY-MM-DD形式）省略時は開始日と同じ
+	EndDate string `json:"end_date"`
+	// ExcludedDates 期間中の休暇を取得しない日（YYYY-MM-DD形式） 公休日など 取得日数に数えない
+	ExcludedDates []string `json:"excluded_dates"`
+	// ShiftTypeID 勤務表に配置する休日扱いのシフト種別ID
+	ShiftTypeID string `json:"shift_type_id"`
+	// Reason 申請理由
+	Reason string `json:"reason"`
+	// UserEmail 操作ユーザーのメールアドレス
+	UserEmail string `json:"-"`
+}
+
+// Validate 入力検証
+func (i *CreateLeaveRequestInput) Validate() error {
+	if i.StaffID == "" {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "スタッフは必須です")
+	}
+	if i.StartDate == "" {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "開始日は必須です")
+	}
+	if i.ShiftTypeID == "" {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "休暇の種類は必須です")
+	}
+	return nil
+}
+
+// LeaveRequestActionInput 休暇申請の承認・却下等の操作入力
+type LeaveRequestActionInput struct {
+	// OrganizationID 組織ID
+	OrganizationID string `json:"-"`
+	// RequestID 休暇申請ID
+	RequestID string `json:"-"`
+	// UserEmail 操作ユーザーのメールアドレス
+	UserEmail string `json:"-"`
+	// Comment コメント
+	Comment string `json:"comment"`
+}
+
+// LeaveBalanceOutput 有給休暇残高出力
+type LeaveBalanceOutput struct {
+	// StaffID スタッフID
+	StaffID string `json:"staff_id"`
+	// StaffName スタッフ名
+	StaffName string `json:"staff_name"`
+	// EmployeeCode 社員番号
+	EmployeeCode string `json:"employee_code"`
+	// HireDate 入社日 未登録は空
+	HireDate string `json:"hire_date,omitempty"`
+	// Granted 有効な付与の合計日数
+	Granted float64 `json:"granted"`
+	// Used 消化日数 申請中・承認済みの休暇を含む
+	Used float64 `json:"used"`
+	// Remaining 残日数
+	Remaining float64 `json:"remaining"`
+	// Expired 消化されずに失効した日数
+	Expired float64 `json:"expired"`
+	// NextGrantDate 次回の法定付与日 入社日未登録は空
+	NextGrantDate string `json:"next_grant_date,omitempty"`
+	// NextGrantDays 次回の法定付与日数
+	NextGrantDays float64 `json:"next_grant_days,omitempty"`
+}
+
+// LeaveGrantOutput 有給休暇付与出力
+type LeaveGrantOutput struct {
+	// ID 付与ID
+	ID string `json:"id"`
+	// GrantDate 付与日
+	GrantDate string `json:"grant_date"`
+	// ExpiresOn 有効期限
+	ExpiresOn string `json:"expires_on"`
+	// Days 付与日数
+	Days float64 `json:"days"`
+	// Used 消化日数
+	Used float64 `json:"used"`
+	// Remaining 残日数
+	Remaining float64 `json:"remaining"`
+	// Expired 失効済み
+	Expired bool `json:"expired"`
+	// Source 付与の種類
+	Source string `json:"source"`
+	// SourceLabel 付与の種類ラベル
+	SourceLabel string `json:"source_label"`
+	// Note 備考
+	Note string `json:"note"`
+	// CreatedBy 登録したユーザーのメールアドレス
+	CreatedBy string `json:"created_by,omitempty"`
+	// Recorded 台帳に登録済み 未登録の法定付与は付与の更新で登録する
+	Recorded bool `json:"recorded"`
+}
+
+// LeaveLedgerOutput スタッフの有給休暇台帳出力
+type LeaveLedgerOutput struct {
+	// Balance 残高
+	Balance LeaveBalanceOutput `json:"balance"`
+	// Grants 付与一覧 付与日順
+	Grants []LeaveGrantOutput `json:"grants"`
+	// Requests 休暇申請一覧 新しい順
+	Requests []LeaveRequestOutput `json:"requests"`
+	// Mandatory 年5日の取得義務の取得状況 対象外はnil
+	Mandatory *MandatoryLeaveOutput `json:"mandatory,omitempty"`
+	// MissedMandatory 前の基準期間が取得日数の不足で終了した場合の取得状況 該当なしはnil
+	MissedMandatory *MandatoryLeaveOutput `json:"missed_mandatory,omitempty"`
+}
+
+// LeaveRequestOutput 休暇申請出力
+type LeaveRequestOutput struct {
+	// ID 休暇申請ID
+	ID string `json:"id"`
+	// StaffID スタッフID
+	StaffID string `json:"staff_id"`
+	// StaffName スタッフ名
+	StaffName string `json:"staff_name"`
+	// StartDate 開始日
+	StartDate string `json:"start_date"`
+	// EndDate 終了日
+	EndDate string `json:"end_date"`
+	// Days 日数 取得しない日を除く
+	Days float64 `json:"days"`
+	// ExcludedDates 期間中の休暇を取得しない日
+	ExcludedDates []string `json:"excluded_dates,omitempty"`
+	// ShiftTypeID シフト種別ID
+	ShiftTypeID string `json:"shift_type_id"`
+	// ShiftName シフト名
+	ShiftName string `json:"shift_name"`
+	// Reason 申請理由
+	Reason string `json:"reason"`
+	// Status 状態
+	Status string `json:"status"`
+	// StatusLabel 状態ラベル
+	StatusLabel string `json:"status_label"`
+	// IsPending 承認待ち
+	IsPending bool `json:"is_pending"`
+	// IsActive 申請中または承認済み
+	IsActive bool `json:"is_active"`
+	// RequestedBy 申請したユーザーのメールアドレス
+	RequestedBy string `json:"requested_by"`
+	// DecidedBy 承認・却下したユーザーのメールアドレス
+	DecidedBy string `json:"decided_by,omitempty"`
+	// DecidedAt 承認・却下日時
+	DecidedAt string `json:"decided_at,omitempty"`
+	// DecisionComment 承認・却下時のコメント
+	DecisionComment string `json:"decision_comment,omitempty"`
+	// UnplacedDates 承認済みで勤務表に未配置の日 勤務表がまだない月の日
+	UnplacedDates []string `json:"unplaced_dates,omitempty"`
+	// CreatedAt 申請日時
+	CreatedAt string `json:"created_at"`
+	// Balance 申請したスタッフの残高 詳細のみ
+	Balance *LeaveBalanceOutput `json:"balance,omitempty"`
+}
+
+// MandatoryLeaveOutput 年5日の取得義務の取得状況出力
+type MandatoryLeaveOutput struct {
+	// StaffID スタッフID
+	StaffID string `json:"staff_id"`
+	// StaffName スタッフ名
+	StaffName string `json:"staff_name"`
+	// EmployeeCode 社員番号
+	EmployeeCode string `json:"employee_code"`
+	// GrantDate 基準日
+	GrantDate string `json:"grant_date"`
+	// EndDate 基準期間の終了日
+	EndDate string `json:"end_date"`
+	// GrantedDays 基準日の付与日数
+	GrantedDays float64 `json:"granted_days"`
+	// Taken 取得済み日数
+	Taken float64 `json:"taken"`
+	// Planned 承認済みの取得予定日数
+	Planned float64 `json:"planned"`
+	// Pending 承認待ちの日数
+	Pending float64 `json:"pending"`
+	// Remaining 取得義務を満たすためにあと必要な日数
+	Remaining float64 `json:"remaining"`
+	// DaysLeft 基準期間の終了までの日数
+	DaysLeft int `json:"days_left"`
+	// Status 取得状況
+	Status string `json:"status"`
+	// StatusLabel 取得状況ラベル
+	StatusLabel string `json:"status_label"`
+	// IsWarning 警告対象
+	IsWarning bool `json:"is_warning"`
+	// Message 警告メッセージ 警告対象のみ
+	Message string `json:"message,omitempty"`
+}
+
+// AccrueOutput 法定付与の更新結果出力
+type AccrueOutput struct {
+	// Created 登録した付与件数
+	Created int `json:"created"`
+}
+
+// OptionOutput 選択肢出力
+type OptionOutput struct {
+	// ID ID
+	ID string `json:"id"`
+	// Name 名称
+	Name string `json:"name"`
+}
@@ -0,0 +1,812 @@
+// Package application 休暇管理アプリケーション層
+package application
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strconv"
+	"time"
+
+	"shiftmaster/internal/modules/leave/domain"
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
+	staffDomain "shiftmaster/internal/modules/staff/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// LeaveUseCase 休暇管理ユースケース
+// 有給休暇の付与・残高と休暇申請を扱い、承認した休暇を勤務表に配置する
+type LeaveUseCase struct {
+	grantRepo     domain.LeaveGrantRepository
+	requestRepo   domain.LeaveRequestRepository
+	staffRepo     staffDomain.StaffRepository
+	shiftTypeRepo shiftDomain.ShiftTypeRepository
+	placer        domain.SchedulePlacer
+	logger        *slog.Logger
+}
+
+// NewLeaveUseCase 休暇管理ユースケース生成
+func NewLeaveUseCase(
+	grantRepo domain.LeaveGrantRepository,
+	requestRepo domain.LeaveRequestRepository,
+	staffRepo staffDomain.StaffRepository,
+	shiftTypeRepo shiftDomain.ShiftTypeRepository,
+	placer domain.SchedulePlacer,
+	logger *slog.Logger,
+) *LeaveUseCase {
+	return &LeaveUseCase{
+		grantRepo:     grantRepo,
+		requestRepo:   requestRepo,
+		staffRepo:     staffRepo,
+		shiftTypeRepo: shiftTypeRepo,
+		placer:        placer,
+		logger:        logger,
+	}
+}
+
+// staffLedger スタッフの付与と休暇申請
+type staffLedger struct {
+	staff    *staffDomain.Staff
+	grants   []domain.LeaveGrant
+	recorded map[sharedDomain.ID]bool
+	requests []domain.LeaveRequest
+}
+
+// newStaffLedger 登録済みの付与に未登録の法定付与を補って台帳を作る
+// 申請中・承認済みの休暇の取得日に有効だった付与は失効済みでも含める
+func newStaffLedger(organizationID sharedDomain.ID, staff *staffDomain.Staff, grants []domain.LeaveGrant, requests []domain.LeaveRequest, today, now time.Time) *staffLedger {
+	ledger := &staffLedger{
+		staff:    staff,
+		grants:   append([]domain.LeaveGrant(nil), grants...),
+		recorded: make(map[sharedDomain.ID]bool, len(grants)),
+		requests: requests,
+	}
+	statutory := make(map[string]bool)
+	for _, g := range grants {
+		ledger.recorded[g.ID] = true
+		if g.Source == domain.GrantSourceStatutory {
+			statutory[g.GrantDate.Format("2006-01-02")] = true
+		}
+	}
+
+	if staff.HireDate != nil {
+		from := today
+		for i := range requests {
+			if requests[i].IsActive() && requests[i].StartDate.Before(from) {
+				from = requests[i].StartDate
+			}
+		}
+		for _, g := range domain.StatutoryGrants(organizationID, staff.ID, dateOf(*staff.HireDate), from, today, now) {
+			if !statutory[g.GrantDate.Format("2006-01-02")] {
+				ledger.grants = append(ledger.grants, g)
+			}
+		}
+	}
+	return ledger
+}
+
+// balance 基準日時点の残高 申請中・承認済みの休暇を消化として数える
+func (l *staffLedger) balance(asOf time.Time) *domain.LeaveBalance {
+	var usages []domain.LeaveUsage
+	for i := range l.requests {
+		if l.requests[i].IsActive() {
+			usages = append(usages, l.requests[i].Usages()...)
+		}
+	}
+	return domain.CalculateBalance(l.grants, usages, asOf)
+}
+
+// mandatory 判定日時点の年5日の取得義務の取得状況 対象外はnil
+func (l *staffLedger) mandatory(asOf time.Time) *MandatoryLeaveOutput {
+	period := domain.CurrentMandatoryLeavePeriod(l.grants, l.requests, asOf)
+	if period == nil {
+		return nil
+	}
+	return mandatoryOutput(l.staff, period, asOf)
+}
+
+// missedMandatory 判定日時点の基準期間より前に取得日数が足りないまま終了した基準期間 該当なしはnil
+func (l *staffLedger) missedMandatory(asOf time.Time) *MandatoryLeaveOutput {
+	period := domain.MissedMandatoryLeavePeriod(l.grants, l.requests, asOf)
+	if period == nil {
+		return nil
+	}
+	return mandatoryOutput(l.staff, period, asOf)
+}
+
+// shortageWith 休暇申請を含めたときに増える不足日数
+func (l *staffLedger) shortageWith(request *domain.LeaveRequest, asOf time.Time) float64 {
+	others := make([]domain.LeaveRequest, 0, len(l.requests))
+	for i := range l.requests {
+		if l.requests[i].ID != request.ID {
+			others = append(others, l.requests[i])
+		}
+	}
+	without := &staffLedger{staff: l.staff, grants: l.grants, requests: others}
+	with := &staffLedger{staff: l.staff, grants: l.grants, requests: append(others, *request)}
+	return with.balance(asOf).Shortage - without.balance(asOf).Shortage
+}
+
+// ListBalances 組織の有効スタッフの有給休暇残高一覧
+func (u *LeaveUseCase) ListBalances(ctx context.Context, organizationID string) ([]LeaveBalanceOutput, error) {
+	orgID, err := sharedDomain.ParseID(organizationID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDが不正です")
+	}
+
+	ledgers, err := u.loadLedgers(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := dateOf(time.Now())
+	outputs := make([]LeaveBalanceOutput, len(ledgers))
+	for i, ledger := range ledgers {
+		outputs[i] = balanceOutput(ledger.staff, ledger.balance(today), today)
+	}
+	return outputs, nil
+}
+
+// ListMandatoryLeave 組織の有効スタッフの年5日の取得義務の取得状況一覧
+// 前の基準期間が未達のスタッフは未達の期間も含める
+// 警告対象を基準期間の終了が近い順に先頭にする warningOnlyの場合は警告対象のみ
+func (u *LeaveUseCase) ListMandatoryLeave(ctx context.Context, organizationID string, warningOnly bool) ([]MandatoryLeaveOutput, error) {
+	orgID, err := sharedDomain.ParseID(organizationID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDが不正です")
+	}
+
+	ledgers, err := u.loadLedgers(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := dateOf(time.Now())
+	outputs := make([]MandatoryLeaveOutput, 0, len(ledgers))
+	for _, ledger := range ledgers {
+		if missed := ledger.missedMandatory(today); missed != nil {
+			outputs = append(outputs, *missed)
+		}
+		output := ledger.mandatory(today)
+		if output == nil || (warningOnly && !output.IsWarning) {
+			continue
+		}
+		outputs = append(outputs, *output)
+	}
+	sort.SliceStable(outputs, func(i, j int) bool {
+		if outputs[i].IsWarning != outputs[j].IsWarning {
+			return outputs[i].IsWarning
+		}
+		return outputs[i].EndDate < outputs[j].EndDate
+	})
+	return outputs, nil
+}
+
+// GetLedger スタッフの有給休暇台帳取得
+func (u *LeaveUseCase) GetLedger(ctx context.Context, organizationID, staffID string) (*LeaveLedgerOutput, error) {
+	orgID, err := sharedDomain.ParseID(organizationID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDが不正です")
+	}
+	staff, err := u.findStaff(ctx, orgID, staffID)
+	if err != nil {
+		return nil, err
+	}
+	if staff == nil {
+		return nil, sharedDomain.ErrNotFound
+	}
+
+	ledger, err := u.loadLedger(ctx, orgID, staff)
+	if err != nil {
+		return nil, err
+	}
+	shiftNames, err := u.shiftTypeNames(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := dateOf(time.Now())
+	balance := ledger.balance(today)
+	output := &LeaveLedgerOutput{
+		Balance:  balanceOutput(staff, balance, today),
+		Grants:   make([]LeaveGrantOutput, len(balance.Grants)),
+		Requests: make([]LeaveRequestOutput, len(ledger.requests)),
+	}
+	for i, gb := range balance.Grants {
+		output.Grants[i] = grantOutput(gb, ledger.recorded[gb.Grant.ID])
+	}
+	for i := range ledger.requests {
+		output.Requests[i] = requestOutput(&ledger.requests[i], staff.FullName(), shiftNames[ledger.requests[i].ShiftTypeID])
+	}
+	output.Mandatory = ledger.mandatory(today)
+	output.MissedMandatory = ledger.missedMandatory(today)
+	return output, nil
+}
+
+// Accrue 有効スタッフの入社日に基づく法定付与を台帳に登録 登録済みの付与日は登録しない
+func (u *LeaveUseCase) Accrue(ctx context.Context, organizationID string) (*AccrueOutput, error) {
+	orgID, err := sharedDomain.ParseID(organizationID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDが不正です")
+	}
+
+	staffs, err := u.staffRepo.FindActiveByOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	today := dateOf(now)
+	var grants []domain.LeaveGrant
+	for i := range staffs {
+		if staffs[i].HireDate == nil {
+			continue
+		}
+		grants = append(grants, domain.StatutoryGrants(orgID, staffs[i].ID, dateOf(*staffs[i].HireDate), today, today, now)...)
+	}
+
+	created, err := u.grantRepo.SaveStatutory(ctx, grants)
+	if err != nil {
+		u.logger.Error("有給休暇付与失敗", "error", err)
+		return nil, err
+	}
+
+	u.logger.Info("有給休暇付与", "organization_id", orgID, "count", created)
+	return &AccrueOutput{Created: created}, nil
+}
+
+// AddGrant 手動付与登録
+func (u *LeaveUseCase) AddGrant(ctx context.Context, input *AddLeaveGrantInput) (*LeaveLedgerOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	orgID, err := sharedDomain.ParseID(input.OrganizationID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDが不正です")
+	}
+	staff, err := u.findStaff(ctx, orgID, input.StaffID)
+	if err != nil {
+		return nil, err
+	}
+	if staff == nil {
+		return nil, sharedDomain.ErrNotFound
+	}
+	grantDate, err := time.Parse("2006-01-02", input.GrantDate)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "付与日が不正です")
+	}
+	days, _ := strconv.ParseFloat(input.Days, 64)
+
+	grant, err := domain.NewManualGrant(orgID, staff.ID, grantDate, days, input.Note, input.UserEmail, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := u.grantRepo.Save(ctx, grant); err != nil {
+		u.logger.Error("有給休暇手動付与失敗", "error", err)
+		return nil, err
+	}
+
+	u.logger.Info("有給休暇手動付与", "grant_id", grant.ID, "staff_id", staff.ID, "days", grant.Days)
+	return u.GetLedger(ctx, input.OrganizationID, input.StaffID)
+}
+
+// LeaveShiftTypes 休暇として配置できる休日扱いのシフト種別一覧
+func (u *LeaveUseCase) LeaveShiftTypes(ctx context.Context, organizationID string) ([]OptionOutput, error) {
+	orgID, err := sharedDomain.ParseID(organizationID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDが不正です")
+	}
+
+	shiftTypes, err := u.shiftTypeRepo.FindByOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	options := make([]OptionOutput, 0)
+	for _, st := range shiftTypes {
+		if st.IsHoliday {
+			options = append(options, OptionOutput{ID: st.ID.String(), Name: st.Name})
+		}
+	}
+	return options, nil
+}
+
+// ListRequests 組織の休暇申請一覧 statusが空の場合は全件
+func (u *LeaveUseCase) ListRequests(ctx context.Context, organizationID, status string) ([]LeaveRequestOutput, error) {
+	orgID, err := sharedDomain.ParseID(organizationID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDが不正です")
+	}
+
+	requests, err := u.requestRepo.FindByOrganizationID(ctx, orgID, domain.LeaveRequestStatus(status))
+	if err != nil {
+		return nil, err
+	}
+	staffNames, err := u.staffNames(ctx, orgID, requests)
+	if err != nil {
+		return nil, err
+	}
+	shiftNames, err := u.shiftTypeNames(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := make([]LeaveRequestOutput, len(requests))
+	for i := range requests {
+		outputs[i] = requestOutput(&requests[i], staffNames[requests[i].StaffID], shiftNames[requests[i].ShiftTypeID])
+	}
+	return outputs, nil
+}
+
+// GetRequest 休暇申請取得 申請したスタッフの残高を含む
+func (u *LeaveUseCase) GetRequest(ctx context.Context, organizationID, id string) (*LeaveRequestOutput, error) {
+	request, err := u.findRequest(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+	return u.buildOutput(ctx, request)
+}
+
+// Request 休暇申請 期間が重なる申請中・承認済みの休暇がある場合や残日数が不足する場合は申請できない
+func (u *LeaveUseCase) Request(ctx context.Context, input *CreateLeaveRequestInput) (*LeaveRequestOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	orgID, err := sharedDomain.ParseID(input.OrganizationID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDが不正です")
+	}
+	staff, err := u.findStaff(ctx, orgID, input.StaffID)
+	if err != nil {
+		return nil, err
+	}
+	if staff == nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "スタッフが見つかりません")
+	}
+	shiftTypeID, err := sharedDomain.ParseID(input.ShiftTypeID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "休暇の種類が不正です")
+	}
+	shiftType, err := u.shiftTypeRepo.FindByID(ctx, shiftTypeID)
+	if err != nil {
+		return nil, err
+	}
+	if shiftType == nil || shiftType.OrganizationID != orgID || !shiftType.IsHoliday {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "休日扱いのシフト種別を選択してください")
+	}
+
+	startDate, err := time.Parse("2006-01-02", input.StartDate)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "開始日が不正です")
+	}
+	endDate := startDate
+	if input.EndDate != "" {
+		if endDate, err = time.Parse("2006-01-02", input.EndDate); err != nil {
+			return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "終了日が不正です")
+		}
+	}
+
+	excludedDates := make([]time.Time, 0, len(input.ExcludedDates))
+	for _, s := range input.ExcludedDates {
+		d, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "取得しない日が不正です")
+		}
+		excludedDates = append(excludedDates, d)
+	}
+
+	request, err := domain.NewLeaveRequest(orgID, staff.ID, startDate, endDate, excludedDates, shiftTypeID, input.Reason, input.UserEmail, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	ledger, err := u.loadLedger(ctx, orgID, staff)
+	if err != nil {
+		return nil, err
+	}
+	for i := range ledger.requests {
+		if ledger.requests[i].IsActive() && ledger.requests[i].Overlaps(request) {
+			return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeConflict, "この期間には既に休暇を申請しています")
+		}
+	}
+	if err := checkShortage(ledger, request, "申請できません"); err != nil {
+		return nil, err
+	}
+
+	if err := u.requestRepo.Save(ctx, request); err != nil {
+		u.logger.Error("休暇申請失敗", "error", err)
+		return nil, err
+	}
+
+	u.logger.Info("休暇申請", "request_id", request.ID, "staff_id", request.StaffID, "days", request.Days())
+	return u.buildOutput(ctx, request)
+}
+
+// Approve 承認 残日数を再確認し、勤務表がある月の日を休暇のシフト種別にする
+func (u *LeaveUseCase) Approve(ctx context.Context, input *LeaveRequestActionInput) (*LeaveRequestOutput, error) {
+	request, err := u.findRequest(ctx, input.OrganizationID, input.RequestID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if err := request.Approve(input.UserEmail, input.Comment, now); err != nil {
+		return nil, err
+	}
+
+	staff, err := u.findStaff(ctx, request.OrganizationID, request.StaffID.String())
+	if err != nil {
+		return nil, err
+	}
+	if staff == nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "スタッフが無効になっているため承認できません")
+	}
+	ledger, err := u.loadLedger(ctx, request.OrganizationID, staff)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkShortage(ledger, request, "承認できません"); err != nil {
+		return nil, err
+	}
+
+	placed, err := u.placer.Place(ctx, request.OrganizationID, request.StaffID, request.UnplacedDates(), request.ShiftTypeID)
+	if err != nil {
+		u.logger.Error("休暇配置失敗", "error", err)
+		return nil, err
+	}
+	request.MarkPlaced(placed, now)
+
+	if err := u.requestRepo.Save(ctx, request); err != nil {
+		u.logger.Error("休暇承認失敗", "error", err)
+		return nil, err
+	}
+
+	u.logger.Info("休暇承認", "request_id", request.ID, "placed", len(placed))
+	return u.buildOutput(ctx, request)
+}
+
+// Reject 却下
+func (u *LeaveUseCase) Reject(ctx context.Context, input *LeaveRequestActionInput) (*LeaveRequestOutput, error) {
+	request, err := u.findRequest(ctx, input.OrganizationID, input.RequestID)
+	if err != nil {
+		return nil, err
+	}
+	if err := request.Reject(input.UserEmail, input.Comment, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := u.requestRepo.Save(ctx, request); err != nil {
+		u.logger.Error("休暇却下失敗", "error", err)
+		return nil, err
+	}
+
+	u.logger.Info("休暇却下", "request_id", request.ID)
+	return u.buildOutput(ctx, request)
+}
+
+// Cancel 取り消し 承認済みの休暇は勤務表に配置したシフトを未割当に戻す
+func (u *LeaveUseCase) Cancel(ctx context.Context, input *LeaveRequestActionInput) (*LeaveRequestOutput, error) {
+	request, err := u.findRequest(ctx, input.OrganizationID, input.RequestID)
+	if err != nil {
+		return nil, err
+	}
+	if err := request.Cancel(time.Now()); err != nil {
+		return nil, err
+	}
+
+	if len(request.PlacedDates) > 0 {
+		if err := u.placer.Remove(ctx, request.OrganizationID, request.StaffID, request.PlacedDates, request.ShiftTypeID); err != nil {
+			u.logger.Error("休暇配置解除失敗", "error", err)
+			return nil, err
+		}
+		request.PlacedDates = nil
+	}
+
+	if err := u.requestRepo.Save(ctx, request); err != nil {
+		u.logger.Error("休暇取り消し失敗", "error", err)
+		return nil, err
+	}
+
+	u.logger.Info("休暇取り消し", "request_id", request.ID)
+	return u.buildOutput(ctx, request)
+}
+
+// Place 承認済みで未配置の日を勤務表に配置 休暇の承認後に作成した勤務表に反映する
+func (u *LeaveUseCase) Place(ctx context.Context, input *LeaveRequestActionInput) (*LeaveRequestOutput, error) {
+	request, err := u.findRequest(ctx, input.OrganizationID, input.RequestID)
+	if err != nil {
+		return nil, err
+	}
+	dates := request.UnplacedDates()
+	if len(dates) == 0 {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "勤務表に配置していない日はありません")
+	}
+
+	placed, err := u.placer.Place(ctx, request.OrganizationID, request.StaffID, dates, request.ShiftTypeID)
+	if err != nil {
+		u.logger.Error("休暇配置失敗", "error", err)
+		return nil, err
+	}
+	if len(placed) == 0 {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "対象月の勤務表がまだ作成されていません")
+	}
+	request.MarkPlaced(placed, time.Now())
+
+	if err := u.requestRepo.Save(ctx, request); err != nil {
+		u.logger.Error("休暇配置失敗", "error", err)
+		return nil, err
+	}
+
+	u.logger.Info("休暇配置", "request_id", request.ID, "placed", len(placed))
+	return u.buildOutput(ctx, request)
+}
+
+// checkShortage 休暇申請で有給休暇の残日数が不足する場合はエラー
+func checkShortage(ledger *staffLedger, request *domain.LeaveRequest, action string) error {
+	today := dateOf(time.Now())
+	if ledger.shortageWith(request, today) > 0 {
+		remaining := strconv.FormatFloat(ledger.balance(today).Remaining, 'f', -1, 64)
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "有給休暇の残日数が不足しているため"+action+"（残り"+remaining+"日）")
+	}
+	return nil
+}
+
+// loadLedger スタッフの台帳を読み込む
+func (u *LeaveUseCase) loadLedger(ctx context.Context, organizationID sharedDomain.ID, staff *staffDomain.Staff) (*staffLedger, error) {
+	grants, err := u.grantRepo.FindByStaffID(ctx, staff.ID)
+	if err != nil {
+		return nil, err
+	}
+	requests, err := u.requestRepo.FindByStaffID(ctx, staff.ID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return newStaffLedger(organizationID, staff, grants, requests, dateOf(now), now), nil
+}
+
+// loadLedgers 組織の有効スタッフの台帳を読み込む
+func (u *LeaveUseCase) loadLedgers(ctx context.Context, organizationID sharedDomain.ID) ([]*staffLedger, error) {
+	staffs, err := u.staffRepo.FindActiveByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := u.grantRepo.FindByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	requests, err := u.requestRepo.FindByOrganizationID(ctx, organizationID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	grantsByStaff := make(map[sharedDomain.ID][]domain.LeaveGrant)
+	for _, g := range grants {
+		grantsByStaff[g.StaffID] = append(grantsByStaff[g.StaffID], g)
+	}
+	requestsByStaff := make(map[sharedDomain.ID][]domain.LeaveRequest)
+	for _, r := range requests {
+		requestsByStaff[r.StaffID] = append(requestsByStaff[r.StaffID], r)
+	}
+
+	now := time.Now()
+	today := dateOf(now)
+	ledgers := make([]*staffLedger, len(staffs))
+	for i := range staffs {
+		ledgers[i] = newStaffLedger(organizationID, &staffs[i], grantsByStaff[staffs[i].ID], requestsByStaff[staffs[i].ID], today, now)
+	}
+	return ledgers, nil
+}
+
+// findStaff 組織の有効スタッフを取得 該当なしはnil
+func (u *LeaveUseCase) findStaff(ctx context.Context, organizationID sharedDomain.ID, staffID string) (*staffDomain.Staff, error) {
+	id, err := sharedDomain.ParseID(staffID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "スタッフIDが不正です")
+	}
+	staffs, err := u.staffRepo.FindActiveByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range staffs {
+		if staffs[i].ID == id {
+			return &staffs[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// findRequest 組織の休暇申請を取得 他組織の申請は見つからない扱い
+func (u *LeaveUseCase) findRequest(ctx context.Context, organizationID, id string) (*domain.LeaveRequest, error) {
+	orgID, err := sharedDomain.ParseID(organizationID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDが不正です")
+	}
+	requestID, err := sharedDomain.ParseID(id)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "休暇申請IDが不正です")
+	}
+
+	request, err := u.requestRepo.FindByID(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil || request.OrganizationID != orgID {
+		return nil, sharedDomain.ErrNotFound
+	}
+	return request, nil
+}
+
+// buildOutput 休暇申請出力を組み立てる 有効スタッフの申請は残高を含む
+func (u *LeaveUseCase) buildOutput(ctx context.Context, request *domain.LeaveRequest) (*LeaveRequestOutput, error) {
+	staffNames, err := u.staffNames(ctx, request.OrganizationID, []domain.LeaveRequest{*request})
+	if err != nil {
+		return nil, err
+	}
+	shiftNames, err := u.shiftTypeNames(ctx, request.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	output := requestOutput(request, staffNames[request.StaffID], shiftNames[request.ShiftTypeID])
+
+	staff, err := u.findStaff(ctx, request.OrganizationID, request.StaffID.String())
+	if err != nil {
+		return nil, err
+	}
+	if staff != nil {
+		ledger, err := u.loadLedger(ctx, request.OrganizationID, staff)
+		if err != nil {
+			return nil, err
+		}
+		today := dateOf(time.Now())
+		balance := balanceOutput(staff, ledger.balance(today), today)
+		output.Balance = &balance
+	}
+	return &output, nil
+}
+
+// staffNames 休暇申請のスタッフ名 無効になったスタッフは個別に取得する
+func (u *LeaveUseCase) staffNames(ctx context.Context, organizationID sharedDomain.ID, requests []domain.LeaveRequest) (map[sharedDomain.ID]string, error) {
+	staffs, err := u.staffRepo.FindActiveByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[sharedDomain.ID]string, len(staffs))
+	for i := range staffs {
+		names[staffs[i].ID] = staffs[i].FullName()
+	}
+	for i := range requests {
+		if _, ok := names[requests[i].StaffID]; ok {
+			continue
+		}
+		staff, err := u.staffRepo.FindByID(ctx, requests[i].StaffID)
+		if err != nil {
+			return nil, err
+		}
+		names[requests[i].StaffID] = ""
+		if staff != nil {
+			names[requests[i].StaffID] = staff.FullName()
+		}
+	}
+	return names, nil
+}
+
+// shiftTypeNames 組織のシフト種別名
+func (u *LeaveUseCase) shiftTypeNames(ctx context.Context, organizationID sharedDomain.ID) (map[sharedDomain.ID]string, error) {
+	shiftTypes, err := u.shiftTypeRepo.FindByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[sharedDomain.ID]string, len(shiftTypes))
+	for _, st := range shiftTypes {
+		names[st.ID] = st.Name
+	}
+	return names, nil
+}
+
+// balanceOutput 残高出力に変換
+func balanceOutput(staff *staffDomain.Staff, balance *domain.LeaveBalance, today time.Time) LeaveBalanceOutput {
+	output := LeaveBalanceOutput{
+		StaffID:      staff.ID.String(),
+		StaffName:    staff.FullName(),
+		EmployeeCode: staff.EmployeeCode,
+		Granted:      balance.Granted,
+		Used:         balance.Used,
+		Remaining:    balance.Remaining,
+		Expired:      balance.Expired,
+	}
+	if staff.HireDate != nil {
+		hireDate := dateOf(*staff.HireDate)
+		nextDate, nextDays := domain.NextStatutoryGrant(hireDate, today)
+		output.HireDate = hireDate.Format("2006-01-02")
+		output.NextGrantDate = nextDate.Format("2006-01-02")
+		output.NextGrantDays = nextDays
+	}
+	return output
+}
+
+// grantOutput 付与出力に変換
+func grantOutput(gb domain.GrantBalance, recorded bool) LeaveGrantOutput {
+	return LeaveGrantOutput{
+		ID:          gb.Grant.ID.String(),
+		GrantDate:   gb.Grant.GrantDate.Format("2006-01-02"),
+		ExpiresOn:   gb.Grant.ExpiresOn.Format("2006-01-02"),
+		Days:        gb.Grant.Days,
+		Used:        gb.Used,
+		Remaining:   gb.Remaining,
+		Expired:     gb.Expired,
+		Source:      gb.Grant.Source.String(),
+		SourceLabel: gb.Grant.Source.Label(),
+		Note:        gb.Grant.Note,
+		CreatedBy:   gb.Grant.CreatedBy,
+		Recorded:    recorded,
+	}
+}
+
+// mandatoryOutput 年5日の取得義務の取得状況出力に変換
+func mandatoryOutput(staff *staffDomain.Staff, period *domain.MandatoryLeavePeriod, asOf time.Time) *MandatoryLeaveOutput {
+	status := period.Status(asOf)
+	output := &MandatoryLeaveOutput{
+		StaffID:      staff.ID.String(),
+		StaffName:    staff.FullName(),
+		EmployeeCode: staff.EmployeeCode,
+		GrantDate:    period.GrantDate.Format("2006-01-02"),
+		EndDate:      period.EndDate.Format("2006-01-02"),
+		GrantedDays:  period.GrantedDays,
+		Taken:        period.Taken,
+		Planned:      period.Planned,
+		Pending:      period.Pending,
+		Remaining:    period.Remaining(),
+		DaysLeft:     period.DaysLeft(asOf),
+		Status:       status.String(),
+		StatusLabel:  status.Label(),
+		IsWarning:    status.IsWarning(),
+	}
+	remaining := strconv.FormatFloat(output.Remaining, 'f', -1, 64)
+	switch status {
+	case domain.MandatoryLeaveAtRisk:
+		output.Message = output.EndDate + "までにあと" + remaining + "日の有給休暇の取得が必要です（残り" + strconv.Itoa(output.DaysLeft) + "日）"
+	case domain.MandatoryLeaveMissed:
+		output.Message = output.EndDate + "までの基準期間に年5日の有給休暇を取得できませんでした（" + remaining + "日不足）"
+	}
+	return output
+}
+
+// requestOutput 休暇申請出力に変換
+func requestOutput(r *domain.LeaveRequest, staffName, shiftName string) LeaveRequestOutput {
+	output := LeaveRequestOutput{
+		ID:              r.ID.String(),
+		StaffID:         r.StaffID.String(),
+		StaffName:       staffName,
+		StartDate:       r.StartDate.Format("2006-01-02"),
+		EndDate:         r.EndDate.Format("2006-01-02"),
+		Days:            r.Days(),
+		ShiftTypeID:     r.ShiftTypeID.String(),
+		ShiftName:       shiftName,
+		Reason:          r.Reason,
+		Status:          r.Status.String(),
+		StatusLabel:     r.Status.Label(),
+		IsPending:       r.Status == domain.LeaveStatusPending,
+		IsActive:        r.IsActive(),
+		RequestedBy:     r.RequestedBy,
+		DecidedBy:       r.DecidedBy,
+		DecisionComment: r.DecisionComment,
+		CreatedAt:       r.CreatedAt.Format(time.RFC3339),
+	}
+	if r.DecidedAt != nil {
+		output.DecidedAt = r.DecidedAt.Format(time.RFC3339)
+	}
+	for _, d := range r.ExcludedDates {
+		output.ExcludedDates = append(output.ExcludedDates, d.Format("2006-01-02"))
+	}
+	for _, d := range r.UnplacedDates() {
+		output.UnplacedDates = append(output.UnplacedDates, d.Format("2006-01-02"))
+	}
+	sort.Strings(output.UnplacedDates)
+	return output
+}
+
+// dateOf 日付部分のみ
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,521 @@
+// Package application 休暇管理ユースケーステスト
+package application
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"shiftmaster/internal/modules/leave/domain"
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
+	staffDomain "shiftmaster/internal/modules/staff/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+	"shiftmaster/internal/shared/infrastructure"
+)
+
+// モック有給休暇付与リポジトリ
+
+type mockLeaveGrantRepository struct {
+	grants []domain.LeaveGrant
+}
+
+func (m *mockLeaveGrantRepository) FindByStaffID(_ context.Context, staffID sharedDomain.ID) ([]domain.LeaveGrant, error) {
+	var result []domain.LeaveGrant
+	for _, g := range m.grants {
+		if g.StaffID == staffID {
+			result = append(result, g)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockLeaveGrantRepository) FindByOrganizationID(_ context.Context, organizationID sharedDomain.ID) ([]domain.LeaveGrant, error) {
+	var result []domain.LeaveGrant
+	for _, g := range m.grants {
+		if g.OrganizationID == organizationID {
+			result = append(result, g)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockLeaveGrantRepository) Save(_ context.Context, grant *domain.LeaveGrant) error {
+	m.grants = append(m.grants, *grant)
+	return nil
+}
+
+func (m *mockLeaveGrantRepository) SaveStatutory(_ context.Context, grants []domain.LeaveGrant) (int, error) {
+	created := 0
+	for _, g := range grants {
+		exists := false
+		for _, e := range m.grants {
+			if e.Source == domain.GrantSourceStatutory && e.StaffID == g.StaffID && e.GrantDate.Equal(g.GrantDate) {
+				exists = true
+			}
+		}
+		if !exists {
+			m.grants = append(m.grants, g)
+			created++
+		}
+	}
+	return created, nil
+}
+
+// モック休暇申請リポジトリ
+
+type mockLeaveRequestRepository struct {
+	requests map[sharedDomain.ID]*domain.LeaveRequest
+}
+
+func (m *mockLeaveRequestRepository) FindByID(_ context.Context, id sharedDomain.ID) (*domain.LeaveRequest, error) {
+	r, ok := m.requests[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *r
+	copied.PlacedDates = append([]time.Time(nil), r.PlacedDates...)
+	return &copied, nil
+}
+
+func (m *mockLeaveRequestRepository) FindByOrganizationID(_ context.Context, organizationID sharedDomain.ID, status domain.LeaveRequestStatus) ([]domain.LeaveRequest, error) {
+	var result []domain.LeaveRequest
+	for _, r := range m.requests {
+		if r.OrganizationID == organizationID && (status == "" || r.Status == status) {
+			result = append(result, *r)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockLeaveRequestRepository) FindByStaffID(_ context.Context, staffID sharedDomain.ID) ([]domain.LeaveRequest, error) {
+	var result []domain.LeaveRequest
+	for _, r := range m.requests {
+		if r.StaffID == staffID {
+			result = append(result, *r)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockLeaveRequestRepository) Save(_ context.Context, request *domain.LeaveRequest) error {
+	m.requests[request.ID] = request
+	return nil
+}
+
+// mockSchedulePlacer モック勤務表配置 配置できる最終日まで配置する
+type mockSchedulePlacer struct {
+	until   time.Time
+	removed []time.Time
+}
+
+func (m *mockSchedulePlacer) Place(_ context.Context, _, _ sharedDomain.ID, dates []time.Time, _ sharedDomain.ID) ([]time.Time, error) {
+	var placed []time.Time
+	for _, d := range dates {
+		if !d.After(m.until) {
+			placed = append(placed, d)
+		}
+	}
+	return placed, nil
+}
+
+func (m *mockSchedulePlacer) Remove(_ context.Context, _, _ sharedDomain.ID, dates []time.Time, _ sharedDomain.ID) error {
+	m.removed = append(m.removed, dates...)
+	return nil
+}
+
+// モックスタッフリポジトリ
+
+type mockStaffRepository struct {
+	staffs []staffDomain.Staff
+}
+
+func (m *mockStaffRepository) FindByID(_ context.Context, id sharedDomain.ID) (*staffDomain.Staff, error) {
+	for i := range m.staffs {
+		if m.staffs[i].ID == id {
+			return &m.staffs[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockStaffRepository) FindAll(_ context.Context, _ infrastructure.Pagination) ([]staffDomain.Staff, int, error) {
+	return m.staffs, len(m.staffs), nil
+}
+
+func (m *mockStaffRepository) FindByOrganizationID(_ context.Context, _ sharedDomain.ID, _ infrastructure.Pagination) ([]staffDomain.Staff, int, error) {
+	return m.staffs, len(m.staffs), nil
+}
+
+func (m *mockStaffRepository) FindByTeamID(_ context.Context, _ sharedDomain.ID) ([]staffDomain.Staff, error) {
+	return nil, nil
+}
+
+func (m *mockStaffRepository) FindActive(_ context.Context) ([]staffDomain.Staff, error) {
+	return m.staffs, nil
+}
+
+func (m *mockStaffRepository) FindActiveByOrganizationID(_ context.Context, _ sharedDomain.ID) ([]staffDomain.Staff, error) {
+	return m.staffs, nil
+}
+
+func (m *mockStaffRepository) Save(_ context.Context, _ *staffDomain.Staff) error {
+	return nil
+}
+
+func (m *mockStaffRepository) Delete(_ context.Context, _ sharedDomain.ID) error {
+	return nil
+}
+
+// モックシフト種別リポジトリ
+
+type mockShiftTypeRepository struct {
+	shiftTypes []shiftDomain.ShiftType
+}
+
+func (m *mockShiftTypeRepository) FindByID(_ context.Context, id sharedDomain.ID) (*shiftDomain.ShiftType, error) {
+	for i := range m.shiftTypes {
+		if m.shiftTypes[i].ID == id {
+			return &m.shiftTypes[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockShiftTypeRepository) FindAll(_ context.Context) ([]shiftDomain.ShiftType, error) {
+	return m.shiftTypes, nil
+}
+
+func (m *mockShiftTypeRepository) FindByOrganizationID(_ context.Context, _ sharedDomain.ID) ([]shiftDomain.ShiftType, error) {
+	return m.shiftTypes, nil
+}
+
+func (m *mockShiftTypeRepository) FindWorkShifts(_ context.Context, _ sharedDomain.ID) ([]shiftDomain.ShiftType, error) {
+	return nil, nil
+}
+
+func (m *mockShiftTypeRepository) Save(_ context.Context, _ *shiftDomain.ShiftType) error {
+	return nil
+}
+
+func (m *mockShiftTypeRepository) Delete(_ context.Context, _ sharedDomain.ID) error {
+	return nil
+}
+
+// leaveFixture テスト用の組織・スタッフ・シフト種別
+// スタッフは7か月前に入社し、1か月前に10日付与済み
+type leaveFixture struct {
+	orgID    sharedDomain.ID
+	staff    staffDomain.Staff
+	paid     shiftDomain.ShiftType
+	day      shiftDomain.ShiftType
+	today    time.Time
+	grants   *mockLeaveGrantRepository
+	requests *mockLeaveRequestRepository
+	placer   *mockSchedulePlacer
+	useCase  *LeaveUseCase
+}
+
+func newLeaveFixture() *leaveFixture {
+	orgID := sharedDomain.NewID()
+	today := dateOf(time.Now())
+	hireDate := today.AddDate(0, -7, 0)
+	f := &leaveFixture{
+		orgID:    orgID,
+		staff:    staffDomain.Staff{ID: sharedDomain.NewID(), LastName: "山田", FirstName: "花子", HireDate: &hireDate, IsActive: true},
+		paid:     shiftDomain.ShiftType{ID: sharedDomain.NewID(), OrganizationID: orgID, Name: "有給", IsHoliday: true},
+		day:      shiftDomain.ShiftType{ID: sharedDomain.NewID(), OrganizationID: orgID, Name: "日勤"},
+		today:    today,
+		grants:   &mockLeaveGrantRepository{},
+		requests: &mockLeaveRequestRepository{requests: make(map[sharedDomain.ID]*domain.LeaveRequest)},
+		placer:   &mockSchedulePlacer{until: today.AddDate(0, 0, 10)},
+	}
+	f.useCase = NewLeaveUseCase(
+		f.grants,
+		f.requests,
+		&mockStaffRepository{staffs: []staffDomain.Staff{f.staff}},
+		&mockShiftTypeRepository{shiftTypes: []shiftDomain.ShiftType{f.paid, f.day}},
+		f.placer,
+		slog.New(slog.NewTextHandler(os.Stdout, nil)),
+	)
+	return f
+}
+
+// request 今日からの日数で休暇を申請
+func (f *leaveFixture) request(startOffset, endOffset int) (*LeaveRequestOutput, error) {
+	return f.useCase.Request(context.Background(), &CreateLeaveRequestInput{
+		OrganizationID: f.orgID.String(),
+		StaffID:        f.staff.ID.String(),
+		StartDate:      f.today.AddDate(0, 0, startOffset).Format("2006-01-02"),
+		EndDate:        f.today.AddDate(0, 0, endOffset).Format("2006-01-02"),
+		ShiftTypeID:    f.paid.ID.String(),
+		UserEmail:      "staff@example.com",
+	})
+}
+
+// action 休暇申請の操作入力
+func (f *leaveFixture) action(id string) *LeaveRequestActionInput {
+	return &LeaveRequestActionInput{OrganizationID: f.orgID.String(), RequestID: id, UserEmail: "manager@example.com"}
+}
+
+func assertErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+	var domainErr *sharedDomain.DomainError
+	if !errors.As(err, &domainErr) || domainErr.Code != code {
+		t.Errorf("error = %v, want %v", err, code)
+	}
+}
+
+func TestLeaveUseCase_Request(t *testing.T) {
+	t.Run("申請すると残日数が減る", func(t *testing.T) {
+		f := newLeaveFixture()
+		output, err := f.request(7, 9)
+		if err != nil {
+			t.Fatalf("Request() error = %v", err)
+		}
+		if output.Days != 3 || output.Balance == nil || output.Balance.Remaining != 7 || output.Balance.Granted != 10 {
+			t.Errorf("output = %+v, balance = %+v", output, output.Balance)
+		}
+	})
+
+	t.Run("取得しない日は残日数から差し引かない", func(t *testing.T) {
+		f := newLeaveFixture()
+		output, err := f.useCase.Request(context.Background(), &CreateLeaveRequestInput{
+			OrganizationID: f.orgID.String(),
+			StaffID:        f.staff.ID.String(),
+			StartDate:      f.today.AddDate(0, 0, 7).Format("2006-01-02"),
+			EndDate:        f.today.AddDate(0, 0, 13).Format("2006-01-02"),
+			ExcludedDates:  []string{f.today.AddDate(0, 0, 8).Format("2006-01-02"), f.today.AddDate(0, 0, 12).Format("2006-01-02")},
+			ShiftTypeID:    f.paid.ID.String(),
+			UserEmail:      "staff@example.com",
+		})
+		if err != nil {
+			t.Fatalf("Request() error = %v", err)
+		}
+		if output.Days != 5 || len(output.ExcludedDates) != 2 || output.Balance.Remaining != 5 {
+			t.Errorf("output = %+v, balance = %+v", output, output.Balance)
+		}
+	})
+
+	t.Run("不正な取得しない日はエラー", func(t *testing.T) {
+		f := newLeaveFixture()
+		_, err := f.useCase.Request(context.Background(), &CreateLeaveRequestInput{
+			OrganizationID: f.orgID.String(),
+			StaffID:        f.staff.ID.String(),
+			StartDate:      f.today.AddDate(0, 0, 7).Format("2006-01-02"),
+			EndDate:        f.today.AddDate(0, 0, 9).Format("2006-01-02"),
+			ExcludedDates:  []string{"4/12"},
+			ShiftTypeID:    f.paid.ID.String(),
+		})
+		assertErrorCode(t, err, sharedDomain.ErrCodeValidation)
+	})
+
+	t.Run("期間が重なる申請は競合", func(t *testing.T) {
+		f := newLeaveFixture()
+		if _, err := f.request(7, 9); err != nil {
+			t.Fatalf("Request() error = %v", err)
+		}
+		_, err := f.request(9, 9)
+		assertErrorCode(t, err, sharedDomain.ErrCodeConflict)
+	})
+
+	t.Run("残日数を超える申請はエラー", func(t *testing.T) {
+		f := newLeaveFixture()
+		if _, err := f.request(7, 9); err != nil {
+			t.Fatalf("Request() error = %v", err)
+		}
+		_, err := f.request(20, 27)
+		assertErrorCode(t, err, sharedDomain.ErrCodeValidation)
+	})
+
+	t.Run("休日扱いでないシフト種別はエラー", func(t *testing.T) {
+		f := newLeaveFixture()
+		_, err := f.useCase.Request(context.Background(), &CreateLeaveRequestInput{
+			OrganizationID: f.orgID.String(),
+			StaffID:        f.staff.ID.String(),
+			StartDate:      f.today.AddDate(0, 0, 7).Format("2006-01-02"),
+			ShiftTypeID:    f.day.ID.String(),
+		})
+		assertErrorCode(t, err, sharedDomain.ErrCodeValidation)
+	})
+}
+
+func TestLeaveUseCase_ApproveAndCancel(t *testing.T) {
+	f := newLeaveFixture()
+	ctx := context.Background()
+	requested, err := f.request(9, 12)
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+
+	// 勤務表がある日まで配置し、残りは未配置
+	approved, err := f.useCase.Approve(ctx, f.action(requested.ID))
+	if err != nil {
+		t.Fatalf("Approve() error = %v", err)
+	}
+	if approved.Status != "approved" || len(approved.UnplacedDates) != 2 {
+		t.Errorf("Status = %v, UnplacedDates = %v", approved.Status, approved.UnplacedDates)
+	}
+
+	// 勤務表作成後に未配置の日を配置
+	f.placer.until = f.today.AddDate(0, 0, 31)
+	placed, err := f.useCase.Place(ctx, f.action(requested.ID))
+	if err != nil {
+		t.Fatalf("Place() error = %v", err)
+	}
+	if len(placed.UnplacedDates) != 0 {
+		t.Errorf("UnplacedDates = %v, want none", placed.UnplacedDates)
+	}
+	_, err = f.useCase.Place(ctx, f.action(requested.ID))
+	assertErrorCode(t, err, sharedDomain.ErrCodeValidation)
+
+	// 取り消すと配置したシフトを戻し、残日数も戻る
+	cancelled, err := f.useCase.Cancel(ctx, f.action(requested.ID))
+	if err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	if len(f.placer.removed) != 4 {
+		t.Errorf("removed = %d, want 4", len(f.placer.removed))
+	}
+	if cancelled.Balance == nil || cancelled.Balance.Remaining != 10 {
+		t.Errorf("Balance = %+v, want remaining 10", cancelled.Balance)
+	}
+}
+
+func TestLeaveUseCase_Reject(t *testing.T) {
+	f := newLeaveFixture()
+	ctx := context.Background()
+	requested, err := f.request(7, 7)
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	if _, err := f.useCase.Reject(ctx, f.action(requested.ID)); err != nil {
+		t.Fatalf("Reject() error = %v", err)
+	}
+	_, err = f.useCase.Approve(ctx, f.action(requested.ID))
+	assertErrorCode(t, err, sharedDomain.ErrCodeConflict)
+}
+
+func TestLeaveUseCase_Accrue(t *testing.T) {
+	f := newLeaveFixture()
+	ctx := context.Background()
+
+	output, err := f.useCase.Accrue(ctx, f.orgID.String())
+	if err != nil {
+		t.Fatalf("Accrue() error = %v", err)
+	}
+	if output.Created != 1 {
+		t.Errorf("Created = %d, want 1", output.Created)
+	}
+	if output, _ := f.useCase.Accrue(ctx, f.orgID.String()); output.Created != 0 {
+		t.Errorf("再実行で Created = %d, want 0", output.Created)
+	}
+
+	// 登録済みの法定付与と未登録の計算上の付与が二重に数えられない
+	ledger, err := f.useCase.GetLedger(ctx, f.orgID.String(), f.staff.ID.String())
+	if err != nil {
+		t.Fatalf("GetLedger() error = %v", err)
+	}
+	if len(ledger.Grants) != 1 || !ledger.Grants[0].Recorded || ledger.Balance.Granted != 10 {
+		t.Errorf("ledger = %+v", ledger)
+	}
+}
+
+func TestLeaveUseCase_AddGrant(t *testing.T) {
+	f := newLeaveFixture()
+	ledger, err := f.useCase.AddGrant(context.Background(), &AddLeaveGrantInput{
+		OrganizationID: f.orgID.String(),
+		StaffID:        f.staff.ID.String(),
+		GrantDate:      f.today.Format("2006-01-02"),
+		Days:           "2.5",
+		Note:           "前倒し付与",
+		UserEmail:      "manager@example.com",
+	})
+	if err != nil {
+		t.Fatalf("AddGrant() error = %v", err)
+	}
+	if ledger.Balance.Remaining != 12.5 || len(ledger.Grants) != 2 {
+		t.Errorf("ledger = %+v", ledger)
+	}
+}
+
+func TestLeaveUseCase_ListMandatoryLeave(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("基準期間の終了まで余裕があれば警告しない", func(t *testing.T) {
+		f := newLeaveFixture()
+		items, err := f.useCase.ListMandatoryLeave(ctx, f.orgID.String(), false)
+		if err != nil {
+			t.Fatalf("ListMandatoryLeave() error = %v", err)
+		}
+		if len(items) != 1 || items[0].Status != "in_progress" || items[0].IsWarning || items[0].Remaining != 5 {
+			t.Errorf("items = %+v", items)
+		}
+		if items, _ := f.useCase.ListMandatoryLeave(ctx, f.orgID.String(), true); len(items) != 0 {
+			t.Errorf("警告のみで items = %+v, want none", items)
+		}
+	})
+
+	t.Run("期間終了が近いスタッフは承認済みの取得予定で満たすまで警告", func(t *testing.T) {
+		f := newLeaveFixture()
+		// 11か月前に10日付与され、基準期間の終了まで約1か月
+		hireDate := f.today.AddDate(0, -17, 0)
+		f.useCase.staffRepo.(*mockStaffRepository).staffs[0].HireDate = &hireDate
+
+		requested, err := f.request(7, 11)
+		if err != nil {
+			t.Fatalf("Request() error = %v", err)
+		}
+		items, err := f.useCase.ListMandatoryLeave(ctx, f.orgID.String(), true)
+		if err != nil {
+			t.Fatalf("ListMandatoryLeave() error = %v", err)
+		}
+		if len(items) != 1 || items[0].Status != "at_risk" || items[0].Pending != 5 || items[0].Message == "" {
+			t.Fatalf("items = %+v", items)
+		}
+
+		if _, err := f.useCase.Approve(ctx, f.action(requested.ID)); err != nil {
+			t.Fatalf("Approve() error = %v", err)
+		}
+		if items, _ := f.useCase.ListMandatoryLeave(ctx, f.orgID.String(), true); len(items) != 0 {
+			t.Errorf("承認後に items = %+v, want none", items)
+		}
+		ledger, err := f.useCase.GetLedger(ctx, f.orgID.String(), f.staff.ID.String())
+		if err != nil {
+			t.Fatalf("GetLedger() error = %v", err)
+		}
+		if ledger.Mandatory == nil || ledger.Mandatory.Status != "scheduled" || ledger.Mandatory.Planned != 5 {
+			t.Errorf("Mandatory = %+v", ledger.Mandatory)
+		}
+	})
+	t.Run("取得しないまま次の付与を受けても前の基準期間を未達として警告", func(t *testing.T) {
+		f := newLeaveFixture()
+		// 14か月前に10日、2か月前に11日付与され、前の基準期間は取得なしで終了
+		hireDate := f.today.AddDate(0, -20, 0)
+		f.useCase.staffRepo.(*mockStaffRepository).staffs[0].HireDate = &hireDate
+
+		items, err := f.useCase.ListMandatoryLeave(ctx, f.orgID.String(), true)
+		if err != nil {
+			t.Fatalf("ListMandatoryLeave() error = %v", err)
+		}
+		if len(items) != 1 || items[0].Status != "missed" || items[0].GrantedDays != 10 || items[0].Remaining != 5 || items[0].Message == "" {
+			t.Fatalf("items = %+v", items)
+		}
+		if items, _ := f.useCase.ListMandatoryLeave(ctx, f.orgID.String(), false); len(items) != 2 {
+			t.Errorf("items = %+v, want 未達と現在の基準期間", items)
+		}
+
+		ledger, err := f.useCase.GetLedger(ctx, f.orgID.String(), f.staff.ID.String())
+		if err != nil {
+			t.Fatalf("GetLedger() error = %v", err)
+		}
+		if ledger.Mandatory == nil || ledger.Mandatory.GrantedDays != 11 || ledger.Mandatory.Status != "in_progress" {
+			t.Errorf("Mandatory = %+v", ledger.Mandatory)
+		}
+		if ledger.MissedMandatory == nil || ledger.MissedMandatory.Status != "missed" {
+			t.Errorf("MissedMandatory = %+v", ledger.MissedMandatory)
+		}
+	})
+}
@@ -0,0 +1,145 @@
+// Package domain 休暇管理ドメイン層
+package domain
+
+import (
+	"sort"
+	"time"
+
+	"shiftmaster/internal/shared/domain"
+)
+
+// statutoryGrantDays 勤続年数ごとの法定付与日数 入社6か月後から1年ごと 7回目以降は20日
+var statutoryGrantDays = []float64{10, 11, 12, 14, 16, 18, 20}
+
+// StatutoryGrants 入社日に基づき基準日までに付与される法定付与
+// 入社6か月後に10日、以降1年ごとに11, 12, 14, 16, 18日、6年6か月以降は毎年20日を付与する
+// 出勤率8割以上の通常の労働者を前提とし、所定労働日数が少ない場合の比例付与は手動付与で扱う
+// from より前に時効（2年）で失効した付与は含めない
+func StatutoryGrants(organizationID, staffID domain.ID, hireDate, from, asOf, now time.Time) []LeaveGrant {
+	var grants []LeaveGrant
+	for i := 0; ; i++ {
+		grantDate := addMonths(hireDate, 6+12*i)
+		if grantDate.After(asOf) {
+			break
+		}
+		expires := expiresOn(grantDate)
+		if expires.Before(from) {
+			continue
+		}
+		grants = append(grants, LeaveGrant{
+			ID:             domain.NewID(),
+			OrganizationID: organizationID,
+			StaffID:        staffID,
+			GrantDate:      grantDate,
+			ExpiresOn:      expires,
+			Days:           statutoryGrantDays[min(i, len(statutoryGrantDays)-1)],
+			Source:         GrantSourceStatutory,
+			CreatedAt:      now,
+		})
+	}
+	return grants
+}
+
+// NextStatutoryGrant 基準日より後の次回の法定付与日と日数
+func NextStatutoryGrant(hireDate, asOf time.Time) (time.Time, float64) {
+	for i := 0; ; i++ {
+		grantDate := addMonths(hireDate, 6+12*i)
+		if grantDate.After(asOf) {
+			return grantDate, statutoryGrantDays[min(i, len(statutoryGrantDays)-1)]
+		}
+	}
+}
+
+// LeaveUsage 有給休暇の消化
+type LeaveUsage struct {
+	// Date 取得日
+	Date time.Time
+	// Days 日数
+	Days float64
+}
+
+// GrantBalance 付与ごとの消化状況
+type GrantBalance struct {
+	// Grant 付与
+	Grant LeaveGrant
+	// Used 消化日数 予定を含む
+	Used float64
+	// Remaining 残日数
+	Remaining float64
+	// Expired 基準日時点で失効済み
+	Expired bool
+}
+
+// LeaveBalance 有給休暇残高
+type LeaveBalance struct {
+	// Grants 付与ごとの消化状況 付与日順
+	Grants []GrantBalance
+	// Granted 基準日時点で有効な付与の合計日数
+	Granted float64
+	// Used 基準日時点で有効な付与からの消化日数 予定を含む
+	Used float64
+	// Remaining 残日数
+	Remaining float64
+	// Expired 消化されずに失効した日数
+	Expired float64
+	// Shortage 有効な付与がなく消化できなかった日数
+	Shortage float64
+}
+
+// CalculateBalance 付与と消化から基準日時点の残高を計算
+// 消化は取得日に有効な付与のうち古いものから充当する（繰越分から優先して消化）
+func CalculateBalance(grants []LeaveGrant, usages []LeaveUsage, asOf time.Time) *LeaveBalance {
+	balance := &LeaveBalance{Grants: make([]GrantBalance, len(grants))}
+	for i, g := range grants {
+		balance.Grants[i] = GrantBalance{Grant: g, Remaining: g.Days}
+	}
+	sort.SliceStable(balance.Grants, func(i, j int) bool {
+		return balance.Grants[i].Grant.GrantDate.Before(balance.Grants[j].Grant.GrantDate)
+	})
+
+	sorted := append([]LeaveUsage(nil), usages...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Date.Before(sorted[j].Date) })
+	for _, u := range sorted {
+		need := u.Days
+		for i := range balance.Grants {
+			gb := &balance.Grants[i]
+			if need <= 0 {
+				break
+			}
+			if gb.Remaining <= 0 || !gb.Grant.IsValidOn(u.Date) {
+				continue
+			}
+			taken := min(need, gb.Remaining)
+			gb.Used += taken
+			gb.Remaining -= taken
+			need -= taken
+		}
+		balance.Shortage += need
+	}
+
+	for i := range balance.Grants {
+		gb := &balance.Grants[i]
+		switch {
+		case gb.Grant.ExpiresOn.Before(asOf):
+			gb.Expired = true
+			balance.Expired += gb.Remaining
+		case !gb.Grant.GrantDate.After(asOf):
+			balance.Granted += gb.Grant.Days
+			balance.Used += gb.Used
+			balance.Remaining += gb.Remaining
+		}
+	}
+	return balance
+}
+
+// expiresOn 付与日から2年間の有効期限
+func expiresOn(grantDate time.Time) time.Time {
+	return addMonths(grantDate, 24).AddDate(0, 0, -1)
+}
+
+// addMonths 月数を加算 加算先の月末を超える日は月末にする
+func addMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return time.Date(first.Year(), first.Month(), min(t.Day(), lastDay), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"shiftmaster/internal/shared/domain"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestStatutoryGrants(t *testing.T) {
+	orgID, staffID := domain.NewID(), domain.NewID()
+	now := date(2025, 10, 17)
+
+	t.Run("有効な付与のみ", func(t *testing.T) {
+		grants := StatutoryGrants(orgID, staffID, date(2020, 4, 1), now, now, now)
+		if len(grants) != 2 {
+			t.Fatalf("len = %d, want 2", len(grants))
+		}
+		if !grants[0].GrantDate.Equal(date(2024, 10, 1)) || grants[0].Days != 16 {
+			t.Errorf("grants[0] = %v %v日, want 2024-10-01 16日", grants[0].GrantDate, grants[0].Days)
+		}
+		if !grants[1].GrantDate.Equal(date(2025, 10, 1)) || grants[1].Days != 18 {
+			t.Errorf("grants[1] = %v %v日, want 2025-10-01 18日", grants[1].GrantDate, grants[1].Days)
+		}
+		if !grants[0].ExpiresOn.Equal(date(2026, 9, 30)) {
+			t.Errorf("ExpiresOn = %v, want 2026-09-30", grants[0].ExpiresOn)
+		}
+	})
+
+	t.Run("失効済みを含める", func(t *testing.T) {
+		grants := StatutoryGrants(orgID, staffID, date(2020, 4, 1), date(2020, 1, 1), now, now)
+		want := []float64{10, 11, 12, 14, 16, 18}
+		if len(grants) != len(want) {
+			t.Fatalf("len = %d, want %d", len(grants), len(want))
+		}
+		for i, g := range grants {
+			if g.Days != want[i] || g.Source != GrantSourceStatutory {
+				t.Errorf("grants[%d] = %v日 %v, want %v日", i, g.Days, g.Source, want[i])
+			}
+		}
+	})
+
+	t.Run("6年6か月以降は20日", func(t *testing.T) {
+		grants := StatutoryGrants(orgID, staffID, date(2010, 4, 1), now, now, now)
+		if last := grants[len(grants)-1]; last.Days != 20 {
+			t.Errorf("Days = %v, want 20", last.Days)
+		}
+	})
+
+	t.Run("入社6か月未満は付与なし", func(t *testing.T) {
+		if grants := StatutoryGrants(orgID, staffID, date(2025, 5, 1), now, now, now); len(grants) != 0 {
+			t.Errorf("len = %d, want 0", len(grants))
+		}
+	})
+
+	t.Run("月末の入社日は付与月の月末", func(t *testing.T) {
+		grants := StatutoryGrants(orgID, staffID, date(2024, 8, 31), date(2025, 3, 1), date(2025, 3, 1), now)
+		if len(grants) != 1 || !grants[0].GrantDate.Equal(date(2025, 2, 28)) {
+			t.Errorf("grants = %+v, want 2025-02-28", grants)
+		}
+	})
+}
+
+func TestNextStatutoryGrant(t *testing.T) {
+	next, days := NextStatutoryGrant(date(2025, 4, 1), date(2025, 10, 1))
+	if !next.Equal(date(2026, 10, 1)) || days != 11 {
+		t.Errorf("NextStatutoryGrant() = %v %v日, want 2026-10-01 11日", next, days)
+	}
+}
+
+func TestCalculateBalance(t *testing.T) {
+	older := LeaveGrant{GrantDate: date(2023, 10, 1), ExpiresOn: date(2025, 9, 30), Days: 10}
+	newer := LeaveGrant{GrantDate: date(2024, 10, 1), ExpiresOn: date(2026, 9, 30), Days: 11}
+	usage := func(y int, m time.Month, d int) LeaveUsage { return LeaveUsage{Date: date(y, m, d), Days: 1} }
+
+	t.Run("古い付与から消化し未消化分は失効", func(t *testing.T) {
+		usages := []LeaveUsage{usage(2024, 11, 1), usage(2024, 11, 2), usage(2024, 11, 3)}
+		b := CalculateBalance([]LeaveGrant{newer, older}, usages, date(2025, 10, 17))
+		if b.Grants[0].Used != 3 || b.Grants[1].Used != 0 {
+			t.Errorf("Used = %v, %v, want 3, 0", b.Grants[0].Used, b.Grants[1].Used)
+		}
+		if b.Expired != 7 || b.Granted != 11 || b.Remaining != 11 || b.Used != 0 {
+			t.Errorf("balance = %+v", b)
+		}
+	})
+
+	t.Run("残りを超えた分は次の付与から消化", func(t *testing.T) {
+		var usages []LeaveUsage
+		for d := 1; d <= 12; d++ {
+			usages = append(usages, usage(2025, 1, d))
+		}
+		b := CalculateBalance([]LeaveGrant{older, newer}, usages, date(2025, 2, 1))
+		if b.Grants[0].Remaining != 0 || b.Grants[1].Used != 2 {
+			t.Errorf("grants = %+v", b.Grants)
+		}
+		if b.Granted != 21 || b.Used != 12 || b.Remaining != 9 || b.Shortage != 0 {
+			t.Errorf("balance = %+v", b)
+		}
+	})
+
+	t.Run("有効な付与がない日は不足", func(t *testing.T) {
+		b := CalculateBalance([]LeaveGrant{older}, []LeaveUsage{usage(2023, 9, 30), usage(2025, 10, 1)}, date(2025, 1, 1))
+		if b.Shortage != 2 {
+			t.Errorf("Shortage = %v, want 2", b.Shortage)
+		}
+	})
+}
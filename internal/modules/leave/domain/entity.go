@@ -0,0 +1,353 @@
+// Package domain 休暇管理ドメイン層
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"shiftmaster/internal/shared/domain"
+)
+
+// MaxLeaveRequestDays 1回の休暇申請で指定できる最大日数
+const MaxLeaveRequestDays = 31
+
+// LeaveGrant 年次有給休暇の付与エンティティ
+// 付与日から2年間有効で、古い付与から順に消化する
+type LeaveGrant struct {
+	// ID 一意識別子
+	ID domain.ID
+	// OrganizationID 組織ID
+	OrganizationID domain.ID
+	// StaffID スタッフID
+	StaffID domain.ID
+	// GrantDate 付与日
+	GrantDate time.Time
+	// ExpiresOn 有効期限 この日まで取得できる
+	ExpiresOn time.Time
+	// Days 付与日数
+	Days float64
+	// Source 付与の種類
+	Source GrantSource
+	// Note 備考
+	Note string
+	// CreatedBy 登録したユーザーのメールアドレス 法定付与は空
+	CreatedBy string
+	// CreatedAt 作成日時
+	CreatedAt time.Time
+}
+
+// NewManualGrant 手動付与生成 比例付与や前倒し付与など法定付与以外に使う
+func NewManualGrant(organizationID, staffID domain.ID, grantDate time.Time, days float64, note, createdBy string, now time.Time) (*LeaveGrant, error) {
+	if days <= 0 || days > 40 {
+		return nil, domain.NewDomainError(domain.ErrCodeValidation, "付与日数は0より大きく40日以下で入力してください")
+	}
+	if math.Mod(days, 0.5) != 0 {
+		return nil, domain.NewDomainError(domain.ErrCodeValidation, "付与日数は0.5日単位で入力してください")
+	}
+	if len([]rune(note)) > 200 {
+		return nil, domain.NewDomainError(domain.ErrCodeValidation, "備考は200文字以内で入力してください")
+	}
+
+	return &LeaveGrant{
+		ID:             domain.NewID(),
+		OrganizationID: organizationID,
+		StaffID:        staffID,
+		GrantDate:      grantDate,
+		ExpiresOn:      expiresOn(grantDate),
+		Days:           days,
+		Source:         GrantSourceManual,
+		Note:           note,
+		CreatedBy:      createdBy,
+		CreatedAt:      now,
+	}, nil
+}
+
+// IsValidOn 指定日に取得できる付与か判定
+func (g *LeaveGrant) IsValidOn(date time.Time) bool {
+	return !date.Before(g.GrantDate) && !date.After(g.ExpiresOn)
+}
+
+// GrantSource 付与の種類
+type GrantSource string
+
+const (
+	// GrantSourceStatutory 入社日に基づく法定付与
+	GrantSourceStatutory GrantSource = "statutory"
+	// GrantSourceManual 管理者による手動付与
+	GrantSourceManual GrantSource = "manual"
+)
+
+// String 文字列変換
+func (s GrantSource) String() string {
+	return string(s)
+}
+
+// Label 表示ラベル
+func (s GrantSource) Label() string {
+	switch s {
+	case GrantSourceStatutory:
+		return "法定付与"
+	case GrantSourceManual:
+		return "手動付与"
+	default:
+		return "不明"
+	}
+}
+
+// LeaveRequest 休暇申請エンティティ
+// 承認すると期間中の勤務表エントリを休日扱いのシフト種別にする
+type LeaveRequest struct {
+	// ID 一意識別子
+	ID domain.ID
+	// OrganizationID 組織ID
+	OrganizationID domain.ID
+	// StaffID スタッフID
+	StaffID domain.ID
+	// StartDate 開始日
+	StartDate time.Time
+	// EndDate 終了日
+	EndDate time.Time
+	// ExcludedDates 期間中の休暇を取得しない日 公休日など勤務予定のない日 日付順
+	// 取得日数に数えず勤務表にも配置しない
+	ExcludedDates []time.Time
+	// ShiftTypeID 勤務表に配置する休日扱いのシフト種別ID
+	ShiftTypeID domain.ID
+	// Reason 申請理由
+	Reason string
+	// Status 状態
+	Status LeaveRequestStatus
+	// RequestedBy 申請したユーザーのメールアドレス
+	RequestedBy string
+	// DecidedBy 承認・却下したユーザーのメールアドレス
+	DecidedBy string
+	// DecidedAt 承認・却下日時
+	DecidedAt *time.Time
+	// DecisionComment 承認・却下時のコメント
+	DecisionComment string
+	// PlacedDates 勤務表に配置済みの日
+	PlacedDates []time.Time
+	// CreatedAt 作成日時
+	CreatedAt time.Time
+	// UpdatedAt 更新日時
+	UpdatedAt time.Time
+}
+
+// NewLeaveRequest 休暇申請生成 excludedDatesは期間中の休暇を取得しない日
+func NewLeaveRequest(
+	organizationID, staffID domain.ID,
+	startDate, endDate time.Time,
+	excludedDates []time.Time,
+	shiftTypeID domain.ID,
+	reason, requestedBy string,
+	now time.Time,
+) (*LeaveRequest, error) {
+	if endDate.Before(startDate) {
+		return nil, domain.NewDomainError(domain.ErrCodeValidation, "終了日は開始日以降を指定してください")
+	}
+	if int(endDate.Sub(startDate).Hours()/24)+1 > MaxLeaveRequestDays {
+		return nil, domain.NewDomainError(domain.ErrCodeValidation, "1回の申請は31日以内にしてください")
+	}
+	if len([]rune(reason)) > 500 {
+		return nil, domain.NewDomainError(domain.ErrCodeValidation, "申請理由は500文字以内で入力してください")
+	}
+
+	excluded := make([]time.Time, 0, len(excludedDates))
+	seen := make(map[string]bool, len(excludedDates))
+	for _, d := range excludedDates {
+		if d.Before(startDate) || d.After(endDate) {
+			return nil, domain.NewDomainError(domain.ErrCodeValidation, "取得しない日は申請期間内の日を指定してください")
+		}
+		if key := d.Format("2006-01-02"); !seen[key] {
+			seen[key] = true
+			excluded = append(excluded, d)
+		}
+	}
+	sort.Slice(excluded, func(i, j int) bool { return excluded[i].Before(excluded[j]) })
+
+	request := &LeaveRequest{
+		ID:             domain.NewID(),
+		OrganizationID: organizationID,
+		StaffID:        staffID,
+		StartDate:      startDate,
+		EndDate:        endDate,
+		ExcludedDates:  excluded,
+		ShiftTypeID:    shiftTypeID,
+		Reason:         reason,
+		Status:         LeaveStatusPending,
+		RequestedBy:    requestedBy,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if len(request.Dates()) == 0 {
+		return nil, domain.NewDomainError(domain.ErrCodeValidation, "休暇を取得する日がありません")
+	}
+	return request, nil
+}
+
+// Dates 休暇を取得する日の一覧 開始日から終了日までのうち取得しない日を除く
+func (r *LeaveRequest) Dates() []time.Time {
+	excluded := make(map[string]bool, len(r.ExcludedDates))
+	for _, d := range r.ExcludedDates {
+		excluded[d.Format("2006-01-02")] = true
+	}
+	var dates []time.Time
+	for d := r.StartDate; !d.After(r.EndDate); d = d.AddDate(0, 0, 1) {
+		if !excluded[d.Format("2006-01-02")] {
+			dates = append(dates, d)
+		}
+	}
+	return dates
+}
+
+// Days 取得日数 休暇を取得する日を1日として数える 残日数の確認・台帳・年5日の取得義務で共通
+func (r *LeaveRequest) Days() float64 {
+	return float64(len(r.Dates()))
+}
+
+// Usages 有給休暇の消化 休暇を取得する日ごとに1日
+func (r *LeaveRequest) Usages() []LeaveUsage {
+	dates := r.Dates()
+	usages := make([]LeaveUsage, len(dates))
+	for i, d := range dates {
+		usages[i] = LeaveUsage{Date: d, Days: 1}
+	}
+	return usages
+}
+
+// Overlaps 休暇を取得する日が重なるか判定
+func (r *LeaveRequest) Overlaps(other *LeaveRequest) bool {
+	if r.StartDate.After(other.EndDate) || other.StartDate.After(r.EndDate) {
+		return false
+	}
+	dates := make(map[string]bool)
+	for _, d := range r.Dates() {
+		dates[d.Format("2006-01-02")] = true
+	}
+	for _, d := range other.Dates() {
+		if dates[d.Format("2006-01-02")] {
+			return true
+		}
+	}
+	return false
+}
+
+// IsActive 申請中または承認済み判定 残日数と期間重複の対象
+func (r *LeaveRequest) IsActive() bool {
+	return r.Status == LeaveStatusPending || r.Status == LeaveStatusApproved
+}
+
+// UnplacedDates 承認済みで勤務表に未配置の日
+func (r *LeaveRequest) UnplacedDates() []time.Time {
+	if r.Status != LeaveStatusApproved {
+		return nil
+	}
+	placed := make(map[string]bool, len(r.PlacedDates))
+	for _, d := range r.PlacedDates {
+		placed[d.Format("2006-01-02")] = true
+	}
+	var result []time.Time
+	for _, d := range r.Dates() {
+		if !placed[d.Format("2006-01-02")] {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+// MarkPlaced 勤務表に配置した日を記録
+func (r *LeaveRequest) MarkPlaced(dates []time.Time, now time.Time) {
+	placed := make(map[string]bool, len(r.PlacedDates))
+	for _, d := range r.PlacedDates {
+		placed[d.Format("2006-01-02")] = true
+	}
+	for _, d := range dates {
+		if !placed[d.Format("2006-01-02")] {
+			r.PlacedDates = append(r.PlacedDates, d)
+			placed[d.Format("2006-01-02")] = true
+		}
+	}
+	r.UpdatedAt = now
+}
+
+// Approve 承認
+func (r *LeaveRequest) Approve(userEmail, comment string, now time.Time) error {
+	return r.decide(LeaveStatusApproved, userEmail, comment, now)
+}
+
+// Reject 却下
+func (r *LeaveRequest) Reject(userEmail, comment string, now time.Time) error {
+	return r.decide(LeaveStatusRejected, userEmail, comment, now)
+}
+
+// Cancel 取り消し 承認済みの休暇は開始日より前のみ
+func (r *LeaveRequest) Cancel(now time.Time) error {
+	if !r.IsActive() {
+		return r.closedError()
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if r.Status == LeaveStatusApproved && !today.Before(r.StartDate) {
+		return domain.NewDomainError(domain.ErrCodeValidation, "開始日を過ぎた承認済みの休暇は取り消せません")
+	}
+
+	r.Status = LeaveStatusCancelled
+	r.UpdatedAt = now
+	return nil
+}
+
+// decide 申請中の休暇を承認・却下
+func (r *LeaveRequest) decide(status LeaveRequestStatus, userEmail, comment string, now time.Time) error {
+	if r.Status != LeaveStatusPending {
+		return r.closedError()
+	}
+	if len([]rune(comment)) > 500 {
+		return domain.NewDomainError(domain.ErrCodeValidation, "コメントは500文字以内で入力してください")
+	}
+
+	r.Status = status
+	r.DecidedBy = userEmail
+	r.DecidedAt = &now
+	r.DecisionComment = comment
+	r.UpdatedAt = now
+	return nil
+}
+
+// closedError 状態変更できないエラー
+func (r *LeaveRequest) closedError() error {
+	return domain.NewDomainError(domain.ErrCodeConflict, "この休暇申請は既に"+r.Status.Label()+"です")
+}
+
+// LeaveRequestStatus 休暇申請状態
+type LeaveRequestStatus string
+
+const (
+	// LeaveStatusPending 申請中
+	LeaveStatusPending LeaveRequestStatus = "pending"
+	// LeaveStatusApproved 承認済み
+	LeaveStatusApproved LeaveRequestStatus = "approved"
+	// LeaveStatusRejected 却下
+	LeaveStatusRejected LeaveRequestStatus = "rejected"
+	// LeaveStatusCancelled 取り消し
+	LeaveStatusCancelled LeaveRequestStatus = "cancelled"
+)
+
+// String 文字列変換
+func (s LeaveRequestStatus) String() string {
+	return string(s)
+}
+
+// Label 表示ラベル
+func (s LeaveRequestStatus) Label() string {
+	switch s {
+	case LeaveStatusPending:
+		return "申請中"
+	case LeaveStatusApproved:
+		return "承認済み"
+	case LeaveStatusRejected:
+		return "却下"
+	case LeaveStatusCancelled:
+		return "取り消し"
+	default:
+		return "不明"
+	}
+}
@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"shiftmaster/internal/shared/domain"
+)
+
+func TestNewManualGrant(t *testing.T) {
+	now := date(2025, 4, 1)
+	tests := []struct {
+		name    string
+		days    float64
+		wantErr bool
+	}{
+		{"半日単位", 7.5, false},
+		{"0日", 0, true},
+		{"40日超", 40.5, true},
+		{"半日単位でない", 1.3, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewManualGrant(domain.NewID(), domain.NewID(), now, tt.days, "", "m@example.com", now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (g.Source != GrantSourceManual || !g.ExpiresOn.Equal(date(2027, 3, 31))) {
+				t.Errorf("grant = %+v", g)
+			}
+		})
+	}
+}
+
+func TestNewLeaveRequest(t *testing.T) {
+	now := date(2025, 4, 1)
+	newRequest := func(start, end time.Time, excluded ...time.Time) (*LeaveRequest, error) {
+		return NewLeaveRequest(domain.NewID(), domain.NewID(), start, end, excluded, domain.NewID(), "", "s@example.com", now)
+	}
+
+	r, err := newRequest(date(2025, 4, 30), date(2025, 5, 2))
+	if err != nil {
+		t.Fatalf("NewLeaveRequest() error = %v", err)
+	}
+	if r.Days() != 3 || r.Status != LeaveStatusPending {
+		t.Errorf("Days = %v, Status = %v", r.Days(), r.Status)
+	}
+
+	if _, err := newRequest(date(2025, 5, 2), date(2025, 4, 30)); err == nil {
+		t.Error("終了日が開始日より前の申請ができています")
+	}
+	if _, err := newRequest(date(2025, 5, 1), date(2025, 6, 1)); err == nil {
+		t.Error("32日間の申請ができています")
+	}
+
+	// 公休日の土日を除いた5日間
+	r, err = newRequest(date(2025, 4, 7), date(2025, 4, 13), date(2025, 4, 13), date(2025, 4, 12), date(2025, 4, 12))
+	if err != nil {
+		t.Fatalf("NewLeaveRequest() error = %v", err)
+	}
+	if r.Days() != 5 || len(r.Usages()) != 5 || len(r.ExcludedDates) != 2 || !r.ExcludedDates[0].Equal(date(2025, 4, 12)) {
+		t.Errorf("Days = %v, Usages = %d, ExcludedDates = %v", r.Days(), len(r.Usages()), r.ExcludedDates)
+	}
+	for _, u := range r.Usages() {
+		if u.Date.Weekday() == time.Saturday || u.Date.Weekday() == time.Sunday {
+			t.Errorf("取得しない日が消化に含まれています: %s", u.Date.Format("2006-01-02"))
+		}
+	}
+	weekend, _ := newRequest(date(2025, 4, 12), date(2025, 4, 12))
+	if r.Overlaps(weekend) {
+		t.Error("取得しない日だけが重なる申請を重複と判定しています")
+	}
+
+	if _, err := newRequest(date(2025, 4, 7), date(2025, 4, 8), date(2025, 4, 9)); err == nil {
+		t.Error("期間外の取得しない日を指定できています")
+	}
+	if _, err := newRequest(date(2025, 4, 12), date(2025, 4, 12), date(2025, 4, 12)); err == nil {
+		t.Error("取得する日がない申請ができています")
+	}
+}
+
+func TestLeaveRequest_Transitions(t *testing.T) {
+	now := date(2025, 4, 1)
+	newRequest := func(t *testing.T) *LeaveRequest {
+		r, err := NewLeaveRequest(domain.NewID(), domain.NewID(), date(2025, 4, 10), date(2025, 4, 11), nil, domain.NewID(), "", "", now)
+		if err != nil {
+			t.Fatalf("NewLeaveRequest() error = %v", err)
+		}
+		return r
+	}
+
+	t.Run("承認後は未配置の日を返す", func(t *testing.T) {
+		r := newRequest(t)
+		if len(r.UnplacedDates()) != 0 {
+			t.Error("申請中の休暇に未配置の日があります")
+		}
+		if err := r.Approve("m@example.com", "", now); err != nil {
+			t.Fatalf("Approve() error = %v", err)
+		}
+		r.MarkPlaced([]time.Time{date(2025, 4, 10)}, now)
+		if got := r.UnplacedDates(); len(got) != 1 || !got[0].Equal(date(2025, 4, 11)) {
+			t.Errorf("UnplacedDates() = %v, want [2025-04-11]", got)
+		}
+	})
+
+	t.Run("決定済みの申請は承認できない", func(t *testing.T) {
+		r := newRequest(t)
+		if err := r.Reject("m@example.com", "", now); err != nil {
+			t.Fatalf("Reject() error = %v", err)
+		}
+		var domainErr *domain.DomainError
+		if err := r.Approve("m@example.com", "", now); !errors.As(err, &domainErr) || domainErr.Code != domain.ErrCodeConflict {
+			t.Errorf("error = %v, want conflict", err)
+		}
+	})
+
+	t.Run("承認済みの休暇は開始日以降は取り消せない", func(t *testing.T) {
+		r := newRequest(t)
+		if err := r.Approve("m@example.com", "", now); err != nil {
+			t.Fatalf("Approve() error = %v", err)
+		}
+		if err := r.Cancel(date(2025, 4, 10)); err == nil {
+			t.Error("開始日に承認済みの休暇を取り消せています")
+		}
+		if err := r.Cancel(date(2025, 4, 9)); err != nil || r.Status != LeaveStatusCancelled {
+			t.Errorf("Cancel() error = %v, Status = %v", err, r.Status)
+		}
+	})
+}
@@ -0,0 +1,191 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	// MandatoryLeaveMinGrantDays 年5日の取得義務の対象となる付与日数
+	MandatoryLeaveMinGrantDays = 10
+	// MandatoryLeaveDays 基準日から1年以内に取得させる日数
+	MandatoryLeaveDays = 5
+	// MandatoryLeaveWarningDays 基準期間の終了までこの日数以内で取得日数が足りない場合は警告する
+	MandatoryLeaveWarningDays = 90
+	// MandatoryLeaveMissedRetentionDays 取得日数が足りないまま終了した基準期間を終了後この日数の間は未達として報告する
+	MandatoryLeaveMissedRetentionDays = 365
+)
+
+// MandatoryLeavePeriod 年5日の取得義務の基準期間と取得状況
+// 10日以上の付与の付与日（基準日）から1年間に取得した有給休暇を数える
+type MandatoryLeavePeriod struct {
+	// GrantDate 基準日
+	GrantDate time.Time
+	// EndDate 基準期間の終了日 基準日から1年後の前日
+	EndDate time.Time
+	// GrantedDays 基準日の付与日数
+	GrantedDays float64
+	// Taken 判定日までに取得した日数 承認済みの休暇を数える
+	Taken float64
+	// Planned 判定日より後に取得予定の日数 承認済みの休暇を数える
+	Planned float64
+	// Pending 承認待ちの日数
+	Pending float64
+}
+
+// CurrentMandatoryLeavePeriod 判定日時点の基準期間 判定日以前で最新の10日以上の付与を基準日とする
+// 対象となる付与がない場合はnil
+func CurrentMandatoryLeavePeriod(grants []LeaveGrant, requests []LeaveRequest, asOf time.Time) *MandatoryLeavePeriod {
+	base := latestMandatoryLeaveGrant(grants, asOf, nil)
+	if base == nil {
+		return nil
+	}
+	return newMandatoryLeavePeriod(base, requests, asOf)
+}
+
+// MissedMandatoryLeavePeriod 判定日時点の基準期間より前に終了し、取得日数が足りなかった直近の基準期間
+// 毎年の付与で基準期間が切り替わっても未達を報告し続けるために使う
+// 終了から MandatoryLeaveMissedRetentionDays 日を過ぎた期間と、取得義務を満たした期間はnil
+func MissedMandatoryLeavePeriod(grants []LeaveGrant, requests []LeaveRequest, asOf time.Time) *MandatoryLeavePeriod {
+	current := latestMandatoryLeaveGrant(grants, asOf, nil)
+	if current == nil {
+		return nil
+	}
+	base := latestMandatoryLeaveGrant(grants, asOf, func(g *LeaveGrant) bool {
+		return g.GrantDate.Before(current.GrantDate) && mandatoryLeaveEndDate(g.GrantDate).Before(asOf)
+	})
+	if base == nil {
+		return nil
+	}
+
+	period := newMandatoryLeavePeriod(base, requests, asOf)
+	if period.Status(asOf) != MandatoryLeaveMissed ||
+		asOf.After(period.EndDate.AddDate(0, 0, MandatoryLeaveMissedRetentionDays)) {
+		return nil
+	}
+	return period
+}
+
+// latestMandatoryLeaveGrant 判定日以前で最新の10日以上の付与 filterがある場合は条件を満たす付与のみ
+func latestMandatoryLeaveGrant(grants []LeaveGrant, asOf time.Time, filter func(*LeaveGrant) bool) *LeaveGrant {
+	var base *LeaveGrant
+	for i := range grants {
+		g := &grants[i]
+		if g.Days < MandatoryLeaveMinGrantDays || g.GrantDate.After(asOf) {
+			continue
+		}
+		if filter != nil && !filter(g) {
+			continue
+		}
+		if base == nil || g.GrantDate.After(base.GrantDate) {
+			base = g
+		}
+	}
+	return base
+}
+
+// mandatoryLeaveEndDate 基準期間の終了日 基準日から1年後の前日
+func mandatoryLeaveEndDate(grantDate time.Time) time.Time {
+	return addMonths(grantDate, 12).AddDate(0, 0, -1)
+}
+
+// newMandatoryLeavePeriod 付与を基準日とする基準期間の取得状況を集計
+func newMandatoryLeavePeriod(base *LeaveGrant, requests []LeaveRequest, asOf time.Time) *MandatoryLeavePeriod {
+	period := &MandatoryLeavePeriod{
+		GrantDate:   base.GrantDate,
+		EndDate:     mandatoryLeaveEndDate(base.GrantDate),
+		GrantedDays: base.Days,
+	}
+	for i := range requests {
+		r := &requests[i]
+		if !r.IsActive() {
+			continue
+		}
+		for _, u := range r.Usages() {
+			if u.Date.Before(period.GrantDate) || u.Date.After(period.EndDate) {
+				continue
+			}
+			switch {
+			case r.Status == LeaveStatusPending:
+				period.Pending += u.Days
+			case u.Date.After(asOf):
+				period.Planned += u.Days
+			default:
+				period.Taken += u.Days
+			}
+		}
+	}
+	return period
+}
+
+// Remaining 取得義務を満たすためにあと必要な日数 取得予定を含めて数える
+func (p *MandatoryLeavePeriod) Remaining() float64 {
+	return max(0, MandatoryLeaveDays-p.Taken-p.Planned)
+}
+
+// DaysLeft 判定日から基準期間の終了日までの日数 終了済みは0
+func (p *MandatoryLeavePeriod) DaysLeft(asOf time.Time) int {
+	if asOf.After(p.EndDate) {
+		return 0
+	}
+	return int(p.EndDate.Sub(asOf).Hours()/24) + 1
+}
+
+// Status 判定日時点の取得状況
+func (p *MandatoryLeavePeriod) Status(asOf time.Time) MandatoryLeaveStatus {
+	switch {
+	case p.Taken >= MandatoryLeaveDays:
+		return MandatoryLeaveMet
+	case p.Remaining() == 0:
+		return MandatoryLeaveScheduled
+	case asOf.After(p.EndDate):
+		return MandatoryLeaveMissed
+	case p.DaysLeft(asOf) <= MandatoryLeaveWarningDays:
+		return MandatoryLeaveAtRisk
+	default:
+		return MandatoryLeaveInProgress
+	}
+}
+
+// MandatoryLeaveStatus 年5日の取得義務の取得状況
+type MandatoryLeaveStatus string
+
+const (
+	// MandatoryLeaveMet 取得済み
+	MandatoryLeaveMet MandatoryLeaveStatus = "met"
+	// MandatoryLeaveScheduled 承認済みの取得予定で満たす
+	MandatoryLeaveScheduled MandatoryLeaveStatus = "scheduled"
+	// MandatoryLeaveInProgress 取得日数が足りないが基準期間の終了まで余裕がある
+	MandatoryLeaveInProgress MandatoryLeaveStatus = "in_progress"
+	// MandatoryLeaveAtRisk 基準期間の終了が近いが取得日数が足りない
+	MandatoryLeaveAtRisk MandatoryLeaveStatus = "at_risk"
+	// MandatoryLeaveMissed 取得日数が足りないまま基準期間が終了した
+	MandatoryLeaveMissed MandatoryLeaveStatus = "missed"
+)
+
+// String 文字列変換
+func (s MandatoryLeaveStatus) String() string {
+	return string(s)
+}
+
+// Label 表示ラベル
+func (s MandatoryLeaveStatus) Label() string {
+	switch s {
+	case MandatoryLeaveMet:
+		return "取得済み"
+	case MandatoryLeaveScheduled:
+		return "取得予定"
+	case MandatoryLeaveInProgress:
+		return "取得中"
+	case MandatoryLeaveAtRisk:
+		return "要注意"
+	case MandatoryLeaveMissed:
+		return "未達"
+	default:
+		return "不明"
+	}
+}
+
+// IsWarning 警告対象か判定
+func (s MandatoryLeaveStatus) IsWarning() bool {
+	return s == MandatoryLeaveAtRisk || s == MandatoryLeaveMissed
+}
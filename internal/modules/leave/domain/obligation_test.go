@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"shiftmaster/internal/shared/domain"
+)
+
+func TestCurrentMandatoryLeavePeriod(t *testing.T) {
+	grants := []LeaveGrant{
+		{GrantDate: date(2024, 4, 1), Days: 10},
+		{GrantDate: date(2025, 4, 1), Days: 11},
+		{GrantDate: date(2025, 6, 1), Days: 3},
+	}
+	leave := func(start, end time.Time, status LeaveRequestStatus) LeaveRequest {
+		return LeaveRequest{ID: domain.NewID(), StartDate: start, EndDate: end, Status: status}
+	}
+	requests := []LeaveRequest{
+		leave(date(2025, 3, 30), date(2025, 4, 1), LeaveStatusApproved),
+		leave(date(2025, 5, 1), date(2025, 5, 1), LeaveStatusApproved),
+		leave(date(2025, 11, 4), date(2025, 11, 5), LeaveStatusApproved),
+		leave(date(2025, 12, 1), date(2025, 12, 1), LeaveStatusPending),
+		leave(date(2025, 12, 2), date(2025, 12, 2), LeaveStatusRejected),
+		leave(date(2026, 4, 1), date(2026, 4, 1), LeaveStatusApproved),
+	}
+
+	p := CurrentMandatoryLeavePeriod(grants, requests, date(2025, 10, 17))
+	if p == nil {
+		t.Fatal("基準期間がありません")
+	}
+	if !p.GrantDate.Equal(date(2025, 4, 1)) || !p.EndDate.Equal(date(2026, 3, 31)) || p.GrantedDays != 11 {
+		t.Errorf("period = %v〜%v %v日, want 2025-04-01〜2026-03-31 11日", p.GrantDate, p.EndDate, p.GrantedDays)
+	}
+	if p.Taken != 2 || p.Planned != 2 || p.Pending != 1 {
+		t.Errorf("Taken = %v, Planned = %v, Pending = %v, want 2, 2, 1", p.Taken, p.Planned, p.Pending)
+	}
+	if p.Remaining() != 1 {
+		t.Errorf("Remaining() = %v, want 1", p.Remaining())
+	}
+
+	t.Run("10日未満の付与のみは対象外", func(t *testing.T) {
+		if p := CurrentMandatoryLeavePeriod(grants[2:], nil, date(2025, 10, 17)); p != nil {
+			t.Errorf("period = %+v, want nil", p)
+		}
+	})
+
+	t.Run("取得しない日は数えない", func(t *testing.T) {
+		week := leave(date(2025, 6, 2), date(2025, 6, 8), LeaveStatusApproved)
+		week.ExcludedDates = []time.Time{date(2025, 6, 7), date(2025, 6, 8)}
+		p := CurrentMandatoryLeavePeriod(grants, []LeaveRequest{week}, date(2025, 10, 17))
+		if p == nil || p.Taken != 5 {
+			t.Errorf("period = %+v, want Taken 5", p)
+		}
+	})
+}
+
+func TestMissedMandatoryLeavePeriod(t *testing.T) {
+	grants := []LeaveGrant{
+		{GrantDate: date(2024, 4, 1), Days: 10},
+		{GrantDate: date(2025, 4, 1), Days: 11},
+	}
+	leave := func(start, end time.Time) LeaveRequest {
+		return LeaveRequest{ID: domain.NewID(), StartDate: start, EndDate: end, Status: LeaveStatusApproved}
+	}
+	// 前の基準期間に3日しか取得しないまま翌年の付与を受けた
+	requests := []LeaveRequest{
+		leave(date(2024, 6, 3), date(2024, 6, 5)),
+		leave(date(2025, 5, 1), date(2025, 5, 2)),
+	}
+
+	// 新しい付与で現在の基準期間は切り替わる
+	current := CurrentMandatoryLeavePeriod(grants, requests, date(2025, 4, 1))
+	if current == nil || !current.GrantDate.Equal(date(2025, 4, 1)) {
+		t.Fatalf("current = %+v, want 2025-04-01", current)
+	}
+
+	p := MissedMandatoryLeavePeriod(grants, requests, date(2025, 4, 1))
+	if p == nil {
+		t.Fatal("前の基準期間の未達がありません")
+	}
+	if !p.GrantDate.Equal(date(2024, 4, 1)) || !p.EndDate.Equal(date(2025, 3, 31)) || p.Taken != 3 {
+		t.Errorf("period = %v〜%v 取得%v日, want 2024-04-01〜2025-03-31 取得3日", p.GrantDate, p.EndDate, p.Taken)
+	}
+	if got := p.Status(date(2025, 4, 1)); got != MandatoryLeaveMissed {
+		t.Errorf("Status() = %v, want %v", got, MandatoryLeaveMissed)
+	}
+
+	t.Run("終了から1年を過ぎると報告しない", func(t *testing.T) {
+		if p := MissedMandatoryLeavePeriod(grants, requests, date(2026, 3, 31)); p == nil {
+			t.Error("終了から1年以内で period = nil")
+		}
+		if p := MissedMandatoryLeavePeriod(grants, requests, date(2026, 4, 1)); p != nil {
+			t.Errorf("period = %+v, want nil", p)
+		}
+	})
+
+	t.Run("取得義務を満たした期間は対象外", func(t *testing.T) {
+		met := append(requests, leave(date(2024, 8, 5), date(2024, 8, 6)))
+		if p := MissedMandatoryLeavePeriod(grants, met, date(2025, 4, 1)); p != nil {
+			t.Errorf("period = %+v, want nil", p)
+		}
+	})
+
+	t.Run("前の基準期間がなければ対象外", func(t *testing.T) {
+		if p := MissedMandatoryLeavePeriod(grants[:1], requests, date(2025, 4, 1)); p != nil {
+			t.Errorf("period = %+v, want nil", p)
+		}
+	})
+}
+
+func TestMandatoryLeavePeriod_Status(t *testing.T) {
+	period := func(taken, planned float64) *MandatoryLeavePeriod {
+		return &MandatoryLeavePeriod{GrantDate: date(2025, 4, 1), EndDate: date(2026, 3, 31), Taken: taken, Planned: planned}
+	}
+
+	tests := []struct {
+		name   string
+		period *MandatoryLeavePeriod
+		asOf   time.Time
+		want   MandatoryLeaveStatus
+	}{
+		{"取得済み", period(5, 0), date(2025, 10, 17), MandatoryLeaveMet},
+		{"取得予定で満たす", period(3, 2), date(2026, 3, 1), MandatoryLeaveScheduled},
+		{"期間終了まで余裕がある", period(1, 0), date(2025, 10, 17), MandatoryLeaveInProgress},
+		{"期間終了まで90日以内", period(1, 0), date(2026, 1, 1), MandatoryLeaveAtRisk},
+		{"期間終了後に不足", period(4.5, 0), date(2026, 4, 1), MandatoryLeaveMissed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.period.Status(tt.asOf); got != tt.want {
+				t.Errorf("Status() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := period(0, 0).DaysLeft(date(2026, 3, 31)); got != 1 {
+		t.Errorf("DaysLeft() = %d, want 1", got)
+	}
+}
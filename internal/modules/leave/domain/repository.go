@@ -0,0 +1,41 @@
+// Package domain 休暇管理ドメイン層
+package domain
+
+import (
+	"context"
+	"time"
+
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// LeaveGrantRepository 有給休暇付与リポジトリインターフェース
+type LeaveGrantRepository interface {
+	// FindByStaffID スタッフの付与一覧 付与日順
+	FindByStaffID(ctx context.Context, staffID sharedDomain.ID) ([]LeaveGrant, error)
+	// FindByOrganizationID 組織の付与一覧 付与日順
+	FindByOrganizationID(ctx context.Context, organizationID sharedDomain.ID) ([]LeaveGrant, error)
+	// Save 保存
+	Save(ctx context.Context, grant *LeaveGrant) error
+	// SaveStatutory 法定付与を登録 同じスタッフ・付与日の法定付与が登録済みの場合は何もしない 登録件数を返す
+	SaveStatutory(ctx context.Context, grants []LeaveGrant) (int, error)
+}
+
+// LeaveRequestRepository 休暇申請リポジトリインターフェース
+type LeaveRequestRepository interface {
+	// FindByID IDで検索 該当なしはnil
+	FindByID(ctx context.Context, id sharedDomain.ID) (*LeaveRequest, error)
+	// FindByOrganizationID 組織の休暇申請一覧 新しい順 statusが空の場合は全件
+	FindByOrganizationID(ctx context.Context, organizationID sharedDomain.ID, status LeaveRequestStatus) ([]LeaveRequest, error)
+	// FindByStaffID スタッフの休暇申請一覧 新しい順
+	FindByStaffID(ctx context.Context, staffID sharedDomain.ID) ([]LeaveRequest, error)
+	// Save 保存
+	Save(ctx context.Context, request *LeaveRequest) error
+}
+
+// SchedulePlacer 勤務表への休暇配置インターフェース
+type SchedulePlacer interface {
+	// Place 勤務表がある月の指定日のエントリを指定シフト種別にする 配置した日を返す
+	Place(ctx context.Context, organizationID, staffID sharedDomain.ID, dates []time.Time, shiftTypeID sharedDomain.ID) ([]time.Time, error)
+	// Remove 指定シフト種別のままのエントリを未割当に戻す
+	Remove(ctx context.Context, organizationID, staffID sharedDomain.ID, dates []time.Time, shiftTypeID sharedDomain.ID) error
+}
@@ -0,0 +1,313 @@
+// Package infrastructure 休暇管理インフラストラクチャ層
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"shiftmaster/internal/modules/leave/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// LeaveGrantModel 有給休暇付与DBモデル
+type LeaveGrantModel struct {
+	bun.BaseModel `bun:"table:leave_grants"`
+
+	ID             uuid.UUID `bun:"id,pk,type:uuid"`
+	OrganizationID uuid.UUID `bun:"organization_id,type:uuid,notnull"`
+	StaffID        uuid.UUID `bun:"staff_id,type:uuid,notnull"`
+	GrantDate      time.Time `bun:"grant_date,type:date,notnull"`
+	ExpiresOn      time.Time `bun:"expires_on,type:date,notnull"`
+	Days           float64   `bun:"days,notnull"`
+	Source         string    `bun:"source,notnull"`
+	Note           string    `bun:"note,notnull"`
+	CreatedBy      string    `bun:"created_by,notnull"`
+	CreatedAt      time.Time `bun:"created_at,notnull"`
+}
+
+// ToDomain DBモデルからドメインエンティティへ変換
+func (m *LeaveGrantModel) ToDomain() *domain.LeaveGrant {
+	return &domain.LeaveGrant{
+		ID:             m.ID,
+		OrganizationID: m.OrganizationID,
+		StaffID:        m.StaffID,
+		GrantDate:      m.GrantDate,
+		ExpiresOn:      m.ExpiresOn,
+		Days:           m.Days,
+		Source:         domain.GrantSource(m.Source),
+		Note:           m.Note,
+		CreatedBy:      m.CreatedBy,
+		CreatedAt:      m.CreatedAt,
+	}
+}
+
+// FromDomain ドメインエンティティからDBモデルへ変換
+func (m *LeaveGrantModel) FromDomain(g *domain.LeaveGrant) {
+	m.ID = g.ID
+	m.OrganizationID = g.OrganizationID
+	m.StaffID = g.StaffID
+	m.GrantDate = g.GrantDate
+	m.ExpiresOn = g.ExpiresOn
+	m.Days = g.Days
+	m.Source = g.Source.String()
+	m.Note = g.Note
+	m.CreatedBy = g.CreatedBy
+	m.CreatedAt = g.CreatedAt
+}
+
+// PostgresLeaveGrantRepository PostgreSQL有給休暇付与リポジトリ
+type PostgresLeaveGrantRepository struct {
+	db *bun.DB
+}
+
+// NewPostgresLeaveGrantRepository リポジトリ生成
+func NewPostgresLeaveGrantRepository(db *bun.DB) *PostgresLeaveGrantRepository {
+	return &PostgresLeaveGrantRepository{db: db}
+}
+
+// FindByStaffID スタッフの付与一覧 付与日順
+func (r *PostgresLeaveGrantRepository) FindByStaffID(ctx context.Context, staffID sharedDomain.ID) ([]domain.LeaveGrant, error) {
+	var models []LeaveGrantModel
+	err := r.db.NewSelect().
+		Model(&models).
+		Where("staff_id = ?", staffID).
+		Order("grant_date ASC", "created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return grantsToDomain(models), nil
+}
+
+// FindByOrganizationID 組織の付与一覧 付与日順
+func (r *PostgresLeaveGrantRepository) FindByOrganizationID(ctx context.Context, organizationID sharedDomain.ID) ([]domain.LeaveGrant, error) {
+	var models []LeaveGrantModel
+	err := r.db.NewSelect().
+		Model(&models).
+		Where("organization_id = ?", organizationID).
+		Order("grant_date ASC", "created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return grantsToDomain(models), nil
+}
+
+// Save 保存
+func (r *PostgresLeaveGrantRepository) Save(ctx context.Context, grant *domain.LeaveGrant) error {
+	model := &LeaveGrantModel{}
+	model.FromDomain(grant)
+
+	_, err := r.db.NewInsert().
+		Model(model).
+		On("CONFLICT (id) DO UPDATE").
+		Set("days = EXCLUDED.days").
+		Set("note = EXCLUDED.note").
+		Exec(ctx)
+	return err
+}
+
+// SaveStatutory 法定付与を登録 登録済みの付与日は何もしない 登録件数を返す
+func (r *PostgresLeaveGrantRepository) SaveStatutory(ctx context.Context, grants []domain.LeaveGrant) (int, error) {
+	if len(grants) == 0 {
+		return 0, nil
+	}
+
+	models := make([]LeaveGrantModel, len(grants))
+	for i := range grants {
+		models[i].FromDomain(&grants[i])
+	}
+
+	result, err := r.db.NewInsert().
+		Model(&models).
+		On("CONFLICT (staff_id, grant_date) WHERE source = 'statutory' DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(n), nil
+}
+
+// grantsToDomain DBモデル一覧をドメインエンティティへ変換
+func grantsToDomain(models []LeaveGrantModel) []domain.LeaveGrant {
+	grants := make([]domain.LeaveGrant, len(models))
+	for i := range models {
+		grants[i] = *models[i].ToDomain()
+	}
+	return grants
+}
+
+// LeaveRequestModel 休暇申請DBモデル
+type LeaveRequestModel struct {
+	bun.BaseModel `bun:"table:leave_requests"`
+
+	ID              uuid.UUID  `bun:"id,pk,type:uuid"`
+	OrganizationID  uuid.UUID  `bun:"organization_id,type:uuid,notnull"`
+	StaffID         uuid.UUID  `bun:"staff_id,type:uuid,notnull"`
+	StartDate       time.Time  `bun:"start_date,type:date,notnull"`
+	EndDate         time.Time  `bun:"end_date,type:date,notnull"`
+	ExcludedDates   []string   `bun:"excluded_dates,type:jsonb,notnull"`
+	ShiftTypeID     uuid.UUID  `bun:"shift_type_id,type:uuid,notnull"`
+	Reason          string     `bun:"reason,notnull"`
+	Status          string     `bun:"status,notnull"`
+	RequestedBy     string     `bun:"requested_by,notnull"`
+	DecidedBy       string     `bun:"decided_by,notnull"`
+	DecidedAt       *time.Time `bun:"decided_at"`
+	DecisionComment string     `bun:"decision_comment,notnull"`
+	PlacedDates     []string   `bun:"placed_dates,type:jsonb,notnull"`
+	CreatedAt       time.Time  `bun:"created_at,notnull"`
+	UpdatedAt       time.Time  `bun:"updated_at,notnull"`
+}
+
+// ToDomain DBモデルからドメインエンティティへ変換
+func (m *LeaveRequestModel) ToDomain() *domain.LeaveRequest {
+	return &domain.LeaveRequest{
+		ID:              m.ID,
+		OrganizationID:  m.OrganizationID,
+		StaffID:         m.StaffID,
+		StartDate:       m.StartDate,
+		EndDate:         m.EndDate,
+		ExcludedDates:   parseDates(m.ExcludedDates),
+		ShiftTypeID:     m.ShiftTypeID,
+		Reason:          m.Reason,
+		Status:          domain.LeaveRequestStatus(m.Status),
+		RequestedBy:     m.RequestedBy,
+		DecidedBy:       m.DecidedBy,
+		DecidedAt:       m.DecidedAt,
+		DecisionComment: m.DecisionComment,
+		PlacedDates:     parseDates(m.PlacedDates),
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}
+}
+
+// parseDates YYYY-MM-DD形式の日付一覧を変換 不正な値は無視
+func parseDates(values []string) []time.Time {
+	dates := make([]time.Time, 0, len(values))
+	for _, s := range values {
+		d, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			continue
+		}
+		dates = append(dates, d)
+	}
+	return dates
+}
+
+// formatDates 日付一覧をYYYY-MM-DD形式に変換
+func formatDates(dates []time.Time) []string {
+	values := make([]string, len(dates))
+	for i, d := range dates {
+		values[i] = d.Format("2006-01-02")
+	}
+	return values
+}
+
+// FromDomain ドメインエンティティからDBモデルへ変換
+func (m *LeaveRequestModel) FromDomain(r *domain.LeaveRequest) {
+	m.ID = r.ID
+	m.OrganizationID = r.OrganizationID
+	m.StaffID = r.StaffID
+	m.StartDate = r.StartDate
+	m.EndDate = r.EndDate
+	m.ExcludedDates = formatDates(r.ExcludedDates)
+	m.ShiftTypeID = r.ShiftTypeID
+	m.Reason = r.Reason
+	m.Status = r.Status.String()
+	m.RequestedBy = r.RequestedBy
+	m.DecidedBy = r.DecidedBy
+	m.DecidedAt = r.DecidedAt
+	m.DecisionComment = r.DecisionComment
+	m.CreatedAt = r.CreatedAt
+	m.UpdatedAt = r.UpdatedAt
+	m.PlacedDates = formatDates(r.PlacedDates)
+}
+
+// PostgresLeaveRequestRepository PostgreSQL休暇申請リポジトリ
+type PostgresLeaveRequestRepository struct {
+	db *bun.DB
+}
+
+// NewPostgresLeaveRequestRepository リポジトリ生成
+func NewPostgresLeaveRequestRepository(db *bun.DB) *PostgresLeaveRequestRepository {
+	return &PostgresLeaveRequestRepository{db: db}
+}
+
+// FindByID IDで検索
+func (r *PostgresLeaveRequestRepository) FindByID(ctx context.Context, id sharedDomain.ID) (*domain.LeaveRequest, error) {
+	model := &LeaveRequestModel{}
+	err := r.db.NewSelect().Model(model).Where("id = ?", id).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.ToDomain(), nil
+}
+
+// FindByOrganizationID 組織の休暇申請一覧 新しい順
+func (r *PostgresLeaveRequestRepository) FindByOrganizationID(ctx context.Context, organizationID sharedDomain.ID, status domain.LeaveRequestStatus) ([]domain.LeaveRequest, error) {
+	var models []LeaveRequestModel
+	query := r.db.NewSelect().
+		Model(&models).
+		Where("organization_id = ?", organizationID).
+		Order("start_date DESC", "created_at DESC")
+	if status != "" {
+		query = query.Where("status = ?", status.String())
+	}
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+	return requestsToDomain(models), nil
+}
+
+// FindByStaffID スタッフの休暇申請一覧 新しい順
+func (r *PostgresLeaveRequestRepository) FindByStaffID(ctx context.Context, staffID sharedDomain.ID) ([]domain.LeaveRequest, error) {
+	var models []LeaveRequestModel
+	err := r.db.NewSelect().
+		Model(&models).
+		Where("staff_id = ?", staffID).
+		Order("start_date DESC", "created_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return requestsToDomain(models), nil
+}
+
+// Save 保存
+func (r *PostgresLeaveRequestRepository) Save(ctx context.Context, request *domain.LeaveRequest) error {
+	model := &LeaveRequestModel{}
+	model.FromDomain(request)
+
+	_, err := r.db.NewInsert().
+		Model(model).
+		On("CONFLICT (id) DO UPDATE").
+		Set("status = EXCLUDED.status").
+		Set("decided_by = EXCLUDED.decided_by").
+		Set("decided_at = EXCLUDED.decided_at").
+		Set("decision_comment = EXCLUDED.decision_comment").
+		Set("placed_dates = EXCLUDED.placed_dates").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+	return err
+}
+
+// requestsToDomain DBモデル一覧をドメインエンティティへ変換
+func requestsToDomain(models []LeaveRequestModel) []domain.LeaveRequest {
+	requests := make([]domain.LeaveRequest, len(models))
+	for i := range models {
+		requests[i] = *models[i].ToDomain()
+	}
+	return requests
+}
@@ -0,0 +1,540 @@
+// Package presentation 休暇管理プレゼンテーション層
+package presentation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"html"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"shiftmaster/internal/modules/leave/application"
+	sharedDomain "shiftmaster/internal/shared/domain"
+	"shiftmaster/internal/web"
+)
+
+// LeaveHandler 休暇管理HTTPハンドラー
+type LeaveHandler struct {
+	useCase   *application.LeaveUseCase
+	templates *web.TemplateEngine
+	logger    *slog.Logger
+}
+
+// NewLeaveHandler ハンドラー生成
+func NewLeaveHandler(useCase *application.LeaveUseCase, templates *web.TemplateEngine, logger *slog.Logger) *LeaveHandler {
+	return &LeaveHandler{
+		useCase:   useCase,
+		templates: templates,
+		logger:    logger,
+	}
+}
+
+// leaveRequestAction 休暇申請の操作
+type leaveRequestAction func(ctx context.Context, input *application.LeaveRequestActionInput) (*application.LeaveRequestOutput, error)
+
+// Balances 有給休暇残高一覧ページ
+func (h *LeaveHandler) Balances(w http.ResponseWriter, r *http.Request) {
+	balances, err := h.useCase.ListBalances(r.Context(), h.getOrganizationID(r))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	claims := web.GetClaimsFromContext(r.Context())
+	data := map[string]any{
+		"Title":     "休暇管理",
+		"Balances":  balances,
+		"CanManage": claims != nil && claims.IsManager(),
+	}
+	if err := h.templates.Render(w, "pages/leave/balances.html", data); err != nil {
+		h.logger.Error("テンプレートレンダリング失敗", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// MandatoryLeave 年5日の取得義務の取得状況ページ warning=1の場合は警告対象のみ
+func (h *LeaveHandler) MandatoryLeave(w http.ResponseWriter, r *http.Request) {
+	warningOnly := r.URL.Query().Get("warning") == "1"
+
+	items, err := h.useCase.ListMandatoryLeave(r.Context(), h.getOrganizationID(r), warningOnly)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	data := map[string]any{
+		"Title":       "年5日の取得義務",
+		"Items":       items,
+		"WarningOnly": warningOnly,
+	}
+	if err := h.templates.Render(w, "pages/leave/mandatory.html", data); err != nil {
+		h.logger.Error("テンプレートレンダリング失敗", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// Ledger スタッフの有給休暇台帳ページ
+func (h *LeaveHandler) Ledger(w http.ResponseWriter, r *http.Request) {
+	ledger, err := h.useCase.GetLedger(r.Context(), h.getOrganizationID(r), r.PathValue("staff_id"))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	claims := web.GetClaimsFromContext(r.Context())
+	data := map[string]any{
+		"Title":     ledger.Balance.StaffName + "の有給休暇",
+		"Ledger":    ledger,
+		"Today":     time.Now().Format("2006-01-02"),
+		"CanManage": claims != nil && claims.IsManager(),
+	}
+	if err := h.templates.Render(w, "pages/leave/ledger.html", data); err != nil {
+		h.logger.Error("テンプレートレンダリング失敗", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// Accrue 法定付与を台帳に登録
+func (h *LeaveHandler) Accrue(w http.ResponseWriter, r *http.Request) {
+	if _, err := h.useCase.Accrue(r.Context(), h.getOrganizationID(r)); err != nil {
+		h.handleFormError(w, r, err)
+		return
+	}
+
+	h.redirect(w, r, "/leave")
+}
+
+// AddGrant 手動付与登録
+func (h *LeaveHandler) AddGrant(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	input := &application.AddLeaveGrantInput{
+		OrganizationID: h.getOrganizationID(r),
+		StaffID:        r.PathValue("staff_id"),
+		GrantDate:      r.FormValue("grant_date"),
+		Days:           r.FormValue("days"),
+		Note:           r.FormValue("note"),
+		UserEmail:      h.getUserEmail(r),
+	}
+
+	ledger, err := h.useCase.AddGrant(r.Context(), input)
+	if err != nil {
+		h.handleFormError(w, r, err)
+		return
+	}
+
+	h.redirect(w, r, "/leave/staffs/"+ledger.Balance.StaffID)
+}
+
+// Requests 休暇申請一覧ページ 既定は申請中のみ
+func (h *LeaveHandler) Requests(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	if !r.URL.Query().Has("status") {
+		status = "pending"
+	}
+
+	requests, err := h.useCase.ListRequests(r.Context(), h.getOrganizationID(r), status)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	data := map[string]any{
+		"Title":    "休暇申請",
+		"Requests": requests,
+		"Status":   status,
+	}
+	if err := h.templates.Render(w, "pages/leave/requests.html", data); err != nil {
+		h.logger.Error("テンプレートレンダリング失敗", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// NewRequest 休暇申請フォーム
+func (h *LeaveHandler) NewRequest(w http.ResponseWriter, r *http.Request) {
+	orgID := h.getOrganizationID(r)
+	balances, err := h.useCase.ListBalances(r.Context(), orgID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	shiftTypes, err := h.useCase.LeaveShiftTypes(r.Context(), orgID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	data := map[string]any{
+		"Title":      "休暇申請",
+		"Balances":   balances,
+		"ShiftTypes": shiftTypes,
+		"StaffID":    r.URL.Query().Get("staff_id"),
+		"Today":      time.Now().Format("2006-01-02"),
+	}
+	if err := h.templates.Render(w, "pages/leave/request_form.html", data); err != nil {
+		h.logger.Error("テンプレートレンダリング失敗", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// CreateRequest 休暇申請
+func (h *LeaveHandler) CreateRequest(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	input := &application.CreateLeaveRequestInput{
+		OrganizationID: h.getOrganizationID(r),
+		StaffID:        r.FormValue("staff_id"),
+		StartDate:      r.FormValue("start_date"),
+		EndDate:        r.FormValue("end_date"),
+		ExcludedDates:  splitDates(r.Form["excluded_dates"]),
+		ShiftTypeID:    r.FormValue("shift_type_id"),
+		Reason:         r.FormValue("reason"),
+		UserEmail:      h.getUserEmail(r),
+	}
+
+	request, err := h.useCase.Request(r.Context(), input)
+	if err != nil {
+		h.handleFormError(w, r, err)
+		return
+	}
+
+	h.redirect(w, r, "/leave/requests/"+request.ID)
+}
+
+// ShowRequest 休暇申請詳細ページ
+func (h *LeaveHandler) ShowRequest(w http.ResponseWriter, r *http.Request) {
+	request, err := h.useCase.GetRequest(r.Context(), h.getOrganizationID(r), r.PathValue("id"))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	claims := web.GetClaimsFromContext(r.Context())
+	data := map[string]any{
+		"Title":     "休暇申請",
+		"Request":   request,
+		"CanManage": claims != nil && claims.IsManager(),
+	}
+	if err := h.templates.Render(w, "pages/leave/request_show.html", data); err != nil {
+		h.logger.Error("テンプレートレンダリング失敗", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// Approve 管理者が承認
+func (h *LeaveHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	h.act(w, r, h.useCase.Approve)
+}
+
+// Reject 管理者が却下
+func (h *LeaveHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	h.act(w, r, h.useCase.Reject)
+}
+
+// Cancel 取り消し
+func (h *LeaveHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	h.act(w, r, h.useCase.Cancel)
+}
+
+// Place 管理者が未配置の日を勤務表に配置
+func (h *LeaveHandler) Place(w http.ResponseWriter, r *http.Request) {
+	h.act(w, r, h.useCase.Place)
+}
+
+// BalancesJSON 有給休暇残高一覧JSON
+func (h *LeaveHandler) BalancesJSON(w http.ResponseWriter, r *http.Request) {
+	balances, err := h.useCase.ListBalances(r.Context(), h.getOrganizationID(r))
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, balances)
+}
+
+// MandatoryLeaveJSON 年5日の取得義務の取得状況一覧API warning=1の場合は警告対象のみ
+func (h *LeaveHandler) MandatoryLeaveJSON(w http.ResponseWriter, r *http.Request) {
+	items, err := h.useCase.ListMandatoryLeave(r.Context(), h.getOrganizationID(r), r.URL.Query().Get("warning") == "1")
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, items)
+}
+
+// LedgerJSON スタッフの有給休暇台帳JSON
+func (h *LeaveHandler) LedgerJSON(w http.ResponseWriter, r *http.Request) {
+	ledger, err := h.useCase.GetLedger(r.Context(), h.getOrganizationID(r), r.PathValue("staff_id"))
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, ledger)
+}
+
+// AccrueJSON 法定付与の登録JSON
+func (h *LeaveHandler) AccrueJSON(w http.ResponseWriter, r *http.Request) {
+	output, err := h.useCase.Accrue(r.Context(), h.getOrganizationID(r))
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, output)
+}
+
+// AddGrantJSON 手動付与登録JSON
+func (h *LeaveHandler) AddGrantJSON(w http.ResponseWriter, r *http.Request) {
+	var input application.AddLeaveGrantInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "リクエストボディが不正です"})
+		return
+	}
+	input.OrganizationID = h.getOrganizationID(r)
+	input.StaffID = r.PathValue("staff_id")
+	input.UserEmail = h.getUserEmail(r)
+
+	ledger, err := h.useCase.AddGrant(r.Context(), &input)
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, ledger)
+}
+
+// RequestsJSON 休暇申請一覧JSON statusが空の場合は全件
+func (h *LeaveHandler) RequestsJSON(w http.ResponseWriter, r *http.Request) {
+	requests, err := h.useCase.ListRequests(r.Context(), h.getOrganizationID(r), r.URL.Query().Get("status"))
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, requests)
+}
+
+// ShowRequestJSON 休暇申請詳細JSON
+func (h *LeaveHandler) ShowRequestJSON(w http.ResponseWriter, r *http.Request) {
+	request, err := h.useCase.GetRequest(r.Context(), h.getOrganizationID(r), r.PathValue("id"))
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, request)
+}
+
+// CreateRequestJSON 休暇申請JSON
+func (h *LeaveHandler) CreateRequestJSON(w http.ResponseWriter, r *http.Request) {
+	var input application.CreateLeaveRequestInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "リクエストボディが不正です"})
+		return
+	}
+	input.OrganizationID = h.getOrganizationID(r)
+	input.UserEmail = h.getUserEmail(r)
+
+	request, err := h.useCase.Request(r.Context(), &input)
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, request)
+}
+
+// ApproveJSON 承認JSON
+func (h *LeaveHandler) ApproveJSON(w http.ResponseWriter, r *http.Request) {
+	h.actJSON(w, r, h.useCase.Approve)
+}
+
+// RejectJSON 却下JSON
+func (h *LeaveHandler) RejectJSON(w http.ResponseWriter, r *http.Request) {
+	h.actJSON(w, r, h.useCase.Reject)
+}
+
+// CancelJSON 取り消しJSON
+func (h *LeaveHandler) CancelJSON(w http.ResponseWriter, r *http.Request) {
+	h.actJSON(w, r, h.useCase.Cancel)
+}
+
+// PlaceJSON 勤務表への配置JSON
+func (h *LeaveHandler) PlaceJSON(w http.ResponseWriter, r *http.Request) {
+	h.actJSON(w, r, h.useCase.Place)
+}
+
+// act フォームからの休暇申請操作 成功時は詳細ページへ
+func (h *LeaveHandler) act(w http.ResponseWriter, r *http.Request, action leaveRequestAction) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	input := &application.LeaveRequestActionInput{
+		OrganizationID: h.getOrganizationID(r),
+		RequestID:      r.PathValue("id"),
+		UserEmail:      h.getUserEmail(r),
+		Comment:        r.FormValue("comment"),
+	}
+
+	request, err := action(r.Context(), input)
+	if err != nil {
+		h.handleFormError(w, r, err)
+		return
+	}
+
+	h.redirect(w, r, "/leave/requests/"+request.ID)
+}
+
+// actJSON JSONでの休暇申請操作 ボディは省略可
+func (h *LeaveHandler) actJSON(w http.ResponseWriter, r *http.Request, action leaveRequestAction) {
+	var input application.LeaveRequestActionInput
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "リクエストボディが不正です"})
+			return
+		}
+	}
+	input.OrganizationID = h.getOrganizationID(r)
+	input.RequestID = r.PathValue("id")
+	input.UserEmail = h.getUserEmail(r)
+
+	request, err := action(r.Context(), &input)
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, request)
+}
+
+// splitDates カンマ・空白・改行区切りの日付入力を分割
+func splitDates(values []string) []string {
+	var dates []string
+	for _, v := range values {
+		dates = append(dates, strings.FieldsFunc(v, func(r rune) bool {
+			return r == ',' || r == '、' || r == ' ' || r == '\n' || r == '\r' || r == '\t' || r == '　'
+		})...)
+	}
+	return dates
+}
+
+// getOrganizationID コンテキストから組織IDを取得
+func (h *LeaveHandler) getOrganizationID(r *http.Request) string {
+	claims := web.GetClaimsFromContext(r.Context())
+	if claims != nil && claims.OrganizationID != nil {
+		return claims.OrganizationID.String()
+	}
+	return ""
+}
+
+// getUserEmail コンテキストから操作ユーザーのメールアドレスを取得
+func (h *LeaveHandler) getUserEmail(r *http.Request) string {
+	if claims := web.GetClaimsFromContext(r.Context()); claims != nil {
+		return claims.Email
+	}
+	return ""
+}
+
+// redirect 指定ページへリダイレクト HTMX対応
+func (h *LeaveHandler) redirect(w http.ResponseWriter, r *http.Request, redirectTo string) {
+	if isHTMXRequest(r) {
+		w.Header().Set("HX-Redirect", redirectTo)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	http.Redirect(w, r, redirectTo, http.StatusSeeOther)
+}
+
+// handleFormError フォーム送信エラーハンドリング 検証エラーと競合はフォーム上に表示
+func (h *LeaveHandler) handleFormError(w http.ResponseWriter, r *http.Request, err error) {
+	var domainErr *sharedDomain.DomainError
+	if isHTMXRequest(r) && errors.As(err, &domainErr) &&
+		(domainErr.Code == sharedDomain.ErrCodeValidation || domainErr.Code == sharedDomain.ErrCodeConflict) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`<p class="text-sm text-red-400">` + html.EscapeString(domainErr.Message) + `</p>`))
+		return
+	}
+
+	h.handleError(w, err)
+}
+
+// handleError エラーハンドリング
+func (h *LeaveHandler) handleError(w http.ResponseWriter, err error) {
+	if errors.Is(err, sharedDomain.ErrNotFound) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+
+	var domainErr *sharedDomain.DomainError
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code {
+		case sharedDomain.ErrCodeNotFound:
+			http.Error(w, domainErr.Message, http.StatusNotFound)
+			return
+		case sharedDomain.ErrCodeValidation:
+			http.Error(w, domainErr.Message, http.StatusBadRequest)
+			return
+		case sharedDomain.ErrCodeConflict:
+			http.Error(w, domainErr.Message, http.StatusConflict)
+			return
+		}
+	}
+
+	h.logger.Error("ハンドラーエラー", "error", err)
+	http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+}
+
+// handleJSONError JSONエラーハンドリング
+func (h *LeaveHandler) handleJSONError(w http.ResponseWriter, err error) {
+	if errors.Is(err, sharedDomain.ErrNotFound) {
+		h.writeJSON(w, http.StatusNotFound, map[string]string{"error": "見つかりません"})
+		return
+	}
+
+	var domainErr *sharedDomain.DomainError
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code {
+		case sharedDomain.ErrCodeNotFound:
+			h.writeJSON(w, http.StatusNotFound, map[string]string{"error": domainErr.Message})
+			return
+		case sharedDomain.ErrCodeValidation:
+			h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": domainErr.Message})
+			return
+		case sharedDomain.ErrCodeConflict:
+			h.writeJSON(w, http.StatusConflict, map[string]string{"error": domainErr.Message})
+			return
+		}
+	}
+
+	h.logger.Error("ハンドラーエラー", "error", err)
+	h.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "内部エラーが発生しました"})
+}
+
+// writeJSON JSONレスポンス書き込み
+func (h *LeaveHandler) writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		h.logger.Error("JSONエンコード失敗", "error", err)
+	}
+}
+
+// isHTMXRequest HTMXリクエスト判定
+func isHTMXRequest(r *http.Request) bool {
+	return r.Header.Get("HX-Request") == "true"
+}
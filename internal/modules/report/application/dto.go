@@ -28,6 +28,9 @@ func (i *GenerateReportInput) Validate() error {
 	if i.Type == "" {
 		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "レポート種別は必須です")
 	}
+	if !domain.ReportType(i.Type).IsValid() {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "レポート種別が不正です")
+	}
 	if i.TargetYear < 2020 || i.TargetYear > 2100 {
 		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "対象年が不正です")
 	}
@@ -57,10 +60,12 @@ type ReportOutput struct {
 	Status string `json:"status"`
 	// StatusLabel 状態ラベル
 	StatusLabel string `json:"status_label"`
+	// InProgress 生成待ち・生成中か
+	InProgress bool `json:"in_progress"`
+	// Downloadable ダウンロード可能か
+	Downloadable bool `json:"downloadable"`
 	// GeneratedAt 生成日時
 	GeneratedAt string `json:"generated_at"`
-	// FilePath ファイルパス
-	FilePath string `json:"file_path"`
 	// CreatedAt 作成日時
 	CreatedAt string `json:"created_at"`
 	// UpdatedAt 更新日時
@@ -84,13 +89,24 @@ func ToReportOutput(r *domain.Report) *ReportOutput {
 		TargetMonth:    r.TargetMonth,
 		Status:         r.Status.String(),
 		StatusLabel:    r.Status.Label(),
+		InProgress:     r.Status.IsInProgress(),
+		Downloadable:   r.IsDownloadable(),
 		GeneratedAt:    generatedAt,
-		FilePath:       r.FilePath,
 		CreatedAt:      r.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:      r.UpdatedAt.Format(time.RFC3339),
 	}
 }
 
+// ReportFileOutput レポートファイル出力
+type ReportFileOutput struct {
+	// FileName ダウンロード時のファイル名
+	FileName string
+	// ContentType MIMEタイプ
+	ContentType string
+	// Data ファイル内容
+	Data []byte
+}
+
 // MonthlySummaryOutput 月次集計出力
 type MonthlySummaryOutput struct {
 	// TargetYear 対象年
@@ -129,6 +145,14 @@ type StaffSummaryOutput struct {
 	HolidayCount int `json:"holiday_count"`
 	// OvertimeHours 残業時間
 	OvertimeHours float64 `json:"overtime_hours"`
+	// WithinLegalOvertimeHours 法定内残業時間
+	WithinLegalOvertimeHours float64 `json:"within_legal_overtime_hours"`
+	// OverLegalOvertimeHours 法定外残業時間
+	OverLegalOvertimeHours float64 `json:"over_legal_overtime_hours"`
+	// LateNightHours 深夜労働時間
+	LateNightHours float64 `json:"late_night_hours"`
+	// HolidayWorkHours 休日労働時間
+	HolidayWorkHours float64 `json:"holiday_work_hours"`
 	// PaidLeaveUsed 有給休暇使用日数
 	PaidLeaveUsed float64 `json:"paid_leave_used"`
 }
@@ -136,14 +160,18 @@ type StaffSummaryOutput struct {
 // ToStaffSummaryOutput ドメインエンティティから出力DTOへ変換
 func ToStaffSummaryOutput(s *domain.StaffSummary) *StaffSummaryOutput {
 	return &StaffSummaryOutput{
-		StaffID:         s.StaffID.String(),
-		StaffName:       s.StaffName,
-		TotalWorkDays:   s.TotalWorkDays,
-		TotalWorkHours:  s.TotalWorkHours(),
-		NightShiftCount: s.NightShiftCount,
-		HolidayCount:    s.HolidayCount,
-		OvertimeHours:   s.OvertimeHours(),
-		PaidLeaveUsed:   s.PaidLeaveUsed,
+		StaffID:                  s.StaffID.String(),
+		StaffName:                s.StaffName,
+		TotalWorkDays:            s.TotalWorkDays,
+		TotalWorkHours:           s.TotalWorkHours(),
+		NightShiftCount:          s.NightShiftCount,
+		HolidayCount:             s.HolidayCount,
+		OvertimeHours:            s.OvertimeHours(),
+		WithinLegalOvertimeHours: float64(s.WithinLegalOvertimeMinutes) / 60.0,
+		OverLegalOvertimeHours:   float64(s.OverLegalOvertimeMinutes) / 60.0,
+		LateNightHours:           float64(s.LateNightMinutes) / 60.0,
+		HolidayWorkHours:         float64(s.HolidayWorkMinutes) / 60.0,
+		PaidLeaveUsed:            s.PaidLeaveUsed,
 	}
 }
 
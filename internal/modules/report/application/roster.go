@@ -0,0 +1,95 @@
+// Package application レポートアプリケーション層
+package application
+
+import (
+	"context"
+	"log/slog"
+
+	"shiftmaster/internal/modules/report/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// ExportRosterInput 掲示用勤務表出力入力
+type ExportRosterInput struct {
+	// OrganizationID 組織ID
+	OrganizationID string `json:"organization_id"`
+	// ScheduleID 勤務表ID
+	ScheduleID string `json:"schedule_id"`
+	// PaperSize 用紙サイズ 未指定はA4
+	PaperSize string `json:"paper_size"`
+}
+
+// Validate 入力検証
+func (i *ExportRosterInput) Validate() error {
+	if i.OrganizationID == "" {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDは必須です")
+	}
+	if i.PaperSize != "" && !domain.PaperSize(i.PaperSize).IsValid() {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "用紙サイズが不正です")
+	}
+	return nil
+}
+
+// RosterExportUseCase 掲示用勤務表出力ユースケース
+type RosterExportUseCase struct {
+	rosterRepo domain.RosterRepository
+	renderer   domain.RosterRenderer
+	logger     *slog.Logger
+}
+
+// NewRosterExportUseCase 掲示用勤務表出力ユースケース生成
+func NewRosterExportUseCase(
+	rosterRepo domain.RosterRepository,
+	renderer domain.RosterRenderer,
+	logger *slog.Logger,
+) *RosterExportUseCase {
+	return &RosterExportUseCase{
+		rosterRepo: rosterRepo,
+		renderer:   renderer,
+		logger:     logger,
+	}
+}
+
+// ExportPDF 公開済み勤務表のPDF出力 他組織の勤務表は存在しないものとして扱う
+func (u *RosterExportUseCase) ExportPDF(ctx context.Context, input *ExportRosterInput) (*ReportFileOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	orgID, err := sharedDomain.ParseID(input.OrganizationID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDが不正です")
+	}
+	scheduleID, err := sharedDomain.ParseID(input.ScheduleID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "勤務表IDが不正です")
+	}
+
+	roster, err := u.rosterRepo.FindByScheduleID(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if roster == nil || roster.OrganizationID != orgID {
+		return nil, sharedDomain.ErrNotFound
+	}
+	if !roster.IsPublished() {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "公開済みの勤務表のみ出力できます")
+	}
+
+	paper := domain.PaperSize(input.PaperSize)
+	if paper == "" {
+		paper = domain.PaperSizeA4
+	}
+
+	data, err := u.renderer.RenderPDF(roster, paper)
+	if err != nil {
+		u.logger.Error("勤務表PDF出力失敗", "error", err, "schedule_id", scheduleID)
+		return nil, err
+	}
+
+	return &ReportFileOutput{
+		FileName:    "勤務表 " + roster.TargetPeriodLabel() + ".pdf",
+		ContentType: "application/pdf",
+		Data:        data,
+	}, nil
+}
@@ -0,0 +1,96 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"shiftmaster/internal/modules/report/domain"
+	scheduleDomain "shiftmaster/internal/modules/schedule/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// モック掲示用勤務表リポジトリ
+
+type mockRosterRepository struct {
+	rosters map[sharedDomain.ID]*domain.Roster
+}
+
+func (m *mockRosterRepository) FindByScheduleID(_ context.Context, scheduleID sharedDomain.ID) (*domain.Roster, error) {
+	return m.rosters[scheduleID], nil
+}
+
+// モックPDF出力
+
+type mockRosterRenderer struct {
+	paper domain.PaperSize
+}
+
+func (m *mockRosterRenderer) RenderPDF(_ *domain.Roster, paper domain.PaperSize) ([]byte, error) {
+	m.paper = paper
+	return []byte("%PDF"), nil
+}
+
+func TestRosterExportUseCase_ExportPDF(t *testing.T) {
+	orgID := sharedDomain.NewID()
+	published := &domain.Roster{ScheduleID: sharedDomain.NewID(), OrganizationID: orgID, TargetYear: 2025, TargetMonth: 5, Status: scheduleDomain.StatusPublished}
+	draft := &domain.Roster{ScheduleID: sharedDomain.NewID(), OrganizationID: orgID, TargetYear: 2025, TargetMonth: 6, Status: scheduleDomain.StatusDraft}
+	otherOrg := &domain.Roster{ScheduleID: sharedDomain.NewID(), OrganizationID: sharedDomain.NewID(), TargetYear: 2025, TargetMonth: 5, Status: scheduleDomain.StatusPublished}
+
+	repo := &mockRosterRepository{rosters: map[sharedDomain.ID]*domain.Roster{
+		published.ScheduleID: published,
+		draft.ScheduleID:     draft,
+		otherOrg.ScheduleID:  otherOrg,
+	}}
+	renderer := &mockRosterRenderer{}
+	useCase := NewRosterExportUseCase(repo, renderer, slog.New(slog.NewTextHandler(os.Stderr, nil)))
+
+	tests := []struct {
+		name       string
+		scheduleID sharedDomain.ID
+		paper      string
+		wantCode   string
+		notFound   bool
+		wantPaper  domain.PaperSize
+	}{
+		{name: "公開済み 用紙未指定はA4", scheduleID: published.ScheduleID, wantPaper: domain.PaperSizeA4},
+		{name: "公開済み A3", scheduleID: published.ScheduleID, paper: "a3", wantPaper: domain.PaperSizeA3},
+		{name: "不正な用紙サイズ", scheduleID: published.ScheduleID, paper: "b4", wantCode: sharedDomain.ErrCodeValidation},
+		{name: "未公開", scheduleID: draft.ScheduleID, wantCode: sharedDomain.ErrCodeValidation},
+		{name: "他組織", scheduleID: otherOrg.ScheduleID, notFound: true},
+		{name: "存在しない", scheduleID: sharedDomain.NewID(), notFound: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := useCase.ExportPDF(context.Background(), &ExportRosterInput{
+				OrganizationID: orgID.String(),
+				ScheduleID:     tt.scheduleID.String(),
+				PaperSize:      tt.paper,
+			})
+			switch {
+			case tt.notFound:
+				if !errors.Is(err, sharedDomain.ErrNotFound) {
+					t.Errorf("ExportPDF() error = %v, want ErrNotFound", err)
+				}
+			case tt.wantCode != "":
+				var domainErr *sharedDomain.DomainError
+				if !errors.As(err, &domainErr) || domainErr.Code != tt.wantCode {
+					t.Errorf("ExportPDF() error = %v, want code %s", err, tt.wantCode)
+				}
+			default:
+				if err != nil {
+					t.Fatalf("ExportPDF() error = %v", err)
+				}
+				if file.FileName != "勤務表 2025年5月.pdf" || file.ContentType != "application/pdf" {
+					t.Errorf("file = %q %q", file.FileName, file.ContentType)
+				}
+				if renderer.paper != tt.wantPaper {
+					t.Errorf("paper = %q, want %q", renderer.paper, tt.wantPaper)
+				}
+			}
+		})
+	}
+}
@@ -5,17 +5,26 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"mime"
+	"path"
 	"time"
 
 	"shiftmaster/internal/modules/report/domain"
 	sharedDomain "shiftmaster/internal/shared/domain"
 )
 
+// reportContentTypes 拡張子ごとのMIMEタイプ 環境のMIME設定に依存しないよう明示
+var reportContentTypes = map[string]string{
+	".csv":  "text/csv; charset=utf-8",
+	".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
 // ReportUseCase レポートユースケース
 type ReportUseCase struct {
 	reportRepo  domain.ReportRepository
 	summaryRepo domain.SummaryRepository
 	generator   domain.ReportGenerator
+	storage     domain.ReportStorage
 	logger      *slog.Logger
 }
 
@@ -24,12 +33,14 @@ func NewReportUseCase(
 	reportRepo domain.ReportRepository,
 	summaryRepo domain.SummaryRepository,
 	generator domain.ReportGenerator,
+	storage domain.ReportStorage,
 	logger *slog.Logger,
 ) *ReportUseCase {
 	return &ReportUseCase{
 		reportRepo:  reportRepo,
 		summaryRepo: summaryRepo,
 		generator:   generator,
+		storage:     storage,
 		logger:      logger,
 	}
 }
@@ -66,11 +77,12 @@ func (u *ReportUseCase) Generate(ctx context.Context, input *GenerateReportInput
 		return nil, err
 	}
 
-	// 非同期で生成処理 実際の実装ではgoroutineやジョブキュー使用
+	// 生成処理で更新される前に出力へ変換してから非同期で生成
+	output := ToReportOutput(report)
 	go u.generateAsync(context.Background(), report)
 
 	u.logger.Info("レポート生成開始", "report_id", report.ID)
-	return ToReportOutput(report), nil
+	return output, nil
 }
 
 // generateAsync 非同期レポート生成
@@ -83,10 +95,10 @@ func (u *ReportUseCase) generateAsync(ctx context.Context, report *domain.Report
 		return
 	}
 
-	var err error
-	switch report.Type {
-	case domain.ReportTypeSummary:
-		_, err = u.summaryRepo.GetMonthlySummary(ctx, report.OrganizationID, report.TargetYear, report.TargetMonth)
+	data, err := u.render(ctx, report)
+	if err == nil {
+		name := fmt.Sprintf("%s/%s%s", report.OrganizationID, report.ID, u.generator.FileExtension(report.Type))
+		report.FilePath, err = u.storage.Save(ctx, name, data)
 	}
 
 	now := time.Now()
@@ -105,6 +117,36 @@ func (u *ReportUseCase) generateAsync(ctx context.Context, report *domain.Report
 	}
 }
 
+// render レポート種別に応じてファイル内容を生成
+func (u *ReportUseCase) render(ctx context.Context, report *domain.Report) ([]byte, error) {
+	switch report.Type {
+	case domain.ReportTypeSchedule:
+		scheduleID, err := u.summaryRepo.FindScheduleID(ctx, report.OrganizationID, report.TargetYear, report.TargetMonth)
+		if err != nil {
+			return nil, err
+		}
+		if scheduleID == nil {
+			return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeNotFound, "対象年月の勤務表がありません")
+		}
+		return u.generator.GenerateScheduleReport(ctx, *scheduleID)
+	case domain.ReportTypeSummary:
+		summary, err := u.summaryRepo.GetMonthlySummary(ctx, report.OrganizationID, report.TargetYear, report.TargetMonth)
+		if err != nil {
+			return nil, err
+		}
+		if summary == nil {
+			return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeNotFound, "対象年月の勤務表がありません")
+		}
+		return u.generator.GenerateSummaryReport(ctx, summary)
+	case domain.ReportTypeActual:
+		return u.generator.GenerateActualReport(ctx, report.OrganizationID, report.TargetYear, report.TargetMonth)
+	case domain.ReportTypeStaffList:
+		return u.generator.GenerateStaffListReport(ctx, report.OrganizationID)
+	default:
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "レポート種別が不正です")
+	}
+}
+
 // GetByID IDでレポート取得
 func (u *ReportUseCase) GetByID(ctx context.Context, id string) (*ReportOutput, error) {
 	reportID, err := sharedDomain.ParseID(id)
@@ -123,6 +165,46 @@ func (u *ReportUseCase) GetByID(ctx context.Context, id string) (*ReportOutput,
 	return ToReportOutput(report), nil
 }
 
+// Download レポートファイル取得 生成完了したレポートのみ
+func (u *ReportUseCase) Download(ctx context.Context, id string) (*ReportFileOutput, error) {
+	reportID, err := sharedDomain.ParseID(id)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "IDが不正です")
+	}
+
+	report, err := u.reportRepo.FindByID(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return nil, sharedDomain.ErrNotFound
+	}
+	if !report.IsDownloadable() {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "レポートはまだ生成されていません")
+	}
+
+	data, err := u.storage.Load(ctx, report.FilePath)
+	if err != nil {
+		u.logger.Error("レポートファイル読み込み失敗", "error", err, "report_id", reportID)
+		return nil, err
+	}
+
+	ext := path.Ext(report.FilePath)
+	contentType, ok := reportContentTypes[ext]
+	if !ok {
+		contentType = mime.TypeByExtension(ext)
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return &ReportFileOutput{
+		FileName:    report.Title + ext,
+		ContentType: contentType,
+		Data:        data,
+	}, nil
+}
+
 // List レポート一覧取得
 func (u *ReportUseCase) List(ctx context.Context, organizationID string) ([]ReportOutput, error) {
 	orgID, err := sharedDomain.ParseID(organizationID)
@@ -163,6 +245,13 @@ func (u *ReportUseCase) Delete(ctx context.Context, id string) error {
 		return err
 	}
 
+	// ファイル削除の失敗はレポート削除を妨げない
+	if report.FilePath != "" {
+		if err := u.storage.Delete(ctx, report.FilePath); err != nil {
+			u.logger.Error("レポートファイル削除失敗", "error", err, "report_id", reportID)
+		}
+	}
+
 	u.logger.Info("レポート削除完了", "report_id", reportID)
 	return nil
 }
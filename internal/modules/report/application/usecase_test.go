@@ -0,0 +1,256 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"shiftmaster/internal/modules/report/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// モックレポートリポジトリ
+
+type mockReportRepository struct {
+	reports map[sharedDomain.ID]*domain.Report
+}
+
+func (m *mockReportRepository) FindByID(_ context.Context, id sharedDomain.ID) (*domain.Report, error) {
+	return m.reports[id], nil
+}
+
+func (m *mockReportRepository) FindByOrganizationID(_ context.Context, organizationID sharedDomain.ID) ([]domain.Report, error) {
+	var result []domain.Report
+	for _, r := range m.reports {
+		if r.OrganizationID == organizationID {
+			result = append(result, *r)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockReportRepository) FindByType(_ context.Context, _ sharedDomain.ID, _ domain.ReportType) ([]domain.Report, error) {
+	return nil, nil
+}
+
+func (m *mockReportRepository) Save(_ context.Context, report *domain.Report) error {
+	copied := *report
+	m.reports[report.ID] = &copied
+	return nil
+}
+
+func (m *mockReportRepository) Delete(_ context.Context, id sharedDomain.ID) error {
+	delete(m.reports, id)
+	return nil
+}
+
+// モック集計リポジトリ
+
+type mockSummaryRepository struct {
+	scheduleID *sharedDomain.ID
+	summary    *domain.MonthlySummary
+}
+
+func (m *mockSummaryRepository) GetMonthlySummary(_ context.Context, _ sharedDomain.ID, _, _ int) (*domain.MonthlySummary, error) {
+	return m.summary, nil
+}
+
+func (m *mockSummaryRepository) GetStaffSummary(_ context.Context, _ sharedDomain.ID, _, _ int) (*domain.StaffSummary, error) {
+	return nil, nil
+}
+
+func (m *mockSummaryRepository) GetDailySummaries(_ context.Context, _ sharedDomain.ID, _, _ int) ([]domain.DailySummary, error) {
+	return nil, nil
+}
+
+func (m *mockSummaryRepository) FindScheduleID(_ context.Context, _ sharedDomain.ID, _, _ int) (*sharedDomain.ID, error) {
+	return m.scheduleID, nil
+}
+
+// モックレポート生成
+
+type mockReportGenerator struct{}
+
+func (m *mockReportGenerator) GenerateScheduleReport(_ context.Context, scheduleID sharedDomain.ID) ([]byte, error) {
+	return []byte("schedule:" + scheduleID.String()), nil
+}
+
+func (m *mockReportGenerator) GenerateSummaryReport(_ context.Context, _ *domain.MonthlySummary) ([]byte, error) {
+	return []byte("summary"), nil
+}
+
+func (m *mockReportGenerator) GenerateActualReport(_ context.Context, _ sharedDomain.ID, _, _ int) ([]byte, error) {
+	return []byte("actual"), nil
+}
+
+func (m *mockReportGenerator) GenerateStaffListReport(_ context.Context, _ sharedDomain.ID) ([]byte, error) {
+	return []byte("staff_list"), nil
+}
+
+func (m *mockReportGenerator) FileExtension(_ domain.ReportType) string {
+	return ".csv"
+}
+
+// モックレポート保存先
+
+type mockReportStorage struct {
+	files map[string][]byte
+}
+
+func (m *mockReportStorage) Save(_ context.Context, name string, data []byte) (string, error) {
+	m.files[name] = data
+	return name, nil
+}
+
+func (m *mockReportStorage) Load(_ context.Context, path string) ([]byte, error) {
+	data, ok := m.files[path]
+	if !ok {
+		return nil, sharedDomain.ErrNotFound
+	}
+	return data, nil
+}
+
+func (m *mockReportStorage) Delete(_ context.Context, path string) error {
+	delete(m.files, path)
+	return nil
+}
+
+type reportFixture struct {
+	useCase *ReportUseCase
+	reports *mockReportRepository
+	summary *mockSummaryRepository
+	storage *mockReportStorage
+}
+
+func newReportFixture() *reportFixture {
+	f := &reportFixture{
+		reports: &mockReportRepository{reports: make(map[sharedDomain.ID]*domain.Report)},
+		summary: &mockSummaryRepository{},
+		storage: &mockReportStorage{files: make(map[string][]byte)},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	f.useCase = NewReportUseCase(f.reports, f.summary, &mockReportGenerator{}, f.storage, logger)
+	return f
+}
+
+func newPendingReport(reportType domain.ReportType) *domain.Report {
+	return &domain.Report{
+		ID:             sharedDomain.NewID(),
+		OrganizationID: sharedDomain.NewID(),
+		Type:           reportType,
+		TargetYear:     2025,
+		TargetMonth:    4,
+		Status:         domain.ReportStatusPending,
+	}
+}
+
+func TestGenerateReportInput_Validate(t *testing.T) {
+	input := &GenerateReportInput{OrganizationID: sharedDomain.NewID().String(), Type: "unknown", TargetYear: 2025, TargetMonth: 4}
+	if err := input.Validate(); err == nil {
+		t.Error("Validate() expected error for unknown report type")
+	}
+	input.Type = domain.ReportTypeSummary.String()
+	if err := input.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestReportUseCase_generateAsync(t *testing.T) {
+	scheduleID := sharedDomain.NewID()
+
+	tests := []struct {
+		name       string
+		reportType domain.ReportType
+		scheduleID *sharedDomain.ID
+		summary    *domain.MonthlySummary
+		wantStatus domain.ReportStatus
+		wantData   string
+	}{
+		{"勤務表", domain.ReportTypeSchedule, &scheduleID, nil, domain.ReportStatusCompleted, "schedule:" + scheduleID.String()},
+		{"勤務表がない月", domain.ReportTypeSchedule, nil, nil, domain.ReportStatusFailed, ""},
+		{"集計", domain.ReportTypeSummary, &scheduleID, &domain.MonthlySummary{TargetYear: 2025, TargetMonth: 4}, domain.ReportStatusCompleted, "summary"},
+		{"集計対象なし", domain.ReportTypeSummary, nil, nil, domain.ReportStatusFailed, ""},
+		{"実績", domain.ReportTypeActual, nil, nil, domain.ReportStatusCompleted, "actual"},
+		{"スタッフ一覧", domain.ReportTypeStaffList, nil, nil, domain.ReportStatusCompleted, "staff_list"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newReportFixture()
+			f.summary.scheduleID = tt.scheduleID
+			f.summary.summary = tt.summary
+			report := newPendingReport(tt.reportType)
+
+			f.useCase.generateAsync(context.Background(), report)
+
+			saved := f.reports.reports[report.ID]
+			if saved.Status != tt.wantStatus {
+				t.Fatalf("Status = %s, want %s", saved.Status, tt.wantStatus)
+			}
+			if tt.wantStatus != domain.ReportStatusCompleted {
+				if saved.FilePath != "" || saved.GeneratedAt != nil {
+					t.Errorf("failed report FilePath = %q GeneratedAt = %v", saved.FilePath, saved.GeneratedAt)
+				}
+				return
+			}
+			want := report.OrganizationID.String() + "/" + report.ID.String() + ".csv"
+			if saved.FilePath != want {
+				t.Errorf("FilePath = %q, want %q", saved.FilePath, want)
+			}
+			if got := string(f.storage.files[saved.FilePath]); got != tt.wantData {
+				t.Errorf("file = %q, want %q", got, tt.wantData)
+			}
+		})
+	}
+}
+
+func TestReportUseCase_Delete(t *testing.T) {
+	f := newReportFixture()
+	report := newPendingReport(domain.ReportTypeStaffList)
+	f.useCase.generateAsync(context.Background(), report)
+	path := f.reports.reports[report.ID].FilePath
+
+	if err := f.useCase.Delete(context.Background(), report.ID.String()); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, ok := f.reports.reports[report.ID]; ok {
+		t.Error("report should be deleted")
+	}
+	if _, err := f.storage.Load(context.Background(), path); !errors.Is(err, sharedDomain.ErrNotFound) {
+		t.Errorf("file should be deleted, Load() error = %v", err)
+	}
+}
+
+func TestReportUseCase_Download(t *testing.T) {
+	f := newReportFixture()
+
+	pending := newPendingReport(domain.ReportTypeSummary)
+	_ = f.reports.Save(context.Background(), pending)
+	_, err := f.useCase.Download(context.Background(), pending.ID.String())
+	var domainErr *sharedDomain.DomainError
+	if !errors.As(err, &domainErr) || domainErr.Code != sharedDomain.ErrCodeValidation {
+		t.Errorf("Download() pending error = %v, want validation error", err)
+	}
+
+	report := newPendingReport(domain.ReportTypeStaffList)
+	f.useCase.generateAsync(context.Background(), report)
+	file, err := f.useCase.Download(context.Background(), report.ID.String())
+	if err != nil {
+		t.Fatalf("Download() error = %v", err)
+	}
+	if file.FileName != report.Title+".csv" {
+		t.Errorf("FileName = %q, want %q", file.FileName, report.Title+".csv")
+	}
+	if file.ContentType != "text/csv; charset=utf-8" {
+		t.Errorf("ContentType = %q", file.ContentType)
+	}
+	if string(file.Data) != "staff_list" {
+		t.Errorf("Data = %q, want %q", file.Data, "staff_list")
+	}
+
+	if _, err := f.useCase.Download(context.Background(), sharedDomain.NewID().String()); !errors.Is(err, sharedDomain.ErrNotFound) {
+		t.Errorf("Download() unknown error = %v, want ErrNotFound", err)
+	}
+}
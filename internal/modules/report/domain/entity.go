@@ -4,6 +4,7 @@ package domain
 import (
 	"time"
 
+	scheduleDomain "shiftmaster/internal/modules/schedule/domain"
 	"shiftmaster/internal/shared/domain"
 )
 
@@ -52,6 +53,16 @@ func (t ReportType) String() string {
 	return string(t)
 }
 
+// IsValid 有効な種別か
+func (t ReportType) IsValid() bool {
+	switch t {
+	case ReportTypeSchedule, ReportTypeSummary, ReportTypeActual, ReportTypeStaffList:
+		return true
+	default:
+		return false
+	}
+}
+
 // Label 表示ラベル
 func (t ReportType) Label() string {
 	switch t {
@@ -103,6 +114,16 @@ func (s ReportStatus) Label() string {
 	}
 }
 
+// IsInProgress 生成待ち・生成中か
+func (s ReportStatus) IsInProgress() bool {
+	return s == ReportStatusPending || s == ReportStatusGenerating
+}
+
+// IsDownloadable ダウンロード可能か 生成完了かつファイルあり
+func (r *Report) IsDownloadable() bool {
+	return r.Status == ReportStatusCompleted && r.FilePath != ""
+}
+
 // StaffSummary スタッフ集計
 type StaffSummary struct {
 	// StaffID スタッフID
@@ -117,8 +138,16 @@ type StaffSummary struct {
 	NightShiftCount int
 	// HolidayCount 休日数
 	HolidayCount int
-	// OvertimeMinutes 残業時間 分
+	// OvertimeMinutes 残業時間 分 法定内残業と法定外残業の合計
 	OvertimeMinutes int
+	// WithinLegalOvertimeMinutes 法定内残業時間 分
+	WithinLegalOvertimeMinutes int
+	// OverLegalOvertimeMinutes 法定外残業時間 分 1日8時間・週40時間超
+	OverLegalOvertimeMinutes int
+	// LateNightMinutes 深夜労働時間 分 22:00〜翌5:00
+	LateNightMinutes int
+	// HolidayWorkMinutes 休日労働時間 分 法定休日の労働
+	HolidayWorkMinutes int
 	// PaidLeaveUsed 有給休暇使用日数
 	PaidLeaveUsed float64
 }
@@ -133,6 +162,15 @@ func (s *StaffSummary) OvertimeHours() float64 {
 	return float64(s.OvertimeMinutes) / 60.0
 }
 
+// AddOvertime 勤務実績の区分別時間外労働を加算
+func (s *StaffSummary) AddOvertime(overtime scheduleDomain.OvertimeBreakdown) {
+	s.OvertimeMinutes += overtime.OvertimeMinutes()
+	s.WithinLegalOvertimeMinutes += overtime.WithinLegalMinutes
+	s.OverLegalOvertimeMinutes += overtime.OverLegalMinutes
+	s.LateNightMinutes += overtime.LateNightMinutes
+	s.HolidayWorkMinutes += overtime.HolidayWorkMinutes
+}
+
 // DailySummary 日別集計
 type DailySummary struct {
 	// Date 日付
@@ -29,6 +29,8 @@ type SummaryRepository interface {
 	GetStaffSummary(ctx context.Context, staffID sharedDomain.ID, year, month int) (*StaffSummary, error)
 	// GetDailySummaries 日別集計取得
 	GetDailySummaries(ctx context.Context, organizationID sharedDomain.ID, year, month int) ([]DailySummary, error)
+	// FindScheduleID 対象年月の勤務表ID取得 存在しない場合はnil
+	FindScheduleID(ctx context.Context, organizationID sharedDomain.ID, year, month int) (*sharedDomain.ID, error)
 }
 
 // ReportGenerator レポート生成インターフェース
@@ -39,4 +41,30 @@ type ReportGenerator interface {
 	GenerateSummaryReport(ctx context.Context, summary *MonthlySummary) ([]byte, error)
 	// GenerateActualReport 実績レポート生成
 	GenerateActualReport(ctx context.Context, organizationID sharedDomain.ID, year, month int) ([]byte, error)
+	// GenerateStaffListReport スタッフ一覧生成
+	GenerateStaffListReport(ctx context.Context, organizationID sharedDomain.ID) ([]byte, error)
+	// FileExtension レポート種別ごとのファイル拡張子 ドット付き
+	FileExtension(reportType ReportType) string
+}
+
+// ReportStorage レポートファイル保存先インターフェース
+type ReportStorage interface {
+	// Save ファイル保存 保存先パスを返す
+	Save(ctx context.Context, name string, data []byte) (string, error)
+	// Load ファイル読み込み
+	Load(ctx context.Context, path string) ([]byte, error)
+	// Delete ファイル削除 存在しない場合は何もしない
+	Delete(ctx context.Context, path string) error
+}
+
+// RosterRepository 掲示用勤務表リポジトリインターフェース
+type RosterRepository interface {
+	// FindByScheduleID 勤務表IDで検索 存在しない場合はnil
+	FindByScheduleID(ctx context.Context, scheduleID sharedDomain.ID) (*Roster, error)
+}
+
+// RosterRenderer 掲示用勤務表の出力インターフェース
+type RosterRenderer interface {
+	// RenderPDF PDF出力
+	RenderPDF(roster *Roster, paper PaperSize) ([]byte, error)
 }
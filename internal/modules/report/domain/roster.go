@@ -0,0 +1,102 @@
+// Package domain レポートドメイン層
+package domain
+
+import (
+	"time"
+
+	scheduleDomain "shiftmaster/internal/modules/schedule/domain"
+	"shiftmaster/internal/shared/domain"
+)
+
+// Roster 掲示用勤務表 勤務表をチーム別にまとめたもの
+type Roster struct {
+	// ScheduleID 勤務表ID
+	ScheduleID domain.ID
+	// OrganizationID 組織ID
+	OrganizationID domain.ID
+	// OrganizationName 組織名
+	OrganizationName string
+	// TargetYear 対象年
+	TargetYear int
+	// TargetMonth 対象月
+	TargetMonth int
+	// Status 勤務表の状態
+	Status scheduleDomain.ScheduleStatus
+	// PublishedAt 公開日時
+	PublishedAt *time.Time
+	// Teams チーム別のスタッフ 部署・チームの表示順
+	Teams []RosterTeam
+	// ShiftTypes 凡例に載せるシフト種別 表示順
+	ShiftTypes []RosterShiftType
+}
+
+// schedule 対象年月の勤務表 期間計算に使用
+func (r *Roster) schedule() *scheduleDomain.Schedule {
+	return &scheduleDomain.Schedule{TargetYear: r.TargetYear, TargetMonth: r.TargetMonth}
+}
+
+// TargetPeriodLabel 対象期間ラベル
+func (r *Roster) TargetPeriodLabel() string {
+	return r.schedule().TargetPeriodLabel()
+}
+
+// DaysInMonth 月の日数
+func (r *Roster) DaysInMonth() int {
+	return r.schedule().DaysInMonth()
+}
+
+// IsPublished 公開済みか
+func (r *Roster) IsPublished() bool {
+	return r.Status == scheduleDomain.StatusPublished
+}
+
+// RosterTeam 掲示用勤務表のチーム
+type RosterTeam struct {
+	// DepartmentName 部署名
+	DepartmentName string
+	// TeamName チーム名
+	TeamName string
+	// Staffs スタッフ
+	Staffs []RosterStaff
+}
+
+// RosterStaff 掲示用勤務表のスタッフ行
+type RosterStaff struct {
+	// EmployeeCode 職員番号
+	EmployeeCode string
+	// Name 氏名
+	Name string
+	// ShiftCodes 日ごとのシフトコード 添字は日-1 未割り当ては空
+	ShiftCodes []string
+}
+
+// RosterShiftType 凡例のシフト種別
+type RosterShiftType struct {
+	// Code コード
+	Code string
+	// Name 名称
+	Name string
+	// Color 表示色 HEX形式
+	Color string
+	// StartTime 開始時刻 HH:MM
+	StartTime string
+	// EndTime 終了時刻 HH:MM
+	EndTime string
+	// IsHoliday 休日シフトか
+	IsHoliday bool
+}
+
+// PaperSize 用紙サイズ いずれも横向き
+type PaperSize string
+
+const (
+	// PaperSizeA4 A4横
+	PaperSizeA4 PaperSize = "a4"
+	// PaperSizeA3 A3横
+	PaperSizeA3 PaperSize = "a3"
+)
+
+// IsValid 有効な用紙サイズか
+func (p PaperSize) IsValid() bool {
+	return p == PaperSizeA4 || p == PaperSizeA3
+}
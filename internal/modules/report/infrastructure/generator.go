@@ -0,0 +1,289 @@
+// Package infrastructure レポートインフラストラクチャ層
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"shiftmaster/internal/modules/report/domain"
+	staffDomain "shiftmaster/internal/modules/staff/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+
+	"github.com/uptrace/bun"
+)
+
+// utf8BOM Excelで文字化けしないよう先頭に付与するBOM
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// CSVReportGenerator CSV形式のレポート生成
+type CSVReportGenerator struct {
+	db      *bun.DB
+	summary *PostgresSummaryRepository
+}
+
+// NewCSVReportGenerator CSVレポート生成器生成
+func NewCSVReportGenerator(db *bun.DB) *CSVReportGenerator {
+	return &CSVReportGenerator{db: db, summary: NewPostgresSummaryRepository(db)}
+}
+
+// FileExtension ファイル拡張子 全種別CSV
+func (g *CSVReportGenerator) FileExtension(_ domain.ReportType) string {
+	return ".csv"
+}
+
+// GenerateScheduleReport 勤務表レポート生成 スタッフ×日のシフトコード表と日別人数
+func (g *CSVReportGenerator) GenerateScheduleReport(ctx context.Context, scheduleID sharedDomain.ID) ([]byte, error) {
+	rows, summary, err := g.loadSchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	header := []string{"職員番号", "氏名"}
+	for _, daily := range summary.DailySummaries {
+		header = append(header, fmt.Sprintf("%d(%s)", daily.Date.Day(), daily.DayOfWeek()))
+	}
+	header = append(header, "勤務日数", "夜勤回数", "休日数")
+	records := [][]string{header}
+
+	days := len(summary.DailySummaries)
+	for i := 0; i < len(rows); {
+		row := &rows[i]
+		record := make([]string, 2+days)
+		record[0] = row.EmployeeCode
+		record[1] = row.staffName()
+		staffID := row.StaffID
+		for ; i < len(rows) && rows[i].StaffID == staffID; i++ {
+			if st := rows[i].shiftType(); st != nil {
+				if day := rows[i].TargetDate.Day(); day <= days {
+					record[1+day] = st.Code
+				}
+			}
+		}
+		staff := findStaffSummary(summary, staffID)
+		record = append(record,
+			strconv.Itoa(staff.TotalWorkDays),
+			strconv.Itoa(staff.NightShiftCount),
+			strconv.Itoa(staff.HolidayCount),
+		)
+		records = append(records, record)
+	}
+
+	dayShift := []string{"", "日勤人数"}
+	nightShift := []string{"", "夜勤人数"}
+	for _, daily := range summary.DailySummaries {
+		dayShift = append(dayShift, strconv.Itoa(daily.DayShiftCount))
+		nightShift = append(nightShift, strconv.Itoa(daily.NightShiftCount))
+	}
+	records = append(records, dayShift, nightShift)
+
+	return writeCSV(records)
+}
+
+// loadSchedule 勤務表のエントリ行と月次集計を取得
+func (g *CSVReportGenerator) loadSchedule(ctx context.Context, scheduleID sharedDomain.ID) ([]entryRow, *domain.MonthlySummary, error) {
+	var target struct {
+		TargetYear  int `bun:"target_year"`
+		TargetMonth int `bun:"target_month"`
+	}
+	err := g.db.NewSelect().
+		TableExpr("schedules").
+		Column("target_year", "target_month").
+		Where("id = ?", scheduleID).
+		Scan(ctx, &target)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, sharedDomain.ErrNotFound
+		}
+		return nil, nil, err
+	}
+
+	rows, err := g.summary.findEntryRows(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("e.schedule_id = ?", scheduleID)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return rows, buildMonthlySummary(target.TargetYear, target.TargetMonth, rows), nil
+}
+
+// GenerateSummaryReport 集計レポート生成 スタッフ別・シフト種別・日別の順に出力
+func (g *CSVReportGenerator) GenerateSummaryReport(_ context.Context, summary *domain.MonthlySummary) ([]byte, error) {
+	records := [][]string{
+		{fmt.Sprintf("%d年%d月 集計", summary.TargetYear, summary.TargetMonth)},
+		{"氏名", "勤務日数", "勤務時間", "夜勤回数", "休日数", "残業時間", "法定内残業", "法定外残業", "深夜労働", "休日労働", "有給取得日数"},
+	}
+	for _, s := range summary.StaffSummaries {
+		records = append(records, []string{
+			s.StaffName,
+			strconv.Itoa(s.TotalWorkDays),
+			formatMinutes(s.TotalWorkMinutes),
+			strconv.Itoa(s.NightShiftCount),
+			strconv.Itoa(s.HolidayCount),
+			formatMinutes(s.OvertimeMinutes),
+			formatMinutes(s.WithinLegalOvertimeMinutes),
+			formatMinutes(s.OverLegalOvertimeMinutes),
+			formatMinutes(s.LateNightMinutes),
+			formatMinutes(s.HolidayWorkMinutes),
+			strconv.FormatFloat(s.PaidLeaveUsed, 'f', -1, 64),
+		})
+	}
+	records = append(records,
+		[]string{"合計", strconv.Itoa(summary.TotalWorkDays), formatMinutes(summary.TotalWorkMinutes)},
+		[]string{"1日平均勤務時間", formatMinutes(int(summary.AverageWorkMinutesPerDay))},
+		nil,
+		[]string{"コード", "シフト種別", "件数", "合計時間"},
+	)
+	for _, s := range summary.ShiftTypeSummaries {
+		records = append(records, []string{s.ShiftTypeCode, s.ShiftTypeName, strconv.Itoa(s.Count), formatMinutes(s.TotalMinutes)})
+	}
+	records = append(records, nil, []string{"日付", "曜日", "出勤人数", "日勤", "夜勤", "休日"})
+	for _, d := range summary.DailySummaries {
+		records = append(records, []string{
+			d.Date.Format("2006-01-02"),
+			d.DayOfWeek(),
+			strconv.Itoa(d.TotalStaff),
+			strconv.Itoa(d.DayShiftCount),
+			strconv.Itoa(d.NightShiftCount),
+			strconv.Itoa(d.HolidayCount),
+		})
+	}
+
+	return writeCSV(records)
+}
+
+// GenerateActualReport 実績レポート生成 エントリごとに予定と実績を1行で出力
+func (g *CSVReportGenerator) GenerateActualReport(ctx context.Context, organizationID sharedDomain.ID, year, month int) ([]byte, error) {
+	scheduleID, err := g.summary.FindScheduleID(ctx, organizationID, year, month)
+	if err != nil {
+		return nil, err
+	}
+	if scheduleID == nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeNotFound, "対象年月の勤務表がありません")
+	}
+
+	rows, err := g.summary.findEntryRows(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("e.schedule_id = ?", *scheduleID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	records := [][]string{
+		{"職員番号", "氏名", "日付", "予定シフト", "出勤", "退勤", "休憩(分)", "実働時間", "法定内残業", "法定外残業", "深夜労働", "休日労働", "備考"},
+	}
+	for i := range rows {
+		row := &rows[i]
+		shiftCode := ""
+		if st := row.shiftType(); st != nil {
+			shiftCode = st.Code
+		}
+		record := []string{row.EmployeeCode, row.staffName(), row.TargetDate.Format("2006-01-02"), shiftCode}
+		if rec := row.record(); rec != nil {
+			record = append(record,
+				formatClock(rec.ActualStartTime),
+				formatClock(rec.ActualEndTime),
+				strconv.Itoa(rec.ActualBreakMinutes),
+				formatMinutes(max(rec.ActualWorkingMinutes(), 0)),
+				formatMinutes(rec.WithinLegalOvertimeMinutes),
+				formatMinutes(rec.OverLegalOvertimeMinutes),
+				formatMinutes(rec.LateNightMinutes),
+				formatMinutes(rec.HolidayWorkMinutes),
+				rec.Note,
+			)
+		} else {
+			record = append(record, "", "", "", "", "", "", "", "", "")
+		}
+		records = append(records, record)
+	}
+
+	return writeCSV(records)
+}
+
+// GenerateStaffListReport スタッフ一覧生成 在籍中のスタッフのみ
+func (g *CSVReportGenerator) GenerateStaffListReport(ctx context.Context, organizationID sharedDomain.ID) ([]byte, error) {
+	var staffs []struct {
+		EmployeeCode   string     `bun:"employee_code"`
+		LastName       string     `bun:"last_name"`
+		FirstName      string     `bun:"first_name"`
+		DepartmentName string     `bun:"department_name"`
+		TeamName       string     `bun:"team_name"`
+		EmploymentType string     `bun:"employment_type"`
+		Email          string     `bun:"email"`
+		Phone          string     `bun:"phone"`
+		HireDate       *time.Time `bun:"hire_date"`
+	}
+	err := g.db.NewSelect().
+		TableExpr("staffs AS st").
+		ColumnExpr("COALESCE(st.employee_code, '') AS employee_code, st.last_name, st.first_name").
+		ColumnExpr("d.name AS department_name, t.name AS team_name, st.employment_type").
+		ColumnExpr("COALESCE(st.email, '') AS email, COALESCE(st.phone, '') AS phone, st.hire_date").
+		Join("INNER JOIN teams AS t ON t.id = st.team_id").
+		Join("INNER JOIN departments AS d ON d.id = t.department_id").
+		Where("d.organization_id = ?", organizationID).
+		Where("st.is_active = ?", true).
+		Order("d.sort_order ASC", "t.sort_order ASC", "st.employee_code ASC", "st.last_name ASC").
+		Scan(ctx, &staffs)
+	if err != nil {
+		return nil, err
+	}
+
+	records := [][]string{{"職員番号", "氏名", "部署", "チーム", "雇用形態", "メールアドレス", "電話番号", "入職日"}}
+	for _, s := range staffs {
+		hireDate := ""
+		if s.HireDate != nil {
+			hireDate = s.HireDate.Format("2006-01-02")
+		}
+		records = append(records, []string{
+			s.EmployeeCode,
+			s.LastName + " " + s.FirstName,
+			s.DepartmentName,
+			s.TeamName,
+			staffDomain.EmploymentType(s.EmploymentType).Label(),
+			s.Email,
+			s.Phone,
+			hireDate,
+		})
+	}
+
+	return writeCSV(records)
+}
+
+// findStaffSummary 月次集計からスタッフ集計を検索
+func findStaffSummary(summary *domain.MonthlySummary, staffID sharedDomain.ID) domain.StaffSummary {
+	for _, s := range summary.StaffSummaries {
+		if s.StaffID == staffID {
+			return s
+		}
+	}
+	return domain.StaffSummary{}
+}
+
+// writeCSV BOM付きUTF-8のCSVを出力
+func writeCSV(records [][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Write(utf8BOM)
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// formatMinutes 分をH:MM形式に変換
+func formatMinutes(minutes int) string {
+	return fmt.Sprintf("%d:%02d", minutes/60, minutes%60)
+}
+
+// formatClock 打刻時刻をHH:MM形式に変換 未入力は空
+func formatClock(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.In(time.Local).Format("15:04")
+}
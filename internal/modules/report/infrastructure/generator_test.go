@@ -0,0 +1,35 @@
+// Package infrastructure レポートインフラストラクチャ層テスト
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"shiftmaster/internal/modules/report/domain"
+)
+
+func TestCSVReportGenerator_GenerateSummaryReport(t *testing.T) {
+	summary := buildMonthlySummary(2025, 4, nil)
+	summary.StaffSummaries = append(summary.StaffSummaries, domain.StaffSummary{
+		StaffName:        "山田 花子",
+		TotalWorkDays:    20,
+		TotalWorkMinutes: 9630,
+		OvertimeMinutes:  90,
+	})
+
+	data, err := NewCSVReportGenerator(nil).GenerateSummaryReport(context.Background(), summary)
+	if err != nil {
+		t.Fatalf("GenerateSummaryReport() error = %v", err)
+	}
+	if !bytes.HasPrefix(data, utf8BOM) {
+		t.Error("CSV should start with UTF-8 BOM")
+	}
+	if !strings.Contains(string(data), "山田 花子,20,160:30,0,0,1:30,") {
+		t.Errorf("staff row missing:\n%s", data)
+	}
+	if !strings.Contains(string(data), "2025-04-30,水,0,0,0,0") {
+		t.Errorf("daily row missing:\n%s", data)
+	}
+}
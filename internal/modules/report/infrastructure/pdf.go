@@ -0,0 +1,281 @@
+// Package infrastructure レポートインフラストラクチャ層
+package infrastructure
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// pdfFontName 日本語フォント 使用する文字のみのサブセットを埋め込み、ビューアのフォントに依存しない
+const pdfFontName = "MPLUS1p-Regular"
+
+// pdfDocument PDF文書 座標は左上原点のポイント単位で指定する
+type pdfDocument struct {
+	width  float64
+	height float64
+	pages  []*pdfPage
+	// glyphs 使用したグリフIDと対応する文字 フォントのサブセットとToUnicodeに使う
+	glyphs map[uint16]rune
+}
+
+// pdfPage PDFページ
+type pdfPage struct {
+	doc     *pdfDocument
+	content bytes.Buffer
+}
+
+// newPDFDocument PDF文書生成 全ページ同じ用紙サイズ
+func newPDFDocument(width, height float64) *pdfDocument {
+	return &pdfDocument{width: width, height: height, glyphs: make(map[uint16]rune)}
+}
+
+// addPage ページ追加
+func (d *pdfDocument) addPage() *pdfPage {
+	page := &pdfPage{doc: d}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+// fillRect 矩形塗りつぶし 色はRRGGBB形式
+func (p *pdfPage) fillRect(x, y, w, h float64, color string) {
+	fmt.Fprintf(&p.content, "%s rg %s %s %s %s re f\n",
+		pdfColor(color), pdfNum(x), pdfNum(p.doc.height-y-h), pdfNum(w), pdfNum(h))
+}
+
+// strokeRect 矩形の枠線 色はRRGGBB形式
+func (p *pdfPage) strokeRect(x, y, w, h, lineWidth float64, color string) {
+	fmt.Fprintf(&p.content, "%s RG %s w %s %s %s %s re S\n",
+		pdfColor(color), pdfNum(lineWidth), pdfNum(x), pdfNum(p.doc.height-y-h), pdfNum(w), pdfNum(h))
+}
+
+// text 文字列描画 yはベースライン 色はRRGGBB形式
+func (p *pdfPage) text(x, y, size float64, s, color string) {
+	fmt.Fprintf(&p.content, "BT %s rg /F1 %s Tf %s %s Td <%s> Tj ET\n",
+		pdfColor(color), pdfNum(size), pdfNum(x), pdfNum(p.doc.height-y), p.doc.encodeText(s))
+}
+
+// encodeText 文字列をグリフIDに変換し、使用したグリフを記録
+func (d *pdfDocument) encodeText(s string) string {
+	font := pdfFont()
+	for _, r := range s {
+		gid := font.glyph(r)
+		if _, ok := d.glyphs[gid]; !ok {
+			if font.cmap[r] != gid {
+				r = pdfFallbackRune
+			}
+			d.glyphs[gid] = r
+		}
+	}
+	return pdfEncodeText(s)
+}
+
+// textCentered 矩形の中央に文字列描画
+func (p *pdfPage) textCentered(x, y, w, h, size float64, s, color string) {
+	p.text(x+(w-pdfTextWidth(s, size))/2, y+h/2+size*0.35, size, s, color)
+}
+
+// Bytes PDFファイル出力
+func (d *pdfDocument) Bytes() ([]byte, error) {
+	// オブジェクト番号 1:カタログ 2:ページツリー 3〜7:フォント 8以降:ページと内容
+	const firstPageObject = 8
+	objects := make([]string, firstPageObject-1, firstPageObject-1+len(d.pages)*2)
+
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPageObject+i*2)
+	}
+	objects[0] = "<< /Type /Catalog /Pages 2 0 R >>"
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages))
+	fontObjects, err := d.fontObjects()
+	if err != nil {
+		return nil, err
+	}
+	copy(objects[2:], fontObjects)
+
+	for i, page := range d.pages {
+		var compressed bytes.Buffer
+		zw := zlib.NewWriter(&compressed)
+		if _, err := zw.Write(page.content.Bytes()); err != nil {
+			return nil, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+				pdfNum(d.width), pdfNum(d.height), firstPageObject+i*2+1),
+			fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", compressed.Len(), compressed.String()),
+		)
+	}
+
+	var buf bytes.Buffer
+	// バイナリを含むことを示すコメント行
+	buf.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buf.Bytes(), nil
+}
+
+// fontObjects 埋め込みフォントのオブジェクト 3:Type0 4:CIDFont 5:FontDescriptor 6:フォントファイル 7:ToUnicode
+// 文字コードはグリフIDをそのまま使う（Identity-H）
+func (d *pdfDocument) fontObjects() ([]string, error) {
+	font := pdfFont()
+	gids := make([]uint16, 0, len(d.glyphs))
+	used := make(map[uint16]bool, len(d.glyphs))
+	for gid := range d.glyphs {
+		gids = append(gids, gid)
+		used[gid] = true
+	}
+	sort.Slice(gids, func(i, j int) bool { return gids[i] < gids[j] })
+	baseFont := subsetTag(gids) + "+" + pdfFontName
+
+	var widths strings.Builder
+	for i, gid := range gids {
+		if i == 0 || gids[i-1] != gid-1 {
+			if i > 0 {
+				widths.WriteString("] ")
+			}
+			fmt.Fprintf(&widths, "%d [", gid)
+		} else {
+			widths.WriteByte(' ')
+		}
+		fmt.Fprintf(&widths, "%d", font.width(gid))
+	}
+	if len(gids) > 0 {
+		widths.WriteString("]")
+	}
+
+	fontFile, err := pdfDeflate(font.subset(used))
+	if err != nil {
+		return nil, err
+	}
+	toUnicode, err := pdfDeflate(d.toUnicodeCMap(gids))
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{
+		"<< /Type /Font /Subtype /Type0 /BaseFont /" + baseFont + " /Encoding /Identity-H /DescendantFonts [4 0 R] /ToUnicode 7 0 R >>",
+		"<< /Type /Font /Subtype /CIDFontType2 /BaseFont /" + baseFont +
+			" /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >>" +
+			" /FontDescriptor 5 0 R /CIDToGIDMap /Identity /DW 1000 /W [" + widths.String() + "] >>",
+		fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 4 /FontBBox [%d %d %d %d] /ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 6 0 R >>",
+			baseFont, font.scale(font.bbox[0]), font.scale(font.bbox[1]), font.scale(font.bbox[2]), font.scale(font.bbox[3]),
+			font.scale(font.ascent), font.scale(font.descent), font.scale(font.ascent)),
+		fmt.Sprintf("<< /Length %d /Length1 %d /Filter /FlateDecode >>\nstream\n%s\nendstream", len(fontFile.compressed), fontFile.length, fontFile.compressed),
+		fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", len(toUnicode.compressed), toUnicode.compressed),
+	}, nil
+}
+
+// toUnicodeCMap グリフIDから文字への対応表 テキストのコピーと検索に使う
+func (d *pdfDocument) toUnicodeCMap(gids []uint16) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n" +
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n" +
+		"/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n" +
+		"1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+	// bfcharは1ブロック100件まで
+	for start := 0; start < len(gids); start += 100 {
+		block := gids[start:min(start+100, len(gids))]
+		fmt.Fprintf(&buf, "%d beginbfchar\n", len(block))
+		for _, gid := range block {
+			var utf16 strings.Builder
+			for _, u := range utf16Encode(d.glyphs[gid]) {
+				fmt.Fprintf(&utf16, "%04X", u)
+			}
+			fmt.Fprintf(&buf, "<%04X> <%s>\n", gid, utf16.String())
+		}
+		buf.WriteString("endbfchar\n")
+	}
+	buf.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+	return buf.Bytes()
+}
+
+// pdfStream 圧縮したストリームと圧縮前の長さ
+type pdfStream struct {
+	compressed []byte
+	length     int
+}
+
+// pdfDeflate ストリームをFlateDecode形式で圧縮
+func pdfDeflate(data []byte) (pdfStream, error) {
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	if _, err := zw.Write(data); err != nil {
+		return pdfStream{}, err
+	}
+	if err := zw.Close(); err != nil {
+		return pdfStream{}, err
+	}
+	return pdfStream{compressed: compressed.Bytes(), length: len(data)}, nil
+}
+
+// utf16Encode 文字をUTF-16のコード単位に変換 BMP外はサロゲートペア
+func utf16Encode(r rune) []uint16 {
+	if r < 0x10000 {
+		return []uint16{uint16(r)}
+	}
+	r -= 0x10000
+	return []uint16{uint16(0xD800 + (r >> 10)), uint16(0xDC00 + (r & 0x3FF))}
+}
+
+// pdfTextWidth 文字列の描画幅 埋め込みフォントの送り幅から求める
+func pdfTextWidth(s string, size float64) float64 {
+	font := pdfFont()
+	width := 0
+	for _, r := range s {
+		width += font.width(font.glyph(r))
+	}
+	return float64(width) * size / 1000
+}
+
+// pdfTruncate 描画幅に収まるよう末尾を省略
+func pdfTruncate(s string, size, maxWidth float64) string {
+	if pdfTextWidth(s, size) <= maxWidth {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && pdfTextWidth(string(runes)+"…", size) > maxWidth {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
+
+// pdfEncodeText 埋め込みフォントのグリフIDの16進文字列に変換 フォントにない文字は〓に置き換える
+func pdfEncodeText(s string) string {
+	font := pdfFont()
+	var sb strings.Builder
+	for _, r := range s {
+		fmt.Fprintf(&sb, "%04X", font.glyph(r))
+	}
+	return sb.String()
+}
+
+// pdfColor RRGGBB形式をPDFの色指定に変換 不正な値は黒
+func pdfColor(color string) string {
+	rgb, err := strconv.ParseUint(color, 16, 32)
+	if err != nil || len(color) != 6 {
+		return "0 0 0"
+	}
+	return pdfNum(float64((rgb>>16)&0xFF)/255) + " " + pdfNum(float64((rgb>>8)&0xFF)/255) + " " + pdfNum(float64(rgb&0xFF)/255)
+}
+
+// pdfNum 数値を小数点以下2桁までで出力
+func pdfNum(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	return strings.TrimRight(strings.TrimRight(s, "0"), ".")
+}
@@ -0,0 +1,416 @@
+// Package infrastructure レポートインフラストラクチャ層
+package infrastructure
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+)
+
+// pdfFontData PDFに埋め込む日本語フォント M+ FONTS（ライセンスは fonts/LICENSE）
+//
+//go:embed fonts/mplus-1p-regular.ttf
+var pdfFontData []byte
+
+// pdfFont 埋め込みフォントの解析結果 初回利用時に一度だけ解析する
+var pdfFont = sync.OnceValue(func() *trueTypeFont {
+	font, err := parseTrueType(pdfFontData)
+	if err != nil {
+		panic("埋め込みフォントの解析に失敗しました: " + err.Error())
+	}
+	return font
+})
+
+// pdfFallbackRune フォントにない文字の代替
+const pdfFallbackRune = '〓'
+
+// サブセットに含めるテーブル PDFのFontFile2に必要なもののみ
+var trueTypeSubsetTables = []string{"cvt ", "fpgm", "glyf", "head", "hhea", "hmtx", "loca", "maxp", "prep"}
+
+// trueTypeEmptyCmap サブセットに入れる空の文字対応表
+// PDFからはグリフIDで参照するため文字の対応は不要だが、cmapテーブルを必須とするビューアがある
+var trueTypeEmptyCmap = []byte{
+	0, 0, 0, 1, // version, numTables
+	0, 3, 0, 1, 0, 0, 0, 12, // Windows Unicode BMP, offset
+	0, 4, 0, 24, 0, 0, // format 4, length, language
+	0, 2, 0, 2, 0, 0, 0, 0, // segCountX2, searchRange, entrySelector, rangeShift
+	0xFF, 0xFF, 0, 0, 0xFF, 0xFF, // endCode, reservedPad, startCode
+	0, 1, 0, 0, // idDelta, idRangeOffset
+}
+
+// 複合グリフの構成要素フラグ
+const (
+	glyphArgsAreWords   = 0x0001
+	glyphHaveScale      = 0x0008
+	glyphMoreComponents = 0x0020
+	glyphHaveXYScale    = 0x0040
+	glyphHaveTwoByTwo   = 0x0080
+)
+
+// trueTypeFont TrueTypeフォント サブセット埋め込みに必要な情報のみ保持する
+type trueTypeFont struct {
+	tables     map[string][]byte
+	unitsPerEm int
+	numGlyphs  int
+	advances   []int
+	loca       []int
+	cmap       map[rune]uint16
+	bbox       [4]int
+	ascent     int
+	descent    int
+}
+
+// parseTrueType TrueTypeフォントの解析 glyf形式のアウトラインのみ対応
+func parseTrueType(data []byte) (*trueTypeFont, error) {
+	if len(data) < 12 || binary.BigEndian.Uint32(data) != 0x00010000 {
+		return nil, errors.New("TrueType形式ではありません")
+	}
+	f := &trueTypeFont{tables: make(map[string][]byte)}
+	numTables := int(binary.BigEndian.Uint16(data[4:]))
+	for i := range numTables {
+		rec := 12 + i*16
+		if rec+16 > len(data) {
+			return nil, errors.New("テーブル一覧が不正です")
+		}
+		offset := int(binary.BigEndian.Uint32(data[rec+8:]))
+		length := int(binary.BigEndian.Uint32(data[rec+12:]))
+		if offset+length > len(data) {
+			return nil, errors.New("テーブルの範囲が不正です")
+		}
+		f.tables[string(data[rec:rec+4])] = data[offset : offset+length]
+	}
+	for _, tag := range []string{"head", "hhea", "maxp", "hmtx", "loca", "glyf", "cmap"} {
+		if _, ok := f.tables[tag]; !ok {
+			return nil, fmt.Errorf("%sテーブルがありません", tag)
+		}
+	}
+
+	head := f.tables["head"]
+	if len(head) < 54 {
+		return nil, errors.New("headテーブルが不正です")
+	}
+	f.unitsPerEm = int(binary.BigEndian.Uint16(head[18:]))
+	for i := range f.bbox {
+		f.bbox[i] = int(int16(binary.BigEndian.Uint16(head[36+i*2:])))
+	}
+	longLoca := binary.BigEndian.Uint16(head[50:]) == 1
+
+	f.numGlyphs = int(binary.BigEndian.Uint16(f.tables["maxp"][4:]))
+	hhea := f.tables["hhea"]
+	f.ascent = int(int16(binary.BigEndian.Uint16(hhea[4:])))
+	f.descent = int(int16(binary.BigEndian.Uint16(hhea[6:])))
+
+	// 水平メトリクス numberOfHMetrics以降のグリフは最後の送り幅を使う
+	numMetrics := int(binary.BigEndian.Uint16(hhea[34:]))
+	hmtx := f.tables["hmtx"]
+	if numMetrics == 0 || len(hmtx) < numMetrics*4 {
+		return nil, errors.New("hmtxテーブルが不正です")
+	}
+	f.advances = make([]int, f.numGlyphs)
+	for i := range f.advances {
+		f.advances[i] = int(binary.BigEndian.Uint16(hmtx[min(i, numMetrics-1)*4:]))
+	}
+
+	loca := f.tables["loca"]
+	f.loca = make([]int, f.numGlyphs+1)
+	for i := range f.loca {
+		if longLoca {
+			if len(loca) < (i+1)*4 {
+				return nil, errors.New("locaテーブルが不正です")
+			}
+			f.loca[i] = int(binary.BigEndian.Uint32(loca[i*4:]))
+		} else {
+			if len(loca) < (i+1)*2 {
+				return nil, errors.New("locaテーブルが不正です")
+			}
+			f.loca[i] = int(binary.BigEndian.Uint16(loca[i*2:])) * 2
+		}
+	}
+	if f.loca[f.numGlyphs] > len(f.tables["glyf"]) {
+		return nil, errors.New("glyfテーブルの範囲が不正です")
+	}
+
+	cmap, err := parseCmap(f.tables["cmap"])
+	if err != nil {
+		return nil, err
+	}
+	f.cmap = cmap
+	return f, nil
+}
+
+// parseCmap Unicodeの文字対応表を解析 Windows Unicodeのフォーマット12または4を使う
+func parseCmap(data []byte) (map[rune]uint16, error) {
+	if len(data) < 4 {
+		return nil, errors.New("cmapテーブルが不正です")
+	}
+	var format4, format12 []byte
+	numTables := int(binary.BigEndian.Uint16(data[2:]))
+	for i := range numTables {
+		rec := 4 + i*8
+		if rec+8 > len(data) {
+			break
+		}
+		platform := binary.BigEndian.Uint16(data[rec:])
+		encoding := binary.BigEndian.Uint16(data[rec+2:])
+		offset := int(binary.BigEndian.Uint32(data[rec+4:]))
+		if platform != 3 || offset+4 > len(data) {
+			continue
+		}
+		sub := data[offset:]
+		switch format := binary.BigEndian.Uint16(sub); {
+		case encoding == 10 && format == 12:
+			format12 = sub
+		case encoding == 1 && format == 4:
+			format4 = sub
+		}
+	}
+
+	cmap := make(map[rune]uint16)
+	switch {
+	case format12 != nil:
+		if len(format12) < 16 {
+			return nil, errors.New("cmapテーブルが不正です")
+		}
+		groups := int(binary.BigEndian.Uint32(format12[12:]))
+		if len(format12) < 16+groups*12 {
+			return nil, errors.New("cmapテーブルが不正です")
+		}
+		for i := range groups {
+			g := format12[16+i*12:]
+			start, end, gid := binary.BigEndian.Uint32(g), binary.BigEndian.Uint32(g[4:]), binary.BigEndian.Uint32(g[8:])
+			for c := start; c <= end && c <= 0x10FFFF; c++ {
+				cmap[rune(c)] = uint16(gid + c - start)
+			}
+		}
+	case format4 != nil:
+		if len(format4) < 14 {
+			return nil, errors.New("cmapテーブルが不正です")
+		}
+		segs := int(binary.BigEndian.Uint16(format4[6:])) / 2
+		ends, starts, deltas, offsets := 14, 16+segs*2, 16+segs*4, 16+segs*6
+		if len(format4) < offsets+segs*2 {
+			return nil, errors.New("cmapテーブルが不正です")
+		}
+		for i := range segs {
+			end := int(binary.BigEndian.Uint16(format4[ends+i*2:]))
+			start := int(binary.BigEndian.Uint16(format4[starts+i*2:]))
+			delta := int(binary.BigEndian.Uint16(format4[deltas+i*2:]))
+			rangeOffset := int(binary.BigEndian.Uint16(format4[offsets+i*2:]))
+			for c := start; c <= end && c != 0xFFFF; c++ {
+				gid := (c + delta) & 0xFFFF
+				if rangeOffset != 0 {
+					pos := offsets + i*2 + rangeOffset + (c-start)*2
+					if pos+2 > len(format4) {
+						continue
+					}
+					gid = int(binary.BigEndian.Uint16(format4[pos:]))
+					if gid != 0 {
+						gid = (gid + delta) & 0xFFFF
+					}
+				}
+				if gid != 0 {
+					cmap[rune(c)] = uint16(gid)
+				}
+			}
+		}
+	default:
+		return nil, errors.New("Unicodeの文字対応表がありません")
+	}
+	return cmap, nil
+}
+
+// glyph 文字のグリフID 制御文字とフォントにない文字は〓で代替する
+func (f *trueTypeFont) glyph(r rune) uint16 {
+	if r >= 0x20 {
+		if gid, ok := f.cmap[r]; ok {
+			return gid
+		}
+	}
+	return f.cmap[pdfFallbackRune]
+}
+
+// width グリフの送り幅 1000分率
+func (f *trueTypeFont) width(gid uint16) int {
+	if int(gid) >= f.numGlyphs {
+		return 0
+	}
+	return f.advances[gid] * 1000 / f.unitsPerEm
+}
+
+// scale フォント単位を1000分率に変換
+func (f *trueTypeFont) scale(v int) int {
+	return v * 1000 / f.unitsPerEm
+}
+
+// glyphData グリフのアウトラインデータ
+func (f *trueTypeFont) glyphData(gid uint16) []byte {
+	if int(gid) >= f.numGlyphs {
+		return nil
+	}
+	return f.tables["glyf"][f.loca[gid]:f.loca[gid+1]]
+}
+
+// subset 指定グリフのみアウトラインを残したフォントファイル
+// グリフIDは元のフォントと同じままにし、使わないグリフは空にする 複合グリフの構成要素も含める
+func (f *trueTypeFont) subset(used map[uint16]bool) []byte {
+	keep := map[uint16]bool{0: true}
+	pending := make([]uint16, 0, len(used))
+	for gid := range used {
+		pending = append(pending, gid)
+	}
+	for len(pending) > 0 {
+		gid := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if keep[gid] || int(gid) >= f.numGlyphs {
+			continue
+		}
+		keep[gid] = true
+		pending = append(pending, f.components(gid)...)
+	}
+
+	var glyf bytes.Buffer
+	loca := make([]byte, (f.numGlyphs+1)*4)
+	for gid := range f.numGlyphs {
+		binary.BigEndian.PutUint32(loca[gid*4:], uint32(glyf.Len()))
+		if keep[uint16(gid)] {
+			glyf.Write(f.glyphData(uint16(gid)))
+			for glyf.Len()%4 != 0 {
+				glyf.WriteByte(0)
+			}
+		}
+	}
+	binary.BigEndian.PutUint32(loca[f.numGlyphs*4:], uint32(glyf.Len()))
+
+	// locaは常に4バイト形式で書き、ファイル全体のチェックサム調整値は書き出し後に設定する
+	head := append([]byte(nil), f.tables["head"]...)
+	binary.BigEndian.PutUint32(head[8:], 0)
+	binary.BigEndian.PutUint16(head[50:], 1)
+
+	tables := make(map[string][]byte, len(trueTypeSubsetTables))
+	for _, tag := range trueTypeSubsetTables {
+		if data, ok := f.tables[tag]; ok {
+			tables[tag] = data
+		}
+	}
+	tables["glyf"] = glyf.Bytes()
+	tables["loca"] = loca
+	tables["head"] = head
+	tables["cmap"] = trueTypeEmptyCmap
+	// postはグリフ名を持たない形式3にする
+	if post := f.tables["post"]; len(post) >= 32 {
+		post = append([]byte(nil), post[:32]...)
+		binary.BigEndian.PutUint32(post, 0x00030000)
+		tables["post"] = post
+	}
+
+	out, offsets := writeTrueType(tables)
+	binary.BigEndian.PutUint32(out[offsets["head"]+8:], 0xB1B0AFBA-trueTypeChecksum(out))
+	return out
+}
+
+// components 複合グリフが参照するグリフID
+func (f *trueTypeFont) components(gid uint16) []uint16 {
+	data := f.glyphData(gid)
+	if len(data) < 10 || int16(binary.BigEndian.Uint16(data)) >= 0 {
+		return nil
+	}
+	var result []uint16
+	for pos := 10; pos+4 <= len(data); {
+		flags := binary.BigEndian.Uint16(data[pos:])
+		result = append(result, binary.BigEndian.Uint16(data[pos+2:]))
+		pos += 4
+		if flags&glyphArgsAreWords != 0 {
+			pos += 4
+		} else {
+			pos += 2
+		}
+		switch {
+		case flags&glyphHaveScale != 0:
+			pos += 2
+		case flags&glyphHaveXYScale != 0:
+			pos += 4
+		case flags&glyphHaveTwoByTwo != 0:
+			pos += 8
+		}
+		if flags&glyphMoreComponents == 0 {
+			break
+		}
+	}
+	return result
+}
+
+// writeTrueType テーブルからフォントファイルを組み立てる テーブルは4バイト境界に揃える
+// 各テーブルのファイル内の位置も返す
+func writeTrueType(tables map[string][]byte) ([]byte, map[string]int) {
+	tags := make([]string, 0, len(tables))
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	numTables := len(tags)
+	entrySelector := 0
+	for 1<<(entrySelector+1) <= numTables {
+		entrySelector++
+	}
+	searchRange := (1 << entrySelector) * 16
+
+	var buf bytes.Buffer
+	header := make([]byte, 12+numTables*16)
+	binary.BigEndian.PutUint32(header, 0x00010000)
+	binary.BigEndian.PutUint16(header[4:], uint16(numTables))
+	binary.BigEndian.PutUint16(header[6:], uint16(searchRange))
+	binary.BigEndian.PutUint16(header[8:], uint16(entrySelector))
+	binary.BigEndian.PutUint16(header[10:], uint16(numTables*16-searchRange))
+
+	offsets := make(map[string]int, numTables)
+	offset := len(header)
+	for i, tag := range tags {
+		offsets[tag] = offset
+		data := tables[tag]
+		rec := header[12+i*16:]
+		copy(rec, tag)
+		binary.BigEndian.PutUint32(rec[4:], trueTypeChecksum(data))
+		binary.BigEndian.PutUint32(rec[8:], uint32(offset))
+		binary.BigEndian.PutUint32(rec[12:], uint32(len(data)))
+		offset += (len(data) + 3) &^ 3
+	}
+	buf.Write(header)
+	for _, tag := range tags {
+		data := tables[tag]
+		buf.Write(data)
+		buf.Write(make([]byte, (4-len(data)%4)%4))
+	}
+	return buf.Bytes(), offsets
+}
+
+// trueTypeChecksum テーブルのチェックサム 4バイトずつの合計
+func trueTypeChecksum(data []byte) uint32 {
+	var sum uint32
+	for i := 0; i < len(data); i += 4 {
+		var word [4]byte
+		copy(word[:], data[i:])
+		sum += binary.BigEndian.Uint32(word[:])
+	}
+	return sum
+}
+
+// subsetTag サブセットフォント名の接頭辞 使用グリフから決まる英大文字6文字
+func subsetTag(gids []uint16) string {
+	h := fnv.New32a()
+	for _, gid := range gids {
+		_ = binary.Write(h, binary.BigEndian, gid)
+	}
+	sum := h.Sum32()
+	tag := make([]byte, 6)
+	for i := range tag {
+		tag[i] = 'A' + byte(sum%26)
+		sum /= 26
+	}
+	return string(tag)
+}
@@ -0,0 +1,146 @@
+// Package infrastructure レポートインフラストラクチャ層
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"shiftmaster/internal/modules/report/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// ReportModel レポートDBモデル
+type ReportModel struct {
+	bun.BaseModel `bun:"table:reports"`
+
+	ID             uuid.UUID  `bun:"id,pk,type:uuid"`
+	OrganizationID uuid.UUID  `bun:"organization_id,type:uuid,notnull"`
+	Type           string     `bun:"type,notnull"`
+	Title          string     `bun:"title,notnull"`
+	TargetYear     int        `bun:"target_year,notnull"`
+	TargetMonth    int        `bun:"target_month,notnull"`
+	Status         string     `bun:"status,notnull"`
+	GeneratedAt    *time.Time `bun:"generated_at"`
+	FilePath       string     `bun:"file_path,nullzero"`
+	CreatedAt      time.Time  `bun:"created_at,notnull"`
+	UpdatedAt      time.Time  `bun:"updated_at,notnull"`
+}
+
+// ToDomain DBモデルからドメインエンティティへ変換
+func (m *ReportModel) ToDomain() *domain.Report {
+	return &domain.Report{
+		ID:             m.ID,
+		OrganizationID: m.OrganizationID,
+		Type:           domain.ReportType(m.Type),
+		Title:          m.Title,
+		TargetYear:     m.TargetYear,
+		TargetMonth:    m.TargetMonth,
+		Status:         domain.ReportStatus(m.Status),
+		GeneratedAt:    m.GeneratedAt,
+		FilePath:       m.FilePath,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
+
+// PostgresReportRepository PostgreSQLレポートリポジトリ
+type PostgresReportRepository struct {
+	db *bun.DB
+}
+
+// NewPostgresReportRepository リポジトリ生成
+func NewPostgresReportRepository(db *bun.DB) *PostgresReportRepository {
+	return &PostgresReportRepository{db: db}
+}
+
+// FindByID IDで検索
+func (r *PostgresReportRepository) FindByID(ctx context.Context, id sharedDomain.ID) (*domain.Report, error) {
+	model := &ReportModel{}
+	err := r.db.NewSelect().Model(model).Where("id = ?", id).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.ToDomain(), nil
+}
+
+// FindByOrganizationID 組織IDで検索 新しい順
+func (r *PostgresReportRepository) FindByOrganizationID(ctx context.Context, organizationID sharedDomain.ID) ([]domain.Report, error) {
+	var models []ReportModel
+	err := r.db.NewSelect().
+		Model(&models).
+		Where("organization_id = ?", organizationID).
+		Order("created_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toReports(models), nil
+}
+
+// FindByType 種別で検索 新しい順
+func (r *PostgresReportRepository) FindByType(ctx context.Context, organizationID sharedDomain.ID, reportType domain.ReportType) ([]domain.Report, error) {
+	var models []ReportModel
+	err := r.db.NewSelect().
+		Model(&models).
+		Where("organization_id = ?", organizationID).
+		Where("type = ?", reportType.String()).
+		Order("created_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toReports(models), nil
+}
+
+// Save 保存
+func (r *PostgresReportRepository) Save(ctx context.Context, report *domain.Report) error {
+	model := &ReportModel{
+		ID:             report.ID,
+		OrganizationID: report.OrganizationID,
+		Type:           report.Type.String(),
+		Title:          report.Title,
+		TargetYear:     report.TargetYear,
+		TargetMonth:    report.TargetMonth,
+		Status:         report.Status.String(),
+		GeneratedAt:    report.GeneratedAt,
+		FilePath:       report.FilePath,
+		CreatedAt:      report.CreatedAt,
+		UpdatedAt:      report.UpdatedAt,
+	}
+
+	_, err := r.db.NewInsert().
+		Model(model).
+		On("CONFLICT (id) DO UPDATE").
+		Set("status = EXCLUDED.status").
+		Set("generated_at = EXCLUDED.generated_at").
+		Set("file_path = EXCLUDED.file_path").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+
+	return err
+}
+
+// Delete 削除
+func (r *PostgresReportRepository) Delete(ctx context.Context, id sharedDomain.ID) error {
+	_, err := r.db.NewDelete().Model((*ReportModel)(nil)).Where("id = ?", id).Exec(ctx)
+	return err
+}
+
+// toReports DBモデル一覧をドメインエンティティ一覧へ変換
+func toReports(models []ReportModel) []domain.Report {
+	reports := make([]domain.Report, len(models))
+	for i := range models {
+		reports[i] = *models[i].ToDomain()
+	}
+	return reports
+}
@@ -0,0 +1,266 @@
+// Package infrastructure レポートインフラストラクチャ層
+package infrastructure
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"shiftmaster/internal/modules/report/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// 掲示用勤務表の配色 RRGGBB形式
+const (
+	rosterTextColor  = "1E293B"
+	rosterMutedColor = "475569"
+	rosterLineColor  = "94A3B8"
+)
+
+// rosterLayout 用紙サイズごとのレイアウト ポイント単位
+type rosterLayout struct {
+	width     float64
+	height    float64
+	margin    float64
+	fontSize  float64
+	titleSize float64
+	rowHeight float64
+	nameWidth float64
+}
+
+// rosterLayouts 用紙サイズごとのレイアウト いずれも横向き
+var rosterLayouts = map[domain.PaperSize]rosterLayout{
+	domain.PaperSizeA4: {width: 841.89, height: 595.28, margin: 24, fontSize: 7, titleSize: 13, rowHeight: 14, nameWidth: 78},
+	domain.PaperSizeA3: {width: 1190.55, height: 841.89, margin: 32, fontSize: 9, titleSize: 18, rowHeight: 18, nameWidth: 110},
+}
+
+// rosterPage 1ページに載せるチームのスタッフ
+type rosterPage struct {
+	team      *domain.RosterTeam
+	staffs    []domain.RosterStaff
+	continued bool
+	last      bool
+}
+
+// legendItem 凡例の項目
+type legendItem struct {
+	code  string
+	label string
+	color string
+	width float64
+}
+
+// PDFRosterRenderer 掲示用勤務表のPDF出力 チームごとに改ページする
+type PDFRosterRenderer struct{}
+
+// NewPDFRosterRenderer PDF出力生成
+func NewPDFRosterRenderer() *PDFRosterRenderer {
+	return &PDFRosterRenderer{}
+}
+
+// RenderPDF PDF出力 1ページに収まらないチームは複数ページに分割する
+func (r *PDFRosterRenderer) RenderPDF(roster *domain.Roster, paper domain.PaperSize) ([]byte, error) {
+	layout, ok := rosterLayouts[paper]
+	if !ok {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "用紙サイズが不正です")
+	}
+
+	days := roster.DaysInMonth()
+	kinds := make([]dayKind, days)
+	weekdays := make([]string, days)
+	for d := range days {
+		date := time.Date(roster.TargetYear, time.Month(roster.TargetMonth), d+1, 0, 0, 0, 0, time.UTC)
+		kinds[d] = dayKindOf(date)
+		weekdays[d] = (&domain.DailySummary{Date: date}).DayOfWeek()
+	}
+
+	legend := layoutLegend(roster.ShiftTypes, layout)
+	legendHeight := float64(len(legend)) * (layout.fontSize + 8)
+	gridTop := layout.margin + layout.titleSize + layout.fontSize + 14
+	// 見出し2行と出勤人数行を除いた高さに入る行数
+	available := layout.height - layout.margin - gridTop - legendHeight - layout.rowHeight*3 - 6
+	pages := paginateRoster(roster.Teams, max(int(available/layout.rowHeight), 1))
+
+	colors := make(map[string]string, len(roster.ShiftTypes))
+	holidays := make(map[string]bool, len(roster.ShiftTypes))
+	for _, st := range roster.ShiftTypes {
+		colors[st.Code] = normalizeColor(st.Color)
+		holidays[st.Code] = st.IsHoliday
+	}
+
+	doc := newPDFDocument(layout.width, layout.height)
+	for i, p := range pages {
+		page := doc.addPage()
+		drawRosterHeader(page, roster, p, layout, i+1, len(pages))
+
+		if p.team == nil {
+			page.text(layout.margin, gridTop+layout.rowHeight, layout.fontSize+2, "勤務予定がありません", rosterMutedColor)
+		} else {
+			drawRosterGrid(page, p, layout, gridTop, kinds, weekdays, colors, holidays)
+		}
+
+		drawLegend(page, legend, layout, layout.height-layout.margin-legendHeight)
+	}
+
+	return doc.Bytes()
+}
+
+// paginateRoster チームごとにページを分け、行数を超える分は次ページに送る
+func paginateRoster(teams []domain.RosterTeam, rowsPerPage int) []rosterPage {
+	var pages []rosterPage
+	for i := range teams {
+		team := &teams[i]
+		staffs := team.Staffs
+		for start := 0; start == 0 || start < len(staffs); start += rowsPerPage {
+			end := min(start+rowsPerPage, len(staffs))
+			pages = append(pages, rosterPage{
+				team:      team,
+				staffs:    staffs[start:end],
+				continued: start > 0,
+				last:      end == len(staffs),
+			})
+		}
+	}
+	if len(pages) == 0 {
+		pages = append(pages, rosterPage{last: true})
+	}
+	return pages
+}
+
+// drawRosterHeader 組織名・対象期間・チーム名・ページ番号
+func drawRosterHeader(page *pdfPage, roster *domain.Roster, p rosterPage, layout rosterLayout, pageNo, pageCount int) {
+	fs := layout.fontSize
+	right := layout.width - layout.margin
+
+	baseline := layout.margin + layout.titleSize
+	page.text(layout.margin, baseline, fs+3, roster.OrganizationName, rosterTextColor)
+	title := roster.TargetPeriodLabel() + " 勤務表"
+	page.text((layout.width-pdfTextWidth(title, layout.titleSize))/2, baseline, layout.titleSize, title, rosterTextColor)
+	if roster.PublishedAt != nil {
+		published := "公開日 " + roster.PublishedAt.In(time.Local).Format("2006/01/02")
+		page.text(right-pdfTextWidth(published, fs), baseline, fs, published, rosterMutedColor)
+	}
+
+	baseline += fs + 8
+	if p.team != nil {
+		teamLabel := p.team.TeamName
+		if p.team.DepartmentName != "" {
+			teamLabel = p.team.DepartmentName + " / " + p.team.TeamName
+		}
+		if p.continued {
+			teamLabel += "（続き）"
+		}
+		page.text(layout.margin, baseline, fs+2, teamLabel, rosterTextColor)
+	}
+	pageLabel := strconv.Itoa(pageNo) + " / " + strconv.Itoa(pageCount)
+	page.text(right-pdfTextWidth(pageLabel, fs), baseline, fs, pageLabel, rosterMutedColor)
+}
+
+// drawRosterGrid スタッフ×日のシフトコード表 チームの最終ページには出勤人数を付ける
+func drawRosterGrid(page *pdfPage, p rosterPage, layout rosterLayout, top float64, kinds []dayKind, weekdays []string, colors map[string]string, holidays map[string]bool) {
+	fs := layout.fontSize
+	rowH := layout.rowHeight
+	days := len(kinds)
+	x0 := layout.margin
+	dayW := (layout.width - layout.margin*2 - layout.nameWidth) / float64(days)
+	dayX := func(d int) float64 { return x0 + layout.nameWidth + dayW*float64(d) }
+	cell := func(x, y, w float64, fill, text, color string) {
+		if fill != "" {
+			page.fillRect(x, y, w, rowH, fill)
+		}
+		page.strokeRect(x, y, w, rowH, 0.4, rosterLineColor)
+		if text != "" {
+			page.textCentered(x, y, w, rowH, fs, pdfTruncate(text, fs, w-2), color)
+		}
+	}
+
+	// 見出し 日付と曜日の2行
+	page.fillRect(x0, top, layout.nameWidth, rowH*2, weekdayHeaderFill)
+	page.strokeRect(x0, top, layout.nameWidth, rowH*2, 0.4, rosterLineColor)
+	page.textCentered(x0, top, layout.nameWidth, rowH*2, fs, "氏名", rosterTextColor)
+	for d := range days {
+		color := kinds[d].font
+		if color == "" {
+			color = rosterTextColor
+		}
+		cell(dayX(d), top, dayW, kinds[d].headerFill, strconv.Itoa(d+1), color)
+		cell(dayX(d), top+rowH, dayW, kinds[d].headerFill, weekdays[d], color)
+	}
+
+	y := top + rowH*2
+	for _, staff := range p.staffs {
+		page.strokeRect(x0, y, layout.nameWidth, rowH, 0.4, rosterLineColor)
+		page.text(x0+3, y+rowH/2+fs*0.35, fs, pdfTruncate(staff.Name, fs, layout.nameWidth-6), rosterTextColor)
+		for d, code := range staff.ShiftCodes[:min(len(staff.ShiftCodes), days)] {
+			fill, color := kinds[d].cellFill, rosterTextColor
+			if c := colors[code]; code != "" && c != "" {
+				fill, color = c, contrastColor(c)
+			}
+			cell(dayX(d), y, dayW, fill, code, color)
+		}
+		y += rowH
+	}
+
+	if !p.last {
+		return
+	}
+	cell(x0, y, layout.nameWidth, weekdayHeaderFill, "出勤人数", rosterTextColor)
+	for d := range days {
+		count := 0
+		for _, staff := range p.team.Staffs {
+			if d < len(staff.ShiftCodes) && staff.ShiftCodes[d] != "" && !holidays[staff.ShiftCodes[d]] {
+				count++
+			}
+		}
+		cell(dayX(d), y, dayW, weekdayHeaderFill, strconv.Itoa(count), rosterTextColor)
+	}
+}
+
+// layoutLegend 凡例を用紙幅で折り返して行に分ける
+func layoutLegend(shiftTypes []domain.RosterShiftType, layout rosterLayout) [][]legendItem {
+	fs := layout.fontSize
+	maxWidth := layout.width - layout.margin*2
+	var lines [][]legendItem
+	var line []legendItem
+	lineWidth := 0.0
+	for _, st := range shiftTypes {
+		label := st.Name
+		if !st.IsHoliday {
+			label = fmt.Sprintf("%s %s〜%s", st.Name, st.StartTime, st.EndTime)
+		}
+		item := legendItem{code: st.Code, label: label, color: normalizeColor(st.Color)}
+		item.width = pdfTextWidth(st.Code, fs) + 6 + 4 + pdfTextWidth(label, fs) + 14
+		if len(line) > 0 && lineWidth+item.width > maxWidth {
+			lines = append(lines, line)
+			line, lineWidth = nil, 0
+		}
+		line = append(line, item)
+		lineWidth += item.width
+	}
+	if len(line) > 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// drawLegend 凡例 シフトコードを表示色で塗り、名称と時間帯を添える
+func drawLegend(page *pdfPage, lines [][]legendItem, layout rosterLayout, top float64) {
+	fs := layout.fontSize
+	lineH := fs + 8
+	for i, line := range lines {
+		y := top + float64(i)*lineH
+		x := layout.margin
+		for _, item := range line {
+			swatchW := pdfTextWidth(item.code, fs) + 6
+			fill, color := weekdayHeaderFill, rosterTextColor
+			if item.color != "" {
+				fill, color = item.color, contrastColor(item.color)
+			}
+			page.fillRect(x, y+2, swatchW, fs+4, fill)
+			page.strokeRect(x, y+2, swatchW, fs+4, 0.4, rosterLineColor)
+			page.textCentered(x, y+2, swatchW, fs+4, fs, item.code, color)
+			page.text(x+swatchW+4, y+2+(fs+4)/2+fs*0.35, fs, item.label, rosterTextColor)
+			x += item.width
+		}
+	}
+}
@@ -0,0 +1,241 @@
+// Package infrastructure レポートインフラストラクチャ層テスト
+package infrastructure
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"shiftmaster/internal/modules/report/domain"
+	scheduleDomain "shiftmaster/internal/modules/schedule/domain"
+
+	"github.com/google/uuid"
+)
+
+// newTestRoster 検証用の掲示用勤務表 チームごとのスタッフ数を指定
+func newTestRoster(staffCounts ...int) *domain.Roster {
+	roster := &domain.Roster{
+		OrganizationName: "さくら病院",
+		TargetYear:       2025,
+		TargetMonth:      5,
+		Status:           scheduleDomain.StatusPublished,
+		ShiftTypes: []domain.RosterShiftType{
+			{Code: "D", Name: "日勤", Color: "#fde68a", StartTime: "08:30", EndTime: "17:30"},
+			{Code: "休", Name: "公休", Color: "#e5e7eb", IsHoliday: true},
+		},
+	}
+	for i, count := range staffCounts {
+		team := domain.RosterTeam{DepartmentName: "看護部", TeamName: fmt.Sprintf("%d病棟", i+1)}
+		for j := range count {
+			codes := make([]string, 31)
+			codes[0], codes[1] = "D", "休"
+			team.Staffs = append(team.Staffs, domain.RosterStaff{Name: fmt.Sprintf("職員 %d", j+1), ShiftCodes: codes})
+		}
+		roster.Teams = append(roster.Teams, team)
+	}
+	return roster
+}
+
+// checkPDFStructure 相互参照表のオフセットが各オブジェクトを指しているか検証
+func checkPDFStructure(t *testing.T, data []byte) {
+	t.Helper()
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(data, []byte("%%EOF\n")) {
+		t.Fatal("missing PDF header or trailer")
+	}
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(data)
+	if m == nil {
+		t.Fatal("startxref not found")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	if !bytes.HasPrefix(data[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d does not point to xref", xref)
+	}
+	lines := strings.Split(string(data[xref:]), "\n")
+	var count int
+	_, _ = fmt.Sscanf(lines[1], "0 %d", &count)
+	for i := 1; i < count; i++ {
+		offset, _ := strconv.Atoi(lines[2+i][:10])
+		if want := fmt.Sprintf("%d 0 obj\n", i); !bytes.HasPrefix(data[offset:], []byte(want)) {
+			t.Errorf("xref entry %d points to %q", i, data[offset:offset+10])
+		}
+	}
+}
+
+// pdfPageContents 全ページの内容ストリームを展開
+func pdfPageContents(t *testing.T, data []byte) string {
+	t.Helper()
+	var sb strings.Builder
+	for _, m := range regexp.MustCompile(`(?s)stream\n(.*?)\nendstream`).FindAllSubmatch(data, -1) {
+		zr, err := zlib.NewReader(bytes.NewReader(m[1]))
+		if err != nil {
+			t.Fatalf("zlib.NewReader() error = %v", err)
+		}
+		body, err := io.ReadAll(zr)
+		if err != nil {
+			t.Fatalf("ReadAll() error = %v", err)
+		}
+		sb.Write(body)
+	}
+	return sb.String()
+}
+
+func TestPDFRosterRenderer_RenderPDF(t *testing.T) {
+	renderer := NewPDFRosterRenderer()
+
+	// 1病棟はA4で1ページに収まらず2ページ、2病棟は1ページ
+	data, err := renderer.RenderPDF(newTestRoster(40, 3), domain.PaperSizeA4)
+	if err != nil {
+		t.Fatalf("RenderPDF() error = %v", err)
+	}
+	checkPDFStructure(t, data)
+	if !bytes.Contains(data, []byte("/Count 3 ")) {
+		t.Error("A4 roster should have 3 pages")
+	}
+	if !bytes.Contains(data, []byte("/MediaBox [0 0 841.89 595.28]")) {
+		t.Error("A4 landscape media box missing")
+	}
+
+	contents := pdfPageContents(t, data)
+	for _, want := range []string{"2025年5月 勤務表", "さくら病院", "看護部 / 1病棟（続き）", "看護部 / 2病棟", "日勤 08:30〜17:30", "出勤人数", "3 / 3"} {
+		if !strings.Contains(contents, "<"+pdfEncodeText(want)+">") {
+			t.Errorf("page contents missing %q", want)
+		}
+	}
+
+	a3, err := renderer.RenderPDF(newTestRoster(3), domain.PaperSizeA3)
+	if err != nil {
+		t.Fatalf("RenderPDF(A3) error = %v", err)
+	}
+	checkPDFStructure(t, a3)
+	if !bytes.Contains(a3, []byte("/MediaBox [0 0 1190.55 841.89]")) {
+		t.Error("A3 landscape media box missing")
+	}
+
+	empty, err := renderer.RenderPDF(newTestRoster(), domain.PaperSizeA4)
+	if err != nil {
+		t.Fatalf("RenderPDF(empty) error = %v", err)
+	}
+	if !strings.Contains(pdfPageContents(t, empty), "<"+pdfEncodeText("勤務予定がありません")+">") {
+		t.Error("empty roster should show a message")
+	}
+
+	if _, err := renderer.RenderPDF(newTestRoster(1), domain.PaperSize("b4")); err == nil {
+		t.Error("RenderPDF() expected error for unknown paper size")
+	}
+}
+
+func TestPDFRosterRenderer_EmbedsFontSubset(t *testing.T) {
+	data, err := NewPDFRosterRenderer().RenderPDF(newTestRoster(3), domain.PaperSizeA4)
+	if err != nil {
+		t.Fatalf("RenderPDF() error = %v", err)
+	}
+	for _, want := range []string{"/Encoding /Identity-H", "/CIDFontType2", "/FontFile2 6 0 R", "/ToUnicode 7 0 R"} {
+		if !bytes.Contains(data, []byte(want)) {
+			t.Errorf("PDF missing %q", want)
+		}
+	}
+
+	m := regexp.MustCompile(`(?s)6 0 obj\n<< /Length \d+ /Length1 (\d+) /Filter /FlateDecode >>\nstream\n(.*?)\nendstream`).FindSubmatch(data)
+	if m == nil {
+		t.Fatal("embedded font stream not found")
+	}
+	zr, err := zlib.NewReader(bytes.NewReader(m[2]))
+	if err != nil {
+		t.Fatalf("zlib.NewReader() error = %v", err)
+	}
+	fontData, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if length1, _ := strconv.Atoi(string(m[1])); length1 != len(fontData) {
+		t.Errorf("Length1 = %d, want %d", length1, len(fontData))
+	}
+	if len(fontData) >= len(pdfFontData) {
+		t.Errorf("subset size = %d, want smaller than %d", len(fontData), len(pdfFontData))
+	}
+
+	// サブセットでも同じグリフIDで使用した文字の字形が残り、使っていない文字は空になる
+	subset, err := parseTrueType(fontData)
+	if err != nil {
+		t.Fatalf("parseTrueType(subset) error = %v", err)
+	}
+	font := pdfFont()
+	for _, r := range "勤務表さくら病院D" {
+		if len(subset.glyphData(font.glyph(r))) == 0 {
+			t.Errorf("glyph for %q missing from subset", r)
+		}
+	}
+	if len(subset.glyphData(font.glyph('鬱'))) != 0 {
+		t.Error("unused glyph should be emptied")
+	}
+
+	// ToUnicodeでグリフIDから元の文字に戻せる
+	if contents := pdfPageContents(t, data); !strings.Contains(contents, fmt.Sprintf("<%04X> <52E4>", font.glyph('勤'))) {
+		t.Error("ToUnicode CMap missing mapping for 勤")
+	}
+}
+
+func TestPDFTextWidth(t *testing.T) {
+	if got, want := pdfTextWidth("日勤", 10), 20.0; got != want {
+		t.Errorf("pdfTextWidth(全角) = %v, want %v", got, want)
+	}
+	if half, full := pdfTextWidth("A", 10), pdfTextWidth("あ", 10); half <= 0 || half >= full {
+		t.Errorf("pdfTextWidth(A) = %v, want between 0 and %v", half, full)
+	}
+}
+
+func TestPaginateRoster(t *testing.T) {
+	roster := newTestRoster(3, 1)
+	pages := paginateRoster(roster.Teams, 2)
+
+	want := []struct {
+		team      string
+		staffs    int
+		continued bool
+		last      bool
+	}{
+		{"1病棟", 2, false, false},
+		{"1病棟", 1, true, true},
+		{"2病棟", 1, false, true},
+	}
+	if len(pages) != len(want) {
+		t.Fatalf("pages = %d, want %d", len(pages), len(want))
+	}
+	for i, w := range want {
+		p := pages[i]
+		if p.team.TeamName != w.team || len(p.staffs) != w.staffs || p.continued != w.continued || p.last != w.last {
+			t.Errorf("page %d = {%s %d %v %v}, want %+v", i, p.team.TeamName, len(p.staffs), p.continued, p.last, w)
+		}
+	}
+}
+
+func TestBuildRosterTeams(t *testing.T) {
+	teamA, teamB := uuid.New(), uuid.New()
+	staffA, staffB := uuid.New(), uuid.New()
+	date := func(day int) time.Time {
+		return time.Date(2025, 5, day, 0, 0, 0, 0, time.UTC)
+	}
+	rows := []rosterEntryRow{
+		{StaffID: staffA, LastName: "山田", FirstName: "花子", TeamID: teamA, TeamName: "1病棟", DepartmentName: "看護部", TargetDate: date(1), ShiftCode: ptr("D")},
+		{StaffID: staffA, LastName: "山田", FirstName: "花子", TeamID: teamA, TeamName: "1病棟", DepartmentName: "看護部", TargetDate: date(2)},
+		{StaffID: staffB, LastName: "佐藤", FirstName: "太郎", TeamID: teamB, TeamName: "2病棟", DepartmentName: "看護部", TargetDate: date(31), ShiftCode: ptr("N")},
+	}
+
+	teams := buildRosterTeams(2025, 5, 31, rows)
+	if len(teams) != 2 {
+		t.Fatalf("teams = %d, want 2", len(teams))
+	}
+	a := teams[0].Staffs[0]
+	if a.Name != "山田 花子" || a.ShiftCodes[0] != "D" || a.ShiftCodes[1] != "" {
+		t.Errorf("staff A = %+v", a)
+	}
+	if b := teams[1].Staffs[0]; teams[1].TeamName != "2病棟" || b.ShiftCodes[30] != "N" {
+		t.Errorf("team B = %+v", teams[1])
+	}
+}
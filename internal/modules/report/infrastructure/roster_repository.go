@@ -0,0 +1,159 @@
+// Package infrastructure レポートインフラストラクチャ層
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"shiftmaster/internal/modules/report/domain"
+	scheduleDomain "shiftmaster/internal/modules/schedule/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// rosterEntryRow 掲示用勤務表のエントリ行 チーム・シフトコードを結合
+type rosterEntryRow struct {
+	StaffID        uuid.UUID `bun:"staff_id"`
+	EmployeeCode   string    `bun:"employee_code"`
+	LastName       string    `bun:"last_name"`
+	FirstName      string    `bun:"first_name"`
+	TeamID         uuid.UUID `bun:"team_id"`
+	TeamName       string    `bun:"team_name"`
+	DepartmentName string    `bun:"department_name"`
+	TargetDate     time.Time `bun:"target_date"`
+	ShiftCode      *string   `bun:"shift_code"`
+}
+
+// PostgresRosterRepository PostgreSQL掲示用勤務表リポジトリ
+type PostgresRosterRepository struct {
+	db *bun.DB
+}
+
+// NewPostgresRosterRepository リポジトリ生成
+func NewPostgresRosterRepository(db *bun.DB) *PostgresRosterRepository {
+	return &PostgresRosterRepository{db: db}
+}
+
+// FindByScheduleID 勤務表IDで検索 存在しない場合はnil
+func (r *PostgresRosterRepository) FindByScheduleID(ctx context.Context, scheduleID sharedDomain.ID) (*domain.Roster, error) {
+	var header struct {
+		OrganizationID   uuid.UUID  `bun:"organization_id"`
+		OrganizationName string     `bun:"organization_name"`
+		TargetYear       int        `bun:"target_year"`
+		TargetMonth      int        `bun:"target_month"`
+		Status           string     `bun:"status"`
+		PublishedAt      *time.Time `bun:"published_at"`
+	}
+	err := r.db.NewSelect().
+		TableExpr("schedules AS s").
+		ColumnExpr("s.organization_id, o.name AS organization_name, s.target_year, s.target_month, s.status, s.published_at").
+		Join("INNER JOIN organizations AS o ON o.id = s.organization_id").
+		Where("s.id = ?", scheduleID).
+		Scan(ctx, &header)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var rows []rosterEntryRow
+	err = r.db.NewSelect().
+		TableExpr("schedule_entries AS e").
+		ColumnExpr("e.staff_id, COALESCE(st.employee_code, '') AS employee_code, st.last_name, st.first_name").
+		ColumnExpr("tm.id AS team_id, tm.name AS team_name, d.name AS department_name").
+		ColumnExpr("e.target_date, t.code AS shift_code").
+		Join("INNER JOIN staffs AS st ON st.id = e.staff_id").
+		Join("INNER JOIN teams AS tm ON tm.id = st.team_id").
+		Join("INNER JOIN departments AS d ON d.id = tm.department_id").
+		Join("LEFT JOIN shift_types AS t ON t.id = e.shift_type_id").
+		Where("e.schedule_id = ?", scheduleID).
+		Order("d.sort_order ASC", "d.name ASC", "tm.sort_order ASC", "tm.name ASC", "tm.id ASC").
+		Order("st.employee_code ASC", "st.last_name ASC", "st.first_name ASC", "e.staff_id ASC", "e.target_date ASC").
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, err
+	}
+
+	var shiftTypes []struct {
+		Code      string `bun:"code"`
+		Name      string `bun:"name"`
+		Color     string `bun:"color"`
+		StartTime string `bun:"start_time"`
+		EndTime   string `bun:"end_time"`
+		IsHoliday bool   `bun:"is_holiday"`
+	}
+	err = r.db.NewSelect().
+		TableExpr("shift_types AS t").
+		ColumnExpr("t.code, t.name, t.color, t.start_time::text AS start_time, t.end_time::text AS end_time, t.is_holiday").
+		Where("t.id IN (SELECT shift_type_id FROM schedule_entries WHERE schedule_id = ?)", scheduleID).
+		Order("t.sort_order ASC", "t.code ASC").
+		Scan(ctx, &shiftTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	roster := &domain.Roster{
+		ScheduleID:       scheduleID,
+		OrganizationID:   header.OrganizationID,
+		OrganizationName: header.OrganizationName,
+		TargetYear:       header.TargetYear,
+		TargetMonth:      header.TargetMonth,
+		Status:           scheduleDomain.ScheduleStatus(header.Status),
+		PublishedAt:      header.PublishedAt,
+		ShiftTypes:       make([]domain.RosterShiftType, len(shiftTypes)),
+	}
+	for i, st := range shiftTypes {
+		roster.ShiftTypes[i] = domain.RosterShiftType{
+			Code:      st.Code,
+			Name:      st.Name,
+			Color:     st.Color,
+			StartTime: clockLabel(st.StartTime),
+			EndTime:   clockLabel(st.EndTime),
+			IsHoliday: st.IsHoliday,
+		}
+	}
+	roster.Teams = buildRosterTeams(roster.TargetYear, roster.TargetMonth, roster.DaysInMonth(), rows)
+
+	return roster, nil
+}
+
+// buildRosterTeams エントリ行をチーム・スタッフ別にまとめる rowsはチーム・スタッフごとにまとまっていること
+func buildRosterTeams(year, month, days int, rows []rosterEntryRow) []domain.RosterTeam {
+	var teams []domain.RosterTeam
+	var teamID, staffID uuid.UUID
+	for i := range rows {
+		row := &rows[i]
+		if len(teams) == 0 || row.TeamID != teamID {
+			teamID = row.TeamID
+			staffID = uuid.Nil
+			teams = append(teams, domain.RosterTeam{DepartmentName: row.DepartmentName, TeamName: row.TeamName})
+		}
+		team := &teams[len(teams)-1]
+		if row.StaffID != staffID {
+			staffID = row.StaffID
+			team.Staffs = append(team.Staffs, domain.RosterStaff{
+				EmployeeCode: row.EmployeeCode,
+				Name:         row.LastName + " " + row.FirstName,
+				ShiftCodes:   make([]string, days),
+			})
+		}
+		staff := &team.Staffs[len(team.Staffs)-1]
+		if row.ShiftCode != nil && row.TargetDate.Year() == year && int(row.TargetDate.Month()) == month {
+			staff.ShiftCodes[row.TargetDate.Day()-1] = *row.ShiftCode
+		}
+	}
+	return teams
+}
+
+// clockLabel TIME型の文字列をHH:MMに変換
+func clockLabel(t string) string {
+	if len(t) >= 5 {
+		return t[:5]
+	}
+	return t
+}
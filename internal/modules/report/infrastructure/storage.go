@@ -0,0 +1,90 @@
+// Package infrastructure レポートインフラストラクチャ層
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// LocalReportStorage ローカルディスクのレポート保存先
+// 保存先パスはベースディレクトリからの相対パスで記録する
+type LocalReportStorage struct {
+	baseDir string
+}
+
+// NewLocalReportStorage ローカル保存先生成
+func NewLocalReportStorage(baseDir string) *LocalReportStorage {
+	return &LocalReportStorage{baseDir: baseDir}
+}
+
+// Save ファイル保存 一時ファイルに書き込んでから置き換える
+func (s *LocalReportStorage) Save(_ context.Context, name string, data []byte) (string, error) {
+	fullPath, err := s.resolve(name)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0o750); err != nil {
+		return "", err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(fullPath), ".report-*")
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), fullPath); err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(filepath.Clean(name)), nil
+}
+
+// Load ファイル読み込み
+func (s *LocalReportStorage) Load(_ context.Context, path string) ([]byte, error) {
+	fullPath, err := s.resolve(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(fullPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, sharedDomain.ErrNotFound
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+// Delete ファイル削除 存在しない場合は何もしない
+func (s *LocalReportStorage) Delete(_ context.Context, path string) error {
+	fullPath, err := s.resolve(path)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(fullPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// resolve 保存先パスを絶対パスに変換 ベースディレクトリ外への参照は拒否
+func (s *LocalReportStorage) resolve(path string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(path))
+	if clean == "." || filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "ファイルパスが不正です")
+	}
+	return filepath.Join(s.baseDir, clean), nil
+}
@@ -0,0 +1,44 @@
+// Package infrastructure レポートインフラストラクチャ層テスト
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+func TestLocalReportStorage(t *testing.T) {
+	ctx := context.Background()
+	storage := NewLocalReportStorage(t.TempDir())
+
+	path, err := storage.Save(ctx, "org/report.csv", []byte("data"))
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if path != "org/report.csv" {
+		t.Errorf("Save() path = %q", path)
+	}
+
+	data, err := storage.Load(ctx, path)
+	if err != nil || string(data) != "data" {
+		t.Errorf("Load() = %q, %v", data, err)
+	}
+
+	if err := storage.Delete(ctx, path); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := storage.Load(ctx, path); !errors.Is(err, sharedDomain.ErrNotFound) {
+		t.Errorf("Load() after Delete error = %v, want ErrNotFound", err)
+	}
+	if err := storage.Delete(ctx, path); err != nil {
+		t.Errorf("Delete() missing file error = %v", err)
+	}
+
+	for _, name := range []string{"../escape.csv", "/etc/passwd", ""} {
+		if _, err := storage.Save(ctx, name, []byte("x")); err == nil {
+			t.Errorf("Save(%q) expected error", name)
+		}
+	}
+}
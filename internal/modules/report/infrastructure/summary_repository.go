@@ -0,0 +1,341 @@
+// Package infrastructure レポートインフラストラクチャ層
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"shiftmaster/internal/modules/report/domain"
+	scheduleDomain "shiftmaster/internal/modules/schedule/domain"
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// entryRow 集計用の勤務表エントリ行 シフト種別と勤務実績を結合
+type entryRow struct {
+	EntryID      uuid.UUID `bun:"entry_id"`
+	StaffID      uuid.UUID `bun:"staff_id"`
+	EmployeeCode string    `bun:"employee_code"`
+	LastName     string    `bun:"last_name"`
+	FirstName    string    `bun:"first_name"`
+	TargetDate   time.Time `bun:"target_date"`
+	Note         string    `bun:"note"`
+
+	ShiftTypeID     *uuid.UUID `bun:"shift_type_id"`
+	ShiftName       *string    `bun:"shift_name"`
+	ShiftCode       *string    `bun:"shift_code"`
+	ShiftColor      *string    `bun:"shift_color"`
+	StartTime       *string    `bun:"start_time"`
+	EndTime         *string    `bun:"end_time"`
+	BreakMinutes    *int       `bun:"break_minutes"`
+	HandoverMinutes *int       `bun:"handover_minutes"`
+	IsNightShift    *bool      `bun:"is_night_shift"`
+	IsHoliday       *bool      `bun:"is_holiday"`
+	SortOrder       *int       `bun:"sort_order"`
+
+	ActualID                   *uuid.UUID `bun:"actual_id"`
+	ActualStartTime            *time.Time `bun:"actual_start_time"`
+	ActualEndTime              *time.Time `bun:"actual_end_time"`
+	ActualBreakMinutes         *int       `bun:"actual_break_minutes"`
+	WithinLegalOvertimeMinutes *int       `bun:"within_legal_overtime_minutes"`
+	OverLegalOvertimeMinutes   *int       `bun:"over_legal_overtime_minutes"`
+	LateNightMinutes           *int       `bun:"late_night_minutes"`
+	HolidayWorkMinutes         *int       `bun:"holiday_work_minutes"`
+	ActualNote                 *string    `bun:"actual_note"`
+}
+
+// staffName スタッフ表示名
+func (r *entryRow) staffName() string {
+	return r.LastName + " " + r.FirstName
+}
+
+// shiftType 予定シフト種別 未割り当てはnil
+func (r *entryRow) shiftType() *shiftDomain.ShiftType {
+	if r.ShiftTypeID == nil {
+		return nil
+	}
+	startTime, _ := time.Parse("15:04:05", deref(r.StartTime))
+	endTime, _ := time.Parse("15:04:05", deref(r.EndTime))
+	return &shiftDomain.ShiftType{
+		ID:              *r.ShiftTypeID,
+		Name:            deref(r.ShiftName),
+		Code:            deref(r.ShiftCode),
+		Color:           deref(r.ShiftColor),
+		StartTime:       startTime,
+		EndTime:         endTime,
+		BreakMinutes:    deref(r.BreakMinutes),
+		HandoverMinutes: deref(r.HandoverMinutes),
+		IsNightShift:    deref(r.IsNightShift),
+		IsHoliday:       deref(r.IsHoliday),
+		SortOrder:       deref(r.SortOrder),
+	}
+}
+
+// record 勤務実績 未登録はnil
+func (r *entryRow) record() *scheduleDomain.ActualRecord {
+	if r.ActualID == nil {
+		return nil
+	}
+	return &scheduleDomain.ActualRecord{
+		ID:                         *r.ActualID,
+		ScheduleEntryID:            r.EntryID,
+		ActualStartTime:            r.ActualStartTime,
+		ActualEndTime:              r.ActualEndTime,
+		ActualBreakMinutes:         deref(r.ActualBreakMinutes),
+		WithinLegalOvertimeMinutes: deref(r.WithinLegalOvertimeMinutes),
+		OverLegalOvertimeMinutes:   deref(r.OverLegalOvertimeMinutes),
+		LateNightMinutes:           deref(r.LateNightMinutes),
+		HolidayWorkMinutes:         deref(r.HolidayWorkMinutes),
+		Note:                       deref(r.ActualNote),
+	}
+}
+
+// isWork 勤務予定か 休日シフト・未割り当て・勤務時間0は休日扱い
+func (r *entryRow) isWork() bool {
+	st := r.shiftType()
+	return st != nil && !st.IsHoliday && st.TotalMinutes() > 0
+}
+
+// workedMinutes 勤務時間 実績の出退勤があれば実績、なければ予定の実働時間
+func (r *entryRow) workedMinutes() int {
+	if rec := r.record(); rec != nil && rec.ActualStartTime != nil && rec.ActualEndTime != nil {
+		return max(rec.ActualWorkingMinutes(), 0)
+	}
+	if r.isWork() {
+		return r.shiftType().WorkingMinutes()
+	}
+	return 0
+}
+
+// deref ポインタの値 nilはゼロ値
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
+
+// PostgresSummaryRepository PostgreSQL集計リポジトリ
+// 勤務表エントリ・シフト種別・勤務実績を結合して取得し、集計はアプリケーション側で行う
+type PostgresSummaryRepository struct {
+	db *bun.DB
+}
+
+// NewPostgresSummaryRepository リポジトリ生成
+func NewPostgresSummaryRepository(db *bun.DB) *PostgresSummaryRepository {
+	return &PostgresSummaryRepository{db: db}
+}
+
+// GetMonthlySummary 月次集計取得 勤務表がない場合はnil
+func (r *PostgresSummaryRepository) GetMonthlySummary(ctx context.Context, organizationID sharedDomain.ID, year, month int) (*domain.MonthlySummary, error) {
+	scheduleID, err := r.FindScheduleID(ctx, organizationID, year, month)
+	if err != nil || scheduleID == nil {
+		return nil, err
+	}
+
+	rows, err := r.findEntryRows(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("e.schedule_id = ?", *scheduleID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buildMonthlySummary(year, month, rows), nil
+}
+
+// GetStaffSummary スタッフ集計取得 勤務予定がない場合はnil
+func (r *PostgresSummaryRepository) GetStaffSummary(ctx context.Context, staffID sharedDomain.ID, year, month int) (*domain.StaffSummary, error) {
+	rows, err := r.findEntryRows(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.
+			Join("INNER JOIN schedules AS s ON s.id = e.schedule_id").
+			Where("e.staff_id = ?", staffID).
+			Where("s.target_year = ?", year).
+			Where("s.target_month = ?", month)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	summary := buildMonthlySummary(year, month, rows)
+	if len(summary.StaffSummaries) == 0 {
+		return nil, nil
+	}
+	return &summary.StaffSummaries[0], nil
+}
+
+// GetDailySummaries 日別集計取得 勤務表がない場合は空
+func (r *PostgresSummaryRepository) GetDailySummaries(ctx context.Context, organizationID sharedDomain.ID, year, month int) ([]domain.DailySummary, error) {
+	summary, err := r.GetMonthlySummary(ctx, organizationID, year, month)
+	if err != nil || summary == nil {
+		return nil, err
+	}
+	return summary.DailySummaries, nil
+}
+
+// FindScheduleID 対象年月の勤務表ID取得 存在しない場合はnil
+func (r *PostgresSummaryRepository) FindScheduleID(ctx context.Context, organizationID sharedDomain.ID, year, month int) (*sharedDomain.ID, error) {
+	var id uuid.UUID
+	err := r.db.NewSelect().
+		TableExpr("schedules").
+		Column("id").
+		Where("organization_id = ?", organizationID).
+		Where("target_year = ?", year).
+		Where("target_month = ?", month).
+		Scan(ctx, &id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &id, nil
+}
+
+// findEntryRows 勤務表エントリ行取得 スタッフ名・日付順
+func (r *PostgresSummaryRepository) findEntryRows(ctx context.Context, filter func(*bun.SelectQuery) *bun.SelectQuery) ([]entryRow, error) {
+	var rows []entryRow
+	q := r.db.NewSelect().
+		TableExpr("schedule_entries AS e").
+		ColumnExpr("e.id AS entry_id, e.staff_id, e.target_date, COALESCE(e.note, '') AS note").
+		ColumnExpr("COALESCE(st.employee_code, '') AS employee_code, st.last_name, st.first_name").
+		ColumnExpr("t.id AS shift_type_id, t.name AS shift_name, t.code AS shift_code, t.color AS shift_color").
+		ColumnExpr("t.start_time::text AS start_time, t.end_time::text AS end_time").
+		ColumnExpr("t.break_minutes, t.handover_minutes, t.is_night_shift, t.is_holiday, t.sort_order").
+		ColumnExpr("a.id AS actual_id, a.actual_start_time, a.actual_end_time, a.actual_break_minutes").
+		ColumnExpr("a.within_legal_overtime_minutes, a.over_legal_overtime_minutes, a.late_night_minutes, a.holiday_work_minutes").
+		ColumnExpr("a.note AS actual_note").
+		Join("INNER JOIN staffs AS st ON st.id = e.staff_id").
+		Join("LEFT JOIN shift_types AS t ON t.id = e.shift_type_id").
+		Join("LEFT JOIN actual_records AS a ON a.schedule_entry_id = e.id")
+	q = filter(q).Order("st.last_name ASC", "st.first_name ASC", "e.staff_id ASC", "e.target_date ASC")
+
+	if err := q.Scan(ctx, &rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// buildMonthlySummary 勤務表エントリ行から月次集計を構築 rowsはスタッフごとにまとまっていること
+func buildMonthlySummary(year, month int, rows []entryRow) *domain.MonthlySummary {
+	summary := &domain.MonthlySummary{
+		TargetYear:         year,
+		TargetMonth:        month,
+		StaffSummaries:     []domain.StaffSummary{},
+		ShiftTypeSummaries: []domain.ShiftTypeSummary{},
+	}
+
+	// 日別集計は勤務予定のない日も含め月の全日を出力
+	first := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	days := first.AddDate(0, 1, -1).Day()
+	summary.DailySummaries = make([]domain.DailySummary, days)
+	for i := range summary.DailySummaries {
+		summary.DailySummaries[i].Date = first.AddDate(0, 0, i)
+	}
+
+	staffIndex := make(map[uuid.UUID]int)
+	shiftTypeIndex := make(map[uuid.UUID]int)
+	var shiftTypeOrder []int
+
+	for i := range rows {
+		row := &rows[i]
+		st := row.shiftType()
+		isWork := row.isWork()
+		worked := row.workedMinutes()
+
+		idx, ok := staffIndex[row.StaffID]
+		if !ok {
+			idx = len(summary.StaffSummaries)
+			staffIndex[row.StaffID] = idx
+			summary.StaffSummaries = append(summary.StaffSummaries, domain.StaffSummary{
+				StaffID:   row.StaffID,
+				StaffName: row.staffName(),
+			})
+		}
+		staff := &summary.StaffSummaries[idx]
+		switch {
+		case worked > 0:
+			staff.TotalWorkDays++
+			staff.TotalWorkMinutes += worked
+			if isWork && st.IsNightShift {
+				staff.NightShiftCount++
+			}
+		case !isWork:
+			staff.HolidayCount++
+		}
+		if rec := row.record(); rec != nil {
+			staff.AddOvertime(rec.Overtime())
+		}
+
+		if day := row.TargetDate.Day(); row.TargetDate.Year() == year && int(row.TargetDate.Month()) == month {
+			daily := &summary.DailySummaries[day-1]
+			switch {
+			case !isWork:
+				daily.HolidayCount++
+			case st.IsNightShift:
+				daily.TotalStaff++
+				daily.NightShiftCount++
+			default:
+				daily.TotalStaff++
+				daily.DayShiftCount++
+			}
+		}
+
+		if st != nil {
+			sIdx, ok := shiftTypeIndex[st.ID]
+			if !ok {
+				sIdx = len(summary.ShiftTypeSummaries)
+				shiftTypeIndex[st.ID] = sIdx
+				shiftTypeOrder = append(shiftTypeOrder, st.SortOrder)
+				summary.ShiftTypeSummaries = append(summary.ShiftTypeSummaries, domain.ShiftTypeSummary{
+					ShiftTypeID:   st.ID,
+					ShiftTypeName: st.Name,
+					ShiftTypeCode: st.Code,
+				})
+			}
+			shiftType := &summary.ShiftTypeSummaries[sIdx]
+			shiftType.Count++
+			if isWork {
+				shiftType.TotalMinutes += st.WorkingMinutes()
+			}
+		}
+	}
+
+	// シフト種別は表示順、同順はコード順
+	sort.Sort(shiftTypeSorter{summaries: summary.ShiftTypeSummaries, order: shiftTypeOrder})
+
+	for _, staff := range summary.StaffSummaries {
+		summary.TotalWorkDays += staff.TotalWorkDays
+		summary.TotalWorkMinutes += staff.TotalWorkMinutes
+	}
+	if summary.TotalWorkDays > 0 {
+		summary.AverageWorkMinutesPerDay = float64(summary.TotalWorkMinutes) / float64(summary.TotalWorkDays)
+	}
+
+	return summary
+}
+
+// shiftTypeSorter シフト種別集計を表示順で並べ替え
+type shiftTypeSorter struct {
+	summaries []domain.ShiftTypeSummary
+	order     []int
+}
+
+func (s shiftTypeSorter) Len() int { return len(s.summaries) }
+
+func (s shiftTypeSorter) Less(i, j int) bool {
+	if s.order[i] != s.order[j] {
+		return s.order[i] < s.order[j]
+	}
+	return s.summaries[i].ShiftTypeCode < s.summaries[j].ShiftTypeCode
+}
+
+func (s shiftTypeSorter) Swap(i, j int) {
+	s.summaries[i], s.summaries[j] = s.summaries[j], s.summaries[i]
+	s.order[i], s.order[j] = s.order[j], s.order[i]
+}
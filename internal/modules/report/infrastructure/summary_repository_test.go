@@ -0,0 +1,96 @@
+// Package infrastructure レポートインフラストラクチャ層テスト
+package infrastructure
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestBuildMonthlySummary(t *testing.T) {
+	staffA, staffB := uuid.New(), uuid.New()
+	dayID, nightID, offID := uuid.New(), uuid.New(), uuid.New()
+	date := func(day int) time.Time {
+		return time.Date(2025, 4, day, 0, 0, 0, 0, time.UTC)
+	}
+	day := func(staffID uuid.UUID, d int) entryRow {
+		return entryRow{
+			EntryID: uuid.New(), StaffID: staffID, LastName: "山田", FirstName: "花子", TargetDate: date(d),
+			ShiftTypeID: &dayID, ShiftName: ptr("日勤"), ShiftCode: ptr("D"),
+			StartTime: ptr("08:30:00"), EndTime: ptr("17:30:00"), BreakMinutes: ptr(60), SortOrder: ptr(1),
+		}
+	}
+	night := func(staffID uuid.UUID, d int) entryRow {
+		return entryRow{
+			EntryID: uuid.New(), StaffID: staffID, LastName: "佐藤", FirstName: "太郎", TargetDate: date(d),
+			ShiftTypeID: &nightID, ShiftName: ptr("夜勤"), ShiftCode: ptr("N"),
+			StartTime: ptr("16:30:00"), EndTime: ptr("09:00:00"), BreakMinutes: ptr(120), IsNightShift: ptr(true), SortOrder: ptr(2),
+		}
+	}
+	off := func(staffID uuid.UUID, d int) entryRow {
+		return entryRow{
+			EntryID: uuid.New(), StaffID: staffID, LastName: "山田", FirstName: "花子", TargetDate: date(d),
+			ShiftTypeID: &offID, ShiftName: ptr("公休"), ShiftCode: ptr("O"),
+			StartTime: ptr("00:00:00"), EndTime: ptr("00:00:00"), IsHoliday: ptr(true), SortOrder: ptr(9),
+		}
+	}
+
+	// 実績は日勤1日のみ 1時間の残業
+	worked := day(staffA, 2)
+	start := time.Date(2025, 4, 2, 8, 30, 0, 0, time.Local)
+	end := time.Date(2025, 4, 2, 18, 30, 0, 0, time.Local)
+	worked.ActualID = ptr(uuid.New())
+	worked.ActualStartTime = &start
+	worked.ActualEndTime = &end
+	worked.ActualBreakMinutes = ptr(60)
+	worked.WithinLegalOvertimeMinutes = ptr(60)
+
+	rows := []entryRow{day(staffA, 1), worked, off(staffA, 3), night(staffB, 1), off(staffB, 2)}
+	summary := buildMonthlySummary(2025, 4, rows)
+
+	if len(summary.StaffSummaries) != 2 {
+		t.Fatalf("StaffSummaries = %d, want 2", len(summary.StaffSummaries))
+	}
+	a := summary.StaffSummaries[0]
+	if a.TotalWorkDays != 2 || a.TotalWorkMinutes != 480+540 || a.HolidayCount != 1 || a.OvertimeMinutes != 60 {
+		t.Errorf("staff A = %+v", a)
+	}
+	b := summary.StaffSummaries[1]
+	if b.TotalWorkDays != 1 || b.NightShiftCount != 1 || b.TotalWorkMinutes != 870 || b.HolidayCount != 1 {
+		t.Errorf("staff B = %+v", b)
+	}
+
+	if len(summary.DailySummaries) != 30 {
+		t.Fatalf("DailySummaries = %d, want 30", len(summary.DailySummaries))
+	}
+	first := summary.DailySummaries[0]
+	if first.TotalStaff != 2 || first.DayShiftCount != 1 || first.NightShiftCount != 1 || first.DayOfWeek() != "火" {
+		t.Errorf("4/1 = %+v", first)
+	}
+	if second := summary.DailySummaries[1]; second.TotalStaff != 1 || second.HolidayCount != 1 {
+		t.Errorf("4/2 = %+v", second)
+	}
+
+	codes := []string{}
+	for _, s := range summary.ShiftTypeSummaries {
+		codes = append(codes, s.ShiftTypeCode)
+	}
+	if len(codes) != 3 || codes[0] != "D" || codes[1] != "N" || codes[2] != "O" {
+		t.Errorf("ShiftTypeSummaries order = %v", codes)
+	}
+	if d := summary.ShiftTypeSummaries[0]; d.Count != 2 || d.TotalMinutes != 960 {
+		t.Errorf("日勤 = %+v", d)
+	}
+
+	if summary.TotalWorkDays != 3 || summary.TotalWorkMinutes != 480+540+870 {
+		t.Errorf("total = %d days %d minutes", summary.TotalWorkDays, summary.TotalWorkMinutes)
+	}
+	if summary.AverageWorkMinutesPerDay != 630 {
+		t.Errorf("AverageWorkMinutesPerDay = %v, want 630", summary.AverageWorkMinutesPerDay)
+	}
+}
@@ -0,0 +1,354 @@
+// Package infrastructure レポートインフラストラクチャ層
+package infrastructure
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// xlsxStyle セル書式 色はRRGGBB形式 空は既定
+type xlsxStyle struct {
+	Fill      string
+	FontColor string
+	Bold      bool
+	Center    bool
+	Border    bool
+}
+
+// xlsxCell セル
+type xlsxCell struct {
+	value  string
+	number bool
+	style  int
+}
+
+// xlsxText 文字列セル
+func xlsxText(value string, style int) xlsxCell {
+	return xlsxCell{value: value, style: style}
+}
+
+// xlsxNumber 数値セル
+func xlsxNumber(value int, style int) xlsxCell {
+	return xlsxCell{value: strconv.Itoa(value), number: true, style: style}
+}
+
+// xlsxWorkbook 1シートのみのExcelブック 外部ライブラリを使わずOffice Open XMLを直接出力する
+type xlsxWorkbook struct {
+	sheetName string
+	styles    []xlsxStyle
+	styleIDs  map[xlsxStyle]int
+	rows      [][]xlsxCell
+	colWidths map[int]float64
+	// freezeRows・freezeCols 固定表示する先頭の行数・列数
+	freezeRows int
+	freezeCols int
+}
+
+// newXLSXWorkbook ブック生成 スタイル0は既定書式
+func newXLSXWorkbook(sheetName string) *xlsxWorkbook {
+	return &xlsxWorkbook{
+		sheetName: sheetName,
+		styles:    []xlsxStyle{{}},
+		styleIDs:  map[xlsxStyle]int{{}: 0},
+		colWidths: make(map[int]float64),
+	}
+}
+
+// style 書式を登録してスタイル番号を返す
+func (b *xlsxWorkbook) style(s xlsxStyle) int {
+	if id, ok := b.styleIDs[s]; ok {
+		return id
+	}
+	id := len(b.styles)
+	b.styles = append(b.styles, s)
+	b.styleIDs[s] = id
+	return id
+}
+
+// addRow 行追加
+func (b *xlsxWorkbook) addRow(cells ...xlsxCell) {
+	b.rows = append(b.rows, cells)
+}
+
+// setColWidth 列幅設定 列は0始まり
+func (b *xlsxWorkbook) setColWidth(col int, width float64) {
+	b.colWidths[col] = width
+}
+
+// freeze 先頭の行・列を固定表示
+func (b *xlsxWorkbook) freeze(rows, cols int) {
+	b.freezeRows = rows
+	b.freezeCols = cols
+}
+
+// Bytes xlsxファイル出力
+func (b *xlsxWorkbook) Bytes() ([]byte, error) {
+	parts := []struct {
+		name string
+		body string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", b.workbookXML()},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", b.stylesXML()},
+		{"xl/worksheets/sheet1.xml", b.sheetXML()},
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, part := range parts {
+		w, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write([]byte(part.body)); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+const (
+	xlsxHeader     = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+	xlsxMainNS     = `http://schemas.openxmlformats.org/spreadsheetml/2006/main`
+	xlsxRelNS      = `http://schemas.openxmlformats.org/officeDocument/2006/relationships`
+	xlsxPackageRel = `http://schemas.openxmlformats.org/package/2006/relationships`
+
+	xlsxContentTypes = xlsxHeader +
+		`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+		`</Types>`
+
+	xlsxRootRels = xlsxHeader +
+		`<Relationships xmlns="` + xlsxPackageRel + `">` +
+		`<Relationship Id="rId1" Type="` + xlsxRelNS + `/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+
+	xlsxWorkbookRels = xlsxHeader +
+		`<Relationships xmlns="` + xlsxPackageRel + `">` +
+		`<Relationship Id="rId1" Type="` + xlsxRelNS + `/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`<Relationship Id="rId2" Type="` + xlsxRelNS + `/styles" Target="styles.xml"/>` +
+		`</Relationships>`
+)
+
+// workbookXML ブック定義
+func (b *xlsxWorkbook) workbookXML() string {
+	return xlsxHeader +
+		`<workbook xmlns="` + xlsxMainNS + `" xmlns:r="` + xlsxRelNS + `">` +
+		`<sheets><sheet name="` + xmlEscape(b.sheetName) + `" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`
+}
+
+// stylesXML 書式定義 フォント・塗りは同じものを共有する
+func (b *xlsxWorkbook) stylesXML() string {
+	type fontKey struct {
+		color string
+		bold  bool
+	}
+	fontIDs := map[fontKey]int{{}: 0}
+	fonts := []fontKey{{}}
+	// 塗り0・1はExcelの予約
+	fillIDs := map[string]int{}
+	fills := []string{}
+
+	var xfs strings.Builder
+	for _, s := range b.styles {
+		fk := fontKey{color: s.FontColor, bold: s.Bold}
+		fontID, ok := fontIDs[fk]
+		if !ok {
+			fontID = len(fonts)
+			fontIDs[fk] = fontID
+			fonts = append(fonts, fk)
+		}
+		fillID := 0
+		if s.Fill != "" {
+			id, ok := fillIDs[s.Fill]
+			if !ok {
+				id = len(fills) + 2
+				fillIDs[s.Fill] = id
+				fills = append(fills, s.Fill)
+			}
+			fillID = id
+		}
+		borderID := 0
+		if s.Border {
+			borderID = 1
+		}
+		fmt.Fprintf(&xfs, `<xf numFmtId="0" fontId="%d" fillId="%d" borderId="%d" xfId="0" applyFont="1" applyFill="1" applyBorder="1"`,
+			fontID, fillID, borderID)
+		if s.Center {
+			xfs.WriteString(` applyAlignment="1"><alignment horizontal="center" vertical="center"/></xf>`)
+		} else {
+			xfs.WriteString(`/>`)
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(xlsxHeader)
+	sb.WriteString(`<styleSheet xmlns="` + xlsxMainNS + `">`)
+	fmt.Fprintf(&sb, `<fonts count="%d">`, len(fonts))
+	for _, f := range fonts {
+		sb.WriteString(`<font>`)
+		if f.bold {
+			sb.WriteString(`<b/>`)
+		}
+		sb.WriteString(`<sz val="10"/>`)
+		if f.color != "" {
+			sb.WriteString(`<color rgb="FF` + f.color + `"/>`)
+		}
+		sb.WriteString(`<name val="Meiryo UI"/></font>`)
+	}
+	sb.WriteString(`</fonts>`)
+	fmt.Fprintf(&sb, `<fills count="%d"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill>`, len(fills)+2)
+	for _, f := range fills {
+		sb.WriteString(`<fill><patternFill patternType="solid"><fgColor rgb="FF` + f + `"/><bgColor indexed="64"/></patternFill></fill>`)
+	}
+	sb.WriteString(`</fills>`)
+	sb.WriteString(`<borders count="2"><border><left/><right/><top/><bottom/><diagonal/></border><border>`)
+	for _, side := range []string{"left", "right", "top", "bottom"} {
+		sb.WriteString(`<` + side + ` style="thin"><color rgb="FFA0A0A0"/></` + side + `>`)
+	}
+	sb.WriteString(`<diagonal/></border></borders>`)
+	sb.WriteString(`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>`)
+	fmt.Fprintf(&sb, `<cellXfs count="%d">%s</cellXfs>`, len(b.styles), xfs.String())
+	sb.WriteString(`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>`)
+	sb.WriteString(`</styleSheet>`)
+	return sb.String()
+}
+
+// sheetXML シート内容 A4横・横1ページに収まるよう印刷設定する
+func (b *xlsxWorkbook) sheetXML() string {
+	var sb strings.Builder
+	sb.WriteString(xlsxHeader)
+	sb.WriteString(`<worksheet xmlns="` + xlsxMainNS + `" xmlns:r="` + xlsxRelNS + `">`)
+	sb.WriteString(`<sheetPr><pageSetUpPr fitToPage="1"/></sheetPr>`)
+
+	sb.WriteString(`<sheetViews><sheetView workbookViewId="0">`)
+	if b.freezeRows > 0 || b.freezeCols > 0 {
+		sb.WriteString(`<pane`)
+		if b.freezeCols > 0 {
+			fmt.Fprintf(&sb, ` xSplit="%d"`, b.freezeCols)
+		}
+		if b.freezeRows > 0 {
+			fmt.Fprintf(&sb, ` ySplit="%d"`, b.freezeRows)
+		}
+		fmt.Fprintf(&sb, ` topLeftCell="%s" activePane="%s" state="frozen"/>`,
+			xlsxCellRef(b.freezeRows, b.freezeCols), xlsxActivePane(b.freezeRows, b.freezeCols))
+	}
+	sb.WriteString(`</sheetView></sheetViews>`)
+
+	if len(b.colWidths) > 0 {
+		maxCol := 0
+		for col := range b.colWidths {
+			maxCol = max(maxCol, col)
+		}
+		sb.WriteString(`<cols>`)
+		for col := 0; col <= maxCol; col++ {
+			if width, ok := b.colWidths[col]; ok {
+				fmt.Fprintf(&sb, `<col min="%d" max="%d" width="%s" customWidth="1"/>`,
+					col+1, col+1, strconv.FormatFloat(width, 'f', -1, 64))
+			}
+		}
+		sb.WriteString(`</cols>`)
+	}
+
+	sb.WriteString(`<sheetData>`)
+	for r, row := range b.rows {
+		fmt.Fprintf(&sb, `<row r="%d">`, r+1)
+		for c, cell := range row {
+			ref := xlsxCellRef(r, c)
+			switch {
+			case cell.number:
+				fmt.Fprintf(&sb, `<c r="%s" s="%d"><v>%s</v></c>`, ref, cell.style, cell.value)
+			case cell.value == "":
+				if cell.style != 0 {
+					fmt.Fprintf(&sb, `<c r="%s" s="%d"/>`, ref, cell.style)
+				}
+			default:
+				fmt.Fprintf(&sb, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`,
+					ref, cell.style, xmlEscape(cell.value))
+			}
+		}
+		sb.WriteString(`</row>`)
+	}
+	sb.WriteString(`</sheetData>`)
+
+	sb.WriteString(`<pageMargins left="0.4" right="0.4" top="0.5" bottom="0.5" header="0.3" footer="0.3"/>`)
+	sb.WriteString(`<pageSetup paperSize="9" orientation="landscape" fitToWidth="1" fitToHeight="0"/>`)
+	sb.WriteString(`</worksheet>`)
+	return sb.String()
+}
+
+// xlsxCellRef セル参照 行・列は0始まり
+func xlsxCellRef(row, col int) string {
+	return xlsxColumnName(col) + strconv.Itoa(row+1)
+}
+
+// xlsxColumnName 列名 0→A、26→AA
+func xlsxColumnName(col int) string {
+	name := ""
+	for col >= 0 {
+		name = string(rune('A'+col%26)) + name
+		col = col/26 - 1
+	}
+	return name
+}
+
+// xlsxActivePane 固定表示時のアクティブ領域
+func xlsxActivePane(rows, cols int) string {
+	switch {
+	case rows > 0 && cols > 0:
+		return "bottomRight"
+	case rows > 0:
+		return "bottomLeft"
+	default:
+		return "topRight"
+	}
+}
+
+// xmlEscape XML文字列エスケープ XMLで使えない制御文字は置き換える
+func xmlEscape(s string) string {
+	var sb strings.Builder
+	_ = xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
+
+// normalizeColor HEX色をRRGGBB形式に正規化 不正な値は空
+func normalizeColor(color string) string {
+	hex := strings.TrimPrefix(strings.TrimSpace(color), "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return ""
+	}
+	if _, err := strconv.ParseUint(hex, 16, 32); err != nil {
+		return ""
+	}
+	return strings.ToUpper(hex)
+}
+
+// contrastColor 背景色に対して読みやすい文字色 RRGGBB形式
+func contrastColor(background string) string {
+	rgb, err := strconv.ParseUint(background, 16, 32)
+	if err != nil {
+		return ""
+	}
+	r, g, b := (rgb>>16)&0xFF, (rgb>>8)&0xFF, rgb&0xFF
+	if r*299+g*587+b*114 < 140*1000 {
+		return "FFFFFF"
+	}
+	return "000000"
+}
@@ -0,0 +1,175 @@
+// Package infrastructure レポートインフラストラクチャ層
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"shiftmaster/internal/modules/report/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+
+	"github.com/uptrace/bun"
+)
+
+// 曜日・祝日の配色 RRGGBB形式
+const (
+	weekdayHeaderFill  = "F1F5F9"
+	saturdayHeaderFill = "DBEAFE"
+	saturdayFont       = "1D4ED8"
+	saturdayCellFill   = "EFF6FF"
+	holidayHeaderFill  = "FEE2E2"
+	holidayFont        = "B91C1C"
+	holidayCellFill    = "FEF2F2"
+)
+
+// XLSXReportGenerator Excel形式のレポート生成 勤務表はExcel、その他の種別はCSVで出力
+type XLSXReportGenerator struct {
+	*CSVReportGenerator
+}
+
+// NewXLSXReportGenerator Excelレポート生成器生成
+func NewXLSXReportGenerator(db *bun.DB) *XLSXReportGenerator {
+	return &XLSXReportGenerator{CSVReportGenerator: NewCSVReportGenerator(db)}
+}
+
+// FileExtension ファイル拡張子 勤務表のみxlsx
+func (g *XLSXReportGenerator) FileExtension(reportType domain.ReportType) string {
+	if reportType == domain.ReportTypeSchedule {
+		return ".xlsx"
+	}
+	return g.CSVReportGenerator.FileExtension(reportType)
+}
+
+// GenerateScheduleReport 勤務表レポート生成 スタッフ×日のシフトコード表と日別人数
+func (g *XLSXReportGenerator) GenerateScheduleReport(ctx context.Context, scheduleID sharedDomain.ID) ([]byte, error) {
+	rows, summary, err := g.loadSchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	return buildScheduleWorkbook(rows, summary).Bytes()
+}
+
+// dayKind 日付の種別ごとの配色
+type dayKind struct {
+	headerFill string
+	font       string
+	cellFill   string
+}
+
+// dayKindOf 日付の配色 日曜・祝日は赤系、土曜は青系
+func dayKindOf(date time.Time) dayKind {
+	switch {
+	case date.Weekday() == time.Sunday || sharedDomain.IsJapaneseHoliday(date):
+		return dayKind{headerFill: holidayHeaderFill, font: holidayFont, cellFill: holidayCellFill}
+	case date.Weekday() == time.Saturday:
+		return dayKind{headerFill: saturdayHeaderFill, font: saturdayFont, cellFill: saturdayCellFill}
+	default:
+		return dayKind{headerFill: weekdayHeaderFill}
+	}
+}
+
+// buildScheduleWorkbook 勤務表のExcelブックを構築 rowsはスタッフごとにまとまっていること
+func buildScheduleWorkbook(rows []entryRow, summary *domain.MonthlySummary) *xlsxWorkbook {
+	wb := newXLSXWorkbook("勤務表")
+	days := len(summary.DailySummaries)
+	kinds := make([]dayKind, days)
+	for i, daily := range summary.DailySummaries {
+		kinds[i] = dayKindOf(daily.Date)
+	}
+
+	title := wb.style(xlsxStyle{Bold: true})
+	header := wb.style(xlsxStyle{Fill: weekdayHeaderFill, Bold: true, Center: true, Border: true})
+	text := wb.style(xlsxStyle{Border: true})
+	number := wb.style(xlsxStyle{Center: true, Border: true})
+	label := wb.style(xlsxStyle{Fill: weekdayHeaderFill, Bold: true, Border: true})
+
+	wb.addRow(xlsxText(fmt.Sprintf("%d年%d月 勤務表", summary.TargetYear, summary.TargetMonth), title))
+
+	// 見出し 日付と曜日の2行
+	dateRow := []xlsxCell{xlsxText("職員番号", header), xlsxText("氏名", header)}
+	weekRow := []xlsxCell{xlsxText("", header), xlsxText("", header)}
+	for i, daily := range summary.DailySummaries {
+		style := wb.style(xlsxStyle{Fill: kinds[i].headerFill, FontColor: kinds[i].font, Bold: true, Center: true, Border: true})
+		dateRow = append(dateRow, xlsxNumber(daily.Date.Day(), style))
+		weekRow = append(weekRow, xlsxText(daily.DayOfWeek(), style))
+	}
+	dateRow = append(dateRow, xlsxText("勤務日数", header), xlsxText("夜勤回数", header), xlsxText("休日数", header))
+	weekRow = append(weekRow, xlsxText("", header), xlsxText("", header), xlsxText("", header))
+	wb.addRow(dateRow...)
+	wb.addRow(weekRow...)
+
+	// スタッフ行 シフトコードをシフト種別の表示色で塗る
+	shiftCounts := make(map[sharedDomain.ID][]int)
+	for i := 0; i < len(rows); {
+		row := &rows[i]
+		cells := make([]xlsxCell, 2+days)
+		cells[0] = xlsxText(row.EmployeeCode, text)
+		cells[1] = xlsxText(row.staffName(), text)
+		for d := range days {
+			cells[2+d] = xlsxText("", wb.style(xlsxStyle{Fill: kinds[d].cellFill, Center: true, Border: true}))
+		}
+
+		staffID := row.StaffID
+		for ; i < len(rows) && rows[i].StaffID == staffID; i++ {
+			st := rows[i].shiftType()
+			date := rows[i].TargetDate
+			if st == nil || date.Year() != summary.TargetYear || int(date.Month()) != summary.TargetMonth {
+				continue
+			}
+			d := date.Day() - 1
+			style := xlsxStyle{Fill: kinds[d].cellFill, Center: true, Border: true}
+			if color := normalizeColor(st.Color); color != "" {
+				style.Fill = color
+				style.FontColor = contrastColor(color)
+			}
+			cells[2+d] = xlsxText(st.Code, wb.style(style))
+
+			if shiftCounts[st.ID] == nil {
+				shiftCounts[st.ID] = make([]int, days)
+			}
+			shiftCounts[st.ID][d]++
+		}
+
+		staff := findStaffSummary(summary, staffID)
+		cells = append(cells,
+			xlsxNumber(staff.TotalWorkDays, number),
+			xlsxNumber(staff.NightShiftCount, number),
+			xlsxNumber(staff.HolidayCount, number),
+		)
+		wb.addRow(cells...)
+	}
+
+	// 日別人数 日勤・夜勤・出勤合計とシフト種別ごとの人数
+	headcount := func(name string, count func(d int) int) {
+		cells := []xlsxCell{xlsxText("", label), xlsxText(name, label)}
+		for d := range days {
+			cells = append(cells, xlsxNumber(count(d), number))
+		}
+		wb.addRow(cells...)
+	}
+	headcount("日勤人数", func(d int) int { return summary.DailySummaries[d].DayShiftCount })
+	headcount("夜勤人数", func(d int) int { return summary.DailySummaries[d].NightShiftCount })
+	headcount("出勤人数", func(d int) int { return summary.DailySummaries[d].TotalStaff })
+	for _, s := range summary.ShiftTypeSummaries {
+		counts := shiftCounts[s.ShiftTypeID]
+		headcount(s.ShiftTypeCode+" "+s.ShiftTypeName, func(d int) int {
+			if counts == nil {
+				return 0
+			}
+			return counts[d]
+		})
+	}
+
+	wb.setColWidth(0, 10)
+	wb.setColWidth(1, 14)
+	for d := range days {
+		wb.setColWidth(2+d, 4.5)
+	}
+	for c := range 3 {
+		wb.setColWidth(2+days+c, 8)
+	}
+	wb.freeze(3, 2)
+
+	return wb
+}
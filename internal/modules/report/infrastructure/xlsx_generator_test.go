@@ -0,0 +1,137 @@
+// Package infrastructure レポートインフラストラクチャ層テスト
+package infrastructure
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"shiftmaster/internal/modules/report/domain"
+
+	"github.com/google/uuid"
+)
+
+// readXLSXPart xlsx内のファイルを読み込む
+func readXLSXPart(t *testing.T, data []byte, name string) string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("zip.NewReader() error = %v", err)
+	}
+	for _, f := range zr.File {
+		if f.Name != name {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("Open(%s) error = %v", name, err)
+		}
+		defer func() { _ = rc.Close() }()
+		body, err := io.ReadAll(rc)
+		if err != nil {
+			t.Fatalf("ReadAll(%s) error = %v", name, err)
+		}
+		return string(body)
+	}
+	t.Fatalf("%s not found in xlsx", name)
+	return ""
+}
+
+func TestBuildScheduleWorkbook(t *testing.T) {
+	staffID, dayID, nightID := uuid.New(), uuid.New(), uuid.New()
+	date := func(day int) time.Time {
+		return time.Date(2025, 5, day, 0, 0, 0, 0, time.UTC)
+	}
+	rows := []entryRow{
+		{
+			EntryID: uuid.New(), StaffID: staffID, EmployeeCode: "N001", LastName: "山田", FirstName: "花子", TargetDate: date(1),
+			ShiftTypeID: &dayID, ShiftName: ptr("日勤"), ShiftCode: ptr("D"), ShiftColor: ptr("#fde68a"),
+			StartTime: ptr("08:30:00"), EndTime: ptr("17:30:00"), BreakMinutes: ptr(60), SortOrder: ptr(1),
+		},
+		{
+			EntryID: uuid.New(), StaffID: staffID, EmployeeCode: "N001", LastName: "山田", FirstName: "花子", TargetDate: date(3),
+			ShiftTypeID: &nightID, ShiftName: ptr("夜勤"), ShiftCode: ptr("N"), ShiftColor: ptr("#1e3a8a"),
+			StartTime: ptr("16:30:00"), EndTime: ptr("09:00:00"), BreakMinutes: ptr(120), IsNightShift: ptr(true), SortOrder: ptr(2),
+		},
+	}
+	summary := buildMonthlySummary(2025, 5, rows)
+
+	data, err := buildScheduleWorkbook(rows, summary).Bytes()
+	if err != nil {
+		t.Fatalf("Bytes() error = %v", err)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml"} {
+		part := readXLSXPart(t, data, name)
+		if err := xml.Unmarshal([]byte(part), new(struct{})); err != nil {
+			t.Errorf("%s is not well-formed XML: %v", name, err)
+		}
+	}
+
+	sheet := readXLSXPart(t, data, "xl/worksheets/sheet1.xml")
+	for _, want := range []string{
+		`<t xml:space="preserve">2025年5月 勤務表</t>`,
+		`<t xml:space="preserve">山田 花子</t>`,
+		`<c r="C4" `, // 5/1 日勤
+		`<c r="E4" `, // 5/3 夜勤
+		`<t xml:space="preserve">夜勤人数</t>`,
+		`<t xml:space="preserve">D 日勤</t>`,
+		`<pane xSplit="2" ySplit="3" topLeftCell="C4" activePane="bottomRight" state="frozen"/>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet missing %q", want)
+		}
+	}
+
+	styles := readXLSXPart(t, data, "xl/styles.xml")
+	for _, want := range []string{
+		`<fgColor rgb="FFFDE68A"/>`,                    // 日勤の表示色
+		`<color rgb="FFFFFFFF"/>`,                      // 濃い夜勤色には白文字
+		`<fgColor rgb="FF` + holidayHeaderFill + `"/>`, // 5/3 憲法記念日
+		`<fgColor rgb="FF` + saturdayHeaderFill + `"/>`,
+	} {
+		if !strings.Contains(styles, want) {
+			t.Errorf("styles missing %q", want)
+		}
+	}
+}
+
+func TestXLSXReportGenerator_FileExtension(t *testing.T) {
+	g := NewXLSXReportGenerator(nil)
+	if got := g.FileExtension(domain.ReportTypeSchedule); got != ".xlsx" {
+		t.Errorf("FileExtension(schedule) = %q, want .xlsx", got)
+	}
+	if got := g.FileExtension(domain.ReportTypeSummary); got != ".csv" {
+		t.Errorf("FileExtension(summary) = %q, want .csv", got)
+	}
+}
+
+func TestNormalizeColor(t *testing.T) {
+	tests := []struct {
+		color string
+		want  string
+	}{
+		{"#4caf50", "4CAF50"},
+		{"#abc", "AABBCC"},
+		{"", ""},
+		{"red", ""},
+		{"#12345g", ""},
+	}
+	for _, tt := range tests {
+		if got := normalizeColor(tt.color); got != tt.want {
+			t.Errorf("normalizeColor(%q) = %q, want %q", tt.color, got, tt.want)
+		}
+	}
+}
+
+func TestXLSXColumnName(t *testing.T) {
+	for col, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if got := xlsxColumnName(col); got != want {
+			t.Errorf("xlsxColumnName(%d) = %q, want %q", col, got, want)
+		}
+	}
+}
@@ -0,0 +1,417 @@
+// Package presentation レポートプレゼンテーション層
+package presentation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"shiftmaster/internal/modules/report/application"
+	"shiftmaster/internal/modules/report/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+	"shiftmaster/internal/web"
+)
+
+// reportTypes 生成フォームで選択できるレポート種別
+var reportTypes = []domain.ReportType{
+	domain.ReportTypeSchedule,
+	domain.ReportTypeSummary,
+	domain.ReportTypeActual,
+	domain.ReportTypeStaffList,
+}
+
+// ReportHandler レポートHTTPハンドラー
+type ReportHandler struct {
+	useCase       *application.ReportUseCase
+	rosterUseCase *application.RosterExportUseCase
+	templates     *web.TemplateEngine
+	logger        *slog.Logger
+}
+
+// NewReportHandler ハンドラー生成
+func NewReportHandler(
+	useCase *application.ReportUseCase,
+	rosterUseCase *application.RosterExportUseCase,
+	templates *web.TemplateEngine,
+	logger *slog.Logger,
+) *ReportHandler {
+	return &ReportHandler{
+		useCase:       useCase,
+		rosterUseCase: rosterUseCase,
+		templates:     templates,
+		logger:        logger,
+	}
+}
+
+// getOrganizationID コンテキストから組織IDを取得
+func (h *ReportHandler) getOrganizationID(r *http.Request) string {
+	claims := web.GetClaimsFromContext(r.Context())
+	if claims != nil && claims.OrganizationID != nil {
+		return claims.OrganizationID.String()
+	}
+	return ""
+}
+
+// findReport パスのレポートを取得 他組織のレポートは存在しないものとして扱う
+func (h *ReportHandler) findReport(r *http.Request) (*application.ReportOutput, error) {
+	report, err := h.useCase.GetByID(r.Context(), r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+	if report.OrganizationID != h.getOrganizationID(r) {
+		return nil, sharedDomain.ErrNotFound
+	}
+	return report, nil
+}
+
+// targetMonth クエリの対象年月 未指定・不正な場合は当月
+func targetMonth(r *http.Request) (int, int) {
+	now := time.Now()
+	year, err := strconv.Atoi(r.URL.Query().Get("year"))
+	if err != nil || year < 2020 || year > 2100 {
+		year = now.Year()
+	}
+	month, err := strconv.Atoi(r.URL.Query().Get("month"))
+	if err != nil || month < 1 || month > 12 {
+		month = int(now.Month())
+	}
+	return year, month
+}
+
+// List レポート一覧ページ
+func (h *ReportHandler) List(w http.ResponseWriter, r *http.Request) {
+	year, month := targetMonth(r)
+	data := map[string]any{
+		"Title":       "レポート",
+		"ReportTypes": reportTypes,
+		"Year":        year,
+		"Month":       month,
+	}
+
+	orgID := h.getOrganizationID(r)
+	if orgID == "" {
+		data["NoOrgSelected"] = true
+	} else {
+		reports, err := h.useCase.List(r.Context(), orgID)
+		if err != nil {
+			h.handleError(w, err)
+			return
+		}
+		data["Reports"] = reports
+	}
+
+	if isHTMXRequest(r) {
+		if err := h.templates.RenderPartial(w, "pages/reports/list.html", "report-list", data); err != nil {
+			h.logger.Error("テンプレートレンダリング失敗", "error", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := h.templates.Render(w, "pages/reports/list.html", data); err != nil {
+		h.logger.Error("テンプレートレンダリング失敗", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// Create レポート生成
+func (h *ReportHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	year, _ := strconv.Atoi(r.FormValue("target_year"))
+	month, _ := strconv.Atoi(r.FormValue("target_month"))
+	input := &application.GenerateReportInput{
+		OrganizationID: h.getOrganizationID(r),
+		Type:           r.FormValue("type"),
+		TargetYear:     year,
+		TargetMonth:    month,
+	}
+
+	if _, err := h.useCase.Generate(r.Context(), input); err != nil {
+		h.handleFormError(w, r, err)
+		return
+	}
+
+	if isHTMXRequest(r) {
+		w.Header().Set("HX-Redirect", "/reports")
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	http.Redirect(w, r, "/reports", http.StatusSeeOther)
+}
+
+// Row レポート行 生成中のレポートの状態ポーリング用
+func (h *ReportHandler) Row(w http.ResponseWriter, r *http.Request) {
+	report, err := h.findReport(r)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	if err := h.templates.RenderPartial(w, "pages/reports/list.html", "report-row", report); err != nil {
+		h.logger.Error("テンプレートレンダリング失敗", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// Download レポートファイルダウンロード
+func (h *ReportHandler) Download(w http.ResponseWriter, r *http.Request) {
+	report, err := h.findReport(r)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	file, err := h.useCase.Download(r.Context(), report.ID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	h.writeFile(w, file)
+}
+
+// RosterPDF 公開済み勤務表の掲示用PDF paperクエリでa4・a3を指定
+func (h *ReportHandler) RosterPDF(w http.ResponseWriter, r *http.Request) {
+	file, err := h.rosterUseCase.ExportPDF(r.Context(), &application.ExportRosterInput{
+		OrganizationID: h.getOrganizationID(r),
+		ScheduleID:     r.PathValue("id"),
+		PaperSize:      r.URL.Query().Get("paper"),
+	})
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	h.writeFile(w, file)
+}
+
+// writeFile ファイルを添付として書き込み
+func (h *ReportHandler) writeFile(w http.ResponseWriter, file *application.ReportFileOutput) {
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Disposition", contentDisposition(file.FileName))
+	w.Header().Set("Content-Length", strconv.Itoa(len(file.Data)))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(file.Data); err != nil {
+		h.logger.Error("レポートファイル送信失敗", "error", err)
+	}
+}
+
+// Delete レポート削除
+func (h *ReportHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	report, err := h.findReport(r)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	if err := h.useCase.Delete(r.Context(), report.ID); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	if isHTMXRequest(r) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	http.Redirect(w, r, "/reports", http.StatusSeeOther)
+}
+
+// Summary 月次集計ページ
+func (h *ReportHandler) Summary(w http.ResponseWriter, r *http.Request) {
+	year, month := targetMonth(r)
+	data := map[string]any{
+		"Title": "月次集計",
+		"Year":  year,
+		"Month": month,
+	}
+
+	orgID := h.getOrganizationID(r)
+	if orgID == "" {
+		data["NoOrgSelected"] = true
+	} else {
+		summary, err := h.useCase.GetMonthlySummary(r.Context(), orgID, year, month)
+		if err != nil {
+			h.handleError(w, err)
+			return
+		}
+		data["Summary"] = summary
+	}
+
+	if err := h.templates.Render(w, "pages/reports/summary.html", data); err != nil {
+		h.logger.Error("テンプレートレンダリング失敗", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// ListJSON レポート一覧JSON
+func (h *ReportHandler) ListJSON(w http.ResponseWriter, r *http.Request) {
+	reports, err := h.useCase.List(r.Context(), h.getOrganizationID(r))
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, reports)
+}
+
+// ShowJSON レポート詳細JSON 生成状態のポーリングに使用
+func (h *ReportHandler) ShowJSON(w http.ResponseWriter, r *http.Request) {
+	report, err := h.findReport(r)
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, report)
+}
+
+// CreateJSON レポート生成JSON 生成は非同期のため受付時点の状態を返す
+func (h *ReportHandler) CreateJSON(w http.ResponseWriter, r *http.Request) {
+	var input application.GenerateReportInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "リクエストボディが不正です"})
+		return
+	}
+	// 組織は認証情報から決定する
+	input.OrganizationID = h.getOrganizationID(r)
+
+	report, err := h.useCase.Generate(r.Context(), &input)
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", "/api/reports/"+report.ID)
+	h.writeJSON(w, http.StatusAccepted, report)
+}
+
+// DeleteJSON レポート削除JSON
+func (h *ReportHandler) DeleteJSON(w http.ResponseWriter, r *http.Request) {
+	report, err := h.findReport(r)
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	if err := h.useCase.Delete(r.Context(), report.ID); err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SummaryJSON 月次集計JSON year・monthクエリ未指定は当月
+func (h *ReportHandler) SummaryJSON(w http.ResponseWriter, r *http.Request) {
+	year, month := targetMonth(r)
+	summary, err := h.useCase.GetMonthlySummary(r.Context(), h.getOrganizationID(r), year, month)
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, summary)
+}
+
+// handleFormError フォーム送信エラーハンドリング 検証エラーはフォーム上に表示
+func (h *ReportHandler) handleFormError(w http.ResponseWriter, r *http.Request, err error) {
+	var domainErr *sharedDomain.DomainError
+	if isHTMXRequest(r) && errors.As(err, &domainErr) && domainErr.Code == sharedDomain.ErrCodeValidation {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`<p class="text-sm text-red-400">` + html.EscapeString(domainErr.Message) + `</p>`))
+		return
+	}
+
+	h.handleError(w, err)
+}
+
+// handleError エラーハンドリング
+func (h *ReportHandler) handleError(w http.ResponseWriter, err error) {
+	if errors.Is(err, sharedDomain.ErrNotFound) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+
+	var domainErr *sharedDomain.DomainError
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code {
+		case sharedDomain.ErrCodeNotFound:
+			http.Error(w, domainErr.Message, http.StatusNotFound)
+			return
+		case sharedDomain.ErrCodeValidation:
+			http.Error(w, domainErr.Message, http.StatusBadRequest)
+			return
+		}
+	}
+
+	h.logger.Error("ハンドラーエラー", "error", err)
+	http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+}
+
+// handleJSONError JSONエラーハンドリング
+func (h *ReportHandler) handleJSONError(w http.ResponseWriter, err error) {
+	if errors.Is(err, sharedDomain.ErrNotFound) {
+		h.writeJSON(w, http.StatusNotFound, map[string]string{"error": "見つかりません"})
+		return
+	}
+
+	var domainErr *sharedDomain.DomainError
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code {
+		case sharedDomain.ErrCodeNotFound:
+			h.writeJSON(w, http.StatusNotFound, map[string]string{"error": domainErr.Message})
+			return
+		case sharedDomain.ErrCodeValidation:
+			h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": domainErr.Message})
+			return
+		}
+	}
+
+	h.logger.Error("ハンドラーエラー", "error", err)
+	h.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "内部エラーが発生しました"})
+}
+
+// writeJSON JSONレスポンス書き込み
+func (h *ReportHandler) writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		h.logger.Error("JSONエンコード失敗", "error", err)
+	}
+}
+
+// contentDisposition 添付ファイルヘッダー生成
+// 日本語のファイル名はfilename*(RFC 5987)で渡し、非対応クライアント向けにASCIIのfilenameも付与
+func contentDisposition(fileName string) string {
+	return fmt.Sprintf(`attachment; filename="report%s"; filename*=UTF-8''%s`,
+		asciiExt(path.Ext(fileName)), strings.ReplaceAll(url.PathEscape(fileName), "'", "%27"))
+}
+
+// asciiExt ヘッダーに埋め込める拡張子のみ返す
+func asciiExt(ext string) string {
+	for _, c := range ext {
+		if c != '.' && (c < '0' || c > '9') && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+			return ""
+		}
+	}
+	return ext
+}
+
+// isHTMXRequest HTMXリクエストか判定
+func isHTMXRequest(r *http.Request) bool {
+	return r.Header.Get("HX-Request") == "true"
+}
@@ -0,0 +1,35 @@
+package presentation
+
+import "testing"
+
+func TestContentDisposition(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		want     string
+	}{
+		{
+			name:     "日本語のファイル名",
+			fileName: "勤務表 2025年4月.csv",
+			want:     `attachment; filename="report.csv"; filename*=UTF-8''%E5%8B%A4%E5%8B%99%E8%A1%A8%202025%E5%B9%B44%E6%9C%88.csv`,
+		},
+		{
+			name:     "引用符を含むファイル名",
+			fileName: `a"b'.csv`,
+			want:     `attachment; filename="report.csv"; filename*=UTF-8''a%22b%27.csv`,
+		},
+		{
+			name:     "拡張子なし",
+			fileName: "report",
+			want:     `attachment; filename="report"; filename*=UTF-8''report`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := contentDisposition(tt.fileName); got != tt.want {
+				t.Errorf("contentDisposition() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,463 @@
+// Package application 勤務表アプリケーション層
+package application
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"time"
+
+	"shiftmaster/internal/modules/schedule/domain"
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
+	staffDomain "shiftmaster/internal/modules/staff/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// ActualRecordUseCase 勤務実績ユースケース
+type ActualRecordUseCase struct {
+	scheduleRepo  domain.ScheduleRepository
+	entryRepo     domain.ScheduleEntryRepository
+	actualRepo    domain.ActualRecordRepository
+	shiftTypeRepo shiftDomain.ShiftTypeRepository
+	staffRepo     staffDomain.StaffRepository
+	logger        *slog.Logger
+}
+
+// NewActualRecordUseCase 勤務実績ユースケース生成
+func NewActualRecordUseCase(
+	scheduleRepo domain.ScheduleRepository,
+	entryRepo domain.ScheduleEntryRepository,
+	actualRepo domain.ActualRecordRepository,
+	shiftTypeRepo shiftDomain.ShiftTypeRepository,
+	staffRepo staffDomain.StaffRepository,
+	logger *slog.Logger,
+) *ActualRecordUseCase {
+	return &ActualRecordUseCase{
+		scheduleRepo:  scheduleRepo,
+		entryRepo:     entryRepo,
+		actualRepo:    actualRepo,
+		shiftTypeRepo: shiftTypeRepo,
+		staffRepo:     staffRepo,
+		logger:        logger,
+	}
+}
+
+// Record 勤務実績登録 エントリの実績が登録済みの場合は上書き
+// 区分別時間外労働はスタッフの月間実績から再算出して保存 マネージャー以外は本人の勤務のみ
+func (u *ActualRecordUseCase) Record(ctx context.Context, input *RecordActualInput) (*ActualRecordOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	entry, err := u.findEditableEntry(ctx, input.ScheduleID, input.EntryID, input.UserID, input.UserEmail, input.IsManager)
+	if err != nil {
+		return nil, err
+	}
+
+	startTime, endTime, err := parseActualTimes(entry.TargetDate, input.ActualStartTime, input.ActualEndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := u.actualRepo.FindByEntryID(ctx, entry.ID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if record == nil {
+		record = &domain.ActualRecord{
+			ID:              sharedDomain.NewID(),
+			ScheduleEntryID: entry.ID,
+			CreatedAt:       now,
+		}
+	}
+	record.ActualStartTime = startTime
+	record.ActualEndTime = endTime
+	record.ActualBreakMinutes = input.ActualBreakMinutes
+	record.Note = input.Note
+	record.UpdatedAt = now
+
+	if err := record.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := u.recalculateOvertime(ctx, entry, record); err != nil {
+		return nil, err
+	}
+
+	u.logger.Info("勤務実績登録完了", "actual_record_id", record.ID, "schedule_entry_id", entry.ID)
+	return ToActualRecordOutput(record), nil
+}
+
+// Delete 勤務実績削除 マネージャー以外は本人の勤務のみ
+func (u *ActualRecordUseCase) Delete(ctx context.Context, input *DeleteActualInput) error {
+	entry, err := u.findEditableEntry(ctx, input.ScheduleID, input.EntryID, input.UserID, input.UserEmail, input.IsManager)
+	if err != nil {
+		return err
+	}
+
+	record, err := u.actualRepo.FindByEntryID(ctx, entry.ID)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		return sharedDomain.ErrNotFound
+	}
+
+	if err := u.actualRepo.Delete(ctx, record.ID); err != nil {
+		u.logger.Error("勤務実績削除失敗", "error", err)
+		return err
+	}
+	if err := u.recalculateOvertime(ctx, entry, nil); err != nil {
+		return err
+	}
+
+	u.logger.Info("勤務実績削除完了", "actual_record_id", record.ID)
+	return nil
+}
+
+// GetTimesheet スタッフ別月次勤務実績表取得 予定と実績の差異を日別に算出
+func (u *ActualRecordUseCase) GetTimesheet(ctx context.Context, scheduleID, staffID string) (*TimesheetOutput, error) {
+	sID, err := sharedDomain.ParseID(scheduleID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "勤務表IDが不正です")
+	}
+	stID, err := sharedDomain.ParseID(staffID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "スタッフIDが不正です")
+	}
+
+	schedule, err := u.scheduleRepo.FindByID(ctx, sID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil {
+		return nil, sharedDomain.ErrNotFound
+	}
+
+	entries, days, err := u.loadAttendance(ctx, schedule, stID)
+	if err != nil {
+		return nil, err
+	}
+	overtimes := domain.CalculateOvertime(days)
+
+	indexMap := make(map[string]int, len(entries))
+	for i := range entries {
+		indexMap[entries[i].TargetDate.Format("2006-01-02")] = i
+	}
+
+	output := &TimesheetOutput{
+		ScheduleID:        schedule.ID.String(),
+		StaffID:           stID.String(),
+		TargetPeriodLabel: schedule.TargetPeriodLabel(),
+		Days:              make([]TimesheetDayOutput, 0, schedule.DaysInMonth()),
+	}
+	if u.staffRepo != nil {
+		staff, staffErr := u.staffRepo.FindByID(ctx, stID)
+		if staffErr == nil && staff != nil {
+			output.StaffName = staff.LastName + " " + staff.FirstName
+		}
+	}
+
+	weekdays := []string{"日", "月", "火", "水", "木", "金", "土"}
+	start := schedule.StartDate()
+	for i := 0; i < schedule.DaysInMonth(); i++ {
+		date := start.AddDate(0, 0, i)
+		day := TimesheetDayOutput{
+			Date:      date.Format("2006-01-02"),
+			Day:       date.Day(),
+			DayOfWeek: weekdays[date.Weekday()],
+			IsWeekend: sharedDomain.IsWeekendOrHoliday(date),
+		}
+
+		if idx, ok := indexMap[day.Date]; ok {
+			entry := &entries[idx]
+			shiftType := days[idx].ShiftType
+			day.EntryID = entry.ID.String()
+			if shiftType != nil {
+				day.ShiftTypeName = shiftType.Name
+				day.ShiftTypeCode = shiftType.Code
+			}
+
+			// 実績未登録の日も予定時刻を表示する
+			record := days[idx].Record
+			if record == nil {
+				record = &domain.ActualRecord{}
+			} else {
+				day.Actual = ToActualRecordOutput(record)
+				day.ActualStartTime = formatClock(record.ActualStartTime)
+				day.ActualEndTime = formatClock(record.ActualEndTime)
+			}
+			diff := record.CompareWith(entry.TargetDate, shiftType)
+			if diff.PlannedStart != nil {
+				day.PlannedStartTime = formatClock(diff.PlannedStart)
+				day.PlannedEndTime = formatClock(diff.PlannedEnd)
+				day.PlannedBreakMinutes = shiftType.BreakMinutes
+				day.PlannedWorkingMinutes = diff.PlannedWorkingMinutes
+			}
+			if day.Actual != nil {
+				overtime := overtimes[idx]
+				day.ActualWorkingMinutes = diff.ActualWorkingMinutes
+				day.LateMinutes = diff.LateMinutes
+				day.EarlyLeaveMinutes = diff.EarlyLeaveMinutes
+				day.OvertimeMinutes = overtime.OvertimeMinutes()
+				day.WithinLegalOvertimeMinutes = overtime.WithinLegalMinutes
+				day.OverLegalOvertimeMinutes = overtime.OverLegalMinutes
+				day.LateNightMinutes = overtime.LateNightMinutes
+				day.HolidayWorkMinutes = overtime.HolidayWorkMinutes
+			}
+		}
+
+		summarizeTimesheetDay(&output.Summary, &day)
+		output.Days = append(output.Days, day)
+	}
+
+	return output, nil
+}
+
+// findEntry 勤務表に属するエントリを取得
+func (u *ActualRecordUseCase) findEntry(ctx context.Context, scheduleID, entryID string) (*domain.ScheduleEntry, error) {
+	sID, err := sharedDomain.ParseID(scheduleID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "勤務表IDが不正です")
+	}
+	eID, err := sharedDomain.ParseID(entryID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "エントリIDが不正です")
+	}
+
+	entry, err := u.entryRepo.FindByID(ctx, eID)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil || entry.ScheduleID != sID {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeNotFound, "勤務表エントリが見つかりません")
+	}
+	return entry, nil
+}
+
+// findEditableEntry 操作ユーザーが実績を変更できるエントリを取得
+// メールアドレスが一致する勤務表の組織のスタッフを本人とみなし、マネージャー以外は本人のエントリのみ許可する
+func (u *ActualRecordUseCase) findEditableEntry(ctx context.Context, scheduleID, entryID, userID, email string, isManager bool) (*domain.ScheduleEntry, error) {
+	entry, err := u.findEntry(ctx, scheduleID, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	var staffs []staffDomain.Staff
+	if !isManager {
+		schedule, err := u.scheduleRepo.FindByID(ctx, entry.ScheduleID)
+		if err != nil {
+			return nil, err
+		}
+		if schedule == nil {
+			return nil, sharedDomain.ErrNotFound
+		}
+		staffs, err = u.staffRepo.FindActiveByOrganizationID(ctx, schedule.OrganizationID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	actor, err := parseSwapActor(userID, email, isManager, staffs)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.CanRequestFor(entry.StaffID) {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeForbidden, "自分の勤務の実績のみ登録・削除できます")
+	}
+	return entry, nil
+}
+
+// loadAttendance スタッフの勤務日を日付順に取得 エントリと勤務日は同じ順序
+// 週は日曜起算のため、月初・月末の週にかかる前後の月の勤務日も対象月外として含める
+func (u *ActualRecordUseCase) loadAttendance(ctx context.Context, schedule *domain.Schedule, staffID sharedDomain.ID) ([]domain.ScheduleEntry, []domain.AttendanceDay, error) {
+	entries, err := u.entryRepo.FindByScheduleAndStaff(ctx, schedule.ID, staffID)
+	if err != nil {
+		return nil, nil, err
+	}
+	records, err := u.actualRepo.FindBySchedule(ctx, schedule.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	start, end := schedule.StartDate(), schedule.EndDate()
+	weekStart := start.AddDate(0, 0, -int(start.Weekday()))
+	weekEnd := end.AddDate(0, 0, 6-int(end.Weekday()))
+	for _, month := range []time.Time{weekStart, weekEnd} {
+		if month.Month() == start.Month() {
+			continue
+		}
+		adjacentEntries, adjacentRecords, err := u.loadAdjacentAttendance(ctx, schedule.OrganizationID, month, staffID)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, entry := range adjacentEntries {
+			date := time.Date(entry.TargetDate.Year(), entry.TargetDate.Month(), entry.TargetDate.Day(), 0, 0, 0, 0, start.Location())
+			if !date.Before(weekStart) && !date.After(weekEnd) {
+				entries = append(entries, entry)
+			}
+		}
+		records = append(records, adjacentRecords...)
+	}
+
+	shiftTypes, err := u.shiftTypeRepo.FindByOrganizationID(ctx, schedule.OrganizationID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	shiftTypeMap := make(map[string]*shiftDomain.ShiftType, len(shiftTypes))
+	for i := range shiftTypes {
+		shiftTypeMap[shiftTypes[i].ID.String()] = &shiftTypes[i]
+	}
+
+	days := buildAttendanceDays(entries, records, shiftTypeMap)
+	for i := range entries {
+		days[i].OutsidePeriod = entries[i].ScheduleID != schedule.ID
+	}
+	return entries, days, nil
+}
+
+// loadAdjacentAttendance 前後の月の勤務表からスタッフのエントリと実績を取得 勤務表がなければ空
+func (u *ActualRecordUseCase) loadAdjacentAttendance(ctx context.Context, organizationID sharedDomain.ID, month time.Time, staffID sharedDomain.ID) ([]domain.ScheduleEntry, []domain.ActualRecord, error) {
+	schedule, err := u.scheduleRepo.FindByTargetMonth(ctx, organizationID, month.Year(), int(month.Month()))
+	if err != nil || schedule == nil {
+		return nil, nil, err
+	}
+	entries, err := u.entryRepo.FindByScheduleAndStaff(ctx, schedule.ID, staffID)
+	if err != nil {
+		return nil, nil, err
+	}
+	records, err := u.actualRepo.FindBySchedule(ctx, schedule.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return entries, records, nil
+}
+
+// buildAttendanceDays エントリを日付順に並べ替え、対応する勤務日を構築 勤務日はエントリと同じ順序
+func buildAttendanceDays(
+	entries []domain.ScheduleEntry,
+	records []domain.ActualRecord,
+	shiftTypeMap map[string]*shiftDomain.ShiftType,
+) []domain.AttendanceDay {
+	recordMap := make(map[string]*domain.ActualRecord, len(records))
+	for i := range records {
+		recordMap[records[i].ScheduleEntryID.String()] = &records[i]
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].TargetDate.Before(entries[j].TargetDate)
+	})
+	days := make([]domain.AttendanceDay, len(entries))
+	for i := range entries {
+		days[i] = domain.AttendanceDay{
+			Date:   entries[i].TargetDate,
+			Record: recordMap[entries[i].ID.String()],
+		}
+		if entries[i].ShiftTypeID != nil {
+			days[i].ShiftType = shiftTypeMap[entries[i].ShiftTypeID.String()]
+		}
+	}
+	return days
+}
+
+// recalculateOvertime スタッフの月間実績から区分別時間外労働を再算出して保存
+// targetはエントリの登録対象の実績 削除時はnil 週40時間・法定休日の判定が他の日に波及するため変更のあった実績も保存
+// 前後の月の実績は週の判定にのみ使い、保存しない
+func (u *ActualRecordUseCase) recalculateOvertime(ctx context.Context, entry *domain.ScheduleEntry, target *domain.ActualRecord) error {
+	schedule, err := u.scheduleRepo.FindByID(ctx, entry.ScheduleID)
+	if err != nil {
+		return err
+	}
+	if schedule == nil {
+		return sharedDomain.ErrNotFound
+	}
+
+	entries, days, err := u.loadAttendance(ctx, schedule, entry.StaffID)
+	if err != nil {
+		return err
+	}
+	for i := range entries {
+		if entries[i].ID == entry.ID {
+			days[i].Record = target
+		}
+	}
+
+	for i, overtime := range domain.CalculateOvertime(days) {
+		record := days[i].Record
+		if record == nil || days[i].OutsidePeriod {
+			continue
+		}
+		if !record.ApplyOvertime(overtime) && record != target {
+			continue
+		}
+		if err := u.actualRepo.Save(ctx, record); err != nil {
+			u.logger.Error("勤務実績登録失敗", "error", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// parseActualTimes HH:MM形式の出勤・退勤時刻をAsia/Tokyoの勤務日の日時に変換 退勤が出勤以前の場合は翌日
+func parseActualTimes(date time.Time, start, end string) (*time.Time, *time.Time, error) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, domain.Tokyo)
+
+	var startTime, endTime *time.Time
+	if start != "" {
+		t, err := sharedDomain.ParseTimeOfDay(start)
+		if err != nil {
+			return nil, nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "出勤時刻は HH:MM 形式で入力してください")
+		}
+		at := day.Add(time.Duration(t.ToMinutes()) * time.Minute)
+		startTime = &at
+	}
+	if end != "" {
+		t, err := sharedDomain.ParseTimeOfDay(end)
+		if err != nil {
+			return nil, nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "退勤時刻は HH:MM 形式で入力してください")
+		}
+		at := day.Add(time.Duration(t.ToMinutes()) * time.Minute)
+		if startTime != nil && !at.After(*startTime) {
+			at = at.AddDate(0, 0, 1)
+		}
+		endTime = &at
+	}
+	return startTime, endTime, nil
+}
+
+// formatClock 日時をAsia/TokyoのHH:MM形式に変換 nilは空文字
+func formatClock(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.In(domain.Tokyo).Format("15:04")
+}
+
+// summarizeTimesheetDay 日別行を月間集計に加算
+func summarizeTimesheetDay(summary *TimesheetSummaryOutput, day *TimesheetDayOutput) {
+	if day.PlannedStartTime != "" {
+		summary.PlannedDays++
+		summary.PlannedWorkingMinutes += day.PlannedWorkingMinutes
+	}
+	if day.Actual == nil {
+		return
+	}
+	if day.ActualWorkingMinutes > 0 {
+		summary.ActualDays++
+	}
+	summary.ActualWorkingMinutes += day.ActualWorkingMinutes
+	if day.LateMinutes > 0 {
+		summary.LateCount++
+		summary.LateMinutes += day.LateMinutes
+	}
+	if day.EarlyLeaveMinutes > 0 {
+		summary.EarlyLeaveCount++
+		summary.EarlyLeaveMinutes += day.EarlyLeaveMinutes
+	}
+	summary.OvertimeMinutes += day.OvertimeMinutes
+	summary.WithinLegalOvertimeMinutes += day.WithinLegalOvertimeMinutes
+	summary.OverLegalOvertimeMinutes += day.OverLegalOvertimeMinutes
+	summary.LateNightMinutes += day.LateNightMinutes
+	summary.HolidayWorkMinutes += day.HolidayWorkMinutes
+}
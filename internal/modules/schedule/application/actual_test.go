@@ -0,0 +1,349 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"shiftmaster/internal/modules/schedule/domain"
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// モック勤務実績リポジトリ
+
+type mockActualRecordRepository struct {
+	records map[sharedDomain.ID]*domain.ActualRecord
+}
+
+func (m *mockActualRecordRepository) FindByID(_ context.Context, id sharedDomain.ID) (*domain.ActualRecord, error) {
+	return m.records[id], nil
+}
+
+func (m *mockActualRecordRepository) FindByEntryID(_ context.Context, entryID sharedDomain.ID) (*domain.ActualRecord, error) {
+	for _, r := range m.records {
+		if r.ScheduleEntryID == entryID {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockActualRecordRepository) FindBySchedule(_ context.Context, _ sharedDomain.ID) ([]domain.ActualRecord, error) {
+	records := make([]domain.ActualRecord, 0, len(m.records))
+	for _, r := range m.records {
+		records = append(records, *r)
+	}
+	return records, nil
+}
+
+func (m *mockActualRecordRepository) Save(_ context.Context, record *domain.ActualRecord) error {
+	m.records[record.ID] = record
+	return nil
+}
+
+func (m *mockActualRecordRepository) Delete(_ context.Context, id sharedDomain.ID) error {
+	delete(m.records, id)
+	return nil
+}
+
+// actualManagerID 実績を登録するマネージャーのユーザーID
+var actualManagerID = sharedDomain.NewID().String()
+
+func newActualRecordUseCase(f *scheduleFixture, actuals *mockActualRecordRepository) *ActualRecordUseCase {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	return NewActualRecordUseCase(
+		&mockScheduleRepository{schedules: map[sharedDomain.ID]*domain.Schedule{f.schedule.ID: f.schedule}, entries: f.entries},
+		f.entries,
+		actuals,
+		&mockShiftTypeRepository{shiftTypes: []shiftDomain.ShiftType{f.day, f.off}},
+		&mockStaffRepository{staffs: f.staffs},
+		logger,
+	)
+}
+
+func TestActualRecordUseCase_Record(t *testing.T) {
+	f := newScheduleFixture(1)
+	f.day.BreakMinutes = 60
+	actuals := &mockActualRecordRepository{records: make(map[sharedDomain.ID]*domain.ActualRecord)}
+	useCase := newActualRecordUseCase(f, actuals)
+	entry := f.addEntry(f.staffs[0].ID, time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC), f.day.ID, true)
+
+	output, err := useCase.Record(context.Background(), &RecordActualInput{
+		UserID:             actualManagerID,
+		IsManager:          true,
+		ScheduleID:         f.schedule.ID.String(),
+		EntryID:            entry.ID.String(),
+		ActualStartTime:    "08:30",
+		ActualEndTime:      "18:30",
+		ActualBreakMinutes: 60,
+	})
+	if err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if output.OvertimeMinutes != 60 {
+		t.Errorf("OvertimeMinutes = %d, want 60", output.OvertimeMinutes)
+	}
+	if output.ActualWorkingMinutes != 540 {
+		t.Errorf("ActualWorkingMinutes = %d, want 540", output.ActualWorkingMinutes)
+	}
+
+	// 同じエントリへの再登録は上書き
+	if _, err := useCase.Record(context.Background(), &RecordActualInput{
+		UserID:          actualManagerID,
+		IsManager:       true,
+		ScheduleID:      f.schedule.ID.String(),
+		EntryID:         entry.ID.String(),
+		ActualStartTime: "08:30",
+		ActualEndTime:   "17:30",
+	}); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if len(actuals.records) != 1 {
+		t.Errorf("records = %d, want 1", len(actuals.records))
+	}
+
+	t.Run("他の勤務表のエントリは対象外", func(t *testing.T) {
+		_, err := useCase.Record(context.Background(), &RecordActualInput{
+			UserID:          actualManagerID,
+			IsManager:       true,
+			ScheduleID:      sharedDomain.NewID().String(),
+			EntryID:         entry.ID.String(),
+			ActualStartTime: "08:30",
+		})
+		if err == nil {
+			t.Error("Record() should return error")
+		}
+	})
+
+	t.Run("時刻形式が不正", func(t *testing.T) {
+		_, err := useCase.Record(context.Background(), &RecordActualInput{
+			UserID:          actualManagerID,
+			IsManager:       true,
+			ScheduleID:      f.schedule.ID.String(),
+			EntryID:         entry.ID.String(),
+			ActualStartTime: "8時",
+		})
+		if err == nil {
+			t.Error("Record() should return error")
+		}
+	})
+}
+
+func TestActualRecordUseCase_Permission(t *testing.T) {
+	f := newScheduleFixture(2)
+	f.staffs[0].Email = "hanako@example.com"
+	f.staffs[1].Email = "taro@example.com"
+	actuals := &mockActualRecordRepository{records: make(map[sharedDomain.ID]*domain.ActualRecord)}
+	useCase := newActualRecordUseCase(f, actuals)
+	entry := f.addEntry(f.staffs[0].ID, time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC), f.day.ID, true)
+	record := func(email string) error {
+		_, err := useCase.Record(context.Background(), &RecordActualInput{
+			ScheduleID:      f.schedule.ID.String(),
+			EntryID:         entry.ID.String(),
+			ActualStartTime: "08:30",
+			ActualEndTime:   "17:30",
+			UserID:          sharedDomain.NewID().String(),
+			UserEmail:       email,
+		})
+		return err
+	}
+	forbidden := func(t *testing.T, err error) {
+		t.Helper()
+		var domainErr *sharedDomain.DomainError
+		if !errors.As(err, &domainErr) || domainErr.Code != sharedDomain.ErrCodeForbidden {
+			t.Errorf("error = %v, want forbidden", err)
+		}
+	}
+	remove := func(email string) error {
+		return useCase.Delete(context.Background(), &DeleteActualInput{
+			ScheduleID: f.schedule.ID.String(),
+			EntryID:    entry.ID.String(),
+			UserID:     sharedDomain.NewID().String(),
+			UserEmail:  email,
+		})
+	}
+
+	for _, email := range []string{"taro@example.com", "unknown@example.com", ""} {
+		forbidden(t, record(email))
+	}
+	if len(actuals.records) != 0 {
+		t.Fatalf("records = %d, want 0", len(actuals.records))
+	}
+
+	// 本人はメールアドレスの大文字小文字を区別せず照合する
+	if err := record("Hanako@example.com"); err != nil {
+		t.Fatalf("本人の Record() error = %v", err)
+	}
+	forbidden(t, remove("taro@example.com"))
+	if len(actuals.records) != 1 {
+		t.Errorf("他のスタッフは削除できない: records = %d, want 1", len(actuals.records))
+	}
+	if err := remove("hanako@example.com"); err != nil {
+		t.Fatalf("本人の Delete() error = %v", err)
+	}
+}
+
+func TestActualRecordUseCase_GetTimesheet(t *testing.T) {
+	f := newScheduleFixture(1)
+	f.day.BreakMinutes = 60
+	actuals := &mockActualRecordRepository{records: make(map[sharedDomain.ID]*domain.ActualRecord)}
+	useCase := newActualRecordUseCase(f, actuals)
+	staffID := f.staffs[0].ID
+	late := f.addEntry(staffID, time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC), f.day.ID, true)
+	f.addEntry(staffID, time.Date(2025, 2, 4, 0, 0, 0, 0, time.UTC), f.day.ID, true)
+	f.addEntry(staffID, time.Date(2025, 2, 5, 0, 0, 0, 0, time.UTC), f.off.ID, true)
+
+	if _, err := useCase.Record(context.Background(), &RecordActualInput{
+		UserID:             actualManagerID,
+		IsManager:          true,
+		ScheduleID:         f.schedule.ID.String(),
+		EntryID:            late.ID.String(),
+		ActualStartTime:    "09:00",
+		ActualEndTime:      "17:30",
+		ActualBreakMinutes: 60,
+	}); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	timesheet, err := useCase.GetTimesheet(context.Background(), f.schedule.ID.String(), staffID.String())
+	if err != nil {
+		t.Fatalf("GetTimesheet() error = %v", err)
+	}
+	if len(timesheet.Days) != 28 {
+		t.Fatalf("days = %d, want 28", len(timesheet.Days))
+	}
+	if timesheet.StaffName != "山田 花子" {
+		t.Errorf("StaffName = %q", timesheet.StaffName)
+	}
+
+	day := timesheet.Days[2]
+	if day.PlannedStartTime != "08:30" || day.PlannedEndTime != "17:30" {
+		t.Errorf("planned = %s-%s, want 08:30-17:30", day.PlannedStartTime, day.PlannedEndTime)
+	}
+	if day.ActualStartTime != "09:00" || day.LateMinutes != 30 {
+		t.Errorf("actual start = %s late = %d, want 09:00 late 30", day.ActualStartTime, day.LateMinutes)
+	}
+	if timesheet.Days[4].PlannedStartTime != "" {
+		t.Error("休日シフトに予定時刻が設定されています")
+	}
+
+	summary := timesheet.Summary
+	if summary.PlannedDays != 2 || summary.ActualDays != 1 {
+		t.Errorf("planned days = %d actual days = %d, want 2 and 1", summary.PlannedDays, summary.ActualDays)
+	}
+	if summary.PlannedWorkingMinutes != 960 || summary.ActualWorkingMinutes != 450 {
+		t.Errorf("planned = %d actual = %d, want 960 and 450", summary.PlannedWorkingMinutes, summary.ActualWorkingMinutes)
+	}
+	if summary.LateCount != 1 || summary.LateMinutes != 30 {
+		t.Errorf("late = %d回 %d分, want 1回 30分", summary.LateCount, summary.LateMinutes)
+	}
+}
+
+func TestActualRecordUseCase_RecordRecalculatesWeek(t *testing.T) {
+	f := newScheduleFixture(1)
+	f.day.BreakMinutes = 60
+	actuals := &mockActualRecordRepository{records: make(map[sharedDomain.ID]*domain.ActualRecord)}
+	useCase := newActualRecordUseCase(f, actuals)
+	staffID := f.staffs[0].ID
+	record := func(entry *domain.ScheduleEntry, start, end string, breakMinutes int) {
+		t.Helper()
+		if _, err := useCase.Record(context.Background(), &RecordActualInput{
+			UserID:             actualManagerID,
+			IsManager:          true,
+			ScheduleID:         f.schedule.ID.String(),
+			EntryID:            entry.ID.String(),
+			ActualStartTime:    start,
+			ActualEndTime:      end,
+			ActualBreakMinutes: breakMinutes,
+		}); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+
+	// 2025-02-03(月)〜07(金)の日勤と08(土)の休日出勤
+	var weekdays []*domain.ScheduleEntry
+	for d := 3; d <= 7; d++ {
+		entry := f.addEntry(staffID, time.Date(2025, 2, d, 0, 0, 0, 0, time.UTC), f.day.ID, true)
+		weekdays = append(weekdays, entry)
+		record(entry, "08:30", "17:30", 60)
+	}
+	saturday := f.addEntry(staffID, time.Date(2025, 2, 8, 0, 0, 0, 0, time.UTC), f.off.ID, true)
+	record(saturday, "09:00", "13:00", 0)
+
+	saved, _ := actuals.FindByEntryID(context.Background(), saturday.ID)
+	if saved.OverLegalOvertimeMinutes != 240 || saved.OvertimeMinutes != 240 {
+		t.Errorf("土曜 法定外 = %d 残業 = %d, want 240", saved.OverLegalOvertimeMinutes, saved.OvertimeMinutes)
+	}
+
+	// 月曜の短縮で週40時間以内に収まると土曜の実績も法定内に再計算される
+	record(weekdays[0], "08:30", "12:30", 0)
+	saved, _ = actuals.FindByEntryID(context.Background(), saturday.ID)
+	if saved.OverLegalOvertimeMinutes != 0 || saved.WithinLegalOvertimeMinutes != 240 {
+		t.Errorf("土曜 法定外 = %d 法定内 = %d, want 0 and 240", saved.OverLegalOvertimeMinutes, saved.WithinLegalOvertimeMinutes)
+	}
+
+	timesheet, err := useCase.GetTimesheet(context.Background(), f.schedule.ID.String(), staffID.String())
+	if err != nil {
+		t.Fatalf("GetTimesheet() error = %v", err)
+	}
+	if timesheet.Summary.WithinLegalOvertimeMinutes != 240 || timesheet.Summary.OverLegalOvertimeMinutes != 0 {
+		t.Errorf("summary = %+v", timesheet.Summary)
+	}
+}
+
+func TestActualRecordUseCase_WeekSpanningMonths(t *testing.T) {
+	f := newScheduleFixture(1)
+	f.day.BreakMinutes = 60
+	actuals := &mockActualRecordRepository{records: make(map[sharedDomain.ID]*domain.ActualRecord)}
+	useCase := newActualRecordUseCase(f, actuals)
+	staffID := f.staffs[0].ID
+
+	// 2025-01-26(日)〜02-01(土)の週 1月分は前月の勤務表に登録済み
+	january := &domain.Schedule{ID: sharedDomain.NewID(), OrganizationID: f.schedule.OrganizationID, TargetYear: 2025, TargetMonth: 1}
+	useCase.scheduleRepo.(*mockScheduleRepository).schedules[january.ID] = january
+	var januaryRecords []*domain.ActualRecord
+	for d := 27; d <= 31; d++ {
+		entry := &domain.ScheduleEntry{ID: sharedDomain.NewID(), ScheduleID: january.ID, StaffID: staffID, TargetDate: time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC), ShiftTypeID: &f.day.ID}
+		f.entries.entries[entry.ID] = entry
+		start, end, _ := parseActualTimes(entry.TargetDate, "08:30", "17:30")
+		record := &domain.ActualRecord{ID: sharedDomain.NewID(), ScheduleEntryID: entry.ID, ActualStartTime: start, ActualEndTime: end, ActualBreakMinutes: 60}
+		actuals.records[record.ID] = record
+		januaryRecords = append(januaryRecords, record)
+	}
+
+	saturday := f.addEntry(staffID, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), f.off.ID, true)
+	output, err := useCase.Record(context.Background(), &RecordActualInput{
+		UserID:          actualManagerID,
+		IsManager:       true,
+		ScheduleID:      f.schedule.ID.String(),
+		EntryID:         saturday.ID.String(),
+		ActualStartTime: "09:00",
+		ActualEndTime:   "13:00",
+	})
+	if err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if output.OverLegalOvertimeMinutes != 240 {
+		t.Errorf("土曜 法定外 = %d, want 240", output.OverLegalOvertimeMinutes)
+	}
+	for _, record := range januaryRecords {
+		if record.Overtime() != (domain.OvertimeBreakdown{}) {
+			t.Errorf("前月の実績が更新されました: %+v", record.Overtime())
+		}
+	}
+
+	timesheet, err := useCase.GetTimesheet(context.Background(), f.schedule.ID.String(), staffID.String())
+	if err != nil {
+		t.Fatalf("GetTimesheet() error = %v", err)
+	}
+	if timesheet.Summary.OverLegalOvertimeMinutes != 240 || timesheet.Summary.ActualWorkingMinutes != 240 {
+		t.Errorf("summary = %+v, want only February counted", timesheet.Summary)
+	}
+	if timesheet.Days[0].ActualStartTime != "09:00" {
+		t.Errorf("ActualStartTime = %q, want 09:00", timesheet.Days[0].ActualStartTime)
+	}
+}
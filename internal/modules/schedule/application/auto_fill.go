@@ -0,0 +1,136 @@
+// Package application 勤務表アプリケーション層
+package application
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"shiftmaster/internal/modules/schedule/domain"
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
+	staffDomain "shiftmaster/internal/modules/staff/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// autoFillOffDays 巡回パターン内の休み日数
+const autoFillOffDays = 2
+
+// autoFillOptimizer 簡易自動割り当て 最適化エンジン未設定時の代替
+// 勤務シフトと休みの巡回パターンをスタッフごとにずらして割り当て、確定済みエントリは維持する
+type autoFillOptimizer struct {
+	scheduleRepo  domain.ScheduleRepository
+	staffRepo     staffDomain.StaffRepository
+	shiftTypeRepo shiftDomain.ShiftTypeRepository
+}
+
+// Optimize 勤務表自動割り当て
+func (a *autoFillOptimizer) Optimize(ctx interface{}, input domain.OptimizeInput) (*domain.OptimizeResult, error) {
+	c, ok := ctx.(context.Context)
+	if !ok {
+		c = context.Background()
+	}
+	started := time.Now()
+
+	schedule, err := a.scheduleRepo.FindByIDWithEntries(c, input.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil {
+		return nil, sharedDomain.ErrNotFound
+	}
+
+	staffs, err := a.staffRepo.FindActiveByOrganizationID(c, schedule.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	shiftTypes, err := a.shiftTypeRepo.FindByOrganizationID(c, schedule.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 巡回パターン 日勤系を開始時刻順に並べ、夜勤を最後に置いて休みへ繋げる
+	var workShifts []shiftDomain.ShiftType
+	var offShiftID *sharedDomain.ID
+	for i := range shiftTypes {
+		st := shiftTypes[i]
+		if st.IsHoliday {
+			if offShiftID == nil || st.SortOrder < findSortOrder(shiftTypes, *offShiftID) {
+				id := st.ID
+				offShiftID = &id
+			}
+			continue
+		}
+		workShifts = append(workShifts, st)
+	}
+	sort.SliceStable(workShifts, func(i, j int) bool {
+		if workShifts[i].IsNightShift != workShifts[j].IsNightShift {
+			return !workShifts[i].IsNightShift
+		}
+		return workShifts[i].StartTimeString() < workShifts[j].StartTimeString()
+	})
+
+	if len(staffs) == 0 || len(workShifts) == 0 {
+		return &domain.OptimizeResult{
+			Success:  false,
+			Entries:  []domain.ScheduleEntry{},
+			Duration: time.Since(started),
+		}, nil
+	}
+
+	pattern := make([]*sharedDomain.ID, 0, len(workShifts)+autoFillOffDays)
+	for i := range workShifts {
+		pattern = append(pattern, &workShifts[i].ID)
+	}
+	for i := 0; i < autoFillOffDays; i++ {
+		pattern = append(pattern, offShiftID)
+	}
+
+	// 確定済みエントリ
+	confirmed := make(map[string]domain.ScheduleEntry)
+	for _, e := range schedule.Entries {
+		if e.IsConfirmed {
+			confirmed[e.StaffID.String()+"_"+e.TargetDate.Format("2006-01-02")] = e
+		}
+	}
+
+	now := time.Now()
+	start := time.Date(schedule.TargetYear, time.Month(schedule.TargetMonth), 1, 0, 0, 0, 0, time.UTC)
+	days := schedule.DaysInMonth()
+	entries := make([]domain.ScheduleEntry, 0, len(staffs)*days)
+	for s, staff := range staffs {
+		for d := 0; d < days; d++ {
+			date := start.AddDate(0, 0, d)
+			if e, ok := confirmed[staff.ID.String()+"_"+date.Format("2006-01-02")]; ok {
+				entries = append(entries, e)
+				continue
+			}
+			entries = append(entries, domain.ScheduleEntry{
+				ID:          sharedDomain.NewID(),
+				ScheduleID:  schedule.ID,
+				StaffID:     staff.ID,
+				TargetDate:  date,
+				ShiftTypeID: pattern[(s+d)%len(pattern)],
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			})
+		}
+	}
+
+	return &domain.OptimizeResult{
+		Success:    true,
+		Entries:    entries,
+		Violations: []domain.ConstraintViolation{},
+		Duration:   time.Since(started),
+	}, nil
+}
+
+// findSortOrder シフト種別の表示順を取得
+func findSortOrder(shiftTypes []shiftDomain.ShiftType, id sharedDomain.ID) int {
+	for _, st := range shiftTypes {
+		if st.ID == id {
+			return st.SortOrder
+		}
+	}
+	return 0
+}
@@ -0,0 +1,248 @@
+// Package application 勤務表アプリケーション層
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"shiftmaster/internal/modules/schedule/domain"
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
+	staffDomain "shiftmaster/internal/modules/staff/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// calendarFeedPastMonths 購読カレンダーに含める過去の月数 当月より前
+const calendarFeedPastMonths = 3
+
+// calendarTokenBytes 購読トークンの乱数バイト数
+const calendarTokenBytes = 32
+
+// CalendarUseCase 勤務予定カレンダー購読ユースケース
+type CalendarUseCase struct {
+	scheduleRepo  domain.ScheduleRepository
+	entryRepo     domain.ScheduleEntryRepository
+	shiftTypeRepo shiftDomain.ShiftTypeRepository
+	staffRepo     staffDomain.StaffRepository
+	tokenRepo     domain.CalendarTokenRepository
+	logger        *slog.Logger
+}
+
+// NewCalendarUseCase 勤務予定カレンダー購読ユースケース生成
+func NewCalendarUseCase(
+	scheduleRepo domain.ScheduleRepository,
+	entryRepo domain.ScheduleEntryRepository,
+	shiftTypeRepo shiftDomain.ShiftTypeRepository,
+	staffRepo staffDomain.StaffRepository,
+	tokenRepo domain.CalendarTokenRepository,
+	logger *slog.Logger,
+) *CalendarUseCase {
+	return &CalendarUseCase{
+		scheduleRepo:  scheduleRepo,
+		entryRepo:     entryRepo,
+		shiftTypeRepo: shiftTypeRepo,
+		staffRepo:     staffRepo,
+		tokenRepo:     tokenRepo,
+		logger:        logger,
+	}
+}
+
+// GetToken 購読トークンの発行状況取得 トークン自体は返さない
+func (u *CalendarUseCase) GetToken(ctx context.Context, organizationID, staffID string) (*CalendarTokenOutput, error) {
+	staff, _, err := u.findOrganizationStaff(ctx, organizationID, staffID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := u.tokenRepo.FindByStaffID(ctx, staff.ID)
+	if err != nil {
+		return nil, err
+	}
+	output := &CalendarTokenOutput{StaffID: staff.ID.String()}
+	if token != nil {
+		output.Issued = true
+		output.CreatedAt = token.CreatedAt.Format(time.RFC3339)
+	}
+	return output, nil
+}
+
+// IssueToken 購読トークン発行 発行済みの場合は置き換え、以前のURLは使えなくなる
+func (u *CalendarUseCase) IssueToken(ctx context.Context, organizationID, staffID string) (*CalendarTokenOutput, error) {
+	staff, orgID, err := u.findOrganizationStaff(ctx, organizationID, staffID)
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, calendarTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	raw := base64.RawURLEncoding.EncodeToString(b)
+
+	token := &domain.CalendarToken{
+		StaffID:        staff.ID,
+		OrganizationID: orgID,
+		TokenHash:      hashCalendarToken(raw),
+		CreatedAt:      time.Now(),
+	}
+	if err := u.tokenRepo.Save(ctx, token); err != nil {
+		u.logger.Error("カレンダー購読トークン発行失敗", "error", err)
+		return nil, err
+	}
+
+	u.logger.Info("カレンダー購読トークン発行", "staff_id", staff.ID)
+	return &CalendarTokenOutput{
+		StaffID:   staff.ID.String(),
+		Issued:    true,
+		Token:     raw,
+		CreatedAt: token.CreatedAt.Format(time.RFC3339),
+	}, nil
+}
+
+// RevokeToken 購読トークン削除 購読URLは使えなくなる
+func (u *CalendarUseCase) RevokeToken(ctx context.Context, organizationID, staffID string) error {
+	staff, _, err := u.findOrganizationStaff(ctx, organizationID, staffID)
+	if err != nil {
+		return err
+	}
+	if err := u.tokenRepo.DeleteByStaffID(ctx, staff.ID); err != nil {
+		return err
+	}
+
+	u.logger.Info("カレンダー購読トークン削除", "staff_id", staff.ID)
+	return nil
+}
+
+// Feed 購読カレンダー出力 公開済み勤務表のエントリのみを含める
+// DTSTAMPは出力日時、LAST-MODIFIEDはエントリの更新日時にする
+func (u *CalendarUseCase) Feed(ctx context.Context, rawToken string) (*CalendarFeedOutput, error) {
+	if rawToken == "" {
+		return nil, sharedDomain.ErrNotFound
+	}
+	token, err := u.tokenRepo.FindByTokenHash(ctx, hashCalendarToken(rawToken))
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		return nil, sharedDomain.ErrNotFound
+	}
+	staff, err := u.staffRepo.FindByID(ctx, token.StaffID)
+	if err != nil {
+		return nil, err
+	}
+	if staff == nil || !staff.IsActive {
+		return nil, sharedDomain.ErrNotFound
+	}
+
+	schedules, err := u.scheduleRepo.FindByOrganizationID(ctx, token.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	shiftTypes, err := u.shiftTypeRepo.FindByOrganizationID(ctx, token.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	shiftTypeMap := make(map[sharedDomain.ID]*shiftDomain.ShiftType, len(shiftTypes))
+	for i := range shiftTypes {
+		shiftTypeMap[shiftTypes[i].ID] = &shiftTypes[i]
+	}
+
+	now := time.Now()
+	oldest := now.Year()*12 + int(now.Month()) - 1 - calendarFeedPastMonths
+	var events []calendarEvent
+	for _, schedule := range schedules {
+		if schedule.Status != domain.StatusPublished || schedule.TargetYear*12+schedule.TargetMonth-1 < oldest {
+			continue
+		}
+		entries, err := u.entryRepo.FindByScheduleAndStaff(ctx, schedule.ID, staff.ID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range entries {
+			if entries[i].ShiftTypeID == nil {
+				continue
+			}
+			shiftType, ok := shiftTypeMap[*entries[i].ShiftTypeID]
+			if !ok {
+				continue
+			}
+			events = append(events, newCalendarEvent(staff.ID, &entries[i], shiftType))
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].start.Before(events[j].start)
+	})
+
+	return &CalendarFeedOutput{
+		FileName: "shifts.ics",
+		Data:     buildICalendar(staff.FullName()+"の勤務予定", events, now),
+	}, nil
+}
+
+// findOrganizationStaff 組織の有効スタッフを取得 他組織のスタッフは見つからない扱い
+func (u *CalendarUseCase) findOrganizationStaff(ctx context.Context, organizationID, staffID string) (*staffDomain.Staff, sharedDomain.ID, error) {
+	orgID, err := sharedDomain.ParseID(organizationID)
+	if err != nil {
+		return nil, orgID, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDが不正です")
+	}
+	stID, err := sharedDomain.ParseID(staffID)
+	if err != nil {
+		return nil, orgID, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "スタッフIDが不正です")
+	}
+
+	staffs, err := u.staffRepo.FindActiveByOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, orgID, err
+	}
+	for i := range staffs {
+		if staffs[i].ID == stID {
+			return &staffs[i], orgID, nil
+		}
+	}
+	return nil, orgID, sharedDomain.ErrNotFound
+}
+
+// newCalendarEvent エントリから予定を生成 休日シフトは終日の予定にする
+// UIDはスタッフと勤務日から作るため、勤務表を作り直しても購読側の予定は重複せず置き換わる
+func newCalendarEvent(staffID sharedDomain.ID, entry *domain.ScheduleEntry, shiftType *shiftDomain.ShiftType) calendarEvent {
+	date := time.Date(entry.TargetDate.Year(), entry.TargetDate.Month(), entry.TargetDate.Day(), 0, 0, 0, 0, time.Local)
+	event := calendarEvent{
+		uid:      fmt.Sprintf("%s-%s@shiftmaster", staffID, date.Format(icalDateLayout)),
+		summary:  shiftType.Name,
+		category: shiftType.Code,
+		modified: entry.UpdatedAt,
+	}
+
+	var details []string
+	if shiftType.IsHoliday {
+		event.allDay = true
+		event.start, event.end = date, date.AddDate(0, 0, 1)
+	} else {
+		event.start, event.end = domain.PlannedPeriod(date, shiftType)
+		details = append(details, "勤務時間 "+shiftType.StartTimeString()+"〜"+shiftType.EndTimeString())
+		if shiftType.HasHandover() {
+			details = append(details, fmt.Sprintf("申し送り %s〜（%d分）", shiftType.ActualStartTimeString(), shiftType.HandoverMinutes))
+		}
+		if shiftType.BreakMinutes > 0 {
+			details = append(details, fmt.Sprintf("休憩 %d分", shiftType.BreakMinutes))
+		}
+	}
+	if entry.Note != "" {
+		details = append(details, entry.Note)
+	}
+	event.description = strings.Join(details, "\n")
+	return event
+}
+
+// hashCalendarToken 購読トークンのハッシュ化
+func hashCalendarToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
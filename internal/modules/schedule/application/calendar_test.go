@@ -0,0 +1,244 @@
+// Package application 勤務予定カレンダー購読テスト
+package application
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"shiftmaster/internal/modules/schedule/domain"
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// モックカレンダー購読トークンリポジトリ
+
+type mockCalendarTokenRepository struct {
+	tokens map[sharedDomain.ID]*domain.CalendarToken
+}
+
+func (m *mockCalendarTokenRepository) FindByStaffID(_ context.Context, staffID sharedDomain.ID) (*domain.CalendarToken, error) {
+	return m.tokens[staffID], nil
+}
+
+func (m *mockCalendarTokenRepository) FindByTokenHash(_ context.Context, tokenHash string) (*domain.CalendarToken, error) {
+	for _, t := range m.tokens {
+		if t.TokenHash == tokenHash {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockCalendarTokenRepository) Save(_ context.Context, token *domain.CalendarToken) error {
+	m.tokens[token.StaffID] = token
+	return nil
+}
+
+func (m *mockCalendarTokenRepository) DeleteByStaffID(_ context.Context, staffID sharedDomain.ID) error {
+	delete(m.tokens, staffID)
+	return nil
+}
+
+// calendarFixture 当月の公開済み勤務表と夜勤を持つテスト用ユースケース
+type calendarFixture struct {
+	*scheduleFixture
+	useCase   *CalendarUseCase
+	tokens    *mockCalendarTokenRepository
+	schedules *mockScheduleRepository
+	night     shiftDomain.ShiftType
+	year      int
+	month     time.Month
+}
+
+func newCalendarFixture() *calendarFixture {
+	f := &calendarFixture{
+		scheduleFixture: newScheduleFixture(1),
+		tokens:          &mockCalendarTokenRepository{tokens: make(map[sharedDomain.ID]*domain.CalendarToken)},
+	}
+	now := time.Now()
+	f.year, f.month = now.Year(), now.Month()
+	f.schedule.TargetYear, f.schedule.TargetMonth = f.year, int(f.month)
+	f.schedule.Status = domain.StatusPublished
+
+	night, _ := time.Parse("15:04", "16:30")
+	morning, _ := time.Parse("15:04", "09:00")
+	f.night = shiftDomain.ShiftType{
+		ID: sharedDomain.NewID(), Name: "夜勤", Code: "N", StartTime: night, EndTime: morning,
+		BreakMinutes: 120, HandoverMinutes: 30, IsNightShift: true,
+	}
+
+	f.schedules = &mockScheduleRepository{
+		schedules: map[sharedDomain.ID]*domain.Schedule{f.schedule.ID: f.schedule},
+		entries:   f.entries,
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	f.useCase = NewCalendarUseCase(
+		f.schedules,
+		f.entries,
+		&mockShiftTypeRepository{shiftTypes: []shiftDomain.ShiftType{f.day, f.off, f.night}},
+		&mockStaffRepository{staffs: f.staffs},
+		f.tokens,
+		logger,
+	)
+	return f
+}
+
+// date 当月の日付
+func (f *calendarFixture) date(day int) time.Time {
+	return time.Date(f.year, f.month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// addSchedule 当月からmonthsずらした勤務表を追加
+func (f *calendarFixture) addSchedule(months int, status domain.ScheduleStatus) *domain.Schedule {
+	target := time.Date(f.year, f.month+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	schedule := &domain.Schedule{
+		ID:             sharedDomain.NewID(),
+		OrganizationID: f.schedule.OrganizationID,
+		TargetYear:     target.Year(),
+		TargetMonth:    int(target.Month()),
+		Status:         status,
+	}
+	f.schedules.schedules[schedule.ID] = schedule
+	return schedule
+}
+
+func TestCalendarUseCase_Feed(t *testing.T) {
+	f := newCalendarFixture()
+	staffID := f.staffs[0].ID
+	orgID := f.schedule.OrganizationID.String()
+
+	night := f.addEntry(staffID, f.date(2), f.night.ID, false)
+	night.Note = "リーダー, 3階"
+	night.UpdatedAt = time.Date(f.year, f.month, 1, 3, 4, 5, 0, time.UTC)
+	f.addEntry(staffID, f.date(1), f.day.ID, false)
+	f.addEntry(staffID, f.date(3), f.off.ID, false)
+
+	// 下書きと古い勤務表は含めない
+	draft := f.addSchedule(1, domain.StatusDraft)
+	f.entries.entries[sharedDomain.NewID()] = &domain.ScheduleEntry{ID: sharedDomain.NewID(), ScheduleID: draft.ID, StaffID: staffID, TargetDate: f.date(1).AddDate(0, 1, 0), ShiftTypeID: &f.day.ID}
+	old := f.addSchedule(-calendarFeedPastMonths-1, domain.StatusPublished)
+	f.entries.entries[sharedDomain.NewID()] = &domain.ScheduleEntry{ID: sharedDomain.NewID(), ScheduleID: old.ID, StaffID: staffID, TargetDate: f.date(1).AddDate(0, -calendarFeedPastMonths-1, 0), ShiftTypeID: &f.day.ID}
+
+	issued, err := f.useCase.IssueToken(context.Background(), orgID, staffID.String())
+	if err != nil {
+		t.Fatalf("IssueToken() error = %v", err)
+	}
+	if issued.Token == "" || f.tokens.tokens[staffID].TokenHash == issued.Token {
+		t.Fatal("トークンはハッシュのみ保存する")
+	}
+
+	output, err := f.useCase.Feed(context.Background(), issued.Token)
+	if err != nil {
+		t.Fatalf("Feed() error = %v", err)
+	}
+	ics := string(output.Data)
+	// 折り返しを戻して内容を検証する
+	unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+
+	if !strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n") || !strings.HasSuffix(ics, "END:VCALENDAR\r\n") {
+		t.Errorf("VCALENDAR の形式が不正です:\n%s", ics)
+	}
+	if strings.Count(ics, "BEGIN:VEVENT") != 3 {
+		t.Errorf("VEVENT = %d, want 3", strings.Count(ics, "BEGIN:VEVENT"))
+	}
+	for _, want := range []string{
+		"TZID:Asia/Tokyo\r\nBEGIN:STANDARD",
+		"X-WR-CALNAME:山田 花子の勤務予定",
+		// 日勤
+		"DTSTART;TZID=Asia/Tokyo:" + f.date(1).Format("20060102") + "T083000",
+		// 夜勤は申し送り開始時刻に出勤し翌朝に退勤
+		"DTSTART;TZID=Asia/Tokyo:" + f.date(2).Format("20060102") + "T160000",
+		"DTEND;TZID=Asia/Tokyo:" + f.date(3).Format("20060102") + "T090000",
+		"DESCRIPTION:勤務時間 16:30〜09:00\\n申し送り 16:00〜（30分）\\n休憩 120分\\nリーダー\\, 3階",
+		// 休日は終日
+		"DTSTART;VALUE=DATE:" + f.date(3).Format("20060102"),
+		"DTEND;VALUE=DATE:" + f.date(4).Format("20060102"),
+		"UID:" + staffID.String() + "-" + f.date(2).Format("20060102") + "@shiftmaster",
+		"LAST-MODIFIED:" + night.UpdatedAt.Format("20060102T150405Z"),
+	} {
+		if !strings.Contains(unfolded, want) {
+			t.Errorf("ICS に %q が含まれていません", want)
+		}
+	}
+	if strings.Contains(unfolded, "DTSTAMP:"+night.UpdatedAt.Format("20060102T150405Z")) {
+		t.Error("DTSTAMP は出力日時にする")
+	}
+	if strings.Index(ics, f.date(1).Format("20060102")+"T083000") > strings.Index(ics, f.date(2).Format("20060102")+"T160000") {
+		t.Error("予定は開始日時順に並べる")
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		if len(line) > icalMaxLineOctets {
+			t.Errorf("75オクテットを超える行があります: %q", line)
+		}
+	}
+
+	// 再発行すると以前のトークンは使えない
+	if _, err := f.useCase.IssueToken(context.Background(), orgID, staffID.String()); err != nil {
+		t.Fatalf("IssueToken() error = %v", err)
+	}
+	if _, err := f.useCase.Feed(context.Background(), issued.Token); !errors.Is(err, sharedDomain.ErrNotFound) {
+		t.Errorf("再発行前のトークン error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestCalendarUseCase_Token(t *testing.T) {
+	f := newCalendarFixture()
+	staffID := f.staffs[0].ID.String()
+	orgID := f.schedule.OrganizationID.String()
+
+	status, err := f.useCase.GetToken(context.Background(), orgID, staffID)
+	if err != nil || status.Issued {
+		t.Fatalf("GetToken() = %+v, %v", status, err)
+	}
+
+	issued, err := f.useCase.IssueToken(context.Background(), orgID, staffID)
+	if err != nil {
+		t.Fatalf("IssueToken() error = %v", err)
+	}
+	status, err = f.useCase.GetToken(context.Background(), orgID, staffID)
+	if err != nil || !status.Issued || status.Token != "" {
+		t.Errorf("GetToken() = %+v, %v", status, err)
+	}
+
+	if err := f.useCase.RevokeToken(context.Background(), orgID, staffID); err != nil {
+		t.Fatalf("RevokeToken() error = %v", err)
+	}
+	if _, err := f.useCase.Feed(context.Background(), issued.Token); !errors.Is(err, sharedDomain.ErrNotFound) {
+		t.Errorf("削除済みトークン error = %v, want ErrNotFound", err)
+	}
+
+	if _, err := f.useCase.IssueToken(context.Background(), orgID, sharedDomain.NewID().String()); !errors.Is(err, sharedDomain.ErrNotFound) {
+		t.Errorf("組織外のスタッフ error = %v, want ErrNotFound", err)
+	}
+	if _, err := f.useCase.Feed(context.Background(), ""); !errors.Is(err, sharedDomain.ErrNotFound) {
+		t.Errorf("空のトークン error = %v, want ErrNotFound", err)
+	}
+
+	// 無効になったスタッフのカレンダーは配信しない
+	issued, _ = f.useCase.IssueToken(context.Background(), orgID, staffID)
+	f.staffs[0].IsActive = false
+	if _, err := f.useCase.Feed(context.Background(), issued.Token); !errors.Is(err, sharedDomain.ErrNotFound) {
+		t.Errorf("無効スタッフ error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestICalWriter_Fold(t *testing.T) {
+	w := &icalWriter{}
+	w.text("DESCRIPTION", strings.Repeat("あ", 40))
+
+	lines := strings.Split(strings.TrimSuffix(w.buf.String(), "\r\n"), "\r\n")
+	if len(lines) != 2 {
+		t.Fatalf("lines = %q", lines)
+	}
+	if len(lines[0]) > icalMaxLineOctets || len(lines[1]) > icalMaxLineOctets || !strings.HasPrefix(lines[1], " ") {
+		t.Errorf("lines = %q", lines)
+	}
+	if got := lines[0] + strings.TrimPrefix(lines[1], " "); got != "DESCRIPTION:"+strings.Repeat("あ", 40) {
+		t.Errorf("折り返しで文字が壊れています: %q", got)
+	}
+}
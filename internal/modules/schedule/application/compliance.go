@@ -0,0 +1,253 @@
+// Package application 勤務表アプリケーション層
+package application
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"time"
+
+	"shiftmaster/internal/modules/schedule/domain"
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
+	staffDomain "shiftmaster/internal/modules/staff/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// OvertimeComplianceService 36協定遵守サービス
+// 勤務実績と実績未登録日の勤務予定から時間外労働の見込みを集計し、組織の36協定の上限と照合する
+type OvertimeComplianceService struct {
+	agreementRepo domain.OvertimeAgreementRepository
+	scheduleRepo  domain.ScheduleRepository
+	entryRepo     domain.ScheduleEntryRepository
+	actualRepo    domain.ActualRecordRepository
+	shiftTypeRepo shiftDomain.ShiftTypeRepository
+	staffRepo     staffDomain.StaffRepository
+	logger        *slog.Logger
+}
+
+// NewOvertimeComplianceService 36協定遵守サービス生成
+func NewOvertimeComplianceService(
+	agreementRepo domain.OvertimeAgreementRepository,
+	scheduleRepo domain.ScheduleRepository,
+	entryRepo domain.ScheduleEntryRepository,
+	actualRepo domain.ActualRecordRepository,
+	shiftTypeRepo shiftDomain.ShiftTypeRepository,
+	staffRepo staffDomain.StaffRepository,
+	logger *slog.Logger,
+) *OvertimeComplianceService {
+	return &OvertimeComplianceService{
+		agreementRepo: agreementRepo,
+		scheduleRepo:  scheduleRepo,
+		entryRepo:     entryRepo,
+		actualRepo:    actualRepo,
+		shiftTypeRepo: shiftTypeRepo,
+		staffRepo:     staffRepo,
+		logger:        logger,
+	}
+}
+
+// GetAgreement 組織の36協定取得 未登録の場合は法定の限度時間
+func (s *OvertimeComplianceService) GetAgreement(ctx context.Context, organizationID string) (*OvertimeAgreementOutput, error) {
+	orgID, err := sharedDomain.ParseID(organizationID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDが不正です")
+	}
+
+	agreement, isDefault, err := s.loadAgreement(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return ToOvertimeAgreementOutput(agreement, isDefault), nil
+}
+
+// UpdateAgreement 組織の36協定更新 未登録の場合は新規登録
+func (s *OvertimeComplianceService) UpdateAgreement(ctx context.Context, input *UpdateOvertimeAgreementInput) (*OvertimeAgreementOutput, error) {
+	orgID, err := sharedDomain.ParseID(input.OrganizationID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDが不正です")
+	}
+
+	agreement, _, err := s.loadAgreement(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	agreement.MonthlyLimitMinutes = input.MonthlyLimitHours * 60
+	agreement.YearlyLimitMinutes = input.YearlyLimitHours * 60
+	agreement.MultiMonthAverageLimitMinutes = input.MultiMonthAverageLimitHours * 60
+	agreement.WarningPercent = input.WarningPercent
+	agreement.StartMonth = input.StartMonth
+	agreement.UpdatedAt = time.Now()
+
+	if err := agreement.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.agreementRepo.Save(ctx, agreement); err != nil {
+		s.logger.Error("36協定更新失敗", "error", err)
+		return nil, err
+	}
+
+	s.logger.Info("36協定更新完了", "organization_id", orgID)
+	return ToOvertimeAgreementOutput(agreement, false), nil
+}
+
+// ListAlerts 対象年月の36協定警告一覧 スタッフ名順
+func (s *OvertimeComplianceService) ListAlerts(ctx context.Context, organizationID string, year, month int) ([]OvertimeAlertOutput, error) {
+	orgID, err := sharedDomain.ParseID(organizationID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDが不正です")
+	}
+	if month < 1 || month > 12 {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "対象月が不正です")
+	}
+
+	alerts, err := s.Check(ctx, orgID, year, month)
+	if err != nil {
+		return nil, err
+	}
+
+	staffNames := make(map[sharedDomain.ID]string)
+	if s.staffRepo != nil {
+		staffs, err := s.staffRepo.FindActiveByOrganizationID(ctx, orgID)
+		if err != nil {
+			return nil, err
+		}
+		for _, staff := range staffs {
+			staffNames[staff.ID] = staff.LastName + " " + staff.FirstName
+		}
+	}
+
+	outputs := make([]OvertimeAlertOutput, len(alerts))
+	for i := range alerts {
+		outputs[i] = ToOvertimeAlertOutput(&alerts[i], staffNames[alerts[i].StaffID])
+	}
+	sort.SliceStable(outputs, func(i, j int) bool {
+		return outputs[i].StaffName < outputs[j].StaffName
+	})
+	return outputs, nil
+}
+
+// Check 対象年月の36協定警告を算出
+// 協定期間の起算月と複数月平均の最長期間のうち早い月から対象月までを集計する
+func (s *OvertimeComplianceService) Check(ctx context.Context, organizationID sharedDomain.ID, year, month int) ([]domain.OvertimeAlert, error) {
+	agreement, _, err := s.loadAgreement(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	shiftTypeMap, err := s.buildShiftTypeMap(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	target := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	startYear, startMonth := agreement.PeriodStart(year, month)
+	first := time.Date(startYear, time.Month(startMonth), 1, 0, 0, 0, 0, time.UTC)
+	if averageStart := target.AddDate(0, -5, 0); averageStart.Before(first) {
+		first = averageStart
+	}
+
+	var months []time.Time
+	var totals []map[sharedDomain.ID]domain.MonthlyOvertime
+	var staffIDs []sharedDomain.ID
+	seen := make(map[sharedDomain.ID]bool)
+	for m := first; !m.After(target); m = m.AddDate(0, 1, 0) {
+		monthly, err := s.monthlyOvertime(ctx, organizationID, m.Year(), int(m.Month()), shiftTypeMap)
+		if err != nil {
+			return nil, err
+		}
+		for staffID := range monthly {
+			if !seen[staffID] {
+				seen[staffID] = true
+				staffIDs = append(staffIDs, staffID)
+			}
+		}
+		months = append(months, m)
+		totals = append(totals, monthly)
+	}
+
+	var alerts []domain.OvertimeAlert
+	for _, staffID := range staffIDs {
+		// 勤務のない月は0として連続した月別集計を構築
+		history := make([]domain.MonthlyOvertime, len(months))
+		for i, m := range months {
+			history[i] = domain.MonthlyOvertime{Year: m.Year(), Month: int(m.Month())}
+			if total, ok := totals[i][staffID]; ok {
+				history[i] = total
+			}
+		}
+		alerts = append(alerts, agreement.Evaluate(staffID, history)...)
+	}
+	return alerts, nil
+}
+
+// monthlyOvertime 対象年月のスタッフ別時間外労働 勤務表がない月は空
+func (s *OvertimeComplianceService) monthlyOvertime(
+	ctx context.Context,
+	organizationID sharedDomain.ID,
+	year, month int,
+	shiftTypeMap map[string]*shiftDomain.ShiftType,
+) (map[sharedDomain.ID]domain.MonthlyOvertime, error) {
+	schedule, err := s.scheduleRepo.FindByTargetMonth(ctx, organizationID, year, month)
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil {
+		return nil, nil
+	}
+
+	entries, err := s.entryRepo.FindByScheduleID(ctx, schedule.ID)
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.actualRepo.FindBySchedule(ctx, schedule.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	byStaff := make(map[sharedDomain.ID][]domain.ScheduleEntry)
+	for _, entry := range entries {
+		byStaff[entry.StaffID] = append(byStaff[entry.StaffID], entry)
+	}
+
+	result := make(map[sharedDomain.ID]domain.MonthlyOvertime, len(byStaff))
+	for staffID, staffEntries := range byStaff {
+		days := domain.ProjectAttendance(buildAttendanceDays(staffEntries, records, shiftTypeMap))
+		var total domain.OvertimeBreakdown
+		for _, overtime := range domain.CalculateOvertime(days) {
+			total.Add(overtime)
+		}
+		result[staffID] = domain.MonthlyOvertime{
+			Year:               year,
+			Month:              month,
+			OverLegalMinutes:   total.OverLegalMinutes,
+			HolidayWorkMinutes: total.HolidayWorkMinutes,
+		}
+	}
+	return result, nil
+}
+
+// loadAgreement 組織の36協定取得 未登録の場合は法定の限度時間とtrue
+func (s *OvertimeComplianceService) loadAgreement(ctx context.Context, organizationID sharedDomain.ID) (*domain.OvertimeAgreement, bool, error) {
+	agreement, err := s.agreementRepo.FindByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, false, err
+	}
+	if agreement == nil {
+		return domain.NewDefaultOvertimeAgreement(organizationID), true, nil
+	}
+	return agreement, false, nil
+}
+
+// buildShiftTypeMap 組織のシフト種別マップを構築
+func (s *OvertimeComplianceService) buildShiftTypeMap(ctx context.Context, organizationID sharedDomain.ID) (map[string]*shiftDomain.ShiftType, error) {
+	shiftTypes, err := s.shiftTypeRepo.FindByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*shiftDomain.ShiftType, len(shiftTypes))
+	for i := range shiftTypes {
+		result[shiftTypes[i].ID.String()] = &shiftTypes[i]
+	}
+	return result, nil
+}
@@ -0,0 +1,135 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"shiftmaster/internal/modules/schedule/domain"
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// モック36協定リポジトリ
+
+type mockOvertimeAgreementRepository struct {
+	agreement *domain.OvertimeAgreement
+}
+
+func (m *mockOvertimeAgreementRepository) FindByOrganizationID(_ context.Context, _ sharedDomain.ID) (*domain.OvertimeAgreement, error) {
+	return m.agreement, nil
+}
+
+func (m *mockOvertimeAgreementRepository) Save(_ context.Context, agreement *domain.OvertimeAgreement) error {
+	m.agreement = agreement
+	return nil
+}
+
+func newOvertimeComplianceService(f *scheduleFixture, agreements *mockOvertimeAgreementRepository, actuals *mockActualRecordRepository) *OvertimeComplianceService {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	return NewOvertimeComplianceService(
+		agreements,
+		&mockScheduleRepository{schedules: map[sharedDomain.ID]*domain.Schedule{f.schedule.ID: f.schedule}, entries: f.entries},
+		f.entries,
+		actuals,
+		&mockShiftTypeRepository{shiftTypes: []shiftDomain.ShiftType{f.day, f.off}},
+		&mockStaffRepository{staffs: f.staffs},
+		logger,
+	)
+}
+
+func TestOvertimeComplianceService_UpdateAgreement(t *testing.T) {
+	f := newScheduleFixture(1)
+	agreements := &mockOvertimeAgreementRepository{}
+	service := newOvertimeComplianceService(f, agreements, &mockActualRecordRepository{records: make(map[sharedDomain.ID]*domain.ActualRecord)})
+	orgID := f.schedule.OrganizationID.String()
+
+	got, err := service.GetAgreement(context.Background(), orgID)
+	if err != nil {
+		t.Fatalf("GetAgreement() error = %v", err)
+	}
+	if !got.IsDefault || got.MonthlyLimitHours != 45 || got.YearlyLimitHours != 360 {
+		t.Errorf("GetAgreement() = %+v, want default limits", got)
+	}
+
+	input := &UpdateOvertimeAgreementInput{
+		OrganizationID:              orgID,
+		MonthlyLimitHours:           30,
+		YearlyLimitHours:            300,
+		MultiMonthAverageLimitHours: 60,
+		WarningPercent:              90,
+		StartMonth:                  1,
+	}
+	if _, err := service.UpdateAgreement(context.Background(), input); err != nil {
+		t.Fatalf("UpdateAgreement() error = %v", err)
+	}
+	if agreements.agreement == nil || agreements.agreement.MonthlyLimitMinutes != 30*60 {
+		t.Errorf("saved agreement = %+v", agreements.agreement)
+	}
+
+	input.MonthlyLimitHours = 400
+	if _, err := service.UpdateAgreement(context.Background(), input); err == nil {
+		t.Error("UpdateAgreement() expected error for monthly limit above yearly limit")
+	}
+}
+
+func TestOvertimeComplianceService_Check(t *testing.T) {
+	f := newScheduleFixture(1)
+	staffID := f.staffs[0].ID
+	actuals := &mockActualRecordRepository{records: make(map[sharedDomain.ID]*domain.ActualRecord)}
+	agreement := domain.NewDefaultOvertimeAgreement(f.schedule.OrganizationID)
+	agreement.MonthlyLimitMinutes = 10 * 60
+	service := newOvertimeComplianceService(f, &mockOvertimeAgreementRepository{agreement: agreement}, actuals)
+
+	// 2025-02-03〜05に4時間ずつ残業した実績 06〜07は実績未登録の予定どおり勤務
+	for d := 3; d <= 7; d++ {
+		date := time.Date(2025, 2, d, 0, 0, 0, 0, time.UTC)
+		entry := f.addEntry(staffID, date, f.day.ID, true)
+		if d > 5 {
+			continue
+		}
+		start := time.Date(2025, 2, d, 8, 30, 0, 0, time.Local)
+		end := time.Date(2025, 2, d, 21, 30, 0, 0, time.Local)
+		record := &domain.ActualRecord{ID: sharedDomain.NewID(), ScheduleEntryID: entry.ID, ActualStartTime: &start, ActualEndTime: &end}
+		actuals.records[record.ID] = record
+	}
+
+	alerts, err := service.Check(context.Background(), f.schedule.OrganizationID, 2025, 2)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if len(alerts) != 1 {
+		t.Fatalf("Check() = %+v, want 1 alert", alerts)
+	}
+	want := domain.OvertimeAlert{
+		StaffID:      staffID,
+		Year:         2025,
+		Month:        2,
+		LimitType:    domain.OvertimeLimitMonthly,
+		Level:        domain.OvertimeAlertExceeded,
+		Minutes:      720,
+		LimitMinutes: 600,
+		Months:       1,
+	}
+	if alerts[0] != want {
+		t.Errorf("Check() = %+v, want %+v", alerts[0], want)
+	}
+
+	// 勤務表検証に警告として含まれる
+	f.useCase.compliance = service
+	result, err := f.useCase.Validate(context.Background(), f.schedule.ID.String())
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	found := false
+	for _, v := range result.Violations {
+		if v.Type == domain.ViolationOvertimeLimit && v.Severity == string(domain.SeverityWarning) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Validate() violations = %+v, want overtime_limit warning", result.Violations)
+	}
+}
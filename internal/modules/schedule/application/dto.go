@@ -221,6 +221,22 @@ type ValidateResult struct {
 	IsValid bool `json:"is_valid"`
 	// Violations 違反リスト
 	Violations []ViolationOutput `json:"violations"`
+	// RequestFulfillment スタッフ別勤務希望充足率
+	RequestFulfillment []RequestFulfillmentOutput `json:"request_fulfillment"`
+}
+
+// RequestFulfillmentOutput スタッフ別勤務希望充足率出力
+type RequestFulfillmentOutput struct {
+	// StaffID スタッフID
+	StaffID string `json:"staff_id"`
+	// StaffName スタッフ名
+	StaffName string `json:"staff_name"`
+	// Total 勤務希望数
+	Total int `json:"total"`
+	// Fulfilled 反映された勤務希望数
+	Fulfilled int `json:"fulfilled"`
+	// Rate 充足率（%）
+	Rate float64 `json:"rate"`
 }
 
 // ViolationOutput 違反出力
@@ -233,6 +249,864 @@ type ViolationOutput struct {
 	StaffID string `json:"staff_id"`
 	// Date 日付
 	Date string `json:"date"`
+	// ShiftTypeID 配置人数違反の対象シフト種別ID
+	ShiftTypeID string `json:"shift_type_id,omitempty"`
 	// Severity 重大度
 	Severity string `json:"severity"`
+	// RuleName 違反したシフトルール名
+	RuleName string `json:"rule_name,omitempty"`
+}
+
+// GenerateScheduleInput 勤務表自動作成入力
+type GenerateScheduleInput struct {
+	// ScheduleID 勤務表ID
+	ScheduleID string `json:"schedule_id"`
+	// MaxIterations 最大反復回数 0は既定値
+	MaxIterations int `json:"max_iterations"`
+	// TimeoutSeconds タイムアウト秒数 0は既定値
+	TimeoutSeconds int `json:"timeout_seconds"`
+	// PrioritizeRequests 希望優先
+	PrioritizeRequests bool `json:"prioritize_requests"`
+}
+
+// Validate 入力検証
+func (i *GenerateScheduleInput) Validate() error {
+	if i.ScheduleID == "" {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "勤務表IDは必須です")
+	}
+	if i.MaxIterations < 0 || i.MaxIterations > 1000000 {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "最大反復回数が不正です")
+	}
+	if i.TimeoutSeconds < 0 || i.TimeoutSeconds > 120 {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "タイムアウト秒数は0から120の範囲で指定してください")
+	}
+	return nil
+}
+
+// ProposalOutput 自動作成案出力
+type ProposalOutput struct {
+	// ID 作成案ID
+	ID string `json:"id"`
+	// ScheduleID 勤務表ID
+	ScheduleID string `json:"schedule_id"`
+	// Status 状態
+	Status string `json:"status"`
+	// StatusLabel 状態ラベル
+	StatusLabel string `json:"status_label"`
+	// IsPending 承認待ちフラグ
+	IsPending bool `json:"is_pending"`
+	// Generator 作成方式
+	Generator string `json:"generator"`
+	// GeneratorLabel 作成方式ラベル
+	GeneratorLabel string `json:"generator_label"`
+	// Score スコア 0が最良
+	Score float64 `json:"score"`
+	// HasScore スコア有無 簡易割り当てではスコアを算出しない
+	HasScore bool `json:"has_score"`
+	// DurationMs 作成処理時間（ミリ秒）
+	DurationMs int64 `json:"duration_ms"`
+	// Entries 作成案エントリ
+	Entries []ScheduleEntryOutput `json:"entries,omitempty"`
+	// Violations 作成時点の制約違反
+	Violations []ViolationOutput `json:"violations"`
+	// Comparison 現在の勤務表との比較
+	Comparison *ProposalComparisonOutput `json:"comparison,omitempty"`
+	// AppliedAt 適用日時
+	AppliedAt string `json:"applied_at"`
+	// CreatedAt 作成日時
+	CreatedAt string `json:"created_at"`
+}
+
+// ToProposalOutput ドメインエンティティから出力DTOへ変換
+func ToProposalOutput(p *domain.ScheduleProposal) *ProposalOutput {
+	appliedAt := ""
+	if p.AppliedAt != nil {
+		appliedAt = p.AppliedAt.Format(time.RFC3339)
+	}
+
+	entries := make([]ScheduleEntryOutput, len(p.Entries))
+	for i, e := range p.Entries {
+		entries[i] = *ToScheduleEntryOutput(&e)
+	}
+
+	violations := make([]ViolationOutput, len(p.Violations))
+	for i, v := range p.Violations {
+		violations[i] = ToViolationOutput(v)
+	}
+
+	return &ProposalOutput{
+		ID:             p.ID.String(),
+		ScheduleID:     p.ScheduleID.String(),
+		Status:         p.Status.String(),
+		StatusLabel:    p.Status.Label(),
+		IsPending:      p.IsPending(),
+		Generator:      p.Generator.String(),
+		GeneratorLabel: p.Generator.Label(),
+		Score:          p.Score,
+		HasScore:       p.Generator == domain.GeneratorOptimizer,
+		DurationMs:     p.Duration.Milliseconds(),
+		Entries:        entries,
+		Violations:     violations,
+		AppliedAt:      appliedAt,
+		CreatedAt:      p.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// ToViolationOutput 制約違反から違反出力へ変換
+func ToViolationOutput(v domain.ConstraintViolation) ViolationOutput {
+	output := ViolationOutput{
+		Type:     v.ConstraintType,
+		Message:  v.Message,
+		Severity: v.Severity,
+		RuleName: v.RuleName,
+	}
+	if v.StaffID != nil {
+		output.StaffID = v.StaffID.String()
+	}
+	if v.Date != nil {
+		output.Date = v.Date.Format("2006-01-02")
+	}
+	if v.ShiftTypeID != nil {
+		output.ShiftTypeID = v.ShiftTypeID.String()
+	}
+	return output
+}
+
+// ProposalComparisonOutput 作成案比較出力
+type ProposalComparisonOutput struct {
+	// Current 現在の勤務表の違反件数
+	Current ViolationSummaryOutput `json:"current"`
+	// Proposed 作成案の違反件数
+	Proposed ViolationSummaryOutput `json:"proposed"`
+	// ChangedCount 変更セル数
+	ChangedCount int `json:"changed_count"`
+	// Changes 変更セル一覧
+	Changes []EntryChangeOutput `json:"changes"`
+	// Resolved 解消される違反
+	Resolved []ViolationOutput `json:"resolved"`
+	// Introduced 新たに発生する違反
+	Introduced []ViolationOutput `json:"introduced"`
+}
+
+// ViolationSummaryOutput 違反件数集計出力
+type ViolationSummaryOutput struct {
+	// Errors エラー件数
+	Errors int `json:"errors"`
+	// Warnings 警告件数
+	Warnings int `json:"warnings"`
+	// Infos 情報件数
+	Infos int `json:"infos"`
+	// Total 合計件数
+	Total int `json:"total"`
+}
+
+// EntryChangeOutput エントリ変更出力
+type EntryChangeOutput struct {
+	// StaffID スタッフID
+	StaffID string `json:"staff_id"`
+	// StaffName スタッフ名
+	StaffName string `json:"staff_name"`
+	// TargetDate 対象日
+	TargetDate string `json:"target_date"`
+	// Before 変更前シフト種別名
+	Before string `json:"before"`
+	// After 変更後シフト種別名
+	After string `json:"after"`
+}
+
+// RecordActualInput 勤務実績登録入力
+type RecordActualInput struct {
+	// ScheduleID 勤務表ID
+	ScheduleID string `json:"schedule_id"`
+	// EntryID 勤務表エントリID
+	EntryID string `json:"entry_id"`
+	// ActualStartTime 出勤時刻（HH:MM形式） 勤務日の時刻として扱う
+	ActualStartTime string `json:"actual_start_time"`
+	// ActualEndTime 退勤時刻（HH:MM形式） 出勤時刻以前の場合は翌日の時刻として扱う
+	ActualEndTime string `json:"actual_end_time"`
+	// ActualBreakMinutes 休憩時間 分
+	ActualBreakMinutes int `json:"actual_break_minutes"`
+	// Note 備考
+	Note string `json:"note"`
+	// UserID 操作ユーザーID
+	UserID string `json:"-"`
+	// UserEmail 操作ユーザーのメールアドレス 同じメールアドレスのスタッフを本人とみなす
+	UserEmail string `json:"-"`
+	// IsManager 操作ユーザーがマネージャー以上 他のスタッフの実績も登録できる
+	IsManager bool `json:"-"`
+}
+
+// Validate 入力検証
+func (i *RecordActualInput) Validate() error {
+	if i.ScheduleID == "" {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "勤務表IDは必須です")
+	}
+	if i.EntryID == "" {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "エントリIDは必須です")
+	}
+	if i.ActualStartTime == "" && i.ActualEndTime == "" {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "出勤時刻または退勤時刻を入力してください")
+	}
+	return nil
+}
+
+// DeleteActualInput 勤務実績削除入力
+type DeleteActualInput struct {
+	// ScheduleID 勤務表ID
+	ScheduleID string
+	// EntryID 勤務表エントリID
+	EntryID string
+	// UserID 操作ユーザーID
+	UserID string
+	// UserEmail 操作ユーザーのメールアドレス
+	UserEmail string
+	// IsManager 操作ユーザーがマネージャー以上 他のスタッフの実績も削除できる
+	IsManager bool
+}
+
+// ActualRecordOutput 勤務実績出力
+type ActualRecordOutput struct {
+	// ID 実績ID
+	ID string `json:"id"`
+	// ScheduleEntryID 勤務表エントリID
+	ScheduleEntryID string `json:"schedule_entry_id"`
+	// ActualStartTime 出勤日時
+	ActualStartTime string `json:"actual_start_time"`
+	// ActualEndTime 退勤日時
+	ActualEndTime string `json:"actual_end_time"`
+	// ActualBreakMinutes 休憩時間 分
+	ActualBreakMinutes int `json:"actual_break_minutes"`
+	// ActualWorkingMinutes 実働時間 分
+	ActualWorkingMinutes int `json:"actual_working_minutes"`
+	// OvertimeMinutes 残業時間 法定内残業と法定外残業の合計 分
+	OvertimeMinutes int `json:"overtime_minutes"`
+	// WithinLegalOvertimeMinutes 法定内残業時間 分
+	WithinLegalOvertimeMinutes int `json:"within_legal_overtime_minutes"`
+	// OverLegalOvertimeMinutes 法定外残業時間 分
+	OverLegalOvertimeMinutes int `json:"over_legal_overtime_minutes"`
+	// LateNightMinutes 深夜労働時間 分
+	LateNightMinutes int `json:"late_night_minutes"`
+	// HolidayWorkMinutes 休日労働時間 分
+	HolidayWorkMinutes int `json:"holiday_work_minutes"`
+	// Note 備考
+	Note string `json:"note"`
+	// CreatedAt 作成日時
+	CreatedAt string `json:"created_at"`
+	// UpdatedAt 更新日時
+	UpdatedAt string `json:"updated_at"`
+}
+
+// ToActualRecordOutput ドメインエンティティから出力DTOへ変換
+func ToActualRecordOutput(r *domain.ActualRecord) *ActualRecordOutput {
+	output := &ActualRecordOutput{
+		ID:                         r.ID.String(),
+		ScheduleEntryID:            r.ScheduleEntryID.String(),
+		ActualBreakMinutes:         r.ActualBreakMinutes,
+		ActualWorkingMinutes:       r.ActualWorkingMinutes(),
+		OvertimeMinutes:            r.OvertimeMinutes,
+		WithinLegalOvertimeMinutes: r.WithinLegalOvertimeMinutes,
+		OverLegalOvertimeMinutes:   r.OverLegalOvertimeMinutes,
+		LateNightMinutes:           r.LateNightMinutes,
+		HolidayWorkMinutes:         r.HolidayWorkMinutes,
+		Note:                       r.Note,
+		CreatedAt:                  r.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:                  r.UpdatedAt.Format(time.RFC3339),
+	}
+	if r.ActualStartTime != nil {
+		output.ActualStartTime = r.ActualStartTime.Format(time.RFC3339)
+	}
+	if r.ActualEndTime != nil {
+		output.ActualEndTime = r.ActualEndTime.Format(time.RFC3339)
+	}
+	return output
+}
+
+// TimesheetOutput スタッフ別月次勤務実績表出力
+type TimesheetOutput struct {
+	// ScheduleID 勤務表ID
+	ScheduleID string `json:"schedule_id"`
+	// StaffID スタッフID
+	StaffID string `json:"staff_id"`
+	// StaffName スタッフ名
+	StaffName string `json:"staff_name"`
+	// TargetPeriodLabel 対象期間ラベル
+	TargetPeriodLabel string `json:"target_period_label"`
+	// Days 日別の予定と実績
+	Days []TimesheetDayOutput `json:"days"`
+	// Summary 月間集計
+	Summary TimesheetSummaryOutput `json:"summary"`
+}
+
+// TimesheetDayOutput 勤務実績表の日別行出力
+type TimesheetDayOutput struct {
+	// Date 日付（YYYY-MM-DD形式）
+	Date string `json:"date"`
+	// Day 日
+	Day int `json:"day"`
+	// DayOfWeek 曜日
+	DayOfWeek string `json:"day_of_week"`
+	// IsWeekend 土日祝フラグ
+	IsWeekend bool `json:"is_weekend"`
+	// EntryID 勤務表エントリID エントリがない日は空
+	EntryID string `json:"entry_id"`
+	// ShiftTypeName シフト種別名
+	ShiftTypeName string `json:"shift_type_name"`
+	// ShiftTypeCode シフト種別コード
+	ShiftTypeCode string `json:"shift_type_code"`
+	// PlannedStartTime 予定出勤時刻（HH:MM形式）
+	PlannedStartTime string `json:"planned_start_time"`
+	// PlannedEndTime 予定退勤時刻（HH:MM形式）
+	PlannedEndTime string `json:"planned_end_time"`
+	// PlannedBreakMinutes 予定休憩時間 分
+	PlannedBreakMinutes int `json:"planned_break_minutes"`
+	// Actual 勤務実績 未登録はnil
+	Actual *ActualRecordOutput `json:"actual"`
+	// ActualStartTime 出勤時刻（HH:MM形式）
+	ActualStartTime string `json:"actual_start_time"`
+	// ActualEndTime 退勤時刻（HH:MM形式）
+	ActualEndTime string `json:"actual_end_time"`
+	// PlannedWorkingMinutes 予定実働時間 分
+	PlannedWorkingMinutes int `json:"planned_working_minutes"`
+	// ActualWorkingMinutes 実績実働時間 分
+	ActualWorkingMinutes int `json:"actual_working_minutes"`
+	// LateMinutes 遅刻時間 分
+	LateMinutes int `json:"late_minutes"`
+	// EarlyLeaveMinutes 早退時間 分
+	EarlyLeaveMinutes int `json:"early_leave_minutes"`
+	// OvertimeMinutes 残業時間 法定内残業と法定外残業の合計 分
+	OvertimeMinutes int `json:"overtime_minutes"`
+	// WithinLegalOvertimeMinutes 法定内残業時間 分
+	WithinLegalOvertimeMinutes int `json:"within_legal_overtime_minutes"`
+	// OverLegalOvertimeMinutes 法定外残業時間 分
+	OverLegalOvertimeMinutes int `json:"over_legal_overtime_minutes"`
+	// LateNightMinutes 深夜労働時間 分
+	LateNightMinutes int `json:"late_night_minutes"`
+	// HolidayWorkMinutes 休日労働時間 分
+	HolidayWorkMinutes int `json:"holiday_work_minutes"`
+}
+
+// TimesheetSummaryOutput 勤務実績表の月間集計出力
+type TimesheetSummaryOutput struct {
+	// PlannedDays 予定勤務日数
+	PlannedDays int `json:"planned_days"`
+	// ActualDays 実績勤務日数
+	ActualDays int `json:"actual_days"`
+	// PlannedWorkingMinutes 予定実働時間合計 分
+	PlannedWorkingMinutes int `json:"planned_working_minutes"`
+	// ActualWorkingMinutes 実績実働時間合計 分
+	ActualWorkingMinutes int `json:"actual_working_minutes"`
+	// LateCount 遅刻回数
+	LateCount int `json:"late_count"`
+	// LateMinutes 遅刻時間合計 分
+	LateMinutes int `json:"late_minutes"`
+	// EarlyLeaveCount 早退回数
+	EarlyLeaveCount int `json:"early_leave_count"`
+	// EarlyLeaveMinutes 早退時間合計 分
+	EarlyLeaveMinutes int `json:"early_leave_minutes"`
+	// OvertimeMinutes 残業時間合計 分
+	OvertimeMinutes int `json:"overtime_minutes"`
+	// WithinLegalOvertimeMinutes 法定内残業時間合計 分
+	WithinLegalOvertimeMinutes int `json:"within_legal_overtime_minutes"`
+	// OverLegalOvertimeMinutes 法定外残業時間合計 分
+	OverLegalOvertimeMinutes int `json:"over_legal_overtime_minutes"`
+	// LateNightMinutes 深夜労働時間合計 分
+	LateNightMinutes int `json:"late_night_minutes"`
+	// HolidayWorkMinutes 休日労働時間合計 分
+	HolidayWorkMinutes int `json:"holiday_work_minutes"`
+}
+
+// UpdateOvertimeAgreementInput 36協定更新入力
+type UpdateOvertimeAgreementInput struct {
+	// OrganizationID 組織ID
+	OrganizationID string `json:"organization_id"`
+	// MonthlyLimitHours 月間上限 時間
+	MonthlyLimitHours int `json:"monthly_limit_hours"`
+	// YearlyLimitHours 年間上限 時間
+	YearlyLimitHours int `json:"yearly_limit_hours"`
+	// MultiMonthAverageLimitHours 複数月平均上限 時間
+	MultiMonthAverageLimitHours int `json:"multi_month_average_limit_hours"`
+	// WarningPercent 警告開始割合 %
+	WarningPercent int `json:"warning_percent"`
+	// StartMonth 協定期間の起算月
+	StartMonth int `json:"start_month"`
+}
+
+// OvertimeAgreementOutput 36協定出力
+type OvertimeAgreementOutput struct {
+	// OrganizationID 組織ID
+	OrganizationID string `json:"organization_id"`
+	// MonthlyLimitHours 月間上限 時間
+	MonthlyLimitHours int `json:"monthly_limit_hours"`
+	// YearlyLimitHours 年間上限 時間
+	YearlyLimitHours int `json:"yearly_limit_hours"`
+	// MultiMonthAverageLimitHours 複数月平均上限 時間
+	MultiMonthAverageLimitHours int `json:"multi_month_average_limit_hours"`
+	// WarningPercent 警告開始割合 %
+	WarningPercent int `json:"warning_percent"`
+	// StartMonth 協定期間の起算月
+	StartMonth int `json:"start_month"`
+	// IsDefault 未登録のため法定の限度時間を使用
+	IsDefault bool `json:"is_default"`
+}
+
+// ToOvertimeAgreementOutput ドメインエンティティから出力DTOへ変換
+func ToOvertimeAgreementOutput(a *domain.OvertimeAgreement, isDefault bool) *OvertimeAgreementOutput {
+	return &OvertimeAgreementOutput{
+		OrganizationID:              a.OrganizationID.String(),
+		MonthlyLimitHours:           a.MonthlyLimitMinutes / 60,
+		YearlyLimitHours:            a.YearlyLimitMinutes / 60,
+		MultiMonthAverageLimitHours: a.MultiMonthAverageLimitMinutes / 60,
+		WarningPercent:              a.WarningPercent,
+		StartMonth:                  a.StartMonth,
+		IsDefault:                   isDefault,
+	}
+}
+
+// OvertimeAlertOutput 36協定警告出力
+type OvertimeAlertOutput struct {
+	// StaffID スタッフID
+	StaffID string `json:"staff_id"`
+	// StaffName スタッフ名
+	StaffName string `json:"staff_name"`
+	// Year 対象年
+	Year int `json:"year"`
+	// Month 対象月
+	Month int `json:"month"`
+	// LimitType 上限種別
+	LimitType string `json:"limit_type"`
+	// Level 警告レベル
+	Level string `json:"level"`
+	// Minutes 見込み時間 分
+	Minutes int `json:"minutes"`
+	// LimitMinutes 上限時間 分
+	LimitMinutes int `json:"limit_minutes"`
+	// Months 複数月平均の月数
+	Months int `json:"months"`
+	// Message メッセージ
+	Message string `json:"message"`
+}
+
+// ToOvertimeAlertOutput ドメインの警告から出力DTOへ変換
+func ToOvertimeAlertOutput(a *domain.OvertimeAlert, staffName string) OvertimeAlertOutput {
+	return OvertimeAlertOutput{
+		StaffID:      a.StaffID.String(),
+		StaffName:    staffName,
+		Year:         a.Year,
+		Month:        a.Month,
+		LimitType:    string(a.LimitType),
+		Level:        string(a.Level),
+		Minutes:      a.Minutes,
+		LimitMinutes: a.LimitMinutes,
+		Months:       a.Months,
+		Message:      a.Message(),
+	}
+}
+
+// ExportEntriesCSVOutput 勤務表エントリCSV出力
+type ExportEntriesCSVOutput struct {
+	// FileName ダウンロード時のファイル名
+	FileName string
+	// Data CSVの内容 BOM付きUTF-8
+	Data []byte
+}
+
+// ImportEntriesCSVInput 勤務表エントリCSV取込入力
+type ImportEntriesCSVInput struct {
+	// OrganizationID 組織ID
+	OrganizationID string
+	// ScheduleID 勤務表ID
+	ScheduleID string
+	// Data CSVの内容
+	Data []byte
+	// DryRun 検証のみ行い保存しない
+	DryRun bool
+}
+
+// Validate 入力検証
+func (i *ImportEntriesCSVInput) Validate() error {
+	if i.ScheduleID == "" {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "勤務表IDは必須です")
+	}
+	if len(i.Data) == 0 {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "CSVが空です")
+	}
+	return nil
+}
+
+// ImportEntriesCSVOutput 勤務表エントリCSV取込結果
+type ImportEntriesCSVOutput struct {
+	// DryRun 検証のみ
+	DryRun bool `json:"dry_run"`
+	// Applied 保存済み
+	Applied bool `json:"applied"`
+	// TotalRows データ行数
+	TotalRows int `json:"total_rows"`
+	// CreatedCount 新規作成するエントリ数
+	CreatedCount int `json:"created_count"`
+	// UpdatedCount 更新するエントリ数
+	UpdatedCount int `json:"updated_count"`
+	// UnchangedCount 変更のないエントリ数
+	UnchangedCount int `json:"unchanged_count"`
+	// Errors 行ごとのエラー
+	Errors []ImportRowErrorOutput `json:"errors"`
+}
+
+// ImportRowErrorOutput 取込行エラー出力
+type ImportRowErrorOutput struct {
+	// Line CSVの行番号 ヘッダーが1行目
+	Line int `json:"line"`
+	// EmployeeCode 職員番号
+	EmployeeCode string `json:"employee_code"`
+	// Date 対象日
+	Date string `json:"date"`
+	// Message エラー内容
+	Message string `json:"message"`
+}
+
+// CalendarTokenOutput カレンダー購読トークン出力
+type CalendarTokenOutput struct {
+	// StaffID スタッフID
+	StaffID string `json:"staff_id"`
+	// Issued 発行済み
+	Issued bool `json:"issued"`
+	// Token 購読用トークン 発行直後のみ返す
+	Token string `json:"token,omitempty"`
+	// CreatedAt 発行日時
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+// CalendarFeedOutput 購読カレンダー出力
+type CalendarFeedOutput struct {
+	// FileName ファイル名
+	FileName string
+	// Data iCalendar形式の内容
+	Data []byte
+}
+
+// RequestShiftSwapInput 勤務交換申請入力
+type RequestShiftSwapInput struct {
+	// OrganizationID 組織ID
+	OrganizationID string `json:"-"`
+	// RequesterStaffID 申請者のスタッフID
+	RequesterStaffID string `json:"requester_staff_id"`
+	// RequesterDate 申請者が手放す勤務日（YYYY-MM-DD形式）
+	RequesterDate string `json:"requester_date"`
+	// CounterpartStaffID 相手のスタッフID
+	CounterpartStaffID string `json:"counterpart_staff_id"`
+	// CounterpartDate 相手が手放す勤務日（YYYY-MM-DD形式）同じ日のシフトを交換する場合は申請者と同じ日付
+	CounterpartDate string `json:"counterpart_date"`
+	// Reason 申請理由
+	Reason string `json:"reason"`
+	// UserID 操作ユーザーID
+	UserID string `json:"-"`
+	// UserEmail 操作ユーザーのメールアドレス スタッフのメールアドレスと照合して本人を判定する
+	UserEmail string `json:"-"`
+	// IsManager 操作ユーザーがマネージャー以上 他のスタッフの勤務も申請できる
+	IsManager bool `json:"-"`
+}
+
+// Validate 入力検証
+func (i *RequestShiftSwapInput) Validate() error {
+	if i.RequesterStaffID == "" || i.CounterpartStaffID == "" {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "申請者と相手のスタッフは必須です")
+	}
+	if i.RequesterStaffID == i.CounterpartStaffID {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "同じスタッフ同士では交換できません")
+	}
+	if i.RequesterDate == "" || i.CounterpartDate == "" {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "交換する勤務日は必須です")
+	}
+	if len([]rune(i.Reason)) > 500 {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "申請理由は500文字以内で入力してください")
+	}
+	return nil
+}
+
+// ShiftSwapActionInput 勤務交換の承諾・承認等の操作入力
+type ShiftSwapActionInput struct {
+	// OrganizationID 組織ID
+	OrganizationID string `json:"-"`
+	// SwapID 勤務交換申請ID
+	SwapID string `json:"-"`
+	// UserID 操作ユーザーID
+	UserID string `json:"-"`
+	// UserEmail 操作ユーザーのメールアドレス スタッフのメールアドレスと照合して本人を判定する
+	UserEmail string `json:"-"`
+	// IsManager 操作ユーザーがマネージャー以上 申請者に代わって取り下げできる
+	IsManager bool `json:"-"`
+	// Comment コメント
+	Comment string `json:"comment"`
+}
+
+// ShiftSwapOutput 勤務交換申請出力
+type ShiftSwapOutput struct {
+	// ID 勤務交換申請ID
+	ID string `json:"id"`
+	// Status 状態
+	Status string `json:"status"`
+	// StatusLabel 状態ラベル
+	StatusLabel string `json:"status_label"`
+	// IsPending 相手の承諾待ち
+	IsPending bool `json:"is_pending"`
+	// IsAccepted 管理者の承認待ち
+	IsAccepted bool `json:"is_accepted"`
+	// RequesterStaffID 申請者のスタッフID
+	RequesterStaffID string `json:"requester_staff_id"`
+	// RequesterStaffName 申請者名
+	RequesterStaffName string `json:"requester_staff_name"`
+	// RequesterDate 申請者が手放す勤務日
+	RequesterDate string `json:"requester_date"`
+	// RequesterShiftName 申請時点の申請者のシフト名
+	RequesterShiftName string `json:"requester_shift_name"`
+	// CounterpartStaffID 相手のスタッフID
+	CounterpartStaffID string `json:"counterpart_staff_id"`
+	// CounterpartStaffName 相手の名前
+	CounterpartStaffName string `json:"counterpart_staff_name"`
+	// CounterpartDate 相手が手放す勤務日
+	CounterpartDate string `json:"counterpart_date"`
+	// CounterpartShiftName 申請時点の相手のシフト名
+	CounterpartShiftName string `json:"counterpart_shift_name"`
+	// Reason 申請理由
+	Reason string `json:"reason"`
+	// History 操作履歴 古い順
+	History []ShiftSwapEventOutput `json:"history"`
+	// Changes 承認時に変更されるシフト 未完了の申請のみ
+	Changes []EntryChangeOutput `json:"changes,omitempty"`
+	// Violations 交換で新たに発生する制約違反 未完了の申請のみ
+	Violations []ViolationOutput `json:"violations,omitempty"`
+	// HasErrors 重大度errorの違反があり承認できない
+	HasErrors bool `json:"has_errors"`
+	// Problem 勤務表の変更などで交換できなくなった理由
+	Problem string `json:"problem,omitempty"`
+	// CreatedAt 申請日時
+	CreatedAt string `json:"created_at"`
+	// UpdatedAt 更新日時
+	UpdatedAt string `json:"updated_at"`
+}
+
+// ShiftSwapEventOutput 勤務交換操作履歴出力
+type ShiftSwapEventOutput struct {
+	// Status 操作後の状態
+	Status string `json:"status"`
+	// StatusLabel 状態ラベル
+	StatusLabel string `json:"status_label"`
+	// UserEmail 操作ユーザーのメールアドレス
+	UserEmail string `json:"user_email"`
+	// Comment コメント
+	Comment string `json:"comment,omitempty"`
+	// At 操作日時
+	At string `json:"at"`
+}
+
+// ToShiftSwapOutput ドメインエンティティから出力DTOへ変換 スタッフ名・シフト名は呼び出し側で設定
+func ToShiftSwapOutput(s *domain.ShiftSwap) *ShiftSwapOutput {
+	history := make([]ShiftSwapEventOutput, len(s.History))
+	for i, e := range s.History {
+		history[i] = ShiftSwapEventOutput{
+			Status:      e.Status.String(),
+			StatusLabel: e.Status.Label(),
+			UserEmail:   e.Actor.Email,
+			Comment:     e.Comment,
+			At:          e.At.Format(time.RFC3339),
+		}
+	}
+
+	return &ShiftSwapOutput{
+		ID:                 s.ID.String(),
+		Status:             s.Status.String(),
+		StatusLabel:        s.Status.Label(),
+		IsPending:          s.IsPending(),
+		IsAccepted:         s.IsAccepted(),
+		RequesterStaffID:   s.RequesterStaffID.String(),
+		RequesterDate:      s.RequesterDate.Format("2006-01-02"),
+		CounterpartStaffID: s.CounterpartStaffID.String(),
+		CounterpartDate:    s.CounterpartDate.Format("2006-01-02"),
+		Reason:             s.Reason,
+		History:            history,
+		CreatedAt:          s.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:          s.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// PostOpenShiftInput 募集シフト登録入力
+type PostOpenShiftInput struct {
+	// OrganizationID 組織ID
+	OrganizationID string `json:"-"`
+	// StaffID 欠勤するスタッフのID
+	StaffID string `json:"staff_id"`
+	// TargetDate 勤務日（YYYY-MM-DD形式）
+	TargetDate string `json:"target_date"`
+	// RequiredSkillID 応募に必要なスキルID
+	RequiredSkillID string `json:"required_skill_id"`
+	// RequiredJobTypeID 応募に必要な職種ID
+	RequiredJobTypeID string `json:"required_job_type_id"`
+	// FillMode 決定方法 first_come, manager_pick 省略時は先着順
+	FillMode string `json:"fill_mode"`
+	// Note 備考
+	Note string `json:"note"`
+	// UserEmail 操作ユーザーのメールアドレス
+	UserEmail string `json:"-"`
+}
+
+// Validate 入力検証
+func (i *PostOpenShiftInput) Validate() error {
+	if i.StaffID == "" {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "スタッフは必須です")
+	}
+	if i.TargetDate == "" {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "勤務日は必須です")
+	}
+	if len([]rune(i.Note)) > 500 {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "備考は500文字以内で入力してください")
+	}
+	return nil
+}
+
+// OpenShiftStaffInput 募集シフトへの応募・決定入力
+type OpenShiftStaffInput struct {
+	// OrganizationID 組織ID
+	OrganizationID string `json:"-"`
+	// OpenShiftID 募集シフトID
+	OpenShiftID string `json:"-"`
+	// StaffID 応募する・決定するスタッフID
+	StaffID string `json:"staff_id"`
+	// UserEmail 操作ユーザーのメールアドレス
+	UserEmail string `json:"-"`
+}
+
+// OpenShiftOutput 募集シフト出力
+type OpenShiftOutput struct {
+	// ID 募集シフトID
+	ID string `json:"id"`
+	// ScheduleID 勤務表ID
+	ScheduleID string `json:"schedule_id"`
+	// Status 状態
+	Status string `json:"status"`
+	// StatusLabel 状態ラベル
+	StatusLabel string `json:"status_label"`
+	// IsOpen 募集中
+	IsOpen bool `json:"is_open"`
+	// FillMode 決定方法
+	FillMode string `json:"fill_mode"`
+	// FillModeLabel 決定方法ラベル
+	FillModeLabel string `json:"fill_mode_label"`
+	// TargetDate 勤務日
+	TargetDate string `json:"target_date"`
+	// ShiftTypeID シフト種別ID
+	ShiftTypeID string `json:"shift_type_id"`
+	// ShiftName シフト名
+	ShiftName string `json:"shift_name"`
+	// SourceStaffID 募集元のスタッフID
+	SourceStaffID string `json:"source_staff_id,omitempty"`
+	// SourceStaffName 募集元のスタッフ名
+	SourceStaffName string `json:"source_staff_name,omitempty"`
+	// RequiredSkillID 応募に必要なスキルID
+	RequiredSkillID string `json:"required_skill_id,omitempty"`
+	// RequiredSkillName 応募に必要なスキル名
+	RequiredSkillName string `json:"required_skill_name,omitempty"`
+	// RequiredJobTypeID 応募に必要な職種ID
+	RequiredJobTypeID string `json:"required_job_type_id,omitempty"`
+	// RequiredJobTypeName 応募に必要な職種名
+	RequiredJobTypeName string `json:"required_job_type_name,omitempty"`
+	// Note 備考
+	Note string `json:"note"`
+	// Claims 応募一覧 古い順
+	Claims []OpenShiftClaimOutput `json:"claims"`
+	// EligibleStaffs 応募できるスタッフ 募集中の詳細のみ
+	EligibleStaffs []EligibleStaffOutput `json:"eligible_staffs,omitempty"`
+	// Problem 勤務表の変更などで決定できない理由 募集中の詳細のみ
+	Problem string `json:"problem,omitempty"`
+	// FilledStaffID 勤務するスタッフID
+	FilledStaffID string `json:"filled_staff_id,omitempty"`
+	// FilledStaffName 勤務するスタッフ名
+	FilledStaffName string `json:"filled_staff_name,omitempty"`
+	// FilledBy 決定したユーザーのメールアドレス
+	FilledBy string `json:"filled_by,omitempty"`
+	// FilledAt 決定日時
+	FilledAt string `json:"filled_at,omitempty"`
+	// CreatedBy 募集したユーザーのメールアドレス
+	CreatedBy string `json:"created_by"`
+	// CreatedAt 募集日時
+	CreatedAt string `json:"created_at"`
+}
+
+// OpenShiftClaimOutput 募集シフト応募出力
+type OpenShiftClaimOutput struct {
+	// StaffID スタッフID
+	StaffID string `json:"staff_id"`
+	// StaffName スタッフ名
+	StaffName string `json:"staff_name"`
+	// UserEmail 応募操作をしたユーザーのメールアドレス
+	UserEmail string `json:"user_email"`
+	// ClaimedAt 応募日時
+	ClaimedAt string `json:"claimed_at"`
+	// Ineligible 応募後の勤務表の変更などで決定できない理由 募集中の詳細のみ
+	Ineligible string `json:"ineligible,omitempty"`
+}
+
+// EligibleStaffOutput 応募できるスタッフ出力
+type EligibleStaffOutput struct {
+	// StaffID スタッフID
+	StaffID string `json:"staff_id"`
+	// StaffName スタッフ名
+	StaffName string `json:"staff_name"`
+}
+
+// OpenShiftOptionsOutput 募集シフト登録フォームの選択肢
+type OpenShiftOptionsOutput struct {
+	// Skills スキル一覧
+	Skills []OptionOutput `json:"skills"`
+	// JobTypes 職種一覧
+	JobTypes []OptionOutput `json:"job_types"`
+}
+
+// OptionOutput 選択肢出力
+type OptionOutput struct {
+	// ID ID
+	ID string `json:"id"`
+	// Name 名称
+	Name string `json:"name"`
+}
+
+// ToOpenShiftOutput ドメインエンティティから出力DTOへ変換 名称は呼び出し側で設定
+func ToOpenShiftOutput(o *domain.OpenShift) *OpenShiftOutput {
+	output := &OpenShiftOutput{
+		ID:            o.ID.String(),
+		ScheduleID:    o.ScheduleID.String(),
+		Status:        o.Status.String(),
+		StatusLabel:   o.Status.Label(),
+		IsOpen:        o.IsOpen(),
+		FillMode:      o.FillMode.String(),
+		FillModeLabel: o.FillMode.Label(),
+		TargetDate:    o.TargetDate.Format("2006-01-02"),
+		ShiftTypeID:   o.ShiftTypeID.String(),
+		Note:          o.Note,
+		Claims:        make([]OpenShiftClaimOutput, len(o.Claims)),
+		FilledBy:      o.FilledBy,
+		CreatedBy:     o.CreatedBy,
+		CreatedAt:     o.CreatedAt.Format(time.RFC3339),
+	}
+	if o.SourceStaffID != nil {
+		output.SourceStaffID = o.SourceStaffID.String()
+	}
+	if o.RequiredSkillID != nil {
+		output.RequiredSkillID = o.RequiredSkillID.String()
+	}
+	if o.RequiredJobTypeID != nil {
+		output.RequiredJobTypeID = o.RequiredJobTypeID.String()
+	}
+	if o.FilledStaffID != nil {
+		output.FilledStaffID = o.FilledStaffID.String()
+	}
+	if o.FilledAt != nil {
+		output.FilledAt = o.FilledAt.Format(time.RFC3339)
+	}
+	for i, c := range o.Claims {
+		output.Claims[i] = OpenShiftClaimOutput{
+			StaffID:   c.StaffID.String(),
+			UserEmail: c.UserEmail,
+			ClaimedAt: c.ClaimedAt.Format(time.RFC3339),
+		}
+	}
+	return output
 }
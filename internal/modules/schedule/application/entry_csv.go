@@ -0,0 +1,368 @@
+// Package application 勤務表アプリケーション層
+package application
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"shiftmaster/internal/modules/schedule/domain"
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
+	staffDomain "shiftmaster/internal/modules/staff/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// entryCSVHeader 勤務表エントリCSVの列 給与システム等との連携のため列名と順序を固定する
+var entryCSVHeader = []string{"employee_code", "date", "shift_code", "note"}
+
+// entryCSVDateLayouts 取込時に受け付ける日付形式
+var entryCSVDateLayouts = []string{"2006-01-02", "2006/01/02", "2006/1/2"}
+
+// maxImportRows 1回で取り込めるデータ行数の上限
+const maxImportRows = 20000
+
+// utf8BOM Excelで文字化けしないよう先頭に付与するBOM
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// ExportEntriesCSV 勤務表エントリをCSVで出力 職員番号・日付順
+func (u *ScheduleUseCase) ExportEntriesCSV(ctx context.Context, organizationID, scheduleID string) (*ExportEntriesCSVOutput, error) {
+	schedule, err := u.findOrganizationSchedule(ctx, organizationID, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := u.entryRepo.FindByScheduleID(ctx, schedule.ID)
+	if err != nil {
+		return nil, err
+	}
+	staffs, err := u.entryStaffs(ctx, schedule.OrganizationID, entries)
+	if err != nil {
+		return nil, err
+	}
+	shiftTypes, err := u.shiftTypeRepo.FindByOrganizationID(ctx, schedule.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	shiftCodes := make(map[sharedDomain.ID]string, len(shiftTypes))
+	for _, st := range shiftTypes {
+		shiftCodes[st.ID] = st.Code
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := staffs[entries[i].StaffID], staffs[entries[j].StaffID]
+		if a.EmployeeCode != b.EmployeeCode {
+			return a.EmployeeCode < b.EmployeeCode
+		}
+		if a.FullName() != b.FullName() {
+			return a.FullName() < b.FullName()
+		}
+		if entries[i].StaffID != entries[j].StaffID {
+			return entries[i].StaffID.String() < entries[j].StaffID.String()
+		}
+		return entries[i].TargetDate.Before(entries[j].TargetDate)
+	})
+
+	var buf bytes.Buffer
+	buf.Write(utf8BOM)
+	w := csv.NewWriter(&buf)
+	if err := w.Write(entryCSVHeader); err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		shiftCode := ""
+		if e.ShiftTypeID != nil {
+			shiftCode = shiftCodes[*e.ShiftTypeID]
+		}
+		record := []string{staffs[e.StaffID].EmployeeCode, e.TargetDate.Format("2006-01-02"), shiftCode, e.Note}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	return &ExportEntriesCSVOutput{
+		FileName: fmt.Sprintf("schedule-%04d-%02d.csv", schedule.TargetYear, schedule.TargetMonth),
+		Data:     buf.Bytes(),
+	}, nil
+}
+
+// ImportEntriesCSV CSVから勤務表エントリを取り込む
+// 職員番号とシフトコードは組織内で解決し、1行でもエラーがあれば何も保存しない
+func (u *ScheduleUseCase) ImportEntriesCSV(ctx context.Context, input *ImportEntriesCSVInput) (*ImportEntriesCSVOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	schedule, err := u.findOrganizationSchedule(ctx, input.OrganizationID, input.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.Status == domain.StatusPublished {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "公開済みの勤務表には取り込めません")
+	}
+
+	records, err := readEntryCSV(input.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	staffs, err := u.staffRepo.FindActiveByOrganizationID(ctx, schedule.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	staffByCode := make(map[string]staffDomain.Staff, len(staffs))
+	for _, s := range staffs {
+		if s.EmployeeCode != "" {
+			staffByCode[s.EmployeeCode] = s
+		}
+	}
+	shiftTypes, err := u.shiftTypeRepo.FindByOrganizationID(ctx, schedule.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	shiftTypeByCode := make(map[string]shiftDomain.ShiftType, len(shiftTypes))
+	for _, st := range shiftTypes {
+		shiftTypeByCode[st.Code] = st
+	}
+
+	current, err := u.entryRepo.FindByScheduleID(ctx, schedule.ID)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]domain.ScheduleEntry, len(current))
+	for _, e := range current {
+		existing[entryKey(e)] = e
+	}
+
+	output := &ImportEntriesCSVOutput{DryRun: input.DryRun, TotalRows: len(records), Errors: []ImportRowErrorOutput{}}
+	now := time.Now()
+	seen := make(map[string]int, len(records))
+	entries := make([]domain.ScheduleEntry, 0, len(records))
+	for _, rec := range records {
+		rowError := func(format string, args ...any) {
+			output.Errors = append(output.Errors, ImportRowErrorOutput{
+				Line:         rec.line,
+				EmployeeCode: rec.employeeCode,
+				Date:         rec.date,
+				Message:      fmt.Sprintf(format, args...),
+			})
+		}
+
+		if rec.employeeCode == "" {
+			rowError("職員番号は必須です")
+			continue
+		}
+		staff, ok := staffByCode[rec.employeeCode]
+		if !ok {
+			rowError("職員番号 %s のスタッフが見つかりません", rec.employeeCode)
+			continue
+		}
+		targetDate, ok := parseEntryCSVDate(rec.date)
+		if !ok {
+			rowError("日付の形式が不正です")
+			continue
+		}
+		if targetDate.Year() != schedule.TargetYear || int(targetDate.Month()) != schedule.TargetMonth {
+			rowError("対象日が勤務表の対象月内ではありません")
+			continue
+		}
+		var shiftTypeID *sharedDomain.ID
+		if rec.shiftCode != "" {
+			st, ok := shiftTypeByCode[rec.shiftCode]
+			if !ok {
+				rowError("シフトコード %s が見つかりません", rec.shiftCode)
+				continue
+			}
+			shiftTypeID = &st.ID
+		}
+
+		entry := domain.ScheduleEntry{ScheduleID: schedule.ID, StaffID: staff.ID, TargetDate: targetDate, ShiftTypeID: shiftTypeID, Note: rec.note}
+		key := entryKey(entry)
+		if line, dup := seen[key]; dup {
+			rowError("同じ職員・日付の行が%d行目にもあります", line)
+			continue
+		}
+		seen[key] = rec.line
+
+		prev, ok := existing[key]
+		if ok {
+			if !rec.hasNote {
+				entry.Note = prev.Note
+			}
+			if sameShift(prev.ShiftTypeID, entry.ShiftTypeID) && prev.Note == entry.Note {
+				output.UnchangedCount++
+				continue
+			}
+			if prev.IsConfirmed {
+				rowError("確定済みのエントリは変更できません")
+				continue
+			}
+			entry.ID = prev.ID
+			entry.CreatedAt = prev.CreatedAt
+			output.UpdatedCount++
+		} else {
+			entry.ID = sharedDomain.NewID()
+			entry.CreatedAt = now
+			output.CreatedCount++
+		}
+		entry.UpdatedAt = now
+		entries = append(entries, entry)
+	}
+
+	if input.DryRun || len(output.Errors) > 0 {
+		return output, nil
+	}
+
+	if err := u.entryRepo.SaveBatch(ctx, entries); err != nil {
+		u.logger.Error("CSV取込失敗", "error", err)
+		return nil, err
+	}
+	output.Applied = true
+
+	u.logger.Info("CSV取込完了", "schedule_id", schedule.ID, "created", output.CreatedCount, "updated", output.UpdatedCount)
+	return output, nil
+}
+
+// findOrganizationSchedule 組織の勤務表を取得 他組織の勤務表は見つからない扱い
+func (u *ScheduleUseCase) findOrganizationSchedule(ctx context.Context, organizationID, scheduleID string) (*domain.Schedule, error) {
+	orgID, err := sharedDomain.ParseID(organizationID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDが不正です")
+	}
+	sID, err := sharedDomain.ParseID(scheduleID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "勤務表IDが不正です")
+	}
+
+	schedule, err := u.scheduleRepo.FindByID(ctx, sID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil || schedule.OrganizationID != orgID {
+		return nil, sharedDomain.ErrNotFound
+	}
+	return schedule, nil
+}
+
+// entryStaffs エントリのスタッフを取得 無効化されたスタッフも個別に補う
+func (u *ScheduleUseCase) entryStaffs(ctx context.Context, organizationID sharedDomain.ID, entries []domain.ScheduleEntry) (map[sharedDomain.ID]staffDomain.Staff, error) {
+	staffs, err := u.staffRepo.FindActiveByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[sharedDomain.ID]staffDomain.Staff, len(staffs))
+	for _, s := range staffs {
+		result[s.ID] = s
+	}
+	for _, e := range entries {
+		if _, ok := result[e.StaffID]; ok {
+			continue
+		}
+		staff, err := u.staffRepo.FindByID(ctx, e.StaffID)
+		if err != nil {
+			return nil, err
+		}
+		if staff != nil {
+			result[e.StaffID] = *staff
+		} else {
+			result[e.StaffID] = staffDomain.Staff{ID: e.StaffID}
+		}
+	}
+	return result, nil
+}
+
+// entryCSVRecord CSVのデータ行
+type entryCSVRecord struct {
+	line         int
+	employeeCode string
+	date         string
+	shiftCode    string
+	note         string
+	hasNote      bool
+}
+
+// readEntryCSV CSVを読み込む 列はヘッダー名で判別し、note列がなければ備考を変更しない
+func readEntryCSV(data []byte) ([]entryCSVRecord, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, utf8BOM)))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "CSVが空です")
+		}
+		return nil, csvFormatError(err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range entryCSVHeader[:3] {
+		if _, ok := columns[name]; !ok {
+			return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "CSVのヘッダーに"+name+"列がありません")
+		}
+	}
+	noteCol, hasNote := columns["note"]
+	field := func(record []string, col int) string {
+		if col < len(record) {
+			return strings.TrimSpace(record[col])
+		}
+		return ""
+	}
+
+	var records []entryCSVRecord
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, csvFormatError(err)
+		}
+		if len(records) >= maxImportRows {
+			return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, fmt.Sprintf("取り込める行数は%d行までです", maxImportRows))
+		}
+		line, _ := r.FieldPos(0)
+		rec := entryCSVRecord{
+			line:         line,
+			employeeCode: field(record, columns["employee_code"]),
+			date:         field(record, columns["date"]),
+			shiftCode:    field(record, columns["shift_code"]),
+			hasNote:      hasNote,
+		}
+		if hasNote && noteCol < len(record) {
+			rec.note = record[noteCol]
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+// csvFormatError CSV解析エラーを行番号付きの検証エラーに変換
+func csvFormatError(err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, fmt.Sprintf("CSVの形式が不正です（%d行目）", parseErr.Line))
+	}
+	return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "CSVの形式が不正です")
+}
+
+// parseEntryCSVDate 日付の解析 YYYY-MM-DDとYYYY/MM/DDを受け付ける
+func parseEntryCSVDate(s string) (time.Time, bool) {
+	for _, layout := range entryCSVDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
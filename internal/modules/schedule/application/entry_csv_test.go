@@ -0,0 +1,182 @@
+// Package application 勤務表エントリCSV連携テスト
+package application
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"shiftmaster/internal/modules/schedule/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// newCSVFixture 職員番号付きのスタッフを持つテスト用ユースケース
+func newCSVFixture() *scheduleFixture {
+	f := newScheduleFixture(2)
+	f.staffs[0].EmployeeCode = "N002"
+	f.staffs[1].EmployeeCode = "N001"
+	return f
+}
+
+func TestScheduleUseCase_ExportEntriesCSV(t *testing.T) {
+	f := newCSVFixture()
+	f.addEntry(f.staffs[0].ID, time.Date(2025, 2, 2, 0, 0, 0, 0, time.UTC), f.off.ID, false)
+	f.addEntry(f.staffs[1].ID, time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC), f.day.ID, false).Note = "研修"
+	f.addEntry(f.staffs[0].ID, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), f.day.ID, false)
+	orgID := f.schedule.OrganizationID.String()
+
+	output, err := f.useCase.ExportEntriesCSV(context.Background(), orgID, f.schedule.ID.String())
+	if err != nil {
+		t.Fatalf("ExportEntriesCSV() error = %v", err)
+	}
+	if output.FileName != "schedule-2025-02.csv" {
+		t.Errorf("FileName = %s", output.FileName)
+	}
+	if !bytes.HasPrefix(output.Data, utf8BOM) {
+		t.Error("CSV should start with UTF-8 BOM")
+	}
+
+	want := "employee_code,date,shift_code,note\n" +
+		"N001,2025-02-03,D,研修\n" +
+		"N002,2025-02-01,D,\n" +
+		"N002,2025-02-02,O,\n"
+	if got := string(bytes.TrimPrefix(output.Data, utf8BOM)); got != want {
+		t.Errorf("CSV = %q, want %q", got, want)
+	}
+
+	if _, err := f.useCase.ExportEntriesCSV(context.Background(), sharedDomain.NewID().String(), f.schedule.ID.String()); !errors.Is(err, sharedDomain.ErrNotFound) {
+		t.Errorf("他組織の勤務表 error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestScheduleUseCase_ImportEntriesCSV(t *testing.T) {
+	f := newCSVFixture()
+	existing := f.addEntry(f.staffs[0].ID, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), f.off.ID, false)
+	unchanged := f.addEntry(f.staffs[0].ID, time.Date(2025, 2, 2, 0, 0, 0, 0, time.UTC), f.day.ID, false)
+	orgID := f.schedule.OrganizationID.String()
+
+	csv := "\xEF\xBB\xBFemployee_code,date,shift_code,note\n" +
+		"N002,2025-02-01,D,変更\n" +
+		"N002,2025-02-02,D,\n" +
+		"N001,2025/02/03,O,\n"
+
+	// 検証のみでは保存しない
+	output, err := f.useCase.ImportEntriesCSV(context.Background(), &ImportEntriesCSVInput{
+		OrganizationID: orgID, ScheduleID: f.schedule.ID.String(), Data: []byte(csv), DryRun: true,
+	})
+	if err != nil {
+		t.Fatalf("ImportEntriesCSV(dry run) error = %v", err)
+	}
+	if output.Applied || len(output.Errors) != 0 || output.TotalRows != 3 {
+		t.Fatalf("dry run output = %+v", output)
+	}
+	if output.CreatedCount != 1 || output.UpdatedCount != 1 || output.UnchangedCount != 1 {
+		t.Errorf("counts = %d/%d/%d, want 1/1/1", output.CreatedCount, output.UpdatedCount, output.UnchangedCount)
+	}
+	if len(f.entries.entries) != 2 || *f.entries.entries[existing.ID].ShiftTypeID != f.off.ID {
+		t.Fatal("dry run should not write entries")
+	}
+
+	output, err = f.useCase.ImportEntriesCSV(context.Background(), &ImportEntriesCSVInput{
+		OrganizationID: orgID, ScheduleID: f.schedule.ID.String(), Data: []byte(csv),
+	})
+	if err != nil {
+		t.Fatalf("ImportEntriesCSV() error = %v", err)
+	}
+	if !output.Applied {
+		t.Fatalf("output = %+v, want applied", output)
+	}
+	if len(f.entries.entries) != 3 {
+		t.Fatalf("entries = %d, want 3", len(f.entries.entries))
+	}
+	updated := f.entries.entries[existing.ID]
+	if *updated.ShiftTypeID != f.day.ID || updated.Note != "変更" {
+		t.Errorf("既存エントリが同じIDで更新されていません: %+v", updated)
+	}
+	if f.entries.entries[unchanged.ID].UpdatedAt != unchanged.UpdatedAt {
+		t.Error("変更のないエントリは保存しない")
+	}
+}
+
+func TestScheduleUseCase_ImportEntriesCSV_RowErrors(t *testing.T) {
+	f := newCSVFixture()
+	f.addEntry(f.staffs[1].ID, time.Date(2025, 2, 5, 0, 0, 0, 0, time.UTC), f.day.ID, true)
+
+	csv := "date,employee_code,shift_code\n" +
+		"2025-02-01,N002,D\n" +
+		"2025-02-01,N999,D\n" +
+		"2025-03-01,N002,D\n" +
+		"2025-02-xx,N002,D\n" +
+		"2025-02-02,N002,ZZ\n" +
+		"2025-02-01,N002,O\n" +
+		"2025-02-05,N001,O\n" +
+		"2025-02-06,,D\n"
+
+	output, err := f.useCase.ImportEntriesCSV(context.Background(), &ImportEntriesCSVInput{
+		OrganizationID: f.schedule.OrganizationID.String(), ScheduleID: f.schedule.ID.String(), Data: []byte(csv),
+	})
+	if err != nil {
+		t.Fatalf("ImportEntriesCSV() error = %v", err)
+	}
+	if output.Applied || len(f.entries.entries) != 1 {
+		t.Fatal("エラーがある場合は何も保存しない")
+	}
+
+	wantLines := []int{3, 4, 5, 6, 7, 8, 9}
+	if len(output.Errors) != len(wantLines) {
+		t.Fatalf("Errors = %+v", output.Errors)
+	}
+	for i, line := range wantLines {
+		if output.Errors[i].Line != line {
+			t.Errorf("Errors[%d].Line = %d, want %d (%s)", i, output.Errors[i].Line, line, output.Errors[i].Message)
+		}
+	}
+	if !strings.Contains(output.Errors[4].Message, "2行目") {
+		t.Errorf("重複行のエラーに元の行番号がありません: %s", output.Errors[4].Message)
+	}
+}
+
+func TestScheduleUseCase_ImportEntriesCSV_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		csv     string
+		publish bool
+		orgID   string
+		wantErr error
+	}{
+		{name: "ヘッダー不足", csv: "employee_code,date\nN001,2025-02-01\n"},
+		{name: "形式不正", csv: "employee_code,date,shift_code\nN001,\"2025-02-01,D\n"},
+		{name: "公開済み", csv: "employee_code,date,shift_code\n", publish: true},
+		{name: "他組織", csv: "employee_code,date,shift_code\n", orgID: sharedDomain.NewID().String(), wantErr: sharedDomain.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newCSVFixture()
+			if tt.publish {
+				f.schedule.Status = domain.StatusPublished
+			}
+			orgID := tt.orgID
+			if orgID == "" {
+				orgID = f.schedule.OrganizationID.String()
+			}
+
+			_, err := f.useCase.ImportEntriesCSV(context.Background(), &ImportEntriesCSVInput{
+				OrganizationID: orgID, ScheduleID: f.schedule.ID.String(), Data: []byte(tt.csv),
+			})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			var domainErr *sharedDomain.DomainError
+			if !errors.As(err, &domainErr) || domainErr.Code != sharedDomain.ErrCodeValidation {
+				t.Errorf("error = %v, want validation error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+// Package application 勤務表アプリケーション層
+package application
+
+import (
+	"bytes"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// icalMaxLineOctets RFC 5545の1行の最大オクテット数 改行を除く
+const icalMaxLineOctets = 75
+
+// 日時の書式 TZID付きの現地時刻・UTC・終日
+const (
+	icalLocalLayout = "20060102T150405"
+	icalUTCLayout   = "20060102T150405Z"
+	icalDateLayout  = "20060102"
+)
+
+// icalTimeZone 日本標準時の定義 夏時間はない
+var icalTimeZone = []string{
+	"BEGIN:VTIMEZONE",
+	"TZID:Asia/Tokyo",
+	"BEGIN:STANDARD",
+	"DTSTART:19700101T000000",
+	"TZOFFSETFROM:+0900",
+	"TZOFFSETTO:+0900",
+	"TZNAME:JST",
+	"END:STANDARD",
+	"END:VTIMEZONE",
+}
+
+// icalTextEscaper TEXT値のエスケープ
+var icalTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// calendarEvent 購読カレンダーの予定
+type calendarEvent struct {
+	uid         string
+	start       time.Time
+	end         time.Time
+	allDay      bool
+	summary     string
+	description string
+	category    string
+	modified    time.Time
+}
+
+// icalWriter iCalendar形式の書き込み 長い行は折り返しCRLFで区切る
+type icalWriter struct {
+	buf bytes.Buffer
+}
+
+// line プロパティ行 値はエスケープ済みであること
+func (w *icalWriter) line(name, value string) {
+	w.fold(name + ":" + value)
+}
+
+// text TEXT型のプロパティ行
+func (w *icalWriter) text(name, value string) {
+	w.line(name, icalTextEscaper.Replace(value))
+}
+
+// fold 75オクテットごとに折り返す 継続行は空白で始める UTF-8の文字の途中では分割しない
+func (w *icalWriter) fold(s string) {
+	limit := icalMaxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.buf.WriteString(s[:cut])
+		w.buf.WriteString("\r\n ")
+		s = s[cut:]
+		// 継続行は先頭の空白の分だけ短くする
+		limit = icalMaxLineOctets - 1
+	}
+	w.buf.WriteString(s)
+	w.buf.WriteString("\r\n")
+}
+
+// event VEVENT出力 時刻はAsia/Tokyoの現地時刻として書く DTSTAMPは出力日時 LAST-MODIFIEDはエントリの更新日時
+func (w *icalWriter) event(e calendarEvent, stamp time.Time) {
+	w.line("BEGIN", "VEVENT")
+	w.line("UID", e.uid)
+	w.line("DTSTAMP", stamp.UTC().Format(icalUTCLayout))
+	if !e.modified.IsZero() {
+		w.line("LAST-MODIFIED", e.modified.UTC().Format(icalUTCLayout))
+	}
+	if e.allDay {
+		w.line("DTSTART;VALUE=DATE", e.start.Format(icalDateLayout))
+		w.line("DTEND;VALUE=DATE", e.end.Format(icalDateLayout))
+		w.line("TRANSP", "TRANSPARENT")
+	} else {
+		w.line("DTSTART;TZID=Asia/Tokyo", e.start.Format(icalLocalLayout))
+		w.line("DTEND;TZID=Asia/Tokyo", e.end.Format(icalLocalLayout))
+		w.line("TRANSP", "OPAQUE")
+	}
+	w.text("SUMMARY", e.summary)
+	if e.description != "" {
+		w.text("DESCRIPTION", e.description)
+	}
+	if e.category != "" {
+		w.text("CATEGORIES", e.category)
+	}
+	w.line("END", "VEVENT")
+}
+
+// buildICalendar VCALENDAR出力 購読アプリが1時間ごとに更新するよう指定する
+func buildICalendar(calendarName string, events []calendarEvent, now time.Time) []byte {
+	w := &icalWriter{}
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", "-//ShiftMaster//Shift Calendar//JA")
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", "PUBLISH")
+	w.text("X-WR-CALNAME", calendarName)
+	w.line("X-WR-TIMEZONE", "Asia/Tokyo")
+	w.line("REFRESH-INTERVAL;VALUE=DURATION", "PT1H")
+	w.line("X-PUBLISHED-TTL", "PT1H")
+	for _, l := range icalTimeZone {
+		w.fold(l)
+	}
+	for _, e := range events {
+		w.event(e, now)
+	}
+	w.line("END", "VCALENDAR")
+	return w.buf.Bytes()
+}
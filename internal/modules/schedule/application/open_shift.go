@@ -0,0 +1,569 @@
+// Package application 勤務表アプリケーション層
+package application
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"time"
+
+	"shiftmaster/internal/modules/schedule/domain"
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
+	staffDomain "shiftmaster/internal/modules/staff/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// OpenShiftUseCase 募集シフトユースケース
+// 公開済み勤務表の空いた枠を募集し、資格とシフトルールを満たすスタッフの応募で埋めて勤務表に反映する
+type OpenShiftUseCase struct {
+	openShiftRepo domain.OpenShiftRepository
+	scheduleRepo  domain.ScheduleRepository
+	entryRepo     domain.ScheduleEntryRepository
+	shiftTypeRepo shiftDomain.ShiftTypeRepository
+	staffRepo     staffDomain.StaffRepository
+	ruleEngine    *RuleEngine
+	logger        *slog.Logger
+}
+
+// NewOpenShiftUseCase 募集シフトユースケース生成
+func NewOpenShiftUseCase(
+	openShiftRepo domain.OpenShiftRepository,
+	scheduleRepo domain.ScheduleRepository,
+	entryRepo domain.ScheduleEntryRepository,
+	shiftTypeRepo shiftDomain.ShiftTypeRepository,
+	staffRepo staffDomain.StaffRepository,
+	ruleRepo shiftDomain.ShiftRuleRepository,
+	qualificationFinder domain.StaffQualificationFinder,
+	requestFinder domain.ShiftRequestFinder,
+	logger *slog.Logger,
+) *OpenShiftUseCase {
+	return &OpenShiftUseCase{
+		openShiftRepo: openShiftRepo,
+		scheduleRepo:  scheduleRepo,
+		entryRepo:     entryRepo,
+		shiftTypeRepo: shiftTypeRepo,
+		staffRepo:     staffRepo,
+		ruleEngine:    NewRuleEngine(ruleRepo, qualificationFinder, requestFinder, logger),
+		logger:        logger,
+	}
+}
+
+// openShiftCheck 応募資格の判定に使う勤務表とルール
+type openShiftCheck struct {
+	openShift      *domain.OpenShift
+	schedule       *domain.Schedule
+	staffs         []staffDomain.Staff
+	shiftTypes     map[string]*shiftDomain.ShiftType
+	qualifications *domain.StaffQualifications
+	before         []ViolationOutput
+	evaluate       func(entries []domain.ScheduleEntry) []ViolationOutput
+}
+
+// Options 募集シフト登録フォームのスキル・職種の選択肢
+func (u *OpenShiftUseCase) Options(ctx context.Context, organizationID string) (*OpenShiftOptionsOutput, error) {
+	orgID, err := sharedDomain.ParseID(organizationID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDが不正です")
+	}
+
+	qualifications, err := u.ruleEngine.LoadQualifications(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	output := &OpenShiftOptionsOutput{Skills: make([]OptionOutput, 0), JobTypes: make([]OptionOutput, 0)}
+	if qualifications != nil {
+		output.Skills = toOptions(qualifications.SkillNames)
+		output.JobTypes = toOptions(qualifications.JobTypeNames)
+	}
+	return output, nil
+}
+
+// List 組織の募集シフト一覧 statusが空の場合は全件
+func (u *OpenShiftUseCase) List(ctx context.Context, organizationID, status string) ([]OpenShiftOutput, error) {
+	orgID, err := sharedDomain.ParseID(organizationID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDが不正です")
+	}
+
+	openShifts, err := u.openShiftRepo.FindByOrganizationID(ctx, orgID, domain.OpenShiftStatus(status))
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := u.newOutputNames(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	outputs := make([]OpenShiftOutput, len(openShifts))
+	for i := range openShifts {
+		output, err := names.output(ctx, &openShifts[i])
+		if err != nil {
+			return nil, err
+		}
+		outputs[i] = *output
+	}
+	return outputs, nil
+}
+
+// Get 募集シフト取得 募集中の場合は応募できるスタッフと決定できない応募者の理由を含む
+func (u *OpenShiftUseCase) Get(ctx context.Context, organizationID, id string) (*OpenShiftOutput, error) {
+	openShift, err := u.findOpenShift(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := u.newOutputNames(ctx, openShift.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	output, err := names.output(ctx, openShift)
+	if err != nil {
+		return nil, err
+	}
+	if !openShift.IsOpen() {
+		return output, nil
+	}
+
+	check, err := u.newCheck(ctx, openShift)
+	var domainErr *sharedDomain.DomainError
+	if errors.As(err, &domainErr) {
+		// 勤務日を過ぎた募集なども表示する
+		output.Problem = domainErr.Message
+		return output, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	output.EligibleStaffs = make([]EligibleStaffOutput, 0)
+	for i := range check.staffs {
+		staff := &check.staffs[i]
+		if openShift.HasClaimed(staff.ID) || check.ineligibleReason(staff.ID) != "" {
+			continue
+		}
+		output.EligibleStaffs = append(output.EligibleStaffs, EligibleStaffOutput{
+			StaffID:   staff.ID.String(),
+			StaffName: staff.FullName(),
+		})
+	}
+	for i, c := range openShift.Claims {
+		output.Claims[i].Ineligible = check.ineligibleReason(c.StaffID)
+	}
+	return output, nil
+}
+
+// Post 募集シフト登録 公開済み勤務表の今日以降のシフトが割り当てられた勤務のみ対象
+func (u *OpenShiftUseCase) Post(ctx context.Context, input *PostOpenShiftInput) (*OpenShiftOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	orgID, err := sharedDomain.ParseID(input.OrganizationID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDが不正です")
+	}
+	staffID, err := sharedDomain.ParseID(input.StaffID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "スタッフIDが不正です")
+	}
+	targetDate, err := time.Parse("2006-01-02", input.TargetDate)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "勤務日が不正です")
+	}
+	if isPastDate(targetDate) {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "勤務日を過ぎた勤務は募集できません")
+	}
+	requiredSkillID, err := parseOptionalID(input.RequiredSkillID, "スキルIDが不正です")
+	if err != nil {
+		return nil, err
+	}
+	requiredJobTypeID, err := parseOptionalID(input.RequiredJobTypeID, "職種IDが不正です")
+	if err != nil {
+		return nil, err
+	}
+	fillMode := domain.FillMode(input.FillMode)
+	if fillMode == "" {
+		fillMode = domain.FillModeFirstCome
+	}
+
+	schedule, err := u.scheduleRepo.FindByTargetMonth(ctx, orgID, targetDate.Year(), int(targetDate.Month()))
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil || schedule.Status != domain.StatusPublished {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, targetDate.Format("2006年1月")+"の勤務表は公開されていません")
+	}
+	entries, err := u.entryRepo.FindByScheduleAndStaff(ctx, schedule.ID, staffID)
+	if err != nil {
+		return nil, err
+	}
+	var entry *domain.ScheduleEntry
+	for i := range entries {
+		if entries[i].TargetDate.Format("2006-01-02") == input.TargetDate {
+			entry = &entries[i]
+		}
+	}
+	if entry == nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, targetDate.Format("1月2日")+"にこのスタッフの勤務がありません")
+	}
+
+	// 同じ勤務の募集中の募集は1件まで
+	open, err := u.openShiftRepo.FindByOrganizationID(ctx, orgID, domain.OpenShiftStatusOpen)
+	if err != nil {
+		return nil, err
+	}
+	for i := range open {
+		if open[i].SourceEntryID != nil && *open[i].SourceEntryID == entry.ID {
+			return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeConflict, "この勤務は既に募集中です")
+		}
+	}
+
+	openShift, err := domain.NewOpenShift(orgID, entry, requiredSkillID, requiredJobTypeID, fillMode, input.Note, input.UserEmail, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := u.openShiftRepo.Save(ctx, openShift); err != nil {
+		u.logger.Error("募集シフト登録失敗", "error", err)
+		return nil, err
+	}
+
+	u.logger.Info("募集シフト登録", "open_shift_id", openShift.ID, "source_staff_id", staffID, "target_date", input.TargetDate)
+	return u.buildOutput(ctx, openShift)
+}
+
+// Claim スタッフが応募 先着順の場合はそのまま決定して勤務表に反映する
+func (u *OpenShiftUseCase) Claim(ctx context.Context, input *OpenShiftStaffInput) (*OpenShiftOutput, error) {
+	openShift, staffID, err := u.findForStaffAction(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if err := openShift.Claim(staffID, input.UserEmail, now); err != nil {
+		return nil, err
+	}
+
+	check, err := u.newCheck(ctx, openShift)
+	if err != nil {
+		return nil, err
+	}
+	if reason := check.ineligibleReason(staffID); reason != "" {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "この募集には応募できません: "+reason)
+	}
+
+	if openShift.FillMode == domain.FillModeFirstCome {
+		return u.fill(ctx, check, staffID, input.UserEmail, now)
+	}
+
+	if err := u.openShiftRepo.Save(ctx, openShift); err != nil {
+		u.logger.Error("募集シフト応募失敗", "error", err)
+		return nil, err
+	}
+
+	u.logger.Info("募集シフト応募", "open_shift_id", openShift.ID, "staff_id", staffID)
+	return u.buildOutput(ctx, openShift)
+}
+
+// Assign 管理者が応募者から勤務するスタッフを決定し勤務表に反映する
+func (u *OpenShiftUseCase) Assign(ctx context.Context, input *OpenShiftStaffInput) (*OpenShiftOutput, error) {
+	openShift, staffID, err := u.findForStaffAction(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	check, err := u.newCheck(ctx, openShift)
+	if err != nil {
+		return nil, err
+	}
+	if reason := check.ineligibleReason(staffID); reason != "" {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "このスタッフには決定できません: "+reason)
+	}
+	return u.fill(ctx, check, staffID, input.UserEmail, time.Now())
+}
+
+// Cancel 管理者が募集を取り消し 勤務表は変更しない
+func (u *OpenShiftUseCase) Cancel(ctx context.Context, organizationID, id string) (*OpenShiftOutput, error) {
+	openShift, err := u.findOpenShift(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := openShift.Cancel(time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := u.openShiftRepo.Save(ctx, openShift); err != nil {
+		u.logger.Error("募集シフト取り消し失敗", "error", err)
+		return nil, err
+	}
+
+	u.logger.Info("募集シフト取り消し", "open_shift_id", openShift.ID)
+	return u.buildOutput(ctx, openShift)
+}
+
+// fill 勤務するスタッフを決定し、募集の決定と勤務表の更新を1トランザクションで行う
+func (u *OpenShiftUseCase) fill(ctx context.Context, check *openShiftCheck, staffID sharedDomain.ID, userEmail string, now time.Time) (*OpenShiftOutput, error) {
+	openShift := check.openShift
+	if err := openShift.Fill(staffID, userEmail, now); err != nil {
+		return nil, err
+	}
+	changed, err := openShift.Assignment(check.schedule.Entries, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.openShiftRepo.Fill(ctx, openShift, changed); err != nil {
+		u.logger.Error("募集シフト決定失敗", "error", err)
+		return nil, err
+	}
+
+	u.logger.Info("募集シフト決定", "open_shift_id", openShift.ID, "staff_id", staffID, "count", len(changed))
+	return u.buildOutput(ctx, openShift)
+}
+
+// newCheck 応募資格の判定を準備 勤務表が公開済みで勤務日が今日以降の募集のみ
+func (u *OpenShiftUseCase) newCheck(ctx context.Context, openShift *domain.OpenShift) (*openShiftCheck, error) {
+	if isPastDate(openShift.TargetDate) {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "勤務日を過ぎた勤務は募集できません")
+	}
+
+	schedule, err := u.scheduleRepo.FindByIDWithEntries(ctx, openShift.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil || schedule.OrganizationID != openShift.OrganizationID {
+		return nil, sharedDomain.ErrNotFound
+	}
+	if schedule.Status != domain.StatusPublished {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "公開済みの勤務表のみ募集できます")
+	}
+
+	staffs, err := u.staffRepo.FindActiveByOrganizationID(ctx, openShift.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	shiftTypeMap, err := u.buildShiftTypeMap(ctx, openShift.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := u.ruleEngine.LoadRules(ctx, openShift.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	qualifications, err := u.ruleEngine.LoadQualifications(ctx, openShift.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	requests, err := u.ruleEngine.LoadRequests(ctx, schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	check := &openShiftCheck{
+		openShift:      openShift,
+		schedule:       schedule,
+		staffs:         staffs,
+		shiftTypes:     shiftTypeMap,
+		qualifications: qualifications,
+	}
+	check.evaluate = func(entries []domain.ScheduleEntry) []ViolationOutput {
+		return u.ruleEngine.Evaluate(rules, &domain.ConstraintInput{
+			Schedule:       schedule,
+			Entries:        entries,
+			ShiftTypes:     shiftTypeMap,
+			Qualifications: qualifications,
+			Requests:       requests,
+		})
+	}
+	check.before = check.evaluate(schedule.Entries)
+	return check, nil
+}
+
+// ineligibleReason スタッフが募集シフトを勤務できない理由 勤務できる場合は空文字
+// 有効なスタッフであること、必要な資格、同じ日の勤務の有無、割り当て後のシフトルール違反の順に判定する
+func (c *openShiftCheck) ineligibleReason(staffID sharedDomain.ID) string {
+	o := c.openShift
+	var staff *staffDomain.Staff
+	for i := range c.staffs {
+		if c.staffs[i].ID == staffID {
+			staff = &c.staffs[i]
+		}
+	}
+	if staff == nil {
+		return "有効なスタッフではありません"
+	}
+	if o.IsSourceStaff(staffID) {
+		return "募集元のスタッフです"
+	}
+	if o.RequiredSkillID != nil && !c.qualifications.HasSkill(staffID.String(), o.RequiredSkillID.String(), 0) {
+		return "スキル「" + c.qualifications.SkillName(o.RequiredSkillID.String()) + "」がありません"
+	}
+	if o.RequiredJobTypeID != nil && !c.qualifications.HasJobType(staffID.String(), o.RequiredJobTypeID.String(), o.TargetDate) {
+		return "職種「" + c.qualifications.JobTypeName(o.RequiredJobTypeID.String()) + "」に所属していません"
+	}
+
+	for _, e := range c.schedule.Entries {
+		if e.StaffID != staffID || e.ShiftTypeID == nil || e.TargetDate.Format("2006-01-02") != o.TargetDate.Format("2006-01-02") {
+			continue
+		}
+		if st, ok := c.shiftTypes[e.ShiftTypeID.String()]; ok && !st.IsHoliday {
+			return "同じ日に「" + st.Name + "」の勤務があります"
+		}
+	}
+
+	// 割り当てた場合にこのスタッフへ新たに発生する違反 情報レベルは対象外
+	candidate := *o
+	candidate.FilledStaffID = &staffID
+	changed, err := candidate.Assignment(c.schedule.Entries, time.Now())
+	if err != nil {
+		return err.Error()
+	}
+	after := c.evaluate(mergeEntries(c.schedule.Entries, changed))
+	for _, v := range diffViolations(after, c.before) {
+		if v.StaffID == staffID.String() && v.Severity != domain.SeverityInfo {
+			return v.Message
+		}
+	}
+	return ""
+}
+
+// findOpenShift 組織の募集シフトを取得 他組織の募集は見つからない扱い
+func (u *OpenShiftUseCase) findOpenShift(ctx context.Context, organizationID, id string) (*domain.OpenShift, error) {
+	orgID, err := sharedDomain.ParseID(organizationID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDが不正です")
+	}
+	openShiftID, err := sharedDomain.ParseID(id)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "募集シフトIDが不正です")
+	}
+
+	openShift, err := u.openShiftRepo.FindByID(ctx, openShiftID)
+	if err != nil {
+		return nil, err
+	}
+	if openShift == nil || openShift.OrganizationID != orgID {
+		return nil, sharedDomain.ErrNotFound
+	}
+	return openShift, nil
+}
+
+// findForStaffAction 操作対象の募集シフトとスタッフIDを取得
+func (u *OpenShiftUseCase) findForStaffAction(ctx context.Context, input *OpenShiftStaffInput) (*domain.OpenShift, sharedDomain.ID, error) {
+	staffID, err := sharedDomain.ParseID(input.StaffID)
+	if err != nil {
+		return nil, staffID, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "スタッフIDが不正です")
+	}
+	openShift, err := u.findOpenShift(ctx, input.OrganizationID, input.OpenShiftID)
+	if err != nil {
+		return nil, staffID, err
+	}
+	return openShift, staffID, nil
+}
+
+// buildOutput 出力を構築
+func (u *OpenShiftUseCase) buildOutput(ctx context.Context, openShift *domain.OpenShift) (*OpenShiftOutput, error) {
+	names, err := u.newOutputNames(ctx, openShift.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	return names.output(ctx, openShift)
+}
+
+// buildShiftTypeMap 組織のシフト種別マップを構築
+func (u *OpenShiftUseCase) buildShiftTypeMap(ctx context.Context, organizationID sharedDomain.ID) (map[string]*shiftDomain.ShiftType, error) {
+	shiftTypes, err := u.shiftTypeRepo.FindByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*shiftDomain.ShiftType)
+	for i := range shiftTypes {
+		result[shiftTypes[i].ID.String()] = &shiftTypes[i]
+	}
+	return result, nil
+}
+
+// openShiftOutputNames 出力用のスタッフ名・シフト名・資格名
+type openShiftOutputNames struct {
+	*staffNameLookup
+	shiftTypes     map[string]*shiftDomain.ShiftType
+	qualifications *domain.StaffQualifications
+}
+
+// newOutputNames 組織の有効スタッフ、シフト種別、資格から出力用の名前を準備
+func (u *OpenShiftUseCase) newOutputNames(ctx context.Context, organizationID sharedDomain.ID) (*openShiftOutputNames, error) {
+	lookup, err := newStaffNameLookup(ctx, u.staffRepo, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	shiftTypes, err := u.buildShiftTypeMap(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	qualifications, err := u.ruleEngine.LoadQualifications(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	return &openShiftOutputNames{staffNameLookup: lookup, shiftTypes: shiftTypes, qualifications: qualifications}, nil
+}
+
+// output 出力DTOへ変換 無効になったスタッフは個別に取得する
+func (n *openShiftOutputNames) output(ctx context.Context, openShift *domain.OpenShift) (*OpenShiftOutput, error) {
+	output := ToOpenShiftOutput(openShift)
+	var err error
+	if openShift.SourceStaffID != nil {
+		if output.SourceStaffName, err = n.staffName(ctx, *openShift.SourceStaffID); err != nil {
+			return nil, err
+		}
+	}
+	if openShift.FilledStaffID != nil {
+		if output.FilledStaffName, err = n.staffName(ctx, *openShift.FilledStaffID); err != nil {
+			return nil, err
+		}
+	}
+	for i, c := range openShift.Claims {
+		if output.Claims[i].StaffName, err = n.staffName(ctx, c.StaffID); err != nil {
+			return nil, err
+		}
+	}
+	output.ShiftName = shiftTypeName(n.shiftTypes, &openShift.ShiftTypeID)
+	if output.RequiredSkillID != "" {
+		output.RequiredSkillName = n.qualifications.SkillName(output.RequiredSkillID)
+	}
+	if output.RequiredJobTypeID != "" {
+		output.RequiredJobTypeName = n.qualifications.JobTypeName(output.RequiredJobTypeID)
+	}
+	return output, nil
+}
+
+// toOptions 名称マップを名称順の選択肢へ変換
+func toOptions(names map[string]string) []OptionOutput {
+	options := make([]OptionOutput, 0, len(names))
+	for id, name := range names {
+		options = append(options, OptionOutput{ID: id, Name: name})
+	}
+	sort.Slice(options, func(i, j int) bool {
+		if options[i].Name != options[j].Name {
+			return options[i].Name < options[j].Name
+		}
+		return options[i].ID < options[j].ID
+	})
+	return options
+}
+
+// isPastDate 勤務日が今日より前か判定
+func isPastDate(date time.Time) bool {
+	now := time.Now()
+	return date.Before(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC))
+}
+
+// parseOptionalID 任意項目のID解析 空文字はnil
+func parseOptionalID(value, message string) (*sharedDomain.ID, error) {
+	if value == "" {
+		return nil, nil
+	}
+	id, err := sharedDomain.ParseID(value)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, message)
+	}
+	return &id, nil
+}
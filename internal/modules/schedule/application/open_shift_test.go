@@ -0,0 +1,326 @@
+// Package application 募集シフトテスト
+package application
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"shiftmaster/internal/modules/schedule/domain"
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
+	staffDomain "shiftmaster/internal/modules/staff/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// モック募集シフトリポジトリ
+
+type mockOpenShiftRepository struct {
+	openShifts map[sharedDomain.ID]*domain.OpenShift
+	entries    *mockScheduleEntryRepository
+}
+
+func (m *mockOpenShiftRepository) FindByID(_ context.Context, id sharedDomain.ID) (*domain.OpenShift, error) {
+	o, ok := m.openShifts[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *o
+	copied.Claims = append([]domain.OpenShiftClaim(nil), o.Claims...)
+	return &copied, nil
+}
+
+func (m *mockOpenShiftRepository) FindByOrganizationID(_ context.Context, organizationID sharedDomain.ID, status domain.OpenShiftStatus) ([]domain.OpenShift, error) {
+	var result []domain.OpenShift
+	for _, o := range m.openShifts {
+		if o.OrganizationID == organizationID && (status == "" || o.Status == status) {
+			result = append(result, *o)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockOpenShiftRepository) Save(_ context.Context, openShift *domain.OpenShift) error {
+	m.openShifts[openShift.ID] = openShift
+	return nil
+}
+
+func (m *mockOpenShiftRepository) Fill(ctx context.Context, openShift *domain.OpenShift, entries []domain.ScheduleEntry) error {
+	m.openShifts[openShift.ID] = openShift
+	return m.entries.SaveBatch(ctx, entries)
+}
+
+// mockQualificationFinder モックスタッフ資格情報取得
+type mockQualificationFinder struct {
+	qualifications *domain.StaffQualifications
+}
+
+func (m *mockQualificationFinder) FindByOrganizationID(_ context.Context, _ sharedDomain.ID) (*domain.StaffQualifications, error) {
+	return m.qualifications, nil
+}
+
+// openShiftFixture 来年同月の公開済み勤務表と夜勤を持つテスト用ユースケース
+// 1人目の勤務を募集し、2人目と3人目が応募する
+type openShiftFixture struct {
+	*swapFixture
+	useCase        *OpenShiftUseCase
+	openShifts     *mockOpenShiftRepository
+	qualifications *domain.StaffQualifications
+}
+
+func newOpenShiftFixture() *openShiftFixture {
+	f := &openShiftFixture{swapFixture: newSwapFixture()}
+	f.staffs = append(f.staffs, staffDomain.Staff{ID: sharedDomain.NewID(), LastName: "佐藤", FirstName: "次郎", IsActive: true})
+	f.openShifts = &mockOpenShiftRepository{openShifts: make(map[sharedDomain.ID]*domain.OpenShift), entries: f.entries}
+	f.qualifications = &domain.StaffQualifications{
+		Staffs:     make(map[string]*staffDomain.Staff),
+		SkillNames: make(map[string]string),
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	f.useCase = NewOpenShiftUseCase(
+		f.openShifts,
+		&mockScheduleRepository{
+			schedules: map[sharedDomain.ID]*domain.Schedule{f.schedule.ID: f.schedule},
+			entries:   f.entries,
+		},
+		f.entries,
+		&mockShiftTypeRepository{shiftTypes: []shiftDomain.ShiftType{f.day, f.off, f.night}},
+		&mockStaffRepository{staffs: f.staffs},
+		f.rules,
+		&mockQualificationFinder{qualifications: f.qualifications},
+		nil,
+		logger,
+	)
+	return f
+}
+
+// post 1人目の指定日の勤務を募集
+func (f *openShiftFixture) post(day int, fillMode domain.FillMode, requiredSkillID string) (*OpenShiftOutput, error) {
+	return f.useCase.Post(context.Background(), &PostOpenShiftInput{
+		OrganizationID:  f.schedule.OrganizationID.String(),
+		StaffID:         f.staffs[0].ID.String(),
+		TargetDate:      f.date(day).Format("2006-01-02"),
+		RequiredSkillID: requiredSkillID,
+		FillMode:        fillMode.String(),
+		UserEmail:       "manager@example.com",
+	})
+}
+
+// staffInput 指定スタッフの応募・決定入力
+func (f *openShiftFixture) staffInput(openShiftID string, staffIndex int) *OpenShiftStaffInput {
+	return &OpenShiftStaffInput{
+		OrganizationID: f.schedule.OrganizationID.String(),
+		OpenShiftID:    openShiftID,
+		StaffID:        f.staffs[staffIndex].ID.String(),
+		UserEmail:      "staff@example.com",
+	}
+}
+
+// shiftOn 指定スタッフの指定日のシフト種別ID
+func (f *openShiftFixture) shiftOn(staffIndex, day int) *sharedDomain.ID {
+	for _, e := range f.entries.entries {
+		if e.StaffID == f.staffs[staffIndex].ID && e.TargetDate.Equal(f.date(day)) {
+			return e.ShiftTypeID
+		}
+	}
+	return nil
+}
+
+func TestOpenShiftUseCase_ClaimFirstCome(t *testing.T) {
+	f := newOpenShiftFixture()
+	ctx := context.Background()
+	f.addEntry(f.staffs[0].ID, f.date(1), f.day.ID, true)
+	f.addEntry(f.staffs[1].ID, f.date(1), f.off.ID, true)
+
+	posted, err := f.post(1, domain.FillModeFirstCome, "")
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	if _, err := f.post(1, domain.FillModeFirstCome, ""); err == nil {
+		t.Error("同じ勤務を重複して募集できています")
+	}
+
+	got, err := f.useCase.Get(ctx, f.schedule.OrganizationID.String(), posted.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if len(got.EligibleStaffs) != 2 {
+		t.Errorf("EligibleStaffs = %d, want 2", len(got.EligibleStaffs))
+	}
+
+	if _, err := f.useCase.Claim(ctx, f.staffInput(posted.ID, 0)); err == nil {
+		t.Error("募集元のスタッフが応募できています")
+	}
+
+	claimed, err := f.useCase.Claim(ctx, f.staffInput(posted.ID, 1))
+	if err != nil {
+		t.Fatalf("Claim() error = %v", err)
+	}
+	if claimed.Status != domain.OpenShiftStatusFilled.String() || claimed.FilledStaffID != f.staffs[1].ID.String() {
+		t.Errorf("先着の応募者で決定されていません: status=%v filled=%v", claimed.Status, claimed.FilledStaffID)
+	}
+	if got := f.shiftOn(1, 1); got == nil || *got != f.day.ID {
+		t.Error("応募者に日勤が割り当てられていません")
+	}
+	if got := f.shiftOn(0, 1); got != nil {
+		t.Error("募集元のエントリが未割当になっていません")
+	}
+
+	_, err = f.useCase.Claim(ctx, f.staffInput(posted.ID, 2))
+	var domainErr *sharedDomain.DomainError
+	if !errors.As(err, &domainErr) || domainErr.Code != sharedDomain.ErrCodeConflict {
+		t.Errorf("決定後の応募 error = %v, want conflict", err)
+	}
+}
+
+func TestOpenShiftUseCase_ManagerPick(t *testing.T) {
+	f := newOpenShiftFixture()
+	ctx := context.Background()
+	f.addEntry(f.staffs[0].ID, f.date(1), f.night.ID, false)
+
+	posted, err := f.post(1, domain.FillModeManagerPick, "")
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	for _, i := range []int{1, 2} {
+		claimed, err := f.useCase.Claim(ctx, f.staffInput(posted.ID, i))
+		if err != nil {
+			t.Fatalf("Claim() error = %v", err)
+		}
+		if !claimed.IsOpen {
+			t.Fatal("管理者が選定する募集が応募で決定されています")
+		}
+	}
+	if _, err := f.useCase.Claim(ctx, f.staffInput(posted.ID, 1)); err == nil {
+		t.Error("同じスタッフが重複して応募できています")
+	}
+
+	assigned, err := f.useCase.Assign(ctx, f.staffInput(posted.ID, 2))
+	if err != nil {
+		t.Fatalf("Assign() error = %v", err)
+	}
+	if assigned.FilledStaffID != f.staffs[2].ID.String() || len(assigned.Claims) != 2 {
+		t.Errorf("選定したスタッフで決定されていません: %+v", assigned)
+	}
+	if got := f.shiftOn(2, 1); got == nil || *got != f.night.ID {
+		t.Error("選定したスタッフに夜勤が割り当てられていません")
+	}
+	if got := f.shiftOn(1, 1); got != nil {
+		t.Error("選定されなかった応募者にシフトが割り当てられています")
+	}
+}
+
+func TestOpenShiftUseCase_Ineligible(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(f *openShiftFixture) string
+	}{
+		{
+			name: "同じ日に勤務あり",
+			setup: func(f *openShiftFixture) string {
+				f.addEntry(f.staffs[1].ID, f.date(3), f.day.ID, false)
+				return ""
+			},
+		},
+		{
+			// 3日連続夜勤になるスタッフは既定ルール（夜勤連続2日まで）に違反
+			name: "連続夜勤の上限超過",
+			setup: func(f *openShiftFixture) string {
+				f.addEntry(f.staffs[1].ID, f.date(1), f.night.ID, false)
+				f.addEntry(f.staffs[1].ID, f.date(2), f.night.ID, false)
+				return ""
+			},
+		},
+		{
+			name: "必要なスキルなし",
+			setup: func(f *openShiftFixture) string {
+				skillID := sharedDomain.NewID()
+				f.qualifications.SkillNames[skillID.String()] = "夜勤リーダー"
+				return skillID.String()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newOpenShiftFixture()
+			ctx := context.Background()
+			f.addEntry(f.staffs[0].ID, f.date(3), f.night.ID, false)
+			skillID := tt.setup(f)
+
+			posted, err := f.post(3, domain.FillModeFirstCome, skillID)
+			if err != nil {
+				t.Fatalf("Post() error = %v", err)
+			}
+			got, err := f.useCase.Get(ctx, f.schedule.OrganizationID.String(), posted.ID)
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			for _, s := range got.EligibleStaffs {
+				if s.StaffID == f.staffs[1].ID.String() {
+					t.Error("応募できないスタッフが応募可能に含まれています")
+				}
+			}
+
+			_, err = f.useCase.Claim(ctx, f.staffInput(posted.ID, 1))
+			var domainErr *sharedDomain.DomainError
+			if !errors.As(err, &domainErr) || domainErr.Code != sharedDomain.ErrCodeValidation {
+				t.Fatalf("Claim() error = %v, want validation error", err)
+			}
+			if !f.openShifts.openShifts[mustParseID(posted.ID)].IsOpen() {
+				t.Error("応募できないスタッフで募集が決定されています")
+			}
+		})
+	}
+}
+
+func TestOpenShiftUseCase_Post_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(f *openShiftFixture)
+	}{
+		{
+			name:  "勤務表が未公開",
+			setup: func(f *openShiftFixture) { f.schedule.Status = domain.StatusDraft },
+		},
+		{
+			name: "シフトが未割当",
+			setup: func(f *openShiftFixture) {
+				for _, e := range f.entries.entries {
+					e.ShiftTypeID = nil
+				}
+			},
+		},
+		{
+			name: "過去の勤務",
+			setup: func(f *openShiftFixture) {
+				f.schedule.TargetYear -= 2
+				f.addEntry(f.staffs[0].ID, f.date(1), f.day.ID, false)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newOpenShiftFixture()
+			f.addEntry(f.staffs[0].ID, f.date(1), f.day.ID, false)
+			tt.setup(f)
+
+			_, err := f.post(1, domain.FillModeFirstCome, "")
+			var domainErr *sharedDomain.DomainError
+			if !errors.As(err, &domainErr) || domainErr.Code != sharedDomain.ErrCodeValidation {
+				t.Errorf("Post() error = %v, want validation error", err)
+			}
+		})
+	}
+}
+
+func mustParseID(s string) sharedDomain.ID {
+	id, err := sharedDomain.ParseID(s)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
@@ -0,0 +1,361 @@
+// Package application 勤務表アプリケーション層
+package application
+
+import (
+	"context"
+	"time"
+
+	"shiftmaster/internal/modules/schedule/domain"
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// Generate 勤務表自動作成 結果は作成案として保存し、適用は別途行う
+func (u *ScheduleUseCase) Generate(ctx context.Context, input *GenerateScheduleInput) (*ProposalOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	scheduleID, err := sharedDomain.ParseID(input.ScheduleID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "勤務表IDが不正です")
+	}
+
+	schedule, err := u.scheduleRepo.FindByIDWithEntries(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil {
+		return nil, sharedDomain.ErrNotFound
+	}
+	if schedule.Status == domain.StatusPublished {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "公開済みの勤務表は自動作成できません")
+	}
+
+	// 最適化エンジン未設定時は簡易自動割り当てで代替
+	optimizer := u.optimizer
+	generator := domain.GeneratorOptimizer
+	if optimizer == nil {
+		optimizer = &autoFillOptimizer{
+			scheduleRepo:  u.scheduleRepo,
+			staffRepo:     u.staffRepo,
+			shiftTypeRepo: u.shiftTypeRepo,
+		}
+		generator = domain.GeneratorAutoFill
+	}
+
+	result, err := optimizer.Optimize(ctx, domain.OptimizeInput{
+		ScheduleID: scheduleID,
+		DateRange: sharedDomain.DateRange{
+			Start: schedule.StartDate(),
+			End:   schedule.EndDate(),
+		},
+		Options: domain.OptimizeOptions{
+			MaxIterations:      input.MaxIterations,
+			TimeoutSeconds:     input.TimeoutSeconds,
+			PrioritizeRequests: input.PrioritizeRequests,
+		},
+	})
+	if err != nil {
+		u.logger.Error("勤務表自動作成失敗", "error", err)
+		return nil, err
+	}
+	if !result.Success {
+		message := "勤務表を自動作成できませんでした"
+		if len(result.Violations) > 0 {
+			message = result.Violations[0].Message
+		}
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, message)
+	}
+
+	now := time.Now()
+	proposal := &domain.ScheduleProposal{
+		ID:         sharedDomain.NewID(),
+		ScheduleID: scheduleID,
+		Status:     domain.ProposalStatusPending,
+		Generator:  generator,
+		Score:      result.Score,
+		Entries:    result.Entries,
+		Violations: result.Violations,
+		Duration:   result.Duration,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := u.proposalRepo.Save(ctx, proposal); err != nil {
+		u.logger.Error("自動作成案保存失敗", "error", err)
+		return nil, err
+	}
+
+	u.logger.Info("勤務表自動作成完了",
+		"schedule_id", scheduleID,
+		"proposal_id", proposal.ID,
+		"generator", generator,
+		"entry_count", len(proposal.Entries),
+	)
+
+	return u.buildProposalOutput(ctx, schedule, proposal)
+}
+
+// GetProposal 自動作成案取得 現在の勤務表との比較を含む
+func (u *ScheduleUseCase) GetProposal(ctx context.Context, scheduleID, proposalID string) (*ProposalOutput, error) {
+	schedule, proposal, err := u.findProposal(ctx, scheduleID, proposalID)
+	if err != nil {
+		return nil, err
+	}
+	return u.buildProposalOutput(ctx, schedule, proposal)
+}
+
+// ListProposals 勤務表の自動作成案一覧取得 比較は含まない
+func (u *ScheduleUseCase) ListProposals(ctx context.Context, scheduleID string) ([]ProposalOutput, error) {
+	id, err := sharedDomain.ParseID(scheduleID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "勤務表IDが不正です")
+	}
+
+	proposals, err := u.proposalRepo.FindByScheduleID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := make([]ProposalOutput, len(proposals))
+	for i := range proposals {
+		outputs[i] = *ToProposalOutput(&proposals[i])
+	}
+	return outputs, nil
+}
+
+// ApplyProposal 自動作成案を勤務表へ適用
+// 既存エントリは同じスタッフ・日付のIDを引き継いで上書きし、確定済みエントリは変更しない
+func (u *ScheduleUseCase) ApplyProposal(ctx context.Context, scheduleID, proposalID string) (*ScheduleOutput, error) {
+	schedule, proposal, err := u.findProposal(ctx, scheduleID, proposalID)
+	if err != nil {
+		return nil, err
+	}
+
+	if schedule.Status == domain.StatusPublished {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "公開済みの勤務表には適用できません")
+	}
+	if !proposal.IsPending() {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeConflict, "この自動作成案は既に"+proposal.Status.Label()+"です")
+	}
+
+	existing := make(map[string]domain.ScheduleEntry, len(schedule.Entries))
+	for _, e := range schedule.Entries {
+		existing[entryKey(e)] = e
+	}
+
+	// 上書きするエントリは適用時に読み込み後の変更・確定がないことを確認する
+	now := time.Now()
+	var originals []domain.ScheduleEntry
+	entries := make([]domain.ScheduleEntry, 0, len(proposal.Entries))
+	for _, e := range proposal.Entries {
+		current, ok := existing[entryKey(e)]
+		if ok && current.IsConfirmed {
+			continue
+		}
+		if ok {
+			originals = append(originals, current)
+			e.ID = current.ID
+			e.CreatedAt = current.CreatedAt
+			e.Note = current.Note
+		} else {
+			e.ID = sharedDomain.NewID()
+			e.CreatedAt = now
+		}
+		e.ScheduleID = schedule.ID
+		e.IsConfirmed = false
+		e.UpdatedAt = now
+		entries = append(entries, e)
+	}
+
+	proposal.Apply(now)
+	if err := u.proposalRepo.Apply(ctx, proposal, originals, entries); err != nil {
+		u.logger.Error("自動作成案適用失敗", "error", err)
+		return nil, err
+	}
+
+	u.logger.Info("自動作成案適用完了", "schedule_id", schedule.ID, "proposal_id", proposal.ID, "count", len(entries))
+	return ToScheduleOutput(schedule), nil
+}
+
+// DiscardProposal 自動作成案破棄
+func (u *ScheduleUseCase) DiscardProposal(ctx context.Context, scheduleID, proposalID string) error {
+	_, proposal, err := u.findProposal(ctx, scheduleID, proposalID)
+	if err != nil {
+		return err
+	}
+
+	if !proposal.IsPending() {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeConflict, "この自動作成案は既に"+proposal.Status.Label()+"です")
+	}
+
+	proposal.Discard(time.Now())
+	if err := u.proposalRepo.Save(ctx, proposal); err != nil {
+		u.logger.Error("自動作成案破棄失敗", "error", err)
+		return err
+	}
+
+	u.logger.Info("自動作成案破棄完了", "proposal_id", proposal.ID)
+	return nil
+}
+
+// findProposal 勤務表と自動作成案を取得 勤務表に属さない作成案は見つからない扱い
+func (u *ScheduleUseCase) findProposal(ctx context.Context, scheduleID, proposalID string) (*domain.Schedule, *domain.ScheduleProposal, error) {
+	sID, err := sharedDomain.ParseID(scheduleID)
+	if err != nil {
+		return nil, nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "勤務表IDが不正です")
+	}
+	pID, err := sharedDomain.ParseID(proposalID)
+	if err != nil {
+		return nil, nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "自動作成案IDが不正です")
+	}
+
+	schedule, err := u.scheduleRepo.FindByIDWithEntries(ctx, sID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if schedule == nil {
+		return nil, nil, sharedDomain.ErrNotFound
+	}
+
+	proposal, err := u.proposalRepo.FindByID(ctx, pID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if proposal == nil || proposal.ScheduleID != schedule.ID {
+		return nil, nil, sharedDomain.ErrNotFound
+	}
+
+	return schedule, proposal, nil
+}
+
+// buildProposalOutput 作成案出力を構築 現在の勤務表と作成案の違反件数・変更セルを比較する
+func (u *ScheduleUseCase) buildProposalOutput(ctx context.Context, schedule *domain.Schedule, proposal *domain.ScheduleProposal) (*ProposalOutput, error) {
+	shiftTypeMap, err := u.buildShiftTypeMap(ctx, schedule.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	staffNames, err := u.staffNames(ctx, schedule.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := ToProposalOutput(proposal)
+	for i := range output.Entries {
+		entry := &output.Entries[i]
+		entry.StaffName = staffNames[entry.StaffID]
+		if st, ok := shiftTypeMap[entry.ShiftTypeID]; ok {
+			entry.ShiftTypeName = st.Name
+			entry.ShiftTypeCode = st.Code
+		}
+	}
+
+	rules, err := u.ruleEngine.LoadRules(ctx, schedule.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	qualifications, err := u.ruleEngine.LoadQualifications(ctx, schedule.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	requests, err := u.ruleEngine.LoadRequests(ctx, schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	currentViolations := u.validateEntries(schedule, schedule.Entries, shiftTypeMap, rules, qualifications, requests)
+	proposedViolations := u.validateEntries(schedule, proposal.Entries, shiftTypeMap, rules, qualifications, requests)
+
+	comparison := &ProposalComparisonOutput{
+		Current:    summarizeViolations(currentViolations),
+		Proposed:   summarizeViolations(proposedViolations),
+		Resolved:   diffViolations(currentViolations, proposedViolations),
+		Introduced: diffViolations(proposedViolations, currentViolations),
+		Changes:    make([]EntryChangeOutput, 0),
+	}
+
+	current := make(map[string]domain.ScheduleEntry, len(schedule.Entries))
+	for _, e := range schedule.Entries {
+		current[entryKey(e)] = e
+	}
+	for _, e := range proposal.Entries {
+		before, ok := current[entryKey(e)]
+		if ok && sameShift(before.ShiftTypeID, e.ShiftTypeID) {
+			continue
+		}
+		change := EntryChangeOutput{
+			StaffID:    e.StaffID.String(),
+			StaffName:  staffNames[e.StaffID.String()],
+			TargetDate: e.TargetDate.Format("2006-01-02"),
+			After:      shiftTypeName(shiftTypeMap, e.ShiftTypeID),
+		}
+		if ok {
+			change.Before = shiftTypeName(shiftTypeMap, before.ShiftTypeID)
+		}
+		comparison.Changes = append(comparison.Changes, change)
+	}
+	comparison.ChangedCount = len(comparison.Changes)
+
+	output.Comparison = comparison
+	return output, nil
+}
+
+// entryKey スタッフ・日付のキー
+func entryKey(e domain.ScheduleEntry) string {
+	return e.StaffID.String() + "_" + e.TargetDate.Format("2006-01-02")
+}
+
+// sameShift シフト種別の同一判定
+func sameShift(a, b *sharedDomain.ID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// shiftTypeName シフト種別名取得 未割り当ては空文字
+func shiftTypeName(shiftTypeMap map[string]*shiftDomain.ShiftType, id *sharedDomain.ID) string {
+	if id == nil {
+		return ""
+	}
+	if st, ok := shiftTypeMap[id.String()]; ok {
+		return st.Name
+	}
+	return ""
+}
+
+// summarizeViolations 重大度別の違反件数を集計
+func summarizeViolations(violations []ViolationOutput) ViolationSummaryOutput {
+	summary := ViolationSummaryOutput{Total: len(violations)}
+	for _, v := range violations {
+		switch v.Severity {
+		case "error":
+			summary.Errors++
+		case "warning":
+			summary.Warnings++
+		default:
+			summary.Infos++
+		}
+	}
+	return summary
+}
+
+// diffViolations baseにありotherにない違反を抽出 種別・スタッフ・日付で同一判定
+func diffViolations(base, other []ViolationOutput) []ViolationOutput {
+	keys := make(map[string]bool, len(other))
+	for _, v := range other {
+		keys[v.Type+"_"+v.StaffID+"_"+v.Date+"_"+v.ShiftTypeID] = true
+	}
+
+	result := make([]ViolationOutput, 0)
+	for _, v := range base {
+		if !keys[v.Type+"_"+v.StaffID+"_"+v.Date+"_"+v.ShiftTypeID] {
+			result = append(result, v)
+		}
+	}
+	return result
+}
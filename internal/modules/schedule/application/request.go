@@ -0,0 +1,106 @@
+// Package application 勤務表アプリケーション層
+package application
+
+import (
+	"context"
+	"sort"
+
+	"shiftmaster/internal/modules/schedule/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// summarizeRequestFulfillment スタッフごとの勤務希望充足率を集計 希望のないスタッフは含めない
+func summarizeRequestFulfillment(results []domain.ShiftRequestResult, staffNames map[string]string) []RequestFulfillmentOutput {
+	byStaff := make(map[string]*RequestFulfillmentOutput)
+	for _, res := range results {
+		staffID := res.Request.StaffID.String()
+		f, ok := byStaff[staffID]
+		if !ok {
+			f = &RequestFulfillmentOutput{StaffID: staffID, StaffName: staffNames[staffID]}
+			byStaff[staffID] = f
+		}
+		f.Total++
+		if res.Satisfied {
+			f.Fulfilled++
+		}
+	}
+
+	output := make([]RequestFulfillmentOutput, 0, len(byStaff))
+	for _, f := range byStaff {
+		f.Rate = float64(f.Fulfilled) * 100 / float64(f.Total)
+		output = append(output, *f)
+	}
+	sort.Slice(output, func(i, j int) bool {
+		if output[i].StaffName != output[j].StaffName {
+			return output[i].StaffName < output[j].StaffName
+		}
+		return output[i].StaffID < output[j].StaffID
+	})
+	return output
+}
+
+// GetRequestFulfillment 勤務表のスタッフ別勤務希望充足率を取得
+func (u *ScheduleUseCase) GetRequestFulfillment(ctx context.Context, id string) ([]RequestFulfillmentOutput, error) {
+	scheduleID, err := sharedDomain.ParseID(id)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "IDが不正です")
+	}
+
+	schedule, err := u.scheduleRepo.FindByIDWithEntries(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil {
+		return nil, sharedDomain.ErrNotFound
+	}
+
+	return u.requestFulfillment(ctx, schedule)
+}
+
+// requestFulfillment 勤務希望の充足率を集計
+func (u *ScheduleUseCase) requestFulfillment(ctx context.Context, schedule *domain.Schedule) ([]RequestFulfillmentOutput, error) {
+	requests, err := u.ruleEngine.LoadRequests(ctx, schedule)
+	if err != nil {
+		u.logger.Error("勤務希望取得失敗", "error", err)
+		return nil, err
+	}
+	if len(requests) == 0 {
+		return make([]RequestFulfillmentOutput, 0), nil
+	}
+
+	shiftTypeMap, err := u.buildShiftTypeMap(ctx, schedule.OrganizationID)
+	if err != nil {
+		u.logger.Error("シフト種別マップ取得失敗", "error", err)
+		return nil, err
+	}
+
+	staffNames, err := u.staffNames(ctx, schedule.OrganizationID)
+	if err != nil {
+		u.logger.Error("スタッフ一覧取得失敗", "error", err)
+		return nil, err
+	}
+
+	results := domain.JudgeShiftRequests(&domain.ConstraintInput{
+		Schedule:   schedule,
+		Entries:    schedule.Entries,
+		ShiftTypes: shiftTypeMap,
+		Requests:   requests,
+	})
+	return summarizeRequestFulfillment(results, staffNames), nil
+}
+
+// staffNames 組織の有効スタッフ名マップ スタッフID文字列 -> 氏名
+func (u *ScheduleUseCase) staffNames(ctx context.Context, organizationID sharedDomain.ID) (map[string]string, error) {
+	names := make(map[string]string)
+	if u.staffRepo == nil {
+		return names, nil
+	}
+	staffs, err := u.staffRepo.FindActiveByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range staffs {
+		names[s.ID.String()] = s.LastName + " " + s.FirstName
+	}
+	return names, nil
+}
@@ -0,0 +1,81 @@
+// Package application 勤務表アプリケーション層
+package application
+
+import (
+	"context"
+	"log/slog"
+
+	requestDomain "shiftmaster/internal/modules/request/domain"
+	"shiftmaster/internal/modules/schedule/domain"
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// RuleEngine シフトルール評価エンジン
+// 組織ごとの有効なShiftRuleと評価に必要な情報を取得し、制約パイプラインで勤務表エントリを評価する
+type RuleEngine struct {
+	ruleRepo            shiftDomain.ShiftRuleRepository
+	qualificationFinder domain.StaffQualificationFinder
+	requestFinder       domain.ShiftRequestFinder
+	logger              *slog.Logger
+}
+
+// NewRuleEngine ルールエンジン生成 ruleRepoがnilの場合は既定ルールのみで評価
+// qualificationFinderがnilの場合はスキル・職種・職位を条件とするルールを評価しない
+// requestFinderがnilの場合は勤務希望の反映を評価しない
+func NewRuleEngine(
+	ruleRepo shiftDomain.ShiftRuleRepository,
+	qualificationFinder domain.StaffQualificationFinder,
+	requestFinder domain.ShiftRequestFinder,
+	logger *slog.Logger,
+) *RuleEngine {
+	return &RuleEngine{
+		ruleRepo:            ruleRepo,
+		qualificationFinder: qualificationFinder,
+		requestFinder:       requestFinder,
+		logger:              logger,
+	}
+}
+
+// LoadRules 組織の有効ルールを取得 未設定の種別は既定ルールで補完
+func (e *RuleEngine) LoadRules(ctx context.Context, organizationID sharedDomain.ID) ([]shiftDomain.ShiftRule, error) {
+	var rules []shiftDomain.ShiftRule
+	if e.ruleRepo != nil {
+		found, err := e.ruleRepo.FindActiveByOrganizationID(ctx, organizationID)
+		if err != nil {
+			return nil, err
+		}
+		rules = found
+	}
+	return shiftDomain.WithDefaultShiftRules(organizationID, rules), nil
+}
+
+// LoadQualifications ルール評価用のスタッフ資格情報を取得 取得手段がない場合はnil
+func (e *RuleEngine) LoadQualifications(ctx context.Context, organizationID sharedDomain.ID) (*domain.StaffQualifications, error) {
+	if e.qualificationFinder == nil {
+		return nil, nil
+	}
+	return e.qualificationFinder.FindByOrganizationID(ctx, organizationID)
+}
+
+// LoadRequests 勤務表と同じ対象年月の勤務希望を取得 取得手段がない場合はnil
+func (e *RuleEngine) LoadRequests(ctx context.Context, schedule *domain.Schedule) ([]requestDomain.ShiftRequest, error) {
+	if e.requestFinder == nil {
+		return nil, nil
+	}
+	return e.requestFinder.FindByTargetMonth(ctx, schedule.OrganizationID, schedule.TargetYear, schedule.TargetMonth)
+}
+
+// Evaluate ルール評価 設定が不正なルールは評価対象外
+func (e *RuleEngine) Evaluate(rules []shiftDomain.ShiftRule, input *domain.ConstraintInput) []ViolationOutput {
+	pipeline := domain.NewConstraintPipeline(rules, func(rule shiftDomain.ShiftRule, err error) {
+		e.logger.Warn("シフトルール設定不正", "rule_id", rule.ID, "rule_type", rule.RuleType, "error", err)
+	})
+
+	found := pipeline.Evaluate(input)
+	violations := make([]ViolationOutput, len(found))
+	for i, v := range found {
+		violations[i] = ToViolationOutput(v)
+	}
+	return violations
+}
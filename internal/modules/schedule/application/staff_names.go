@@ -0,0 +1,46 @@
+// Package application 勤務表アプリケーション層
+package application
+
+import (
+	"context"
+
+	staffDomain "shiftmaster/internal/modules/staff/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// staffNameLookup 出力用のスタッフ名 組織の有効スタッフを一括取得し、無効になったスタッフは個別に取得する
+type staffNameLookup struct {
+	staffRepo staffDomain.StaffRepository
+	names     map[string]string
+}
+
+// newStaffNameLookup 組織の有効スタッフからスタッフ名を準備
+func newStaffNameLookup(ctx context.Context, staffRepo staffDomain.StaffRepository, organizationID sharedDomain.ID) (*staffNameLookup, error) {
+	staffs, err := staffRepo.FindActiveByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	lookup := &staffNameLookup{staffRepo: staffRepo, names: make(map[string]string, len(staffs))}
+	for i := range staffs {
+		lookup.names[staffs[i].ID.String()] = staffs[i].FullName()
+	}
+	return lookup, nil
+}
+
+// staffName スタッフ名取得 該当なしは空文字
+func (l *staffNameLookup) staffName(ctx context.Context, id sharedDomain.ID) (string, error) {
+	if name, ok := l.names[id.String()]; ok {
+		return name, nil
+	}
+	staff, err := l.staffRepo.FindByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	name := ""
+	if staff != nil {
+		name = staff.FullName()
+	}
+	l.names[id.String()] = name
+	return name, nil
+}
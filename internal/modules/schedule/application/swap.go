@@ -0,0 +1,582 @@
+// Package application 勤務表アプリケーション層
+package application
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"shiftmaster/internal/modules/schedule/domain"
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
+	staffDomain "shiftmaster/internal/modules/staff/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// ShiftSwapUseCase 勤務交換ユースケース
+// 申請 → 相手の承諾 → 管理者の承認 の順に進め、承諾時と承認時にシフトルールで両者の勤務を再検証する
+type ShiftSwapUseCase struct {
+	swapRepo      domain.ShiftSwapRepository
+	scheduleRepo  domain.ScheduleRepository
+	entryRepo     domain.ScheduleEntryRepository
+	shiftTypeRepo shiftDomain.ShiftTypeRepository
+	staffRepo     staffDomain.StaffRepository
+	ruleEngine    *RuleEngine
+	logger        *slog.Logger
+}
+
+// NewShiftSwapUseCase 勤務交換ユースケース生成
+func NewShiftSwapUseCase(
+	swapRepo domain.ShiftSwapRepository,
+	scheduleRepo domain.ScheduleRepository,
+	entryRepo domain.ScheduleEntryRepository,
+	shiftTypeRepo shiftDomain.ShiftTypeRepository,
+	staffRepo staffDomain.StaffRepository,
+	ruleRepo shiftDomain.ShiftRuleRepository,
+	qualificationFinder domain.StaffQualificationFinder,
+	requestFinder domain.ShiftRequestFinder,
+	logger *slog.Logger,
+) *ShiftSwapUseCase {
+	return &ShiftSwapUseCase{
+		swapRepo:      swapRepo,
+		scheduleRepo:  scheduleRepo,
+		entryRepo:     entryRepo,
+		shiftTypeRepo: shiftTypeRepo,
+		staffRepo:     staffRepo,
+		ruleEngine:    NewRuleEngine(ruleRepo, qualificationFinder, requestFinder, logger),
+		logger:        logger,
+	}
+}
+
+// swapPlan 勤務交換の実行計画
+type swapPlan struct {
+	// changed 更新するエントリ
+	changed []domain.ScheduleEntry
+	// originals 更新するエントリの交換前の状態 反映時に他の操作で変更されていないか確認する
+	originals []domain.ScheduleEntry
+	// changes 変更内容
+	changes []EntryChangeOutput
+	// violations 交換で新たに発生する制約違反
+	violations []ViolationOutput
+}
+
+// hasErrors 重大度errorの違反有無
+func (p *swapPlan) hasErrors() bool {
+	return firstError(p.violations) != nil
+}
+
+// List 組織の勤務交換申請一覧 statusが空の場合は全件
+func (u *ShiftSwapUseCase) List(ctx context.Context, organizationID, status string) ([]ShiftSwapOutput, error) {
+	orgID, err := sharedDomain.ParseID(organizationID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDが不正です")
+	}
+
+	swaps, err := u.swapRepo.FindByOrganizationID(ctx, orgID, domain.SwapStatus(status))
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := u.newOutputNames(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	outputs := make([]ShiftSwapOutput, len(swaps))
+	for i := range swaps {
+		output, err := names.output(ctx, &swaps[i])
+		if err != nil {
+			return nil, err
+		}
+		outputs[i] = *output
+	}
+	return outputs, nil
+}
+
+// Get 勤務交換申請取得 未完了の申請は変更内容と新たに発生する制約違反を含む
+func (u *ShiftSwapUseCase) Get(ctx context.Context, organizationID, id string) (*ShiftSwapOutput, error) {
+	swap, err := u.findSwap(ctx, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var plan *swapPlan
+	problem := ""
+	if swap.IsOpen() {
+		plan, err = u.plan(ctx, swap)
+		var domainErr *sharedDomain.DomainError
+		if errors.As(err, &domainErr) {
+			// 勤務表の変更などで交換できなくなった申請も表示する
+			problem = domainErr.Message
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	output, err := u.buildOutput(ctx, swap, plan)
+	if err != nil {
+		return nil, err
+	}
+	output.Problem = problem
+	return output, nil
+}
+
+// Request 勤務交換申請 公開済み勤務表の今日以降の勤務のみ対象
+func (u *ShiftSwapUseCase) Request(ctx context.Context, input *RequestShiftSwapInput) (*ShiftSwapOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	orgID, err := sharedDomain.ParseID(input.OrganizationID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDが不正です")
+	}
+	staffs, err := u.staffRepo.FindActiveByOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	actor, err := parseSwapActor(input.UserID, input.UserEmail, input.IsManager, staffs)
+	if err != nil {
+		return nil, err
+	}
+	activeStaffs := make(map[sharedDomain.ID]*staffDomain.Staff, len(staffs))
+	for i := range staffs {
+		activeStaffs[staffs[i].ID] = &staffs[i]
+	}
+
+	requester, err := u.findPublishedEntry(ctx, orgID, activeStaffs, input.RequesterStaffID, input.RequesterDate, "申請者")
+	if err != nil {
+		return nil, err
+	}
+	counterpart, err := u.findPublishedEntry(ctx, orgID, activeStaffs, input.CounterpartStaffID, input.CounterpartDate, "相手")
+	if err != nil {
+		return nil, err
+	}
+	if !actor.CanRequestFor(requester.StaffID) {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeForbidden, "自分の勤務のみ交換を申請できます")
+	}
+
+	// 同じ勤務を対象とする未完了の申請は1件まで
+	open, err := u.swapRepo.FindByOrganizationID(ctx, orgID, "")
+	if err != nil {
+		return nil, err
+	}
+	for i := range open {
+		if open[i].IsOpen() && (open[i].Involves(requester.ID) || open[i].Involves(counterpart.ID)) {
+			return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeConflict, "この勤務は既に交換を申請中です")
+		}
+	}
+
+	swap := domain.NewShiftSwap(orgID, requester, counterpart, input.Reason, actor, time.Now())
+	plan, err := u.plan(ctx, swap)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.swapRepo.Save(ctx, swap); err != nil {
+		u.logger.Error("勤務交換申請失敗", "error", err)
+		return nil, err
+	}
+
+	u.logger.Info("勤務交換申請", "swap_id", swap.ID, "requester_staff_id", swap.RequesterStaffID, "counterpart_staff_id", swap.CounterpartStaffID)
+	return u.buildOutput(ctx, swap, plan)
+}
+
+// Accept 相手のスタッフが承諾 交換後の勤務がシフトルールに違反する場合は承諾できない
+func (u *ShiftSwapUseCase) Accept(ctx context.Context, input *ShiftSwapActionInput) (*ShiftSwapOutput, error) {
+	swap, actor, err := u.findSwapForAction(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if err := swap.Accept(actor, time.Now()); err != nil {
+		return nil, err
+	}
+
+	plan, err := u.plan(ctx, swap)
+	if err != nil {
+		return nil, err
+	}
+	if v := firstError(plan.violations); v != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "交換後の勤務がシフトルールに違反するため承諾できません: "+v.Message)
+	}
+
+	if err := u.swapRepo.Save(ctx, swap); err != nil {
+		u.logger.Error("勤務交換承諾失敗", "error", err)
+		return nil, err
+	}
+
+	u.logger.Info("勤務交換承諾", "swap_id", swap.ID)
+	return u.buildOutput(ctx, swap, plan)
+}
+
+// Decline 相手のスタッフが辞退
+func (u *ShiftSwapUseCase) Decline(ctx context.Context, input *ShiftSwapActionInput) (*ShiftSwapOutput, error) {
+	return u.close(ctx, input, "勤務交換辞退", func(swap *domain.ShiftSwap, actor domain.SwapActor, now time.Time) error {
+		return swap.Decline(actor, input.Comment, now)
+	})
+}
+
+// Cancel 申請者が取り下げ
+func (u *ShiftSwapUseCase) Cancel(ctx context.Context, input *ShiftSwapActionInput) (*ShiftSwapOutput, error) {
+	return u.close(ctx, input, "勤務交換取り下げ", func(swap *domain.ShiftSwap, actor domain.SwapActor, now time.Time) error {
+		return swap.Cancel(actor, input.Comment, now)
+	})
+}
+
+// Reject 管理者が却下
+func (u *ShiftSwapUseCase) Reject(ctx context.Context, input *ShiftSwapActionInput) (*ShiftSwapOutput, error) {
+	return u.close(ctx, input, "勤務交換却下", func(swap *domain.ShiftSwap, actor domain.SwapActor, now time.Time) error {
+		return swap.Reject(actor, input.Comment, now)
+	})
+}
+
+// Approve 管理者が承認 再検証の上、両者のエントリ更新と承認の記録を1トランザクションで行う
+func (u *ShiftSwapUseCase) Approve(ctx context.Context, input *ShiftSwapActionInput) (*ShiftSwapOutput, error) {
+	swap, actor, err := u.findSwapForAction(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if err := swap.Approve(actor, input.Comment, time.Now()); err != nil {
+		return nil, err
+	}
+
+	plan, err := u.plan(ctx, swap)
+	if err != nil {
+		return nil, err
+	}
+	if v := firstError(plan.violations); v != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "交換後の勤務がシフトルールに違反するため承認できません: "+v.Message)
+	}
+
+	if err := u.swapRepo.Apply(ctx, swap, plan.originals, plan.changed); err != nil {
+		u.logger.Error("勤務交換承認失敗", "error", err)
+		return nil, err
+	}
+
+	u.logger.Info("勤務交換承認", "swap_id", swap.ID, "count", len(plan.changed))
+	return u.buildOutput(ctx, swap, nil)
+}
+
+// close 勤務表を変更せずに申請を終了する
+func (u *ShiftSwapUseCase) close(
+	ctx context.Context,
+	input *ShiftSwapActionInput,
+	action string,
+	transition func(swap *domain.ShiftSwap, actor domain.SwapActor, now time.Time) error,
+) (*ShiftSwapOutput, error) {
+	swap, actor, err := u.findSwapForAction(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if err := transition(swap, actor, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := u.swapRepo.Save(ctx, swap); err != nil {
+		u.logger.Error(action+"失敗", "error", err)
+		return nil, err
+	}
+
+	u.logger.Info(action, "swap_id", swap.ID)
+	return u.buildOutput(ctx, swap, nil)
+}
+
+// plan 現在の勤務表に対する交換内容と新たに発生する制約違反を求める
+// 申請後に対象エントリが変更された場合は競合エラー
+func (u *ShiftSwapUseCase) plan(ctx context.Context, swap *domain.ShiftSwap) (*swapPlan, error) {
+	requester, err := u.entryRepo.FindByID(ctx, swap.RequesterEntryID)
+	if err != nil {
+		return nil, err
+	}
+	counterpart, err := u.entryRepo.FindByID(ctx, swap.CounterpartEntryID)
+	if err != nil {
+		return nil, err
+	}
+	if requester == nil || counterpart == nil || !swap.MatchesEntries(requester, counterpart) {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeConflict, "申請後に勤務表が変更されたため、この勤務交換は行えません")
+	}
+
+	// 月をまたぐ交換では2つの勤務表が対象
+	scheduleIDs := []sharedDomain.ID{requester.ScheduleID}
+	if counterpart.ScheduleID != requester.ScheduleID {
+		scheduleIDs = append(scheduleIDs, counterpart.ScheduleID)
+	}
+	schedules := make([]*domain.Schedule, 0, len(scheduleIDs))
+	var entries []domain.ScheduleEntry
+	for _, id := range scheduleIDs {
+		schedule, err := u.scheduleRepo.FindByIDWithEntries(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if schedule == nil || schedule.OrganizationID != swap.OrganizationID {
+			return nil, sharedDomain.ErrNotFound
+		}
+		if schedule.Status != domain.StatusPublished {
+			return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "公開済みの勤務表のみ交換できます")
+		}
+		schedules = append(schedules, schedule)
+		entries = append(entries, schedule.Entries...)
+	}
+
+	changed, err := domain.ExchangeShifts(entries, swap.RequesterStaffID, swap.CounterpartStaffID, swap.Dates(), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	shiftTypeMap, err := u.buildShiftTypeMap(ctx, swap.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := u.ruleEngine.LoadRules(ctx, swap.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	qualifications, err := u.ruleEngine.LoadQualifications(ctx, swap.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &swapPlan{changed: changed, violations: make([]ViolationOutput, 0)}
+	for _, schedule := range schedules {
+		requests, err := u.ruleEngine.LoadRequests(ctx, schedule)
+		if err != nil {
+			return nil, err
+		}
+		before := u.ruleEngine.Evaluate(rules, &domain.ConstraintInput{
+			Schedule:       schedule,
+			Entries:        schedule.Entries,
+			ShiftTypes:     shiftTypeMap,
+			Qualifications: qualifications,
+			Requests:       requests,
+		})
+		after := u.ruleEngine.Evaluate(rules, &domain.ConstraintInput{
+			Schedule:       schedule,
+			Entries:        mergeEntries(schedule.Entries, changed),
+			ShiftTypes:     shiftTypeMap,
+			Qualifications: qualifications,
+			Requests:       requests,
+		})
+		plan.violations = append(plan.violations, diffViolations(after, before)...)
+	}
+
+	current := make(map[sharedDomain.ID]domain.ScheduleEntry, len(entries))
+	for _, e := range entries {
+		current[e.ID] = e
+	}
+	for _, e := range changed {
+		plan.originals = append(plan.originals, current[e.ID])
+		plan.changes = append(plan.changes, EntryChangeOutput{
+			StaffID:    e.StaffID.String(),
+			TargetDate: e.TargetDate.Format("2006-01-02"),
+			Before:     shiftTypeName(shiftTypeMap, current[e.ID].ShiftTypeID),
+			After:      shiftTypeName(shiftTypeMap, e.ShiftTypeID),
+		})
+	}
+	return plan, nil
+}
+
+// findPublishedEntry 公開済み勤務表から組織の有効スタッフの勤務日のエントリを取得
+func (u *ShiftSwapUseCase) findPublishedEntry(
+	ctx context.Context,
+	organizationID sharedDomain.ID,
+	activeStaffs map[sharedDomain.ID]*staffDomain.Staff,
+	staffID, date, label string,
+) (*domain.ScheduleEntry, error) {
+	sID, err := sharedDomain.ParseID(staffID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, label+"のスタッフIDが不正です")
+	}
+	targetDate, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, label+"の勤務日が不正です")
+	}
+	now := time.Now()
+	if targetDate.Before(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)) {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "過去の勤務は交換できません")
+	}
+
+	staff, ok := activeStaffs[sID]
+	if !ok {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, label+"のスタッフが見つかりません")
+	}
+
+	schedule, err := u.scheduleRepo.FindByTargetMonth(ctx, organizationID, targetDate.Year(), int(targetDate.Month()))
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil || schedule.Status != domain.StatusPublished {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, targetDate.Format("2006年1月")+"の勤務表は公開されていません")
+	}
+
+	entries, err := u.entryRepo.FindByScheduleAndStaff(ctx, schedule.ID, sID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		if entries[i].TargetDate.Format("2006-01-02") == date {
+			return &entries[i], nil
+		}
+	}
+	return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, targetDate.Format("1月2日")+"に"+staff.FullName()+"の勤務がありません")
+}
+
+// findSwap 組織の勤務交換申請を取得 他組織の申請は見つからない扱い
+func (u *ShiftSwapUseCase) findSwap(ctx context.Context, organizationID, id string) (*domain.ShiftSwap, error) {
+	orgID, err := sharedDomain.ParseID(organizationID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDが不正です")
+	}
+	swapID, err := sharedDomain.ParseID(id)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "勤務交換申請IDが不正です")
+	}
+
+	swap, err := u.swapRepo.FindByID(ctx, swapID)
+	if err != nil {
+		return nil, err
+	}
+	if swap == nil || swap.OrganizationID != orgID {
+		return nil, sharedDomain.ErrNotFound
+	}
+	return swap, nil
+}
+
+// findSwapForAction 操作対象の申請と操作ユーザーを取得
+func (u *ShiftSwapUseCase) findSwapForAction(ctx context.Context, input *ShiftSwapActionInput) (*domain.ShiftSwap, domain.SwapActor, error) {
+	if len([]rune(input.Comment)) > 500 {
+		return nil, domain.SwapActor{}, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "コメントは500文字以内で入力してください")
+	}
+	swap, err := u.findSwap(ctx, input.OrganizationID, input.SwapID)
+	if err != nil {
+		return nil, domain.SwapActor{}, err
+	}
+	staffs, err := u.staffRepo.FindActiveByOrganizationID(ctx, swap.OrganizationID)
+	if err != nil {
+		return nil, domain.SwapActor{}, err
+	}
+	actor, err := parseSwapActor(input.UserID, input.UserEmail, input.IsManager, staffs)
+	if err != nil {
+		return nil, actor, err
+	}
+	return swap, actor, nil
+}
+
+// buildOutput 出力を構築 planがある場合は変更内容と制約違反を含める
+func (u *ShiftSwapUseCase) buildOutput(ctx context.Context, swap *domain.ShiftSwap, plan *swapPlan) (*ShiftSwapOutput, error) {
+	names, err := u.newOutputNames(ctx, swap.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	output, err := names.output(ctx, swap)
+	if err != nil {
+		return nil, err
+	}
+	if plan != nil {
+		output.Changes = plan.changes
+		for i := range output.Changes {
+			output.Changes[i].StaffName = names.names[output.Changes[i].StaffID]
+		}
+		output.Violations = plan.violations
+		output.HasErrors = plan.hasErrors()
+	}
+	return output, nil
+}
+
+// buildShiftTypeMap 組織のシフト種別マップを構築
+func (u *ShiftSwapUseCase) buildShiftTypeMap(ctx context.Context, organizationID sharedDomain.ID) (map[string]*shiftDomain.ShiftType, error) {
+	shiftTypes, err := u.shiftTypeRepo.FindByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*shiftDomain.ShiftType)
+	for i := range shiftTypes {
+		result[shiftTypes[i].ID.String()] = &shiftTypes[i]
+	}
+	return result, nil
+}
+
+// swapOutputNames 出力用のスタッフ名・シフト名
+type swapOutputNames struct {
+	*staffNameLookup
+	shiftTypes map[string]*shiftDomain.ShiftType
+}
+
+// newOutputNames 組織の有効スタッフとシフト種別から出力用の名前を準備
+func (u *ShiftSwapUseCase) newOutputNames(ctx context.Context, organizationID sharedDomain.ID) (*swapOutputNames, error) {
+	lookup, err := newStaffNameLookup(ctx, u.staffRepo, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	shiftTypes, err := u.buildShiftTypeMap(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	return &swapOutputNames{staffNameLookup: lookup, shiftTypes: shiftTypes}, nil
+}
+
+// output 出力DTOへ変換 無効になったスタッフは個別に取得する
+func (n *swapOutputNames) output(ctx context.Context, swap *domain.ShiftSwap) (*ShiftSwapOutput, error) {
+	output := ToShiftSwapOutput(swap)
+	var err error
+	if output.RequesterStaffName, err = n.staffName(ctx, swap.RequesterStaffID); err != nil {
+		return nil, err
+	}
+	if output.CounterpartStaffName, err = n.staffName(ctx, swap.CounterpartStaffID); err != nil {
+		return nil, err
+	}
+	output.RequesterShiftName = shiftTypeName(n.shiftTypes, swap.RequesterShiftTypeID)
+	output.CounterpartShiftName = shiftTypeName(n.shiftTypes, swap.CounterpartShiftTypeID)
+	return output, nil
+}
+
+// parseSwapActor 操作ユーザーの変換 メールアドレスが一致する組織の有効スタッフを本人とする
+func parseSwapActor(userID, email string, isManager bool, staffs []staffDomain.Staff) (domain.SwapActor, error) {
+	id, err := sharedDomain.ParseID(userID)
+	if err != nil {
+		return domain.SwapActor{}, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "操作ユーザーが不正です")
+	}
+	actor := domain.SwapActor{UserID: id, Email: email, IsManager: isManager}
+	if email == "" {
+		return actor, nil
+	}
+	for i := range staffs {
+		if strings.EqualFold(staffs[i].Email, email) {
+			actor.StaffID = &staffs[i].ID
+			break
+		}
+	}
+	return actor, nil
+}
+
+// mergeEntries エントリ一覧のうちIDが一致するものを置き換え、一致しないものを追加した複製
+func mergeEntries(entries, changes []domain.ScheduleEntry) []domain.ScheduleEntry {
+	byID := make(map[sharedDomain.ID]domain.ScheduleEntry, len(changes))
+	for _, e := range changes {
+		byID[e.ID] = e
+	}
+	result := make([]domain.ScheduleEntry, 0, len(entries)+len(changes))
+	for _, e := range entries {
+		if changed, ok := byID[e.ID]; ok {
+			e = changed
+			delete(byID, e.ID)
+		}
+		result = append(result, e)
+	}
+	for _, e := range changes {
+		if _, ok := byID[e.ID]; ok {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// firstError 最初の重大度errorの違反 なければnil
+func firstError(violations []ViolationOutput) *ViolationOutput {
+	for i := range violations {
+		if violations[i].Severity == "error" {
+			return &violations[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,416 @@
+// Package application 勤務交換テスト
+package application
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"shiftmaster/internal/modules/schedule/domain"
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// モック勤務交換申請リポジトリ
+
+type mockShiftSwapRepository struct {
+	swaps   map[sharedDomain.ID]*domain.ShiftSwap
+	entries *mockScheduleEntryRepository
+	// beforeApply 反映直前に他の操作を割り込ませる
+	beforeApply func()
+}
+
+func (m *mockShiftSwapRepository) FindByID(_ context.Context, id sharedDomain.ID) (*domain.ShiftSwap, error) {
+	s, ok := m.swaps[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *s
+	copied.History = append([]domain.SwapEvent(nil), s.History...)
+	return &copied, nil
+}
+
+func (m *mockShiftSwapRepository) FindByOrganizationID(_ context.Context, organizationID sharedDomain.ID, status domain.SwapStatus) ([]domain.ShiftSwap, error) {
+	var result []domain.ShiftSwap
+	for _, s := range m.swaps {
+		if s.OrganizationID == organizationID && (status == "" || s.Status == status) {
+			result = append(result, *s)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockShiftSwapRepository) Save(_ context.Context, swap *domain.ShiftSwap) error {
+	m.swaps[swap.ID] = swap
+	return nil
+}
+
+func (m *mockShiftSwapRepository) Apply(ctx context.Context, swap *domain.ShiftSwap, originals, entries []domain.ScheduleEntry) error {
+	if m.beforeApply != nil {
+		m.beforeApply()
+	}
+	for _, o := range originals {
+		current, ok := m.entries.entries[o.ID]
+		if !ok || !sameID(current.ShiftTypeID, o.ShiftTypeID) || !current.UpdatedAt.Equal(o.UpdatedAt) {
+			return sharedDomain.NewDomainError(sharedDomain.ErrCodeConflict, "処理中に勤務表が変更されました")
+		}
+	}
+	m.swaps[swap.ID] = swap
+	return m.entries.SaveBatch(ctx, entries)
+}
+
+func sameID(a, b *sharedDomain.ID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// swapFixture 来年同月の公開済み勤務表と夜勤を持つテスト用ユースケース
+type swapFixture struct {
+	*scheduleFixture
+	useCase *ShiftSwapUseCase
+	swaps   *mockShiftSwapRepository
+	night   shiftDomain.ShiftType
+	userID  string
+}
+
+func newSwapFixture() *swapFixture {
+	f := &swapFixture{
+		scheduleFixture: newScheduleFixture(2),
+		userID:          sharedDomain.NewID().String(),
+	}
+	next := time.Now().AddDate(1, 0, 0)
+	f.schedule.TargetYear, f.schedule.TargetMonth = next.Year(), int(next.Month())
+	f.schedule.Status = domain.StatusPublished
+	f.swaps = &mockShiftSwapRepository{swaps: make(map[sharedDomain.ID]*domain.ShiftSwap), entries: f.entries}
+	f.staffs[0].Email = "staff@example.com"
+	f.staffs[1].Email = "colleague@example.com"
+
+	night, _ := time.Parse("15:04", "16:30")
+	morning, _ := time.Parse("15:04", "09:00")
+	f.night = shiftDomain.ShiftType{
+		ID: sharedDomain.NewID(), Name: "夜勤", Code: "N", StartTime: night, EndTime: morning,
+		BreakMinutes: 120, IsNightShift: true,
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	f.useCase = NewShiftSwapUseCase(
+		f.swaps,
+		&mockScheduleRepository{
+			schedules: map[sharedDomain.ID]*domain.Schedule{f.schedule.ID: f.schedule},
+			entries:   f.entries,
+		},
+		f.entries,
+		&mockShiftTypeRepository{shiftTypes: []shiftDomain.ShiftType{f.day, f.off, f.night}},
+		&mockStaffRepository{staffs: f.staffs},
+		f.rules,
+		nil,
+		nil,
+		logger,
+	)
+	return f
+}
+
+// date 勤務表の対象月の日付
+func (f *swapFixture) date(day int) time.Time {
+	return time.Date(f.schedule.TargetYear, time.Month(f.schedule.TargetMonth), day, 0, 0, 0, 0, time.UTC)
+}
+
+// request 1人目から2人目へ指定日同士の交換を申請
+func (f *swapFixture) request(requesterDay, counterpartDay int) (*ShiftSwapOutput, error) {
+	return f.useCase.Request(context.Background(), &RequestShiftSwapInput{
+		OrganizationID:     f.schedule.OrganizationID.String(),
+		RequesterStaffID:   f.staffs[0].ID.String(),
+		RequesterDate:      f.date(requesterDay).Format("2006-01-02"),
+		CounterpartStaffID: f.staffs[1].ID.String(),
+		CounterpartDate:    f.date(counterpartDay).Format("2006-01-02"),
+		Reason:             "私用のため",
+		UserID:             f.userID,
+		UserEmail:          "staff@example.com",
+	})
+}
+
+// action 管理者の操作入力
+func (f *swapFixture) action(swapID string) *ShiftSwapActionInput {
+	input := f.actionBy(swapID, "manager@example.com")
+	input.IsManager = true
+	return input
+}
+
+// actionBy メールアドレスで本人を判定するスタッフの操作入力
+func (f *swapFixture) actionBy(swapID, email string) *ShiftSwapActionInput {
+	return &ShiftSwapActionInput{
+		OrganizationID: f.schedule.OrganizationID.String(),
+		SwapID:         swapID,
+		UserID:         f.userID,
+		UserEmail:      email,
+	}
+}
+
+func TestShiftSwapUseCase_RequestAcceptApprove(t *testing.T) {
+	f := newSwapFixture()
+	ctx := context.Background()
+	a1 := f.addEntry(f.staffs[0].ID, f.date(1), f.day.ID, true)
+	b1 := f.addEntry(f.staffs[1].ID, f.date(1), f.off.ID, true)
+	a2 := f.addEntry(f.staffs[0].ID, f.date(2), f.off.ID, true)
+	b2 := f.addEntry(f.staffs[1].ID, f.date(2), f.day.ID, true)
+
+	requested, err := f.request(1, 2)
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	if !requested.IsPending {
+		t.Errorf("Status = %v, want pending", requested.Status)
+	}
+	if len(requested.Changes) != 4 {
+		t.Errorf("Changes = %d, want 4", len(requested.Changes))
+	}
+
+	if _, err := f.useCase.Approve(ctx, f.action(requested.ID)); err == nil {
+		t.Error("相手の承諾前に承認できています")
+	}
+
+	accepted, err := f.useCase.Accept(ctx, f.actionBy(requested.ID, "colleague@example.com"))
+	if err != nil {
+		t.Fatalf("Accept() error = %v", err)
+	}
+	if !accepted.IsAccepted {
+		t.Errorf("Status = %v, want accepted", accepted.Status)
+	}
+
+	approved, err := f.useCase.Approve(ctx, f.action(requested.ID))
+	if err != nil {
+		t.Fatalf("Approve() error = %v", err)
+	}
+	if approved.Status != domain.SwapStatusApproved.String() {
+		t.Errorf("Status = %v, want approved", approved.Status)
+	}
+	if len(approved.History) != 3 || approved.History[2].UserEmail != "manager@example.com" {
+		t.Errorf("History が不正です: %+v", approved.History)
+	}
+
+	want := map[*domain.ScheduleEntry]sharedDomain.ID{a1: f.off.ID, b1: f.day.ID, a2: f.day.ID, b2: f.off.ID}
+	for entry, shiftTypeID := range want {
+		if got := f.entries.entries[entry.ID].ShiftTypeID; got == nil || *got != shiftTypeID {
+			t.Errorf("%s のシフトが入れ替わっていません", entry.TargetDate.Format("2006-01-02"))
+		}
+	}
+
+	if _, err := f.useCase.Cancel(ctx, f.actionBy(requested.ID, "staff@example.com")); err == nil {
+		t.Error("承認済みの申請を取り下げできています")
+	}
+}
+
+func TestShiftSwapUseCase_ActorChecks(t *testing.T) {
+	f := newSwapFixture()
+	ctx := context.Background()
+	f.addEntry(f.staffs[0].ID, f.date(1), f.day.ID, false)
+	f.addEntry(f.staffs[1].ID, f.date(1), f.off.ID, false)
+	forbidden := func(t *testing.T, err error) {
+		t.Helper()
+		var domainErr *sharedDomain.DomainError
+		if !errors.As(err, &domainErr) || domainErr.Code != sharedDomain.ErrCodeForbidden {
+			t.Errorf("error = %v, want forbidden", err)
+		}
+	}
+
+	// 他のスタッフの勤務は本人でなければ申請できない
+	input := &RequestShiftSwapInput{
+		OrganizationID:     f.schedule.OrganizationID.String(),
+		RequesterStaffID:   f.staffs[0].ID.String(),
+		RequesterDate:      f.date(1).Format("2006-01-02"),
+		CounterpartStaffID: f.staffs[1].ID.String(),
+		CounterpartDate:    f.date(1).Format("2006-01-02"),
+		UserID:             f.userID,
+		UserEmail:          "colleague@example.com",
+	}
+	_, err := f.useCase.Request(ctx, input)
+	forbidden(t, err)
+
+	requested, err := f.request(1, 1)
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+
+	_, err = f.useCase.Accept(ctx, f.actionBy(requested.ID, "staff@example.com"))
+	forbidden(t, err)
+	_, err = f.useCase.Accept(ctx, f.action(requested.ID))
+	forbidden(t, err)
+	_, err = f.useCase.Decline(ctx, f.actionBy(requested.ID, "other@example.com"))
+	forbidden(t, err)
+	_, err = f.useCase.Cancel(ctx, f.actionBy(requested.ID, "colleague@example.com"))
+	forbidden(t, err)
+
+	cancelled, err := f.useCase.Cancel(ctx, f.actionBy(requested.ID, "STAFF@example.com"))
+	if err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	if cancelled.Status != domain.SwapStatusCancelled.String() {
+		t.Errorf("Status = %v, want cancelled", cancelled.Status)
+	}
+
+	// 管理者は申請者に代わって申請できる
+	input.UserEmail, input.IsManager = "manager@example.com", true
+	if _, err := f.useCase.Request(ctx, input); err != nil {
+		t.Errorf("管理者の代理申請 error = %v", err)
+	}
+}
+
+func TestShiftSwapUseCase_Accept_RuleViolation(t *testing.T) {
+	f := newSwapFixture()
+	// 1人目が3日連続夜勤になる交換は既定ルール（夜勤連続2日まで）に違反
+	f.addEntry(f.staffs[0].ID, f.date(1), f.night.ID, false)
+	f.addEntry(f.staffs[0].ID, f.date(2), f.night.ID, false)
+	f.addEntry(f.staffs[0].ID, f.date(3), f.off.ID, false)
+	f.addEntry(f.staffs[1].ID, f.date(3), f.night.ID, false)
+
+	requested, err := f.request(3, 3)
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	if !requested.HasErrors {
+		t.Error("交換後の制約違反が検出されていません")
+	}
+
+	_, err = f.useCase.Accept(context.Background(), f.actionBy(requested.ID, "colleague@example.com"))
+	var domainErr *sharedDomain.DomainError
+	if !errors.As(err, &domainErr) || domainErr.Code != sharedDomain.ErrCodeValidation {
+		t.Fatalf("Accept() error = %v, want validation error", err)
+	}
+
+	got, err := f.useCase.Get(context.Background(), f.schedule.OrganizationID.String(), requested.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if !got.IsPending {
+		t.Errorf("Status = %v, want pending", got.Status)
+	}
+}
+
+func TestShiftSwapUseCase_Approve_StaleEntry(t *testing.T) {
+	f := newSwapFixture()
+	ctx := context.Background()
+	f.addEntry(f.staffs[0].ID, f.date(1), f.day.ID, false)
+	counterpart := f.addEntry(f.staffs[1].ID, f.date(1), f.off.ID, false)
+
+	requested, err := f.request(1, 1)
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	if _, err := f.useCase.Accept(ctx, f.actionBy(requested.ID, "colleague@example.com")); err != nil {
+		t.Fatalf("Accept() error = %v", err)
+	}
+
+	// 承諾後に管理者が勤務表を修正
+	counterpart.ShiftTypeID = &f.day.ID
+
+	_, err = f.useCase.Approve(ctx, f.action(requested.ID))
+	var domainErr *sharedDomain.DomainError
+	if !errors.As(err, &domainErr) || domainErr.Code != sharedDomain.ErrCodeConflict {
+		t.Fatalf("Approve() error = %v, want conflict", err)
+	}
+
+	got, err := f.useCase.Get(ctx, f.schedule.OrganizationID.String(), requested.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Problem == "" {
+		t.Error("交換できない理由が表示されません")
+	}
+}
+
+func TestShiftSwapUseCase_Approve_ConcurrentEdit(t *testing.T) {
+	f := newSwapFixture()
+	ctx := context.Background()
+	requester := f.addEntry(f.staffs[0].ID, f.date(1), f.day.ID, false)
+	counterpart := f.addEntry(f.staffs[1].ID, f.date(1), f.off.ID, false)
+
+	requested, err := f.request(1, 1)
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	if _, err := f.useCase.Accept(ctx, f.actionBy(requested.ID, "colleague@example.com")); err != nil {
+		t.Fatalf("Accept() error = %v", err)
+	}
+
+	// 交換内容を求めた後、反映前に募集シフトの補充などでエントリが更新された
+	f.swaps.beforeApply = func() {
+		edited := *counterpart
+		edited.ShiftTypeID = &f.night.ID
+		edited.UpdatedAt = time.Now().Add(time.Second)
+		f.entries.entries[edited.ID] = &edited
+	}
+
+	_, err = f.useCase.Approve(ctx, f.action(requested.ID))
+	var domainErr *sharedDomain.DomainError
+	if !errors.As(err, &domainErr) || domainErr.Code != sharedDomain.ErrCodeConflict {
+		t.Fatalf("Approve() error = %v, want conflict", err)
+	}
+	if got := f.entries.entries[requester.ID].ShiftTypeID; *got != f.day.ID {
+		t.Error("競合したのに申請者のエントリが更新されています")
+	}
+	if got := f.entries.entries[counterpart.ID].ShiftTypeID; *got != f.night.ID {
+		t.Error("割り込んだ更新が上書きされています")
+	}
+}
+
+func TestShiftSwapUseCase_Request_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(f *swapFixture)
+		day      int
+		wantCode string
+	}{
+		{
+			name:     "勤務表が未公開",
+			setup:    func(f *swapFixture) { f.schedule.Status = domain.StatusDraft },
+			day:      1,
+			wantCode: sharedDomain.ErrCodeValidation,
+		},
+		{
+			name:     "エントリがない日",
+			setup:    func(_ *swapFixture) {},
+			day:      2,
+			wantCode: sharedDomain.ErrCodeValidation,
+		},
+		{
+			name: "同じ勤務を申請中",
+			setup: func(f *swapFixture) {
+				if _, err := f.request(1, 1); err != nil {
+					panic(err)
+				}
+			},
+			day:      1,
+			wantCode: sharedDomain.ErrCodeConflict,
+		},
+		{
+			name: "過去の勤務",
+			setup: func(f *swapFixture) {
+				f.schedule.TargetYear -= 2
+				f.addEntry(f.staffs[0].ID, f.date(1), f.day.ID, false)
+				f.addEntry(f.staffs[1].ID, f.date(1), f.off.ID, false)
+			},
+			day:      1,
+			wantCode: sharedDomain.ErrCodeValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newSwapFixture()
+			f.addEntry(f.staffs[0].ID, f.date(1), f.day.ID, false)
+			f.addEntry(f.staffs[1].ID, f.date(1), f.off.ID, false)
+			tt.setup(f)
+
+			_, err := f.request(tt.day, tt.day)
+			var domainErr *sharedDomain.DomainError
+			if !errors.As(err, &domainErr) || domainErr.Code != tt.wantCode {
+				t.Errorf("Request() error = %v, want %v", err, tt.wantCode)
+			}
+		})
+	}
+}
@@ -3,11 +3,10 @@ package application
 
 import (
 	"context"
-	"fmt"
 	"log/slog"
-	"sort"
 	"time"
 
+	requestDomain "shiftmaster/internal/modules/request/domain"
 	"shiftmaster/internal/modules/schedule/domain"
 	shiftDomain "shiftmaster/internal/modules/shift/domain"
 	staffDomain "shiftmaster/internal/modules/staff/domain"
@@ -18,27 +17,38 @@ import (
 type ScheduleUseCase struct {
 	scheduleRepo  domain.ScheduleRepository
 	entryRepo     domain.ScheduleEntryRepository
+	proposalRepo  domain.ScheduleProposalRepository
 	shiftTypeRepo shiftDomain.ShiftTypeRepository
 	staffRepo     staffDomain.StaffRepository
+	ruleEngine    *RuleEngine
 	optimizer     domain.ScheduleOptimizer
+	compliance    *OvertimeComplianceService
 	logger        *slog.Logger
 }
 
-// NewScheduleUseCase 勤務表ユースケース生成
+// NewScheduleUseCase 勤務表ユースケース生成 complianceがnilの場合は36協定を検証しない
 func NewScheduleUseCase(
 	scheduleRepo domain.ScheduleRepository,
 	entryRepo domain.ScheduleEntryRepository,
+	proposalRepo domain.ScheduleProposalRepository,
 	shiftTypeRepo shiftDomain.ShiftTypeRepository,
 	staffRepo staffDomain.StaffRepository,
+	ruleRepo shiftDomain.ShiftRuleRepository,
+	qualificationFinder domain.StaffQualificationFinder,
+	requestFinder domain.ShiftRequestFinder,
 	optimizer domain.ScheduleOptimizer,
+	compliance *OvertimeComplianceService,
 	logger *slog.Logger,
 ) *ScheduleUseCase {
 	return &ScheduleUseCase{
 		scheduleRepo:  scheduleRepo,
 		entryRepo:     entryRepo,
+		proposalRepo:  proposalRepo,
 		shiftTypeRepo: shiftTypeRepo,
 		staffRepo:     staffRepo,
+		ruleEngine:    NewRuleEngine(ruleRepo, qualificationFinder, requestFinder, logger),
 		optimizer:     optimizer,
+		compliance:    compliance,
 		logger:        logger,
 	}
 }
@@ -371,307 +381,92 @@ func (u *ScheduleUseCase) Validate(ctx context.Context, id string) (*ValidateRes
 		return nil, err
 	}
 
-	// 違反リストを収集
-	violations := make([]ViolationOutput, 0)
-
-	// スタッフごとにエントリをグルーピング
-	staffEntries := u.groupEntriesByStaff(schedule.Entries)
-
-	for staffID, entries := range staffEntries {
-		// 日付でソート
-		sort.Slice(entries, func(i, j int) bool {
-			return entries[i].TargetDate.Before(entries[j].TargetDate)
-		})
-
-		// 連続勤務チェック
-		consecutiveViolations := u.checkConsecutiveWorkDays(staffID, entries, shiftTypeMap)
-		violations = append(violations, consecutiveViolations...)
-
-		// 夜勤連続チェック
-		nightViolations := u.checkConsecutiveNightShifts(staffID, entries, shiftTypeMap)
-		violations = append(violations, nightViolations...)
-
-		// シフト間隔チェック
-		intervalViolations := u.checkShiftInterval(staffID, entries, shiftTypeMap)
-		violations = append(violations, intervalViolations...)
-
-		// 月間夜勤回数チェック
-		monthlyNightViolations := u.checkMonthlyNightShiftLimit(staffID, entries, shiftTypeMap)
-		violations = append(violations, monthlyNightViolations...)
-	}
-
-	// シフト未割り当てチェック
-	unassignedViolations := u.checkUnassignedShifts(schedule.Entries)
-	violations = append(violations, unassignedViolations...)
-
-	u.logger.Info("勤務表検証完了", "schedule_id", scheduleID, "violation_count", len(violations))
-
-	return &ValidateResult{
-		IsValid:    len(violations) == 0,
-		Violations: violations,
-	}, nil
-}
-
-// buildShiftTypeMap 組織のシフト種別マップを構築
-func (u *ScheduleUseCase) buildShiftTypeMap(ctx context.Context, organizationID sharedDomain.ID) (map[string]*shiftDomain.ShiftType, error) {
-	shiftTypes, err := u.shiftTypeRepo.FindByOrganizationID(ctx, organizationID)
+	// 有効なシフトルールを取得
+	rules, err := u.ruleEngine.LoadRules(ctx, schedule.OrganizationID)
 	if err != nil {
+		u.logger.Error("シフトルール取得失敗", "error", err)
 		return nil, err
 	}
 
-	result := make(map[string]*shiftDomain.ShiftType)
-	for i := range shiftTypes {
-		result[shiftTypes[i].ID.String()] = &shiftTypes[i]
+	// スキル・職種条件の評価に使うスタッフ資格情報を取得
+	qualifications, err := u.ruleEngine.LoadQualifications(ctx, schedule.OrganizationID)
+	if err != nil {
+		u.logger.Error("スタッフ資格情報取得失敗", "error", err)
+		return nil, err
 	}
-	return result, nil
-}
 
-// groupEntriesByStaff エントリをスタッフIDでグルーピング
-func (u *ScheduleUseCase) groupEntriesByStaff(entries []domain.ScheduleEntry) map[string][]domain.ScheduleEntry {
-	result := make(map[string][]domain.ScheduleEntry)
-	for _, entry := range entries {
-		staffID := entry.StaffID.String()
-		result[staffID] = append(result[staffID], entry)
+	// 同じ対象年月の勤務希望を取得
+	requests, err := u.ruleEngine.LoadRequests(ctx, schedule)
+	if err != nil {
+		u.logger.Error("勤務希望取得失敗", "error", err)
+		return nil, err
 	}
-	return result
-}
 
-// checkConsecutiveWorkDays 連続勤務日数チェック 7日以上連続で勤務している場合に警告
-func (u *ScheduleUseCase) checkConsecutiveWorkDays(
-	staffID string,
-	entries []domain.ScheduleEntry,
-	shiftTypeMap map[string]*shiftDomain.ShiftType,
-) []ViolationOutput {
-	const maxConsecutiveDays = 6
-	violations := make([]ViolationOutput, 0)
-
-	consecutiveCount := 0
-	var consecutiveStart time.Time
-
-	for i, entry := range entries {
-		// シフトが割り当てられているか確認
-		if entry.ShiftTypeID == nil {
-			consecutiveCount = 0
-			continue
-		}
+	violations := u.validateEntries(schedule, schedule.Entries, shiftTypeMap, rules, qualifications, requests)
 
-		// 休日シフトかどうか確認
-		shiftType, ok := shiftTypeMap[entry.ShiftTypeID.String()]
-		if !ok || shiftType.IsHoliday {
-			consecutiveCount = 0
-			continue
-		}
-
-		// 連続勤務のカウント
-		if consecutiveCount == 0 {
-			consecutiveStart = entry.TargetDate
-			consecutiveCount = 1
-		} else {
-			prevEntry := entries[i-1]
-			daysDiff := entry.TargetDate.Sub(prevEntry.TargetDate).Hours() / 24
-			if daysDiff == 1 {
-				consecutiveCount++
-			} else {
-				consecutiveStart = entry.TargetDate
-				consecutiveCount = 1
-			}
+	// 勤務予定を含めた36協定の上限超過見込み
+	if u.compliance != nil {
+		alerts, err := u.compliance.Check(ctx, schedule.OrganizationID, schedule.TargetYear, schedule.TargetMonth)
+		if err != nil {
+			u.logger.Error("36協定判定失敗", "error", err)
+			return nil, err
 		}
-
-		// 連続勤務日数が上限を超えた場合
-		if consecutiveCount > maxConsecutiveDays {
-			violations = append(violations, ViolationOutput{
-				Type:     "consecutive_work",
-				Message:  fmt.Sprintf("%d日連続勤務です（上限: %d日）", consecutiveCount, maxConsecutiveDays),
-				StaffID:  staffID,
-				Date:     consecutiveStart.Format("2006-01-02"),
-				Severity: "warning",
-			})
+		for i := range alerts {
+			violations = append(violations, ToViolationOutput(alerts[i].ToViolation()))
 		}
 	}
 
-	return violations
-}
-
-// checkConsecutiveNightShifts 連続夜勤チェック 3日以上連続で夜勤の場合に警告
-func (u *ScheduleUseCase) checkConsecutiveNightShifts(
-	staffID string,
-	entries []domain.ScheduleEntry,
-	shiftTypeMap map[string]*shiftDomain.ShiftType,
-) []ViolationOutput {
-	const maxConsecutiveNights = 2
-	violations := make([]ViolationOutput, 0)
-
-	consecutiveCount := 0
-	var consecutiveStart time.Time
-
-	for i, entry := range entries {
-		if entry.ShiftTypeID == nil {
-			consecutiveCount = 0
-			continue
-		}
-
-		shiftType, ok := shiftTypeMap[entry.ShiftTypeID.String()]
-		if !ok || !shiftType.IsNightShift {
-			consecutiveCount = 0
-			continue
-		}
-
-		// 連続夜勤のカウント
-		if consecutiveCount == 0 {
-			consecutiveStart = entry.TargetDate
-			consecutiveCount = 1
-		} else {
-			prevEntry := entries[i-1]
-			daysDiff := entry.TargetDate.Sub(prevEntry.TargetDate).Hours() / 24
-			if daysDiff == 1 {
-				consecutiveCount++
-			} else {
-				consecutiveStart = entry.TargetDate
-				consecutiveCount = 1
-			}
-		}
-
-		// 連続夜勤が上限を超えた場合
-		if consecutiveCount > maxConsecutiveNights {
-			violations = append(violations, ViolationOutput{
-				Type:     "consecutive_night",
-				Message:  fmt.Sprintf("%d日連続夜勤です（上限: %d日）", consecutiveCount, maxConsecutiveNights),
-				StaffID:  staffID,
-				Date:     consecutiveStart.Format("2006-01-02"),
-				Severity: "error",
-			})
-		}
+	staffNames, err := u.staffNames(ctx, schedule.OrganizationID)
+	if err != nil {
+		u.logger.Error("スタッフ一覧取得失敗", "error", err)
+		return nil, err
 	}
+	fulfillment := summarizeRequestFulfillment(domain.JudgeShiftRequests(&domain.ConstraintInput{
+		Schedule:   schedule,
+		Entries:    schedule.Entries,
+		ShiftTypes: shiftTypeMap,
+		Requests:   requests,
+	}), staffNames)
 
-	return violations
-}
-
-// checkShiftInterval シフト間隔チェック 夜勤明けの翌日が日勤の場合に警告
-func (u *ScheduleUseCase) checkShiftInterval(
-	staffID string,
-	entries []domain.ScheduleEntry,
-	shiftTypeMap map[string]*shiftDomain.ShiftType,
-) []ViolationOutput {
-	const minIntervalHours = 11 // 最小インターバル11時間
-	violations := make([]ViolationOutput, 0)
-
-	for i := 1; i < len(entries); i++ {
-		prevEntry := entries[i-1]
-		currEntry := entries[i]
-
-		// 両方ともシフトが割り当てられている必要がある
-		if prevEntry.ShiftTypeID == nil || currEntry.ShiftTypeID == nil {
-			continue
-		}
-
-		prevShift, prevOk := shiftTypeMap[prevEntry.ShiftTypeID.String()]
-		currShift, currOk := shiftTypeMap[currEntry.ShiftTypeID.String()]
-
-		if !prevOk || !currOk {
-			continue
-		}
-
-		// 休日シフトはスキップ
-		if prevShift.IsHoliday || currShift.IsHoliday {
-			continue
-		}
-
-		// 連続する日付かどうか確認
-		daysDiff := currEntry.TargetDate.Sub(prevEntry.TargetDate).Hours() / 24
-		if daysDiff != 1 {
-			continue
-		}
-
-		// 前のシフト終了時刻から次のシフト開始時刻までの間隔を計算
-		prevEndHour := prevShift.EndTime.Hour()
-		prevEndMin := prevShift.EndTime.Minute()
-		currStartHour := currShift.StartTime.Hour()
-		currStartMin := currShift.StartTime.Minute()
-
-		// 夜勤（日跨ぎ）の場合の調整
-		var intervalHours float64
-		if prevShift.IsNightShift {
-			// 夜勤の場合、終了時刻は翌日
-			// 24時間 - 前のシフト終了時刻 + 次のシフト開始時刻ではなく
-			// 次のシフト開始時刻 - 前のシフト終了時刻（翌日扱い）
-			intervalHours = float64(currStartHour) + float64(currStartMin)/60 - float64(prevEndHour) - float64(prevEndMin)/60
-			if intervalHours < 0 {
-				intervalHours += 24
-			}
-		} else {
-			// 日勤の場合
-			intervalHours = 24 - float64(prevEndHour) - float64(prevEndMin)/60 + float64(currStartHour) + float64(currStartMin)/60
-		}
-
-		if intervalHours < float64(minIntervalHours) {
-			violations = append(violations, ViolationOutput{
-				Type:     "shift_interval",
-				Message:  fmt.Sprintf("シフト間隔が%.1f時間です（最小: %d時間）", intervalHours, minIntervalHours),
-				StaffID:  staffID,
-				Date:     currEntry.TargetDate.Format("2006-01-02"),
-				Severity: "warning",
-			})
-		}
-	}
+	u.logger.Info("勤務表検証完了", "schedule_id", scheduleID, "violation_count", len(violations))
 
-	return violations
+	return &ValidateResult{
+		IsValid:            len(violations) == 0,
+		Violations:         violations,
+		RequestFulfillment: fulfillment,
+	}, nil
 }
 
-// checkMonthlyNightShiftLimit 月間夜勤回数チェック 8回以上の場合に警告
-func (u *ScheduleUseCase) checkMonthlyNightShiftLimit(
-	staffID string,
+// validateEntries エントリ一覧の制約違反を検出
+func (u *ScheduleUseCase) validateEntries(
+	schedule *domain.Schedule,
 	entries []domain.ScheduleEntry,
 	shiftTypeMap map[string]*shiftDomain.ShiftType,
+	rules []shiftDomain.ShiftRule,
+	qualifications *domain.StaffQualifications,
+	requests []requestDomain.ShiftRequest,
 ) []ViolationOutput {
-	const maxMonthlyNights = 8
-	violations := make([]ViolationOutput, 0)
-
-	nightCount := 0
-	for _, entry := range entries {
-		if entry.ShiftTypeID == nil {
-			continue
-		}
-
-		shiftType, ok := shiftTypeMap[entry.ShiftTypeID.String()]
-		if !ok {
-			continue
-		}
-
-		if shiftType.IsNightShift {
-			nightCount++
-		}
-	}
-
-	if nightCount > maxMonthlyNights {
-		violations = append(violations, ViolationOutput{
-			Type:     "monthly_night_limit",
-			Message:  fmt.Sprintf("月間夜勤回数が%d回です（上限: %d回）", nightCount, maxMonthlyNights),
-			StaffID:  staffID,
-			Date:     "",
-			Severity: "warning",
-		})
-	}
-
-	return violations
+	return u.ruleEngine.Evaluate(rules, &domain.ConstraintInput{
+		Schedule:       schedule,
+		Entries:        entries,
+		ShiftTypes:     shiftTypeMap,
+		Qualifications: qualifications,
+		Requests:       requests,
+	})
 }
 
-// checkUnassignedShifts シフト未割り当てチェック
-func (u *ScheduleUseCase) checkUnassignedShifts(entries []domain.ScheduleEntry) []ViolationOutput {
-	violations := make([]ViolationOutput, 0)
-
-	for _, entry := range entries {
-		if entry.ShiftTypeID == nil && !entry.IsConfirmed {
-			violations = append(violations, ViolationOutput{
-				Type:     "unassigned_shift",
-				Message:  "シフトが未割り当てです",
-				StaffID:  entry.StaffID.String(),
-				Date:     entry.TargetDate.Format("2006-01-02"),
-				Severity: "info",
-			})
-		}
+// buildShiftTypeMap 組織のシフト種別マップを構築
+func (u *ScheduleUseCase) buildShiftTypeMap(ctx context.Context, organizationID sharedDomain.ID) (map[string]*shiftDomain.ShiftType, error) {
+	shiftTypes, err := u.shiftTypeRepo.FindByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, err
 	}
 
-	return violations
+	result := make(map[string]*shiftDomain.ShiftType)
+	for i := range shiftTypes {
+		result[shiftTypes[i].ID.String()] = &shiftTypes[i]
+	}
+	return result, nil
 }
 
 // Delete 勤務表削除
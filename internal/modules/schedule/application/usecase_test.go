@@ -0,0 +1,692 @@
+// Package application 勤務表ユースケーステスト
+package application
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	requestDomain "shiftmaster/internal/modules/request/domain"
+	"shiftmaster/internal/modules/schedule/domain"
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
+	staffDomain "shiftmaster/internal/modules/staff/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+	"shiftmaster/internal/shared/infrastructure"
+)
+
+// モック勤務表リポジトリ
+
+type mockScheduleRepository struct {
+	schedules map[sharedDomain.ID]*domain.Schedule
+	entries   *mockScheduleEntryRepository
+}
+
+func (m *mockScheduleRepository) FindByID(_ context.Context, id sharedDomain.ID) (*domain.Schedule, error) {
+	return m.schedules[id], nil
+}
+
+func (m *mockScheduleRepository) FindByIDWithEntries(_ context.Context, id sharedDomain.ID) (*domain.Schedule, error) {
+	s, ok := m.schedules[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *s
+	copied.Entries = nil
+	for _, e := range m.entries.entries {
+		if e.ScheduleID == id {
+			copied.Entries = append(copied.Entries, *e)
+		}
+	}
+	return &copied, nil
+}
+
+func (m *mockScheduleRepository) FindByOrganizationID(_ context.Context, organizationID sharedDomain.ID) ([]domain.Schedule, error) {
+	var schedules []domain.Schedule
+	for _, s := range m.schedules {
+		if s.OrganizationID == organizationID {
+			schedules = append(schedules, *s)
+		}
+	}
+	return schedules, nil
+}
+
+func (m *mockScheduleRepository) FindByTargetMonth(_ context.Context, organizationID sharedDomain.ID, year, month int) (*domain.Schedule, error) {
+	for _, s := range m.schedules {
+		if s.OrganizationID == organizationID && s.TargetYear == year && s.TargetMonth == month {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockScheduleRepository) Save(_ context.Context, schedule *domain.Schedule) error {
+	m.schedules[schedule.ID] = schedule
+	return nil
+}
+
+func (m *mockScheduleRepository) Delete(_ context.Context, id sharedDomain.ID) error {
+	delete(m.schedules, id)
+	return nil
+}
+
+// モック勤務表エントリリポジトリ
+
+type mockScheduleEntryRepository struct {
+	entries map[sharedDomain.ID]*domain.ScheduleEntry
+}
+
+func (m *mockScheduleEntryRepository) FindByID(_ context.Context, id sharedDomain.ID) (*domain.ScheduleEntry, error) {
+	return m.entries[id], nil
+}
+
+func (m *mockScheduleEntryRepository) FindByScheduleID(_ context.Context, scheduleID sharedDomain.ID) ([]domain.ScheduleEntry, error) {
+	var entries []domain.ScheduleEntry
+	for _, e := range m.entries {
+		if e.ScheduleID == scheduleID {
+			entries = append(entries, *e)
+		}
+	}
+	return entries, nil
+}
+
+func (m *mockScheduleEntryRepository) FindByScheduleAndStaff(_ context.Context, scheduleID, staffID sharedDomain.ID) ([]domain.ScheduleEntry, error) {
+	var entries []domain.ScheduleEntry
+	for _, e := range m.entries {
+		if e.ScheduleID == scheduleID && e.StaffID == staffID {
+			entries = append(entries, *e)
+		}
+	}
+	return entries, nil
+}
+
+func (m *mockScheduleEntryRepository) FindByScheduleAndDate(_ context.Context, _ sharedDomain.ID, _ time.Time) ([]domain.ScheduleEntry, error) {
+	return nil, nil
+}
+
+func (m *mockScheduleEntryRepository) Save(_ context.Context, entry *domain.ScheduleEntry) error {
+	m.entries[entry.ID] = entry
+	return nil
+}
+
+func (m *mockScheduleEntryRepository) SaveBatch(_ context.Context, entries []domain.ScheduleEntry) error {
+	for i := range entries {
+		e := entries[i]
+		m.entries[e.ID] = &e
+	}
+	return nil
+}
+
+func (m *mockScheduleEntryRepository) Delete(_ context.Context, id sharedDomain.ID) error {
+	delete(m.entries, id)
+	return nil
+}
+
+func (m *mockScheduleEntryRepository) DeleteBySchedule(_ context.Context, _ sharedDomain.ID) error {
+	return nil
+}
+
+// モック自動作成案リポジトリ
+
+type mockProposalRepository struct {
+	proposals map[sharedDomain.ID]*domain.ScheduleProposal
+	entries   *mockScheduleEntryRepository
+	// beforeApply 適用の直前に呼ばれる 同時更新の再現用
+	beforeApply func()
+}
+
+func (m *mockProposalRepository) FindByID(_ context.Context, id sharedDomain.ID) (*domain.ScheduleProposal, error) {
+	return m.proposals[id], nil
+}
+
+func (m *mockProposalRepository) FindByScheduleID(_ context.Context, scheduleID sharedDomain.ID) ([]domain.ScheduleProposal, error) {
+	var result []domain.ScheduleProposal
+	for _, p := range m.proposals {
+		if p.ScheduleID == scheduleID {
+			result = append(result, *p)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockProposalRepository) Save(_ context.Context, proposal *domain.ScheduleProposal) error {
+	m.proposals[proposal.ID] = proposal
+	return nil
+}
+
+func (m *mockProposalRepository) Apply(ctx context.Context, proposal *domain.ScheduleProposal, originals, entries []domain.ScheduleEntry) error {
+	if m.beforeApply != nil {
+		m.beforeApply()
+	}
+	for _, o := range originals {
+		current := m.entries.entries[o.ID]
+		if current == nil || current.IsConfirmed != o.IsConfirmed || !current.UpdatedAt.Equal(o.UpdatedAt) {
+			return sharedDomain.NewDomainError(sharedDomain.ErrCodeConflict, "処理中に勤務表が変更されました。もう一度やり直してください")
+		}
+	}
+	m.proposals[proposal.ID] = proposal
+	return m.entries.SaveBatch(ctx, entries)
+}
+
+func (m *mockProposalRepository) Delete(_ context.Context, id sharedDomain.ID) error {
+	delete(m.proposals, id)
+	return nil
+}
+
+// モックシフト種別リポジトリ
+
+type mockShiftTypeRepository struct {
+	shiftTypes []shiftDomain.ShiftType
+}
+
+func (m *mockShiftTypeRepository) FindByID(_ context.Context, id sharedDomain.ID) (*shiftDomain.ShiftType, error) {
+	for i := range m.shiftTypes {
+		if m.shiftTypes[i].ID == id {
+			return &m.shiftTypes[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockShiftTypeRepository) FindAll(_ context.Context) ([]shiftDomain.ShiftType, error) {
+	return m.shiftTypes, nil
+}
+
+func (m *mockShiftTypeRepository) FindByOrganizationID(_ context.Context, _ sharedDomain.ID) ([]shiftDomain.ShiftType, error) {
+	return m.shiftTypes, nil
+}
+
+func (m *mockShiftTypeRepository) FindWorkShifts(_ context.Context, _ sharedDomain.ID) ([]shiftDomain.ShiftType, error) {
+	return nil, nil
+}
+
+func (m *mockShiftTypeRepository) Save(_ context.Context, _ *shiftDomain.ShiftType) error {
+	return nil
+}
+
+func (m *mockShiftTypeRepository) Delete(_ context.Context, _ sharedDomain.ID) error {
+	return nil
+}
+
+// モックシフトルールリポジトリ
+
+type mockShiftRuleRepository struct {
+	rules []shiftDomain.ShiftRule
+}
+
+func (m *mockShiftRuleRepository) FindByID(_ context.Context, id sharedDomain.ID) (*shiftDomain.ShiftRule, error) {
+	for i := range m.rules {
+		if m.rules[i].ID == id {
+			return &m.rules[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockShiftRuleRepository) FindAll(_ context.Context) ([]shiftDomain.ShiftRule, error) {
+	return m.rules, nil
+}
+
+func (m *mockShiftRuleRepository) FindByOrganizationID(_ context.Context, _ sharedDomain.ID) ([]shiftDomain.ShiftRule, error) {
+	return m.rules, nil
+}
+
+func (m *mockShiftRuleRepository) FindActiveByOrganizationID(_ context.Context, _ sharedDomain.ID) ([]shiftDomain.ShiftRule, error) {
+	var result []shiftDomain.ShiftRule
+	for _, r := range m.rules {
+		if r.IsActive {
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockShiftRuleRepository) Save(_ context.Context, rule *shiftDomain.ShiftRule) error {
+	m.rules = append(m.rules, *rule)
+	return nil
+}
+
+func (m *mockShiftRuleRepository) Delete(_ context.Context, _ sharedDomain.ID) error {
+	return nil
+}
+
+// モックスタッフリポジトリ
+
+type mockStaffRepository struct {
+	staffs []staffDomain.Staff
+}
+
+func (m *mockStaffRepository) FindByID(_ context.Context, id sharedDomain.ID) (*staffDomain.Staff, error) {
+	for i := range m.staffs {
+		if m.staffs[i].ID == id {
+			return &m.staffs[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockStaffRepository) FindAll(_ context.Context, _ infrastructure.Pagination) ([]staffDomain.Staff, int, error) {
+	return m.staffs, len(m.staffs), nil
+}
+
+func (m *mockStaffRepository) FindByOrganizationID(_ context.Context, _ sharedDomain.ID, _ infrastructure.Pagination) ([]staffDomain.Staff, int, error) {
+	return m.staffs, len(m.staffs), nil
+}
+
+func (m *mockStaffRepository) FindByTeamID(_ context.Context, _ sharedDomain.ID) ([]staffDomain.Staff, error) {
+	return nil, nil
+}
+
+func (m *mockStaffRepository) FindActive(_ context.Context) ([]staffDomain.Staff, error) {
+	return m.staffs, nil
+}
+
+func (m *mockStaffRepository) FindActiveByOrganizationID(_ context.Context, _ sharedDomain.ID) ([]staffDomain.Staff, error) {
+	return m.staffs, nil
+}
+
+func (m *mockStaffRepository) Save(_ context.Context, _ *staffDomain.Staff) error {
+	return nil
+}
+
+func (m *mockStaffRepository) Delete(_ context.Context, _ sharedDomain.ID) error {
+	return nil
+}
+
+// モック勤務希望取得
+
+type mockShiftRequestFinder struct {
+	requests []requestDomain.ShiftRequest
+}
+
+func (m *mockShiftRequestFinder) FindByTargetMonth(_ context.Context, _ sharedDomain.ID, _, _ int) ([]requestDomain.ShiftRequest, error) {
+	return m.requests, nil
+}
+
+// scheduleFixture テスト用ユースケースと勤務表
+type scheduleFixture struct {
+	useCase   *ScheduleUseCase
+	schedule  *domain.Schedule
+	entries   *mockScheduleEntryRepository
+	proposals *mockProposalRepository
+	rules     *mockShiftRuleRepository
+	requests  *mockShiftRequestFinder
+	staffs    []staffDomain.Staff
+	day       shiftDomain.ShiftType
+	off       shiftDomain.ShiftType
+}
+
+func newScheduleFixture(staffCount int) *scheduleFixture {
+	clock := func(s string) time.Time {
+		t, _ := time.Parse("15:04", s)
+		return t
+	}
+
+	f := &scheduleFixture{
+		schedule: &domain.Schedule{
+			ID:             sharedDomain.NewID(),
+			OrganizationID: sharedDomain.NewID(),
+			TargetYear:     2025,
+			TargetMonth:    2,
+			Status:         domain.StatusDraft,
+		},
+		entries:  &mockScheduleEntryRepository{entries: make(map[sharedDomain.ID]*domain.ScheduleEntry)},
+		rules:    &mockShiftRuleRepository{},
+		requests: &mockShiftRequestFinder{},
+		day:      shiftDomain.ShiftType{ID: sharedDomain.NewID(), Name: "日勤", Code: "D", StartTime: clock("08:30"), EndTime: clock("17:30"), SortOrder: 1},
+		off:      shiftDomain.ShiftType{ID: sharedDomain.NewID(), Name: "公休", Code: "O", IsHoliday: true, SortOrder: 2},
+	}
+	f.proposals = &mockProposalRepository{proposals: make(map[sharedDomain.ID]*domain.ScheduleProposal), entries: f.entries}
+	for i := 0; i < staffCount; i++ {
+		f.staffs = append(f.staffs, staffDomain.Staff{ID: sharedDomain.NewID(), LastName: "山田", FirstName: "花子", IsActive: true})
+	}
+
+	scheduleRepo := &mockScheduleRepository{
+		schedules: map[sharedDomain.ID]*domain.Schedule{f.schedule.ID: f.schedule},
+		entries:   f.entries,
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	f.useCase = NewScheduleUseCase(
+		scheduleRepo,
+		f.entries,
+		f.proposals,
+		&mockShiftTypeRepository{shiftTypes: []shiftDomain.ShiftType{f.day, f.off}},
+		&mockStaffRepository{staffs: f.staffs},
+		f.rules,
+		nil,
+		f.requests,
+		nil,
+		nil,
+		logger,
+	)
+	return f
+}
+
+func (f *scheduleFixture) addEntry(staffID sharedDomain.ID, date time.Time, shiftTypeID sharedDomain.ID, confirmed bool) *domain.ScheduleEntry {
+	entry := &domain.ScheduleEntry{
+		ID:          sharedDomain.NewID(),
+		ScheduleID:  f.schedule.ID,
+		StaffID:     staffID,
+		TargetDate:  date,
+		ShiftTypeID: &shiftTypeID,
+		IsConfirmed: confirmed,
+	}
+	f.entries.entries[entry.ID] = entry
+	return entry
+}
+
+func TestScheduleUseCase_Generate(t *testing.T) {
+	f := newScheduleFixture(3)
+	confirmed := f.addEntry(f.staffs[0].ID, time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC), f.off.ID, true)
+
+	output, err := f.useCase.Generate(context.Background(), &GenerateScheduleInput{ScheduleID: f.schedule.ID.String()})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if output.Generator != domain.GeneratorAutoFill.String() {
+		t.Errorf("Generator = %v, want %v", output.Generator, domain.GeneratorAutoFill)
+	}
+	if !output.IsPending {
+		t.Error("作成案が承認待ちになっていません")
+	}
+	if len(output.Entries) != 3*28 {
+		t.Errorf("Entries = %d, want %d", len(output.Entries), 3*28)
+	}
+	if output.Comparison == nil || output.Comparison.ChangedCount != 3*28-1 {
+		t.Errorf("ChangedCount が不正です: %+v", output.Comparison)
+	}
+	if len(f.proposals.proposals) != 1 {
+		t.Errorf("保存された作成案 = %d, want 1", len(f.proposals.proposals))
+	}
+
+	for _, e := range output.Entries {
+		if e.StaffID == confirmed.StaffID.String() && e.TargetDate == "2025-02-03" && e.ID != confirmed.ID.String() {
+			t.Error("確定済みエントリが置き換えられています")
+		}
+	}
+}
+
+func TestScheduleUseCase_Generate_PublishedSchedule(t *testing.T) {
+	f := newScheduleFixture(1)
+	f.schedule.Status = domain.StatusPublished
+
+	_, err := f.useCase.Generate(context.Background(), &GenerateScheduleInput{ScheduleID: f.schedule.ID.String()})
+	var domainErr *sharedDomain.DomainError
+	if !errors.As(err, &domainErr) || domainErr.Code != sharedDomain.ErrCodeValidation {
+		t.Errorf("Generate() error = %v, want validation error", err)
+	}
+}
+
+func TestScheduleUseCase_ApplyProposal(t *testing.T) {
+	f := newScheduleFixture(2)
+	date := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+	existing := f.addEntry(f.staffs[0].ID, date, f.off.ID, false)
+	confirmed := f.addEntry(f.staffs[1].ID, date, f.off.ID, true)
+
+	generated, err := f.useCase.Generate(context.Background(), &GenerateScheduleInput{ScheduleID: f.schedule.ID.String()})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if _, err := f.useCase.ApplyProposal(context.Background(), f.schedule.ID.String(), generated.ID); err != nil {
+		t.Fatalf("ApplyProposal() error = %v", err)
+	}
+
+	if len(f.entries.entries) != 2*28 {
+		t.Errorf("エントリ数 = %d, want %d 既存エントリのIDが引き継がれていません", len(f.entries.entries), 2*28)
+	}
+	if e := f.entries.entries[existing.ID]; e == nil || e.ScheduleID != f.schedule.ID {
+		t.Error("既存エントリが更新されていません")
+	}
+	if e := f.entries.entries[confirmed.ID]; e == nil || !e.IsConfirmed || *e.ShiftTypeID != f.off.ID {
+		t.Error("確定済みエントリが変更されています")
+	}
+
+	proposalID, _ := sharedDomain.ParseID(generated.ID)
+	if p := f.proposals.proposals[proposalID]; p.Status != domain.ProposalStatusApplied || p.AppliedAt == nil {
+		t.Errorf("作成案の状態 = %v, want %v", p.Status, domain.ProposalStatusApplied)
+	}
+
+	// 適用済みの作成案は再適用できない
+	_, err = f.useCase.ApplyProposal(context.Background(), f.schedule.ID.String(), generated.ID)
+	var domainErr *sharedDomain.DomainError
+	if !errors.As(err, &domainErr) || domainErr.Code != sharedDomain.ErrCodeConflict {
+		t.Errorf("再適用 error = %v, want conflict", err)
+	}
+}
+
+func TestScheduleUseCase_ApplyProposalConflict(t *testing.T) {
+	f := newScheduleFixture(1)
+	date := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+	existing := f.addEntry(f.staffs[0].ID, date, f.off.ID, false)
+
+	generated, err := f.useCase.Generate(context.Background(), &GenerateScheduleInput{ScheduleID: f.schedule.ID.String()})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// 読み込み後・書き込み前にエントリが確定された
+	f.proposals.beforeApply = func() {
+		f.entries.entries[existing.ID].IsConfirmed = true
+		f.entries.entries[existing.ID].UpdatedAt = time.Now()
+	}
+	_, err = f.useCase.ApplyProposal(context.Background(), f.schedule.ID.String(), generated.ID)
+	var domainErr *sharedDomain.DomainError
+	if !errors.As(err, &domainErr) || domainErr.Code != sharedDomain.ErrCodeConflict {
+		t.Fatalf("ApplyProposal() error = %v, want conflict", err)
+	}
+	if e := f.entries.entries[existing.ID]; !e.IsConfirmed || *e.ShiftTypeID != f.off.ID {
+		t.Error("確定されたエントリが上書きされています")
+	}
+	if len(f.entries.entries) != 1 {
+		t.Errorf("エントリ数 = %d, want 1 競合時は何も保存しない", len(f.entries.entries))
+	}
+}
+
+func TestScheduleUseCase_DiscardProposal(t *testing.T) {
+	f := newScheduleFixture(1)
+
+	generated, err := f.useCase.Generate(context.Background(), &GenerateScheduleInput{ScheduleID: f.schedule.ID.String()})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if err := f.useCase.DiscardProposal(context.Background(), f.schedule.ID.String(), generated.ID); err != nil {
+		t.Fatalf("DiscardProposal() error = %v", err)
+	}
+	if len(f.entries.entries) != 0 {
+		t.Error("破棄した作成案がエントリに反映されています")
+	}
+
+	// 別の勤務表IDでは取得できない
+	_, err = f.useCase.GetProposal(context.Background(), sharedDomain.NewID().String(), generated.ID)
+	if !errors.Is(err, sharedDomain.ErrNotFound) {
+		t.Errorf("GetProposal() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestDiffViolations(t *testing.T) {
+	current := []ViolationOutput{
+		{Type: "consecutive_work", StaffID: "a", Date: "2025-02-01"},
+		{Type: "unassigned_shift", StaffID: "b", Date: "2025-02-02"},
+	}
+	proposed := []ViolationOutput{
+		{Type: "unassigned_shift", StaffID: "b", Date: "2025-02-02"},
+		{Type: "shift_interval", StaffID: "c", Date: "2025-02-03"},
+	}
+
+	resolved := diffViolations(current, proposed)
+	if len(resolved) != 1 || resolved[0].Type != "consecutive_work" {
+		t.Errorf("resolved = %+v", resolved)
+	}
+	introduced := diffViolations(proposed, current)
+	if len(introduced) != 1 || introduced[0].Type != "shift_interval" {
+		t.Errorf("introduced = %+v", introduced)
+	}
+}
+
+func TestScheduleUseCase_Validate_UsesShiftRules(t *testing.T) {
+	countType := func(violations []ViolationOutput, violationType string) int {
+		count := 0
+		for _, v := range violations {
+			if v.Type == violationType {
+				count++
+			}
+		}
+		return count
+	}
+
+	tests := []struct {
+		name      string
+		rules     []shiftDomain.ShiftRule
+		workDays  int
+		wantType  string
+		wantCount int
+	}{
+		{
+			name:      "ルール未設定時は既定の6日上限",
+			workDays:  7,
+			wantType:  "consecutive_work",
+			wantCount: 1,
+		},
+		{
+			name:      "既定の上限以内",
+			workDays:  6,
+			wantType:  "consecutive_work",
+			wantCount: 0,
+		},
+		{
+			name: "組織ルールで上限を緩和",
+			rules: []shiftDomain.ShiftRule{
+				{Name: "連続勤務上限", RuleType: shiftDomain.RuleTypeConsecutive, IsActive: true, Config: `{"max_days":8}`},
+			},
+			workDays:  7,
+			wantType:  "consecutive_work",
+			wantCount: 0,
+		},
+		{
+			name: "無効なルールは既定値で補完",
+			rules: []shiftDomain.ShiftRule{
+				{Name: "連続勤務上限", RuleType: shiftDomain.RuleTypeConsecutive, IsActive: false, Config: `{"max_days":8}`},
+			},
+			workDays:  7,
+			wantType:  "consecutive_work",
+			wantCount: 1,
+		},
+		{
+			name: "最小配置人数",
+			rules: []shiftDomain.ShiftRule{
+				{Name: "日勤2名", RuleType: shiftDomain.RuleTypeMinStaff, IsActive: true, Config: `{"min_count":2}`},
+			},
+			workDays:  3,
+			wantType:  "coverage_shortage",
+			wantCount: 28,
+		},
+		{
+			name: "月間労働時間上限",
+			rules: []shiftDomain.ShiftRule{
+				{Name: "月間上限", RuleType: shiftDomain.RuleTypeMonthlyHours, IsActive: true, Config: `{"max_hours":40}`},
+			},
+			workDays:  6,
+			wantType:  "monthly_hours",
+			wantCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newScheduleFixture(1)
+			f.rules.rules = tt.rules
+			for d := 0; d < tt.workDays; d++ {
+				f.addEntry(f.staffs[0].ID, time.Date(2025, 2, 3+d, 0, 0, 0, 0, time.UTC), f.day.ID, false)
+			}
+
+			result, err := f.useCase.Validate(context.Background(), f.schedule.ID.String())
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if got := countType(result.Violations, tt.wantType); got != tt.wantCount {
+				t.Errorf("%s = %d, want %d: %+v", tt.wantType, got, tt.wantCount, result.Violations)
+			}
+		})
+	}
+}
+
+func TestScheduleUseCase_Validate_ShiftRequests(t *testing.T) {
+	f := newScheduleFixture(2)
+	night := shiftDomain.ShiftType{ID: sharedDomain.NewID(), Name: "夜勤", Code: "N", IsNightShift: true}
+	f.useCase.shiftTypeRepo = &mockShiftTypeRepository{shiftTypes: []shiftDomain.ShiftType{f.day, f.off, night}}
+	date := func(day int) time.Time { return time.Date(2025, 2, day, 0, 0, 0, 0, time.UTC) }
+	request := func(staff int, day int, shiftTypeID *sharedDomain.ID, requestType requestDomain.RequestType, priority requestDomain.RequestPriority) requestDomain.ShiftRequest {
+		return requestDomain.ShiftRequest{
+			ID:          sharedDomain.NewID(),
+			StaffID:     f.staffs[staff].ID,
+			TargetDate:  date(day),
+			ShiftTypeID: shiftTypeID,
+			RequestType: requestType,
+			Priority:    priority,
+		}
+	}
+
+	f.addEntry(f.staffs[0].ID, date(3), f.day.ID, false)
+	f.addEntry(f.staffs[0].ID, date(4), f.day.ID, false)
+	f.addEntry(f.staffs[0].ID, date(5), night.ID, false)
+	f.addEntry(f.staffs[1].ID, date(3), f.off.ID, false)
+
+	f.requests.requests = []requestDomain.ShiftRequest{
+		// 反映済み: 日勤希望・シフト指定なしの勤務希望・夜勤回避・休み希望（エントリなし）
+		request(0, 3, &f.day.ID, requestDomain.RequestTypePreferred, requestDomain.PriorityOptional),
+		request(0, 4, nil, requestDomain.RequestTypePreferred, requestDomain.PriorityRequired),
+		request(1, 3, &night.ID, requestDomain.RequestTypeAvoided, requestDomain.PriorityRequired),
+		request(1, 6, &f.off.ID, requestDomain.RequestTypeFixed, requestDomain.PriorityOptional),
+		// 未反映: 夜勤回避（必須）・休み固定・日勤希望（できれば）
+		request(0, 5, &night.ID, requestDomain.RequestTypeAvoided, requestDomain.PriorityRequired),
+		request(0, 4, &f.off.ID, requestDomain.RequestTypeFixed, requestDomain.PriorityOptional),
+		request(1, 3, &f.day.ID, requestDomain.RequestTypePreferred, requestDomain.PriorityOptional),
+		// 対象月外は評価しない
+		{ID: sharedDomain.NewID(), StaffID: f.staffs[1].ID, TargetDate: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), ShiftTypeID: &f.day.ID, RequestType: requestDomain.RequestTypePreferred, Priority: requestDomain.PriorityRequired},
+	}
+
+	result, err := f.useCase.Validate(context.Background(), f.schedule.ID.String())
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	severities := make(map[string]int)
+	for _, v := range result.Violations {
+		if v.Type == "request_unmet" {
+			severities[v.Severity]++
+		}
+	}
+	if severities["error"] != 2 || severities["warning"] != 1 {
+		t.Errorf("request_unmet severities = %v, want error:2 warning:1: %+v", severities, result.Violations)
+	}
+
+	if len(result.RequestFulfillment) != 2 {
+		t.Fatalf("RequestFulfillment = %+v, want 2 staffs", result.RequestFulfillment)
+	}
+	want := map[string][2]int{
+		f.staffs[0].ID.String(): {2, 4},
+		f.staffs[1].ID.String(): {2, 3},
+	}
+	for _, got := range result.RequestFulfillment {
+		w := want[got.StaffID]
+		if got.Fulfilled != w[0] || got.Total != w[1] {
+			t.Errorf("%s: Fulfilled/Total = %d/%d, want %d/%d", got.StaffID, got.Fulfilled, got.Total, w[0], w[1])
+		}
+		if wantRate := float64(w[0]) * 100 / float64(w[1]); got.Rate != wantRate {
+			t.Errorf("%s: Rate = %v, want %v", got.StaffID, got.Rate, wantRate)
+		}
+	}
+
+	fulfillment, err := f.useCase.GetRequestFulfillment(context.Background(), f.schedule.ID.String())
+	if err != nil {
+		t.Fatalf("GetRequestFulfillment() error = %v", err)
+	}
+	if len(fulfillment) != len(result.RequestFulfillment) {
+		t.Errorf("GetRequestFulfillment() = %+v, want %+v", fulfillment, result.RequestFulfillment)
+	}
+}
@@ -0,0 +1,87 @@
+// Package domain 勤務表ドメイン層
+package domain
+
+import (
+	"time"
+
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
+	"shiftmaster/internal/shared/domain"
+)
+
+// AttendanceDifference 予定と実績の差異 分単位
+type AttendanceDifference struct {
+	// PlannedStart 予定出勤日時 申し送り開始時刻 勤務シフト以外はnil
+	PlannedStart *time.Time
+	// PlannedEnd 予定退勤日時 勤務シフト以外はnil
+	PlannedEnd *time.Time
+	// PlannedWorkingMinutes 予定実働時間
+	PlannedWorkingMinutes int
+	// ActualWorkingMinutes 実績実働時間
+	ActualWorkingMinutes int
+	// LateMinutes 遅刻時間
+	LateMinutes int
+	// EarlyLeaveMinutes 早退時間
+	EarlyLeaveMinutes int
+}
+
+// PlannedPeriod 勤務日とシフト種別から予定出勤・退勤日時を算出 出勤は申し送り開始時刻 日跨ぎシフトは翌日に退勤
+func PlannedPeriod(date time.Time, shiftType *shiftDomain.ShiftType) (start, end time.Time) {
+	day := dayOf(date, Tokyo)
+	// 申し送りが0時をまたぐ場合は前日に出勤する
+	start = day.Add(time.Duration(shiftType.StartTime.Hour()*60+shiftType.StartTime.Minute()-shiftType.HandoverMinutes) * time.Minute)
+	end = day.Add(time.Duration(shiftType.StartTime.Hour()*60+shiftType.StartTime.Minute()+shiftType.TotalMinutes()) * time.Minute)
+	return start, end
+}
+
+// Validate 実績の整合性検証
+func (r *ActualRecord) Validate() error {
+	if r.ActualEndTime != nil && r.ActualStartTime == nil {
+		return domain.NewDomainError(domain.ErrCodeValidation, "出勤時刻を入力してください")
+	}
+	if r.ActualBreakMinutes < 0 {
+		return domain.NewDomainError(domain.ErrCodeValidation, "休憩時間は0分以上で入力してください")
+	}
+	if r.ActualStartTime == nil || r.ActualEndTime == nil {
+		return nil
+	}
+	if !r.ActualEndTime.After(*r.ActualStartTime) {
+		return domain.NewDomainError(domain.ErrCodeValidation, "退勤時刻は出勤時刻より後にしてください")
+	}
+	if r.ActualEndTime.Sub(*r.ActualStartTime) > 24*time.Hour {
+		return domain.NewDomainError(domain.ErrCodeValidation, "勤務時間は24時間以内で入力してください")
+	}
+	if r.ActualBreakMinutes >= int(r.ActualEndTime.Sub(*r.ActualStartTime).Minutes()) {
+		return domain.NewDomainError(domain.ErrCodeValidation, "休憩時間が勤務時間を超えています")
+	}
+	return nil
+}
+
+// CompareWith 予定シフトとの差異を算出 shiftTypeがnil・休日シフトの場合は予定なしとして扱う
+// 退勤が未入力の場合は遅刻のみ算出
+func (r *ActualRecord) CompareWith(date time.Time, shiftType *shiftDomain.ShiftType) AttendanceDifference {
+	diff := AttendanceDifference{ActualWorkingMinutes: r.ActualWorkingMinutes()}
+
+	if shiftType == nil || shiftType.IsHoliday || shiftType.TotalMinutes() == 0 {
+		return diff
+	}
+
+	start, end := PlannedPeriod(date, shiftType)
+	diff.PlannedStart = &start
+	diff.PlannedEnd = &end
+	diff.PlannedWorkingMinutes = int(end.Sub(start).Minutes()) - shiftType.BreakMinutes
+
+	if r.ActualStartTime == nil {
+		return diff
+	}
+	if r.ActualStartTime.After(start) {
+		diff.LateMinutes = int(r.ActualStartTime.Sub(start).Minutes())
+	}
+
+	if r.ActualEndTime == nil {
+		return diff
+	}
+	if r.ActualEndTime.Before(end) {
+		diff.EarlyLeaveMinutes = int(end.Sub(*r.ActualEndTime).Minutes())
+	}
+	return diff
+}
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
+)
+
+func TestActualRecord_Validate(t *testing.T) {
+	at := func(hour, minute int) *time.Time {
+		return ptrTime(time.Date(2025, 4, 1, hour, minute, 0, 0, Tokyo))
+	}
+
+	tests := []struct {
+		name         string
+		start        *time.Time
+		end          *time.Time
+		breakMinutes int
+		wantErr      bool
+	}{
+		{"出勤のみ", at(8, 30), nil, 0, false},
+		{"出勤・退勤・休憩", at(8, 30), at(17, 30), 60, false},
+		{"退勤のみ", nil, at(17, 30), 0, true},
+		{"退勤が出勤以前", at(17, 30), at(8, 30), 0, true},
+		{"休憩が負", at(8, 30), at(17, 30), -1, true},
+		{"休憩が勤務時間以上", at(8, 30), at(9, 30), 60, true},
+		{"24時間超", at(8, 30), ptrTime(time.Date(2025, 4, 2, 9, 0, 0, 0, Tokyo)), 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := &ActualRecord{ActualStartTime: tt.start, ActualEndTime: tt.end, ActualBreakMinutes: tt.breakMinutes}
+			if err := record.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestActualRecord_CompareWith(t *testing.T) {
+	clock := func(s string) time.Time {
+		c, _ := time.Parse("15:04", s)
+		return c
+	}
+	date := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	at := func(day, hour, minute int) *time.Time {
+		return ptrTime(time.Date(2025, 4, day, hour, minute, 0, 0, Tokyo))
+	}
+
+	day := &shiftDomain.ShiftType{Name: "日勤", StartTime: clock("08:30"), EndTime: clock("17:30"), BreakMinutes: 60}
+	night := &shiftDomain.ShiftType{Name: "夜勤", StartTime: clock("16:30"), EndTime: clock("09:00"), BreakMinutes: 120, HandoverMinutes: 30, IsNightShift: true}
+	off := &shiftDomain.ShiftType{Name: "公休", IsHoliday: true}
+
+	tests := []struct {
+		name       string
+		shiftType  *shiftDomain.ShiftType
+		record     ActualRecord
+		planned    int
+		late       int
+		earlyLeave int
+	}{
+		{
+			name:      "予定どおり",
+			shiftType: day,
+			record:    ActualRecord{ActualStartTime: at(1, 8, 30), ActualEndTime: at(1, 17, 30), ActualBreakMinutes: 60},
+			planned:   480,
+		},
+		{
+			name:       "遅刻と早退",
+			shiftType:  day,
+			record:     ActualRecord{ActualStartTime: at(1, 8, 45), ActualEndTime: at(1, 17, 0), ActualBreakMinutes: 60},
+			planned:    480,
+			late:       15,
+			earlyLeave: 30,
+		},
+		{
+			name:      "早出と残業",
+			shiftType: day,
+			record:    ActualRecord{ActualStartTime: at(1, 8, 0), ActualEndTime: at(1, 19, 0), ActualBreakMinutes: 60},
+			planned:   480,
+		},
+		{
+			name:      "日跨ぎシフトは申し送り開始を予定出勤とする",
+			shiftType: night,
+			record:    ActualRecord{ActualStartTime: at(1, 16, 0), ActualEndTime: at(2, 9, 30), ActualBreakMinutes: 120},
+			planned:   900,
+		},
+		{
+			name:      "休日出勤は予定なし",
+			shiftType: off,
+			record:    ActualRecord{ActualStartTime: at(1, 9, 0), ActualEndTime: at(1, 13, 0)},
+		},
+		{
+			name:      "退勤未入力は遅刻のみ",
+			shiftType: day,
+			record:    ActualRecord{ActualStartTime: at(1, 9, 0)},
+			planned:   480,
+			late:      30,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := tt.record.CompareWith(date, tt.shiftType)
+			if diff.PlannedWorkingMinutes != tt.planned {
+				t.Errorf("PlannedWorkingMinutes = %d, want %d", diff.PlannedWorkingMinutes, tt.planned)
+			}
+			if diff.LateMinutes != tt.late {
+				t.Errorf("LateMinutes = %d, want %d", diff.LateMinutes, tt.late)
+			}
+			if diff.EarlyLeaveMinutes != tt.earlyLeave {
+				t.Errorf("EarlyLeaveMinutes = %d, want %d", diff.EarlyLeaveMinutes, tt.earlyLeave)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+// Package domain 勤務表ドメイン層
+package domain
+
+import (
+	"time"
+
+	"shiftmaster/internal/shared/domain"
+)
+
+// CalendarToken 勤務予定カレンダーの購読トークン スタッフごとに1件
+// URLに含めるトークン自体は保存せずハッシュのみ保持する
+type CalendarToken struct {
+	// StaffID スタッフID
+	StaffID domain.ID
+	// OrganizationID 組織ID
+	OrganizationID domain.ID
+	// TokenHash トークンのSHA-256ハッシュ
+	TokenHash string
+	// CreatedAt 発行日時
+	CreatedAt time.Time
+}
@@ -0,0 +1,252 @@
+// Package domain 勤務表ドメイン層
+package domain
+
+import (
+	"sort"
+	"time"
+
+	requestDomain "shiftmaster/internal/modules/request/domain"
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
+	"shiftmaster/internal/shared/domain"
+)
+
+// 制約違反の重大度
+const (
+	// SeverityError エラー 勤務表として成立しない
+	SeverityError = "error"
+	// SeverityWarning 警告 是正が望ましい
+	SeverityWarning = "warning"
+	// SeverityInfo 情報
+	SeverityInfo = "info"
+)
+
+// 制約違反種別コード 検証結果・作成案・APIで共通
+const (
+	// ViolationCoverageShortage 配置人数不足
+	ViolationCoverageShortage = "coverage_shortage"
+	// ViolationCoverageExcess 配置人数超過
+	ViolationCoverageExcess = "coverage_excess"
+	// ViolationSkillShortage 必須スキル保有者不足
+	ViolationSkillShortage = "skill_shortage"
+	// ViolationPositionShortage 必須職位不足
+	ViolationPositionShortage = "position_shortage"
+	// ViolationConsecutiveWork 連続勤務超過
+	ViolationConsecutiveWork = "consecutive_work"
+	// ViolationConsecutiveNight 連続夜勤超過
+	ViolationConsecutiveNight = "consecutive_night"
+	// ViolationShiftInterval 勤務間インターバル不足
+	ViolationShiftInterval = "shift_interval"
+	// ViolationMonthlyNightLimit 月間夜勤回数超過
+	ViolationMonthlyNightLimit = "monthly_night_limit"
+	// ViolationWeeklyHours 週間労働時間
+	ViolationWeeklyHours = "weekly_hours"
+	// ViolationMonthlyHours 月間労働時間
+	ViolationMonthlyHours = "monthly_hours"
+	// ViolationRequestUnmet 勤務希望の未反映
+	ViolationRequestUnmet = "request_unmet"
+	// ViolationTimeOverlap 勤務時間の重複
+	ViolationTimeOverlap = "time_overlap"
+	// ViolationAvailability スタッフの勤務可能条件
+	ViolationAvailability = "availability"
+	// ViolationUnassignedShift シフト未割り当て
+	ViolationUnassignedShift = "unassigned_shift"
+	// ViolationOvertimeLimit 36協定の時間外労働上限
+	ViolationOvertimeLimit = "overtime_limit"
+)
+
+// Constraint 勤務表の制約 勤務表検証・自動作成で同じ評価ロジックを共有する
+type Constraint interface {
+	// Name 制約名 シフトルール由来の場合はルール名
+	Name() string
+	// Evaluate 制約違反を検出
+	Evaluate(input *ConstraintInput) []ConstraintViolation
+}
+
+// StaffConstraint スタッフごとに独立して評価できる制約
+// 自動作成の探索では割り当てを変更したスタッフ1名分だけを再評価する
+type StaffConstraint interface {
+	Constraint
+	// EvaluateStaff スタッフ1名分の日付順エントリを評価
+	EvaluateStaff(input *ConstraintInput, entries []ScheduleEntry) []ConstraintViolation
+}
+
+// DayConstraint 日ごとに独立して評価できる制約
+// 自動作成の探索では割り当てを変更した1日分だけを再評価する
+type DayConstraint interface {
+	Constraint
+	// EvaluateDay 1日分のエントリを評価 entriesは対象日のエントリのみ
+	EvaluateDay(input *ConstraintInput, date time.Time, entries []ScheduleEntry) []ConstraintViolation
+}
+
+// ConstraintInput 制約評価入力
+type ConstraintInput struct {
+	// Schedule 対象勤務表
+	Schedule *Schedule
+	// Entries 評価対象エントリ
+	Entries []ScheduleEntry
+	// ShiftTypes シフト種別マップ ID文字列 -> シフト種別
+	ShiftTypes map[string]*shiftDomain.ShiftType
+	// Qualifications スタッフ資格情報 nilの場合はスキル・職種・職位・雇用形態を条件とする制約を評価しない
+	Qualifications *StaffQualifications
+	// Requests 対象月の勤務希望
+	Requests []requestDomain.ShiftRequest
+
+	// byStaff スタッフごとの日付順エントリ 初回参照時に構築
+	byStaff map[string][]ScheduleEntry
+	// shiftByID IDをキーとするシフト種別 初回参照時に構築
+	shiftByID map[domain.ID]*shiftDomain.ShiftType
+}
+
+// EntriesByStaff エントリをスタッフID文字列でグルーピングし日付順に並べる
+func (in *ConstraintInput) EntriesByStaff() map[string][]ScheduleEntry {
+	if in.byStaff != nil {
+		return in.byStaff
+	}
+	in.byStaff = make(map[string][]ScheduleEntry)
+	for _, entry := range in.Entries {
+		staffID := entry.StaffID.String()
+		in.byStaff[staffID] = append(in.byStaff[staffID], entry)
+	}
+	for _, list := range in.byStaff {
+		sort.SliceStable(list, func(i, j int) bool {
+			return list[i].TargetDate.Before(list[j].TargetDate)
+		})
+	}
+	return in.byStaff
+}
+
+// WorkingShift エントリの勤務シフト 未割り当て・休日・未登録はnil
+func (in *ConstraintInput) WorkingShift(entry ScheduleEntry) *shiftDomain.ShiftType {
+	if entry.ShiftTypeID == nil {
+		return nil
+	}
+	if in.shiftByID == nil {
+		in.shiftByID = make(map[domain.ID]*shiftDomain.ShiftType, len(in.ShiftTypes))
+		for _, st := range in.ShiftTypes {
+			in.shiftByID[st.ID] = st
+		}
+	}
+	shiftType, ok := in.shiftByID[*entry.ShiftTypeID]
+	if !ok || shiftType.IsHoliday {
+		return nil
+	}
+	return shiftType
+}
+
+// entriesByDate エントリを日付でグルーピング
+func entriesByDate(entries []ScheduleEntry) map[string][]ScheduleEntry {
+	result := make(map[string][]ScheduleEntry)
+	for _, entry := range entries {
+		key := entry.TargetDate.Format("2006-01-02")
+		result[key] = append(result[key], entry)
+	}
+	return result
+}
+
+// ConstraintFactory 型付き設定に変換済みのシフトルールから制約を生成
+type ConstraintFactory func(rule shiftDomain.ShiftRule, config shiftDomain.RuleConfig) Constraint
+
+// constraintFactories ルール種別ごとの制約生成関数
+var constraintFactories = map[shiftDomain.ShiftRuleType]ConstraintFactory{
+	shiftDomain.RuleTypeMinStaff:         newStaffCoverageConstraint,
+	shiftDomain.RuleTypeMaxStaff:         newStaffCoverageConstraint,
+	shiftDomain.RuleTypeConsecutive:      newConsecutiveConstraint,
+	shiftDomain.RuleTypeInterval:         newIntervalConstraint,
+	shiftDomain.RuleTypeNightLimit:       newNightLimitConstraint,
+	shiftDomain.RuleTypeSkillRequired:    newSkillRequiredConstraint,
+	shiftDomain.RuleTypePositionRequired: newPositionRequiredConstraint,
+	shiftDomain.RuleTypeWeeklyHours:      newWorkingHoursConstraint,
+	shiftDomain.RuleTypeMonthlyHours:     newWorkingHoursConstraint,
+}
+
+// RegisterConstraint ルール種別の制約生成関数を登録 登録済みの種別は置き換える
+// パッケージ初期化時に呼び出す 自動作成の探索ではStaffConstraint・DayConstraintを実装した制約のみを考慮する
+func RegisterConstraint(ruleType shiftDomain.ShiftRuleType, factory ConstraintFactory) {
+	constraintFactories[ruleType] = factory
+}
+
+// NewRuleConstraint シフトルールから制約を生成 設定が不正な場合はエラー
+// 制約が登録されていない種別はnil
+func NewRuleConstraint(rule shiftDomain.ShiftRule) (Constraint, error) {
+	cfg, err := rule.ParseConfig()
+	if err != nil {
+		return nil, err
+	}
+	factory, ok := constraintFactories[rule.RuleType]
+	if !ok {
+		return nil, nil
+	}
+	return factory(rule, cfg), nil
+}
+
+// ConstraintPipeline 制約の評価順序を保持するパイプライン
+type ConstraintPipeline struct {
+	constraints []Constraint
+}
+
+// NewConstraintPipeline 有効なシフトルールと組み込み制約からパイプラインを生成
+// ルールは優先度の高い順に評価し、設定が不正なルールはonInvalidに渡して評価対象外とする
+func NewConstraintPipeline(rules []shiftDomain.ShiftRule, onInvalid func(rule shiftDomain.ShiftRule, err error)) *ConstraintPipeline {
+	active := make([]shiftDomain.ShiftRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.IsActive {
+			active = append(active, rule)
+		}
+	}
+	sort.SliceStable(active, func(i, j int) bool {
+		return active[i].Priority > active[j].Priority
+	})
+
+	p := &ConstraintPipeline{constraints: make([]Constraint, 0, len(active)+4)}
+	for _, rule := range active {
+		c, err := NewRuleConstraint(rule)
+		if err != nil {
+			if onInvalid != nil {
+				onInvalid(rule, err)
+			}
+			continue
+		}
+		if c != nil {
+			p.constraints = append(p.constraints, c)
+		}
+	}
+	p.constraints = append(p.constraints,
+		TimeOverlapConstraint{},
+		AvailabilityConstraint{},
+		ShiftRequestConstraint{},
+		UnassignedShiftConstraint{},
+	)
+	return p
+}
+
+// Constraints 評価順の制約一覧
+func (p *ConstraintPipeline) Constraints() []Constraint {
+	return p.constraints
+}
+
+// Evaluate 全制約を評価順に評価
+func (p *ConstraintPipeline) Evaluate(input *ConstraintInput) []ConstraintViolation {
+	violations := make([]ConstraintViolation, 0)
+	for _, c := range p.constraints {
+		violations = append(violations, c.Evaluate(input)...)
+	}
+	return violations
+}
+
+// scheduleDates 勤務表対象月の日付一覧
+func scheduleDates(schedule *Schedule) []time.Time {
+	if schedule == nil {
+		return nil
+	}
+	start := schedule.StartDate()
+	dates := make([]time.Time, schedule.DaysInMonth())
+	for i := range dates {
+		dates[i] = start.AddDate(0, 0, i)
+	}
+	return dates
+}
+
+// dayOf 日付部分のみを指定タイムゾーンの0時として取得
+func dayOf(date time.Time, loc *time.Location) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,192 @@
+// Package domain 勤務表ドメイン層
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
+
+	"shiftmaster/internal/shared/domain"
+)
+
+// TimeOverlapConstraint 同一スタッフの勤務時間重複 日跨ぎシフトと翌日の勤務の重複を含む
+type TimeOverlapConstraint struct{}
+
+// Name 制約名
+func (TimeOverlapConstraint) Name() string {
+	return "勤務時間の重複"
+}
+
+// Evaluate 重複するエントリの組ごとに前の勤務日で1件報告
+func (c TimeOverlapConstraint) Evaluate(input *ConstraintInput) []ConstraintViolation {
+	violations := make([]ConstraintViolation, 0)
+	for _, entries := range input.EntriesByStaff() {
+		violations = append(violations, c.EvaluateStaff(input, entries)...)
+	}
+	return violations
+}
+
+// EvaluateStaff スタッフ1名分の重複を評価 日付が離れた組は重複しないため比較を打ち切る
+func (TimeOverlapConstraint) EvaluateStaff(input *ConstraintInput, entries []ScheduleEntry) []ConstraintViolation {
+	violations := make([]ConstraintViolation, 0)
+
+	working := make([]ScheduleEntry, 0, len(entries))
+	ranges := make([]domain.ShiftTimeRange, 0, len(entries))
+	for _, entry := range entries {
+		shiftType := input.WorkingShift(entry)
+		if shiftType == nil || shiftType.TotalMinutes() == 0 {
+			continue
+		}
+		working = append(working, entry)
+		ranges = append(ranges, shiftType.TimeRange())
+	}
+
+	for i := 0; i < len(working); i++ {
+		for j := i + 1; j < len(working); j++ {
+			if working[j].TargetDate.Sub(working[i].TargetDate) > 48*time.Hour {
+				break
+			}
+			if !overlapsOnDates(working[i], ranges[i], working[j], ranges[j]) {
+				continue
+			}
+			staffID, date := working[i].StaffID, working[i].TargetDate
+			violations = append(violations, ConstraintViolation{
+				ConstraintType: ViolationTimeOverlap,
+				Message:        "同一スタッフに時間が重複するシフトが割り当てられています",
+				StaffID:        &staffID,
+				Date:           &date,
+				Severity:       SeverityError,
+			})
+		}
+	}
+
+	return violations
+}
+
+// overlapsOnDates 勤務日を考慮した2つの勤務の時間重複判定 e1はe2と同日以前
+func overlapsOnDates(e1 ScheduleEntry, r1 domain.ShiftTimeRange, e2 ScheduleEntry, r2 domain.ShiftTimeRange) bool {
+	days := int(dayOf(e2.TargetDate, time.UTC).Sub(dayOf(e1.TargetDate, time.UTC)).Hours() / 24)
+	switch {
+	case days == 0:
+		return r1.Overlaps(r2)
+	case days == 1 && r1.CrossesDate():
+		return r1.OverlapsOnConsecutiveDays(r2)
+	}
+	return false
+}
+
+// AvailabilityConstraint スタッフの勤務可能条件 曜日・時間帯・シフト種別・期間例外・週の上限勤務日数
+// 勤務可能条件が未登録のスタッフは評価しない
+type AvailabilityConstraint struct{}
+
+// Name 制約名
+func (AvailabilityConstraint) Name() string {
+	return "勤務可能条件"
+}
+
+// Evaluate 条件に反する勤務と、週（月曜始まり）の勤務日数が上限を超えた日を報告
+// 週の勤務日数は評価対象エントリの範囲で数える
+func (c AvailabilityConstraint) Evaluate(input *ConstraintInput) []ConstraintViolation {
+	violations := make([]ConstraintViolation, 0)
+	for _, entries := range input.EntriesByStaff() {
+		violations = append(violations, c.EvaluateStaff(input, entries)...)
+	}
+	return violations
+}
+
+// EvaluateStaff スタッフ1名分の勤務可能条件を評価 週の上限超過は上限を超えた日で1件報告し、超過日数を大きさとする
+func (AvailabilityConstraint) EvaluateStaff(input *ConstraintInput, entries []ScheduleEntry) []ConstraintViolation {
+	violations := make([]ConstraintViolation, 0)
+	if len(entries) == 0 {
+		return violations
+	}
+	availability := input.Qualifications.Availability(entries[0].StaffID.String())
+	if availability == nil {
+		return violations
+	}
+
+	weekDays := make(map[time.Time]int)
+	// exceeded 週ごとの上限超過の違反の位置
+	exceeded := make(map[time.Time]int)
+	var lastDay time.Time
+	for _, entry := range entries {
+		shiftType := input.WorkingShift(entry)
+		if shiftType == nil {
+			continue
+		}
+		staffID, date, shiftTypeID := entry.StaffID, entry.TargetDate, shiftType.ID
+
+		start, end := shiftMinutes(shiftType)
+		if reason := availability.ConflictOn(date, shiftType.ID, start, end); reason != "" {
+			violations = append(violations, ConstraintViolation{
+				ConstraintType: ViolationAvailability,
+				Message:        fmt.Sprintf("勤務可能条件に反する割り当てです（%s: %s）", shiftType.Name, reason),
+				StaffID:        &staffID,
+				Date:           &date,
+				ShiftTypeID:    &shiftTypeID,
+				Severity:       SeverityError,
+			})
+		}
+
+		// 同日の複数勤務は1日と数える
+		day := dayOf(date, time.UTC)
+		if day.Equal(lastDay) {
+			continue
+		}
+		lastDay = day
+		week := weekStart(date)
+		weekDays[week]++
+		if !availability.ExceedsDaysPerWeek(weekDays[week]) {
+			continue
+		}
+		if i, ok := exceeded[week]; ok {
+			violations[i].Amount++
+			continue
+		}
+		exceeded[week] = len(violations)
+		violations = append(violations, ConstraintViolation{
+			ConstraintType: ViolationAvailability,
+			Message:        fmt.Sprintf("%s週の勤務日数が上限の%d日を超えています", week.Format("1/2"), availability.MaxDaysPerWeek),
+			StaffID:        &staffID,
+			Date:           &date,
+			Severity:       SeverityError,
+			Amount:         1,
+		})
+	}
+
+	return violations
+}
+
+// shiftMinutes 勤務日0時起点の出勤・退勤時刻（分） 出勤は申し送りを含み、日跨ぎの退勤は1440以上
+func shiftMinutes(shiftType *shiftDomain.ShiftType) (start, end int) {
+	start = shiftType.StartTime.Hour()*60 + shiftType.StartTime.Minute()
+	return start - shiftType.HandoverMinutes, start + shiftType.TotalMinutes()
+}
+
+// UnassignedShiftConstraint 未確定のシフト未割り当て
+type UnassignedShiftConstraint struct{}
+
+// Name 制約名
+func (UnassignedShiftConstraint) Name() string {
+	return "シフト未割り当て"
+}
+
+// Evaluate シフト種別が未設定の未確定エントリを報告
+func (UnassignedShiftConstraint) Evaluate(input *ConstraintInput) []ConstraintViolation {
+	violations := make([]ConstraintViolation, 0)
+	for _, entry := range input.Entries {
+		if entry.ShiftTypeID != nil || entry.IsConfirmed {
+			continue
+		}
+		staffID, date := entry.StaffID, entry.TargetDate
+		violations = append(violations, ConstraintViolation{
+			ConstraintType: ViolationUnassignedShift,
+			Message:        "シフトが未割り当てです",
+			StaffID:        &staffID,
+			Date:           &date,
+			Severity:       SeverityInfo,
+		})
+	}
+	return violations
+}
@@ -0,0 +1,130 @@
+// Package domain 勤務表ドメイン層
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	requestDomain "shiftmaster/internal/modules/request/domain"
+	"shiftmaster/internal/shared/domain"
+)
+
+// ShiftRequestFinder 勤務希望取得インターフェース
+type ShiftRequestFinder interface {
+	// FindByTargetMonth 対象年月の受付期間に登録された勤務希望を取得 受付期間がない場合は空
+	FindByTargetMonth(ctx context.Context, organizationID domain.ID, year, month int) ([]requestDomain.ShiftRequest, error)
+}
+
+// ShiftRequestResult 勤務希望ごとの充足判定結果
+type ShiftRequestResult struct {
+	// Request 勤務希望
+	Request requestDomain.ShiftRequest
+	// Satisfied 勤務表に反映されているか
+	Satisfied bool
+	// Label 希望シフトの表示名 シフト指定なしは「勤務」
+	Label string
+}
+
+// JudgeShiftRequests 勤務希望の充足判定 対象月外・未登録シフト指定の希望は対象外
+func JudgeShiftRequests(input *ConstraintInput) []ShiftRequestResult {
+	return judgeShiftRequests(input, input.Requests, input.Entries)
+}
+
+// judgeShiftRequests 指定した勤務希望をエントリと照合して充足判定
+func judgeShiftRequests(input *ConstraintInput, requests []requestDomain.ShiftRequest, entries []ScheduleEntry) []ShiftRequestResult {
+	entryMap := make(map[string]*ScheduleEntry, len(entries))
+	for i := range entries {
+		entryMap[entries[i].StaffID.String()+"_"+entries[i].TargetDate.Format("2006-01-02")] = &entries[i]
+	}
+
+	results := make([]ShiftRequestResult, 0, len(requests))
+	for _, r := range requests {
+		if input.Schedule != nil &&
+			(r.TargetDate.Year() != input.Schedule.TargetYear || int(r.TargetDate.Month()) != input.Schedule.TargetMonth) {
+			continue
+		}
+		entry := entryMap[r.StaffID.String()+"_"+r.TargetDate.Format("2006-01-02")]
+
+		var matched bool
+		label := "勤務"
+		if r.ShiftTypeID == nil {
+			// シフト指定なしは何らかの勤務シフトで一致
+			matched = entry != nil && input.WorkingShift(*entry) != nil
+		} else {
+			requested, ok := input.ShiftTypes[r.ShiftTypeID.String()]
+			if !ok {
+				continue
+			}
+			label = requested.Name
+			if requested.IsHoliday {
+				// 休み希望は未割り当て・休日シフトのいずれでも一致
+				matched = entry == nil || input.WorkingShift(*entry) == nil
+			} else {
+				matched = entry != nil && entry.ShiftTypeID != nil && *entry.ShiftTypeID == *r.ShiftTypeID
+			}
+		}
+
+		satisfied := matched
+		if r.RequestType == requestDomain.RequestTypeAvoided {
+			satisfied = !matched
+		}
+		results = append(results, ShiftRequestResult{Request: r, Satisfied: satisfied, Label: label})
+	}
+	return results
+}
+
+// ShiftRequestConstraint 勤務希望の反映 固定・必須の希望はエラー、できれば希望は警告
+type ShiftRequestConstraint struct{}
+
+// Name 制約名
+func (ShiftRequestConstraint) Name() string {
+	return "勤務希望"
+}
+
+// Evaluate 反映されていない勤務希望を報告
+func (ShiftRequestConstraint) Evaluate(input *ConstraintInput) []ConstraintViolation {
+	if len(input.Requests) == 0 {
+		return make([]ConstraintViolation, 0)
+	}
+	return requestViolations(JudgeShiftRequests(input))
+}
+
+// EvaluateStaff スタッフ1名分の勤務希望の反映を評価
+func (ShiftRequestConstraint) EvaluateStaff(input *ConstraintInput, entries []ScheduleEntry) []ConstraintViolation {
+	if len(input.Requests) == 0 || len(entries) == 0 {
+		return make([]ConstraintViolation, 0)
+	}
+	staffID := entries[0].StaffID
+	requests := make([]requestDomain.ShiftRequest, 0)
+	for _, r := range input.Requests {
+		if r.StaffID == staffID {
+			requests = append(requests, r)
+		}
+	}
+	return requestViolations(judgeShiftRequests(input, requests, entries))
+}
+
+// requestViolations 充足判定結果のうち反映されていない勤務希望を違反に変換
+func requestViolations(results []ShiftRequestResult) []ConstraintViolation {
+	violations := make([]ConstraintViolation, 0)
+	for _, res := range results {
+		if res.Satisfied {
+			continue
+		}
+		r := res.Request
+		severity := SeverityWarning
+		if r.RequestType == requestDomain.RequestTypeFixed || r.Priority == requestDomain.PriorityRequired {
+			severity = SeverityError
+		}
+		staffID, date := r.StaffID, r.TargetDate
+		violations = append(violations, ConstraintViolation{
+			ConstraintType: ViolationRequestUnmet,
+			Message:        fmt.Sprintf("勤務希望（%s: %s・%s）が反映されていません", r.RequestType.Label(), res.Label, r.Priority.Label()),
+			StaffID:        &staffID,
+			Date:           &date,
+			ShiftTypeID:    r.ShiftTypeID,
+			Severity:       severity,
+		})
+	}
+	return violations
+}
@@ -0,0 +1,577 @@
+// Package domain 勤務表ドメイン層
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
+	"shiftmaster/internal/shared/domain"
+)
+
+// ruleConstraint シフトルール由来の制約の共通部分
+type ruleConstraint struct {
+	rule shiftDomain.ShiftRule
+}
+
+// Name ルール名
+func (c ruleConstraint) Name() string {
+	return c.rule.Name
+}
+
+// violation ルール名付きの違反を生成
+func (c ruleConstraint) violation(code, severity, message string) ConstraintViolation {
+	return ConstraintViolation{
+		ConstraintType: code,
+		Message:        message,
+		Severity:       severity,
+		RuleName:       c.rule.Name,
+	}
+}
+
+// consecutiveConstraint 連続勤務・連続夜勤制限
+type consecutiveConstraint struct {
+	ruleConstraint
+	cfg *shiftDomain.ConsecutiveConfig
+}
+
+func newConsecutiveConstraint(rule shiftDomain.ShiftRule, config shiftDomain.RuleConfig) Constraint {
+	return consecutiveConstraint{ruleConstraint{rule}, config.(*shiftDomain.ConsecutiveConfig)}
+}
+
+// Evaluate 上限を超えた連続区間ごとに区間の開始日で1件報告
+func (c consecutiveConstraint) Evaluate(input *ConstraintInput) []ConstraintViolation {
+	violations := make([]ConstraintViolation, 0)
+	for _, entries := range input.EntriesByStaff() {
+		violations = append(violations, c.EvaluateStaff(input, entries)...)
+	}
+	return violations
+}
+
+// EvaluateStaff スタッフ1名分の連続区間を評価 上限を超えた日数を大きさとする
+func (c consecutiveConstraint) EvaluateStaff(input *ConstraintInput, entries []ScheduleEntry) []ConstraintViolation {
+	violations := make([]ConstraintViolation, 0)
+
+	count := 0
+	var runStart, prevDate time.Time
+	flush := func(staffID domain.ID) {
+		if count <= c.cfg.MaxDays {
+			return
+		}
+		v := c.violation(ViolationConsecutiveWork, SeverityWarning,
+			fmt.Sprintf("%d日連続勤務です（上限: %d日）", count, c.cfg.MaxDays))
+		if c.cfg.NightOnly {
+			v = c.violation(ViolationConsecutiveNight, SeverityError,
+				fmt.Sprintf("%d日連続夜勤です（上限: %d日）", count, c.cfg.MaxDays))
+		}
+		date := runStart
+		v.StaffID = &staffID
+		v.Date = &date
+		v.Amount = count - c.cfg.MaxDays
+		violations = append(violations, v)
+	}
+
+	for _, entry := range entries {
+		shiftType := input.WorkingShift(entry)
+		if shiftType == nil || (c.cfg.NightOnly && !shiftType.IsNightShift) {
+			flush(entry.StaffID)
+			count = 0
+			continue
+		}
+
+		if count > 0 && dayOf(entry.TargetDate, time.UTC).Sub(dayOf(prevDate, time.UTC)) == 24*time.Hour {
+			count++
+		} else {
+			flush(entry.StaffID)
+			runStart = entry.TargetDate
+			count = 1
+		}
+		prevDate = entry.TargetDate
+	}
+	if len(entries) > 0 {
+		flush(entries[0].StaffID)
+	}
+
+	return violations
+}
+
+// intervalConstraint 勤務間インターバル
+type intervalConstraint struct {
+	ruleConstraint
+	cfg *shiftDomain.IntervalConfig
+}
+
+func newIntervalConstraint(rule shiftDomain.ShiftRule, config shiftDomain.RuleConfig) Constraint {
+	return intervalConstraint{ruleConstraint{rule}, config.(*shiftDomain.IntervalConfig)}
+}
+
+// Evaluate 前の勤務の終了時刻から次の勤務の実際の出勤時刻（申し送り開始）までの休息時間を実時間で評価
+func (c intervalConstraint) Evaluate(input *ConstraintInput) []ConstraintViolation {
+	violations := make([]ConstraintViolation, 0)
+	for _, entries := range input.EntriesByStaff() {
+		violations = append(violations, c.EvaluateStaff(input, entries)...)
+	}
+	return violations
+}
+
+// EvaluateStaff スタッフ1名分の勤務間インターバルを評価
+func (c intervalConstraint) EvaluateStaff(input *ConstraintInput, entries []ScheduleEntry) []ConstraintViolation {
+	violations := make([]ConstraintViolation, 0)
+	minRest := int(c.cfg.MinHours * 60)
+
+	var prev *ScheduleEntry
+	var prevShift *shiftDomain.ShiftType
+	for i := range entries {
+		currShift := input.WorkingShift(entries[i])
+		if currShift == nil || currShift.TotalMinutes() == 0 {
+			continue
+		}
+		if prev != nil {
+			days := int(dayOf(entries[i].TargetDate, time.UTC).Sub(dayOf(prev.TargetDate, time.UTC)).Hours() / 24)
+			rest := prevShift.TimeRange().RestMinutesUntil(currShift.TimeRange(), days) - currShift.HandoverMinutes
+
+			if days > 0 && rest < minRest {
+				message := fmt.Sprintf("勤務間インターバルが%.1f時間です（最小: %g時間）", float64(rest)/60, c.cfg.MinHours)
+				if currShift.HasHandover() {
+					message = fmt.Sprintf("勤務間インターバルが%.1f時間です（申し送り%d分を含む 最小: %g時間）",
+						float64(rest)/60, currShift.HandoverMinutes, c.cfg.MinHours)
+				}
+				v := c.violation(ViolationShiftInterval, SeverityWarning, message)
+				staffID, date := entries[i].StaffID, entries[i].TargetDate
+				v.StaffID = &staffID
+				v.Date = &date
+				violations = append(violations, v)
+			}
+		}
+		prev = &entries[i]
+		prevShift = currShift
+	}
+
+	return violations
+}
+
+// nightLimitConstraint 月間夜勤回数制限
+type nightLimitConstraint struct {
+	ruleConstraint
+	cfg *shiftDomain.NightLimitConfig
+}
+
+func newNightLimitConstraint(rule shiftDomain.ShiftRule, config shiftDomain.RuleConfig) Constraint {
+	return nightLimitConstraint{ruleConstraint{rule}, config.(*shiftDomain.NightLimitConfig)}
+}
+
+// Evaluate スタッフごとの夜勤回数を評価
+func (c nightLimitConstraint) Evaluate(input *ConstraintInput) []ConstraintViolation {
+	violations := make([]ConstraintViolation, 0)
+	for _, entries := range input.EntriesByStaff() {
+		violations = append(violations, c.EvaluateStaff(input, entries)...)
+	}
+	return violations
+}
+
+// EvaluateStaff スタッフ1名分の夜勤回数を評価 上限を超えた回数を大きさとする
+func (c nightLimitConstraint) EvaluateStaff(input *ConstraintInput, entries []ScheduleEntry) []ConstraintViolation {
+	violations := make([]ConstraintViolation, 0)
+
+	nightCount := 0
+	for _, entry := range entries {
+		if shiftType := input.WorkingShift(entry); shiftType != nil && shiftType.IsNightShift {
+			nightCount++
+		}
+	}
+	if nightCount <= c.cfg.MaxPerMonth {
+		return violations
+	}
+	v := c.violation(ViolationMonthlyNightLimit, SeverityWarning,
+		fmt.Sprintf("月間夜勤回数が%d回です（上限: %d回）", nightCount, c.cfg.MaxPerMonth))
+	staffID := entries[0].StaffID
+	v.StaffID = &staffID
+	v.Amount = nightCount - c.cfg.MaxPerMonth
+	return append(violations, v)
+}
+
+// staffCoverageConstraint 最小・最大配置人数 対象日区分・スキル・職種の条件で絞り込んで評価
+type staffCoverageConstraint struct {
+	ruleConstraint
+	filter      *shiftDomain.CoverageFilter
+	shiftTypeID string
+	dayKind     shiftDomain.DayKind
+	// limit 最小人数または最大人数
+	limit int
+	// isMax 最大配置人数かどうか
+	isMax bool
+}
+
+func newStaffCoverageConstraint(rule shiftDomain.ShiftRule, config shiftDomain.RuleConfig) Constraint {
+	c := staffCoverageConstraint{ruleConstraint: ruleConstraint{rule}}
+	switch cfg := config.(type) {
+	case *shiftDomain.MinStaffConfig:
+		c.filter, c.shiftTypeID, c.dayKind, c.limit = &cfg.CoverageFilter, cfg.ShiftTypeID, cfg.DayKind, cfg.MinCount
+	case *shiftDomain.MaxStaffConfig:
+		c.filter, c.shiftTypeID, c.dayKind, c.limit = &cfg.CoverageFilter, cfg.ShiftTypeID, cfg.DayKind, cfg.MaxCount
+		c.isMax = true
+	}
+	return c
+}
+
+// Evaluate 日付・シフト種別ごとの配置人数を評価
+func (c staffCoverageConstraint) Evaluate(input *ConstraintInput) []ConstraintViolation {
+	violations := make([]ConstraintViolation, 0)
+	byDate := entriesByDate(input.Entries)
+	for _, date := range scheduleDates(input.Schedule) {
+		violations = append(violations, c.EvaluateDay(input, date, byDate[date.Format("2006-01-02")])...)
+	}
+	return violations
+}
+
+// EvaluateDay 1日分のシフト種別ごとの配置人数を評価 不足・超過人数を大きさとする
+func (c staffCoverageConstraint) EvaluateDay(input *ConstraintInput, date time.Time, entries []ScheduleEntry) []ConstraintViolation {
+	violations := make([]ConstraintViolation, 0)
+	if !c.dayKind.Matches(date) || (c.filter.HasStaffFilter() && input.Qualifications == nil) {
+		return violations
+	}
+
+	counts := countByShift(input, entries, c.filter)
+	subject := coverageSubject(c.filter, input.Qualifications)
+	for _, st := range targetShiftTypes(c.shiftTypeID, input.ShiftTypes) {
+		count := counts[st.ID]
+		var v ConstraintViolation
+		switch {
+		case c.isMax && count > c.limit:
+			v = c.violation(ViolationCoverageExcess, SeverityWarning, coverageMessage(date, st.Name, subject, count, "上限", c.limit))
+			v.Amount = count - c.limit
+		case !c.isMax && count < c.limit:
+			v = c.violation(ViolationCoverageShortage, SeverityError, coverageMessage(date, st.Name, subject, count, "必要", c.limit))
+			v.Amount = c.limit - count
+		default:
+			continue
+		}
+		violations = append(violations, withShiftDate(v, st.ID, date))
+	}
+
+	return violations
+}
+
+// qualifiedStaffConstraint 必須スキル・必須職位 配置のある枠ごとに条件を満たすスタッフ数を評価
+// 配置のない枠は最小配置人数ルールで検出するため対象外
+type qualifiedStaffConstraint struct {
+	ruleConstraint
+	code        string
+	shiftTypeID string
+	dayKind     shiftDomain.DayKind
+	minCount    int
+	// subject 条件の表示名
+	subject func(q *StaffQualifications) string
+	// qualified エントリのスタッフが条件を満たすか
+	qualified func(q *StaffQualifications, entry ScheduleEntry) bool
+}
+
+func newSkillRequiredConstraint(rule shiftDomain.ShiftRule, config shiftDomain.RuleConfig) Constraint {
+	cfg := config.(*shiftDomain.SkillRequiredConfig)
+	return qualifiedStaffConstraint{
+		ruleConstraint: ruleConstraint{rule},
+		code:           ViolationSkillShortage,
+		shiftTypeID:    cfg.ShiftTypeID,
+		dayKind:        cfg.DayKind,
+		minCount:       cfg.MinCount,
+		subject: func(q *StaffQualifications) string {
+			return coverageSubject(&shiftDomain.CoverageFilter{SkillID: cfg.SkillID, MinSkillLevel: cfg.MinLevel}, q)
+		},
+		qualified: func(q *StaffQualifications, entry ScheduleEntry) bool {
+			return q.HasSkill(entry.StaffID.String(), cfg.SkillID, cfg.MinLevel)
+		},
+	}
+}
+
+func newPositionRequiredConstraint(rule shiftDomain.ShiftRule, config shiftDomain.RuleConfig) Constraint {
+	cfg := config.(*shiftDomain.PositionRequiredConfig)
+	return qualifiedStaffConstraint{
+		ruleConstraint: ruleConstraint{rule},
+		code:           ViolationPositionShortage,
+		shiftTypeID:    cfg.ShiftTypeID,
+		dayKind:        cfg.DayKind,
+		minCount:       cfg.MinCount,
+		subject: func(q *StaffQualifications) string {
+			return q.PositionLabel(cfg.PositionID, cfg.Level)
+		},
+		qualified: func(q *StaffQualifications, entry ScheduleEntry) bool {
+			return q.HasPosition(entry.StaffID.String(), cfg.PositionID, cfg.Level, entry.TargetDate)
+		},
+	}
+}
+
+// Evaluate 配置のある枠ごとに有資格者数を評価 資格情報がない場合は評価しない
+func (c qualifiedStaffConstraint) Evaluate(input *ConstraintInput) []ConstraintViolation {
+	violations := make([]ConstraintViolation, 0)
+	byDate := entriesByDate(input.Entries)
+	for _, date := range scheduleDates(input.Schedule) {
+		violations = append(violations, c.EvaluateDay(input, date, byDate[date.Format("2006-01-02")])...)
+	}
+	return violations
+}
+
+// EvaluateDay 1日分の配置のある枠ごとに有資格者数を評価 不足人数を大きさとする
+func (c qualifiedStaffConstraint) EvaluateDay(input *ConstraintInput, date time.Time, entries []ScheduleEntry) []ConstraintViolation {
+	violations := make([]ConstraintViolation, 0)
+	if input.Qualifications == nil || !c.dayKind.Matches(date) {
+		return violations
+	}
+
+	// シフト種別ごとの配置人数と有資格者数
+	staffed := make(map[domain.ID]int)
+	counts := make(map[domain.ID]int)
+	for _, entry := range entries {
+		if input.WorkingShift(entry) == nil {
+			continue
+		}
+		staffed[*entry.ShiftTypeID]++
+		if c.qualified(input.Qualifications, entry) {
+			counts[*entry.ShiftTypeID]++
+		}
+	}
+
+	subject := c.subject(input.Qualifications)
+	for _, st := range targetShiftTypes(c.shiftTypeID, input.ShiftTypes) {
+		if staffed[st.ID] == 0 {
+			continue
+		}
+		if count := counts[st.ID]; count < c.minCount {
+			v := c.violation(c.code, SeverityError, coverageMessage(date, st.Name, subject, count, "必要", c.minCount))
+			v.Amount = c.minCount - count
+			violations = append(violations, withShiftDate(v, st.ID, date))
+		}
+	}
+
+	return violations
+}
+
+// workingHoursConstraint 週間・月間労働時間
+// 週間はISO週（月曜始まり）、月間は勤務表の対象月で集計し、日跨ぎシフトの翌日分は翌日の属する週・月に計上する
+type workingHoursConstraint struct {
+	ruleConstraint
+	cfg *shiftDomain.WorkingHoursConfig
+}
+
+func newWorkingHoursConstraint(rule shiftDomain.ShiftRule, config shiftDomain.RuleConfig) Constraint {
+	return workingHoursConstraint{ruleConstraint{rule}, config.(*shiftDomain.WorkingHoursConfig)}
+}
+
+// Evaluate スタッフごとの労働時間を評価 雇用形態を指定したルールは該当スタッフのみ評価
+func (c workingHoursConstraint) Evaluate(input *ConstraintInput) []ConstraintViolation {
+	violations := make([]ConstraintViolation, 0)
+	if c.cfg.EmploymentType != "" && input.Qualifications == nil {
+		return violations
+	}
+	for _, entries := range input.EntriesByStaff() {
+		violations = append(violations, c.EvaluateStaff(input, entries)...)
+	}
+	return violations
+}
+
+// EvaluateStaff スタッフ1名分の労働時間を評価 上限・下限との差の時間（切り上げ）を大きさとする
+func (c workingHoursConstraint) EvaluateStaff(input *ConstraintInput, entries []ScheduleEntry) []ConstraintViolation {
+	violations := make([]ConstraintViolation, 0)
+	if len(entries) == 0 || (c.cfg.EmploymentType != "" && input.Qualifications == nil) {
+		return violations
+	}
+	staffID := entries[0].StaffID
+
+	employment := ""
+	if c.cfg.EmploymentType != "" {
+		staff, ok := input.Qualifications.Staffs[staffID.String()]
+		if !ok || !c.cfg.AppliesTo(staff.EmploymentType.String()) {
+			return violations
+		}
+		employment = staff.EmploymentType.Label() + "の"
+	}
+
+	weekly := c.rule.RuleType == shiftDomain.RuleTypeWeeklyHours
+	periodKey := func(date time.Time) string {
+		if weekly {
+			return weekStart(date).Format("2006-01-02")
+		}
+		return date.Format("2006-01")
+	}
+
+	// 集計単位の開始日（月間は年月） -> 実働分
+	totals := make(map[string]int)
+	keys := make([]string, 0)
+	add := func(date time.Time, minutes int) {
+		if minutes <= 0 {
+			return
+		}
+		key := periodKey(date)
+		if _, ok := totals[key]; !ok {
+			keys = append(keys, key)
+		}
+		totals[key] += minutes
+	}
+	for _, entry := range entries {
+		shiftType := input.WorkingShift(entry)
+		if shiftType == nil {
+			continue
+		}
+		minutes := c.cfg.ShiftMinutes(shiftType)
+		spill := overnightMinutes(shiftType, minutes)
+		add(entry.TargetDate, minutes-spill)
+		add(entry.TargetDate.AddDate(0, 0, 1), spill)
+	}
+
+	var monthStart, monthEnd time.Time
+	if input.Schedule != nil {
+		monthStart = dayOf(input.Schedule.StartDate(), time.UTC)
+		monthEnd = dayOf(input.Schedule.EndDate(), time.UTC)
+	}
+
+	code := ViolationMonthlyHours
+	if weekly {
+		code = ViolationWeeklyHours
+	}
+
+	for _, key := range keys {
+		hours := float64(totals[key]) / 60
+		period := "月間"
+		var date *time.Time
+		partial := false
+		if weekly {
+			start, _ := time.Parse("2006-01-02", key)
+			year, week := start.ISOWeek()
+			period = fmt.Sprintf("週間（%d年第%d週）", year, week)
+			if input.Schedule != nil {
+				// 対象月を跨ぐ週は集計が一部のみ
+				partial = start.Before(monthStart) || start.AddDate(0, 0, 6).After(monthEnd)
+				if start.Before(monthStart) {
+					start = monthStart
+				}
+			}
+			date = &start
+		} else if input.Schedule != nil && key != monthStart.Format("2006-01") {
+			// 月末日の日跨ぎ分は翌月分のため評価しない
+			continue
+		}
+
+		if c.cfg.MaxHours > 0 && hours > c.cfg.MaxHours {
+			v := c.violation(code, SeverityWarning,
+				fmt.Sprintf("%s%s労働時間が%.1f時間です（上限: %g時間）", employment, period, hours, c.cfg.MaxHours))
+			v.StaffID = &staffID
+			v.Date = date
+			v.Amount = int(math.Ceil(hours - c.cfg.MaxHours))
+			violations = append(violations, v)
+		}
+		if c.cfg.MinHours > 0 && hours < c.cfg.MinHours && !partial {
+			v := c.violation(code, SeverityInfo,
+				fmt.Sprintf("%s%s労働時間が%.1f時間です（下限: %g時間）", employment, period, hours, c.cfg.MinHours))
+			v.StaffID = &staffID
+			v.Date = date
+			v.Amount = int(math.Ceil(c.cfg.MinHours - hours))
+			violations = append(violations, v)
+		}
+	}
+
+	return violations
+}
+
+// withShiftDate シフト種別と日付を設定した違反
+func withShiftDate(v ConstraintViolation, shiftTypeID domain.ID, date time.Time) ConstraintViolation {
+	v.ShiftTypeID = &shiftTypeID
+	v.Date = &date
+	return v
+}
+
+// countByShift シフト種別ごとの配置人数を集計 絞り込み条件に該当するスタッフのみ数える
+func countByShift(input *ConstraintInput, entries []ScheduleEntry, filter *shiftDomain.CoverageFilter) map[domain.ID]int {
+	counts := make(map[domain.ID]int)
+	for _, entry := range entries {
+		if input.WorkingShift(entry) == nil {
+			continue
+		}
+		if !matchesStaffFilter(entry, filter, input.Qualifications) {
+			continue
+		}
+		counts[*entry.ShiftTypeID]++
+	}
+	return counts
+}
+
+// matchesStaffFilter エントリのスタッフがスキル・職種条件を満たすか
+func matchesStaffFilter(entry ScheduleEntry, filter *shiftDomain.CoverageFilter, q *StaffQualifications) bool {
+	staffID := entry.StaffID.String()
+	if filter.SkillID != "" && !q.HasSkill(staffID, filter.SkillID, filter.MinSkillLevel) {
+		return false
+	}
+	if filter.JobTypeID != "" && !q.HasJobType(staffID, filter.JobTypeID, entry.TargetDate) {
+		return false
+	}
+	return true
+}
+
+// coverageSubject 絞り込み条件の表示名 例: 看護師・IV保有(Lv2以上)
+func coverageSubject(filter *shiftDomain.CoverageFilter, q *StaffQualifications) string {
+	parts := make([]string, 0, 2)
+	if filter.JobTypeID != "" {
+		name := q.JobTypeName(filter.JobTypeID)
+		if name == "" {
+			name = "指定職種"
+		}
+		parts = append(parts, name)
+	}
+	if filter.SkillID != "" {
+		name := q.SkillName(filter.SkillID)
+		if name == "" {
+			name = "指定スキル"
+		}
+		name += "保有"
+		if filter.MinSkillLevel > 0 {
+			name += fmt.Sprintf("(Lv%d以上)", filter.MinSkillLevel)
+		}
+		parts = append(parts, name)
+	}
+	return strings.Join(parts, "・")
+}
+
+// coverageMessage 配置人数違反メッセージ limitLabelは「必要」または「上限」
+func coverageMessage(date time.Time, shiftName, subject string, count int, limitLabel string, limit int) string {
+	if subject == "" {
+		return fmt.Sprintf("%s %sの配置が%d名です（%s: %d名）", date.Format("1/2"), shiftName, count, limitLabel, limit)
+	}
+	return fmt.Sprintf("%s %sの%sが%d名です（%s: %d名）", date.Format("1/2"), shiftName, subject, count, limitLabel, limit)
+}
+
+// targetShiftTypes 評価対象のシフト種別 指定なしは全勤務シフト
+func targetShiftTypes(shiftTypeID string, shiftTypes map[string]*shiftDomain.ShiftType) []*shiftDomain.ShiftType {
+	result := make([]*shiftDomain.ShiftType, 0)
+	for id, st := range shiftTypes {
+		if st.IsHoliday {
+			continue
+		}
+		if shiftTypeID == "" || id == shiftTypeID {
+			result = append(result, st)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].SortOrder < result[j].SortOrder
+	})
+	return result
+}
+
+// weekStart 日付の属するISO週の月曜日
+func weekStart(date time.Time) time.Time {
+	offset := (int(date.Weekday()) + 6) % 7
+	return dayOf(date, time.UTC).AddDate(0, 0, -offset)
+}
+
+// overnightMinutes 日跨ぎシフトの翌日分の労働時間 休憩は当日分から差し引く
+func overnightMinutes(shiftType *shiftDomain.ShiftType, minutes int) int {
+	start := shiftType.StartTime.Hour()*60 + shiftType.StartTime.Minute()
+	end := shiftType.EndTime.Hour()*60 + shiftType.EndTime.Minute()
+	if end >= start {
+		return 0
+	}
+	if end > minutes {
+		return minutes
+	}
+	return end
+}
@@ -0,0 +1,742 @@
+// Package domain 勤務表制約テスト
+package domain
+
+import (
+	"testing"
+	"time"
+
+	requestDomain "shiftmaster/internal/modules/request/domain"
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
+	staffDomain "shiftmaster/internal/modules/staff/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// testShiftType テスト用シフト種別 開始・終了は"HH:MM"
+func testShiftType(name, start, end string) *shiftDomain.ShiftType {
+	clock := func(s string) time.Time {
+		c, _ := time.Parse("15:04", s)
+		return c
+	}
+	return &shiftDomain.ShiftType{ID: sharedDomain.NewID(), Name: name, StartTime: clock(start), EndTime: clock(end)}
+}
+
+// shiftTypesOf シフト種別マップを構築
+func shiftTypesOf(shiftTypes ...*shiftDomain.ShiftType) map[string]*shiftDomain.ShiftType {
+	result := make(map[string]*shiftDomain.ShiftType, len(shiftTypes))
+	for _, st := range shiftTypes {
+		result[st.ID.String()] = st
+	}
+	return result
+}
+
+// dateOf 違反の日付文字列 日付なしは空文字
+func dateOf(v ConstraintViolation) string {
+	if v.Date == nil {
+		return ""
+	}
+	return v.Date.Format("2006-01-02")
+}
+
+func TestNewConstraintPipeline(t *testing.T) {
+	rules := []shiftDomain.ShiftRule{
+		{Name: "低優先", RuleType: shiftDomain.RuleTypeNightLimit, Priority: 1, IsActive: true, Config: `{"max_per_month":8}`},
+		{Name: "高優先", RuleType: shiftDomain.RuleTypeConsecutive, Priority: 10, IsActive: true, Config: `{"max_days":5}`},
+		{Name: "無効", RuleType: shiftDomain.RuleTypeInterval, IsActive: false, Config: `{"min_hours":11}`},
+		{Name: "設定不正", RuleType: shiftDomain.RuleTypeInterval, IsActive: true, Config: `{"min_hours":"x"}`},
+	}
+
+	var invalid []string
+	pipeline := NewConstraintPipeline(rules, func(rule shiftDomain.ShiftRule, _ error) {
+		invalid = append(invalid, rule.Name)
+	})
+
+	names := make([]string, 0)
+	for _, c := range pipeline.Constraints() {
+		names = append(names, c.Name())
+	}
+	want := []string{"高優先", "低優先", "勤務時間の重複", "勤務可能条件", "勤務希望", "シフト未割り当て"}
+	if len(names) != len(want) {
+		t.Fatalf("constraints = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("constraints[%d] = %s, want %s", i, names[i], want[i])
+		}
+	}
+	if len(invalid) != 1 || invalid[0] != "設定不正" {
+		t.Errorf("invalid = %v, want [設定不正]", invalid)
+	}
+}
+
+func TestRegisterConstraint(t *testing.T) {
+	original := constraintFactories[shiftDomain.RuleTypeNightLimit]
+	defer RegisterConstraint(shiftDomain.RuleTypeNightLimit, original)
+
+	RegisterConstraint(shiftDomain.RuleTypeNightLimit, func(_ shiftDomain.ShiftRule, _ shiftDomain.RuleConfig) Constraint {
+		return UnassignedShiftConstraint{}
+	})
+
+	rule := shiftDomain.ShiftRule{Name: "夜勤上限", RuleType: shiftDomain.RuleTypeNightLimit, IsActive: true, Config: `{"max_per_month":8}`}
+	c, err := NewRuleConstraint(rule)
+	if err != nil {
+		t.Fatalf("NewRuleConstraint() error = %v", err)
+	}
+	if _, ok := c.(UnassignedShiftConstraint); !ok {
+		t.Errorf("NewRuleConstraint() = %T, want 登録した制約", c)
+	}
+}
+
+func TestTimeOverlapConstraint_SameDay(t *testing.T) {
+	staffID := sharedDomain.NewID()
+	targetDate := time.Date(2025, 1, 15, 0, 0, 0, 0, time.Local)
+
+	holiday := testShiftType("公休", "09:00", "17:00")
+	holiday.IsHoliday = true
+
+	tests := []struct {
+		name           string
+		shift1         *shiftDomain.ShiftType
+		shift2         *shiftDomain.ShiftType
+		wantViolations int
+	}{
+		{"重複あり_日勤同士", testShiftType("日勤", "09:00", "17:00"), testShiftType("遅番", "12:00", "20:00"), 1},
+		{"重複なし_連続シフト", testShiftType("日勤", "09:00", "17:00"), testShiftType("準夜", "17:00", "22:00"), 0},
+		{"重複あり_完全包含", testShiftType("長日勤", "08:00", "20:00"), testShiftType("日勤", "10:00", "18:00"), 1},
+		{"公休はスキップ", testShiftType("日勤", "09:00", "17:00"), holiday, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := &ConstraintInput{
+				Entries: []ScheduleEntry{
+					{ID: sharedDomain.NewID(), StaffID: staffID, TargetDate: targetDate, ShiftTypeID: &tt.shift1.ID},
+					{ID: sharedDomain.NewID(), StaffID: staffID, TargetDate: targetDate, ShiftTypeID: &tt.shift2.ID},
+				},
+				ShiftTypes: shiftTypesOf(tt.shift1, tt.shift2),
+			}
+
+			violations := TimeOverlapConstraint{}.Evaluate(input)
+			if len(violations) != tt.wantViolations {
+				t.Errorf("violations = %d, want %d", len(violations), tt.wantViolations)
+			}
+		})
+	}
+}
+
+func TestTimeOverlapConstraint_ConsecutiveDays(t *testing.T) {
+	staffID := sharedDomain.NewID()
+	day1 := time.Date(2025, 1, 15, 0, 0, 0, 0, time.Local)
+	day2 := time.Date(2025, 1, 16, 0, 0, 0, 0, time.Local)
+
+	night := testShiftType("夜勤", "17:00", "09:00")
+	day := testShiftType("日勤", "09:00", "17:00")
+	early := testShiftType("早番", "06:00", "14:00")
+
+	tests := []struct {
+		name           string
+		day1Shift      *shiftDomain.ShiftType
+		day2Shift      *shiftDomain.ShiftType
+		wantViolations int
+	}{
+		{"夜勤後の早番_重複あり", night, early, 1},
+		{"夜勤後の日勤_重複なし", night, day, 0},
+		{"日勤後の早番_重複なし", day, early, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := &ConstraintInput{
+				// 日付順に並べ替えて評価することを確認するため翌日を先に置く
+				Entries: []ScheduleEntry{
+					{ID: sharedDomain.NewID(), StaffID: staffID, TargetDate: day2, ShiftTypeID: &tt.day2Shift.ID},
+					{ID: sharedDomain.NewID(), StaffID: staffID, TargetDate: day1, ShiftTypeID: &tt.day1Shift.ID},
+				},
+				ShiftTypes: shiftTypesOf(tt.day1Shift, tt.day2Shift),
+			}
+
+			violations := TimeOverlapConstraint{}.Evaluate(input)
+			if len(violations) != tt.wantViolations {
+				t.Errorf("violations = %d, want %d", len(violations), tt.wantViolations)
+			}
+		})
+	}
+}
+
+func TestTimeOverlapConstraint_DifferentStaff(t *testing.T) {
+	targetDate := time.Date(2025, 1, 15, 0, 0, 0, 0, time.Local)
+	day := testShiftType("日勤", "09:00", "17:00")
+
+	// 異なるスタッフなら同じ時間でも重複なし
+	input := &ConstraintInput{
+		Entries: []ScheduleEntry{
+			{ID: sharedDomain.NewID(), StaffID: sharedDomain.NewID(), TargetDate: targetDate, ShiftTypeID: &day.ID},
+			{ID: sharedDomain.NewID(), StaffID: sharedDomain.NewID(), TargetDate: targetDate, ShiftTypeID: &day.ID},
+		},
+		ShiftTypes: shiftTypesOf(day),
+	}
+
+	if violations := (TimeOverlapConstraint{}).Evaluate(input); len(violations) != 0 {
+		t.Errorf("異なるスタッフなら重複なし、violations = %d, want 0", len(violations))
+	}
+}
+
+func TestAvailabilityConstraint(t *testing.T) {
+	day := testShiftType("日勤", "09:00", "17:00")
+	late := testShiftType("遅番", "12:00", "20:00")
+	early := testShiftType("早番", "07:00", "15:00")
+	early.HandoverMinutes = 30
+	night := testShiftType("夜勤", "16:00", "09:00")
+	holiday := testShiftType("公休", "00:00", "00:00")
+	holiday.IsHoliday = true
+
+	partTimer := sharedDomain.NewID()
+	unrestricted := sharedDomain.NewID()
+	until := sharedDomain.MustParseTimeOfDay("17:00")
+	from := sharedDomain.MustParseTimeOfDay("07:00")
+	availability := staffDomain.NewStaffAvailability(partTimer)
+	if err := availability.Update([]staffDomain.WeeklyAvailability{
+		{Weekday: time.Tuesday, Unavailable: true},
+		{Weekday: time.Wednesday, From: &from, Until: &until},
+	}, []sharedDomain.ID{day.ID, late.ID, early.ID, holiday.ID}, 3, "", ""); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	// 2025-01-06は月曜日
+	date := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC) }
+	entry := func(staffID sharedDomain.ID, d int, st *shiftDomain.ShiftType) ScheduleEntry {
+		return ScheduleEntry{ID: sharedDomain.NewID(), StaffID: staffID, TargetDate: date(d), ShiftTypeID: &st.ID}
+	}
+	input := &ConstraintInput{
+		Entries: []ScheduleEntry{
+			entry(partTimer, 6, day),      // 月 条件なし
+			entry(partTimer, 7, day),      // 火 勤務不可
+			entry(partTimer, 8, late),     // 水 17:00を超える
+			entry(partTimer, 9, night),    // 木 勤務可能でないシフト種別 週4日目
+			entry(partTimer, 10, holiday), // 金 休日は数えない
+			entry(partTimer, 11, day),     // 土 週の上限超過は1件のみ報告
+			entry(partTimer, 15, early),   // 翌週水 申し送り込みで6:30出勤
+			entry(unrestricted, 7, night),
+		},
+		ShiftTypes:     shiftTypesOf(day, late, early, night, holiday),
+		Qualifications: &StaffQualifications{Availabilities: map[string]*staffDomain.StaffAvailability{partTimer.String(): availability}},
+	}
+
+	violations := AvailabilityConstraint{}.Evaluate(input)
+	got := make([]string, 0, len(violations))
+	for _, v := range violations {
+		if v.ConstraintType != ViolationAvailability || *v.StaffID != partTimer {
+			t.Errorf("violation = %+v", v)
+		}
+		got = append(got, dateOf(v))
+	}
+	want := []string{"2025-01-07", "2025-01-08", "2025-01-09", "2025-01-09", "2025-01-15"}
+	if len(got) != len(want) {
+		t.Fatalf("violations = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("violations[%d] = %s, want %s", i, got[i], want[i])
+		}
+	}
+
+	if v := (AvailabilityConstraint{}).Evaluate(&ConstraintInput{Entries: input.Entries, ShiftTypes: input.ShiftTypes}); len(v) != 0 {
+		t.Errorf("勤務可能条件なしで違反を報告: %d", len(v))
+	}
+}
+
+func TestConsecutiveConstraint(t *testing.T) {
+	staffID := sharedDomain.NewID()
+	day := testShiftType("日勤", "09:00", "17:00")
+	night := testShiftType("夜勤", "17:00", "09:00")
+	night.IsNightShift = true
+	rule := shiftDomain.ShiftRule{Name: "連続勤務"}
+
+	tests := []struct {
+		name           string
+		shift          *shiftDomain.ShiftType
+		days           int
+		cfg            *shiftDomain.ConsecutiveConfig
+		wantViolations int
+		wantType       string
+	}{
+		{"5連勤_上限5_違反なし", day, 5, &shiftDomain.ConsecutiveConfig{MaxDays: 5}, 0, ""},
+		{"6連勤_上限5_違反あり", day, 6, &shiftDomain.ConsecutiveConfig{MaxDays: 5}, 1, ViolationConsecutiveWork},
+		{"7連勤_上限5_区間ごとに1件", day, 7, &shiftDomain.ConsecutiveConfig{MaxDays: 5}, 1, ViolationConsecutiveWork},
+		{"3連勤_上限5_違反なし", day, 3, &shiftDomain.ConsecutiveConfig{MaxDays: 5}, 0, ""},
+		{"日勤の連続は夜勤限定ルールの対象外", day, 4, &shiftDomain.ConsecutiveConfig{MaxDays: 2, NightOnly: true}, 0, ""},
+		{"3連続夜勤_上限2", night, 3, &shiftDomain.ConsecutiveConfig{MaxDays: 2, NightOnly: true}, 1, ViolationConsecutiveNight},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var entries []ScheduleEntry
+			baseDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local)
+			for i := 0; i < tt.days; i++ {
+				entries = append(entries, ScheduleEntry{
+					ID:          sharedDomain.NewID(),
+					StaffID:     staffID,
+					TargetDate:  baseDate.AddDate(0, 0, i),
+					ShiftTypeID: &tt.shift.ID,
+				})
+			}
+
+			violations := newConsecutiveConstraint(rule, tt.cfg).Evaluate(&ConstraintInput{Entries: entries, ShiftTypes: shiftTypesOf(tt.shift)})
+			if len(violations) != tt.wantViolations {
+				t.Fatalf("violations = %d, want %d", len(violations), tt.wantViolations)
+			}
+			if tt.wantViolations > 0 {
+				v := violations[0]
+				if v.ConstraintType != tt.wantType || dateOf(v) != "2025-01-01" || v.RuleName != rule.Name {
+					t.Errorf("violation = %+v", v)
+				}
+			}
+		})
+	}
+}
+
+func TestShiftRequestConstraint(t *testing.T) {
+	day := testShiftType("日勤", "09:00", "17:00")
+	off := testShiftType("公休", "00:00", "00:00")
+	off.IsHoliday = true
+	staffID := sharedDomain.NewID()
+	date := func(d int) time.Time { return time.Date(2025, 4, d, 0, 0, 0, 0, time.UTC) }
+
+	input := &ConstraintInput{
+		Schedule: &Schedule{TargetYear: 2025, TargetMonth: 4},
+		Entries: []ScheduleEntry{
+			{StaffID: staffID, TargetDate: date(1), ShiftTypeID: &day.ID},
+			{StaffID: staffID, TargetDate: date(2), ShiftTypeID: &day.ID},
+		},
+		ShiftTypes: shiftTypesOf(day, off),
+		Requests: []requestDomain.ShiftRequest{
+			// 1日の日勤希望は反映 2日の休み固定希望は未反映 3日は休み希望で未割り当てのため反映
+			{StaffID: staffID, TargetDate: date(1), ShiftTypeID: &day.ID, RequestType: requestDomain.RequestTypePreferred, Priority: requestDomain.PriorityOptional},
+			{StaffID: staffID, TargetDate: date(2), ShiftTypeID: &off.ID, RequestType: requestDomain.RequestTypeFixed, Priority: requestDomain.PriorityRequired},
+			{StaffID: staffID, TargetDate: date(3), ShiftTypeID: &off.ID, RequestType: requestDomain.RequestTypePreferred, Priority: requestDomain.PriorityOptional},
+			// 対象月外
+			{StaffID: staffID, TargetDate: date(1).AddDate(0, 1, 0), RequestType: requestDomain.RequestTypePreferred, Priority: requestDomain.PriorityOptional},
+		},
+	}
+
+	results := JudgeShiftRequests(input)
+	if len(results) != 3 {
+		t.Fatalf("results = %d, want 3", len(results))
+	}
+
+	violations := ShiftRequestConstraint{}.Evaluate(input)
+	if len(violations) != 1 {
+		t.Fatalf("violations = %d, want 1: %+v", len(violations), violations)
+	}
+	if v := violations[0]; dateOf(v) != "2025-04-02" || v.Severity != SeverityError || v.ShiftTypeID == nil || *v.ShiftTypeID != off.ID {
+		t.Errorf("violation = %+v", v)
+	}
+}
+
+func TestUnassignedShiftConstraint(t *testing.T) {
+	day := testShiftType("日勤", "09:00", "17:00")
+	date := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	input := &ConstraintInput{
+		Entries: []ScheduleEntry{
+			{StaffID: sharedDomain.NewID(), TargetDate: date},
+			{StaffID: sharedDomain.NewID(), TargetDate: date, IsConfirmed: true},
+			{StaffID: sharedDomain.NewID(), TargetDate: date, ShiftTypeID: &day.ID},
+		},
+		ShiftTypes: shiftTypesOf(day),
+	}
+
+	violations := UnassignedShiftConstraint{}.Evaluate(input)
+	if len(violations) != 1 || violations[0].Severity != SeverityInfo {
+		t.Errorf("violations = %+v, want 1 info", violations)
+	}
+}
+
+func TestIntervalConstraint(t *testing.T) {
+	clock := func(s string) time.Time {
+		c, _ := time.Parse("15:04", s)
+		return c
+	}
+	late := &shiftDomain.ShiftType{ID: sharedDomain.NewID(), Name: "遅番", StartTime: clock("13:00"), EndTime: clock("22:00")}
+	early := &shiftDomain.ShiftType{ID: sharedDomain.NewID(), Name: "早番", StartTime: clock("07:00"), EndTime: clock("16:00")}
+	night := &shiftDomain.ShiftType{ID: sharedDomain.NewID(), Name: "夜勤", StartTime: clock("22:00"), EndTime: clock("07:00"), IsNightShift: true}
+	day := &shiftDomain.ShiftType{ID: sharedDomain.NewID(), Name: "日勤", StartTime: clock("09:00"), EndTime: clock("18:00")}
+	dayHandover := &shiftDomain.ShiftType{ID: sharedDomain.NewID(), Name: "日勤（申し送り）", StartTime: clock("09:00"), EndTime: clock("18:00"), HandoverMinutes: 30}
+	off := &shiftDomain.ShiftType{ID: sharedDomain.NewID(), Name: "公休", IsHoliday: true}
+	shiftTypeMap := map[string]*shiftDomain.ShiftType{
+		late.ID.String():        late,
+		early.ID.String():       early,
+		night.ID.String():       night,
+		day.ID.String():         day,
+		dayHandover.ID.String(): dayHandover,
+		off.ID.String():         off,
+	}
+	entry := func(day int, st *shiftDomain.ShiftType) ScheduleEntry {
+		return ScheduleEntry{TargetDate: time.Date(2025, 2, day, 0, 0, 0, 0, time.UTC), ShiftTypeID: &st.ID}
+	}
+	rule := shiftDomain.ShiftRule{Name: "インターバル"}
+	cfg := &shiftDomain.IntervalConfig{MinHours: 11}
+
+	tests := []struct {
+		name    string
+		entries []ScheduleEntry
+		want    int
+	}{
+		{"遅番から早番は9時間", []ScheduleEntry{entry(1, late), entry(2, early)}, 1},
+		{"早番から遅番は21時間", []ScheduleEntry{entry(1, early), entry(2, late)}, 0},
+		{"夜勤明けの遅番は6時間", []ScheduleEntry{entry(1, night), entry(2, late)}, 1},
+		{"連続しない日付は休息が十分", []ScheduleEntry{entry(1, late), entry(3, early)}, 0},
+		{"遅番から日勤はちょうど11時間", []ScheduleEntry{entry(1, late), entry(2, day)}, 0},
+		{"申し送り時間を含めると11時間未満", []ScheduleEntry{entry(1, late), entry(2, dayHandover)}, 1},
+		{"休日は間隔の起点にならない", []ScheduleEntry{entry(1, late), entry(2, off), entry(3, early)}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newIntervalConstraint(rule, cfg).Evaluate(&ConstraintInput{Entries: tt.entries, ShiftTypes: shiftTypeMap})
+			if len(got) != tt.want {
+				t.Errorf("violations = %d, want %d: %+v", len(got), tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWorkingHoursConstraint(t *testing.T) {
+	clock := func(s string) time.Time {
+		c, _ := time.Parse("15:04", s)
+		return c
+	}
+	day := &shiftDomain.ShiftType{ID: sharedDomain.NewID(), Name: "日勤", StartTime: clock("09:00"), EndTime: clock("18:00"), BreakMinutes: 60, HandoverMinutes: 60}
+	night := &shiftDomain.ShiftType{ID: sharedDomain.NewID(), Name: "夜勤", StartTime: clock("16:00"), EndTime: clock("09:00"), BreakMinutes: 120, IsNightShift: true}
+	shiftTypeMap := map[string]*shiftDomain.ShiftType{
+		day.ID.String():   day,
+		night.ID.String(): night,
+	}
+	staffID := sharedDomain.NewID()
+	input := &ConstraintInput{
+		// 2025年2月 1日(土)〜28日(金)
+		Schedule:   &Schedule{TargetYear: 2025, TargetMonth: 2},
+		ShiftTypes: shiftTypeMap,
+		Qualifications: &StaffQualifications{Staffs: map[string]*staffDomain.Staff{
+			staffID.String(): {ID: staffID, EmploymentType: staffDomain.EmploymentPartTime},
+		}},
+	}
+	entry := func(d int, st *shiftDomain.ShiftType) ScheduleEntry {
+		return ScheduleEntry{StaffID: staffID, TargetDate: time.Date(2025, 2, d, 0, 0, 0, 0, time.UTC), ShiftTypeID: &st.ID}
+	}
+	weekly := shiftDomain.ShiftRule{Name: "週間", RuleType: shiftDomain.RuleTypeWeeklyHours}
+	monthly := shiftDomain.ShiftRule{Name: "月間", RuleType: shiftDomain.RuleTypeMonthlyHours}
+
+	tests := []struct {
+		name     string
+		rule     shiftDomain.ShiftRule
+		cfg      *shiftDomain.WorkingHoursConfig
+		entries  []ScheduleEntry
+		input    *ConstraintInput
+		want     int
+		wantDate string
+	}{
+		{
+			// 3日(月)〜7日(金) 日勤8h×5 = 40h
+			name:    "週40時間は上限内",
+			rule:    weekly,
+			cfg:     &shiftDomain.WorkingHoursConfig{MaxHours: 40},
+			entries: []ScheduleEntry{entry(3, day), entry(4, day), entry(5, day), entry(6, day), entry(7, day)},
+			input:   input,
+			want:    0,
+		},
+		{
+			// 日曜夜勤の翌日分9hは翌週に計上 当週は日勤8h×4 + 夜勤6h = 38h、翌週は9h
+			name:    "日跨ぎ分は翌週に計上",
+			rule:    weekly,
+			cfg:     &shiftDomain.WorkingHoursConfig{MaxHours: 38.5},
+			entries: []ScheduleEntry{entry(3, day), entry(4, day), entry(5, day), entry(6, day), entry(9, night)},
+			input:   input,
+			want:    0,
+		},
+		{
+			name:     "日跨ぎ分を含めて週上限超過",
+			rule:     weekly,
+			cfg:      &shiftDomain.WorkingHoursConfig{MaxHours: 12},
+			entries:  []ScheduleEntry{entry(9, night), entry(10, day)},
+			input:    input,
+			want:     1,
+			wantDate: "2025-02-10",
+		},
+		{
+			name:    "申し送り時間を除外",
+			rule:    weekly,
+			cfg:     &shiftDomain.WorkingHoursConfig{MaxHours: 35, ExcludeHandover: true},
+			entries: []ScheduleEntry{entry(3, day), entry(4, day), entry(5, day), entry(6, day), entry(7, day)},
+			input:   input,
+			want:    0,
+		},
+		{
+			// 1日(土)を含む週は月初を跨ぐため下限を評価しない
+			name:    "月を跨ぐ週は下限対象外",
+			rule:    weekly,
+			cfg:     &shiftDomain.WorkingHoursConfig{MinHours: 20},
+			entries: []ScheduleEntry{entry(1, day), entry(3, day)},
+			input:   input,
+			want:    1,
+		},
+		{
+			name:    "雇用形態が一致しない",
+			rule:    monthly,
+			cfg:     &shiftDomain.WorkingHoursConfig{EmploymentType: "full_time", MaxHours: 8},
+			entries: []ScheduleEntry{entry(3, day), entry(4, day)},
+			input:   input,
+			want:    0,
+		},
+		{
+			name:    "雇用形態が一致",
+			rule:    monthly,
+			cfg:     &shiftDomain.WorkingHoursConfig{EmploymentType: "part_time", MaxHours: 8},
+			entries: []ScheduleEntry{entry(3, day), entry(4, day)},
+			input:   input,
+			want:    1,
+		},
+		{
+			name:    "資格情報なしでは雇用形態指定ルールを評価しない",
+			rule:    monthly,
+			cfg:     &shiftDomain.WorkingHoursConfig{EmploymentType: "part_time", MaxHours: 8},
+			entries: []ScheduleEntry{entry(3, day), entry(4, day)},
+			input:   &ConstraintInput{Schedule: input.Schedule, ShiftTypes: shiftTypeMap},
+			want:    0,
+		},
+		{
+			// 月末夜勤の翌日分9hは翌月分 当月は6h
+			name:    "月末の日跨ぎ分は当月に含めない",
+			rule:    monthly,
+			cfg:     &shiftDomain.WorkingHoursConfig{MaxHours: 6},
+			entries: []ScheduleEntry{entry(28, night)},
+			input:   input,
+			want:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := *tt.input
+			in.Entries = tt.entries
+			got := newWorkingHoursConstraint(tt.rule, tt.cfg).Evaluate(&in)
+			if len(got) != tt.want {
+				t.Fatalf("violations = %d, want %d: %+v", len(got), tt.want, got)
+			}
+			if tt.wantDate != "" && dateOf(got[0]) != tt.wantDate {
+				t.Errorf("Date = %s, want %s", dateOf(got[0]), tt.wantDate)
+			}
+		})
+	}
+}
+
+func TestStaffCoverageConstraint(t *testing.T) {
+	night := &shiftDomain.ShiftType{ID: sharedDomain.NewID(), Name: "夜勤", IsNightShift: true}
+	shiftTypeMap := map[string]*shiftDomain.ShiftType{night.ID.String(): night}
+	schedule := &Schedule{TargetYear: 2025, TargetMonth: 2}
+
+	skillID := sharedDomain.NewID()
+	nurseID := sharedDomain.NewID()
+	expert := staffDomain.Staff{ID: sharedDomain.NewID(), Skills: []staffDomain.StaffSkill{{SkillID: skillID, Level: 3}}}
+	novice := staffDomain.Staff{ID: sharedDomain.NewID(), Skills: []staffDomain.StaffSkill{{SkillID: skillID, Level: 1}}}
+	ended := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+	qualifications := &StaffQualifications{
+		Staffs: map[string]*staffDomain.Staff{
+			expert.ID.String(): &expert,
+			novice.ID.String(): &novice,
+		},
+		Assignments: map[string][]staffDomain.StaffAssignment{
+			expert.ID.String(): {{StaffID: expert.ID, JobTypeID: &nurseID}},
+			novice.ID.String(): {{StaffID: novice.ID, JobTypeID: &nurseID, EndDate: &ended}},
+		},
+		SkillNames:   map[string]string{skillID.String(): "IV"},
+		JobTypeNames: map[string]string{nurseID.String(): "看護師"},
+	}
+
+	entry := func(staff staffDomain.Staff, day int) ScheduleEntry {
+		return ScheduleEntry{StaffID: staff.ID, TargetDate: time.Date(2025, 2, day, 0, 0, 0, 0, time.UTC), ShiftTypeID: &night.ID}
+	}
+	// 2/14は熟練者と新人、2/15は新人のみ夜勤
+	entries := []ScheduleEntry{entry(expert, 14), entry(novice, 14), entry(novice, 15)}
+	rule := shiftDomain.ShiftRule{Name: "夜勤体制"}
+
+	onDate := func(violations []ConstraintViolation, date string) []ConstraintViolation {
+		result := make([]ConstraintViolation, 0)
+		for _, v := range violations {
+			if dateOf(v) == date {
+				result = append(result, v)
+			}
+		}
+		return result
+	}
+
+	t.Run("土日祝のみ評価", func(t *testing.T) {
+		cfg := &shiftDomain.MinStaffConfig{CoverageFilter: shiftDomain.CoverageFilter{DayKind: shiftDomain.DayKindHoliday}, MinCount: 1}
+		got := newStaffCoverageConstraint(rule, cfg).Evaluate(&ConstraintInput{Schedule: schedule, ShiftTypes: shiftTypeMap})
+		// 2025年2月の土日8日と建国記念の日・振替休日
+		if len(got) != 10 {
+			t.Errorf("violations = %d, want 10", len(got))
+		}
+		if len(onDate(got, "2025-02-24")) != 1 {
+			t.Errorf("振替休日が対象になっていません: %+v", got)
+		}
+	})
+
+	t.Run("平日のみ評価", func(t *testing.T) {
+		cfg := &shiftDomain.MinStaffConfig{CoverageFilter: shiftDomain.CoverageFilter{DayKind: shiftDomain.DayKindWeekday}, MinCount: 1}
+		got := newStaffCoverageConstraint(rule, cfg).Evaluate(&ConstraintInput{Schedule: schedule, Entries: entries, ShiftTypes: shiftTypeMap})
+		// 平日18日のうち2/14のみ配置あり
+		if len(got) != 17 {
+			t.Errorf("violations = %d, want 17", len(got))
+		}
+	})
+
+	t.Run("スキルレベルで絞り込み", func(t *testing.T) {
+		cfg := &shiftDomain.MinStaffConfig{
+			CoverageFilter: shiftDomain.CoverageFilter{ShiftTypeID: night.ID.String(), SkillID: skillID.String(), MinSkillLevel: 2},
+			MinCount:       2,
+		}
+		got := onDate(newStaffCoverageConstraint(rule, cfg).Evaluate(&ConstraintInput{
+			Schedule: schedule, Entries: entries, ShiftTypes: shiftTypeMap, Qualifications: qualifications,
+		}), "2025-02-14")
+		if len(got) != 1 {
+			t.Fatalf("violations = %d, want 1", len(got))
+		}
+		want := "2/14 夜勤のIV保有(Lv2以上)が1名です（必要: 2名）"
+		if got[0].Message != want || got[0].ShiftTypeID == nil || *got[0].ShiftTypeID != night.ID {
+			t.Errorf("violation = %+v, want message %q", got[0], want)
+		}
+	})
+
+	t.Run("職種は所属期間内のみ数える", func(t *testing.T) {
+		cfg := &shiftDomain.MinStaffConfig{CoverageFilter: shiftDomain.CoverageFilter{JobTypeID: nurseID.String()}, MinCount: 1}
+		got := newStaffCoverageConstraint(rule, cfg).Evaluate(&ConstraintInput{
+			Schedule: schedule, Entries: entries, ShiftTypes: shiftTypeMap, Qualifications: qualifications,
+		})
+		if len(onDate(got, "2025-02-14")) != 0 {
+			t.Errorf("2/14は看護師が配置されています: %+v", onDate(got, "2025-02-14"))
+		}
+		if v := onDate(got, "2025-02-15"); len(v) != 1 || v[0].Message != "2/15 夜勤の看護師が0名です（必要: 1名）" {
+			t.Errorf("2/15 violations = %+v", v)
+		}
+	})
+
+	t.Run("資格情報がない場合は絞り込みルールを評価しない", func(t *testing.T) {
+		cfg := &shiftDomain.MinStaffConfig{CoverageFilter: shiftDomain.CoverageFilter{SkillID: skillID.String()}, MinCount: 1}
+		got := newStaffCoverageConstraint(rule, cfg).Evaluate(&ConstraintInput{Schedule: schedule, Entries: entries, ShiftTypes: shiftTypeMap})
+		if len(got) != 0 {
+			t.Errorf("violations = %d, want 0", len(got))
+		}
+	})
+
+	t.Run("職種ごとの上限", func(t *testing.T) {
+		cfg := &shiftDomain.MaxStaffConfig{CoverageFilter: shiftDomain.CoverageFilter{JobTypeID: nurseID.String()}, MaxCount: 1}
+		assignments := map[string][]staffDomain.StaffAssignment{
+			expert.ID.String(): qualifications.Assignments[expert.ID.String()],
+			novice.ID.String(): {{StaffID: novice.ID, JobTypeID: &nurseID}},
+		}
+		q := *qualifications
+		q.Assignments = assignments
+		got := newStaffCoverageConstraint(rule, cfg).Evaluate(&ConstraintInput{
+			Schedule: schedule, Entries: entries, ShiftTypes: shiftTypeMap, Qualifications: &q,
+		})
+		if len(got) != 1 || dateOf(got[0]) != "2025-02-14" || got[0].ConstraintType != ViolationCoverageExcess {
+			t.Errorf("violations = %+v", got)
+		}
+	})
+}
+
+func TestQualifiedStaffConstraint(t *testing.T) {
+	night := &shiftDomain.ShiftType{ID: sharedDomain.NewID(), Name: "夜勤", IsNightShift: true}
+	shiftTypeMap := map[string]*shiftDomain.ShiftType{night.ID.String(): night}
+	schedule := &Schedule{TargetYear: 2025, TargetMonth: 3}
+
+	skillID := sharedDomain.NewID()
+	leader := staffDomain.Position{ID: sharedDomain.NewID(), Name: "主任", Level: 2}
+	director := staffDomain.Position{ID: sharedDomain.NewID(), Name: "師長", Level: 1}
+	expert := staffDomain.Staff{ID: sharedDomain.NewID(), Skills: []staffDomain.StaffSkill{{SkillID: skillID, Level: 3}}}
+	novice := staffDomain.Staff{ID: sharedDomain.NewID(), Skills: []staffDomain.StaffSkill{{SkillID: skillID, Level: 2}}}
+	promoted := time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)
+	qualifications := &StaffQualifications{
+		Staffs: map[string]*staffDomain.Staff{
+			expert.ID.String(): &expert,
+			novice.ID.String(): &novice,
+		},
+		Assignments: map[string][]staffDomain.StaffAssignment{
+			// 新人は3/15から主任
+			novice.ID.String(): {{StaffID: novice.ID, PositionID: &leader.ID, StartDate: &promoted}},
+		},
+		SkillNames: map[string]string{skillID.String(): "IV"},
+		Positions: map[string]*staffDomain.Position{
+			leader.ID.String():   &leader,
+			director.ID.String(): &director,
+		},
+	}
+
+	entry := func(staff staffDomain.Staff, day int) ScheduleEntry {
+		return ScheduleEntry{StaffID: staff.ID, TargetDate: time.Date(2025, 3, day, 0, 0, 0, 0, time.UTC), ShiftTypeID: &night.ID}
+	}
+	// 3/14と3/15は新人のみ 3/16は熟練者のみ夜勤
+	entries := []ScheduleEntry{entry(novice, 14), entry(novice, 15), entry(expert, 16)}
+	input := &ConstraintInput{Schedule: schedule, Entries: entries, ShiftTypes: shiftTypeMap, Qualifications: qualifications}
+	rule := shiftDomain.ShiftRule{Name: "夜勤体制"}
+	level := 2
+
+	tests := []struct {
+		name      string
+		evaluate  func(*ConstraintInput) []ConstraintViolation
+		input     *ConstraintInput
+		wantDates []string
+		wantMsg   string
+	}{
+		{
+			name: "必須スキルはレベル3以上のみ数える",
+			evaluate: func(in *ConstraintInput) []ConstraintViolation {
+				return newSkillRequiredConstraint(rule, &shiftDomain.SkillRequiredConfig{SkillID: skillID.String(), MinLevel: 3, MinCount: 1}).Evaluate(in)
+			},
+			input:     input,
+			wantDates: []string{"2025-03-14", "2025-03-15"},
+			wantMsg:   "3/14 夜勤のIV保有(Lv3以上)が0名です（必要: 1名）",
+		},
+		{
+			name: "必須職位は所属期間内のみ数える",
+			evaluate: func(in *ConstraintInput) []ConstraintViolation {
+				return newPositionRequiredConstraint(rule, &shiftDomain.PositionRequiredConfig{Level: &level, MinCount: 1}).Evaluate(in)
+			},
+			input:     input,
+			wantDates: []string{"2025-03-14", "2025-03-16"},
+			wantMsg:   "3/14 夜勤の主任以上が0名です（必要: 1名）",
+		},
+		{
+			name: "職位指定",
+			evaluate: func(in *ConstraintInput) []ConstraintViolation {
+				return newPositionRequiredConstraint(rule, &shiftDomain.PositionRequiredConfig{PositionID: director.ID.String(), MinCount: 1}).Evaluate(in)
+			},
+			input:     input,
+			wantDates: []string{"2025-03-14", "2025-03-15", "2025-03-16"},
+			wantMsg:   "3/14 夜勤の師長が0名です（必要: 1名）",
+		},
+		{
+			name: "資格情報がない場合は評価しない",
+			evaluate: func(in *ConstraintInput) []ConstraintViolation {
+				return newSkillRequiredConstraint(rule, &shiftDomain.SkillRequiredConfig{SkillID: skillID.String(), MinCount: 1}).Evaluate(in)
+			},
+			input: &ConstraintInput{Schedule: schedule, Entries: entries, ShiftTypes: shiftTypeMap},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.evaluate(tt.input)
+			if len(got) != len(tt.wantDates) {
+				t.Fatalf("violations = %d, want %d: %+v", len(got), len(tt.wantDates), got)
+			}
+			for i, v := range got {
+				if dateOf(v) != tt.wantDates[i] {
+					t.Errorf("violations[%d].Date = %s, want %s", i, dateOf(v), tt.wantDates[i])
+				}
+			}
+			if len(got) > 0 && got[0].Message != tt.wantMsg {
+				t.Errorf("Message = %s, want %s", got[0].Message, tt.wantMsg)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ package domain
 import (
 	"time"
 
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
 	"shiftmaster/internal/shared/domain"
 )
 
@@ -118,8 +119,16 @@ type ActualRecord struct {
 	ActualEndTime *time.Time
 	// ActualBreakMinutes 実際の休憩時間
 	ActualBreakMinutes int
-	// OvertimeMinutes 残業時間
+	// OvertimeMinutes 残業時間 法定内残業と法定外残業の合計
 	OvertimeMinutes int
+	// WithinLegalOvertimeMinutes 法定内残業時間
+	WithinLegalOvertimeMinutes int
+	// OverLegalOvertimeMinutes 法定外残業時間
+	OverLegalOvertimeMinutes int
+	// LateNightMinutes 深夜労働時間
+	LateNightMinutes int
+	// HolidayWorkMinutes 休日労働時間
+	HolidayWorkMinutes int
 	// Note 備考
 	Note string
 	// CreatedAt 作成日時
@@ -149,8 +158,8 @@ type OptimizeInput struct {
 	ScheduleID domain.ID
 	// DateRange 対象日範囲
 	DateRange domain.DateRange
-	// Constraints 制約条件
-	Constraints []Constraint
+	// Constraints 追加の制約条件 組織の有効なシフトルールに加えて評価
+	Constraints []ConstraintConfig
 	// Options 最適化オプション
 	Options OptimizeOptions
 }
@@ -179,16 +188,31 @@ type OptimizeResult struct {
 	Duration time.Duration
 }
 
-// Constraint 制約条件
-type Constraint struct {
+// ConstraintConfig 制約条件設定 シフトルールと同じ種別・設定JSONで指定
+type ConstraintConfig struct {
 	// Type 制約種別
 	Type string
+	// Name 制約名
+	Name string
 	// Priority 優先度
 	Priority int
 	// Config 設定JSON
 	Config string
 }
 
+// ToShiftRule 有効なシフトルールへ変換
+func (c ConstraintConfig) ToShiftRule(organizationID domain.ID) shiftDomain.ShiftRule {
+	return shiftDomain.ShiftRule{
+		ID:             domain.NewID(),
+		OrganizationID: organizationID,
+		Name:           c.Name,
+		RuleType:       shiftDomain.ShiftRuleType(c.Type),
+		Priority:       c.Priority,
+		IsActive:       true,
+		Config:         c.Config,
+	}
+}
+
 // ConstraintViolation 制約違反
 type ConstraintViolation struct {
 	// ConstraintType 制約種別
@@ -199,6 +223,13 @@ type ConstraintViolation struct {
 	StaffID *domain.ID
 	// Date 関連日付
 	Date *time.Time
+	// ShiftTypeID 関連シフト種別ID
+	ShiftTypeID *domain.ID
 	// Severity 重大度
 	Severity string
+	// RuleName 違反したルール名
+	RuleName string
+	// Amount 違反の大きさ 不足・超過人数、上限を超えた日数・回数・時間など 自動作成の探索で重み付けに使用
+	// 0は1件として扱う
+	Amount int
 }
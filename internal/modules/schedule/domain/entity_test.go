@@ -501,7 +501,7 @@ func TestOptimizeInput_Structure(t *testing.T) {
 		input := OptimizeInput{
 			ScheduleID: scheduleID,
 			DateRange:  dateRange,
-			Constraints: []Constraint{
+			Constraints: []ConstraintConfig{
 				{Type: "min_staff", Priority: 100},
 			},
 			Options: OptimizeOptions{
@@ -589,9 +589,9 @@ func TestOptimizeResult_Structure(t *testing.T) {
 	})
 }
 
-func TestConstraint_Structure(t *testing.T) {
-	t.Run("Constraint構造体の初期化", func(t *testing.T) {
-		constraint := Constraint{
+func TestConstraintConfig_Structure(t *testing.T) {
+	t.Run("ConstraintConfig構造体の初期化", func(t *testing.T) {
+		constraint := ConstraintConfig{
 			Type:     "min_staff",
 			Priority: 100,
 			Config:   `{"shift_type_id": "xxx", "min_count": 3}`,
@@ -607,6 +607,19 @@ func TestConstraint_Structure(t *testing.T) {
 			t.Errorf("Config = %v, want %v", constraint.Config, `{"shift_type_id": "xxx", "min_count": 3}`)
 		}
 	})
+
+	t.Run("シフトルールへの変換", func(t *testing.T) {
+		orgID := sharedDomain.NewID()
+		constraint := ConstraintConfig{Type: "night_limit", Name: "夜勤上限", Priority: 5, Config: `{"max_per_month":6}`}
+
+		rule := constraint.ToShiftRule(orgID)
+		if rule.OrganizationID != orgID || rule.Name != "夜勤上限" || rule.Priority != 5 || !rule.IsActive {
+			t.Errorf("ToShiftRule() = %+v", rule)
+		}
+		if _, err := rule.ParseConfig(); err != nil {
+			t.Errorf("ParseConfig() error = %v", err)
+		}
+	})
 }
 
 func TestConstraintViolation_Structure(t *testing.T) {
@@ -0,0 +1,271 @@
+// Package domain 勤務表ドメイン層
+package domain
+
+import (
+	"time"
+
+	"shiftmaster/internal/shared/domain"
+)
+
+// OpenShift 募集シフトエンティティ
+// 急な欠勤などで空いた勤務表エントリの枠を募集し、応募したスタッフで埋める
+// 埋まった時点で応募者にシフトを割り当て、元のスタッフのエントリを未割当に戻す
+type OpenShift struct {
+	// ID 一意識別子
+	ID domain.ID
+	// OrganizationID 組織ID
+	OrganizationID domain.ID
+	// ScheduleID 勤務表ID
+	ScheduleID domain.ID
+	// SourceEntryID 募集元のエントリID
+	SourceEntryID *domain.ID
+	// SourceStaffID 募集元のスタッフID
+	SourceStaffID *domain.ID
+	// TargetDate 勤務日
+	TargetDate time.Time
+	// ShiftTypeID 募集するシフト種別ID
+	ShiftTypeID domain.ID
+	// RequiredSkillID 応募に必要なスキルID
+	RequiredSkillID *domain.ID
+	// RequiredJobTypeID 応募に必要な職種ID
+	RequiredJobTypeID *domain.ID
+	// FillMode 決定方法
+	FillMode FillMode
+	// Status 状態
+	Status OpenShiftStatus
+	// Note 備考
+	Note string
+	// Claims 応募一覧 古い順
+	Claims []OpenShiftClaim
+	// FilledStaffID 勤務するスタッフID
+	FilledStaffID *domain.ID
+	// FilledBy 決定したユーザーのメールアドレス
+	FilledBy string
+	// FilledAt 決定日時
+	FilledAt *time.Time
+	// CreatedBy 募集したユーザーのメールアドレス
+	CreatedBy string
+	// CreatedAt 作成日時
+	CreatedAt time.Time
+	// UpdatedAt 更新日時
+	UpdatedAt time.Time
+}
+
+// OpenShiftClaim 募集シフトへの応募
+type OpenShiftClaim struct {
+	// StaffID 応募したスタッフID
+	StaffID domain.ID
+	// UserEmail 応募操作をしたユーザーのメールアドレス
+	UserEmail string
+	// ClaimedAt 応募日時
+	ClaimedAt time.Time
+}
+
+// NewOpenShift 募集シフト生成 シフトが割り当てられたエントリのみ募集できる
+func NewOpenShift(
+	organizationID domain.ID,
+	entry *ScheduleEntry,
+	requiredSkillID, requiredJobTypeID *domain.ID,
+	fillMode FillMode,
+	note, createdBy string,
+	now time.Time,
+) (*OpenShift, error) {
+	if entry.ShiftTypeID == nil {
+		return nil, domain.NewDomainError(domain.ErrCodeValidation, "シフトが割り当てられていない勤務は募集できません")
+	}
+	if !fillMode.IsValid() {
+		return nil, domain.NewDomainError(domain.ErrCodeValidation, "決定方法が不正です")
+	}
+
+	entryID, staffID := entry.ID, entry.StaffID
+	return &OpenShift{
+		ID:                domain.NewID(),
+		OrganizationID:    organizationID,
+		ScheduleID:        entry.ScheduleID,
+		SourceEntryID:     &entryID,
+		SourceStaffID:     &staffID,
+		TargetDate:        entry.TargetDate,
+		ShiftTypeID:       *entry.ShiftTypeID,
+		RequiredSkillID:   requiredSkillID,
+		RequiredJobTypeID: requiredJobTypeID,
+		FillMode:          fillMode,
+		Status:            OpenShiftStatusOpen,
+		Note:              note,
+		CreatedBy:         createdBy,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}, nil
+}
+
+// IsOpen 募集中判定
+func (o *OpenShift) IsOpen() bool {
+	return o.Status == OpenShiftStatusOpen
+}
+
+// IsSourceStaff 募集元のスタッフか判定
+func (o *OpenShift) IsSourceStaff(staffID domain.ID) bool {
+	return o.SourceStaffID != nil && *o.SourceStaffID == staffID
+}
+
+// HasClaimed 応募済み判定
+func (o *OpenShift) HasClaimed(staffID domain.ID) bool {
+	for _, c := range o.Claims {
+		if c.StaffID == staffID {
+			return true
+		}
+	}
+	return false
+}
+
+// Claim 応募を記録 先着順の場合は呼び出し側でそのまま決定する
+func (o *OpenShift) Claim(staffID domain.ID, userEmail string, now time.Time) error {
+	if !o.IsOpen() {
+		return o.closedError()
+	}
+	if o.IsSourceStaff(staffID) {
+		return domain.NewDomainError(domain.ErrCodeValidation, "募集元のスタッフは応募できません")
+	}
+	if o.HasClaimed(staffID) {
+		return domain.NewDomainError(domain.ErrCodeConflict, "このスタッフは既に応募しています")
+	}
+
+	o.Claims = append(o.Claims, OpenShiftClaim{StaffID: staffID, UserEmail: userEmail, ClaimedAt: now})
+	o.UpdatedAt = now
+	return nil
+}
+
+// Fill 勤務するスタッフを決定 応募済みのスタッフのみ
+func (o *OpenShift) Fill(staffID domain.ID, userEmail string, now time.Time) error {
+	if !o.IsOpen() {
+		return o.closedError()
+	}
+	if !o.HasClaimed(staffID) {
+		return domain.NewDomainError(domain.ErrCodeValidation, "応募していないスタッフには決定できません")
+	}
+
+	o.Status = OpenShiftStatusFilled
+	o.FilledStaffID = &staffID
+	o.FilledBy = userEmail
+	o.FilledAt = &now
+	o.UpdatedAt = now
+	return nil
+}
+
+// Cancel 募集取り消し
+func (o *OpenShift) Cancel(now time.Time) error {
+	if !o.IsOpen() {
+		return o.closedError()
+	}
+	o.Status = OpenShiftStatusCancelled
+	o.UpdatedAt = now
+	return nil
+}
+
+// Assignment 決定したスタッフへの割り当てで変更されるエントリ
+// 勤務日のエントリがなければ作成し、募集元のエントリは募集したシフトのままなら未割当に戻す
+func (o *OpenShift) Assignment(entries []ScheduleEntry, now time.Time) ([]ScheduleEntry, error) {
+	if o.FilledStaffID == nil {
+		return nil, domain.NewDomainError(domain.ErrCodeValidation, "勤務するスタッフが決定していません")
+	}
+
+	shiftTypeID := o.ShiftTypeID
+	var assigned *ScheduleEntry
+	changed := make([]ScheduleEntry, 0, 2)
+	for i := range entries {
+		e := entries[i]
+		if !sameDate(e.TargetDate, o.TargetDate) {
+			continue
+		}
+		switch {
+		case e.StaffID == *o.FilledStaffID:
+			e.ShiftTypeID = &shiftTypeID
+			e.UpdatedAt = now
+			assigned = &e
+		case o.SourceEntryID != nil && e.ID == *o.SourceEntryID && sameShiftTypeID(e.ShiftTypeID, &shiftTypeID):
+			e.ShiftTypeID = nil
+			e.UpdatedAt = now
+			changed = append(changed, e)
+		}
+	}
+
+	if assigned == nil {
+		assigned = &ScheduleEntry{
+			ID:          domain.NewID(),
+			ScheduleID:  o.ScheduleID,
+			StaffID:     *o.FilledStaffID,
+			TargetDate:  o.TargetDate,
+			ShiftTypeID: &shiftTypeID,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+	}
+	return append([]ScheduleEntry{*assigned}, changed...), nil
+}
+
+// closedError 募集終了エラー
+func (o *OpenShift) closedError() error {
+	return domain.NewDomainError(domain.ErrCodeConflict, "この募集は既に"+o.Status.Label()+"です")
+}
+
+// FillMode 募集シフトの決定方法
+type FillMode string
+
+const (
+	// FillModeFirstCome 先着順 最初に応募したスタッフで決定
+	FillModeFirstCome FillMode = "first_come"
+	// FillModeManagerPick 管理者が応募者から選定
+	FillModeManagerPick FillMode = "manager_pick"
+)
+
+// String 文字列変換
+func (m FillMode) String() string {
+	return string(m)
+}
+
+// IsValid 有効な決定方法か判定
+func (m FillMode) IsValid() bool {
+	return m == FillModeFirstCome || m == FillModeManagerPick
+}
+
+// Label 表示ラベル
+func (m FillMode) Label() string {
+	switch m {
+	case FillModeFirstCome:
+		return "先着順"
+	case FillModeManagerPick:
+		return "管理者が選定"
+	default:
+		return "不明"
+	}
+}
+
+// OpenShiftStatus 募集シフト状態
+type OpenShiftStatus string
+
+const (
+	// OpenShiftStatusOpen 募集中
+	OpenShiftStatusOpen OpenShiftStatus = "open"
+	// OpenShiftStatusFilled 決定済み 勤務表に反映済み
+	OpenShiftStatusFilled OpenShiftStatus = "filled"
+	// OpenShiftStatusCancelled 取り消し
+	OpenShiftStatusCancelled OpenShiftStatus = "cancelled"
+)
+
+// String 文字列変換
+func (s OpenShiftStatus) String() string {
+	return string(s)
+}
+
+// Label 表示ラベル
+func (s OpenShiftStatus) Label() string {
+	switch s {
+	case OpenShiftStatusOpen:
+		return "募集中"
+	case OpenShiftStatusFilled:
+		return "決定済み"
+	case OpenShiftStatusCancelled:
+		return "取り消し"
+	default:
+		return "不明"
+	}
+}
@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"shiftmaster/internal/shared/domain"
+)
+
+func TestOpenShift_Assignment(t *testing.T) {
+	now := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	day, off := domain.NewID(), domain.NewID()
+	source := ScheduleEntry{ID: domain.NewID(), ScheduleID: domain.NewID(), StaffID: domain.NewID(), TargetDate: now, ShiftTypeID: &day}
+	holiday := ScheduleEntry{ID: domain.NewID(), ScheduleID: source.ScheduleID, StaffID: domain.NewID(), TargetDate: now, ShiftTypeID: &off}
+
+	newFilled := func(staffID domain.ID) *OpenShift {
+		o, err := NewOpenShift(domain.NewID(), &source, nil, nil, FillModeFirstCome, "", "m@example.com", now)
+		if err != nil {
+			t.Fatalf("NewOpenShift() error = %v", err)
+		}
+		o.FilledStaffID = &staffID
+		return o
+	}
+
+	t.Run("既存エントリを更新し募集元を未割当に戻す", func(t *testing.T) {
+		changed, err := newFilled(holiday.StaffID).Assignment([]ScheduleEntry{source, holiday}, now)
+		if err != nil {
+			t.Fatalf("Assignment() error = %v", err)
+		}
+		if len(changed) != 2 {
+			t.Fatalf("changed = %d, want 2", len(changed))
+		}
+		if changed[0].ID != holiday.ID || *changed[0].ShiftTypeID != day {
+			t.Error("応募者のエントリに募集したシフトが割り当てられていません")
+		}
+		if changed[1].ID != source.ID || changed[1].ShiftTypeID != nil {
+			t.Error("募集元のエントリが未割当になっていません")
+		}
+	})
+
+	t.Run("エントリがなければ作成", func(t *testing.T) {
+		staffID := domain.NewID()
+		changed, err := newFilled(staffID).Assignment([]ScheduleEntry{source}, now)
+		if err != nil {
+			t.Fatalf("Assignment() error = %v", err)
+		}
+		if len(changed) != 2 || changed[0].StaffID != staffID || changed[0].ScheduleID != source.ScheduleID {
+			t.Errorf("応募者のエントリが作成されていません: %+v", changed)
+		}
+	})
+
+	t.Run("募集元のシフトが変更済みなら募集元は変更しない", func(t *testing.T) {
+		moved := source
+		moved.ShiftTypeID = &off
+		changed, err := newFilled(holiday.StaffID).Assignment([]ScheduleEntry{moved, holiday}, now)
+		if err != nil {
+			t.Fatalf("Assignment() error = %v", err)
+		}
+		if len(changed) != 1 {
+			t.Errorf("changed = %d, want 1", len(changed))
+		}
+	})
+}
+
+func TestOpenShift_Transitions(t *testing.T) {
+	now := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	shiftTypeID := domain.NewID()
+	source := ScheduleEntry{ID: domain.NewID(), StaffID: domain.NewID(), TargetDate: now, ShiftTypeID: &shiftTypeID}
+	claimant := domain.NewID()
+
+	tests := []struct {
+		name     string
+		steps    func(o *OpenShift) error
+		want     OpenShiftStatus
+		wantCode string
+	}{
+		{"応募", func(o *OpenShift) error { return o.Claim(claimant, "", now) }, OpenShiftStatusOpen, ""},
+		{"募集元の応募はエラー", func(o *OpenShift) error { return o.Claim(source.StaffID, "", now) }, OpenShiftStatusOpen, domain.ErrCodeValidation},
+		{"重複応募はエラー", func(o *OpenShift) error {
+			if err := o.Claim(claimant, "", now); err != nil {
+				return err
+			}
+			return o.Claim(claimant, "", now)
+		}, OpenShiftStatusOpen, domain.ErrCodeConflict},
+		{"未応募のスタッフで決定はエラー", func(o *OpenShift) error { return o.Fill(claimant, "", now) }, OpenShiftStatusOpen, domain.ErrCodeValidation},
+		{"応募後に決定", func(o *OpenShift) error {
+			if err := o.Claim(claimant, "", now); err != nil {
+				return err
+			}
+			return o.Fill(claimant, "", now)
+		}, OpenShiftStatusFilled, ""},
+		{"取り消し後の応募はエラー", func(o *OpenShift) error {
+			if err := o.Cancel(now); err != nil {
+				return err
+			}
+			return o.Claim(claimant, "", now)
+		}, OpenShiftStatusCancelled, domain.ErrCodeConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, err := NewOpenShift(domain.NewID(), &source, nil, nil, FillModeManagerPick, "", "", now)
+			if err != nil {
+				t.Fatalf("NewOpenShift() error = %v", err)
+			}
+			err = tt.steps(o)
+			var domainErr *domain.DomainError
+			if tt.wantCode == "" && err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if tt.wantCode != "" && (!errors.As(err, &domainErr) || domainErr.Code != tt.wantCode) {
+				t.Errorf("error = %v, want %v", err, tt.wantCode)
+			}
+			if o.Status != tt.want {
+				t.Errorf("Status = %v, want %v", o.Status, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewOpenShift_Invalid(t *testing.T) {
+	now := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	shiftTypeID := domain.NewID()
+	entry := ScheduleEntry{ID: domain.NewID(), StaffID: domain.NewID(), TargetDate: now, ShiftTypeID: &shiftTypeID}
+	unassigned := ScheduleEntry{ID: domain.NewID(), StaffID: domain.NewID(), TargetDate: now}
+
+	if _, err := NewOpenShift(domain.NewID(), &unassigned, nil, nil, FillModeFirstCome, "", "", now); err == nil {
+		t.Error("シフトが未割当の勤務を募集できています")
+	}
+	if _, err := NewOpenShift(domain.NewID(), &entry, nil, nil, FillMode("lottery"), "", "", now); err == nil {
+		t.Error("不正な決定方法で募集できています")
+	}
+}
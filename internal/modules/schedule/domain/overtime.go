@@ -0,0 +1,244 @@
+// Package domain 勤務表ドメイン層
+package domain
+
+import (
+	"sort"
+	"time"
+
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
+)
+
+const (
+	// LegalDailyWorkingMinutes 法定労働時間 1日8時間
+	LegalDailyWorkingMinutes = 8 * 60
+	// LegalWeeklyWorkingMinutes 法定労働時間 週40時間
+	LegalWeeklyWorkingMinutes = 40 * 60
+	// lateNightStartHour 深夜時間帯の開始時刻
+	lateNightStartHour = 22
+	// lateNightEndHour 深夜時間帯の終了時刻 翌日
+	lateNightEndHour = 24 + 5
+)
+
+// Tokyo 勤務時刻を扱うタイムゾーン 実行環境のタイムゾーンに依存しないよう固定する
+var Tokyo = loadTokyo()
+
+// loadTokyo Asia/Tokyoを読み込む タイムゾーンデータベースがない環境では同じオフセットの固定タイムゾーンを使う
+func loadTokyo() *time.Location {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		return time.FixedZone("Asia/Tokyo", 9*60*60)
+	}
+	return loc
+}
+
+// OvertimeBreakdown 労働基準法の区分別時間外労働 分単位
+type OvertimeBreakdown struct {
+	// WithinLegalMinutes 法定内残業 所定労働時間を超え法定労働時間以内の労働
+	WithinLegalMinutes int
+	// OverLegalMinutes 法定外残業 1日8時間または週40時間を超える労働
+	OverLegalMinutes int
+	// LateNightMinutes 深夜労働 22:00〜翌5:00の労働 他の区分と重複して計上
+	LateNightMinutes int
+	// HolidayWorkMinutes 休日労働 法定休日の労働
+	HolidayWorkMinutes int
+}
+
+// OvertimeMinutes 残業時間 法定内残業と法定外残業の合計
+func (b OvertimeBreakdown) OvertimeMinutes() int {
+	return b.WithinLegalMinutes + b.OverLegalMinutes
+}
+
+// Add 区分別時間外労働を加算
+func (b *OvertimeBreakdown) Add(other OvertimeBreakdown) {
+	b.WithinLegalMinutes += other.WithinLegalMinutes
+	b.OverLegalMinutes += other.OverLegalMinutes
+	b.LateNightMinutes += other.LateNightMinutes
+	b.HolidayWorkMinutes += other.HolidayWorkMinutes
+}
+
+// AttendanceDay 時間外労働算出用の勤務日
+type AttendanceDay struct {
+	// Date 勤務日
+	Date time.Time
+	// ShiftType 予定シフト種別 未割り当てはnil
+	ShiftType *shiftDomain.ShiftType
+	// Record 勤務実績 未登録はnil
+	Record *ActualRecord
+	// OutsidePeriod 対象月の前後の月の勤務日 月をまたぐ週の労働時間と法定休日の判定にのみ使い、時間外労働は計上しない
+	OutsidePeriod bool
+}
+
+// isDayOff 休日予定か シフト未割り当て・休日シフトを含む
+func (d *AttendanceDay) isDayOff() bool {
+	return d.ShiftType == nil || d.ShiftType.IsHoliday || d.ShiftType.TotalMinutes() == 0
+}
+
+// scheduledMinutes 所定労働時間 申し送りを含み休憩を除く
+func (d *AttendanceDay) scheduledMinutes() int {
+	if d.isDayOff() {
+		return 0
+	}
+	start, end := PlannedPeriod(d.Date, d.ShiftType)
+	return int(end.Sub(start).Minutes()) - d.ShiftType.BreakMinutes
+}
+
+// workedMinutes 実績実働時間 実績未登録・退勤未入力は0
+func (d *AttendanceDay) workedMinutes() int {
+	if d.Record == nil {
+		return 0
+	}
+	return max(d.Record.ActualWorkingMinutes(), 0)
+}
+
+// CalculateOvertime 同一スタッフの勤務日から区分別時間外労働を日別に算出 結果はdaysと同じ順序
+// 週は日曜起算とし、入力に含まれない日は週の労働時間に含めない 対象月外の日の結果は常にゼロ
+// 所定労働時間が1日8時間・週40時間を超える場合は1箇月単位の変形労働時間制として所定労働時間を上限とする
+// 法定休日は週7日すべてが入力に含まれ労働のない日がない週に限り判定する 月をまたぐ週は前後の月の勤務日を含めて渡す
+func CalculateOvertime(days []AttendanceDay) []OvertimeBreakdown {
+	result := make([]OvertimeBreakdown, len(days))
+
+	weeks := make(map[time.Time][]int)
+	var order []time.Time
+	for i := range days {
+		date := dayOf(days[i].Date, time.UTC)
+		key := date.AddDate(0, 0, -int(date.Weekday()))
+		if _, ok := weeks[key]; !ok {
+			order = append(order, key)
+		}
+		weeks[key] = append(weeks[key], i)
+	}
+
+	for _, key := range order {
+		indexes := weeks[key]
+		sort.SliceStable(indexes, func(a, b int) bool {
+			return days[indexes[a]].Date.Before(days[indexes[b]].Date)
+		})
+		holiday := legalHolidayIndex(days, indexes)
+
+		weeklyLimit := 0
+		for _, i := range indexes {
+			if i != holiday {
+				weeklyLimit += days[i].scheduledMinutes()
+			}
+		}
+		weeklyLimit = max(weeklyLimit, LegalWeeklyWorkingMinutes)
+
+		weekly := 0
+		for _, i := range indexes {
+			day := &days[i]
+			// 対象月外の日も週の労働時間には含め、結果は捨てる
+			b := &OvertimeBreakdown{}
+			if !day.OutsidePeriod {
+				b = &result[i]
+			}
+			if day.Record != nil {
+				b.LateNightMinutes = day.Record.ActualLateNightMinutes()
+			}
+			worked := day.workedMinutes()
+			if i == holiday {
+				b.HolidayWorkMinutes = worked
+				continue
+			}
+
+			scheduled := day.scheduledMinutes()
+			regular := min(worked, max(scheduled, LegalDailyWorkingMinutes))
+			b.OverLegalMinutes = worked - regular
+			b.WithinLegalMinutes = max(regular-scheduled, 0)
+
+			// 週の上限を超えた分は法定外残業に振り替える
+			if excess := min(weekly+regular-weeklyLimit, regular); excess > 0 {
+				b.OverLegalMinutes += excess
+				b.WithinLegalMinutes = max(b.WithinLegalMinutes-excess, 0)
+			}
+			weekly += regular
+		}
+	}
+
+	return result
+}
+
+// ProjectAttendance 実績未登録の勤務日を予定どおりに勤務した見込みで補完
+func ProjectAttendance(days []AttendanceDay) []AttendanceDay {
+	result := make([]AttendanceDay, len(days))
+	copy(result, days)
+	for i := range result {
+		day := &result[i]
+		if day.Record != nil || day.isDayOff() {
+			continue
+		}
+		start, end := PlannedPeriod(day.Date, day.ShiftType)
+		day.Record = &ActualRecord{
+			ActualStartTime:    &start,
+			ActualEndTime:      &end,
+			ActualBreakMinutes: day.ShiftType.BreakMinutes,
+		}
+	}
+	return result
+}
+
+// legalHolidayIndex 週の法定休日に労働した日のインデックス 該当なしは-1
+// 週内に休日出勤があればその最後の日、休日予定がなければ週の最終日を法定休日とする
+func legalHolidayIndex(days []AttendanceDay, indexes []int) int {
+	if len(indexes) < 7 {
+		return -1
+	}
+	holiday := -1
+	for _, i := range indexes {
+		if days[i].workedMinutes() == 0 {
+			return -1
+		}
+		if days[i].isDayOff() {
+			holiday = i
+		}
+	}
+	if holiday < 0 {
+		holiday = indexes[len(indexes)-1]
+	}
+	return holiday
+}
+
+// ActualLateNightMinutes 深夜時間帯(22:00〜翌5:00)の勤務時間 休憩の時間帯は記録されないため控除しない
+func (r *ActualRecord) ActualLateNightMinutes() int {
+	if r.ActualStartTime == nil || r.ActualEndTime == nil {
+		return 0
+	}
+	start := r.ActualStartTime.In(Tokyo)
+	end := r.ActualEndTime.In(Tokyo)
+
+	total := 0
+	for day := dayOf(start, Tokyo).AddDate(0, 0, -1); day.Before(end); day = day.AddDate(0, 0, 1) {
+		from := day.Add(lateNightStartHour * time.Hour)
+		to := day.Add(lateNightEndHour * time.Hour)
+		if from.Before(start) {
+			from = start
+		}
+		if to.After(end) {
+			to = end
+		}
+		if to.After(from) {
+			total += int(to.Sub(from).Minutes())
+		}
+	}
+	return total
+}
+
+// ApplyOvertime 区分別時間外労働を実績に反映 変更があればtrue
+func (r *ActualRecord) ApplyOvertime(b OvertimeBreakdown) bool {
+	changed := r.Overtime() != b || r.OvertimeMinutes != b.OvertimeMinutes()
+	r.OvertimeMinutes = b.OvertimeMinutes()
+	r.WithinLegalOvertimeMinutes = b.WithinLegalMinutes
+	r.OverLegalOvertimeMinutes = b.OverLegalMinutes
+	r.LateNightMinutes = b.LateNightMinutes
+	r.HolidayWorkMinutes = b.HolidayWorkMinutes
+	return changed
+}
+
+// Overtime 実績に保存された区分別時間外労働
+func (r *ActualRecord) Overtime() OvertimeBreakdown {
+	return OvertimeBreakdown{
+		WithinLegalMinutes: r.WithinLegalOvertimeMinutes,
+		OverLegalMinutes:   r.OverLegalOvertimeMinutes,
+		LateNightMinutes:   r.LateNightMinutes,
+		HolidayWorkMinutes: r.HolidayWorkMinutes,
+	}
+}
@@ -0,0 +1,239 @@
+// Package domain 勤務表ドメイン層
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"shiftmaster/internal/shared/domain"
+)
+
+// 36協定の既定上限 労働基準法36条4項の限度時間と同条6項の複数月平均
+const (
+	// DefaultMonthlyOvertimeLimitMinutes 月45時間
+	DefaultMonthlyOvertimeLimitMinutes = 45 * 60
+	// DefaultYearlyOvertimeLimitMinutes 年360時間
+	DefaultYearlyOvertimeLimitMinutes = 360 * 60
+	// DefaultMultiMonthAverageLimitMinutes 2〜6箇月平均80時間 休日労働を含む
+	DefaultMultiMonthAverageLimitMinutes = 80 * 60
+	// DefaultOvertimeWarningPercent 上限に対する警告開始割合
+	DefaultOvertimeWarningPercent = 80
+	// DefaultAgreementStartMonth 協定期間の起算月
+	DefaultAgreementStartMonth = 4
+	// maxAverageMonths 複数月平均の最大月数
+	maxAverageMonths = 6
+)
+
+// OvertimeLimitType 36協定の上限種別
+type OvertimeLimitType string
+
+const (
+	// OvertimeLimitMonthly 月間上限
+	OvertimeLimitMonthly OvertimeLimitType = "monthly"
+	// OvertimeLimitYearly 年間上限
+	OvertimeLimitYearly OvertimeLimitType = "yearly"
+	// OvertimeLimitMultiMonthAverage 複数月平均上限
+	OvertimeLimitMultiMonthAverage OvertimeLimitType = "multi_month_average"
+)
+
+// OvertimeAlertLevel 36協定警告レベル
+type OvertimeAlertLevel string
+
+const (
+	// OvertimeAlertWarning 上限に接近
+	OvertimeAlertWarning OvertimeAlertLevel = "warning"
+	// OvertimeAlertExceeded 上限超過
+	OvertimeAlertExceeded OvertimeAlertLevel = "exceeded"
+)
+
+// OvertimeAgreement 36協定 組織ごとの時間外労働の上限
+type OvertimeAgreement struct {
+	// ID 一意識別子
+	ID domain.ID
+	// OrganizationID 組織ID
+	OrganizationID domain.ID
+	// MonthlyLimitMinutes 月間の法定外残業上限 分
+	MonthlyLimitMinutes int
+	// YearlyLimitMinutes 年間の法定外残業上限 分
+	YearlyLimitMinutes int
+	// MultiMonthAverageLimitMinutes 2〜6箇月平均の法定外残業と休日労働の上限 分
+	MultiMonthAverageLimitMinutes int
+	// WarningPercent 上限に対する警告開始割合 %
+	WarningPercent int
+	// StartMonth 協定期間の起算月
+	StartMonth int
+	// CreatedAt 作成日時
+	CreatedAt time.Time
+	// UpdatedAt 更新日時
+	UpdatedAt time.Time
+}
+
+// NewDefaultOvertimeAgreement 法定の限度時間で36協定を生成 組織に未登録の場合に使用
+func NewDefaultOvertimeAgreement(organizationID domain.ID) *OvertimeAgreement {
+	now := time.Now()
+	return &OvertimeAgreement{
+		ID:                            domain.NewID(),
+		OrganizationID:                organizationID,
+		MonthlyLimitMinutes:           DefaultMonthlyOvertimeLimitMinutes,
+		YearlyLimitMinutes:            DefaultYearlyOvertimeLimitMinutes,
+		MultiMonthAverageLimitMinutes: DefaultMultiMonthAverageLimitMinutes,
+		WarningPercent:                DefaultOvertimeWarningPercent,
+		StartMonth:                    DefaultAgreementStartMonth,
+		CreatedAt:                     now,
+		UpdatedAt:                     now,
+	}
+}
+
+// Validate 36協定の検証
+func (a *OvertimeAgreement) Validate() error {
+	if a.MonthlyLimitMinutes <= 0 || a.YearlyLimitMinutes <= 0 || a.MultiMonthAverageLimitMinutes <= 0 {
+		return domain.NewDomainError(domain.ErrCodeValidation, "上限時間は1分以上で入力してください")
+	}
+	if a.MonthlyLimitMinutes > a.YearlyLimitMinutes {
+		return domain.NewDomainError(domain.ErrCodeValidation, "月間上限は年間上限以下で入力してください")
+	}
+	if a.WarningPercent < 1 || a.WarningPercent > 100 {
+		return domain.NewDomainError(domain.ErrCodeValidation, "警告開始割合は1〜100%で入力してください")
+	}
+	if a.StartMonth < 1 || a.StartMonth > 12 {
+		return domain.NewDomainError(domain.ErrCodeValidation, "起算月は1〜12で入力してください")
+	}
+	return nil
+}
+
+// PeriodStart 対象年月が属する協定期間の開始年月
+func (a *OvertimeAgreement) PeriodStart(year, month int) (int, int) {
+	if month < a.StartMonth {
+		return year - 1, a.StartMonth
+	}
+	return year, a.StartMonth
+}
+
+// MonthlyOvertime スタッフの月間時間外労働 実績と勤務予定からの見込みを合算
+type MonthlyOvertime struct {
+	// Year 年
+	Year int
+	// Month 月
+	Month int
+	// OverLegalMinutes 法定外残業 分
+	OverLegalMinutes int
+	// HolidayWorkMinutes 休日労働 分
+	HolidayWorkMinutes int
+}
+
+// OvertimeAlert 36協定の上限超過・接近の警告
+type OvertimeAlert struct {
+	// StaffID スタッフID
+	StaffID domain.ID
+	// Year 対象年
+	Year int
+	// Month 対象月
+	Month int
+	// LimitType 上限種別
+	LimitType OvertimeLimitType
+	// Level 警告レベル
+	Level OvertimeAlertLevel
+	// Minutes 見込み時間 複数月平均は平均値 分
+	Minutes int
+	// LimitMinutes 上限時間 分
+	LimitMinutes int
+	// Months 複数月平均の月数
+	Months int
+}
+
+// Message 警告メッセージ
+func (a *OvertimeAlert) Message() string {
+	label := "上限に近づいています"
+	if a.Level == OvertimeAlertExceeded {
+		label = "上限を超える見込みです"
+	}
+	hours := func(minutes int) string {
+		return fmt.Sprintf("%d:%02d", minutes/60, minutes%60)
+	}
+
+	switch a.LimitType {
+	case OvertimeLimitYearly:
+		return fmt.Sprintf("36協定の年間時間外労働が%sで%s（上限%s）", hours(a.Minutes), label, hours(a.LimitMinutes))
+	case OvertimeLimitMultiMonthAverage:
+		return fmt.Sprintf("36協定の%d箇月平均の時間外・休日労働が%sで%s（上限%s）", a.Months, hours(a.Minutes), label, hours(a.LimitMinutes))
+	default:
+		return fmt.Sprintf("36協定の%d月の時間外労働が%sで%s（上限%s）", a.Month, hours(a.Minutes), label, hours(a.LimitMinutes))
+	}
+}
+
+// ToViolation 勤務表検証の制約違反に変換
+func (a *OvertimeAlert) ToViolation() ConstraintViolation {
+	staffID := a.StaffID
+	return ConstraintViolation{
+		ConstraintType: ViolationOvertimeLimit,
+		Message:        a.Message(),
+		StaffID:        &staffID,
+		Severity:       SeverityWarning,
+	}
+}
+
+// Evaluate スタッフの月別時間外労働を上限と照合 historyは対象月で終わる連続した年月昇順
+// 月間・年間は法定外残業、複数月平均は法定外残業と休日労働の合計で判定する
+func (a *OvertimeAgreement) Evaluate(staffID domain.ID, history []MonthlyOvertime) []OvertimeAlert {
+	if len(history) == 0 {
+		return nil
+	}
+	target := history[len(history)-1]
+
+	var alerts []OvertimeAlert
+	check := func(limitType OvertimeLimitType, minutes, limit, months int) {
+		level := a.level(minutes, limit)
+		if level == "" {
+			return
+		}
+		alerts = append(alerts, OvertimeAlert{
+			StaffID:      staffID,
+			Year:         target.Year,
+			Month:        target.Month,
+			LimitType:    limitType,
+			Level:        level,
+			Minutes:      minutes,
+			LimitMinutes: limit,
+			Months:       months,
+		})
+	}
+
+	check(OvertimeLimitMonthly, target.OverLegalMinutes, a.MonthlyLimitMinutes, 1)
+
+	startYear, startMonth := a.PeriodStart(target.Year, target.Month)
+	yearly := 0
+	for _, m := range history {
+		if m.Year*12+m.Month >= startYear*12+startMonth {
+			yearly += m.OverLegalMinutes
+		}
+	}
+	check(OvertimeLimitYearly, yearly, a.YearlyLimitMinutes, 1)
+
+	// 平均が最も高い期間のみ警告する
+	worst, worstMonths := 0, 0
+	total := target.OverLegalMinutes + target.HolidayWorkMinutes
+	for months := 2; months <= maxAverageMonths && months <= len(history); months++ {
+		m := history[len(history)-months]
+		total += m.OverLegalMinutes + m.HolidayWorkMinutes
+		if average := total / months; average > worst {
+			worst, worstMonths = average, months
+		}
+	}
+	if worstMonths > 0 {
+		check(OvertimeLimitMultiMonthAverage, worst, a.MultiMonthAverageLimitMinutes, worstMonths)
+	}
+
+	return alerts
+}
+
+// level 上限に対する警告レベル 警告不要の場合は空
+func (a *OvertimeAgreement) level(minutes, limit int) OvertimeAlertLevel {
+	switch {
+	case minutes > limit:
+		return OvertimeAlertExceeded
+	case minutes*100 >= limit*a.WarningPercent && minutes > 0:
+		return OvertimeAlertWarning
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"testing"
+
+	"shiftmaster/internal/shared/domain"
+)
+
+func TestOvertimeAgreement_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(a *OvertimeAgreement)
+		wantErr bool
+	}{
+		{"既定値", func(_ *OvertimeAgreement) {}, false},
+		{"月間上限0", func(a *OvertimeAgreement) { a.MonthlyLimitMinutes = 0 }, true},
+		{"月間上限が年間上限超", func(a *OvertimeAgreement) { a.MonthlyLimitMinutes = a.YearlyLimitMinutes + 1 }, true},
+		{"警告割合0", func(a *OvertimeAgreement) { a.WarningPercent = 0 }, true},
+		{"起算月13", func(a *OvertimeAgreement) { a.StartMonth = 13 }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewDefaultOvertimeAgreement(domain.NewID())
+			tt.modify(a)
+			if err := a.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOvertimeAgreement_PeriodStart(t *testing.T) {
+	a := NewDefaultOvertimeAgreement(domain.NewID())
+	if y, m := a.PeriodStart(2025, 3); y != 2024 || m != 4 {
+		t.Errorf("PeriodStart(2025, 3) = %d-%d, want 2024-4", y, m)
+	}
+	if y, m := a.PeriodStart(2025, 4); y != 2025 || m != 4 {
+		t.Errorf("PeriodStart(2025, 4) = %d-%d, want 2025-4", y, m)
+	}
+}
+
+func TestOvertimeAgreement_Evaluate(t *testing.T) {
+	staffID := domain.NewID()
+	a := NewDefaultOvertimeAgreement(domain.NewID())
+	hours := func(h int) int { return h * 60 }
+
+	t.Run("上限未満は警告なし", func(t *testing.T) {
+		alerts := a.Evaluate(staffID, []MonthlyOvertime{{Year: 2025, Month: 4, OverLegalMinutes: hours(30)}})
+		if len(alerts) != 0 {
+			t.Errorf("Evaluate() = %+v, want none", alerts)
+		}
+	})
+
+	t.Run("月間上限の80%以上は警告", func(t *testing.T) {
+		alerts := a.Evaluate(staffID, []MonthlyOvertime{{Year: 2025, Month: 4, OverLegalMinutes: hours(36)}})
+		if len(alerts) != 1 || alerts[0].LimitType != OvertimeLimitMonthly || alerts[0].Level != OvertimeAlertWarning {
+			t.Errorf("Evaluate() = %+v, want monthly warning", alerts)
+		}
+	})
+
+	t.Run("年間上限は起算月以降を合算", func(t *testing.T) {
+		var history []MonthlyOvertime
+		// 2025年3月は前年度のため年間に含めず、上限ちょうどは超過ではなく警告
+		history = append(history, MonthlyOvertime{Year: 2025, Month: 3, OverLegalMinutes: hours(40)})
+		for m := 4; m <= 12; m++ {
+			history = append(history, MonthlyOvertime{Year: 2025, Month: m, OverLegalMinutes: hours(40)})
+		}
+		alerts := a.Evaluate(staffID, history)
+		if len(alerts) != 2 {
+			t.Fatalf("Evaluate() = %+v, want monthly and yearly", alerts)
+		}
+		yearly := alerts[1]
+		if yearly.LimitType != OvertimeLimitYearly || yearly.Level != OvertimeAlertWarning || yearly.Minutes != hours(360) {
+			t.Errorf("yearly = %+v", yearly)
+		}
+	})
+
+	t.Run("複数月平均は休日労働を含め最も高い期間で判定", func(t *testing.T) {
+		history := []MonthlyOvertime{
+			{Year: 2025, Month: 5, OverLegalMinutes: hours(10)},
+			{Year: 2025, Month: 6, OverLegalMinutes: hours(40), HolidayWorkMinutes: hours(50)},
+			{Year: 2025, Month: 7, OverLegalMinutes: hours(40), HolidayWorkMinutes: hours(45)},
+		}
+		alerts := a.Evaluate(staffID, history)
+		var average *OvertimeAlert
+		for i := range alerts {
+			if alerts[i].LimitType == OvertimeLimitMultiMonthAverage {
+				average = &alerts[i]
+			}
+		}
+		if average == nil {
+			t.Fatalf("Evaluate() = %+v, want multi month average", alerts)
+		}
+		if average.Months != 2 || average.Minutes != hours(175)/2 || average.Level != OvertimeAlertExceeded {
+			t.Errorf("average = %+v", *average)
+		}
+	})
+}
@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
+)
+
+func TestActualRecord_ActualLateNightMinutes(t *testing.T) {
+	at := func(day, hour, minute int) *time.Time {
+		return ptrTime(time.Date(2025, 4, day, hour, minute, 0, 0, Tokyo))
+	}
+
+	tests := []struct {
+		name   string
+		record ActualRecord
+		want   int
+	}{
+		{"日勤は深夜なし", ActualRecord{ActualStartTime: at(1, 9, 0), ActualEndTime: at(1, 17, 0)}, 0},
+		{"23時までの残業", ActualRecord{ActualStartTime: at(1, 9, 0), ActualEndTime: at(1, 23, 0)}, 60},
+		{"夜勤", ActualRecord{ActualStartTime: at(1, 16, 0), ActualEndTime: at(2, 9, 0), ActualBreakMinutes: 120}, 420},
+		{"早朝出勤", ActualRecord{ActualStartTime: at(1, 3, 0), ActualEndTime: at(1, 8, 0)}, 120},
+		{"退勤未入力", ActualRecord{ActualStartTime: at(1, 22, 0)}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.record.ActualLateNightMinutes(); got != tt.want {
+				t.Errorf("ActualLateNightMinutes() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateOvertime(t *testing.T) {
+	clock := func(s string) time.Time {
+		c, _ := time.Parse("15:04", s)
+		return c
+	}
+	day := &shiftDomain.ShiftType{Name: "日勤", StartTime: clock("08:30"), EndTime: clock("17:30"), BreakMinutes: 60}
+	short := &shiftDomain.ShiftType{Name: "短時間", StartTime: clock("09:00"), EndTime: clock("17:00"), BreakMinutes: 60}
+	night := &shiftDomain.ShiftType{Name: "夜勤", StartTime: clock("16:30"), EndTime: clock("09:00"), BreakMinutes: 120, HandoverMinutes: 30, IsNightShift: true}
+	off := &shiftDomain.ShiftType{Name: "公休", IsHoliday: true}
+
+	// 2025-04-06は日曜日
+	work := func(date int, shiftType *shiftDomain.ShiftType, start, end string, breakMinutes int) AttendanceDay {
+		d := time.Date(2025, 4, date, 0, 0, 0, 0, time.UTC)
+		base := time.Date(2025, 4, date, 0, 0, 0, 0, Tokyo)
+		s, e := clock(start), clock(end)
+		startAt := base.Add(time.Duration(s.Hour()*60+s.Minute()) * time.Minute)
+		endAt := base.Add(time.Duration(e.Hour()*60+e.Minute()) * time.Minute)
+		if !endAt.After(startAt) {
+			endAt = endAt.AddDate(0, 0, 1)
+		}
+		return AttendanceDay{Date: d, ShiftType: shiftType, Record: &ActualRecord{ActualStartTime: &startAt, ActualEndTime: &endAt, ActualBreakMinutes: breakMinutes}}
+	}
+	rest := func(date int) AttendanceDay {
+		return AttendanceDay{Date: time.Date(2025, 4, date, 0, 0, 0, 0, time.UTC), ShiftType: off}
+	}
+
+	t.Run("所定7時間を超え8時間までは法定内残業", func(t *testing.T) {
+		got := CalculateOvertime([]AttendanceDay{work(7, short, "09:00", "19:00", 60)})
+		want := OvertimeBreakdown{WithinLegalMinutes: 60, OverLegalMinutes: 60}
+		if got[0] != want {
+			t.Errorf("CalculateOvertime() = %+v, want %+v", got[0], want)
+		}
+	})
+
+	t.Run("休日出勤で週40時間を超えた分は法定外残業", func(t *testing.T) {
+		days := []AttendanceDay{
+			rest(6),
+			work(7, day, "08:30", "17:30", 60),
+			work(8, day, "08:30", "17:30", 60),
+			work(9, day, "08:30", "17:30", 60),
+			work(10, day, "08:30", "17:30", 60),
+			work(11, day, "08:30", "17:30", 60),
+			work(12, off, "09:00", "13:00", 0),
+		}
+		got := CalculateOvertime(days)
+		want := OvertimeBreakdown{OverLegalMinutes: 240}
+		if got[6] != want {
+			t.Errorf("土曜 = %+v, want %+v", got[6], want)
+		}
+		if got[5] != (OvertimeBreakdown{}) {
+			t.Errorf("金曜 = %+v, want zero", got[5])
+		}
+	})
+
+	t.Run("休日のない週は最後の休日出勤を休日労働とする", func(t *testing.T) {
+		days := []AttendanceDay{
+			work(6, off, "09:00", "13:00", 0),
+			work(7, day, "08:30", "17:30", 60),
+			work(8, day, "08:30", "17:30", 60),
+			work(9, day, "08:30", "17:30", 60),
+			work(10, day, "08:30", "17:30", 60),
+			work(11, day, "08:30", "17:30", 60),
+			work(12, off, "09:00", "13:00", 0),
+		}
+		got := CalculateOvertime(days)
+		if got[6] != (OvertimeBreakdown{HolidayWorkMinutes: 240}) {
+			t.Errorf("土曜 = %+v, want HolidayWorkMinutes 240", got[6])
+		}
+		if got[0] != (OvertimeBreakdown{WithinLegalMinutes: 240}) {
+			t.Errorf("日曜 = %+v, want WithinLegalMinutes 240", got[0])
+		}
+		if got[5] != (OvertimeBreakdown{OverLegalMinutes: 240}) {
+			t.Errorf("金曜 = %+v, want OverLegalMinutes 240", got[5])
+		}
+	})
+
+	t.Run("所定8時間超の夜勤は所定労働時間を上限とする", func(t *testing.T) {
+		got := CalculateOvertime([]AttendanceDay{work(7, night, "16:00", "09:30", 120)})
+		want := OvertimeBreakdown{OverLegalMinutes: 30, LateNightMinutes: 420}
+		if got[0] != want {
+			t.Errorf("CalculateOvertime() = %+v, want %+v", got[0], want)
+		}
+	})
+
+	// 2025-04-01は火曜日 3/30(日)〜4/5(土)と4/27(日)〜5/3(土)は月をまたぐ週
+	outside := func(day AttendanceDay) AttendanceDay {
+		day.OutsidePeriod = true
+		return day
+	}
+
+	t.Run("月初の週は前月の労働時間を含めて週40時間を判定する", func(t *testing.T) {
+		days := []AttendanceDay{
+			outside(rest(-1)),
+			outside(work(0, day, "08:30", "17:30", 60)),
+			work(1, day, "08:30", "17:30", 60),
+			work(2, day, "08:30", "17:30", 60),
+			work(3, day, "08:30", "17:30", 60),
+			work(4, day, "08:30", "17:30", 60),
+			work(5, off, "09:00", "13:00", 0),
+		}
+		got := CalculateOvertime(days)
+		if got[6] != (OvertimeBreakdown{OverLegalMinutes: 240}) {
+			t.Errorf("土曜 = %+v, want OverLegalMinutes 240", got[6])
+		}
+		if got[1] != (OvertimeBreakdown{}) {
+			t.Errorf("前月の月曜 = %+v, want zero", got[1])
+		}
+	})
+
+	t.Run("月末の週は翌月の勤務日を含めて法定休日を判定する", func(t *testing.T) {
+		days := []AttendanceDay{
+			work(27, off, "09:00", "13:00", 0),
+			work(28, day, "08:30", "17:30", 60),
+			work(29, day, "08:30", "17:30", 60),
+			work(30, day, "08:30", "17:30", 60),
+			outside(work(31, day, "08:30", "17:30", 60)),
+			outside(work(32, day, "08:30", "17:30", 60)),
+			outside(work(33, day, "08:30", "20:30", 60)),
+		}
+		got := CalculateOvertime(days)
+		if got[0] != (OvertimeBreakdown{HolidayWorkMinutes: 240}) {
+			t.Errorf("日曜 = %+v, want HolidayWorkMinutes 240", got[0])
+		}
+		for i := 4; i < 7; i++ {
+			if got[i] != (OvertimeBreakdown{}) {
+				t.Errorf("翌月の%d日目 = %+v, want zero", i, got[i])
+			}
+		}
+
+		// 翌月の勤務日がなければ法定休日は判定しない
+		if got := CalculateOvertime(days[:4]); got[0].HolidayWorkMinutes != 0 {
+			t.Errorf("日曜 = %+v, want no HolidayWorkMinutes", got[0])
+		}
+	})
+
+	t.Run("実績未登録の日は計上しない", func(t *testing.T) {
+		got := CalculateOvertime([]AttendanceDay{{Date: time.Date(2025, 4, 7, 0, 0, 0, 0, time.UTC), ShiftType: day}})
+		if got[0] != (OvertimeBreakdown{}) {
+			t.Errorf("CalculateOvertime() = %+v, want zero", got[0])
+		}
+	})
+}
@@ -0,0 +1,111 @@
+// Package domain 勤務表ドメイン層
+package domain
+
+import (
+	"time"
+
+	"shiftmaster/internal/shared/domain"
+)
+
+// ScheduleProposal 勤務表自動作成案エンティティ
+// 自動作成の結果を既存エントリへ反映する前に保持し、管理者の承認後に適用する
+type ScheduleProposal struct {
+	// ID 一意識別子
+	ID domain.ID
+	// ScheduleID 勤務表ID
+	ScheduleID domain.ID
+	// Status 状態
+	Status ProposalStatus
+	// Generator 作成方式
+	Generator ProposalGenerator
+	// Score スコア 0が最良
+	Score float64
+	// Entries 作成案エントリ
+	Entries []ScheduleEntry
+	// Violations 作成時点の制約違反
+	Violations []ConstraintViolation
+	// Duration 作成処理時間
+	Duration time.Duration
+	// AppliedAt 適用日時
+	AppliedAt *time.Time
+	// CreatedAt 作成日時
+	CreatedAt time.Time
+	// UpdatedAt 更新日時
+	UpdatedAt time.Time
+}
+
+// IsPending 承認待ち判定
+func (p *ScheduleProposal) IsPending() bool {
+	return p.Status == ProposalStatusPending
+}
+
+// Apply 適用済みに変更
+func (p *ScheduleProposal) Apply(now time.Time) {
+	p.Status = ProposalStatusApplied
+	p.AppliedAt = &now
+	p.UpdatedAt = now
+}
+
+// Discard 破棄
+func (p *ScheduleProposal) Discard(now time.Time) {
+	p.Status = ProposalStatusDiscarded
+	p.UpdatedAt = now
+}
+
+// ProposalStatus 自動作成案状態
+type ProposalStatus string
+
+const (
+	// ProposalStatusPending 承認待ち
+	ProposalStatusPending ProposalStatus = "pending"
+	// ProposalStatusApplied 適用済み
+	ProposalStatusApplied ProposalStatus = "applied"
+	// ProposalStatusDiscarded 破棄
+	ProposalStatusDiscarded ProposalStatus = "discarded"
+)
+
+// String 文字列変換
+func (s ProposalStatus) String() string {
+	return string(s)
+}
+
+// Label 表示ラベル
+func (s ProposalStatus) Label() string {
+	switch s {
+	case ProposalStatusPending:
+		return "承認待ち"
+	case ProposalStatusApplied:
+		return "適用済み"
+	case ProposalStatusDiscarded:
+		return "破棄"
+	default:
+		return "不明"
+	}
+}
+
+// ProposalGenerator 自動作成方式
+type ProposalGenerator string
+
+const (
+	// GeneratorOptimizer 最適化エンジン
+	GeneratorOptimizer ProposalGenerator = "optimizer"
+	// GeneratorAutoFill 簡易自動割り当て
+	GeneratorAutoFill ProposalGenerator = "auto_fill"
+)
+
+// String 文字列変換
+func (g ProposalGenerator) String() string {
+	return string(g)
+}
+
+// Label 表示ラベル
+func (g ProposalGenerator) Label() string {
+	switch g {
+	case GeneratorOptimizer:
+		return "最適化"
+	case GeneratorAutoFill:
+		return "簡易割り当て"
+	default:
+		return "不明"
+	}
+}
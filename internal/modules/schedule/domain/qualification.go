@@ -0,0 +1,141 @@
+// Package domain 勤務表ドメイン層
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	staffDomain "shiftmaster/internal/modules/staff/domain"
+	"shiftmaster/internal/shared/domain"
+)
+
+// StaffQualificationFinder スタッフ資格情報取得インターフェース
+type StaffQualificationFinder interface {
+	// FindByOrganizationID 組織の有効スタッフの保有スキル・所属・職位を取得
+	FindByOrganizationID(ctx context.Context, organizationID domain.ID) (*StaffQualifications, error)
+}
+
+// StaffQualifications 制約評価用のスタッフ資格情報
+type StaffQualifications struct {
+	// Staffs スタッフマップ ID文字列 -> スタッフ（保有スキルを含む）
+	Staffs map[string]*staffDomain.Staff
+	// Assignments 所属マップ スタッフID文字列 -> 所属一覧
+	Assignments map[string][]staffDomain.StaffAssignment
+	// SkillNames スキル名マップ ID文字列 -> 名称
+	SkillNames map[string]string
+	// JobTypeNames 職種名マップ ID文字列 -> 名称
+	JobTypeNames map[string]string
+	// Positions 職位マップ ID文字列 -> 職位
+	Positions map[string]*staffDomain.Position
+	// Availabilities 勤務可能条件マップ スタッフID文字列 -> 勤務可能条件 未登録のスタッフは含まない
+	Availabilities map[string]*staffDomain.StaffAvailability
+}
+
+// Availability スタッフの勤務可能条件 未登録の場合はnil
+func (q *StaffQualifications) Availability(staffID string) *staffDomain.StaffAvailability {
+	if q == nil {
+		return nil
+	}
+	return q.Availabilities[staffID]
+}
+
+// HasSkill 指定スキルを最低レベル以上で保有しているか minLevelが0の場合はレベル不問
+func (q *StaffQualifications) HasSkill(staffID, skillID string, minLevel int) bool {
+	if q == nil {
+		return false
+	}
+	staff, ok := q.Staffs[staffID]
+	if !ok {
+		return false
+	}
+	id, err := domain.ParseID(skillID)
+	if err != nil {
+		return false
+	}
+	return staff.HasSkillAtLevel(id, minLevel)
+}
+
+// HasJobType 指定日に指定職種の所属が有効か
+func (q *StaffQualifications) HasJobType(staffID, jobTypeID string, date time.Time) bool {
+	if q == nil {
+		return false
+	}
+	for _, a := range q.Assignments[staffID] {
+		if a.JobTypeID != nil && a.JobTypeID.String() == jobTypeID && a.IsActiveOn(date) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPosition 指定日に有効な所属の職位が条件を満たすか
+// positionIDが空でない場合は職位の一致、levelがnilでない場合は同等以上のレベルかを判定
+func (q *StaffQualifications) HasPosition(staffID, positionID string, level *int, date time.Time) bool {
+	if q == nil {
+		return false
+	}
+	for _, a := range q.Assignments[staffID] {
+		if a.PositionID == nil || !a.IsActiveOn(date) {
+			continue
+		}
+		position, ok := q.Positions[a.PositionID.String()]
+		if !ok {
+			continue
+		}
+		if positionID != "" && position.ID.String() != positionID {
+			continue
+		}
+		if level != nil && !position.IsAtLevelOrHigher(*level) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// PositionLabel 職位条件の表示名 例: 主任以上
+func (q *StaffQualifications) PositionLabel(positionID string, level *int) string {
+	if q != nil && positionID != "" {
+		if position, ok := q.Positions[positionID]; ok {
+			return position.Name
+		}
+		return "指定職位"
+	}
+	if level == nil {
+		return "指定職位"
+	}
+
+	// 条件を満たす最下位の職位名で表示
+	var lowest *staffDomain.Position
+	if q != nil {
+		for _, position := range q.Positions {
+			if !position.IsAtLevelOrHigher(*level) {
+				continue
+			}
+			if lowest == nil || position.Level > lowest.Level || (position.Level == lowest.Level && position.Name < lowest.Name) {
+				lowest = position
+			}
+		}
+	}
+	if lowest == nil {
+		return fmt.Sprintf("職位レベル%d以上", *level)
+	}
+	return lowest.Name + "以上"
+}
+
+// SkillName スキル名 未登録の場合は空文字
+func (q *StaffQualifications) SkillName(skillID string) string {
+	if q == nil {
+		return ""
+	}
+	return q.SkillNames[skillID]
+}
+
+// JobTypeName 職種名 未登録の場合は空文字
+func (q *StaffQualifications) JobTypeName(jobTypeID string) string {
+	if q == nil {
+		return ""
+	}
+	return q.JobTypeNames[jobTypeID]
+}
@@ -57,3 +57,66 @@ type ActualRecordRepository interface {
 	// Delete 削除
 	Delete(ctx context.Context, id sharedDomain.ID) error
 }
+
+// OvertimeAgreementRepository 36協定リポジトリインターフェース
+type OvertimeAgreementRepository interface {
+	// FindByOrganizationID 組織IDで検索 未登録はnil
+	FindByOrganizationID(ctx context.Context, organizationID sharedDomain.ID) (*OvertimeAgreement, error)
+	// Save 保存
+	Save(ctx context.Context, agreement *OvertimeAgreement) error
+}
+
+// ScheduleProposalRepository 勤務表自動作成案リポジトリインターフェース
+type ScheduleProposalRepository interface {
+	// FindByID IDで検索
+	FindByID(ctx context.Context, id sharedDomain.ID) (*ScheduleProposal, error)
+	// FindByScheduleID 勤務表IDで検索 新しい順
+	FindByScheduleID(ctx context.Context, scheduleID sharedDomain.ID) ([]ScheduleProposal, error)
+	// Save 保存
+	Save(ctx context.Context, proposal *ScheduleProposal) error
+	// Apply 適用した作成案の保存とエントリ更新を1トランザクションで行う
+	// 承認待ちでなくなっていた場合や、上書きするエントリ（originals）が読み込み後に変更・確定されていた場合は競合エラー
+	Apply(ctx context.Context, proposal *ScheduleProposal, originals, entries []ScheduleEntry) error
+	// Delete 削除
+	Delete(ctx context.Context, id sharedDomain.ID) error
+}
+
+// CalendarTokenRepository カレンダー購読トークンリポジトリインターフェース
+type CalendarTokenRepository interface {
+	// FindByStaffID スタッフIDで検索 未発行はnil
+	FindByStaffID(ctx context.Context, staffID sharedDomain.ID) (*CalendarToken, error)
+	// FindByTokenHash トークンハッシュで検索 該当なしはnil
+	FindByTokenHash(ctx context.Context, tokenHash string) (*CalendarToken, error)
+	// Save 保存 スタッフごとに1件 再発行時は置き換える
+	Save(ctx context.Context, token *CalendarToken) error
+	// DeleteByStaffID スタッフのトークン削除
+	DeleteByStaffID(ctx context.Context, staffID sharedDomain.ID) error
+}
+
+// ShiftSwapRepository 勤務交換申請リポジトリインターフェース
+type ShiftSwapRepository interface {
+	// FindByID IDで検索 該当なしはnil
+	FindByID(ctx context.Context, id sharedDomain.ID) (*ShiftSwap, error)
+	// FindByOrganizationID 組織IDで検索 新しい順 statusが空の場合は全件
+	FindByOrganizationID(ctx context.Context, organizationID sharedDomain.ID, status SwapStatus) ([]ShiftSwap, error)
+	// Save 保存
+	Save(ctx context.Context, swap *ShiftSwap) error
+	// Apply 承認した申請の保存とエントリ更新を1トランザクションで行う
+	// originalsは交換内容を求めた時点のエントリ 承認待ちでなくなっていた場合と、
+	// その後に他の操作でエントリが変更されていた場合は競合エラー
+	Apply(ctx context.Context, swap *ShiftSwap, originals, entries []ScheduleEntry) error
+}
+
+// OpenShiftRepository 募集シフトリポジトリインターフェース
+type OpenShiftRepository interface {
+	// FindByID IDで検索 該当なしはnil
+	FindByID(ctx context.Context, id sharedDomain.ID) (*OpenShift, error)
+	// FindByOrganizationID 組織IDで検索 勤務日順 statusが空の場合は全件
+	FindByOrganizationID(ctx context.Context, organizationID sharedDomain.ID, status OpenShiftStatus) ([]OpenShift, error)
+	// Save 保存 同時に保存された応募は上書きせずに追記し、openShiftの応募一覧も最新にする
+	// 募集中でなくなっていた場合は競合エラー
+	Save(ctx context.Context, openShift *OpenShift) error
+	// Fill 決定した募集の保存とエントリ更新を1トランザクションで行う
+	// 募集中でなくなっていた場合は競合エラー
+	Fill(ctx context.Context, openShift *OpenShift, entries []ScheduleEntry) error
+}
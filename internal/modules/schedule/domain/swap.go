@@ -0,0 +1,302 @@
+// Package domain 勤務表ドメイン層
+package domain
+
+import (
+	"time"
+
+	"shiftmaster/internal/shared/domain"
+)
+
+// ShiftSwap 勤務交換申請エンティティ
+// 公開済み勤務表で申請者と相手のシフトを入れ替える 相手の承諾と管理者の承認を経て勤務表に反映する
+// 日付が異なる場合は両日とも2人のシフトを入れ替え、申請者が相手の勤務日に、相手が申請者の勤務日に勤務する
+type ShiftSwap struct {
+	// ID 一意識別子
+	ID domain.ID
+	// OrganizationID 組織ID
+	OrganizationID domain.ID
+	// RequesterStaffID 申請者のスタッフID
+	RequesterStaffID domain.ID
+	// RequesterEntryID 申請者が手放すエントリID
+	RequesterEntryID domain.ID
+	// RequesterDate 申請者が手放す勤務日
+	RequesterDate time.Time
+	// RequesterShiftTypeID 申請時点の申請者のシフト種別ID
+	RequesterShiftTypeID *domain.ID
+	// CounterpartStaffID 相手のスタッフID
+	CounterpartStaffID domain.ID
+	// CounterpartEntryID 相手が手放すエントリID
+	CounterpartEntryID domain.ID
+	// CounterpartDate 相手が手放す勤務日
+	CounterpartDate time.Time
+	// CounterpartShiftTypeID 申請時点の相手のシフト種別ID
+	CounterpartShiftTypeID *domain.ID
+	// Status 状態
+	Status SwapStatus
+	// Reason 申請理由
+	Reason string
+	// History 操作履歴 古い順
+	History []SwapEvent
+	// CreatedAt 作成日時
+	CreatedAt time.Time
+	// UpdatedAt 更新日時
+	UpdatedAt time.Time
+}
+
+// SwapActor 勤務交換を操作したユーザー
+type SwapActor struct {
+	// UserID ユーザーID
+	UserID domain.ID
+	// Email 操作時点のメールアドレス
+	Email string
+	// StaffID メールアドレスが一致する組織のスタッフID 該当なしはnil 履歴には記録しない
+	StaffID *domain.ID
+	// IsManager マネージャー以上のロール 履歴には記録しない
+	IsManager bool
+}
+
+// IsStaff 操作ユーザーが指定スタッフ本人か判定
+func (a SwapActor) IsStaff(staffID domain.ID) bool {
+	return a.StaffID != nil && *a.StaffID == staffID
+}
+
+// CanRequestFor 指定スタッフの勤務の交換を申請できるか判定 本人またはマネージャー以上
+func (a SwapActor) CanRequestFor(staffID domain.ID) bool {
+	return a.IsManager || a.IsStaff(staffID)
+}
+
+// SwapEvent 勤務交換の操作履歴
+type SwapEvent struct {
+	// Status 操作後の状態
+	Status SwapStatus
+	// Actor 操作ユーザー
+	Actor SwapActor
+	// Comment コメント
+	Comment string
+	// At 操作日時
+	At time.Time
+}
+
+// NewShiftSwap 勤務交換申請生成 申請時点のエントリを記録する
+func NewShiftSwap(organizationID domain.ID, requester, counterpart *ScheduleEntry, reason string, actor SwapActor, now time.Time) *ShiftSwap {
+	swap := &ShiftSwap{
+		ID:                     domain.NewID(),
+		OrganizationID:         organizationID,
+		RequesterStaffID:       requester.StaffID,
+		RequesterEntryID:       requester.ID,
+		RequesterDate:          requester.TargetDate,
+		RequesterShiftTypeID:   requester.ShiftTypeID,
+		CounterpartStaffID:     counterpart.StaffID,
+		CounterpartEntryID:     counterpart.ID,
+		CounterpartDate:        counterpart.TargetDate,
+		CounterpartShiftTypeID: counterpart.ShiftTypeID,
+		Reason:                 reason,
+		CreatedAt:              now,
+	}
+	swap.transition(SwapStatusPending, actor, "", now)
+	return swap
+}
+
+// IsPending 相手の承諾待ち判定
+func (s *ShiftSwap) IsPending() bool {
+	return s.Status == SwapStatusPending
+}
+
+// IsAccepted 管理者の承認待ち判定
+func (s *ShiftSwap) IsAccepted() bool {
+	return s.Status == SwapStatusAccepted
+}
+
+// IsOpen 未完了判定 承諾待ちまたは承認待ち
+func (s *ShiftSwap) IsOpen() bool {
+	return s.IsPending() || s.IsAccepted()
+}
+
+// Dates 交換で勤務が入れ替わる日付 同じ日の交換は1日
+func (s *ShiftSwap) Dates() []time.Time {
+	if sameDate(s.RequesterDate, s.CounterpartDate) {
+		return []time.Time{s.RequesterDate}
+	}
+	return []time.Time{s.RequesterDate, s.CounterpartDate}
+}
+
+// Involves エントリが交換対象か判定
+func (s *ShiftSwap) Involves(entryID domain.ID) bool {
+	return s.RequesterEntryID == entryID || s.CounterpartEntryID == entryID
+}
+
+// MatchesEntries 申請時点から対象エントリが変更されていないか判定
+func (s *ShiftSwap) MatchesEntries(requester, counterpart *ScheduleEntry) bool {
+	return requester.StaffID == s.RequesterStaffID &&
+		counterpart.StaffID == s.CounterpartStaffID &&
+		sameShiftTypeID(requester.ShiftTypeID, s.RequesterShiftTypeID) &&
+		sameShiftTypeID(counterpart.ShiftTypeID, s.CounterpartShiftTypeID)
+}
+
+// Accept 相手が承諾 相手のスタッフ本人のみ
+func (s *ShiftSwap) Accept(actor SwapActor, now time.Time) error {
+	if !actor.IsStaff(s.CounterpartStaffID) {
+		return domain.NewDomainError(domain.ErrCodeForbidden, "相手のスタッフ本人のみ承諾できます")
+	}
+	if !s.IsPending() {
+		return s.closedError()
+	}
+	s.transition(SwapStatusAccepted, actor, "", now)
+	return nil
+}
+
+// Decline 相手が辞退 相手のスタッフ本人のみ
+func (s *ShiftSwap) Decline(actor SwapActor, comment string, now time.Time) error {
+	if !actor.IsStaff(s.CounterpartStaffID) {
+		return domain.NewDomainError(domain.ErrCodeForbidden, "相手のスタッフ本人のみ辞退できます")
+	}
+	if !s.IsPending() {
+		return s.closedError()
+	}
+	s.transition(SwapStatusDeclined, actor, comment, now)
+	return nil
+}
+
+// Approve 管理者が承認
+func (s *ShiftSwap) Approve(actor SwapActor, comment string, now time.Time) error {
+	if !s.IsAccepted() {
+		return s.notAcceptedError()
+	}
+	s.transition(SwapStatusApproved, actor, comment, now)
+	return nil
+}
+
+// Reject 管理者が却下
+func (s *ShiftSwap) Reject(actor SwapActor, comment string, now time.Time) error {
+	if !s.IsAccepted() {
+		return s.notAcceptedError()
+	}
+	s.transition(SwapStatusRejected, actor, comment, now)
+	return nil
+}
+
+// Cancel 申請者が取り下げ 承認前まで可能 申請者本人とマネージャー以上のみ
+func (s *ShiftSwap) Cancel(actor SwapActor, comment string, now time.Time) error {
+	if !actor.CanRequestFor(s.RequesterStaffID) {
+		return domain.NewDomainError(domain.ErrCodeForbidden, "申請者本人のみ取り下げできます")
+	}
+	if !s.IsOpen() {
+		return s.closedError()
+	}
+	s.transition(SwapStatusCancelled, actor, comment, now)
+	return nil
+}
+
+// transition 状態を変更し履歴に記録
+func (s *ShiftSwap) transition(status SwapStatus, actor SwapActor, comment string, now time.Time) {
+	s.Status = status
+	s.History = append(s.History, SwapEvent{Status: status, Actor: actor, Comment: comment, At: now})
+	s.UpdatedAt = now
+}
+
+// closedError 受付終了エラー
+func (s *ShiftSwap) closedError() error {
+	if s.IsAccepted() {
+		return domain.NewDomainError(domain.ErrCodeConflict, "この勤務交換は既に承諾されています")
+	}
+	return domain.NewDomainError(domain.ErrCodeConflict, "この勤務交換は既に"+s.Status.Label()+"です")
+}
+
+// notAcceptedError 承認待ちでない場合のエラー
+func (s *ShiftSwap) notAcceptedError() error {
+	if s.IsPending() {
+		return domain.NewDomainError(domain.ErrCodeConflict, "相手のスタッフがまだ承諾していません")
+	}
+	return domain.NewDomainError(domain.ErrCodeConflict, "この勤務交換は既に"+s.Status.Label()+"です")
+}
+
+// SwapStatus 勤務交換状態
+type SwapStatus string
+
+const (
+	// SwapStatusPending 相手の承諾待ち
+	SwapStatusPending SwapStatus = "pending"
+	// SwapStatusAccepted 管理者の承認待ち
+	SwapStatusAccepted SwapStatus = "accepted"
+	// SwapStatusApproved 承認済み 勤務表に反映済み
+	SwapStatusApproved SwapStatus = "approved"
+	// SwapStatusDeclined 相手が辞退
+	SwapStatusDeclined SwapStatus = "declined"
+	// SwapStatusRejected 管理者が却下
+	SwapStatusRejected SwapStatus = "rejected"
+	// SwapStatusCancelled 申請者が取り下げ
+	SwapStatusCancelled SwapStatus = "cancelled"
+)
+
+// String 文字列変換
+func (s SwapStatus) String() string {
+	return string(s)
+}
+
+// Label 表示ラベル
+func (s SwapStatus) Label() string {
+	switch s {
+	case SwapStatusPending:
+		return "承諾待ち"
+	case SwapStatusAccepted:
+		return "承認待ち"
+	case SwapStatusApproved:
+		return "承認済み"
+	case SwapStatusDeclined:
+		return "辞退"
+	case SwapStatusRejected:
+		return "却下"
+	case SwapStatusCancelled:
+		return "取り下げ"
+	default:
+		return "不明"
+	}
+}
+
+// ExchangeShifts 2人のスタッフの指定日のシフトを入れ替える 変更されるエントリの複製を返す
+// いずれかの日に2人のどちらかのエントリがない場合はエラー
+func ExchangeShifts(entries []ScheduleEntry, staffA, staffB domain.ID, dates []time.Time, now time.Time) ([]ScheduleEntry, error) {
+	changed := make([]ScheduleEntry, 0, len(dates)*2)
+	for _, date := range dates {
+		var a, b *ScheduleEntry
+		for i := range entries {
+			if !sameDate(entries[i].TargetDate, date) {
+				continue
+			}
+			switch entries[i].StaffID {
+			case staffA:
+				a = &entries[i]
+			case staffB:
+				b = &entries[i]
+			}
+		}
+		if a == nil || b == nil {
+			return nil, domain.NewDomainError(domain.ErrCodeValidation, date.Format("1月2日")+"に2人分の勤務表エントリがないため交換できません")
+		}
+		if sameShiftTypeID(a.ShiftTypeID, b.ShiftTypeID) {
+			continue
+		}
+
+		newA, newB := *a, *b
+		newA.ShiftTypeID, newB.ShiftTypeID = b.ShiftTypeID, a.ShiftTypeID
+		newA.UpdatedAt, newB.UpdatedAt = now, now
+		changed = append(changed, newA, newB)
+	}
+	if len(changed) == 0 {
+		return nil, domain.NewDomainError(domain.ErrCodeValidation, "シフトが同じため交換しても勤務表は変わりません")
+	}
+	return changed, nil
+}
+
+// sameDate 日付の同一判定
+func sameDate(a, b time.Time) bool {
+	return a.Format("2006-01-02") == b.Format("2006-01-02")
+}
+
+// sameShiftTypeID シフト種別IDの同一判定
+func sameShiftTypeID(a, b *domain.ID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
@@ -0,0 +1,200 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"shiftmaster/internal/shared/domain"
+)
+
+func TestExchangeShifts(t *testing.T) {
+	day, night := domain.NewID(), domain.NewID()
+	staffA, staffB := domain.NewID(), domain.NewID()
+	jan := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC) }
+	entry := func(staffID domain.ID, date time.Time, shiftTypeID domain.ID) ScheduleEntry {
+		return ScheduleEntry{ID: domain.NewID(), StaffID: staffID, TargetDate: date, ShiftTypeID: &shiftTypeID}
+	}
+	entries := []ScheduleEntry{
+		entry(staffA, jan(1), day),
+		entry(staffB, jan(1), night),
+		entry(staffA, jan(2), night),
+		entry(staffB, jan(2), day),
+		entry(staffA, jan(3), day),
+		entry(staffB, jan(3), day),
+	}
+
+	t.Run("同じ日の交換は2件", func(t *testing.T) {
+		changed, err := ExchangeShifts(entries, staffA, staffB, []time.Time{jan(1)}, jan(1))
+		if err != nil {
+			t.Fatalf("ExchangeShifts() error = %v", err)
+		}
+		if len(changed) != 2 {
+			t.Fatalf("changed = %d, want 2", len(changed))
+		}
+		if *changed[0].ShiftTypeID != night || *changed[1].ShiftTypeID != day {
+			t.Error("シフトが入れ替わっていません")
+		}
+		if *entries[0].ShiftTypeID != day {
+			t.Error("元のエントリが変更されています")
+		}
+	})
+
+	t.Run("異なる日の交換は両日とも入れ替え", func(t *testing.T) {
+		changed, err := ExchangeShifts(entries, staffA, staffB, []time.Time{jan(1), jan(2)}, jan(1))
+		if err != nil {
+			t.Fatalf("ExchangeShifts() error = %v", err)
+		}
+		if len(changed) != 4 {
+			t.Errorf("changed = %d, want 4", len(changed))
+		}
+	})
+
+	t.Run("同じシフトの日は変更なし", func(t *testing.T) {
+		changed, err := ExchangeShifts(entries, staffA, staffB, []time.Time{jan(1), jan(3)}, jan(1))
+		if err != nil {
+			t.Fatalf("ExchangeShifts() error = %v", err)
+		}
+		if len(changed) != 2 {
+			t.Errorf("changed = %d, want 2", len(changed))
+		}
+	})
+
+	t.Run("シフトが同じ場合はエラー", func(t *testing.T) {
+		_, err := ExchangeShifts(entries, staffA, staffB, []time.Time{jan(3)}, jan(1))
+		if err == nil {
+			t.Error("エラーになりません")
+		}
+	})
+
+	t.Run("エントリがない日はエラー", func(t *testing.T) {
+		_, err := ExchangeShifts(entries, staffA, staffB, []time.Time{jan(4)}, jan(1))
+		if err == nil {
+			t.Error("エラーになりません")
+		}
+	})
+}
+
+func TestShiftSwap_Transitions(t *testing.T) {
+	now := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	shiftTypeID := domain.NewID()
+	requesterStaffID, counterpartStaffID := domain.NewID(), domain.NewID()
+	newSwap := func() *ShiftSwap {
+		requester := &ScheduleEntry{ID: domain.NewID(), StaffID: requesterStaffID, TargetDate: now, ShiftTypeID: &shiftTypeID}
+		counterpart := &ScheduleEntry{ID: domain.NewID(), StaffID: counterpartStaffID, TargetDate: now}
+		return NewShiftSwap(domain.NewID(), requester, counterpart, "私用のため", SwapActor{Email: "a@example.com", StaffID: &requesterStaffID}, now)
+	}
+	requesterActor := SwapActor{Email: "a@example.com", StaffID: &requesterStaffID}
+	actor := SwapActor{Email: "b@example.com", StaffID: &counterpartStaffID}
+	manager := SwapActor{Email: "manager@example.com", IsManager: true}
+
+	tests := []struct {
+		name    string
+		steps   func(s *ShiftSwap) error
+		want    SwapStatus
+		wantErr bool
+	}{
+		{"承諾", func(s *ShiftSwap) error { return s.Accept(actor, now) }, SwapStatusAccepted, false},
+		{"辞退", func(s *ShiftSwap) error { return s.Decline(actor, "", now) }, SwapStatusDeclined, false},
+		{"承諾前の承認はエラー", func(s *ShiftSwap) error { return s.Approve(manager, "", now) }, SwapStatusPending, true},
+		{"承諾前の却下はエラー", func(s *ShiftSwap) error { return s.Reject(manager, "", now) }, SwapStatusPending, true},
+		{"承諾後に承認", func(s *ShiftSwap) error {
+			if err := s.Accept(actor, now); err != nil {
+				return err
+			}
+			return s.Approve(manager, "", now)
+		}, SwapStatusApproved, false},
+		{"承諾後に取り下げ", func(s *ShiftSwap) error {
+			if err := s.Accept(actor, now); err != nil {
+				return err
+			}
+			return s.Cancel(requesterActor, "", now)
+		}, SwapStatusCancelled, false},
+		{"マネージャーが取り下げ", func(s *ShiftSwap) error { return s.Cancel(manager, "", now) }, SwapStatusCancelled, false},
+		{"承諾後の辞退はエラー", func(s *ShiftSwap) error {
+			if err := s.Accept(actor, now); err != nil {
+				return err
+			}
+			return s.Decline(actor, "", now)
+		}, SwapStatusAccepted, true},
+		{"辞退後の取り下げはエラー", func(s *ShiftSwap) error {
+			if err := s.Decline(actor, "", now); err != nil {
+				return err
+			}
+			return s.Cancel(requesterActor, "", now)
+		}, SwapStatusDeclined, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newSwap()
+			err := tt.steps(s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var domainErr *domain.DomainError
+			if err != nil && (!errors.As(err, &domainErr) || domainErr.Code != domain.ErrCodeConflict) {
+				t.Errorf("error = %v, want conflict", err)
+			}
+			if s.Status != tt.want {
+				t.Errorf("Status = %v, want %v", s.Status, tt.want)
+			}
+		})
+	}
+}
+
+func TestShiftSwap_ActorPermissions(t *testing.T) {
+	now := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	requesterStaffID, counterpartStaffID, otherStaffID := domain.NewID(), domain.NewID(), domain.NewID()
+	requesterActor := SwapActor{Email: "a@example.com", StaffID: &requesterStaffID}
+	other := SwapActor{Email: "c@example.com", StaffID: &otherStaffID}
+	manager := SwapActor{Email: "manager@example.com", IsManager: true}
+	newSwap := func() *ShiftSwap {
+		requester := &ScheduleEntry{ID: domain.NewID(), StaffID: requesterStaffID, TargetDate: now}
+		counterpart := &ScheduleEntry{ID: domain.NewID(), StaffID: counterpartStaffID, TargetDate: now}
+		return NewShiftSwap(domain.NewID(), requester, counterpart, "", requesterActor, now)
+	}
+
+	tests := []struct {
+		name string
+		step func(s *ShiftSwap) error
+	}{
+		{"申請者は承諾できない", func(s *ShiftSwap) error { return s.Accept(requesterActor, now) }},
+		{"他のスタッフは承諾できない", func(s *ShiftSwap) error { return s.Accept(other, now) }},
+		{"マネージャーも代わりに承諾できない", func(s *ShiftSwap) error { return s.Accept(manager, now) }},
+		{"他のスタッフは辞退できない", func(s *ShiftSwap) error { return s.Decline(other, "", now) }},
+		{"他のスタッフは取り下げできない", func(s *ShiftSwap) error { return s.Cancel(other, "", now) }},
+		{"スタッフ未登録のユーザーは取り下げできない", func(s *ShiftSwap) error { return s.Cancel(SwapActor{Email: "x@example.com"}, "", now) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newSwap()
+			err := tt.step(s)
+			var domainErr *domain.DomainError
+			if !errors.As(err, &domainErr) || domainErr.Code != domain.ErrCodeForbidden {
+				t.Errorf("error = %v, want forbidden", err)
+			}
+			if s.Status != SwapStatusPending || len(s.History) != 1 {
+				t.Errorf("Status = %v, History = %d, want pending", s.Status, len(s.History))
+			}
+		})
+	}
+}
+
+func TestShiftSwap_MatchesEntries(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	day, night := domain.NewID(), domain.NewID()
+	requester := ScheduleEntry{ID: domain.NewID(), StaffID: domain.NewID(), TargetDate: now, ShiftTypeID: &day}
+	counterpart := ScheduleEntry{ID: domain.NewID(), StaffID: domain.NewID(), TargetDate: now, ShiftTypeID: &night}
+	swap := NewShiftSwap(domain.NewID(), &requester, &counterpart, "", SwapActor{}, now)
+
+	if !swap.MatchesEntries(&requester, &counterpart) {
+		t.Error("申請時点のエントリと一致しません")
+	}
+
+	changed := counterpart
+	changed.ShiftTypeID = nil
+	if swap.MatchesEntries(&requester, &changed) {
+		t.Error("シフト変更後のエントリと一致しています")
+	}
+}
@@ -0,0 +1,151 @@
+// Package infrastructure 勤務表インフラストラクチャ層
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"shiftmaster/internal/modules/schedule/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// ActualRecordModel 勤務実績DBモデル
+type ActualRecordModel struct {
+	bun.BaseModel `bun:"table:actual_records"`
+
+	ID                         uuid.UUID  `bun:"id,pk,type:uuid"`
+	ScheduleEntryID            uuid.UUID  `bun:"schedule_entry_id,type:uuid,notnull"`
+	ActualStartTime            *time.Time `bun:"actual_start_time"`
+	ActualEndTime              *time.Time `bun:"actual_end_time"`
+	ActualBreakMinutes         int        `bun:"actual_break_minutes,notnull"`
+	OvertimeMinutes            int        `bun:"overtime_minutes,notnull"`
+	WithinLegalOvertimeMinutes int        `bun:"within_legal_overtime_minutes,notnull"`
+	OverLegalOvertimeMinutes   int        `bun:"over_legal_overtime_minutes,notnull"`
+	LateNightMinutes           int        `bun:"late_night_minutes,notnull"`
+	HolidayWorkMinutes         int        `bun:"holiday_work_minutes,notnull"`
+	Note                       string     `bun:"note"`
+	CreatedAt                  time.Time  `bun:"created_at,notnull"`
+	UpdatedAt                  time.Time  `bun:"updated_at,notnull"`
+}
+
+// ToDomain DBモデルからドメインエンティティへ変換
+func (m *ActualRecordModel) ToDomain() *domain.ActualRecord {
+	return &domain.ActualRecord{
+		ID:                         m.ID,
+		ScheduleEntryID:            m.ScheduleEntryID,
+		ActualStartTime:            m.ActualStartTime,
+		ActualEndTime:              m.ActualEndTime,
+		ActualBreakMinutes:         m.ActualBreakMinutes,
+		OvertimeMinutes:            m.OvertimeMinutes,
+		WithinLegalOvertimeMinutes: m.WithinLegalOvertimeMinutes,
+		OverLegalOvertimeMinutes:   m.OverLegalOvertimeMinutes,
+		LateNightMinutes:           m.LateNightMinutes,
+		HolidayWorkMinutes:         m.HolidayWorkMinutes,
+		Note:                       m.Note,
+		CreatedAt:                  m.CreatedAt,
+		UpdatedAt:                  m.UpdatedAt,
+	}
+}
+
+// PostgresActualRecordRepository PostgreSQL勤務実績リポジトリ
+type PostgresActualRecordRepository struct {
+	db *bun.DB
+}
+
+// NewPostgresActualRecordRepository リポジトリ生成
+func NewPostgresActualRecordRepository(db *bun.DB) *PostgresActualRecordRepository {
+	return &PostgresActualRecordRepository{db: db}
+}
+
+// FindByID IDで検索
+func (r *PostgresActualRecordRepository) FindByID(ctx context.Context, id sharedDomain.ID) (*domain.ActualRecord, error) {
+	model := &ActualRecordModel{}
+	err := r.db.NewSelect().Model(model).Where("id = ?", id).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.ToDomain(), nil
+}
+
+// FindByEntryID エントリIDで検索
+func (r *PostgresActualRecordRepository) FindByEntryID(ctx context.Context, entryID sharedDomain.ID) (*domain.ActualRecord, error) {
+	model := &ActualRecordModel{}
+	err := r.db.NewSelect().Model(model).Where("schedule_entry_id = ?", entryID).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.ToDomain(), nil
+}
+
+// FindBySchedule 勤務表の実績検索
+func (r *PostgresActualRecordRepository) FindBySchedule(ctx context.Context, scheduleID sharedDomain.ID) ([]domain.ActualRecord, error) {
+	var models []ActualRecordModel
+	err := r.db.NewSelect().
+		Model(&models).
+		Where("schedule_entry_id IN (SELECT id FROM schedule_entries WHERE schedule_id = ?)", scheduleID).
+		Order("actual_start_time ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]domain.ActualRecord, len(models))
+	for i, m := range models {
+		records[i] = *m.ToDomain()
+	}
+
+	return records, nil
+}
+
+// Save 保存
+func (r *PostgresActualRecordRepository) Save(ctx context.Context, record *domain.ActualRecord) error {
+	model := &ActualRecordModel{
+		ID:                         record.ID,
+		ScheduleEntryID:            record.ScheduleEntryID,
+		ActualStartTime:            record.ActualStartTime,
+		ActualEndTime:              record.ActualEndTime,
+		ActualBreakMinutes:         record.ActualBreakMinutes,
+		OvertimeMinutes:            record.OvertimeMinutes,
+		WithinLegalOvertimeMinutes: record.WithinLegalOvertimeMinutes,
+		OverLegalOvertimeMinutes:   record.OverLegalOvertimeMinutes,
+		LateNightMinutes:           record.LateNightMinutes,
+		HolidayWorkMinutes:         record.HolidayWorkMinutes,
+		Note:                       record.Note,
+		CreatedAt:                  record.CreatedAt,
+		UpdatedAt:                  record.UpdatedAt,
+	}
+
+	_, err := r.db.NewInsert().
+		Model(model).
+		On("CONFLICT (id) DO UPDATE").
+		Set("actual_start_time = EXCLUDED.actual_start_time").
+		Set("actual_end_time = EXCLUDED.actual_end_time").
+		Set("actual_break_minutes = EXCLUDED.actual_break_minutes").
+		Set("overtime_minutes = EXCLUDED.overtime_minutes").
+		Set("within_legal_overtime_minutes = EXCLUDED.within_legal_overtime_minutes").
+		Set("over_legal_overtime_minutes = EXCLUDED.over_legal_overtime_minutes").
+		Set("late_night_minutes = EXCLUDED.late_night_minutes").
+		Set("holiday_work_minutes = EXCLUDED.holiday_work_minutes").
+		Set("note = EXCLUDED.note").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+
+	return err
+}
+
+// Delete 削除
+func (r *PostgresActualRecordRepository) Delete(ctx context.Context, id sharedDomain.ID) error {
+	_, err := r.db.NewDelete().Model((*ActualRecordModel)(nil)).Where("id = ?", id).Exec(ctx)
+	return err
+}
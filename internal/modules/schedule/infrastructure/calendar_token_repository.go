@@ -0,0 +1,94 @@
+// Package infrastructure 勤務表インフラストラクチャ層
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"shiftmaster/internal/modules/schedule/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// CalendarTokenModel カレンダー購読トークンDBモデル
+type CalendarTokenModel struct {
+	bun.BaseModel `bun:"table:calendar_tokens"`
+
+	StaffID        uuid.UUID `bun:"staff_id,pk,type:uuid"`
+	OrganizationID uuid.UUID `bun:"organization_id,type:uuid,notnull"`
+	TokenHash      string    `bun:"token_hash,notnull"`
+	CreatedAt      time.Time `bun:"created_at,notnull"`
+}
+
+// ToDomain DBモデルからドメインエンティティへ変換
+func (m *CalendarTokenModel) ToDomain() *domain.CalendarToken {
+	return &domain.CalendarToken{
+		StaffID:        m.StaffID,
+		OrganizationID: m.OrganizationID,
+		TokenHash:      m.TokenHash,
+		CreatedAt:      m.CreatedAt,
+	}
+}
+
+// PostgresCalendarTokenRepository PostgreSQLカレンダー購読トークンリポジトリ
+type PostgresCalendarTokenRepository struct {
+	db *bun.DB
+}
+
+// NewPostgresCalendarTokenRepository リポジトリ生成
+func NewPostgresCalendarTokenRepository(db *bun.DB) *PostgresCalendarTokenRepository {
+	return &PostgresCalendarTokenRepository{db: db}
+}
+
+// FindByStaffID スタッフIDで検索
+func (r *PostgresCalendarTokenRepository) FindByStaffID(ctx context.Context, staffID sharedDomain.ID) (*domain.CalendarToken, error) {
+	return r.findOne(ctx, "staff_id = ?", staffID)
+}
+
+// FindByTokenHash トークンハッシュで検索
+func (r *PostgresCalendarTokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*domain.CalendarToken, error) {
+	return r.findOne(ctx, "token_hash = ?", tokenHash)
+}
+
+// findOne 条件に一致する1件を取得 該当なしはnil
+func (r *PostgresCalendarTokenRepository) findOne(ctx context.Context, where string, arg any) (*domain.CalendarToken, error) {
+	model := &CalendarTokenModel{}
+	err := r.db.NewSelect().Model(model).Where(where, arg).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.ToDomain(), nil
+}
+
+// Save 保存 スタッフごとに1件
+func (r *PostgresCalendarTokenRepository) Save(ctx context.Context, token *domain.CalendarToken) error {
+	model := &CalendarTokenModel{
+		StaffID:        token.StaffID,
+		OrganizationID: token.OrganizationID,
+		TokenHash:      token.TokenHash,
+		CreatedAt:      token.CreatedAt,
+	}
+
+	_, err := r.db.NewInsert().
+		Model(model).
+		On("CONFLICT (staff_id) DO UPDATE").
+		Set("organization_id = EXCLUDED.organization_id").
+		Set("token_hash = EXCLUDED.token_hash").
+		Set("created_at = EXCLUDED.created_at").
+		Exec(ctx)
+
+	return err
+}
+
+// DeleteByStaffID スタッフのトークン削除
+func (r *PostgresCalendarTokenRepository) DeleteByStaffID(ctx context.Context, staffID sharedDomain.ID) error {
+	_, err := r.db.NewDelete().Model((*CalendarTokenModel)(nil)).Where("staff_id = ?", staffID).Exec(ctx)
+	return err
+}
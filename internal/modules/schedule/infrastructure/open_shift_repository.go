@@ -0,0 +1,249 @@
+// Package infrastructure 勤務表インフラストラクチャ層
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"shiftmaster/internal/modules/schedule/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+	"shiftmaster/internal/shared/infrastructure"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// OpenShiftModel 募集シフトDBモデル
+type OpenShiftModel struct {
+	bun.BaseModel `bun:"table:open_shifts"`
+
+	ID                uuid.UUID            `bun:"id,pk,type:uuid"`
+	OrganizationID    uuid.UUID            `bun:"organization_id,type:uuid,notnull"`
+	ScheduleID        uuid.UUID            `bun:"schedule_id,type:uuid,notnull"`
+	SourceEntryID     *uuid.UUID           `bun:"source_entry_id,type:uuid"`
+	SourceStaffID     *uuid.UUID           `bun:"source_staff_id,type:uuid"`
+	TargetDate        time.Time            `bun:"target_date,type:date,notnull"`
+	ShiftTypeID       uuid.UUID            `bun:"shift_type_id,type:uuid,notnull"`
+	RequiredSkillID   *uuid.UUID           `bun:"required_skill_id,type:uuid"`
+	RequiredJobTypeID *uuid.UUID           `bun:"required_job_type_id,type:uuid"`
+	FillMode          string               `bun:"fill_mode,notnull"`
+	Status            string               `bun:"status,notnull"`
+	Note              string               `bun:"note,notnull"`
+	Claims            []OpenShiftClaimJSON `bun:"claims,type:jsonb,notnull"`
+	FilledStaffID     *uuid.UUID           `bun:"filled_staff_id,type:uuid"`
+	FilledBy          string               `bun:"filled_by,notnull"`
+	FilledAt          *time.Time           `bun:"filled_at"`
+	CreatedBy         string               `bun:"created_by,notnull"`
+	CreatedAt         time.Time            `bun:"created_at,notnull"`
+	UpdatedAt         time.Time            `bun:"updated_at,notnull"`
+}
+
+// OpenShiftClaimJSON 募集シフト応募JSON
+type OpenShiftClaimJSON struct {
+	StaffID   string    `json:"staff_id"`
+	UserEmail string    `json:"user_email"`
+	ClaimedAt time.Time `json:"claimed_at"`
+}
+
+// ToDomain DBモデルからドメインエンティティへ変換
+func (m *OpenShiftModel) ToDomain() *domain.OpenShift {
+	claims := make([]domain.OpenShiftClaim, 0, len(m.Claims))
+	for _, c := range m.Claims {
+		staffID, err := sharedDomain.ParseID(c.StaffID)
+		if err != nil {
+			continue
+		}
+		claims = append(claims, domain.OpenShiftClaim{StaffID: staffID, UserEmail: c.UserEmail, ClaimedAt: c.ClaimedAt})
+	}
+
+	return &domain.OpenShift{
+		ID:                m.ID,
+		OrganizationID:    m.OrganizationID,
+		ScheduleID:        m.ScheduleID,
+		SourceEntryID:     m.SourceEntryID,
+		SourceStaffID:     m.SourceStaffID,
+		TargetDate:        m.TargetDate,
+		ShiftTypeID:       m.ShiftTypeID,
+		RequiredSkillID:   m.RequiredSkillID,
+		RequiredJobTypeID: m.RequiredJobTypeID,
+		FillMode:          domain.FillMode(m.FillMode),
+		Status:            domain.OpenShiftStatus(m.Status),
+		Note:              m.Note,
+		Claims:            claims,
+		FilledStaffID:     m.FilledStaffID,
+		FilledBy:          m.FilledBy,
+		FilledAt:          m.FilledAt,
+		CreatedBy:         m.CreatedBy,
+		CreatedAt:         m.CreatedAt,
+		UpdatedAt:         m.UpdatedAt,
+	}
+}
+
+// FromDomain ドメインエンティティからDBモデルへ変換
+func (m *OpenShiftModel) FromDomain(o *domain.OpenShift) {
+	m.ID = o.ID
+	m.OrganizationID = o.OrganizationID
+	m.ScheduleID = o.ScheduleID
+	m.SourceEntryID = o.SourceEntryID
+	m.SourceStaffID = o.SourceStaffID
+	m.TargetDate = o.TargetDate
+	m.ShiftTypeID = o.ShiftTypeID
+	m.RequiredSkillID = o.RequiredSkillID
+	m.RequiredJobTypeID = o.RequiredJobTypeID
+	m.FillMode = o.FillMode.String()
+	m.Status = o.Status.String()
+	m.Note = o.Note
+	m.FilledStaffID = o.FilledStaffID
+	m.FilledBy = o.FilledBy
+	m.FilledAt = o.FilledAt
+	m.CreatedBy = o.CreatedBy
+	m.CreatedAt = o.CreatedAt
+	m.UpdatedAt = o.UpdatedAt
+
+	m.Claims = make([]OpenShiftClaimJSON, len(o.Claims))
+	for i, c := range o.Claims {
+		m.Claims[i] = OpenShiftClaimJSON{StaffID: c.StaffID.String(), UserEmail: c.UserEmail, ClaimedAt: c.ClaimedAt}
+	}
+}
+
+// PostgresOpenShiftRepository PostgreSQL募集シフトリポジトリ
+type PostgresOpenShiftRepository struct {
+	db *bun.DB
+}
+
+// NewPostgresOpenShiftRepository リポジトリ生成
+func NewPostgresOpenShiftRepository(db *bun.DB) *PostgresOpenShiftRepository {
+	return &PostgresOpenShiftRepository{db: db}
+}
+
+// FindByID IDで検索
+func (r *PostgresOpenShiftRepository) FindByID(ctx context.Context, id sharedDomain.ID) (*domain.OpenShift, error) {
+	model := &OpenShiftModel{}
+	err := r.db.NewSelect().Model(model).Where("id = ?", id).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.ToDomain(), nil
+}
+
+// FindByOrganizationID 組織IDで検索 勤務日順
+func (r *PostgresOpenShiftRepository) FindByOrganizationID(ctx context.Context, organizationID sharedDomain.ID, status domain.OpenShiftStatus) ([]domain.OpenShift, error) {
+	var models []OpenShiftModel
+	query := r.db.NewSelect().
+		Model(&models).
+		Where("organization_id = ?", organizationID).
+		Order("target_date ASC", "created_at ASC")
+	if status != "" {
+		query = query.Where("status = ?", status.String())
+	}
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	openShifts := make([]domain.OpenShift, len(models))
+	for i := range models {
+		openShifts[i] = *models[i].ToDomain()
+	}
+	return openShifts, nil
+}
+
+// Save 保存 応募の記録と取り消しは募集中の行だけを更新し、決定済みの募集は上書きしない
+// 行をロックして保存済みの応募に追記するため、同時の応募が互いを上書きしない
+func (r *PostgresOpenShiftRepository) Save(ctx context.Context, openShift *domain.OpenShift) error {
+	model := &OpenShiftModel{}
+	model.FromDomain(openShift)
+
+	return infrastructure.RunInTransaction(ctx, r.db, func(ctx context.Context, tx bun.Tx) error {
+		current, err := lockOpenShift(ctx, tx, model.ID)
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			_, err := tx.NewInsert().Model(model).Exec(ctx)
+			return err
+		}
+		if current.Status != domain.OpenShiftStatusOpen.String() {
+			return sharedDomain.NewDomainError(sharedDomain.ErrCodeConflict, "この募集は既に締め切られています")
+		}
+
+		model.Claims = mergeOpenShiftClaims(current.Claims, model.Claims)
+		if _, err := tx.NewUpdate().
+			Model(model).
+			Column("status", "claims", "updated_at").
+			WherePK().
+			Exec(ctx); err != nil {
+			return err
+		}
+		openShift.Claims = model.ToDomain().Claims
+		return nil
+	})
+}
+
+// Fill 決定した募集の保存とエントリ更新を1トランザクションで行う
+// 募集中の行だけを更新し、同時に決定・取り消しされた場合は競合エラーとする
+func (r *PostgresOpenShiftRepository) Fill(ctx context.Context, openShift *domain.OpenShift, entries []domain.ScheduleEntry) error {
+	model := &OpenShiftModel{}
+	model.FromDomain(openShift)
+
+	return infrastructure.RunInTransaction(ctx, r.db, func(ctx context.Context, tx bun.Tx) error {
+		current, err := lockOpenShift(ctx, tx, model.ID)
+		if err != nil {
+			return err
+		}
+		if current == nil || current.Status != domain.OpenShiftStatusOpen.String() {
+			return sharedDomain.NewDomainError(sharedDomain.ErrCodeConflict, "この募集は既に締め切られています")
+		}
+
+		model.Claims = mergeOpenShiftClaims(current.Claims, model.Claims)
+		if _, err := tx.NewUpdate().
+			Model(model).
+			Column("status", "claims", "filled_staff_id", "filled_by", "filled_at", "updated_at").
+			WherePK().
+			Exec(ctx); err != nil {
+			return err
+		}
+		openShift.Claims = model.ToDomain().Claims
+
+		return upsertEntries(ctx, tx, entries)
+	})
+}
+
+// lockOpenShift 募集の行をトランザクション終了までロックして取得 該当なしはnil
+func lockOpenShift(ctx context.Context, tx bun.Tx, id uuid.UUID) (*OpenShiftModel, error) {
+	current := &OpenShiftModel{}
+	err := tx.NewSelect().
+		Model(current).
+		Where("id = ?", id).
+		For("UPDATE").
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return current, nil
+}
+
+// mergeOpenShiftClaims 保存済みの応募に未登録のスタッフの応募を古い順のまま追記
+// 応募は追加のみのため、読み込み後に他の応募が保存されていても失われない
+func mergeOpenShiftClaims(stored, claims []OpenShiftClaimJSON) []OpenShiftClaimJSON {
+	merged := append([]OpenShiftClaimJSON{}, stored...)
+	seen := make(map[string]bool, len(merged)+len(claims))
+	for _, c := range merged {
+		seen[c.StaffID] = true
+	}
+	for _, c := range claims {
+		if seen[c.StaffID] {
+			continue
+		}
+		seen[c.StaffID] = true
+		merged = append(merged, c)
+	}
+	return merged
+}
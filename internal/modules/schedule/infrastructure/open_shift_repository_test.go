@@ -0,0 +1,37 @@
+// Package infrastructure 募集シフトリポジトリテスト
+package infrastructure
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMergeOpenShiftClaims(t *testing.T) {
+	base := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	stored := []OpenShiftClaimJSON{
+		{StaffID: "a", UserEmail: "a@example.com", ClaimedAt: base},
+		{StaffID: "b", UserEmail: "b@example.com", ClaimedAt: base.Add(time.Minute)},
+	}
+	// 読み込み時点ではaのみで、bの応募を知らないままcが応募した
+	claims := []OpenShiftClaimJSON{
+		{StaffID: "a", UserEmail: "a@example.com", ClaimedAt: base},
+		{StaffID: "c", UserEmail: "c@example.com", ClaimedAt: base.Add(2 * time.Minute)},
+	}
+
+	merged := mergeOpenShiftClaims(stored, claims)
+	if len(merged) != 3 {
+		t.Fatalf("expected 3 claims, got %d", len(merged))
+	}
+	for i, want := range []string{"a", "b", "c"} {
+		if merged[i].StaffID != want {
+			t.Errorf("claims[%d]: expected %s, got %s", i, want, merged[i].StaffID)
+		}
+	}
+	if len(stored) != 2 {
+		t.Errorf("stored claims should not be modified, got %d", len(stored))
+	}
+
+	if got := mergeOpenShiftClaims(nil, nil); got == nil || len(got) != 0 {
+		t.Errorf("expected empty non-nil claims, got %v", got)
+	}
+}
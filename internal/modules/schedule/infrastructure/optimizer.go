@@ -0,0 +1,177 @@
+// Package infrastructure 勤務表インフラストラクチャ層
+package infrastructure
+
+import (
+	"context"
+	"encoding/binary"
+	"log/slog"
+	"time"
+
+	requestDomain "shiftmaster/internal/modules/request/domain"
+	"shiftmaster/internal/modules/schedule/domain"
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
+	staffDomain "shiftmaster/internal/modules/staff/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+const (
+	// defaultOptimizeIterations 既定の最大反復回数
+	defaultOptimizeIterations = 50000
+	// defaultOptimizeTimeout 既定のタイムアウト
+	defaultOptimizeTimeout = 30 * time.Second
+)
+
+// LocalSearchOptimizer 貪欲法と焼きなまし法による勤務表自動作成
+type LocalSearchOptimizer struct {
+	scheduleRepo  domain.ScheduleRepository
+	staffRepo     staffDomain.StaffRepository
+	shiftTypeRepo shiftDomain.ShiftTypeRepository
+	ruleRepo      shiftDomain.ShiftRuleRepository
+	requestFinder domain.ShiftRequestFinder
+	// qualificationFinder 職位要件の判定と違反の評価に使用
+	qualificationFinder domain.StaffQualificationFinder
+	logger              *slog.Logger
+}
+
+// NewLocalSearchOptimizer 勤務表自動作成オプティマイザ生成
+// ruleRepo・requestFinderはnil可 nilの場合は既定ルールのみで作成
+// qualificationFinderはnil可 nilの場合は必須職位ルールを満たすスタッフなしとして扱う
+func NewLocalSearchOptimizer(
+	scheduleRepo domain.ScheduleRepository,
+	staffRepo staffDomain.StaffRepository,
+	shiftTypeRepo shiftDomain.ShiftTypeRepository,
+	ruleRepo shiftDomain.ShiftRuleRepository,
+	requestFinder domain.ShiftRequestFinder,
+	qualificationFinder domain.StaffQualificationFinder,
+	logger *slog.Logger,
+) *LocalSearchOptimizer {
+	return &LocalSearchOptimizer{
+		scheduleRepo:        scheduleRepo,
+		staffRepo:           staffRepo,
+		shiftTypeRepo:       shiftTypeRepo,
+		ruleRepo:            ruleRepo,
+		requestFinder:       requestFinder,
+		qualificationFinder: qualificationFinder,
+		logger:              logger,
+	}
+}
+
+// Optimize 勤務表最適化
+// 確定済みエントリと固定希望は変更せず、残りのセルを貪欲法で埋めた後に焼きなまし法で改善する
+func (o *LocalSearchOptimizer) Optimize(ctx interface{}, input domain.OptimizeInput) (*domain.OptimizeResult, error) {
+	c, ok := ctx.(context.Context)
+	if !ok {
+		c = context.Background()
+	}
+	started := time.Now()
+
+	schedule, err := o.scheduleRepo.FindByIDWithEntries(c, input.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil {
+		return nil, sharedDomain.ErrNotFound
+	}
+
+	staffs, err := o.staffRepo.FindActiveByOrganizationID(c, schedule.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	shiftTypes, err := o.shiftTypeRepo.FindByOrganizationID(c, schedule.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := o.loadRules(c, schedule.OrganizationID, input.Constraints)
+	if err != nil {
+		return nil, err
+	}
+
+	var requests []requestDomain.ShiftRequest
+	if o.requestFinder != nil {
+		requests, err = o.requestFinder.FindByTargetMonth(c, schedule.OrganizationID, schedule.TargetYear, schedule.TargetMonth)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var qualifications *domain.StaffQualifications
+	if o.qualificationFinder != nil {
+		qualifications, err = o.qualificationFinder.FindByOrganizationID(c, schedule.OrganizationID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	problem := newOptimizerProblem(schedule, input.DateRange, staffs, shiftTypes, requests, input.Options)
+	problem.setQualifications(qualifications)
+	problem.applyRules(rules)
+
+	if len(problem.staffIDs) == 0 || len(problem.workShifts) == 0 {
+		return &domain.OptimizeResult{
+			Success: false,
+			Entries: []domain.ScheduleEntry{},
+			Violations: []domain.ConstraintViolation{{
+				ConstraintType: "no_candidate",
+				Message:        "有効なスタッフまたは勤務シフトが登録されていません",
+				Severity:       "error",
+			}},
+			Duration: time.Since(started),
+		}, nil
+	}
+
+	maxIterations := input.Options.MaxIterations
+	if maxIterations <= 0 {
+		maxIterations = defaultOptimizeIterations
+	}
+	timeout := defaultOptimizeTimeout
+	if input.Options.TimeoutSeconds > 0 {
+		timeout = time.Duration(input.Options.TimeoutSeconds) * time.Second
+	}
+
+	solver := newAnnealingSolver(problem, seedFromID(schedule.ID))
+	grid, iterations := solver.solve(c, maxIterations, started.Add(timeout))
+
+	penalty, violations := problem.evaluate(grid)
+	entries := problem.toEntries(grid, time.Now())
+
+	o.logger.Info("勤務表自動作成完了",
+		"schedule_id", schedule.ID,
+		"iterations", iterations,
+		"penalty", penalty,
+		"violation_count", len(violations),
+	)
+
+	return &domain.OptimizeResult{
+		Success:    true,
+		Entries:    entries,
+		Score:      -penalty,
+		Violations: violations,
+		Duration:   time.Since(started),
+	}, nil
+}
+
+// loadRules 有効なシフトルールと入力制約を結合
+func (o *LocalSearchOptimizer) loadRules(ctx context.Context, organizationID sharedDomain.ID, extra []domain.ConstraintConfig) ([]shiftDomain.ShiftRule, error) {
+	var rules []shiftDomain.ShiftRule
+	if o.ruleRepo != nil {
+		found, err := o.ruleRepo.FindActiveByOrganizationID(ctx, organizationID)
+		if err != nil {
+			return nil, err
+		}
+		rules = found
+	}
+	for _, c := range extra {
+		rules = append(rules, c.ToShiftRule(organizationID))
+	}
+	return rules, nil
+}
+
+// seedFromID 勤務表IDから乱数シードを生成 同じ勤務表では同じ結果を再現
+func seedFromID(id sharedDomain.ID) [2]uint64 {
+	return [2]uint64{
+		binary.BigEndian.Uint64(id[:8]),
+		binary.BigEndian.Uint64(id[8:]),
+	}
+}
@@ -0,0 +1,795 @@
+// Package infrastructure 勤務表インフラストラクチャ層
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"time"
+
+	requestDomain "shiftmaster/internal/modules/request/domain"
+	"shiftmaster/internal/modules/schedule/domain"
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
+	staffDomain "shiftmaster/internal/modules/staff/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// offCell 勤務なし（休み）を表すセル値
+const offCell = -1
+
+// defaultMinStaffPerShift 最小人数ルール未設定時に探索で目標とする各勤務シフトの配置人数
+const defaultMinStaffPerShift = 1
+
+// ペナルティ重み 大きいほど優先して解消 違反の大きさ1あたりの値
+const (
+	penaltyAvailability     = 150.0
+	penaltyShortage         = 100.0
+	penaltyQualification    = 90.0
+	penaltyRequiredRequest  = 80.0
+	penaltyInterval         = 50.0
+	penaltyExcess           = 40.0
+	penaltyConsecutive      = 30.0
+	penaltyOther            = 30.0
+	penaltyNightLimit       = 20.0
+	penaltyWorkingHours     = 10.0
+	penaltyOptionalRequest  = 5.0
+	penaltyWorkload         = 4.0
+	prioritizedRequestScale = 3.0
+)
+
+// violationPenalties 制約違反種別ごとのペナルティ重み 勤務希望は重大度で重みを変えるため含まない
+// 未登録の種別はpenaltyOtherを使用
+var violationPenalties = map[string]float64{
+	domain.ViolationAvailability:      penaltyAvailability,
+	domain.ViolationCoverageShortage:  penaltyShortage,
+	domain.ViolationSkillShortage:     penaltyQualification,
+	domain.ViolationPositionShortage:  penaltyQualification,
+	domain.ViolationShiftInterval:     penaltyInterval,
+	domain.ViolationTimeOverlap:       penaltyInterval,
+	domain.ViolationCoverageExcess:    penaltyExcess,
+	domain.ViolationConsecutiveWork:   penaltyConsecutive,
+	domain.ViolationConsecutiveNight:  penaltyConsecutive,
+	domain.ViolationMonthlyNightLimit: penaltyNightLimit,
+	domain.ViolationWeeklyHours:       penaltyWorkingHours,
+	domain.ViolationMonthlyHours:      penaltyWorkingHours,
+}
+
+// 焼きなまし温度
+const (
+	annealingStartTemperature = 60.0
+	annealingEndTemperature   = 0.5
+)
+
+// optimizerShift 最適化用シフト情報
+type optimizerShift struct {
+	id        sharedDomain.ID
+	name      string
+	isHoliday bool
+	isNight   bool
+	// start 出勤時刻 申し送り込み 当日0時起点の分
+	start int
+	// end 退勤時刻 当日0時起点の分 日跨ぎは1440以上
+	end int
+}
+
+// optimizerRequest 最適化用勤務希望 固定希望のセル固定と休日シフトの設定に使用
+// 充足の評価は勤務表検証と同じ制約で行う
+type optimizerRequest struct {
+	requestType requestDomain.RequestType
+	// shift 対象シフトのインデックス 休み希望はoffCell 勤務シフト指定なしはanyWork
+	shift int
+	// holidayShiftID 休日シフト指定時のシフト種別ID
+	holidayShiftID *sharedDomain.ID
+}
+
+// anyWork 勤務シフト指定なしの希望を表す値
+const anyWork = -2
+
+// optimizerProblem 最適化問題
+type optimizerProblem struct {
+	schedule   *domain.Schedule
+	scheduleID sharedDomain.ID
+	days       []time.Time
+	staffIDs   []sharedDomain.ID
+	staffs     []staffDomain.Staff
+	// qualifications スタッフ資格情報 違反の評価に使用
+	qualifications *domain.StaffQualifications
+	// flexible 勤務日数目標の下限を課さないスタッフ パート等
+	flexible []bool
+	// unavailable スタッフ・日・シフトごとの勤務可能条件違反 勤務可能条件が未登録のスタッフはnil
+	// 初期解の構築で割り当て候補から除外するために使用
+	unavailable [][][]bool
+	shifts      []optimizerShift
+	// workShifts 割り当て候補となる勤務シフトのインデックス
+	workShifts []int
+	// offShiftID 休みセルに設定する休日シフト種別ID nilの場合は未割り当て
+	offShiftID *sharedDomain.ID
+
+	initial  [][]int
+	pinned   [][]bool
+	fixed    map[[2]int]domain.ScheduleEntry
+	requests [][]*optimizerRequest
+	// others 最適化対象外のスタッフ・日付の既存エントリ 違反の評価に含める
+	others []domain.ScheduleEntry
+
+	// shiftTypes shiftRequests pipeline 違反の評価に使用
+	shiftTypes    map[string]*shiftDomain.ShiftType
+	shiftRequests []requestDomain.ShiftRequest
+	pipeline      *domain.ConstraintPipeline
+
+	// input 探索中の評価に使う制約入力 エントリは評価ごとに渡す
+	input *domain.ConstraintInput
+	// staffConstraints dayConstraints 探索でスタッフ1名分・1日分を再評価する制約
+	staffConstraints []domain.StaffConstraint
+	dayConstraints   []domain.DayConstraint
+	// rowEntries スタッフごとの日付順の評価用エントリ 対象期間外の既存エントリを含み、評価時に割り当てを反映する
+	rowEntries [][]domain.ScheduleEntry
+	// rowIndex スタッフ・日ごとのrowEntries上の位置
+	rowIndex [][]int
+	// dayOthers 日ごとの最適化対象外スタッフの既存エントリ
+	dayOthers [][]domain.ScheduleEntry
+	// dayEntries 1日分の評価用バッファ
+	dayEntries []domain.ScheduleEntry
+
+	targetWorkDays int
+	requestScale   float64
+}
+
+// newOptimizerProblem 最適化問題を構築
+func newOptimizerProblem(
+	schedule *domain.Schedule,
+	dateRange sharedDomain.DateRange,
+	staffs []staffDomain.Staff,
+	shiftTypes []shiftDomain.ShiftType,
+	requests []requestDomain.ShiftRequest,
+	options domain.OptimizeOptions,
+) *optimizerProblem {
+	p := &optimizerProblem{
+		schedule:      schedule,
+		scheduleID:    schedule.ID,
+		shiftTypes:    make(map[string]*shiftDomain.ShiftType, len(shiftTypes)),
+		shiftRequests: requests,
+		fixed:         make(map[[2]int]domain.ScheduleEntry),
+		requestScale:  1.0,
+	}
+	if options.PrioritizeRequests {
+		p.requestScale = prioritizedRequestScale
+	}
+
+	// 対象日
+	start := time.Date(schedule.TargetYear, time.Month(schedule.TargetMonth), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, -1)
+	if !dateRange.Start.IsZero() && !dateRange.End.IsZero() {
+		start = time.Date(dateRange.Start.Year(), dateRange.Start.Month(), dateRange.Start.Day(), 0, 0, 0, 0, time.UTC)
+		end = time.Date(dateRange.End.Year(), dateRange.End.Month(), dateRange.End.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	weekendDays := 0
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		p.days = append(p.days, d)
+		if d.Weekday() == time.Saturday || d.Weekday() == time.Sunday {
+			weekendDays++
+		}
+	}
+	p.targetWorkDays = len(p.days) - weekendDays
+
+	// シフト 勤務シフトは開始時刻順 休日シフトは表示順の先頭を休みに使用
+	sorted := make([]shiftDomain.ShiftType, len(shiftTypes))
+	copy(sorted, shiftTypes)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].SortOrder < sorted[j].SortOrder
+	})
+	shiftIndex := make(map[sharedDomain.ID]int, len(sorted))
+	for i := range sorted {
+		p.shiftTypes[sorted[i].ID.String()] = &sorted[i]
+	}
+	for _, st := range sorted {
+		startMinutes := st.StartTime.Hour()*60 + st.StartTime.Minute()
+		shift := optimizerShift{
+			id:        st.ID,
+			name:      st.Name,
+			isHoliday: st.IsHoliday,
+			isNight:   st.IsNightShift,
+			start:     startMinutes - st.HandoverMinutes,
+			end:       startMinutes + st.TotalMinutes(),
+		}
+		shiftIndex[st.ID] = len(p.shifts)
+		p.shifts = append(p.shifts, shift)
+		if st.IsHoliday {
+			if p.offShiftID == nil {
+				id := st.ID
+				p.offShiftID = &id
+			}
+			continue
+		}
+		p.workShifts = append(p.workShifts, len(p.shifts)-1)
+	}
+
+	// スタッフ 資格情報が設定されない場合はスタッフの保有スキルのみで評価
+	staffIndex := make(map[sharedDomain.ID]int, len(staffs))
+	for _, s := range staffs {
+		staffIndex[s.ID] = len(p.staffIDs)
+		p.staffIDs = append(p.staffIDs, s.ID)
+		p.staffs = append(p.staffs, s)
+		p.flexible = append(p.flexible,
+			s.EmploymentType == staffDomain.EmploymentPartTime || s.EmploymentType == staffDomain.EmploymentTemporary)
+	}
+	staffMap := make(map[string]*staffDomain.Staff, len(p.staffs))
+	for i := range p.staffs {
+		staffMap[p.staffs[i].ID.String()] = &p.staffs[i]
+	}
+	p.qualifications = &domain.StaffQualifications{Staffs: staffMap}
+
+	dayIndex := make(map[string]int, len(p.days))
+	for i, d := range p.days {
+		dayIndex[d.Format("2006-01-02")] = i
+	}
+
+	p.initial = make([][]int, len(p.staffIDs))
+	p.pinned = make([][]bool, len(p.staffIDs))
+	p.requests = make([][]*optimizerRequest, len(p.staffIDs))
+	for s := range p.staffIDs {
+		p.initial[s] = make([]int, len(p.days))
+		p.pinned[s] = make([]bool, len(p.days))
+		p.requests[s] = make([]*optimizerRequest, len(p.days))
+		for d := range p.days {
+			p.initial[s][d] = offCell
+		}
+	}
+
+	// cellValue シフト種別IDをセル値へ変換
+	cellValue := func(shiftTypeID *sharedDomain.ID) (int, bool) {
+		if shiftTypeID == nil {
+			return offCell, true
+		}
+		idx, ok := shiftIndex[*shiftTypeID]
+		if !ok {
+			return offCell, false
+		}
+		if p.shifts[idx].isHoliday {
+			return offCell, true
+		}
+		return idx, true
+	}
+
+	// 既存エントリ 確定済みは固定 未確定は初期解として利用
+	for _, e := range schedule.Entries {
+		s, ok := staffIndex[e.StaffID]
+		if !ok {
+			p.others = append(p.others, e)
+			continue
+		}
+		d, ok := dayIndex[e.TargetDate.Format("2006-01-02")]
+		if !ok {
+			p.others = append(p.others, e)
+			continue
+		}
+		v, ok := cellValue(e.ShiftTypeID)
+		if !ok {
+			continue
+		}
+		p.initial[s][d] = v
+		if e.IsConfirmed {
+			p.pinned[s][d] = true
+			p.fixed[[2]int{s, d}] = e
+		}
+	}
+
+	// 勤務希望 固定希望はセルを固定
+	for _, r := range requests {
+		s, ok := staffIndex[r.StaffID]
+		if !ok {
+			continue
+		}
+		d, ok := dayIndex[r.TargetDate.Format("2006-01-02")]
+		if !ok || p.pinned[s][d] {
+			continue
+		}
+		req := &optimizerRequest{
+			requestType: r.RequestType,
+			shift:       anyWork,
+		}
+		if r.ShiftTypeID != nil {
+			v, ok := cellValue(r.ShiftTypeID)
+			if !ok {
+				continue
+			}
+			req.shift = v
+			if v == offCell {
+				id := *r.ShiftTypeID
+				req.holidayShiftID = &id
+			}
+		}
+		if r.RequestType == requestDomain.RequestTypeFixed && req.shift != anyWork {
+			p.initial[s][d] = req.shift
+			p.pinned[s][d] = true
+		}
+		p.requests[s][d] = req
+	}
+
+	p.buildEvaluationEntries(dayIndex)
+	return p
+}
+
+// buildEvaluationEntries 探索中の評価に使うスタッフごと・日ごとのエントリを構築
+// 最適化対象外の既存エントリは評価に含め、対象セルは評価時に割り当てを反映する
+func (p *optimizerProblem) buildEvaluationEntries(dayIndex map[string]int) {
+	staffIndex := make(map[sharedDomain.ID]int, len(p.staffIDs))
+	for s, staffID := range p.staffIDs {
+		staffIndex[staffID] = s
+	}
+
+	p.rowEntries = make([][]domain.ScheduleEntry, len(p.staffIDs))
+	p.rowIndex = make([][]int, len(p.staffIDs))
+	p.dayOthers = make([][]domain.ScheduleEntry, len(p.days))
+	for s, staffID := range p.staffIDs {
+		for _, date := range p.days {
+			p.rowEntries[s] = append(p.rowEntries[s], domain.ScheduleEntry{StaffID: staffID, TargetDate: date})
+		}
+	}
+	for _, e := range p.others {
+		if s, ok := staffIndex[e.StaffID]; ok {
+			p.rowEntries[s] = append(p.rowEntries[s], e)
+		} else if d, ok := dayIndex[e.TargetDate.Format("2006-01-02")]; ok {
+			p.dayOthers[d] = append(p.dayOthers[d], e)
+		}
+	}
+
+	for s := range p.staffIDs {
+		row := p.rowEntries[s]
+		sort.SliceStable(row, func(i, j int) bool {
+			return row[i].TargetDate.Before(row[j].TargetDate)
+		})
+		p.rowIndex[s] = make([]int, len(p.days))
+		d := 0
+		for i := range row {
+			if d < len(p.days) && row[i].ID == (sharedDomain.ID{}) && row[i].TargetDate.Equal(p.days[d]) {
+				p.rowIndex[s][d] = i
+				d++
+			}
+		}
+	}
+}
+
+// setQualifications 違反の評価に使うスタッフ資格情報を設定 applyRulesより前に呼び出す
+// nilの場合はスタッフの保有スキルのみで評価し、必須職位ルールを満たすスタッフなしとして扱う
+func (p *optimizerProblem) setQualifications(qualifications *domain.StaffQualifications) {
+	if qualifications != nil {
+		p.qualifications = qualifications
+	}
+	p.applyAvailabilities()
+}
+
+// applyAvailabilities スタッフの勤務可能条件を日・シフトごとの勤務可否に展開
+func (p *optimizerProblem) applyAvailabilities() {
+	p.unavailable = make([][][]bool, len(p.staffIDs))
+	for s, staffID := range p.staffIDs {
+		availability := p.qualifications.Availability(staffID.String())
+		if availability == nil {
+			continue
+		}
+		table := make([][]bool, len(p.days))
+		for d, date := range p.days {
+			table[d] = make([]bool, len(p.shifts))
+			for k, shift := range p.shifts {
+				if shift.isHoliday {
+					continue
+				}
+				table[d][k] = availability.ConflictOn(date, shift.id, shift.start, shift.end) != ""
+			}
+		}
+		p.unavailable[s] = table
+	}
+}
+
+// isUnavailable 割り当てがスタッフの勤務可能条件に反するか
+func (p *optimizerProblem) isUnavailable(s, d, v int) bool {
+	return v != offCell && s < len(p.unavailable) && p.unavailable[s] != nil && p.unavailable[s][d][v]
+}
+
+// applyRules シフトルールを反映 未設定の種別は既定ルールで補完
+// 探索のペナルティと違反の評価には勤務表検証と同じ制約を使用し、同種のルールが複数ある場合はそれぞれを評価する
+// 最小人数ルールがない場合は各勤務シフト1名以上を探索の目標とするが、違反としては報告しない
+func (p *optimizerProblem) applyRules(rules []shiftDomain.ShiftRule) {
+	rules = shiftDomain.WithDefaultShiftRules(p.schedule.OrganizationID, rules)
+	p.pipeline = domain.NewConstraintPipeline(rules, nil)
+
+	search := p.pipeline
+	if !hasMinStaffRule(rules) {
+		search = domain.NewConstraintPipeline(append(append([]shiftDomain.ShiftRule{}, rules...), shiftDomain.ShiftRule{
+			Name:     "既定の最小配置人数",
+			RuleType: shiftDomain.RuleTypeMinStaff,
+			IsActive: true,
+			Config:   fmt.Sprintf(`{"min_count":%d}`, defaultMinStaffPerShift),
+		}), nil)
+	}
+
+	p.staffConstraints, p.dayConstraints = nil, nil
+	for _, c := range search.Constraints() {
+		if sc, ok := c.(domain.StaffConstraint); ok {
+			p.staffConstraints = append(p.staffConstraints, sc)
+		}
+		if dc, ok := c.(domain.DayConstraint); ok {
+			p.dayConstraints = append(p.dayConstraints, dc)
+		}
+	}
+	p.input = &domain.ConstraintInput{
+		Schedule:       p.schedule,
+		ShiftTypes:     p.shiftTypes,
+		Qualifications: p.qualifications,
+		Requests:       p.shiftRequests,
+	}
+}
+
+// hasMinStaffRule 有効な最小配置人数ルールがあるか
+func hasMinStaffRule(rules []shiftDomain.ShiftRule) bool {
+	for _, rule := range rules {
+		if rule.IsActive && rule.RuleType == shiftDomain.RuleTypeMinStaff {
+			if _, err := rule.ParseConfig(); err == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// cellShiftID セル値のシフト種別ID 休みはnil
+func (p *optimizerProblem) cellShiftID(v int) *sharedDomain.ID {
+	if v == offCell {
+		return nil
+	}
+	return &p.shifts[v].id
+}
+
+// violationPenalty 制約違反の重み付き合計 情報レベルの違反は対象外
+func (p *optimizerProblem) violationPenalty(violations []domain.ConstraintViolation) float64 {
+	penalty := 0.0
+	for _, v := range violations {
+		if v.Severity == domain.SeverityInfo {
+			continue
+		}
+		weight, ok := violationPenalties[v.ConstraintType]
+		switch {
+		case v.ConstraintType == domain.ViolationRequestUnmet:
+			weight = penaltyOptionalRequest * p.requestScale
+			if v.Severity == domain.SeverityError {
+				weight = penaltyRequiredRequest * p.requestScale
+			}
+		case !ok:
+			weight = penaltyOther
+		}
+		penalty += weight * float64(max(v.Amount, 1))
+	}
+	return penalty
+}
+
+// rowViolations スタッフ1名分の割り当てをスタッフ単位の制約で評価
+func (p *optimizerProblem) rowViolations(row []int, s int) []domain.ConstraintViolation {
+	entries := p.rowEntries[s]
+	for d, v := range row {
+		entries[p.rowIndex[s][d]].ShiftTypeID = p.cellShiftID(v)
+	}
+	violations := make([]domain.ConstraintViolation, 0)
+	for _, c := range p.staffConstraints {
+		violations = append(violations, c.EvaluateStaff(p.input, entries)...)
+	}
+	return violations
+}
+
+// rowPenalty スタッフ1名分のペナルティ 制約違反と勤務日数の平準化
+func (p *optimizerProblem) rowPenalty(row []int, s int) float64 {
+	penalty := p.violationPenalty(p.rowViolations(row, s))
+
+	workDays := 0
+	for _, v := range row {
+		if v != offCell {
+			workDays++
+		}
+	}
+	// 勤務日数の平準化 パート等は上限側のみ
+	diff := workDays - p.targetWorkDays
+	if diff > 0 || !p.flexible[s] {
+		penalty += math.Abs(float64(diff)) * penaltyWorkload
+	}
+
+	return penalty
+}
+
+// columnViolations 1日分の割り当てを日単位の制約で評価
+func (p *optimizerProblem) columnViolations(grid [][]int, d int) []domain.ConstraintViolation {
+	entries := append(p.dayEntries[:0], p.dayOthers[d]...)
+	for s := range grid {
+		if v := grid[s][d]; v != offCell {
+			entries = append(entries, domain.ScheduleEntry{StaffID: p.staffIDs[s], TargetDate: p.days[d], ShiftTypeID: p.cellShiftID(v)})
+		}
+	}
+	p.dayEntries = entries
+
+	violations := make([]domain.ConstraintViolation, 0)
+	for _, c := range p.dayConstraints {
+		violations = append(violations, c.EvaluateDay(p.input, p.days[d], entries)...)
+	}
+	return violations
+}
+
+// columnPenalty 1日分の配置人数・有資格者のペナルティ
+func (p *optimizerProblem) columnPenalty(grid [][]int, d int) float64 {
+	return p.violationPenalty(p.columnViolations(grid, d))
+}
+
+// evaluate 総ペナルティと違反リストを算出 違反は勤務表検証と同じ制約パイプラインで評価
+// applyRulesより後に呼び出す
+func (p *optimizerProblem) evaluate(grid [][]int) (float64, []domain.ConstraintViolation) {
+	total := 0.0
+	for d := range p.days {
+		total += p.columnPenalty(grid, d)
+	}
+	for s := range p.staffIDs {
+		total += p.rowPenalty(grid[s], s)
+	}
+
+	entries := append(p.toEntries(grid, time.Time{}), p.others...)
+	violations := p.pipeline.Evaluate(&domain.ConstraintInput{
+		Schedule:       p.schedule,
+		Entries:        entries,
+		ShiftTypes:     p.shiftTypes,
+		Qualifications: p.qualifications,
+		Requests:       p.shiftRequests,
+	})
+	return total, violations
+}
+
+// toEntries 解を勤務表エントリへ変換
+func (p *optimizerProblem) toEntries(grid [][]int, now time.Time) []domain.ScheduleEntry {
+	entries := make([]domain.ScheduleEntry, 0, len(p.staffIDs)*len(p.days))
+	for s, staffID := range p.staffIDs {
+		for d, date := range p.days {
+			if e, ok := p.fixed[[2]int{s, d}]; ok {
+				entries = append(entries, e)
+				continue
+			}
+
+			var shiftTypeID *sharedDomain.ID
+			if v := grid[s][d]; v != offCell {
+				id := p.shifts[v].id
+				shiftTypeID = &id
+			} else if req := p.requests[s][d]; req != nil && req.holidayShiftID != nil && req.requestType != requestDomain.RequestTypeAvoided {
+				shiftTypeID = req.holidayShiftID
+			} else {
+				shiftTypeID = p.offShiftID
+			}
+
+			entries = append(entries, domain.ScheduleEntry{
+				ID:          sharedDomain.NewID(),
+				ScheduleID:  p.scheduleID,
+				StaffID:     staffID,
+				TargetDate:  date,
+				ShiftTypeID: shiftTypeID,
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			})
+		}
+	}
+	return entries
+}
+
+// annealingSolver 焼きなまし法ソルバー
+type annealingSolver struct {
+	problem *optimizerProblem
+	rng     *rand.Rand
+	grid    [][]int
+	rows    []float64
+	columns []float64
+	total   float64
+	// freeStaff 日ごとの変更可能なスタッフ
+	freeStaff [][]int
+	// freeCells 変更可能なセル
+	freeCells [][2]int
+}
+
+// newAnnealingSolver ソルバー生成
+func newAnnealingSolver(p *optimizerProblem, seed [2]uint64) *annealingSolver {
+	solver := &annealingSolver{
+		problem:   p,
+		rng:       rand.New(rand.NewPCG(seed[0], seed[1])),
+		grid:      make([][]int, len(p.staffIDs)),
+		rows:      make([]float64, len(p.staffIDs)),
+		columns:   make([]float64, len(p.days)),
+		freeStaff: make([][]int, len(p.days)),
+	}
+	for s := range p.staffIDs {
+		solver.grid[s] = make([]int, len(p.days))
+		copy(solver.grid[s], p.initial[s])
+		for d := range p.days {
+			if !p.pinned[s][d] {
+				solver.freeStaff[d] = append(solver.freeStaff[d], s)
+				solver.freeCells = append(solver.freeCells, [2]int{s, d})
+			}
+		}
+	}
+	return solver
+}
+
+// solve 初期解を構築し局所探索で改善 最良解と反復回数を返す
+func (a *annealingSolver) solve(ctx context.Context, maxIterations int, deadline time.Time) ([][]int, int) {
+	a.construct()
+	a.recalculate()
+
+	best := cloneGrid(a.grid)
+	bestTotal := a.total
+	if len(a.freeCells) == 0 {
+		return best, 0
+	}
+
+	iterations := 0
+	for ; iterations < maxIterations; iterations++ {
+		if iterations&0xff == 0 {
+			if time.Now().After(deadline) || ctx.Err() != nil {
+				break
+			}
+		}
+		if bestTotal == 0 {
+			break
+		}
+
+		progress := float64(iterations) / float64(maxIterations)
+		temperature := annealingStartTemperature * math.Pow(annealingEndTemperature/annealingStartTemperature, progress)
+
+		var accepted bool
+		if a.rng.IntN(2) == 0 {
+			accepted = a.tryChange(temperature)
+		} else {
+			accepted = a.trySwap(temperature)
+		}
+
+		if accepted && a.total < bestTotal-1e-9 {
+			bestTotal = a.total
+			best = cloneGrid(a.grid)
+		}
+	}
+
+	return best, iterations
+}
+
+// construct 貪欲法による初期解構築 夜勤から順に、その日の配置人数・有資格者のペナルティが減る間、増分ペナルティ最小のスタッフを割り当て
+func (a *annealingSolver) construct() {
+	p := a.problem
+
+	// 夜勤を先に埋める
+	order := make([]int, len(p.workShifts))
+	copy(order, p.workShifts)
+	sort.SliceStable(order, func(i, j int) bool {
+		si, sj := p.shifts[order[i]], p.shifts[order[j]]
+		if si.isNight != sj.isNight {
+			return si.isNight
+		}
+		return si.start < sj.start
+	})
+
+	for d := range p.days {
+		for _, k := range order {
+			for {
+				columnBefore := p.columnPenalty(a.grid, d)
+				if columnBefore == 0 {
+					break
+				}
+				bestStaff, bestDelta := -1, math.Inf(1)
+				for _, s := range a.freeStaff[d] {
+					// 勤務可能条件に反するスタッフは割り当てず人員不足として残す
+					if a.grid[s][d] != offCell || p.isUnavailable(s, d, k) {
+						continue
+					}
+					before := p.rowPenalty(a.grid[s], s)
+					a.grid[s][d] = k
+					column := p.columnPenalty(a.grid, d) - columnBefore
+					delta := p.rowPenalty(a.grid[s], s) - before + column
+					a.grid[s][d] = offCell
+					if column < 0 && delta < bestDelta {
+						bestStaff, bestDelta = s, delta
+					}
+				}
+				if bestStaff < 0 {
+					break
+				}
+				a.grid[bestStaff][d] = k
+			}
+		}
+	}
+}
+
+// recalculate キャッシュ済みペナルティを再計算
+func (a *annealingSolver) recalculate() {
+	a.total = 0
+	for s := range a.grid {
+		a.rows[s] = a.problem.rowPenalty(a.grid[s], s)
+		a.total += a.rows[s]
+	}
+	for d := range a.columns {
+		a.columns[d] = a.problem.columnPenalty(a.grid, d)
+		a.total += a.columns[d]
+	}
+}
+
+// tryChange 1セルの割り当てを変更
+func (a *annealingSolver) tryChange(temperature float64) bool {
+	p := a.problem
+	cell := a.freeCells[a.rng.IntN(len(a.freeCells))]
+	s, d := cell[0], cell[1]
+
+	current := a.grid[s][d]
+	candidate := offCell
+	if pick := a.rng.IntN(len(p.workShifts) + 1); pick < len(p.workShifts) {
+		candidate = p.workShifts[pick]
+	}
+	if candidate == current {
+		return false
+	}
+
+	a.grid[s][d] = candidate
+	row := p.rowPenalty(a.grid[s], s)
+	column := p.columnPenalty(a.grid, d)
+	delta := row - a.rows[s] + column - a.columns[d]
+
+	if !a.accept(delta, temperature) {
+		a.grid[s][d] = current
+		return false
+	}
+
+	a.rows[s] = row
+	a.columns[d] = column
+	a.total += delta
+	return true
+}
+
+// trySwap 同日の2名の割り当てを交換 シフトごとの総人数は変わらないが、絞り込み条件付きの人数と有資格者数が変わるため列も再計算
+func (a *annealingSolver) trySwap(temperature float64) bool {
+	p := a.problem
+	d := a.rng.IntN(len(p.days))
+	free := a.freeStaff[d]
+	if len(free) < 2 {
+		return false
+	}
+
+	s1 := free[a.rng.IntN(len(free))]
+	s2 := free[a.rng.IntN(len(free))]
+	if s1 == s2 || a.grid[s1][d] == a.grid[s2][d] {
+		return false
+	}
+
+	a.grid[s1][d], a.grid[s2][d] = a.grid[s2][d], a.grid[s1][d]
+	row1 := p.rowPenalty(a.grid[s1], s1)
+	row2 := p.rowPenalty(a.grid[s2], s2)
+	delta := row1 - a.rows[s1] + row2 - a.rows[s2]
+	column := p.columnPenalty(a.grid, d)
+	delta += column - a.columns[d]
+
+	if !a.accept(delta, temperature) {
+		a.grid[s1][d], a.grid[s2][d] = a.grid[s2][d], a.grid[s1][d]
+		return false
+	}
+
+	a.rows[s1] = row1
+	a.rows[s2] = row2
+	a.columns[d] = column
+	a.total += delta
+	return true
+}
+
+// accept メトロポリス基準による受理判定
+func (a *annealingSolver) accept(delta, temperature float64) bool {
+	if delta <= 0 {
+		return true
+	}
+	return a.rng.Float64() < math.Exp(-delta/temperature)
+}
+
+// cloneGrid 割り当て表を複製
+func cloneGrid(grid [][]int) [][]int {
+	result := make([][]int, len(grid))
+	for i := range grid {
+		result[i] = make([]int, len(grid[i]))
+		copy(result[i], grid[i])
+	}
+	return result
+}
@@ -0,0 +1,436 @@
+// Package infrastructure 勤務表自動作成テスト
+package infrastructure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	requestDomain "shiftmaster/internal/modules/request/domain"
+	"shiftmaster/internal/modules/schedule/domain"
+	shiftDomain "shiftmaster/internal/modules/shift/domain"
+	staffDomain "shiftmaster/internal/modules/staff/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// optimizerFixture テスト用の勤務表・スタッフ・シフト種別
+type optimizerFixture struct {
+	schedule   *domain.Schedule
+	staffs     []staffDomain.Staff
+	shiftTypes []shiftDomain.ShiftType
+	day        shiftDomain.ShiftType
+	night      shiftDomain.ShiftType
+	off        shiftDomain.ShiftType
+}
+
+func newOptimizerFixture(staffCount int) *optimizerFixture {
+	clock := func(s string) time.Time {
+		t, _ := time.Parse("15:04", s)
+		return t
+	}
+
+	f := &optimizerFixture{
+		schedule: &domain.Schedule{
+			ID:             sharedDomain.NewID(),
+			OrganizationID: sharedDomain.NewID(),
+			TargetYear:     2025,
+			TargetMonth:    4,
+		},
+		day:   shiftDomain.ShiftType{ID: sharedDomain.NewID(), Name: "日勤", StartTime: clock("08:30"), EndTime: clock("17:30"), BreakMinutes: 60, SortOrder: 1},
+		night: shiftDomain.ShiftType{ID: sharedDomain.NewID(), Name: "夜勤", StartTime: clock("16:30"), EndTime: clock("09:00"), BreakMinutes: 120, IsNightShift: true, SortOrder: 2},
+		off:   shiftDomain.ShiftType{ID: sharedDomain.NewID(), Name: "公休", IsHoliday: true, SortOrder: 3},
+	}
+	f.shiftTypes = []shiftDomain.ShiftType{f.day, f.night, f.off}
+	for i := 0; i < staffCount; i++ {
+		f.staffs = append(f.staffs, staffDomain.Staff{
+			ID:             sharedDomain.NewID(),
+			EmploymentType: staffDomain.EmploymentFullTime,
+			IsActive:       true,
+		})
+	}
+	return f
+}
+
+func (f *optimizerFixture) solve(requests []requestDomain.ShiftRequest, rules []shiftDomain.ShiftRule) (*optimizerProblem, [][]int) {
+	problem := newOptimizerProblem(f.schedule, sharedDomain.DateRange{}, f.staffs, f.shiftTypes, requests, domain.OptimizeOptions{})
+	problem.applyRules(rules)
+	solver := newAnnealingSolver(problem, seedFromID(f.schedule.ID))
+	grid, _ := solver.solve(context.Background(), 20000, time.Now().Add(10*time.Second))
+	return problem, grid
+}
+
+func solveProblem(problem *optimizerProblem, scheduleID sharedDomain.ID) [][]int {
+	solver := newAnnealingSolver(problem, seedFromID(scheduleID))
+	grid, _ := solver.solve(context.Background(), 20000, time.Now().Add(10*time.Second))
+	return grid
+}
+
+// emptyGrid 全セルが休みの割り当て表
+func emptyGrid(problem *optimizerProblem) [][]int {
+	grid := make([][]int, len(problem.staffIDs))
+	for s := range grid {
+		grid[s] = make([]int, len(problem.days))
+		for d := range grid[s] {
+			grid[s][d] = offCell
+		}
+	}
+	return grid
+}
+
+func shiftIndexOf(problem *optimizerProblem, id sharedDomain.ID) int {
+	for k, shift := range problem.shifts {
+		if shift.id == id {
+			return k
+		}
+	}
+	return offCell
+}
+
+func countViolations(violations []domain.ConstraintViolation, constraintType string) int {
+	count := 0
+	for _, v := range violations {
+		if v.ConstraintType == constraintType {
+			count++
+		}
+	}
+	return count
+}
+
+func TestOptimizer_FillsMinimumCoverage(t *testing.T) {
+	f := newOptimizerFixture(8)
+	rules := []shiftDomain.ShiftRule{
+		{RuleType: "min_staff", IsActive: true, Config: `{"shift_type_id":"` + f.day.ID.String() + `","min_count":3}`},
+		{RuleType: "min_staff", IsActive: true, Config: `{"shift_type_id":"` + f.night.ID.String() + `","min_count":1}`},
+	}
+
+	problem, grid := f.solve(nil, rules)
+	_, violations := problem.evaluate(grid)
+
+	if n := countViolations(violations, "coverage_shortage"); n != 0 {
+		t.Errorf("coverage_shortage = %d, want 0", n)
+	}
+	if n := countViolations(violations, "consecutive_night"); n != 0 {
+		t.Errorf("consecutive_night = %d, want 0", n)
+	}
+	if n := countViolations(violations, "shift_interval"); n != 0 {
+		t.Errorf("shift_interval = %d, want 0", n)
+	}
+	if len(problem.days) != 30 {
+		t.Errorf("days = %d, want 30", len(problem.days))
+	}
+}
+
+func TestOptimizer_QualifiedStaffRequirements(t *testing.T) {
+	f := newOptimizerFixture(8)
+	skillID := sharedDomain.NewID()
+	for i := 0; i < 4; i++ {
+		f.staffs[i].Skills = []staffDomain.StaffSkill{{StaffID: f.staffs[i].ID, SkillID: skillID, Level: 3}}
+	}
+	f.staffs[4].Skills = []staffDomain.StaffSkill{{StaffID: f.staffs[4].ID, SkillID: skillID, Level: 1}}
+
+	// 4名が主任 うち1名は4/15で所属終了
+	leader, _ := staffDomain.NewPosition(f.schedule.OrganizationID, "主任", "LDR", 2)
+	ended := time.Date(2025, 4, 15, 0, 0, 0, 0, time.UTC)
+	assignmentMap := make(map[string][]staffDomain.StaffAssignment)
+	for i := 2; i < 6; i++ {
+		a := staffDomain.StaffAssignment{StaffID: f.staffs[i].ID, PositionID: &leader.ID}
+		if i == 5 {
+			a.EndDate = &ended
+		}
+		assignmentMap[f.staffs[i].ID.String()] = []staffDomain.StaffAssignment{a}
+	}
+	staffMap := make(map[string]*staffDomain.Staff, len(f.staffs))
+	for i := range f.staffs {
+		staffMap[f.staffs[i].ID.String()] = &f.staffs[i]
+	}
+
+	rules := []shiftDomain.ShiftRule{
+		{RuleType: "min_staff", IsActive: true, Config: `{"shift_type_id":"` + f.day.ID.String() + `","min_count":2}`},
+		{RuleType: "skill_required", IsActive: true, Config: `{"shift_type_id":"` + f.night.ID.String() + `","skill_id":"` + skillID.String() + `","min_level":3,"min_count":1}`},
+		{RuleType: "position_required", IsActive: true, Config: `{"shift_type_id":"` + f.day.ID.String() + `","level":2,"min_count":1}`},
+	}
+
+	problem := newOptimizerProblem(f.schedule, sharedDomain.DateRange{}, f.staffs, f.shiftTypes, nil, domain.OptimizeOptions{})
+	problem.setQualifications(&domain.StaffQualifications{
+		Staffs:      staffMap,
+		Assignments: assignmentMap,
+		Positions:   map[string]*staffDomain.Position{leader.ID.String(): leader},
+	})
+	problem.applyRules(rules)
+
+	// 所属終了前後の日勤に主任1名のみを配置
+	probe := emptyGrid(problem)
+	probe[5][13] = shiftIndexOf(problem, f.day.ID)
+	probe[5][15] = shiftIndexOf(problem, f.day.ID)
+	if n := countViolations(problem.columnViolations(probe, 13), "position_shortage"); n != 0 {
+		t.Errorf("4/14 position_shortage = %d, want 0", n)
+	}
+	if n := countViolations(problem.columnViolations(probe, 15), "position_shortage"); n != 1 {
+		t.Errorf("所属期間が職位要件に反映されていません position_shortage = %d, want 1", n)
+	}
+
+	solver := newAnnealingSolver(problem, seedFromID(f.schedule.ID))
+	grid, _ := solver.solve(context.Background(), 20000, time.Now().Add(10*time.Second))
+	_, violations := problem.evaluate(grid)
+
+	if n := countViolations(violations, "skill_shortage"); n != 0 {
+		t.Errorf("skill_shortage = %d, want 0", n)
+	}
+	if n := countViolations(violations, "position_shortage"); n != 0 {
+		t.Errorf("position_shortage = %d, want 0", n)
+	}
+}
+
+func TestOptimizer_StaffAvailability(t *testing.T) {
+	f := newOptimizerFixture(8)
+	// スタッフ0は火曜日不可・日勤のみ・週3日まで スタッフ1は4/10〜4/20勤務不可
+	limited := staffDomain.NewStaffAvailability(f.staffs[0].ID)
+	if err := limited.Update(
+		[]staffDomain.WeeklyAvailability{{Weekday: time.Tuesday, Unavailable: true}},
+		[]sharedDomain.ID{f.day.ID}, 3, "", "",
+	); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	away := staffDomain.NewStaffAvailability(f.staffs[1].ID)
+	if _, err := away.AddException(
+		time.Date(2025, 4, 10, 0, 0, 0, 0, time.UTC), time.Date(2025, 4, 20, 0, 0, 0, 0, time.UTC), false, "帰省", "",
+	); err != nil {
+		t.Fatalf("AddException() error = %v", err)
+	}
+
+	rules := []shiftDomain.ShiftRule{
+		{RuleType: "min_staff", IsActive: true, Config: `{"shift_type_id":"` + f.day.ID.String() + `","min_count":2}`},
+		{RuleType: "min_staff", IsActive: true, Config: `{"shift_type_id":"` + f.night.ID.String() + `","min_count":1}`},
+	}
+
+	problem := newOptimizerProblem(f.schedule, sharedDomain.DateRange{}, f.staffs, f.shiftTypes, nil, domain.OptimizeOptions{})
+	problem.setQualifications(&domain.StaffQualifications{
+		Availabilities: map[string]*staffDomain.StaffAvailability{
+			f.staffs[0].ID.String(): limited,
+			f.staffs[1].ID.String(): away,
+		},
+	})
+	problem.applyRules(rules)
+
+	solver := newAnnealingSolver(problem, seedFromID(f.schedule.ID))
+	grid, _ := solver.solve(context.Background(), 20000, time.Now().Add(10*time.Second))
+	_, violations := problem.evaluate(grid)
+
+	if n := countViolations(violations, "availability"); n != 0 {
+		t.Errorf("availability = %d, want 0", n)
+	}
+	if n := countViolations(violations, "coverage_shortage"); n != 0 {
+		t.Errorf("coverage_shortage = %d, want 0", n)
+	}
+	for d, date := range problem.days {
+		if v := grid[0][d]; v != offCell {
+			if date.Weekday() == time.Tuesday || problem.shifts[v].id != f.day.ID {
+				t.Errorf("%s スタッフ0に%sが割り当てられています", date.Format("2006-01-02"), problem.shifts[v].name)
+			}
+		}
+		if date.Day() >= 10 && date.Day() <= 20 && grid[1][d] != offCell {
+			t.Errorf("%s 勤務不可期間のスタッフ1に割り当てられています", date.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestOptimizer_KeepsConfirmedEntriesAndFixedRequests(t *testing.T) {
+	f := newOptimizerFixture(4)
+	confirmedDate := time.Date(2025, 4, 10, 0, 0, 0, 0, time.UTC)
+	confirmed := domain.ScheduleEntry{
+		ID:          sharedDomain.NewID(),
+		ScheduleID:  f.schedule.ID,
+		StaffID:     f.staffs[0].ID,
+		TargetDate:  confirmedDate,
+		ShiftTypeID: &f.off.ID,
+		IsConfirmed: true,
+	}
+	f.schedule.Entries = []domain.ScheduleEntry{confirmed}
+
+	fixedDate := time.Date(2025, 4, 5, 0, 0, 0, 0, time.UTC)
+	requests := []requestDomain.ShiftRequest{{
+		StaffID:     f.staffs[1].ID,
+		TargetDate:  fixedDate,
+		ShiftTypeID: &f.night.ID,
+		RequestType: requestDomain.RequestTypeFixed,
+		Priority:    requestDomain.PriorityRequired,
+	}}
+
+	problem, grid := f.solve(requests, nil)
+	entries := problem.toEntries(grid, time.Now())
+
+	if len(entries) != 4*30 {
+		t.Fatalf("entries = %d, want %d", len(entries), 4*30)
+	}
+	for _, e := range entries {
+		switch {
+		case e.StaffID == confirmed.StaffID && e.TargetDate.Equal(confirmedDate):
+			if e.ID != confirmed.ID {
+				t.Error("確定済みエントリが置き換えられています")
+			}
+		case e.StaffID == f.staffs[1].ID && e.TargetDate.Equal(fixedDate):
+			if e.ShiftTypeID == nil || *e.ShiftTypeID != f.night.ID {
+				t.Error("固定希望が反映されていません")
+			}
+		}
+	}
+}
+
+func TestOptimizer_OffCellsUseHolidayShift(t *testing.T) {
+	f := newOptimizerFixture(3)
+	problem, grid := f.solve(nil, nil)
+
+	for _, e := range problem.toEntries(grid, time.Now()) {
+		if e.ShiftTypeID == nil {
+			t.Fatal("休みセルに休日シフトが設定されていません")
+		}
+	}
+}
+
+func TestOptimizer_ApplyRules(t *testing.T) {
+	// cells 日ごとの割り当て 'D'は日勤 'N'は夜勤 それ以外は休み
+	tests := []struct {
+		name      string
+		rules     []shiftDomain.ShiftRule
+		cells     string
+		violation string
+		amount    int
+	}{
+		{
+			name:      "連続夜勤上限の上書き",
+			rules:     []shiftDomain.ShiftRule{{RuleType: "consecutive", IsActive: true, Config: `{"max_days":3,"night_only":true}`}},
+			cells:     "NNN",
+			violation: "consecutive_night",
+			amount:    0,
+		},
+		{
+			name:      "既定の連続夜勤上限",
+			cells:     "NNN",
+			violation: "consecutive_night",
+			amount:    1,
+		},
+		{
+			name: "同種ルールは厳しい方で違反",
+			rules: []shiftDomain.ShiftRule{
+				{RuleType: "consecutive", IsActive: true, Config: `{"max_days":7}`},
+				{RuleType: "consecutive", IsActive: true, Config: `{"max_days":5}`},
+			},
+			cells:     "DDDDDD",
+			violation: "consecutive_work",
+			amount:    1,
+		},
+		{
+			name:      "不正なJSONは無視して既定の夜勤回数上限を適用",
+			rules:     []shiftDomain.ShiftRule{{RuleType: "night_limit", IsActive: true, Config: `{`}},
+			cells:     "N-N-N-N-N-N-N-N-N-N",
+			violation: "monthly_night_limit",
+			amount:    2,
+		},
+		{
+			name:      "週間労働時間の上限",
+			rules:     []shiftDomain.ShiftRule{{RuleType: "weekly_hours", IsActive: true, Config: `{"max_hours":24}`}},
+			cells:     "-------DDDD",
+			violation: "weekly_hours",
+			amount:    8,
+		},
+		{
+			name:      "月間労働時間の上限",
+			rules:     []shiftDomain.ShiftRule{{RuleType: "monthly_hours", IsActive: true, Config: `{"max_hours":40}`}},
+			cells:     "D-D-D-D-D-D-D",
+			violation: "monthly_hours",
+			amount:    16,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newOptimizerFixture(2)
+			problem := newOptimizerProblem(f.schedule, sharedDomain.DateRange{}, f.staffs, f.shiftTypes, nil, domain.OptimizeOptions{})
+			problem.applyRules(tt.rules)
+
+			grid := emptyGrid(problem)
+			for d, c := range tt.cells {
+				switch c {
+				case 'D':
+					grid[0][d] = shiftIndexOf(problem, f.day.ID)
+				case 'N':
+					grid[0][d] = shiftIndexOf(problem, f.night.ID)
+				}
+			}
+
+			amount := 0
+			for _, v := range problem.rowViolations(grid[0], 0) {
+				if v.ConstraintType == tt.violation {
+					amount += max(v.Amount, 1)
+				}
+			}
+			if amount != tt.amount {
+				t.Errorf("%s = %d, want %d", tt.violation, amount, tt.amount)
+			}
+		})
+	}
+}
+
+func TestOptimizer_FilteredMinStaffCountsMatchingStaffOnly(t *testing.T) {
+	f := newOptimizerFixture(6)
+	skillID := sharedDomain.NewID()
+	for i := 0; i < 2; i++ {
+		f.staffs[i].Skills = []staffDomain.StaffSkill{{StaffID: f.staffs[i].ID, SkillID: skillID, Level: 1}}
+	}
+	rules := []shiftDomain.ShiftRule{
+		{RuleType: "min_staff", IsActive: true, Config: `{"shift_type_id":"` + f.day.ID.String() + `","min_count":1}`},
+		{RuleType: "min_staff", IsActive: true, Config: `{"shift_type_id":"` + f.day.ID.String() + `","skill_id":"` + skillID.String() + `","min_count":1}`},
+	}
+
+	problem := newOptimizerProblem(f.schedule, sharedDomain.DateRange{}, f.staffs, f.shiftTypes, nil, domain.OptimizeOptions{})
+	problem.applyRules(rules)
+
+	// スキル保有者のいない日勤は総人数を満たしても不足
+	probe := emptyGrid(problem)
+	for s := 2; s < 6; s++ {
+		probe[s][0] = shiftIndexOf(problem, f.day.ID)
+	}
+	if n := countViolations(problem.columnViolations(probe, 0), "coverage_shortage"); n != 1 {
+		t.Errorf("coverage_shortage = %d, want 1", n)
+	}
+
+	grid := solveProblem(problem, f.schedule.ID)
+	_, violations := problem.evaluate(grid)
+	if n := countViolations(violations, "coverage_shortage"); n != 0 {
+		t.Errorf("coverage_shortage after solve = %d, want 0", n)
+	}
+}
+
+func TestOptimizer_WorkingHoursLimit(t *testing.T) {
+	f := newOptimizerFixture(8)
+	rules := []shiftDomain.ShiftRule{
+		{RuleType: "min_staff", IsActive: true, Config: `{"shift_type_id":"` + f.day.ID.String() + `","min_count":2}`},
+		{RuleType: "weekly_hours", IsActive: true, Config: `{"max_hours":32}`},
+	}
+
+	problem := newOptimizerProblem(f.schedule, sharedDomain.DateRange{}, f.staffs, f.shiftTypes, nil, domain.OptimizeOptions{})
+	problem.applyRules(rules)
+	grid := solveProblem(problem, f.schedule.ID)
+	_, violations := problem.evaluate(grid)
+
+	if n := countViolations(violations, "weekly_hours"); n != 0 {
+		t.Errorf("weekly_hours = %d, want 0", n)
+	}
+	if n := countViolations(violations, "coverage_shortage"); n != 0 {
+		t.Errorf("coverage_shortage = %d, want 0", n)
+	}
+}
+
+func TestOptimizer_Deterministic(t *testing.T) {
+	f := newOptimizerFixture(5)
+	_, first := f.solve(nil, nil)
+	_, second := f.solve(nil, nil)
+
+	for s := range first {
+		for d := range first[s] {
+			if first[s][d] != second[s][d] {
+				t.Fatalf("同一勤務表で結果が異なります staff=%d day=%d", s, d)
+			}
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// Package infrastructure 勤務表インフラストラクチャ層
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"shiftmaster/internal/modules/schedule/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// OvertimeAgreementModel 36協定DBモデル
+type OvertimeAgreementModel struct {
+	bun.BaseModel `bun:"table:overtime_agreements"`
+
+	ID                            uuid.UUID `bun:"id,pk,type:uuid"`
+	OrganizationID                uuid.UUID `bun:"organization_id,type:uuid,notnull"`
+	MonthlyLimitMinutes           int       `bun:"monthly_limit_minutes,notnull"`
+	YearlyLimitMinutes            int       `bun:"yearly_limit_minutes,notnull"`
+	MultiMonthAverageLimitMinutes int       `bun:"multi_month_average_limit_minutes,notnull"`
+	WarningPercent                int       `bun:"warning_percent,notnull"`
+	StartMonth                    int       `bun:"start_month,notnull"`
+	CreatedAt                     time.Time `bun:"created_at,notnull"`
+	UpdatedAt                     time.Time `bun:"updated_at,notnull"`
+}
+
+// ToDomain DBモデルからドメインエンティティへ変換
+func (m *OvertimeAgreementModel) ToDomain() *domain.OvertimeAgreement {
+	return &domain.OvertimeAgreement{
+		ID:                            m.ID,
+		OrganizationID:                m.OrganizationID,
+		MonthlyLimitMinutes:           m.MonthlyLimitMinutes,
+		YearlyLimitMinutes:            m.YearlyLimitMinutes,
+		MultiMonthAverageLimitMinutes: m.MultiMonthAverageLimitMinutes,
+		WarningPercent:                m.WarningPercent,
+		StartMonth:                    m.StartMonth,
+		CreatedAt:                     m.CreatedAt,
+		UpdatedAt:                     m.UpdatedAt,
+	}
+}
+
+// PostgresOvertimeAgreementRepository PostgreSQL 36協定リポジトリ
+type PostgresOvertimeAgreementRepository struct {
+	db *bun.DB
+}
+
+// NewPostgresOvertimeAgreementRepository リポジトリ生成
+func NewPostgresOvertimeAgreementRepository(db *bun.DB) *PostgresOvertimeAgreementRepository {
+	return &PostgresOvertimeAgreementRepository{db: db}
+}
+
+// FindByOrganizationID 組織IDで検索
+func (r *PostgresOvertimeAgreementRepository) FindByOrganizationID(ctx context.Context, organizationID sharedDomain.ID) (*domain.OvertimeAgreement, error) {
+	model := &OvertimeAgreementModel{}
+	err := r.db.NewSelect().Model(model).Where("organization_id = ?", organizationID).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.ToDomain(), nil
+}
+
+// Save 保存 組織ごとに1件
+func (r *PostgresOvertimeAgreementRepository) Save(ctx context.Context, agreement *domain.OvertimeAgreement) error {
+	model := &OvertimeAgreementModel{
+		ID:                            agreement.ID,
+		OrganizationID:                agreement.OrganizationID,
+		MonthlyLimitMinutes:           agreement.MonthlyLimitMinutes,
+		YearlyLimitMinutes:            agreement.YearlyLimitMinutes,
+		MultiMonthAverageLimitMinutes: agreement.MultiMonthAverageLimitMinutes,
+		WarningPercent:                agreement.WarningPercent,
+		StartMonth:                    agreement.StartMonth,
+		CreatedAt:                     agreement.CreatedAt,
+		UpdatedAt:                     agreement.UpdatedAt,
+	}
+
+	_, err := r.db.NewInsert().
+		Model(model).
+		On("CONFLICT (organization_id) DO UPDATE").
+		Set("monthly_limit_minutes = EXCLUDED.monthly_limit_minutes").
+		Set("yearly_limit_minutes = EXCLUDED.yearly_limit_minutes").
+		Set("multi_month_average_limit_minutes = EXCLUDED.multi_month_average_limit_minutes").
+		Set("warning_percent = EXCLUDED.warning_percent").
+		Set("start_month = EXCLUDED.start_month").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+
+	return err
+}
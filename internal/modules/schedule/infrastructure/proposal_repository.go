@@ -0,0 +1,276 @@
+// Package infrastructure 勤務表インフラストラクチャ層
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"shiftmaster/internal/modules/schedule/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+	"shiftmaster/internal/shared/infrastructure"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// ScheduleProposalModel 勤務表自動作成案DBモデル
+type ScheduleProposalModel struct {
+	bun.BaseModel `bun:"table:schedule_proposals"`
+
+	ID         uuid.UUID               `bun:"id,pk,type:uuid"`
+	ScheduleID uuid.UUID               `bun:"schedule_id,type:uuid,notnull"`
+	Status     string                  `bun:"status,notnull"`
+	Generator  string                  `bun:"generator,notnull"`
+	Score      float64                 `bun:"score,notnull"`
+	Entries    []ProposalEntryJSON     `bun:"entries,type:jsonb,notnull"`
+	Violations []ProposalViolationJSON `bun:"violations,type:jsonb,notnull"`
+	DurationMs int64                   `bun:"duration_ms,notnull"`
+	AppliedAt  *time.Time              `bun:"applied_at"`
+	CreatedAt  time.Time               `bun:"created_at,notnull"`
+	UpdatedAt  time.Time               `bun:"updated_at,notnull"`
+}
+
+// ProposalEntryJSON 作成案エントリJSON
+type ProposalEntryJSON struct {
+	ID          string  `json:"id"`
+	StaffID     string  `json:"staff_id"`
+	TargetDate  string  `json:"target_date"`
+	ShiftTypeID *string `json:"shift_type_id,omitempty"`
+	IsConfirmed bool    `json:"is_confirmed,omitempty"`
+	Note        string  `json:"note,omitempty"`
+}
+
+// ProposalViolationJSON 作成案制約違反JSON
+type ProposalViolationJSON struct {
+	ConstraintType string  `json:"constraint_type"`
+	Message        string  `json:"message"`
+	StaffID        *string `json:"staff_id,omitempty"`
+	Date           *string `json:"date,omitempty"`
+	ShiftTypeID    *string `json:"shift_type_id,omitempty"`
+	RuleName       string  `json:"rule_name,omitempty"`
+	Severity       string  `json:"severity"`
+}
+
+// ToDomain DBモデルからドメインエンティティへ変換
+func (m *ScheduleProposalModel) ToDomain() *domain.ScheduleProposal {
+	entries := make([]domain.ScheduleEntry, 0, len(m.Entries))
+	for _, e := range m.Entries {
+		id, err := sharedDomain.ParseID(e.ID)
+		if err != nil {
+			id = sharedDomain.NewID()
+		}
+		staffID, err := sharedDomain.ParseID(e.StaffID)
+		if err != nil {
+			continue
+		}
+		targetDate, err := time.Parse("2006-01-02", e.TargetDate)
+		if err != nil {
+			continue
+		}
+		var shiftTypeID *sharedDomain.ID
+		if e.ShiftTypeID != nil {
+			if parsed, err := sharedDomain.ParseID(*e.ShiftTypeID); err == nil {
+				shiftTypeID = &parsed
+			}
+		}
+		entries = append(entries, domain.ScheduleEntry{
+			ID:          id,
+			ScheduleID:  m.ScheduleID,
+			StaffID:     staffID,
+			TargetDate:  targetDate,
+			ShiftTypeID: shiftTypeID,
+			IsConfirmed: e.IsConfirmed,
+			Note:        e.Note,
+			CreatedAt:   m.CreatedAt,
+			UpdatedAt:   m.UpdatedAt,
+		})
+	}
+
+	violations := make([]domain.ConstraintViolation, 0, len(m.Violations))
+	for _, v := range m.Violations {
+		violation := domain.ConstraintViolation{
+			ConstraintType: v.ConstraintType,
+			Message:        v.Message,
+			RuleName:       v.RuleName,
+			Severity:       v.Severity,
+		}
+		if v.StaffID != nil {
+			if staffID, err := sharedDomain.ParseID(*v.StaffID); err == nil {
+				violation.StaffID = &staffID
+			}
+		}
+		if v.Date != nil {
+			if date, err := time.Parse("2006-01-02", *v.Date); err == nil {
+				violation.Date = &date
+			}
+		}
+		if v.ShiftTypeID != nil {
+			if shiftTypeID, err := sharedDomain.ParseID(*v.ShiftTypeID); err == nil {
+				violation.ShiftTypeID = &shiftTypeID
+			}
+		}
+		violations = append(violations, violation)
+	}
+
+	return &domain.ScheduleProposal{
+		ID:         m.ID,
+		ScheduleID: m.ScheduleID,
+		Status:     domain.ProposalStatus(m.Status),
+		Generator:  domain.ProposalGenerator(m.Generator),
+		Score:      m.Score,
+		Entries:    entries,
+		Violations: violations,
+		Duration:   time.Duration(m.DurationMs) * time.Millisecond,
+		AppliedAt:  m.AppliedAt,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+	}
+}
+
+// FromDomain ドメインエンティティからDBモデルへ変換
+func (m *ScheduleProposalModel) FromDomain(p *domain.ScheduleProposal) {
+	m.ID = p.ID
+	m.ScheduleID = p.ScheduleID
+	m.Status = p.Status.String()
+	m.Generator = p.Generator.String()
+	m.Score = p.Score
+	m.DurationMs = p.Duration.Milliseconds()
+	m.AppliedAt = p.AppliedAt
+	m.CreatedAt = p.CreatedAt
+	m.UpdatedAt = p.UpdatedAt
+
+	m.Entries = make([]ProposalEntryJSON, len(p.Entries))
+	for i, e := range p.Entries {
+		entry := ProposalEntryJSON{
+			ID:          e.ID.String(),
+			StaffID:     e.StaffID.String(),
+			TargetDate:  e.TargetDate.Format("2006-01-02"),
+			IsConfirmed: e.IsConfirmed,
+			Note:        e.Note,
+		}
+		if e.ShiftTypeID != nil {
+			id := e.ShiftTypeID.String()
+			entry.ShiftTypeID = &id
+		}
+		m.Entries[i] = entry
+	}
+
+	m.Violations = make([]ProposalViolationJSON, len(p.Violations))
+	for i, v := range p.Violations {
+		violation := ProposalViolationJSON{
+			ConstraintType: v.ConstraintType,
+			Message:        v.Message,
+			RuleName:       v.RuleName,
+			Severity:       v.Severity,
+		}
+		if v.StaffID != nil {
+			id := v.StaffID.String()
+			violation.StaffID = &id
+		}
+		if v.Date != nil {
+			date := v.Date.Format("2006-01-02")
+			violation.Date = &date
+		}
+		if v.ShiftTypeID != nil {
+			id := v.ShiftTypeID.String()
+			violation.ShiftTypeID = &id
+		}
+		m.Violations[i] = violation
+	}
+}
+
+// PostgresScheduleProposalRepository PostgreSQL勤務表自動作成案リポジトリ
+type PostgresScheduleProposalRepository struct {
+	db *bun.DB
+}
+
+// NewPostgresScheduleProposalRepository リポジトリ生成
+func NewPostgresScheduleProposalRepository(db *bun.DB) *PostgresScheduleProposalRepository {
+	return &PostgresScheduleProposalRepository{db: db}
+}
+
+// FindByID IDで検索
+func (r *PostgresScheduleProposalRepository) FindByID(ctx context.Context, id sharedDomain.ID) (*domain.ScheduleProposal, error) {
+	model := &ScheduleProposalModel{}
+	err := r.db.NewSelect().Model(model).Where("id = ?", id).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.ToDomain(), nil
+}
+
+// FindByScheduleID 勤務表IDで検索 新しい順
+func (r *PostgresScheduleProposalRepository) FindByScheduleID(ctx context.Context, scheduleID sharedDomain.ID) ([]domain.ScheduleProposal, error) {
+	var models []ScheduleProposalModel
+	err := r.db.NewSelect().
+		Model(&models).
+		Where("schedule_id = ?", scheduleID).
+		Order("created_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	proposals := make([]domain.ScheduleProposal, len(models))
+	for i, m := range models {
+		proposals[i] = *m.ToDomain()
+	}
+
+	return proposals, nil
+}
+
+// Save 保存
+func (r *PostgresScheduleProposalRepository) Save(ctx context.Context, proposal *domain.ScheduleProposal) error {
+	model := &ScheduleProposalModel{}
+	model.FromDomain(proposal)
+
+	_, err := r.db.NewInsert().
+		Model(model).
+		On("CONFLICT (id) DO UPDATE").
+		Set("status = EXCLUDED.status").
+		Set("applied_at = EXCLUDED.applied_at").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+
+	return err
+}
+
+// Apply 適用した作成案の保存とエントリ更新を1トランザクションで行う
+// 承認待ちの行だけを更新し、同時に適用・破棄された場合は競合エラーとする
+func (r *PostgresScheduleProposalRepository) Apply(ctx context.Context, proposal *domain.ScheduleProposal, originals, entries []domain.ScheduleEntry) error {
+	model := &ScheduleProposalModel{}
+	model.FromDomain(proposal)
+
+	return infrastructure.RunInTransaction(ctx, r.db, func(ctx context.Context, tx bun.Tx) error {
+		result, err := tx.NewUpdate().
+			Model(model).
+			Column("status", "applied_at", "updated_at").
+			WherePK().
+			Where("status = ?", domain.ProposalStatusPending.String()).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return sharedDomain.NewDomainError(sharedDomain.ErrCodeConflict, "この自動作成案は既に適用または破棄されています")
+		}
+
+		if err := lockUnchangedEntries(ctx, tx, originals); err != nil {
+			return err
+		}
+		return upsertEntries(ctx, tx, entries)
+	})
+}
+
+// Delete 削除
+func (r *PostgresScheduleProposalRepository) Delete(ctx context.Context, id sharedDomain.ID) error {
+	_, err := r.db.NewDelete().Model((*ScheduleProposalModel)(nil)).Where("id = ?", id).Exec(ctx)
+	return err
+}
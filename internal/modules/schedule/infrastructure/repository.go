@@ -311,6 +311,11 @@ func (r *PostgresScheduleEntryRepository) Save(ctx context.Context, entry *domai
 
 // SaveBatch 一括保存
 func (r *PostgresScheduleEntryRepository) SaveBatch(ctx context.Context, entries []domain.ScheduleEntry) error {
+	return upsertEntries(ctx, r.db, entries)
+}
+
+// upsertEntries エントリの一括登録・更新 トランザクション内からも利用する
+func upsertEntries(ctx context.Context, db bun.IDB, entries []domain.ScheduleEntry) error {
 	if len(entries) == 0 {
 		return nil
 	}
@@ -336,7 +341,7 @@ func (r *PostgresScheduleEntryRepository) SaveBatch(ctx context.Context, entries
 		}
 	}
 
-	_, err := r.db.NewInsert().
+	_, err := db.NewInsert().
 		Model(&models).
 		On("CONFLICT (id) DO UPDATE").
 		Set("shift_type_id = EXCLUDED.shift_type_id").
@@ -348,6 +353,48 @@ func (r *PostgresScheduleEntryRepository) SaveBatch(ctx context.Context, entries
 	return err
 }
 
+// lockUnchangedEntries エントリを行ロックし、読み込んだ時点からシフトと更新日時が変わっていないか確認する
+// 削除・変更されていた場合は競合エラー トランザクション内から利用する
+func lockUnchangedEntries(ctx context.Context, tx bun.Tx, originals []domain.ScheduleEntry) error {
+	if len(originals) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(originals))
+	for i := range originals {
+		ids[i] = originals[i].ID
+	}
+	var models []ScheduleEntryModel
+	err := tx.NewSelect().
+		Model(&models).
+		Where("id IN (?)", bun.In(ids)).
+		For("UPDATE").
+		Scan(ctx)
+	if err != nil {
+		return err
+	}
+
+	current := make(map[uuid.UUID]*ScheduleEntryModel, len(models))
+	for i := range models {
+		current[models[i].ID] = &models[i]
+	}
+	for i := range originals {
+		m, ok := current[originals[i].ID]
+		if !ok || !sameUUID(m.ShiftTypeID, originals[i].ShiftTypeID) || m.IsConfirmed != originals[i].IsConfirmed || !m.UpdatedAt.Equal(originals[i].UpdatedAt) {
+			return sharedDomain.NewDomainError(sharedDomain.ErrCodeConflict, "処理中に勤務表が変更されました。もう一度やり直してください")
+		}
+	}
+	return nil
+}
+
+// sameUUID シフト種別IDの同一判定
+func sameUUID(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
 // Delete 削除
 func (r *PostgresScheduleEntryRepository) Delete(ctx context.Context, id sharedDomain.ID) error {
 	_, err := r.db.NewDelete().Model((*ScheduleEntryModel)(nil)).Where("id = ?", id).Exec(ctx)
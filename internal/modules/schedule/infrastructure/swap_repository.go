@@ -0,0 +1,198 @@
+// Package infrastructure 勤務表インフラストラクチャ層
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"shiftmaster/internal/modules/schedule/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+	"shiftmaster/internal/shared/infrastructure"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// ShiftSwapModel 勤務交換申請DBモデル
+type ShiftSwapModel struct {
+	bun.BaseModel `bun:"table:shift_swaps"`
+
+	ID                     uuid.UUID       `bun:"id,pk,type:uuid"`
+	OrganizationID         uuid.UUID       `bun:"organization_id,type:uuid,notnull"`
+	RequesterStaffID       uuid.UUID       `bun:"requester_staff_id,type:uuid,notnull"`
+	RequesterEntryID       uuid.UUID       `bun:"requester_entry_id,type:uuid,notnull"`
+	RequesterDate          time.Time       `bun:"requester_date,type:date,notnull"`
+	RequesterShiftTypeID   *uuid.UUID      `bun:"requester_shift_type_id,type:uuid"`
+	CounterpartStaffID     uuid.UUID       `bun:"counterpart_staff_id,type:uuid,notnull"`
+	CounterpartEntryID     uuid.UUID       `bun:"counterpart_entry_id,type:uuid,notnull"`
+	CounterpartDate        time.Time       `bun:"counterpart_date,type:date,notnull"`
+	CounterpartShiftTypeID *uuid.UUID      `bun:"counterpart_shift_type_id,type:uuid"`
+	Status                 string          `bun:"status,notnull"`
+	Reason                 string          `bun:"reason,notnull"`
+	History                []SwapEventJSON `bun:"history,type:jsonb,notnull"`
+	CreatedAt              time.Time       `bun:"created_at,notnull"`
+	UpdatedAt              time.Time       `bun:"updated_at,notnull"`
+}
+
+// SwapEventJSON 勤務交換操作履歴JSON
+type SwapEventJSON struct {
+	Status    string    `json:"status"`
+	UserID    string    `json:"user_id"`
+	UserEmail string    `json:"user_email"`
+	Comment   string    `json:"comment,omitempty"`
+	At        time.Time `json:"at"`
+}
+
+// ToDomain DBモデルからドメインエンティティへ変換
+func (m *ShiftSwapModel) ToDomain() *domain.ShiftSwap {
+	history := make([]domain.SwapEvent, 0, len(m.History))
+	for _, e := range m.History {
+		// 削除済みユーザー等で不正な場合はゼロ値 メールアドレスで表示する
+		userID, _ := sharedDomain.ParseID(e.UserID)
+		history = append(history, domain.SwapEvent{
+			Status:  domain.SwapStatus(e.Status),
+			Actor:   domain.SwapActor{UserID: userID, Email: e.UserEmail},
+			Comment: e.Comment,
+			At:      e.At,
+		})
+	}
+
+	return &domain.ShiftSwap{
+		ID:                     m.ID,
+		OrganizationID:         m.OrganizationID,
+		RequesterStaffID:       m.RequesterStaffID,
+		RequesterEntryID:       m.RequesterEntryID,
+		RequesterDate:          m.RequesterDate,
+		RequesterShiftTypeID:   m.RequesterShiftTypeID,
+		CounterpartStaffID:     m.CounterpartStaffID,
+		CounterpartEntryID:     m.CounterpartEntryID,
+		CounterpartDate:        m.CounterpartDate,
+		CounterpartShiftTypeID: m.CounterpartShiftTypeID,
+		Status:                 domain.SwapStatus(m.Status),
+		Reason:                 m.Reason,
+		History:                history,
+		CreatedAt:              m.CreatedAt,
+		UpdatedAt:              m.UpdatedAt,
+	}
+}
+
+// FromDomain ドメインエンティティからDBモデルへ変換
+func (m *ShiftSwapModel) FromDomain(s *domain.ShiftSwap) {
+	m.ID = s.ID
+	m.OrganizationID = s.OrganizationID
+	m.RequesterStaffID = s.RequesterStaffID
+	m.RequesterEntryID = s.RequesterEntryID
+	m.RequesterDate = s.RequesterDate
+	m.RequesterShiftTypeID = s.RequesterShiftTypeID
+	m.CounterpartStaffID = s.CounterpartStaffID
+	m.CounterpartEntryID = s.CounterpartEntryID
+	m.CounterpartDate = s.CounterpartDate
+	m.CounterpartShiftTypeID = s.CounterpartShiftTypeID
+	m.Status = s.Status.String()
+	m.Reason = s.Reason
+	m.CreatedAt = s.CreatedAt
+	m.UpdatedAt = s.UpdatedAt
+
+	m.History = make([]SwapEventJSON, len(s.History))
+	for i, e := range s.History {
+		m.History[i] = SwapEventJSON{
+			Status:    e.Status.String(),
+			UserID:    e.Actor.UserID.String(),
+			UserEmail: e.Actor.Email,
+			Comment:   e.Comment,
+			At:        e.At,
+		}
+	}
+}
+
+// PostgresShiftSwapRepository PostgreSQL勤務交換申請リポジトリ
+type PostgresShiftSwapRepository struct {
+	db *bun.DB
+}
+
+// NewPostgresShiftSwapRepository リポジトリ生成
+func NewPostgresShiftSwapRepository(db *bun.DB) *PostgresShiftSwapRepository {
+	return &PostgresShiftSwapRepository{db: db}
+}
+
+// FindByID IDで検索
+func (r *PostgresShiftSwapRepository) FindByID(ctx context.Context, id sharedDomain.ID) (*domain.ShiftSwap, error) {
+	model := &ShiftSwapModel{}
+	err := r.db.NewSelect().Model(model).Where("id = ?", id).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.ToDomain(), nil
+}
+
+// FindByOrganizationID 組織IDで検索 新しい順
+func (r *PostgresShiftSwapRepository) FindByOrganizationID(ctx context.Context, organizationID sharedDomain.ID, status domain.SwapStatus) ([]domain.ShiftSwap, error) {
+	var models []ShiftSwapModel
+	query := r.db.NewSelect().
+		Model(&models).
+		Where("organization_id = ?", organizationID).
+		Order("created_at DESC")
+	if status != "" {
+		query = query.Where("status = ?", status.String())
+	}
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	swaps := make([]domain.ShiftSwap, len(models))
+	for i := range models {
+		swaps[i] = *models[i].ToDomain()
+	}
+	return swaps, nil
+}
+
+// Save 保存
+func (r *PostgresShiftSwapRepository) Save(ctx context.Context, swap *domain.ShiftSwap) error {
+	model := &ShiftSwapModel{}
+	model.FromDomain(swap)
+
+	_, err := r.db.NewInsert().
+		Model(model).
+		On("CONFLICT (id) DO UPDATE").
+		Set("status = EXCLUDED.status").
+		Set("history = EXCLUDED.history").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+
+	return err
+}
+
+// Apply 承認した申請の保存とエントリ更新を1トランザクションで行う
+// 承認待ちの行だけを更新し、同時に承認・却下された場合は競合エラーとする
+// 対象エントリは行ロックの上で交換内容を求めた時点から変更されていないことを確認する
+func (r *PostgresShiftSwapRepository) Apply(ctx context.Context, swap *domain.ShiftSwap, originals, entries []domain.ScheduleEntry) error {
+	model := &ShiftSwapModel{}
+	model.FromDomain(swap)
+
+	return infrastructure.RunInTransaction(ctx, r.db, func(ctx context.Context, tx bun.Tx) error {
+		result, err := tx.NewUpdate().
+			Model(model).
+			Column("status", "history", "updated_at").
+			WherePK().
+			Where("status = ?", domain.SwapStatusAccepted.String()).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return sharedDomain.NewDomainError(sharedDomain.ErrCodeConflict, "この勤務交換は既に処理されています")
+		}
+
+		if err := lockUnchangedEntries(ctx, tx, originals); err != nil {
+			return err
+		}
+		return upsertEntries(ctx, tx, entries)
+	})
+}
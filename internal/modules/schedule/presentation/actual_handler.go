@@ -0,0 +1,264 @@
+// Package presentation 勤務表プレゼンテーション層
+package presentation
+
+import (
+	"encoding/json"
+	"errors"
+	"html"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"shiftmaster/internal/modules/schedule/application"
+	sharedDomain "shiftmaster/internal/shared/domain"
+	"shiftmaster/internal/web"
+)
+
+// ActualRecordHandler 勤務実績HTTPハンドラー
+type ActualRecordHandler struct {
+	useCase     *application.ActualRecordUseCase
+	staffFinder StaffFinder
+	templates   *web.TemplateEngine
+	logger      *slog.Logger
+}
+
+// NewActualRecordHandler ハンドラー生成
+func NewActualRecordHandler(
+	useCase *application.ActualRecordUseCase,
+	staffFinder StaffFinder,
+	templates *web.TemplateEngine,
+	logger *slog.Logger,
+) *ActualRecordHandler {
+	return &ActualRecordHandler{
+		useCase:     useCase,
+		staffFinder: staffFinder,
+		templates:   templates,
+		logger:      logger,
+	}
+}
+
+// Timesheet スタッフ別勤務実績表ページ スタッフ未指定時は先頭のスタッフを表示
+func (h *ActualRecordHandler) Timesheet(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	staffID := r.URL.Query().Get("staff_id")
+
+	var staffs []StaffInfo
+	claims := web.GetClaimsFromContext(r.Context())
+	if claims != nil && claims.OrganizationID != nil && h.staffFinder != nil {
+		found, err := h.staffFinder.FindActiveByOrganizationID(r.Context(), *claims.OrganizationID)
+		if err != nil {
+			h.logger.Warn("スタッフ一覧取得失敗", "error", err)
+		}
+		staffs = found
+	}
+	if staffID == "" && len(staffs) > 0 {
+		staffID = staffs[0].ID
+	}
+
+	data := map[string]any{
+		"Title":      "勤務実績",
+		"ScheduleID": id,
+		"Staffs":     staffs,
+		"StaffID":    staffID,
+	}
+	if staffID != "" {
+		timesheet, err := h.useCase.GetTimesheet(r.Context(), id, staffID)
+		if err != nil {
+			h.handleError(w, err)
+			return
+		}
+		data["Title"] = timesheet.TargetPeriodLabel + " 勤務実績"
+		data["Timesheet"] = timesheet
+	}
+
+	if err := h.templates.Render(w, "pages/schedules/timesheet.html", data); err != nil {
+		h.logger.Error("テンプレートレンダリング失敗", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// Record 勤務実績登録
+func (h *ActualRecordHandler) Record(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	breakMinutes, _ := strconv.Atoi(r.FormValue("actual_break_minutes"))
+	input := &application.RecordActualInput{
+		ScheduleID:         id,
+		EntryID:            r.PathValue("entry_id"),
+		ActualStartTime:    r.FormValue("actual_start_time"),
+		ActualEndTime:      r.FormValue("actual_end_time"),
+		ActualBreakMinutes: breakMinutes,
+		Note:               r.FormValue("note"),
+	}
+	h.setActor(r, &input.UserID, &input.UserEmail, &input.IsManager)
+
+	if _, err := h.useCase.Record(r.Context(), input); err != nil {
+		h.handleFormError(w, r, err)
+		return
+	}
+
+	h.redirectToTimesheet(w, r, id, r.FormValue("staff_id"))
+}
+
+// Delete 勤務実績削除
+func (h *ActualRecordHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	input := &application.DeleteActualInput{ScheduleID: id, EntryID: r.PathValue("entry_id")}
+	h.setActor(r, &input.UserID, &input.UserEmail, &input.IsManager)
+
+	if err := h.useCase.Delete(r.Context(), input); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	h.redirectToTimesheet(w, r, id, r.URL.Query().Get("staff_id"))
+}
+
+// TimesheetJSON スタッフ別勤務実績表JSON
+func (h *ActualRecordHandler) TimesheetJSON(w http.ResponseWriter, r *http.Request) {
+	timesheet, err := h.useCase.GetTimesheet(r.Context(), r.PathValue("id"), r.PathValue("staff_id"))
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, timesheet)
+}
+
+// RecordJSON 勤務実績登録JSON
+func (h *ActualRecordHandler) RecordJSON(w http.ResponseWriter, r *http.Request) {
+	var input application.RecordActualInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "リクエストボディが不正です"})
+		return
+	}
+	input.ScheduleID = r.PathValue("id")
+	input.EntryID = r.PathValue("entry_id")
+	h.setActor(r, &input.UserID, &input.UserEmail, &input.IsManager)
+
+	record, err := h.useCase.Record(r.Context(), &input)
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, record)
+}
+
+// DeleteJSON 勤務実績削除JSON
+func (h *ActualRecordHandler) DeleteJSON(w http.ResponseWriter, r *http.Request) {
+	input := &application.DeleteActualInput{ScheduleID: r.PathValue("id"), EntryID: r.PathValue("entry_id")}
+	h.setActor(r, &input.UserID, &input.UserEmail, &input.IsManager)
+
+	if err := h.useCase.Delete(r.Context(), input); err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// setActor 操作ユーザーを入力に設定
+func (h *ActualRecordHandler) setActor(r *http.Request, userID, email *string, isManager *bool) {
+	if claims := web.GetClaimsFromContext(r.Context()); claims != nil {
+		*userID = claims.UserID.String()
+		*email = claims.Email
+		*isManager = claims.IsManager()
+	}
+}
+
+// redirectToTimesheet 勤務実績表ページへリダイレクト HTMX対応
+func (h *ActualRecordHandler) redirectToTimesheet(w http.ResponseWriter, r *http.Request, scheduleID, staffID string) {
+	redirectTo := "/schedules/" + scheduleID + "/timesheet"
+	if staffID != "" {
+		redirectTo += "?staff_id=" + url.QueryEscape(staffID)
+	}
+
+	if isHTMXRequest(r) {
+		w.Header().Set("HX-Redirect", redirectTo)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	http.Redirect(w, r, redirectTo, http.StatusSeeOther)
+}
+
+// handleFormError フォーム送信エラーハンドリング 検証エラーはフォーム上に表示
+func (h *ActualRecordHandler) handleFormError(w http.ResponseWriter, r *http.Request, err error) {
+	var domainErr *sharedDomain.DomainError
+	if isHTMXRequest(r) && errors.As(err, &domainErr) && domainErr.Code == sharedDomain.ErrCodeValidation {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`<p class="text-sm text-red-400">` + html.EscapeString(domainErr.Message) + `</p>`))
+		return
+	}
+
+	h.handleError(w, err)
+}
+
+// handleError エラーハンドリング
+func (h *ActualRecordHandler) handleError(w http.ResponseWriter, err error) {
+	if errors.Is(err, sharedDomain.ErrNotFound) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+
+	var domainErr *sharedDomain.DomainError
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code {
+		case sharedDomain.ErrCodeNotFound:
+			http.Error(w, domainErr.Message, http.StatusNotFound)
+			return
+		case sharedDomain.ErrCodeValidation:
+			http.Error(w, domainErr.Message, http.StatusBadRequest)
+			return
+		case sharedDomain.ErrCodeForbidden:
+			http.Error(w, domainErr.Message, http.StatusForbidden)
+			return
+		}
+	}
+
+	h.logger.Error("ハンドラーエラー", "error", err)
+	http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+}
+
+// handleJSONError JSONエラーハンドリング
+func (h *ActualRecordHandler) handleJSONError(w http.ResponseWriter, err error) {
+	if errors.Is(err, sharedDomain.ErrNotFound) {
+		h.writeJSON(w, http.StatusNotFound, map[string]string{"error": "見つかりません"})
+		return
+	}
+
+	var domainErr *sharedDomain.DomainError
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code {
+		case sharedDomain.ErrCodeNotFound:
+			h.writeJSON(w, http.StatusNotFound, map[string]string{"error": domainErr.Message})
+			return
+		case sharedDomain.ErrCodeValidation:
+			h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": domainErr.Message})
+			return
+		case sharedDomain.ErrCodeForbidden:
+			h.writeJSON(w, http.StatusForbidden, map[string]string{"error": domainErr.Message})
+			return
+		}
+	}
+
+	h.logger.Error("ハンドラーエラー", "error", err)
+	h.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "内部エラーが発生しました"})
+}
+
+// writeJSON JSONレスポンス書き込み
+func (h *ActualRecordHandler) writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		h.logger.Error("JSONエンコード失敗", "error", err)
+	}
+}
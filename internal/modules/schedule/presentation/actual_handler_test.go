@@ -0,0 +1,129 @@
+package presentation
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	authDomain "shiftmaster/internal/modules/auth/domain"
+	"shiftmaster/internal/modules/schedule/application"
+	"shiftmaster/internal/modules/schedule/domain"
+	staffDomain "shiftmaster/internal/modules/staff/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+	"shiftmaster/internal/web"
+)
+
+// 権限の検証で使うメソッドのみ実装したモック 他のメソッドは呼ばれない
+
+type stubScheduleRepository struct {
+	domain.ScheduleRepository
+	schedule *domain.Schedule
+}
+
+func (m *stubScheduleRepository) FindByID(_ context.Context, id sharedDomain.ID) (*domain.Schedule, error) {
+	if m.schedule.ID == id {
+		return m.schedule, nil
+	}
+	return nil, nil
+}
+
+type stubEntryRepository struct {
+	domain.ScheduleEntryRepository
+	entry *domain.ScheduleEntry
+}
+
+func (m *stubEntryRepository) FindByID(_ context.Context, id sharedDomain.ID) (*domain.ScheduleEntry, error) {
+	if m.entry.ID == id {
+		return m.entry, nil
+	}
+	return nil, nil
+}
+
+type stubActualRecordRepository struct {
+	domain.ActualRecordRepository
+	record *domain.ActualRecord
+	writes int
+}
+
+func (m *stubActualRecordRepository) FindByEntryID(_ context.Context, _ sharedDomain.ID) (*domain.ActualRecord, error) {
+	return m.record, nil
+}
+
+func (m *stubActualRecordRepository) Save(_ context.Context, _ *domain.ActualRecord) error {
+	m.writes++
+	return nil
+}
+
+func (m *stubActualRecordRepository) Delete(_ context.Context, _ sharedDomain.ID) error {
+	m.writes++
+	return nil
+}
+
+type stubStaffRepository struct {
+	staffDomain.StaffRepository
+	staffs []staffDomain.Staff
+}
+
+func (m *stubStaffRepository) FindActiveByOrganizationID(_ context.Context, _ sharedDomain.ID) ([]staffDomain.Staff, error) {
+	return m.staffs, nil
+}
+
+func TestActualRecordHandler_RefusesOtherStaffRecord(t *testing.T) {
+	orgID := sharedDomain.NewID()
+	owner := staffDomain.Staff{ID: sharedDomain.NewID(), LastName: "山田", FirstName: "花子", Email: "hanako@example.com", IsActive: true}
+	other := staffDomain.Staff{ID: sharedDomain.NewID(), LastName: "佐藤", FirstName: "太郎", Email: "taro@example.com", IsActive: true}
+	schedule := &domain.Schedule{ID: sharedDomain.NewID(), OrganizationID: orgID, TargetYear: 2025, TargetMonth: 2}
+	entry := &domain.ScheduleEntry{ID: sharedDomain.NewID(), ScheduleID: schedule.ID, StaffID: owner.ID, TargetDate: time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC)}
+	actuals := &stubActualRecordRepository{record: &domain.ActualRecord{ID: sharedDomain.NewID(), ScheduleEntryID: entry.ID}}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	useCase := application.NewActualRecordUseCase(
+		&stubScheduleRepository{schedule: schedule},
+		&stubEntryRepository{entry: entry},
+		actuals,
+		nil,
+		&stubStaffRepository{staffs: []staffDomain.Staff{owner, other}},
+		logger,
+	)
+	handler := NewActualRecordHandler(useCase, nil, nil, logger)
+
+	// 一般ユーザーの太郎が花子の勤務の実績を変更・削除しようとする
+	claims := &authDomain.Claims{UserID: sharedDomain.NewID(), Email: other.Email, Role: "user", OrganizationID: &orgID}
+	tests := []struct {
+		name    string
+		method  string
+		body    string
+		handler http.HandlerFunc
+	}{
+		{name: "フォームで登録", method: http.MethodPost, body: "actual_start_time=08:30&actual_end_time=17:30", handler: handler.Record},
+		{name: "フォームで削除", method: http.MethodDelete, handler: handler.Delete},
+		{name: "APIで登録", method: http.MethodPut, body: `{"actual_start_time":"08:30","actual_end_time":"17:30"}`, handler: handler.RecordJSON},
+		{name: "APIで削除", method: http.MethodDelete, handler: handler.DeleteJSON},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/schedules/"+schedule.ID.String()+"/entries/"+entry.ID.String()+"/actual", strings.NewReader(tt.body))
+			if strings.HasPrefix(tt.body, "actual_") {
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			}
+			req.SetPathValue("id", schedule.ID.String())
+			req.SetPathValue("entry_id", entry.ID.String())
+			req = req.WithContext(context.WithValue(req.Context(), web.ContextKeyClaims, claims))
+
+			rec := httptest.NewRecorder()
+			tt.handler(rec, req)
+
+			if rec.Code != http.StatusForbidden {
+				t.Errorf("status = %d, want %d: %s", rec.Code, http.StatusForbidden, rec.Body.String())
+			}
+		})
+	}
+	if actuals.writes != 0 {
+		t.Errorf("他のスタッフの実績が変更されました: writes = %d", actuals.writes)
+	}
+}
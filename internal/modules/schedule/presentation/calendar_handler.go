@@ -0,0 +1,201 @@
+// Package presentation 勤務表プレゼンテーション層
+package presentation
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"shiftmaster/internal/modules/schedule/application"
+	sharedDomain "shiftmaster/internal/shared/domain"
+	"shiftmaster/internal/web"
+)
+
+// CalendarHandler 勤務予定カレンダー購読HTTPハンドラー
+type CalendarHandler struct {
+	useCase   *application.CalendarUseCase
+	templates *web.TemplateEngine
+	logger    *slog.Logger
+}
+
+// NewCalendarHandler ハンドラー生成
+func NewCalendarHandler(
+	useCase *application.CalendarUseCase,
+	templates *web.TemplateEngine,
+	logger *slog.Logger,
+) *CalendarHandler {
+	return &CalendarHandler{
+		useCase:   useCase,
+		templates: templates,
+		logger:    logger,
+	}
+}
+
+// getOrganizationID コンテキストから組織IDを取得
+func (h *CalendarHandler) getOrganizationID(r *http.Request) string {
+	claims := web.GetClaimsFromContext(r.Context())
+	if claims != nil && claims.OrganizationID != nil {
+		return claims.OrganizationID.String()
+	}
+	return ""
+}
+
+// Feed 購読カレンダー配信 認証不要 トークンが無効な場合は404
+func (h *CalendarHandler) Feed(w http.ResponseWriter, r *http.Request) {
+	output, err := h.useCase.Feed(r.Context(), r.PathValue("token"))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="`+output.FileName+`"`)
+	w.Header().Set("Cache-Control", "no-cache")
+	if _, err := w.Write(output.Data); err != nil {
+		h.logger.Error("カレンダー出力失敗", "error", err)
+	}
+}
+
+// Show 購読URLの発行状況 スタッフ詳細ページに埋め込む
+func (h *CalendarHandler) Show(w http.ResponseWriter, r *http.Request) {
+	output, err := h.useCase.GetToken(r.Context(), h.getOrganizationID(r), r.PathValue("id"))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	h.render(w, r, output)
+}
+
+// Issue 購読URL発行 発行済みの場合は再発行
+func (h *CalendarHandler) Issue(w http.ResponseWriter, r *http.Request) {
+	output, err := h.useCase.IssueToken(r.Context(), h.getOrganizationID(r), r.PathValue("id"))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	h.render(w, r, output)
+}
+
+// Revoke 購読URL削除
+func (h *CalendarHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	staffID := r.PathValue("id")
+	if err := h.useCase.RevokeToken(r.Context(), h.getOrganizationID(r), staffID); err != nil {
+		h.handleError(w, err)
+		return
+	}
+	h.render(w, r, &application.CalendarTokenOutput{StaffID: staffID})
+}
+
+// ShowJSON 購読URLの発行状況JSON
+func (h *CalendarHandler) ShowJSON(w http.ResponseWriter, r *http.Request) {
+	output, err := h.useCase.GetToken(r.Context(), h.getOrganizationID(r), r.PathValue("id"))
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, output)
+}
+
+// IssueJSON 購読URL発行JSON 購読URLは発行直後のみ返す
+func (h *CalendarHandler) IssueJSON(w http.ResponseWriter, r *http.Request) {
+	output, err := h.useCase.IssueToken(r.Context(), h.getOrganizationID(r), r.PathValue("id"))
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+	h.writeJSON(w, http.StatusCreated, map[string]any{
+		"token":  output,
+		"url":    feedURL(r, "https", output.Token),
+		"webcal": feedURL(r, "webcal", output.Token),
+	})
+}
+
+// RevokeJSON 購読URL削除JSON
+func (h *CalendarHandler) RevokeJSON(w http.ResponseWriter, r *http.Request) {
+	if err := h.useCase.RevokeToken(r.Context(), h.getOrganizationID(r), r.PathValue("id")); err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// render 購読URL部分テンプレート出力
+func (h *CalendarHandler) render(w http.ResponseWriter, r *http.Request, output *application.CalendarTokenOutput) {
+	data := map[string]any{
+		"Calendar": output,
+	}
+	if output.Token != "" {
+		data["FeedURL"] = feedURL(r, "https", output.Token)
+		data["WebcalURL"] = feedURL(r, "webcal", output.Token)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := h.templates.RenderPartial(w, "pages/staffs/calendar.html", "calendar-feed", data); err != nil {
+		h.logger.Error("テンプレートレンダリング失敗", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// feedURL 購読URL組み立て TLS終端がプロキシの場合はX-Forwarded-Protoを見る
+func feedURL(r *http.Request, scheme, token string) string {
+	if scheme == "https" && r.TLS == nil && r.Header.Get("X-Forwarded-Proto") != "https" {
+		scheme = "http"
+	}
+	return scheme + "://" + r.Host + "/calendar/" + token + "/shifts.ics"
+}
+
+// handleError エラーハンドリング
+func (h *CalendarHandler) handleError(w http.ResponseWriter, err error) {
+	if errors.Is(err, sharedDomain.ErrNotFound) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+
+	var domainErr *sharedDomain.DomainError
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code {
+		case sharedDomain.ErrCodeNotFound:
+			http.Error(w, domainErr.Message, http.StatusNotFound)
+			return
+		case sharedDomain.ErrCodeValidation:
+			http.Error(w, domainErr.Message, http.StatusBadRequest)
+			return
+		}
+	}
+
+	h.logger.Error("ハンドラーエラー", "error", err)
+	http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+}
+
+// handleJSONError JSONエラーハンドリング
+func (h *CalendarHandler) handleJSONError(w http.ResponseWriter, err error) {
+	if errors.Is(err, sharedDomain.ErrNotFound) {
+		h.writeJSON(w, http.StatusNotFound, map[string]string{"error": "見つかりません"})
+		return
+	}
+
+	var domainErr *sharedDomain.DomainError
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code {
+		case sharedDomain.ErrCodeNotFound:
+			h.writeJSON(w, http.StatusNotFound, map[string]string{"error": domainErr.Message})
+			return
+		case sharedDomain.ErrCodeValidation:
+			h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": domainErr.Message})
+			return
+		}
+	}
+
+	h.logger.Error("ハンドラーエラー", "error", err)
+	h.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "内部エラーが発生しました"})
+}
+
+// writeJSON JSONレスポンス書き込み
+func (h *CalendarHandler) writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		h.logger.Error("JSONエンコード失敗", "error", err)
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"log/slog"
+	"mime"
 	"net/http"
 	"strconv"
 	"time"
@@ -73,12 +75,22 @@ func (h *ScheduleHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("POST /schedules", h.Create)
 	mux.HandleFunc("POST /schedules/{id}/publish", h.Publish)
 	mux.HandleFunc("DELETE /schedules/{id}", h.Delete)
+	mux.HandleFunc("POST /schedules/{id}/generate", h.Generate)
+	mux.HandleFunc("GET /schedules/{id}/proposals/{proposal_id}", h.ShowProposal)
+	mux.HandleFunc("POST /schedules/{id}/proposals/{proposal_id}/apply", h.ApplyProposal)
+	mux.HandleFunc("POST /schedules/{id}/proposals/{proposal_id}/discard", h.DiscardProposal)
 
 	// API用エンドポイント
 	mux.HandleFunc("GET /api/schedules", h.ListJSON)
 	mux.HandleFunc("GET /api/schedules/{id}", h.ShowJSON)
 	mux.HandleFunc("POST /api/schedules", h.CreateJSON)
 	mux.HandleFunc("POST /api/schedules/{id}/validate", h.ValidateJSON)
+	mux.HandleFunc("POST /api/schedules/{id}/generate", h.GenerateJSON)
+	mux.HandleFunc("GET /api/schedules/{id}/proposals/{proposal_id}", h.ShowProposalJSON)
+	mux.HandleFunc("POST /api/schedules/{id}/proposals/{proposal_id}/apply", h.ApplyProposalJSON)
+	mux.HandleFunc("POST /api/schedules/{id}/proposals/{proposal_id}/discard", h.DiscardProposalJSON)
+	mux.HandleFunc("GET /api/schedules/{id}/export.csv", h.ExportCSV)
+	mux.HandleFunc("POST /api/schedules/{id}/import.csv", h.ImportCSV)
 }
 
 // List 勤務表一覧ページ
@@ -170,16 +182,7 @@ func (h *ScheduleHandler) Show(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 日付一覧を生成
-	dates := make([]DateInfo, schedule.DaysInMonth)
-	for day := 1; day <= schedule.DaysInMonth; day++ {
-		date := time.Date(schedule.TargetYear, time.Month(schedule.TargetMonth), day, 0, 0, 0, 0, time.Local)
-		dates[day-1] = DateInfo{
-			Day:       day,
-			Date:      date.Format("2006-01-02"),
-			DayOfWeek: weekdayToJapanese(date.Weekday()),
-			IsWeekend: date.Weekday() == time.Saturday || date.Weekday() == time.Sunday,
-		}
-	}
+	dates := buildDates(schedule)
 
 	// スタッフ一覧とスタッフごとの日別シフトマップを作成
 	var staffs []StaffInfo
@@ -211,6 +214,18 @@ func (h *ScheduleHandler) Show(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// 自動作成案一覧取得
+	proposals, err := h.useCase.ListProposals(r.Context(), id)
+	if err != nil {
+		h.logger.Warn("自動作成案一覧取得失敗", "error", err)
+	}
+
+	// スタッフ別勤務希望充足率
+	fulfillment, err := h.useCase.GetRequestFulfillment(r.Context(), id)
+	if err != nil {
+		h.logger.Warn("勤務希望充足率取得失敗", "error", err)
+	}
+
 	data := map[string]any{
 		"Title":         schedule.TargetPeriodLabel + " 勤務表",
 		"Schedule":      schedule,
@@ -218,6 +233,8 @@ func (h *ScheduleHandler) Show(w http.ResponseWriter, r *http.Request) {
 		"Staffs":        staffs,
 		"StaffShiftMap": staffShiftMap,
 		"ShiftTypes":    shiftTypes,
+		"Proposals":     proposals,
+		"Fulfillment":   fulfillment,
 	}
 
 	if err := h.templates.Render(w, "pages/schedules/show.html", data); err != nil {
@@ -234,6 +251,21 @@ type DateInfo struct {
 	IsWeekend bool
 }
 
+// buildDates 勤務表対象月の日付一覧を生成
+func buildDates(schedule *application.ScheduleOutput) []DateInfo {
+	dates := make([]DateInfo, schedule.DaysInMonth)
+	for day := 1; day <= schedule.DaysInMonth; day++ {
+		date := time.Date(schedule.TargetYear, time.Month(schedule.TargetMonth), day, 0, 0, 0, 0, time.Local)
+		dates[day-1] = DateInfo{
+			Day:       day,
+			Date:      date.Format("2006-01-02"),
+			DayOfWeek: weekdayToJapanese(date.Weekday()),
+			IsWeekend: date.Weekday() == time.Saturday || date.Weekday() == time.Sunday,
+		}
+	}
+	return dates
+}
+
 // weekdayToJapanese 曜日を日本語に変換
 func weekdayToJapanese(w time.Weekday) string {
 	weekdays := []string{"日", "月", "火", "水", "木", "金", "土"}
@@ -430,6 +462,249 @@ func (h *ScheduleHandler) CreateEntry(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/schedules/"+scheduleID, http.StatusSeeOther)
 }
 
+// Generate 勤務表自動作成 作成後は作成案の確認ページへ遷移
+func (h *ScheduleHandler) Generate(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	if err := r.ParseForm(); err != nil {
+		h.handleError(w, r, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "フォーム解析失敗"))
+		return
+	}
+
+	timeoutSeconds, _ := strconv.Atoi(r.FormValue("timeout_seconds"))
+	input := &application.GenerateScheduleInput{
+		ScheduleID:         id,
+		TimeoutSeconds:     timeoutSeconds,
+		PrioritizeRequests: r.FormValue("prioritize_requests") != "",
+	}
+
+	proposal, err := h.useCase.Generate(r.Context(), input)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	redirectTo := "/schedules/" + id + "/proposals/" + proposal.ID
+	if isHTMXRequest(r) {
+		w.Header().Set("HX-Redirect", redirectTo)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	http.Redirect(w, r, redirectTo, http.StatusSeeOther)
+}
+
+// ShowProposal 自動作成案確認ページ
+func (h *ScheduleHandler) ShowProposal(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	proposalID := r.PathValue("proposal_id")
+
+	schedule, err := h.useCase.GetByID(r.Context(), id)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	proposal, err := h.useCase.GetProposal(r.Context(), id, proposalID)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	var staffs []StaffInfo
+	orgID, parseErr := sharedDomain.ParseID(schedule.OrganizationID)
+	if parseErr == nil && h.staffFinder != nil {
+		foundStaffs, staffErr := h.staffFinder.FindActiveByOrganizationID(r.Context(), orgID)
+		if staffErr == nil {
+			staffs = foundStaffs
+		}
+	}
+
+	// 作成案エントリと変更セルをマップに変換
+	proposalShiftMap := make(map[string]map[string]*application.ScheduleEntryOutput)
+	for i := range proposal.Entries {
+		entry := &proposal.Entries[i]
+		if proposalShiftMap[entry.StaffID] == nil {
+			proposalShiftMap[entry.StaffID] = make(map[string]*application.ScheduleEntryOutput)
+		}
+		proposalShiftMap[entry.StaffID][entry.TargetDate] = entry
+	}
+	changedMap := make(map[string]map[string]bool)
+	for _, c := range proposal.Comparison.Changes {
+		if changedMap[c.StaffID] == nil {
+			changedMap[c.StaffID] = make(map[string]bool)
+		}
+		changedMap[c.StaffID][c.TargetDate] = true
+	}
+
+	data := map[string]any{
+		"Title":            schedule.TargetPeriodLabel + " 自動作成案",
+		"Schedule":         schedule,
+		"Proposal":         proposal,
+		"Dates":            buildDates(schedule),
+		"Staffs":           staffs,
+		"ProposalShiftMap": proposalShiftMap,
+		"ChangedMap":       changedMap,
+	}
+
+	if err := h.templates.Render(w, "pages/schedules/proposal.html", data); err != nil {
+		h.logger.Error("テンプレートレンダリング失敗", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// ApplyProposal 自動作成案適用
+func (h *ScheduleHandler) ApplyProposal(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	if _, err := h.useCase.ApplyProposal(r.Context(), id, r.PathValue("proposal_id")); err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	if isHTMXRequest(r) {
+		w.Header().Set("HX-Redirect", "/schedules/"+id)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	http.Redirect(w, r, "/schedules/"+id, http.StatusSeeOther)
+}
+
+// DiscardProposal 自動作成案破棄
+func (h *ScheduleHandler) DiscardProposal(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	if err := h.useCase.DiscardProposal(r.Context(), id, r.PathValue("proposal_id")); err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	if isHTMXRequest(r) {
+		w.Header().Set("HX-Redirect", "/schedules/"+id)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	http.Redirect(w, r, "/schedules/"+id, http.StatusSeeOther)
+}
+
+// GenerateJSON 勤務表自動作成JSON
+func (h *ScheduleHandler) GenerateJSON(w http.ResponseWriter, r *http.Request) {
+	var input application.GenerateScheduleInput
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "リクエストの解析に失敗しました"})
+			return
+		}
+	}
+	input.ScheduleID = r.PathValue("id")
+
+	proposal, err := h.useCase.Generate(r.Context(), &input)
+	if err != nil {
+		h.writeJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, proposal)
+}
+
+// ShowProposalJSON 自動作成案JSON
+func (h *ScheduleHandler) ShowProposalJSON(w http.ResponseWriter, r *http.Request) {
+	proposal, err := h.useCase.GetProposal(r.Context(), r.PathValue("id"), r.PathValue("proposal_id"))
+	if err != nil {
+		h.writeJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, proposal)
+}
+
+// ApplyProposalJSON 自動作成案適用JSON
+func (h *ScheduleHandler) ApplyProposalJSON(w http.ResponseWriter, r *http.Request) {
+	schedule, err := h.useCase.ApplyProposal(r.Context(), r.PathValue("id"), r.PathValue("proposal_id"))
+	if err != nil {
+		h.writeJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, schedule)
+}
+
+// DiscardProposalJSON 自動作成案破棄JSON
+func (h *ScheduleHandler) DiscardProposalJSON(w http.ResponseWriter, r *http.Request) {
+	if err := h.useCase.DiscardProposal(r.Context(), r.PathValue("id"), r.PathValue("proposal_id")); err != nil {
+		h.writeJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]string{"status": "discarded"})
+}
+
+// ExportCSV 勤務表エントリCSV出力
+func (h *ScheduleHandler) ExportCSV(w http.ResponseWriter, r *http.Request) {
+	output, err := h.useCase.ExportEntriesCSV(r.Context(), h.getDefaultOrganizationID(r.Context()), r.PathValue("id"))
+	if err != nil {
+		h.writeJSONError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+output.FileName+`"`)
+	w.Header().Set("Content-Length", strconv.Itoa(len(output.Data)))
+	w.Header().Set("Cache-Control", "no-store")
+	if _, err := w.Write(output.Data); err != nil {
+		h.logger.Error("CSV書き込み失敗", "error", err)
+	}
+}
+
+// ImportCSV 勤務表エントリCSV取込
+// 本文にCSVを直接送るか、multipart/form-dataのfile項目で送る ?dry_run=trueの場合は検証結果のみ返す
+func (h *ScheduleHandler) ImportCSV(w http.ResponseWriter, r *http.Request) {
+	data, err := readCSVUpload(w, r)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "CSVの読み込みに失敗しました"})
+		return
+	}
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run"))
+
+	result, err := h.useCase.ImportEntriesCSV(r.Context(), &application.ImportEntriesCSVInput{
+		OrganizationID: h.getDefaultOrganizationID(r.Context()),
+		ScheduleID:     r.PathValue("id"),
+		Data:           data,
+		DryRun:         dryRun,
+	})
+	if err != nil {
+		h.writeJSONError(w, err)
+		return
+	}
+
+	status := http.StatusOK
+	if len(result.Errors) > 0 && !result.DryRun {
+		status = http.StatusBadRequest
+	}
+	h.writeJSON(w, status, result)
+}
+
+// maxCSVUploadBytes 取込CSVの最大サイズ
+const maxCSVUploadBytes = 10 << 20
+
+// readCSVUpload リクエストからCSVを読み込む
+func readCSVUpload(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxCSVUploadBytes)
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		return io.ReadAll(r.Body)
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+	return io.ReadAll(file)
+}
+
 // handleError エラーハンドリング
 func (h *ScheduleHandler) handleError(w http.ResponseWriter, r *http.Request, err error) {
 	h.logger.Warn("ハンドラーエラー", "error", err, "method", r.Method, "path", r.URL.Path)
@@ -454,6 +729,30 @@ func (h *ScheduleHandler) handleError(w http.ResponseWriter, r *http.Request, er
 	http.Error(w, err.Error(), http.StatusBadRequest)
 }
 
+// writeJSONError ドメインエラーをJSONで返す
+func (h *ScheduleHandler) writeJSONError(w http.ResponseWriter, err error) {
+	if errors.Is(err, sharedDomain.ErrNotFound) {
+		h.writeJSON(w, http.StatusNotFound, map[string]string{"error": "見つかりません"})
+		return
+	}
+
+	var domainErr *sharedDomain.DomainError
+	if errors.As(err, &domainErr) {
+		status := http.StatusBadRequest
+		switch domainErr.Code {
+		case sharedDomain.ErrCodeNotFound:
+			status = http.StatusNotFound
+		case sharedDomain.ErrCodeConflict:
+			status = http.StatusConflict
+		}
+		h.writeJSON(w, status, map[string]string{"error": domainErr.Message})
+		return
+	}
+
+	h.logger.Error("ハンドラーエラー", "error", err)
+	h.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "処理に失敗しました"})
+}
+
 // writeJSON JSONレスポンス書き込み
 func (h *ScheduleHandler) writeJSON(w http.ResponseWriter, status int, data any) {
 	w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,379 @@
+// Package presentation 勤務表プレゼンテーション層
+package presentation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"html"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"shiftmaster/internal/modules/schedule/application"
+	sharedDomain "shiftmaster/internal/shared/domain"
+	"shiftmaster/internal/web"
+)
+
+// OpenShiftHandler 募集シフトHTTPハンドラー
+type OpenShiftHandler struct {
+	useCase     *application.OpenShiftUseCase
+	staffFinder StaffFinder
+	templates   *web.TemplateEngine
+	logger      *slog.Logger
+}
+
+// NewOpenShiftHandler ハンドラー生成
+func NewOpenShiftHandler(
+	useCase *application.OpenShiftUseCase,
+	staffFinder StaffFinder,
+	templates *web.TemplateEngine,
+	logger *slog.Logger,
+) *OpenShiftHandler {
+	return &OpenShiftHandler{
+		useCase:     useCase,
+		staffFinder: staffFinder,
+		templates:   templates,
+		logger:      logger,
+	}
+}
+
+// openShiftStaffAction スタッフを指定する募集シフト操作
+type openShiftStaffAction func(ctx context.Context, input *application.OpenShiftStaffInput) (*application.OpenShiftOutput, error)
+
+// List 募集シフト一覧ページ 既定は募集中のみ
+func (h *OpenShiftHandler) List(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	if !r.URL.Query().Has("status") {
+		status = "open"
+	}
+
+	openShifts, err := h.useCase.List(r.Context(), h.getOrganizationID(r), status)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	claims := web.GetClaimsFromContext(r.Context())
+	data := map[string]any{
+		"Title":      "募集シフト",
+		"OpenShifts": openShifts,
+		"Status":     status,
+		"CanManage":  claims != nil && claims.IsManager(),
+	}
+	if err := h.templates.Render(w, "pages/open_shifts/list.html", data); err != nil {
+		h.logger.Error("テンプレートレンダリング失敗", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// New 募集シフト登録フォーム
+func (h *OpenShiftHandler) New(w http.ResponseWriter, r *http.Request) {
+	var staffs []StaffInfo
+	claims := web.GetClaimsFromContext(r.Context())
+	if claims != nil && claims.OrganizationID != nil && h.staffFinder != nil {
+		found, err := h.staffFinder.FindActiveByOrganizationID(r.Context(), *claims.OrganizationID)
+		if err != nil {
+			h.logger.Warn("スタッフ一覧取得失敗", "error", err)
+		}
+		staffs = found
+	}
+
+	options, err := h.useCase.Options(r.Context(), h.getOrganizationID(r))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	data := map[string]any{
+		"Title":    "シフト募集",
+		"Staffs":   staffs,
+		"Options":  options,
+		"Today":    time.Now().Format("2006-01-02"),
+		"StaffID":  r.URL.Query().Get("staff_id"),
+		"Date":     r.URL.Query().Get("date"),
+		"FillMode": "first_come",
+	}
+	if err := h.templates.Render(w, "pages/open_shifts/form.html", data); err != nil {
+		h.logger.Error("テンプレートレンダリング失敗", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// Create 募集シフト登録
+func (h *OpenShiftHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	input := &application.PostOpenShiftInput{
+		OrganizationID:    h.getOrganizationID(r),
+		StaffID:           r.FormValue("staff_id"),
+		TargetDate:        r.FormValue("target_date"),
+		RequiredSkillID:   r.FormValue("required_skill_id"),
+		RequiredJobTypeID: r.FormValue("required_job_type_id"),
+		FillMode:          r.FormValue("fill_mode"),
+		Note:              r.FormValue("note"),
+		UserEmail:         h.getUserEmail(r),
+	}
+
+	openShift, err := h.useCase.Post(r.Context(), input)
+	if err != nil {
+		h.handleFormError(w, r, err)
+		return
+	}
+
+	h.redirectToOpenShift(w, r, openShift.ID)
+}
+
+// Show 募集シフト詳細ページ
+func (h *OpenShiftHandler) Show(w http.ResponseWriter, r *http.Request) {
+	openShift, err := h.useCase.Get(r.Context(), h.getOrganizationID(r), r.PathValue("id"))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	claims := web.GetClaimsFromContext(r.Context())
+	data := map[string]any{
+		"Title":     "募集シフト",
+		"OpenShift": openShift,
+		"CanManage": claims != nil && claims.IsManager(),
+	}
+	if err := h.templates.Render(w, "pages/open_shifts/show.html", data); err != nil {
+		h.logger.Error("テンプレートレンダリング失敗", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// Claim スタッフが応募
+func (h *OpenShiftHandler) Claim(w http.ResponseWriter, r *http.Request) {
+	h.act(w, r, h.useCase.Claim)
+}
+
+// Assign 管理者が応募者から決定
+func (h *OpenShiftHandler) Assign(w http.ResponseWriter, r *http.Request) {
+	h.act(w, r, h.useCase.Assign)
+}
+
+// Cancel 管理者が募集を取り消し
+func (h *OpenShiftHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	openShift, err := h.useCase.Cancel(r.Context(), h.getOrganizationID(r), r.PathValue("id"))
+	if err != nil {
+		h.handleFormError(w, r, err)
+		return
+	}
+
+	h.redirectToOpenShift(w, r, openShift.ID)
+}
+
+// ListJSON 募集シフト一覧JSON statusが空の場合は全件
+func (h *OpenShiftHandler) ListJSON(w http.ResponseWriter, r *http.Request) {
+	openShifts, err := h.useCase.List(r.Context(), h.getOrganizationID(r), r.URL.Query().Get("status"))
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, openShifts)
+}
+
+// ShowJSON 募集シフト詳細JSON
+func (h *OpenShiftHandler) ShowJSON(w http.ResponseWriter, r *http.Request) {
+	openShift, err := h.useCase.Get(r.Context(), h.getOrganizationID(r), r.PathValue("id"))
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, openShift)
+}
+
+// CreateJSON 募集シフト登録JSON
+func (h *OpenShiftHandler) CreateJSON(w http.ResponseWriter, r *http.Request) {
+	var input application.PostOpenShiftInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "リクエストボディが不正です"})
+		return
+	}
+	input.OrganizationID = h.getOrganizationID(r)
+	input.UserEmail = h.getUserEmail(r)
+
+	openShift, err := h.useCase.Post(r.Context(), &input)
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, openShift)
+}
+
+// ClaimJSON 応募JSON
+func (h *OpenShiftHandler) ClaimJSON(w http.ResponseWriter, r *http.Request) {
+	h.actJSON(w, r, h.useCase.Claim)
+}
+
+// AssignJSON 決定JSON
+func (h *OpenShiftHandler) AssignJSON(w http.ResponseWriter, r *http.Request) {
+	h.actJSON(w, r, h.useCase.Assign)
+}
+
+// CancelJSON 取り消しJSON
+func (h *OpenShiftHandler) CancelJSON(w http.ResponseWriter, r *http.Request) {
+	openShift, err := h.useCase.Cancel(r.Context(), h.getOrganizationID(r), r.PathValue("id"))
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, openShift)
+}
+
+// act フォームからのスタッフ指定操作 成功時は詳細ページへ
+func (h *OpenShiftHandler) act(w http.ResponseWriter, r *http.Request, action openShiftStaffAction) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	input := &application.OpenShiftStaffInput{
+		OrganizationID: h.getOrganizationID(r),
+		OpenShiftID:    r.PathValue("id"),
+		StaffID:        r.FormValue("staff_id"),
+		UserEmail:      h.getUserEmail(r),
+	}
+
+	openShift, err := action(r.Context(), input)
+	if err != nil {
+		h.handleFormError(w, r, err)
+		return
+	}
+
+	h.redirectToOpenShift(w, r, openShift.ID)
+}
+
+// actJSON JSONでのスタッフ指定操作
+func (h *OpenShiftHandler) actJSON(w http.ResponseWriter, r *http.Request, action openShiftStaffAction) {
+	var input application.OpenShiftStaffInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "リクエストボディが不正です"})
+		return
+	}
+	input.OrganizationID = h.getOrganizationID(r)
+	input.OpenShiftID = r.PathValue("id")
+	input.UserEmail = h.getUserEmail(r)
+
+	openShift, err := action(r.Context(), &input)
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, openShift)
+}
+
+// getOrganizationID コンテキストから組織IDを取得
+func (h *OpenShiftHandler) getOrganizationID(r *http.Request) string {
+	claims := web.GetClaimsFromContext(r.Context())
+	if claims != nil && claims.OrganizationID != nil {
+		return claims.OrganizationID.String()
+	}
+	return ""
+}
+
+// getUserEmail コンテキストから操作ユーザーのメールアドレスを取得
+func (h *OpenShiftHandler) getUserEmail(r *http.Request) string {
+	if claims := web.GetClaimsFromContext(r.Context()); claims != nil {
+		return claims.Email
+	}
+	return ""
+}
+
+// redirectToOpenShift 募集シフト詳細ページへリダイレクト HTMX対応
+func (h *OpenShiftHandler) redirectToOpenShift(w http.ResponseWriter, r *http.Request, id string) {
+	redirectTo := "/open-shifts/" + id
+
+	if isHTMXRequest(r) {
+		w.Header().Set("HX-Redirect", redirectTo)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	http.Redirect(w, r, redirectTo, http.StatusSeeOther)
+}
+
+// handleFormError フォーム送信エラーハンドリング 検証エラーと競合はフォーム上に表示
+func (h *OpenShiftHandler) handleFormError(w http.ResponseWriter, r *http.Request, err error) {
+	var domainErr *sharedDomain.DomainError
+	if isHTMXRequest(r) && errors.As(err, &domainErr) &&
+		(domainErr.Code == sharedDomain.ErrCodeValidation || domainErr.Code == sharedDomain.ErrCodeConflict) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`<p class="text-sm text-red-400">` + html.EscapeString(domainErr.Message) + `</p>`))
+		return
+	}
+
+	h.handleError(w, err)
+}
+
+// handleError エラーハンドリング
+func (h *OpenShiftHandler) handleError(w http.ResponseWriter, err error) {
+	if errors.Is(err, sharedDomain.ErrNotFound) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+
+	var domainErr *sharedDomain.DomainError
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code {
+		case sharedDomain.ErrCodeNotFound:
+			http.Error(w, domainErr.Message, http.StatusNotFound)
+			return
+		case sharedDomain.ErrCodeValidation:
+			http.Error(w, domainErr.Message, http.StatusBadRequest)
+			return
+		case sharedDomain.ErrCodeConflict:
+			http.Error(w, domainErr.Message, http.StatusConflict)
+			return
+		}
+	}
+
+	h.logger.Error("ハンドラーエラー", "error", err)
+	http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+}
+
+// handleJSONError JSONエラーハンドリング
+func (h *OpenShiftHandler) handleJSONError(w http.ResponseWriter, err error) {
+	if errors.Is(err, sharedDomain.ErrNotFound) {
+		h.writeJSON(w, http.StatusNotFound, map[string]string{"error": "見つかりません"})
+		return
+	}
+
+	var domainErr *sharedDomain.DomainError
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code {
+		case sharedDomain.ErrCodeNotFound:
+			h.writeJSON(w, http.StatusNotFound, map[string]string{"error": domainErr.Message})
+			return
+		case sharedDomain.ErrCodeValidation:
+			h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": domainErr.Message})
+			return
+		case sharedDomain.ErrCodeConflict:
+			h.writeJSON(w, http.StatusConflict, map[string]string{"error": domainErr.Message})
+			return
+		}
+	}
+
+	h.logger.Error("ハンドラーエラー", "error", err)
+	h.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "内部エラーが発生しました"})
+}
+
+// writeJSON JSONレスポンス書き込み
+func (h *OpenShiftHandler) writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		h.logger.Error("JSONエンコード失敗", "error", err)
+	}
+}
@@ -0,0 +1,239 @@
+// Package presentation 勤務表プレゼンテーション層
+package presentation
+
+import (
+	"encoding/json"
+	"errors"
+	"html"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"shiftmaster/internal/modules/schedule/application"
+	sharedDomain "shiftmaster/internal/shared/domain"
+	"shiftmaster/internal/web"
+)
+
+// OvertimeAgreementHandler 36協定HTTPハンドラー
+type OvertimeAgreementHandler struct {
+	service   *application.OvertimeComplianceService
+	templates *web.TemplateEngine
+	logger    *slog.Logger
+}
+
+// NewOvertimeAgreementHandler ハンドラー生成
+func NewOvertimeAgreementHandler(
+	service *application.OvertimeComplianceService,
+	templates *web.TemplateEngine,
+	logger *slog.Logger,
+) *OvertimeAgreementHandler {
+	return &OvertimeAgreementHandler{
+		service:   service,
+		templates: templates,
+		logger:    logger,
+	}
+}
+
+// getOrganizationID コンテキストから組織IDを取得
+func (h *OvertimeAgreementHandler) getOrganizationID(r *http.Request) string {
+	claims := web.GetClaimsFromContext(r.Context())
+	if claims != nil && claims.OrganizationID != nil {
+		return claims.OrganizationID.String()
+	}
+	return ""
+}
+
+// targetMonth クエリの対象年月 未指定・不正な場合は当月
+func targetMonth(r *http.Request) (int, int) {
+	now := time.Now()
+	year, err := strconv.Atoi(r.URL.Query().Get("year"))
+	if err != nil || year < 2020 || year > 2100 {
+		year = now.Year()
+	}
+	month, err := strconv.Atoi(r.URL.Query().Get("month"))
+	if err != nil || month < 1 || month > 12 {
+		month = int(now.Month())
+	}
+	return year, month
+}
+
+// Show 36協定設定と警告一覧ページ
+func (h *OvertimeAgreementHandler) Show(w http.ResponseWriter, r *http.Request) {
+	year, month := targetMonth(r)
+	data := map[string]any{
+		"Title": "36協定",
+		"Year":  year,
+		"Month": month,
+	}
+
+	orgID := h.getOrganizationID(r)
+	if orgID == "" {
+		data["NoOrgSelected"] = true
+	} else {
+		agreement, err := h.service.GetAgreement(r.Context(), orgID)
+		if err != nil {
+			h.handleError(w, err)
+			return
+		}
+		alerts, err := h.service.ListAlerts(r.Context(), orgID, year, month)
+		if err != nil {
+			h.handleError(w, err)
+			return
+		}
+		data["Agreement"] = agreement
+		data["Alerts"] = alerts
+	}
+
+	if err := h.templates.Render(w, "pages/schedules/overtime_agreement.html", data); err != nil {
+		h.logger.Error("テンプレートレンダリング失敗", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// Update 36協定更新
+func (h *OvertimeAgreementHandler) Update(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	monthly, _ := strconv.Atoi(r.FormValue("monthly_limit_hours"))
+	yearly, _ := strconv.Atoi(r.FormValue("yearly_limit_hours"))
+	average, _ := strconv.Atoi(r.FormValue("multi_month_average_limit_hours"))
+	warning, _ := strconv.Atoi(r.FormValue("warning_percent"))
+	startMonth, _ := strconv.Atoi(r.FormValue("start_month"))
+	input := &application.UpdateOvertimeAgreementInput{
+		OrganizationID:              h.getOrganizationID(r),
+		MonthlyLimitHours:           monthly,
+		YearlyLimitHours:            yearly,
+		MultiMonthAverageLimitHours: average,
+		WarningPercent:              warning,
+		StartMonth:                  startMonth,
+	}
+
+	if _, err := h.service.UpdateAgreement(r.Context(), input); err != nil {
+		h.handleFormError(w, r, err)
+		return
+	}
+
+	if isHTMXRequest(r) {
+		w.Header().Set("HX-Redirect", "/overtime-agreement")
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	http.Redirect(w, r, "/overtime-agreement", http.StatusSeeOther)
+}
+
+// ShowJSON 36協定取得JSON
+func (h *OvertimeAgreementHandler) ShowJSON(w http.ResponseWriter, r *http.Request) {
+	agreement, err := h.service.GetAgreement(r.Context(), h.getOrganizationID(r))
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, agreement)
+}
+
+// UpdateJSON 36協定更新JSON
+func (h *OvertimeAgreementHandler) UpdateJSON(w http.ResponseWriter, r *http.Request) {
+	var input application.UpdateOvertimeAgreementInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "リクエストボディが不正です"})
+		return
+	}
+	input.OrganizationID = h.getOrganizationID(r)
+
+	agreement, err := h.service.UpdateAgreement(r.Context(), &input)
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, agreement)
+}
+
+// AlertsJSON 36協定警告一覧JSON year・monthクエリ未指定は当月
+func (h *OvertimeAgreementHandler) AlertsJSON(w http.ResponseWriter, r *http.Request) {
+	year, month := targetMonth(r)
+	alerts, err := h.service.ListAlerts(r.Context(), h.getOrganizationID(r), year, month)
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]any{
+		"year":   year,
+		"month":  month,
+		"alerts": alerts,
+	})
+}
+
+// handleFormError フォーム送信エラーハンドリング 検証エラーはフォーム上に表示
+func (h *OvertimeAgreementHandler) handleFormError(w http.ResponseWriter, r *http.Request, err error) {
+	var domainErr *sharedDomain.DomainError
+	if isHTMXRequest(r) && errors.As(err, &domainErr) && domainErr.Code == sharedDomain.ErrCodeValidation {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`<p class="text-sm text-red-400">` + html.EscapeString(domainErr.Message) + `</p>`))
+		return
+	}
+
+	h.handleError(w, err)
+}
+
+// handleError エラーハンドリング
+func (h *OvertimeAgreementHandler) handleError(w http.ResponseWriter, err error) {
+	if errors.Is(err, sharedDomain.ErrNotFound) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+
+	var domainErr *sharedDomain.DomainError
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code {
+		case sharedDomain.ErrCodeNotFound:
+			http.Error(w, domainErr.Message, http.StatusNotFound)
+			return
+		case sharedDomain.ErrCodeValidation:
+			http.Error(w, domainErr.Message, http.StatusBadRequest)
+			return
+		}
+	}
+
+	h.logger.Error("ハンドラーエラー", "error", err)
+	http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+}
+
+// handleJSONError JSONエラーハンドリング
+func (h *OvertimeAgreementHandler) handleJSONError(w http.ResponseWriter, err error) {
+	if errors.Is(err, sharedDomain.ErrNotFound) {
+		h.writeJSON(w, http.StatusNotFound, map[string]string{"error": "見つかりません"})
+		return
+	}
+
+	var domainErr *sharedDomain.DomainError
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code {
+		case sharedDomain.ErrCodeNotFound:
+			h.writeJSON(w, http.StatusNotFound, map[string]string{"error": domainErr.Message})
+			return
+		case sharedDomain.ErrCodeValidation:
+			h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": domainErr.Message})
+			return
+		}
+	}
+
+	h.logger.Error("ハンドラーエラー", "error", err)
+	h.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "内部エラーが発生しました"})
+}
+
+// writeJSON JSONレスポンス書き込み
+func (h *OvertimeAgreementHandler) writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		h.logger.Error("JSONエンコード失敗", "error", err)
+	}
+}
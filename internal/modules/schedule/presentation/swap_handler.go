@@ -0,0 +1,378 @@
+// Package presentation 勤務表プレゼンテーション層
+package presentation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"html"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"shiftmaster/internal/modules/schedule/application"
+	sharedDomain "shiftmaster/internal/shared/domain"
+	"shiftmaster/internal/web"
+)
+
+// ShiftSwapHandler 勤務交換HTTPハンドラー
+type ShiftSwapHandler struct {
+	useCase     *application.ShiftSwapUseCase
+	staffFinder StaffFinder
+	templates   *web.TemplateEngine
+	logger      *slog.Logger
+}
+
+// NewShiftSwapHandler ハンドラー生成
+func NewShiftSwapHandler(
+	useCase *application.ShiftSwapUseCase,
+	staffFinder StaffFinder,
+	templates *web.TemplateEngine,
+	logger *slog.Logger,
+) *ShiftSwapHandler {
+	return &ShiftSwapHandler{
+		useCase:     useCase,
+		staffFinder: staffFinder,
+		templates:   templates,
+		logger:      logger,
+	}
+}
+
+// swapAction 勤務交換の状態を変更するユースケース操作
+type swapAction func(ctx context.Context, input *application.ShiftSwapActionInput) (*application.ShiftSwapOutput, error)
+
+// List 勤務交換申請一覧ページ
+func (h *ShiftSwapHandler) List(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+
+	swaps, err := h.useCase.List(r.Context(), h.getOrganizationID(r), status)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	data := map[string]any{
+		"Title":  "勤務交換",
+		"Swaps":  swaps,
+		"Status": status,
+	}
+	if err := h.templates.Render(w, "pages/swaps/list.html", data); err != nil {
+		h.logger.Error("テンプレートレンダリング失敗", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// New 勤務交換申請フォーム
+func (h *ShiftSwapHandler) New(w http.ResponseWriter, r *http.Request) {
+	var staffs []StaffInfo
+	claims := web.GetClaimsFromContext(r.Context())
+	if claims != nil && claims.OrganizationID != nil && h.staffFinder != nil {
+		found, err := h.staffFinder.FindActiveByOrganizationID(r.Context(), *claims.OrganizationID)
+		if err != nil {
+			h.logger.Warn("スタッフ一覧取得失敗", "error", err)
+		}
+		staffs = found
+	}
+
+	data := map[string]any{
+		"Title":  "勤務交換申請",
+		"Staffs": staffs,
+		"Today":  time.Now().Format("2006-01-02"),
+	}
+	if err := h.templates.Render(w, "pages/swaps/form.html", data); err != nil {
+		h.logger.Error("テンプレートレンダリング失敗", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// Create 勤務交換申請
+func (h *ShiftSwapHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	input := &application.RequestShiftSwapInput{
+		RequesterStaffID:   r.FormValue("requester_staff_id"),
+		RequesterDate:      r.FormValue("requester_date"),
+		CounterpartStaffID: r.FormValue("counterpart_staff_id"),
+		CounterpartDate:    r.FormValue("counterpart_date"),
+		Reason:             r.FormValue("reason"),
+	}
+	h.setActor(r, &input.OrganizationID, &input.UserID, &input.UserEmail, &input.IsManager)
+
+	swap, err := h.useCase.Request(r.Context(), input)
+	if err != nil {
+		h.handleFormError(w, r, err)
+		return
+	}
+
+	h.redirectToSwap(w, r, swap.ID)
+}
+
+// Show 勤務交換申請詳細ページ
+func (h *ShiftSwapHandler) Show(w http.ResponseWriter, r *http.Request) {
+	swap, err := h.useCase.Get(r.Context(), h.getOrganizationID(r), r.PathValue("id"))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	claims := web.GetClaimsFromContext(r.Context())
+	data := map[string]any{
+		"Title":      "勤務交換申請",
+		"Swap":       swap,
+		"CanApprove": claims != nil && claims.IsManager(),
+	}
+	if err := h.templates.Render(w, "pages/swaps/show.html", data); err != nil {
+		h.logger.Error("テンプレートレンダリング失敗", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// Accept 相手のスタッフが承諾
+func (h *ShiftSwapHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	h.act(w, r, h.useCase.Accept)
+}
+
+// Decline 相手のスタッフが辞退
+func (h *ShiftSwapHandler) Decline(w http.ResponseWriter, r *http.Request) {
+	h.act(w, r, h.useCase.Decline)
+}
+
+// Cancel 申請者が取り下げ
+func (h *ShiftSwapHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	h.act(w, r, h.useCase.Cancel)
+}
+
+// Approve 管理者が承認し勤務表に反映
+func (h *ShiftSwapHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	h.act(w, r, h.useCase.Approve)
+}
+
+// Reject 管理者が却下
+func (h *ShiftSwapHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	h.act(w, r, h.useCase.Reject)
+}
+
+// ListJSON 勤務交換申請一覧JSON
+func (h *ShiftSwapHandler) ListJSON(w http.ResponseWriter, r *http.Request) {
+	swaps, err := h.useCase.List(r.Context(), h.getOrganizationID(r), r.URL.Query().Get("status"))
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, swaps)
+}
+
+// ShowJSON 勤務交換申請詳細JSON
+func (h *ShiftSwapHandler) ShowJSON(w http.ResponseWriter, r *http.Request) {
+	swap, err := h.useCase.Get(r.Context(), h.getOrganizationID(r), r.PathValue("id"))
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, swap)
+}
+
+// CreateJSON 勤務交換申請JSON
+func (h *ShiftSwapHandler) CreateJSON(w http.ResponseWriter, r *http.Request) {
+	var input application.RequestShiftSwapInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "リクエストボディが不正です"})
+		return
+	}
+	h.setActor(r, &input.OrganizationID, &input.UserID, &input.UserEmail, &input.IsManager)
+
+	swap, err := h.useCase.Request(r.Context(), &input)
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, swap)
+}
+
+// AcceptJSON 承諾JSON
+func (h *ShiftSwapHandler) AcceptJSON(w http.ResponseWriter, r *http.Request) {
+	h.actJSON(w, r, h.useCase.Accept)
+}
+
+// DeclineJSON 辞退JSON
+func (h *ShiftSwapHandler) DeclineJSON(w http.ResponseWriter, r *http.Request) {
+	h.actJSON(w, r, h.useCase.Decline)
+}
+
+// CancelJSON 取り下げJSON
+func (h *ShiftSwapHandler) CancelJSON(w http.ResponseWriter, r *http.Request) {
+	h.actJSON(w, r, h.useCase.Cancel)
+}
+
+// ApproveJSON 承認JSON
+func (h *ShiftSwapHandler) ApproveJSON(w http.ResponseWriter, r *http.Request) {
+	h.actJSON(w, r, h.useCase.Approve)
+}
+
+// RejectJSON 却下JSON
+func (h *ShiftSwapHandler) RejectJSON(w http.ResponseWriter, r *http.Request) {
+	h.actJSON(w, r, h.useCase.Reject)
+}
+
+// act フォームからの状態変更 成功時は詳細ページへ
+func (h *ShiftSwapHandler) act(w http.ResponseWriter, r *http.Request, action swapAction) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	input := &application.ShiftSwapActionInput{
+		SwapID:  r.PathValue("id"),
+		Comment: r.FormValue("comment"),
+	}
+	h.setActor(r, &input.OrganizationID, &input.UserID, &input.UserEmail, &input.IsManager)
+
+	swap, err := action(r.Context(), input)
+	if err != nil {
+		h.handleFormError(w, r, err)
+		return
+	}
+
+	h.redirectToSwap(w, r, swap.ID)
+}
+
+// actJSON JSONでの状態変更 コメントは任意
+func (h *ShiftSwapHandler) actJSON(w http.ResponseWriter, r *http.Request, action swapAction) {
+	var input application.ShiftSwapActionInput
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "リクエストボディが不正です"})
+			return
+		}
+	}
+	input.SwapID = r.PathValue("id")
+	h.setActor(r, &input.OrganizationID, &input.UserID, &input.UserEmail, &input.IsManager)
+
+	swap, err := action(r.Context(), &input)
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, swap)
+}
+
+// getOrganizationID コンテキストから組織IDを取得
+func (h *ShiftSwapHandler) getOrganizationID(r *http.Request) string {
+	claims := web.GetClaimsFromContext(r.Context())
+	if claims != nil && claims.OrganizationID != nil {
+		return claims.OrganizationID.String()
+	}
+	return ""
+}
+
+// setActor 組織IDと操作ユーザーを入力に設定
+func (h *ShiftSwapHandler) setActor(r *http.Request, organizationID, userID, email *string, isManager *bool) {
+	*organizationID = h.getOrganizationID(r)
+	if claims := web.GetClaimsFromContext(r.Context()); claims != nil {
+		*userID = claims.UserID.String()
+		*email = claims.Email
+		*isManager = claims.IsManager()
+	}
+}
+
+// redirectToSwap 勤務交換申請詳細ページへリダイレクト HTMX対応
+func (h *ShiftSwapHandler) redirectToSwap(w http.ResponseWriter, r *http.Request, id string) {
+	redirectTo := "/swaps/" + id
+
+	if isHTMXRequest(r) {
+		w.Header().Set("HX-Redirect", redirectTo)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	http.Redirect(w, r, redirectTo, http.StatusSeeOther)
+}
+
+// handleFormError フォーム送信エラーハンドリング 検証エラーと競合はフォーム上に表示
+func (h *ShiftSwapHandler) handleFormError(w http.ResponseWriter, r *http.Request, err error) {
+	var domainErr *sharedDomain.DomainError
+	if isHTMXRequest(r) && errors.As(err, &domainErr) &&
+		(domainErr.Code == sharedDomain.ErrCodeValidation || domainErr.Code == sharedDomain.ErrCodeConflict ||
+			domainErr.Code == sharedDomain.ErrCodeForbidden) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`<p class="text-sm text-red-400">` + html.EscapeString(domainErr.Message) + `</p>`))
+		return
+	}
+
+	h.handleError(w, err)
+}
+
+// handleError エラーハンドリング
+func (h *ShiftSwapHandler) handleError(w http.ResponseWriter, err error) {
+	if errors.Is(err, sharedDomain.ErrNotFound) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+
+	var domainErr *sharedDomain.DomainError
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code {
+		case sharedDomain.ErrCodeNotFound:
+			http.Error(w, domainErr.Message, http.StatusNotFound)
+			return
+		case sharedDomain.ErrCodeValidation:
+			http.Error(w, domainErr.Message, http.StatusBadRequest)
+			return
+		case sharedDomain.ErrCodeConflict:
+			http.Error(w, domainErr.Message, http.StatusConflict)
+			return
+		case sharedDomain.ErrCodeForbidden:
+			http.Error(w, domainErr.Message, http.StatusForbidden)
+			return
+		}
+	}
+
+	h.logger.Error("ハンドラーエラー", "error", err)
+	http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+}
+
+// handleJSONError JSONエラーハンドリング
+func (h *ShiftSwapHandler) handleJSONError(w http.ResponseWriter, err error) {
+	if errors.Is(err, sharedDomain.ErrNotFound) {
+		h.writeJSON(w, http.StatusNotFound, map[string]string{"error": "見つかりません"})
+		return
+	}
+
+	var domainErr *sharedDomain.DomainError
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code {
+		case sharedDomain.ErrCodeNotFound:
+			h.writeJSON(w, http.StatusNotFound, map[string]string{"error": domainErr.Message})
+			return
+		case sharedDomain.ErrCodeValidation:
+			h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": domainErr.Message})
+			return
+		case sharedDomain.ErrCodeConflict:
+			h.writeJSON(w, http.StatusConflict, map[string]string{"error": domainErr.Message})
+			return
+		case sharedDomain.ErrCodeForbidden:
+			h.writeJSON(w, http.StatusForbidden, map[string]string{"error": domainErr.Message})
+			return
+		}
+	}
+
+	h.logger.Error("ハンドラーエラー", "error", err)
+	h.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "内部エラーが発生しました"})
+}
+
+// writeJSON JSONレスポンス書き込み
+func (h *ShiftSwapHandler) writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		h.logger.Error("JSONエンコード失敗", "error", err)
+	}
+}
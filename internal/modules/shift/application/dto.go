@@ -2,6 +2,7 @@
 package application
 
 import (
+	"encoding/json"
 	"time"
 
 	"shiftmaster/internal/modules/shift/domain"
@@ -316,3 +317,146 @@ func GetRotationTypeOptions() []RotationTypeOption {
 		{Value: string(domain.RotationTypeCustom), Label: domain.RotationTypeCustom.Label()},
 	}
 }
+
+// CreateShiftRuleInput シフトルール作成入力
+type CreateShiftRuleInput struct {
+	// OrganizationID 組織ID
+	OrganizationID string `json:"organization_id"`
+	// Name ルール名
+	Name string `json:"name"`
+	// Description 説明
+	Description string `json:"description"`
+	// RuleType ルール種別
+	RuleType string `json:"rule_type"`
+	// Priority 優先度
+	Priority int `json:"priority"`
+	// IsActive 有効フラグ 未指定は有効
+	IsActive *bool `json:"is_active"`
+	// Config ルール設定 ルール種別ごとのJSONオブジェクト
+	Config json.RawMessage `json:"config"`
+}
+
+// Validate 入力検証
+func (i *CreateShiftRuleInput) Validate() error {
+	if i.OrganizationID == "" {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDは必須です")
+	}
+	if i.Name == "" {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "ルール名は必須です")
+	}
+	if !domain.ShiftRuleType(i.RuleType).IsValid() {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "ルール種別が不正です")
+	}
+	return nil
+}
+
+// UpdateShiftRuleInput シフトルール更新入力
+type UpdateShiftRuleInput struct {
+	// ID ルールID
+	ID string `json:"id"`
+	// Name ルール名
+	Name string `json:"name"`
+	// Description 説明
+	Description string `json:"description"`
+	// RuleType ルール種別
+	RuleType string `json:"rule_type"`
+	// Priority 優先度
+	Priority int `json:"priority"`
+	// IsActive 有効フラグ
+	IsActive bool `json:"is_active"`
+	// Config ルール設定 ルール種別ごとのJSONオブジェクト
+	Config json.RawMessage `json:"config"`
+}
+
+// Validate 入力検証
+func (i *UpdateShiftRuleInput) Validate() error {
+	if i.ID == "" {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "IDは必須です")
+	}
+	if i.Name == "" {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "ルール名は必須です")
+	}
+	if !domain.ShiftRuleType(i.RuleType).IsValid() {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "ルール種別が不正です")
+	}
+	return nil
+}
+
+// ShiftRuleOutput シフトルール出力
+type ShiftRuleOutput struct {
+	// ID ルールID
+	ID string `json:"id"`
+	// OrganizationID 組織ID
+	OrganizationID string `json:"organization_id"`
+	// Name ルール名
+	Name string `json:"name"`
+	// Description 説明
+	Description string `json:"description"`
+	// RuleType ルール種別
+	RuleType string `json:"rule_type"`
+	// RuleTypeLabel ルール種別ラベル
+	RuleTypeLabel string `json:"rule_type_label"`
+	// Priority 優先度
+	Priority int `json:"priority"`
+	// IsActive 有効フラグ
+	IsActive bool `json:"is_active"`
+	// Config ルール設定
+	Config json.RawMessage `json:"config"`
+	// CreatedAt 作成日時
+	CreatedAt string `json:"created_at"`
+	// UpdatedAt 更新日時
+	UpdatedAt string `json:"updated_at"`
+}
+
+// ToShiftRuleOutput ドメインエンティティから出力DTOへ変換
+func ToShiftRuleOutput(rule *domain.ShiftRule) *ShiftRuleOutput {
+	config := rule.Config
+	if config == "" {
+		config = "{}"
+	}
+
+	return &ShiftRuleOutput{
+		ID:             rule.ID.String(),
+		OrganizationID: rule.OrganizationID.String(),
+		Name:           rule.Name,
+		Description:    rule.Description,
+		RuleType:       rule.RuleType.String(),
+		RuleTypeLabel:  rule.RuleType.Label(),
+		Priority:       rule.Priority,
+		IsActive:       rule.IsActive,
+		Config:         json.RawMessage(config),
+		CreatedAt:      rule.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      rule.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// ShiftRuleListOutput シフトルール一覧出力
+type ShiftRuleListOutput struct {
+	// ShiftRules シフトルール一覧
+	ShiftRules []ShiftRuleOutput `json:"shift_rules"`
+	// Total 総件数
+	Total int `json:"total"`
+}
+
+// RuleTypeOption ルール種別選択肢
+type RuleTypeOption struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+	// Example 設定例
+	Example string `json:"example"`
+}
+
+// GetRuleTypeOptions ルール種別選択肢取得
+func GetRuleTypeOptions() []RuleTypeOption {
+	return []RuleTypeOption{
+		{Value: string(domain.RuleTypeMinStaff), Label: domain.RuleTypeMinStaff.Label(), Example: `{"shift_type_id":"","day_kind":"holiday","skill_id":"","min_skill_level":0,"job_type_id":"","min_count":2}`},
+		{Value: string(domain.RuleTypeMaxStaff), Label: domain.RuleTypeMaxStaff.Label(), Example: `{"shift_type_id":"","day_kind":"weekday","max_count":5}`},
+		{Value: string(domain.RuleTypeConsecutive), Label: domain.RuleTypeConsecutive.Label(), Example: `{"max_days":6,"night_only":false}`},
+		{Value: string(domain.RuleTypeInterval), Label: domain.RuleTypeInterval.Label(), Example: `{"min_hours":11}`},
+		{Value: string(domain.RuleTypeSkillRequired), Label: domain.RuleTypeSkillRequired.Label(), Example: `{"shift_type_id":"","skill_id":"","min_level":3,"min_count":1}`},
+		{Value: string(domain.RuleTypePositionRequired), Label: domain.RuleTypePositionRequired.Label(), Example: `{"shift_type_id":"","position_id":"","level":2,"min_count":1}`},
+		{Value: string(domain.RuleTypeNightLimit), Label: domain.RuleTypeNightLimit.Label(), Example: `{"max_per_month":8}`},
+		{Value: string(domain.RuleTypeWeeklyHours), Label: domain.RuleTypeWeeklyHours.Label(), Example: `{"employment_type":"part_time","max_hours":30}`},
+		{Value: string(domain.RuleTypeMonthlyHours), Label: domain.RuleTypeMonthlyHours.Label(), Example: `{"employment_type":"full_time","max_hours":177,"min_hours":140}`},
+	}
+}
@@ -0,0 +1,196 @@
+// Package application シフトアプリケーション層
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"shiftmaster/internal/modules/shift/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// ShiftRuleUseCase シフトルールユースケース
+type ShiftRuleUseCase struct {
+	repo   domain.ShiftRuleRepository
+	logger *slog.Logger
+}
+
+// NewShiftRuleUseCase シフトルールユースケース生成
+func NewShiftRuleUseCase(repo domain.ShiftRuleRepository, logger *slog.Logger) *ShiftRuleUseCase {
+	return &ShiftRuleUseCase{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// Create シフトルール作成
+func (u *ShiftRuleUseCase) Create(ctx context.Context, input *CreateShiftRuleInput) (*ShiftRuleOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	orgID, err := sharedDomain.ParseID(input.OrganizationID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDが不正です")
+	}
+
+	ruleType := domain.ShiftRuleType(input.RuleType)
+	config, err := normalizeRuleConfig(ruleType, input.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	isActive := true
+	if input.IsActive != nil {
+		isActive = *input.IsActive
+	}
+
+	now := time.Now()
+	rule := &domain.ShiftRule{
+		ID:             sharedDomain.NewID(),
+		OrganizationID: orgID,
+		Name:           input.Name,
+		Description:    input.Description,
+		RuleType:       ruleType,
+		Priority:       input.Priority,
+		IsActive:       isActive,
+		Config:         config,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	if err := u.repo.Save(ctx, rule); err != nil {
+		u.logger.Error("シフトルール作成失敗", "error", err)
+		return nil, err
+	}
+
+	u.logger.Info("シフトルール作成完了", "shift_rule_id", rule.ID, "rule_type", rule.RuleType)
+	return ToShiftRuleOutput(rule), nil
+}
+
+// Update シフトルール更新
+func (u *ShiftRuleUseCase) Update(ctx context.Context, input *UpdateShiftRuleInput) (*ShiftRuleOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	id, err := sharedDomain.ParseID(input.ID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "IDが不正です")
+	}
+
+	rule, err := u.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil {
+		return nil, sharedDomain.ErrNotFound
+	}
+
+	ruleType := domain.ShiftRuleType(input.RuleType)
+	config, err := normalizeRuleConfig(ruleType, input.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	rule.Name = input.Name
+	rule.Description = input.Description
+	rule.RuleType = ruleType
+	rule.Priority = input.Priority
+	rule.IsActive = input.IsActive
+	rule.Config = config
+	rule.UpdatedAt = time.Now()
+
+	if err := u.repo.Save(ctx, rule); err != nil {
+		u.logger.Error("シフトルール更新失敗", "error", err)
+		return nil, err
+	}
+
+	u.logger.Info("シフトルール更新完了", "shift_rule_id", rule.ID)
+	return ToShiftRuleOutput(rule), nil
+}
+
+// GetByID IDでシフトルール取得
+func (u *ShiftRuleUseCase) GetByID(ctx context.Context, id string) (*ShiftRuleOutput, error) {
+	ruleID, err := sharedDomain.ParseID(id)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "IDが不正です")
+	}
+
+	rule, err := u.repo.FindByID(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil {
+		return nil, sharedDomain.ErrNotFound
+	}
+
+	return ToShiftRuleOutput(rule), nil
+}
+
+// ListByOrganization 組織IDでシフトルール一覧取得
+func (u *ShiftRuleUseCase) ListByOrganization(ctx context.Context, orgID string) (*ShiftRuleListOutput, error) {
+	if orgID == "" {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDが必要です")
+	}
+
+	organizationID, err := sharedDomain.ParseID(orgID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDが不正です")
+	}
+
+	rules, err := u.repo.FindByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := make([]ShiftRuleOutput, len(rules))
+	for i, rule := range rules {
+		outputs[i] = *ToShiftRuleOutput(&rule)
+	}
+
+	return &ShiftRuleListOutput{
+		ShiftRules: outputs,
+		Total:      len(outputs),
+	}, nil
+}
+
+// Delete シフトルール削除
+func (u *ShiftRuleUseCase) Delete(ctx context.Context, id string) error {
+	ruleID, err := sharedDomain.ParseID(id)
+	if err != nil {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "IDが不正です")
+	}
+
+	rule, err := u.repo.FindByID(ctx, ruleID)
+	if err != nil {
+		return err
+	}
+	if rule == nil {
+		return sharedDomain.ErrNotFound
+	}
+
+	if err := u.repo.Delete(ctx, ruleID); err != nil {
+		u.logger.Error("シフトルール削除失敗", "error", err)
+		return err
+	}
+
+	u.logger.Info("シフトルール削除完了", "shift_rule_id", ruleID)
+	return nil
+}
+
+// normalizeRuleConfig ルール種別の型付き設定で検証し正規化したJSONを返す
+func normalizeRuleConfig(ruleType domain.ShiftRuleType, raw json.RawMessage) (string, error) {
+	cfg, err := domain.ParseRuleConfig(ruleType, string(raw))
+	if err != nil {
+		return "", err
+	}
+
+	normalized, err := json.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+	return string(normalized), nil
+}
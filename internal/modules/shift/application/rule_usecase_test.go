@@ -0,0 +1,263 @@
+// Package application シフトルールユースケーステスト
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"testing"
+
+	"shiftmaster/internal/modules/shift/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// モックシフトルールリポジトリ
+
+type mockShiftRuleRepository struct {
+	rules map[sharedDomain.ID]*domain.ShiftRule
+}
+
+func newMockShiftRuleRepository() *mockShiftRuleRepository {
+	return &mockShiftRuleRepository{
+		rules: make(map[sharedDomain.ID]*domain.ShiftRule),
+	}
+}
+
+func (m *mockShiftRuleRepository) FindByID(_ context.Context, id sharedDomain.ID) (*domain.ShiftRule, error) {
+	return m.rules[id], nil
+}
+
+func (m *mockShiftRuleRepository) FindAll(_ context.Context) ([]domain.ShiftRule, error) {
+	result := make([]domain.ShiftRule, 0, len(m.rules))
+	for _, rule := range m.rules {
+		result = append(result, *rule)
+	}
+	return result, nil
+}
+
+func (m *mockShiftRuleRepository) FindByOrganizationID(_ context.Context, orgID sharedDomain.ID) ([]domain.ShiftRule, error) {
+	var result []domain.ShiftRule
+	for _, rule := range m.rules {
+		if rule.OrganizationID == orgID {
+			result = append(result, *rule)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockShiftRuleRepository) FindActiveByOrganizationID(_ context.Context, orgID sharedDomain.ID) ([]domain.ShiftRule, error) {
+	var result []domain.ShiftRule
+	for _, rule := range m.rules {
+		if rule.OrganizationID == orgID && rule.IsActive {
+			result = append(result, *rule)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockShiftRuleRepository) Save(_ context.Context, rule *domain.ShiftRule) error {
+	m.rules[rule.ID] = rule
+	return nil
+}
+
+func (m *mockShiftRuleRepository) Delete(_ context.Context, id sharedDomain.ID) error {
+	delete(m.rules, id)
+	return nil
+}
+
+// テスト
+
+func TestShiftRuleUseCase_Create(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	orgID := sharedDomain.NewID().String()
+	inactive := false
+
+	tests := []struct {
+		name       string
+		input      *CreateShiftRuleInput
+		wantErr    bool
+		wantConfig string
+		wantActive bool
+	}{
+		{
+			name: "正常作成 設定は正規化される",
+			input: &CreateShiftRuleInput{
+				OrganizationID: orgID,
+				Name:           "勤務間インターバル",
+				RuleType:       "interval",
+				Config:         json.RawMessage(`{ "min_hours": 12 }`),
+			},
+			wantConfig: `{"min_hours":12}`,
+			wantActive: true,
+		},
+		{
+			name: "無効として作成",
+			input: &CreateShiftRuleInput{
+				OrganizationID: orgID,
+				Name:           "夜勤回数",
+				RuleType:       "night_limit",
+				IsActive:       &inactive,
+				Config:         json.RawMessage(`{"max_per_month":8}`),
+			},
+			wantConfig: `{"max_per_month":8}`,
+			wantActive: false,
+		},
+		{
+			name: "不正なルール種別",
+			input: &CreateShiftRuleInput{
+				OrganizationID: orgID,
+				Name:           "不明",
+				RuleType:       "unknown",
+				Config:         json.RawMessage(`{}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "種別に合わない設定項目",
+			input: &CreateShiftRuleInput{
+				OrganizationID: orgID,
+				Name:           "最小人数",
+				RuleType:       "min_staff",
+				Config:         json.RawMessage(`{"max_days":3}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "設定値の範囲外",
+			input: &CreateShiftRuleInput{
+				OrganizationID: orgID,
+				Name:           "連続勤務",
+				RuleType:       "consecutive",
+				Config:         json.RawMessage(`{"max_days":0}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "ルール名なし",
+			input: &CreateShiftRuleInput{
+				OrganizationID: orgID,
+				RuleType:       "interval",
+				Config:         json.RawMessage(`{"min_hours":11}`),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockShiftRuleRepository()
+			useCase := NewShiftRuleUseCase(repo, logger)
+
+			output, err := useCase.Create(context.Background(), tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("エラーが期待されました")
+				}
+				if len(repo.rules) != 0 {
+					t.Error("エラー時にルールが保存されています")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if string(output.Config) != tt.wantConfig {
+				t.Errorf("Config = %s, want %s", output.Config, tt.wantConfig)
+			}
+			if output.IsActive != tt.wantActive {
+				t.Errorf("IsActive = %v, want %v", output.IsActive, tt.wantActive)
+			}
+		})
+	}
+}
+
+func TestShiftRuleUseCase_Update(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	repo := newMockShiftRuleRepository()
+	useCase := NewShiftRuleUseCase(repo, logger)
+	ctx := context.Background()
+
+	created, err := useCase.Create(ctx, &CreateShiftRuleInput{
+		OrganizationID: sharedDomain.NewID().String(),
+		Name:           "連続勤務上限",
+		RuleType:       "consecutive",
+		Config:         json.RawMessage(`{"max_days":6}`),
+	})
+	if err != nil {
+		t.Fatalf("作成失敗: %v", err)
+	}
+
+	updated, err := useCase.Update(ctx, &UpdateShiftRuleInput{
+		ID:       created.ID,
+		Name:     "連続夜勤上限",
+		RuleType: "consecutive",
+		Priority: 10,
+		IsActive: false,
+		Config:   json.RawMessage(`{"max_days":2,"night_only":true}`),
+	})
+	if err != nil {
+		t.Fatalf("更新失敗: %v", err)
+	}
+	if updated.Name != "連続夜勤上限" || updated.Priority != 10 || updated.IsActive {
+		t.Errorf("更新内容が反映されていません: %+v", updated)
+	}
+
+	// 検証エラー時は保存済みの設定を変更しない
+	_, err = useCase.Update(ctx, &UpdateShiftRuleInput{
+		ID:       created.ID,
+		Name:     "連続夜勤上限",
+		RuleType: "consecutive",
+		Config:   json.RawMessage(`{"max_days":-1}`),
+	})
+	if err == nil {
+		t.Fatal("エラーが期待されました")
+	}
+	id, _ := sharedDomain.ParseID(created.ID)
+	if repo.rules[id].Config != `{"max_days":2,"night_only":true}` {
+		t.Errorf("Config = %s, 変更されていないことが期待されました", repo.rules[id].Config)
+	}
+
+	_, err = useCase.Update(ctx, &UpdateShiftRuleInput{
+		ID:       sharedDomain.NewID().String(),
+		Name:     "存在しない",
+		RuleType: "interval",
+		Config:   json.RawMessage(`{"min_hours":11}`),
+	})
+	if err != sharedDomain.ErrNotFound {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestShiftRuleUseCase_ListAndDelete(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	repo := newMockShiftRuleRepository()
+	useCase := NewShiftRuleUseCase(repo, logger)
+	ctx := context.Background()
+	orgID := sharedDomain.NewID().String()
+
+	created, err := useCase.Create(ctx, &CreateShiftRuleInput{
+		OrganizationID: orgID,
+		Name:           "夜勤最低人数",
+		RuleType:       "min_staff",
+		Config:         json.RawMessage(`{"min_count":2}`),
+	})
+	if err != nil {
+		t.Fatalf("作成失敗: %v", err)
+	}
+
+	list, err := useCase.ListByOrganization(ctx, orgID)
+	if err != nil {
+		t.Fatalf("一覧取得失敗: %v", err)
+	}
+	if list.Total != 1 {
+		t.Errorf("Total = %d, want 1", list.Total)
+	}
+
+	if err := useCase.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("削除失敗: %v", err)
+	}
+	if err := useCase.Delete(ctx, created.ID); err != sharedDomain.ErrNotFound {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
@@ -90,6 +90,13 @@ func (s *ShiftType) TotalMinutes() int {
 	return end - start
 }
 
+// TimeRange 勤務時間帯（申し送り時間を含まない予定の開始・終了時刻）
+func (s *ShiftType) TimeRange() domain.ShiftTimeRange {
+	start, _ := domain.NewTimeOfDay(s.StartTime.Hour(), s.StartTime.Minute())
+	end, _ := domain.NewTimeOfDay(s.EndTime.Hour(), s.EndTime.Minute())
+	return domain.NewShiftTimeRange(start, end)
+}
+
 // WorkingHours 実働時間 時間単位
 func (s *ShiftType) WorkingHours() float64 {
 	return float64(s.WorkingMinutes()) / 60.0
@@ -215,6 +222,8 @@ const (
 	RuleTypeInterval ShiftRuleType = "interval"
 	// RuleTypeSkillRequired 必須スキル
 	RuleTypeSkillRequired ShiftRuleType = "skill_required"
+	// RuleTypePositionRequired 必須職位
+	RuleTypePositionRequired ShiftRuleType = "position_required"
 	// RuleTypeNightLimit 夜勤回数制限
 	RuleTypeNightLimit ShiftRuleType = "night_limit"
 	// RuleTypeWeeklyHours 週間労働時間制限
@@ -241,6 +250,8 @@ func (t ShiftRuleType) Label() string {
 		return "シフト間隔"
 	case RuleTypeSkillRequired:
 		return "必須スキル"
+	case RuleTypePositionRequired:
+		return "必須職位"
 	case RuleTypeNightLimit:
 		return "夜勤回数制限"
 	case RuleTypeWeeklyHours:
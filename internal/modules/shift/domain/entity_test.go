@@ -891,6 +891,11 @@ func TestShiftRuleType_Label(t *testing.T) {
 			ruleType: RuleTypeSkillRequired,
 			expected: "必須スキル",
 		},
+		{
+			name:     "position_required",
+			ruleType: RuleTypePositionRequired,
+			expected: "必須職位",
+		},
 		{
 			name:     "night_limit",
 			ruleType: RuleTypeNightLimit,
@@ -940,6 +945,7 @@ func TestRuleTypeConstants(t *testing.T) {
 			RuleTypeConsecutive,
 			RuleTypeInterval,
 			RuleTypeSkillRequired,
+			RuleTypePositionRequired,
 			RuleTypeNightLimit,
 			RuleTypeWeeklyHours,
 			RuleTypeMonthlyHours,
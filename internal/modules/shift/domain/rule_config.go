@@ -0,0 +1,412 @@
+// Package domain シフトドメイン層
+package domain
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"shiftmaster/internal/shared/domain"
+)
+
+// RuleConfig ルール種別ごとの型付き設定
+type RuleConfig interface {
+	// Validate 設定値検証
+	Validate() error
+}
+
+// DayKind 配置人数ルールの対象日区分
+type DayKind string
+
+const (
+	// DayKindAll 全日
+	DayKindAll DayKind = ""
+	// DayKindWeekday 平日 土日祝以外
+	DayKindWeekday DayKind = "weekday"
+	// DayKindHoliday 休日 土日祝
+	DayKindHoliday DayKind = "holiday"
+)
+
+// String 文字列変換
+func (k DayKind) String() string {
+	return string(k)
+}
+
+// Label 表示ラベル
+func (k DayKind) Label() string {
+	switch k {
+	case DayKindAll:
+		return "全日"
+	case DayKindWeekday:
+		return "平日"
+	case DayKindHoliday:
+		return "土日祝"
+	default:
+		return "不明"
+	}
+}
+
+// IsValid 有効な対象日区分かチェック
+func (k DayKind) IsValid() bool {
+	switch k {
+	case DayKindAll, DayKindWeekday, DayKindHoliday:
+		return true
+	default:
+		return false
+	}
+}
+
+// Matches 指定日が対象日区分に該当するか
+func (k DayKind) Matches(date time.Time) bool {
+	switch k {
+	case DayKindWeekday:
+		return !domain.IsWeekendOrHoliday(date)
+	case DayKindHoliday:
+		return domain.IsWeekendOrHoliday(date)
+	default:
+		return true
+	}
+}
+
+// CoverageFilter 配置人数ルールの対象絞り込み
+type CoverageFilter struct {
+	// ShiftTypeID 対象シフト種別ID 空は全勤務シフト
+	ShiftTypeID string `json:"shift_type_id,omitempty"`
+	// DayKind 対象日区分 空は全日
+	DayKind DayKind `json:"day_kind,omitempty"`
+	// SkillID 人数に数えるスタッフの保有スキルID 空は全スタッフ
+	SkillID string `json:"skill_id,omitempty"`
+	// MinSkillLevel 保有スキルの最低レベル 0はレベル不問
+	MinSkillLevel int `json:"min_skill_level,omitempty"`
+	// JobTypeID 人数に数えるスタッフの職種ID 空は全職種
+	JobTypeID string `json:"job_type_id,omitempty"`
+}
+
+// HasStaffFilter スキル・職種による人数の絞り込みがあるか
+func (f *CoverageFilter) HasStaffFilter() bool {
+	return f.SkillID != "" || f.JobTypeID != ""
+}
+
+// validate 絞り込み条件検証
+func (f *CoverageFilter) validate() error {
+	if err := validateOptionalID(f.ShiftTypeID, "シフト種別ID"); err != nil {
+		return err
+	}
+	if !f.DayKind.IsValid() {
+		return domain.NewDomainError(domain.ErrCodeValidation, "対象日区分が不正です")
+	}
+	if err := validateOptionalID(f.SkillID, "スキルID"); err != nil {
+		return err
+	}
+	if f.MinSkillLevel < 0 || f.MinSkillLevel > 5 {
+		return domain.NewDomainError(domain.ErrCodeValidation, "最低スキルレベルは0から5の範囲で指定してください")
+	}
+	if f.MinSkillLevel > 0 && f.SkillID == "" {
+		return domain.NewDomainError(domain.ErrCodeValidation, "最低スキルレベルを指定する場合はスキルIDが必須です")
+	}
+	return validateOptionalID(f.JobTypeID, "職種ID")
+}
+
+// MinStaffConfig 最小配置人数設定
+type MinStaffConfig struct {
+	CoverageFilter
+	// MinCount 最小人数
+	MinCount int `json:"min_count"`
+}
+
+// Validate 設定値検証
+func (c *MinStaffConfig) Validate() error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+	if c.MinCount <= 0 {
+		return domain.NewDomainError(domain.ErrCodeValidation, "最小人数は1以上で指定してください")
+	}
+	return nil
+}
+
+// MaxStaffConfig 最大配置人数設定
+type MaxStaffConfig struct {
+	CoverageFilter
+	// MaxCount 最大人数
+	MaxCount int `json:"max_count"`
+}
+
+// Validate 設定値検証
+func (c *MaxStaffConfig) Validate() error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+	if c.MaxCount <= 0 {
+		return domain.NewDomainError(domain.ErrCodeValidation, "最大人数は1以上で指定してください")
+	}
+	return nil
+}
+
+// ConsecutiveConfig 連続勤務制限設定
+type ConsecutiveConfig struct {
+	// MaxDays 最大連続日数
+	MaxDays int `json:"max_days"`
+	// NightOnly 夜勤のみを対象
+	NightOnly bool `json:"night_only,omitempty"`
+}
+
+// Validate 設定値検証
+func (c *ConsecutiveConfig) Validate() error {
+	if c.MaxDays <= 0 || c.MaxDays > 31 {
+		return domain.NewDomainError(domain.ErrCodeValidation, "最大連続日数は1から31の範囲で指定してください")
+	}
+	return nil
+}
+
+// IntervalConfig シフト間隔設定
+type IntervalConfig struct {
+	// MinHours 最小休息時間
+	MinHours float64 `json:"min_hours"`
+}
+
+// Validate 設定値検証
+func (c *IntervalConfig) Validate() error {
+	if c.MinHours <= 0 || c.MinHours > 48 {
+		return domain.NewDomainError(domain.ErrCodeValidation, "最小休息時間は0より大きく48以下で指定してください")
+	}
+	return nil
+}
+
+// SkillRequiredConfig 必須スキル設定
+type SkillRequiredConfig struct {
+	// ShiftTypeID 対象シフト種別ID 空は全勤務シフト
+	ShiftTypeID string `json:"shift_type_id,omitempty"`
+	// DayKind 対象日区分 空は全日
+	DayKind DayKind `json:"day_kind,omitempty"`
+	// SkillID 必須スキルID
+	SkillID string `json:"skill_id"`
+	// MinLevel 最低スキルレベル
+	MinLevel int `json:"min_level,omitempty"`
+	// MinCount 必要人数
+	MinCount int `json:"min_count"`
+}
+
+// Validate 設定値検証
+func (c *SkillRequiredConfig) Validate() error {
+	if err := validateOptionalID(c.ShiftTypeID, "シフト種別ID"); err != nil {
+		return err
+	}
+	if !c.DayKind.IsValid() {
+		return domain.NewDomainError(domain.ErrCodeValidation, "対象日区分が不正です")
+	}
+	if c.SkillID == "" {
+		return domain.NewDomainError(domain.ErrCodeValidation, "スキルIDは必須です")
+	}
+	if err := validateOptionalID(c.SkillID, "スキルID"); err != nil {
+		return err
+	}
+	if c.MinLevel < 0 || c.MinLevel > 5 {
+		return domain.NewDomainError(domain.ErrCodeValidation, "最低スキルレベルは0から5の範囲で指定してください")
+	}
+	if c.MinCount <= 0 {
+		return domain.NewDomainError(domain.ErrCodeValidation, "必要人数は1以上で指定してください")
+	}
+	return nil
+}
+
+// PositionRequiredConfig 必須職位設定
+type PositionRequiredConfig struct {
+	// ShiftTypeID 対象シフト種別ID 空は全勤務シフト
+	ShiftTypeID string `json:"shift_type_id,omitempty"`
+	// DayKind 対象日区分 空は全日
+	DayKind DayKind `json:"day_kind,omitempty"`
+	// PositionID 必須職位ID 空の場合はLevelで判定
+	PositionID string `json:"position_id,omitempty"`
+	// Level 職位レベル このレベルと同等以上（数値が以下）の職位を数える
+	Level *int `json:"level,omitempty"`
+	// MinCount 必要人数
+	MinCount int `json:"min_count"`
+}
+
+// Validate 設定値検証
+func (c *PositionRequiredConfig) Validate() error {
+	if err := validateOptionalID(c.ShiftTypeID, "シフト種別ID"); err != nil {
+		return err
+	}
+	if !c.DayKind.IsValid() {
+		return domain.NewDomainError(domain.ErrCodeValidation, "対象日区分が不正です")
+	}
+	if c.PositionID == "" && c.Level == nil {
+		return domain.NewDomainError(domain.ErrCodeValidation, "職位IDまたは職位レベルを指定してください")
+	}
+	if err := validateOptionalID(c.PositionID, "職位ID"); err != nil {
+		return err
+	}
+	if c.Level != nil && *c.Level < 0 {
+		return domain.NewDomainError(domain.ErrCodeValidation, "職位レベルは0以上で指定してください")
+	}
+	if c.MinCount <= 0 {
+		return domain.NewDomainError(domain.ErrCodeValidation, "必要人数は1以上で指定してください")
+	}
+	return nil
+}
+
+// NightLimitConfig 夜勤回数制限設定
+type NightLimitConfig struct {
+	// MaxPerMonth 月間最大夜勤回数
+	MaxPerMonth int `json:"max_per_month"`
+}
+
+// Validate 設定値検証
+func (c *NightLimitConfig) Validate() error {
+	if c.MaxPerMonth <= 0 || c.MaxPerMonth > 31 {
+		return domain.NewDomainError(domain.ErrCodeValidation, "月間最大夜勤回数は1から31の範囲で指定してください")
+	}
+	return nil
+}
+
+// WorkingHoursConfig 労働時間制限設定 週間・月間共通
+type WorkingHoursConfig struct {
+	// EmploymentType 対象の雇用形態 空は全スタッフ 例: part_time
+	EmploymentType string `json:"employment_type,omitempty"`
+	// MaxHours 上限時間 0は上限なし
+	MaxHours float64 `json:"max_hours,omitempty"`
+	// MinHours 下限時間 0は下限なし
+	MinHours float64 `json:"min_hours,omitempty"`
+	// ExcludeHandover 申し送り時間を労働時間に含めない
+	ExcludeHandover bool `json:"exclude_handover,omitempty"`
+}
+
+// AppliesTo 指定の雇用形態のスタッフに適用されるか
+func (c *WorkingHoursConfig) AppliesTo(employmentType string) bool {
+	return c.EmploymentType == "" || c.EmploymentType == employmentType
+}
+
+// ShiftMinutes シフトの労働時間 分単位
+func (c *WorkingHoursConfig) ShiftMinutes(shiftType *ShiftType) int {
+	if c.ExcludeHandover {
+		return shiftType.EffectiveWorkingMinutes()
+	}
+	return shiftType.WorkingMinutes()
+}
+
+// Validate 設定値検証
+func (c *WorkingHoursConfig) Validate() error {
+	if c.MaxHours < 0 || c.MinHours < 0 {
+		return domain.NewDomainError(domain.ErrCodeValidation, "労働時間は0以上で指定してください")
+	}
+	if c.MaxHours == 0 && c.MinHours == 0 {
+		return domain.NewDomainError(domain.ErrCodeValidation, "上限時間または下限時間を指定してください")
+	}
+	if c.MaxHours > 0 && c.MinHours > c.MaxHours {
+		return domain.NewDomainError(domain.ErrCodeValidation, "下限時間が上限時間を超えています")
+	}
+	return nil
+}
+
+// IsValid 有効なルール種別かチェック
+func (t ShiftRuleType) IsValid() bool {
+	switch t {
+	case RuleTypeMinStaff, RuleTypeMaxStaff, RuleTypeConsecutive, RuleTypeInterval,
+		RuleTypeSkillRequired, RuleTypePositionRequired, RuleTypeNightLimit, RuleTypeWeeklyHours, RuleTypeMonthlyHours:
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseRuleConfig ルール種別に応じた型付き設定へ変換し検証
+func ParseRuleConfig(ruleType ShiftRuleType, config string) (RuleConfig, error) {
+	var cfg RuleConfig
+	switch ruleType {
+	case RuleTypeMinStaff:
+		cfg = &MinStaffConfig{}
+	case RuleTypeMaxStaff:
+		cfg = &MaxStaffConfig{}
+	case RuleTypeConsecutive:
+		cfg = &ConsecutiveConfig{}
+	case RuleTypeInterval:
+		cfg = &IntervalConfig{}
+	case RuleTypeSkillRequired:
+		cfg = &SkillRequiredConfig{}
+	case RuleTypePositionRequired:
+		cfg = &PositionRequiredConfig{}
+	case RuleTypeNightLimit:
+		cfg = &NightLimitConfig{}
+	case RuleTypeWeeklyHours, RuleTypeMonthlyHours:
+		cfg = &WorkingHoursConfig{}
+	default:
+		return nil, domain.NewDomainError(domain.ErrCodeValidation, "ルール種別が不正です")
+	}
+
+	if strings.TrimSpace(config) == "" {
+		config = "{}"
+	}
+	decoder := json.NewDecoder(strings.NewReader(config))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(cfg); err != nil {
+		return nil, domain.NewDomainError(domain.ErrCodeValidation, "ルール設定の形式が不正です: "+err.Error())
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// ParseConfig ルール設定を型付き設定へ変換
+func (r *ShiftRule) ParseConfig() (RuleConfig, error) {
+	return ParseRuleConfig(r.RuleType, r.Config)
+}
+
+// DefaultShiftRules 組織にルールが未設定の場合に適用する既定ルール
+func DefaultShiftRules() []ShiftRule {
+	return []ShiftRule{
+		{Name: "連続勤務上限", RuleType: RuleTypeConsecutive, IsActive: true, Config: `{"max_days":6}`},
+		{Name: "連続夜勤上限", RuleType: RuleTypeConsecutive, IsActive: true, Config: `{"max_days":2,"night_only":true}`},
+		{Name: "勤務間インターバル", RuleType: RuleTypeInterval, IsActive: true, Config: `{"min_hours":11}`},
+		{Name: "月間夜勤回数上限", RuleType: RuleTypeNightLimit, IsActive: true, Config: `{"max_per_month":8}`},
+	}
+}
+
+// WithDefaultShiftRules 未設定の種別を既定ルールで補完したルール一覧 設定が不正なルールは未設定として扱う
+func WithDefaultShiftRules(organizationID domain.ID, rules []ShiftRule) []ShiftRule {
+	result := make([]ShiftRule, len(rules), len(rules)+len(DefaultShiftRules()))
+	copy(result, rules)
+
+	configured := make(map[string]bool)
+	for i := range rules {
+		cfg, err := rules[i].ParseConfig()
+		if err != nil {
+			continue
+		}
+		configured[ruleKind(rules[i].RuleType, cfg)] = true
+	}
+
+	for _, def := range DefaultShiftRules() {
+		cfg, err := def.ParseConfig()
+		if err != nil {
+			continue
+		}
+		if !configured[ruleKind(def.RuleType, cfg)] {
+			def.OrganizationID = organizationID
+			result = append(result, def)
+		}
+	}
+	return result
+}
+
+// ruleKind 既定ルール補完用の種別キー 連続勤務は夜勤限定かどうかで区別
+func ruleKind(ruleType ShiftRuleType, cfg RuleConfig) string {
+	if c, ok := cfg.(*ConsecutiveConfig); ok && c.NightOnly {
+		return ruleType.String() + "_night"
+	}
+	return ruleType.String()
+}
+
+// validateOptionalID 任意のID文字列を検証
+func validateOptionalID(value, label string) error {
+	if value == "" {
+		return nil
+	}
+	if _, err := domain.ParseID(value); err != nil {
+		return domain.NewDomainError(domain.ErrCodeValidation, label+"が不正です")
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+// Package domain シフトルール設定テスト
+package domain
+
+import (
+	"testing"
+	"time"
+
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+func TestParseRuleConfig(t *testing.T) {
+	shiftTypeID := sharedDomain.NewID().String()
+
+	tests := []struct {
+		name     string
+		ruleType ShiftRuleType
+		config   string
+		wantErr  bool
+	}{
+		{"最小配置人数", RuleTypeMinStaff, `{"shift_type_id":"` + shiftTypeID + `","min_count":2}`, false},
+		{"最小配置人数 人数0", RuleTypeMinStaff, `{"min_count":0}`, true},
+		{"最小配置人数 不正なシフト種別ID", RuleTypeMinStaff, `{"shift_type_id":"x","min_count":1}`, true},
+		{"最小配置人数 休日スキル条件", RuleTypeMinStaff, `{"day_kind":"holiday","skill_id":"` + shiftTypeID + `","min_skill_level":2,"min_count":1}`, false},
+		{"最小配置人数 職種条件", RuleTypeMinStaff, `{"job_type_id":"` + shiftTypeID + `","min_count":2}`, false},
+		{"最小配置人数 不正な対象日区分", RuleTypeMinStaff, `{"day_kind":"sunday","min_count":1}`, true},
+		{"最小配置人数 スキルなしのレベル指定", RuleTypeMinStaff, `{"min_skill_level":2,"min_count":1}`, true},
+		{"最大配置人数", RuleTypeMaxStaff, `{"max_count":5}`, false},
+		{"最大配置人数 平日", RuleTypeMaxStaff, `{"day_kind":"weekday","max_count":5}`, false},
+		{"連続勤務", RuleTypeConsecutive, `{"max_days":5,"night_only":true}`, false},
+		{"連続勤務 範囲外", RuleTypeConsecutive, `{"max_days":40}`, true},
+		{"シフト間隔", RuleTypeInterval, `{"min_hours":11}`, false},
+		{"必須スキル スキル未指定", RuleTypeSkillRequired, `{"min_count":1}`, true},
+		{"必須スキル 夜勤レベル3以上", RuleTypeSkillRequired, `{"shift_type_id":"` + shiftTypeID + `","skill_id":"` + shiftTypeID + `","min_level":3,"min_count":1}`, false},
+		{"必須職位 レベル指定", RuleTypePositionRequired, `{"level":2,"min_count":1}`, false},
+		{"必須職位 最上位レベル", RuleTypePositionRequired, `{"level":0,"min_count":1}`, false},
+		{"必須職位 職位指定", RuleTypePositionRequired, `{"position_id":"` + shiftTypeID + `","min_count":1}`, false},
+		{"必須職位 条件なし", RuleTypePositionRequired, `{"min_count":1}`, true},
+		{"必須職位 負のレベル", RuleTypePositionRequired, `{"level":-1,"min_count":1}`, true},
+		{"夜勤回数", RuleTypeNightLimit, `{"max_per_month":8}`, false},
+		{"週間労働時間", RuleTypeWeeklyHours, `{"max_hours":40}`, false},
+		{"週間労働時間 雇用形態指定", RuleTypeWeeklyHours, `{"employment_type":"part_time","max_hours":30,"exclude_handover":true}`, false},
+		{"月間労働時間 下限が上限超過", RuleTypeMonthlyHours, `{"max_hours":100,"min_hours":120}`, true},
+		{"月間労働時間 未指定", RuleTypeMonthlyHours, ``, true},
+		{"未知の項目", RuleTypeInterval, `{"min_hours":11,"unknown":1}`, true},
+		{"不正なJSON", RuleTypeNightLimit, `{`, true},
+		{"不正なルール種別", ShiftRuleType("unknown"), `{}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRuleConfig(tt.ruleType, tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseRuleConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDayKind_Matches(t *testing.T) {
+	friday := time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)
+	saturday := time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)
+	equinox := time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		kind DayKind
+		date time.Time
+		want bool
+	}{
+		{"全日 平日", DayKindAll, friday, true},
+		{"全日 土曜", DayKindAll, saturday, true},
+		{"平日 平日", DayKindWeekday, friday, true},
+		{"平日 土曜", DayKindWeekday, saturday, false},
+		{"平日 祝日", DayKindWeekday, equinox, false},
+		{"休日 土曜", DayKindHoliday, saturday, true},
+		{"休日 祝日", DayKindHoliday, equinox, true},
+		{"休日 平日", DayKindHoliday, friday, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.kind.Matches(tt.date); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShiftRule_ParseConfig(t *testing.T) {
+	rule := &ShiftRule{RuleType: RuleTypeConsecutive, Config: `{"max_days":4}`}
+
+	cfg, err := rule.ParseConfig()
+	if err != nil {
+		t.Fatalf("ParseConfig() error = %v", err)
+	}
+	consecutive, ok := cfg.(*ConsecutiveConfig)
+	if !ok {
+		t.Fatalf("ParseConfig() type = %T, want *ConsecutiveConfig", cfg)
+	}
+	if consecutive.MaxDays != 4 || consecutive.NightOnly {
+		t.Errorf("ParseConfig() = %+v", consecutive)
+	}
+}
+
+func TestDefaultShiftRules(t *testing.T) {
+	for _, rule := range DefaultShiftRules() {
+		if _, err := rule.ParseConfig(); err != nil {
+			t.Errorf("既定ルール %s の設定が不正です: %v", rule.Name, err)
+		}
+	}
+}
+
+func TestWithDefaultShiftRules(t *testing.T) {
+	orgID := sharedDomain.NewID()
+	rules := []ShiftRule{
+		{Name: "連続夜勤3日", RuleType: RuleTypeConsecutive, IsActive: true, Config: `{"max_days":3,"night_only":true}`},
+		{Name: "不正な夜勤回数", RuleType: RuleTypeNightLimit, IsActive: true, Config: `{`},
+	}
+
+	got := WithDefaultShiftRules(orgID, rules)
+
+	names := make(map[string]bool)
+	for _, rule := range got[len(rules):] {
+		names[rule.Name] = true
+		if rule.OrganizationID != orgID {
+			t.Errorf("既定ルール %s に組織IDが設定されていません", rule.Name)
+		}
+	}
+	if len(got) != 5 {
+		t.Errorf("len = %d, want 5", len(got))
+	}
+	if names["連続夜勤上限"] {
+		t.Error("設定済みの連続夜勤ルールが既定ルールで補完されています")
+	}
+	for _, name := range []string{"連続勤務上限", "勤務間インターバル", "月間夜勤回数上限"} {
+		if !names[name] {
+			t.Errorf("既定ルール %s が補完されていません", name)
+		}
+	}
+}
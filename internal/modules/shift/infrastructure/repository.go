@@ -4,7 +4,9 @@ package infrastructure
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"strings"
 	"time"
 
 	"shiftmaster/internal/modules/shift/domain"
@@ -360,3 +362,163 @@ func (r *PostgresShiftPatternRepository) Delete(ctx context.Context, id sharedDo
 	_, err := r.db.NewDelete().Model((*ShiftPatternModel)(nil)).Where("id = ?", id).Exec(ctx)
 	return err
 }
+
+// ShiftRuleModel シフトルールDBモデル
+type ShiftRuleModel struct {
+	bun.BaseModel `bun:"table:shift_rules"`
+
+	ID             uuid.UUID       `bun:"id,pk,type:uuid"`
+	OrganizationID uuid.UUID       `bun:"organization_id,type:uuid,notnull"`
+	Name           string          `bun:"name,notnull"`
+	Description    string          `bun:"description"`
+	RuleType       string          `bun:"rule_type,notnull"`
+	Priority       int             `bun:"priority,notnull"`
+	IsActive       bool            `bun:"is_active,notnull"`
+	Config         json.RawMessage `bun:"config,type:jsonb,notnull"`
+	CreatedAt      time.Time       `bun:"created_at,notnull"`
+	UpdatedAt      time.Time       `bun:"updated_at,notnull"`
+}
+
+// ToDomain ShiftRuleModel DBモデルからドメインエンティティへ変換
+func (m *ShiftRuleModel) ToDomain() *domain.ShiftRule {
+	return &domain.ShiftRule{
+		ID:             m.ID,
+		OrganizationID: m.OrganizationID,
+		Name:           m.Name,
+		Description:    m.Description,
+		RuleType:       domain.ShiftRuleType(m.RuleType),
+		Priority:       m.Priority,
+		IsActive:       m.IsActive,
+		Config:         string(m.Config),
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
+
+// FromDomain ShiftRuleModel ドメインエンティティからDBモデルへ変換
+func (m *ShiftRuleModel) FromDomain(rule *domain.ShiftRule) {
+	m.ID = rule.ID
+	m.OrganizationID = rule.OrganizationID
+	m.Name = rule.Name
+	m.Description = rule.Description
+	m.RuleType = rule.RuleType.String()
+	m.Priority = rule.Priority
+	m.IsActive = rule.IsActive
+	// 空設定はJSONB列に保存できないため空オブジェクトとする
+	config := strings.TrimSpace(rule.Config)
+	if config == "" {
+		config = "{}"
+	}
+	m.Config = json.RawMessage(config)
+	m.CreatedAt = rule.CreatedAt
+	m.UpdatedAt = rule.UpdatedAt
+}
+
+// PostgresShiftRuleRepository PostgreSQLシフトルールリポジトリ
+type PostgresShiftRuleRepository struct {
+	db *bun.DB
+}
+
+// NewPostgresShiftRuleRepository リポジトリ生成
+func NewPostgresShiftRuleRepository(db *bun.DB) *PostgresShiftRuleRepository {
+	return &PostgresShiftRuleRepository{db: db}
+}
+
+// FindByID IDで検索
+func (r *PostgresShiftRuleRepository) FindByID(ctx context.Context, id sharedDomain.ID) (*domain.ShiftRule, error) {
+	model := &ShiftRuleModel{}
+	err := r.db.NewSelect().Model(model).Where("id = ?", id).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.ToDomain(), nil
+}
+
+// FindAll 全件取得
+func (r *PostgresShiftRuleRepository) FindAll(ctx context.Context) ([]domain.ShiftRule, error) {
+	var models []ShiftRuleModel
+	err := r.db.NewSelect().
+		Model(&models).
+		Order("priority DESC", "name ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]domain.ShiftRule, len(models))
+	for i, m := range models {
+		rules[i] = *m.ToDomain()
+	}
+
+	return rules, nil
+}
+
+// FindByOrganizationID 組織IDで検索
+func (r *PostgresShiftRuleRepository) FindByOrganizationID(ctx context.Context, organizationID sharedDomain.ID) ([]domain.ShiftRule, error) {
+	var models []ShiftRuleModel
+	err := r.db.NewSelect().
+		Model(&models).
+		Where("organization_id = ?", organizationID).
+		Order("priority DESC", "name ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]domain.ShiftRule, len(models))
+	for i, m := range models {
+		rules[i] = *m.ToDomain()
+	}
+
+	return rules, nil
+}
+
+// FindActiveByOrganizationID 有効ルールのみ取得
+func (r *PostgresShiftRuleRepository) FindActiveByOrganizationID(ctx context.Context, organizationID sharedDomain.ID) ([]domain.ShiftRule, error) {
+	var models []ShiftRuleModel
+	err := r.db.NewSelect().
+		Model(&models).
+		Where("organization_id = ?", organizationID).
+		Where("is_active = ?", true).
+		Order("priority DESC", "name ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]domain.ShiftRule, len(models))
+	for i, m := range models {
+		rules[i] = *m.ToDomain()
+	}
+
+	return rules, nil
+}
+
+// Save 保存
+func (r *PostgresShiftRuleRepository) Save(ctx context.Context, rule *domain.ShiftRule) error {
+	model := &ShiftRuleModel{}
+	model.FromDomain(rule)
+
+	_, err := r.db.NewInsert().
+		Model(model).
+		On("CONFLICT (id) DO UPDATE").
+		Set("name = EXCLUDED.name").
+		Set("description = EXCLUDED.description").
+		Set("rule_type = EXCLUDED.rule_type").
+		Set("priority = EXCLUDED.priority").
+		Set("is_active = EXCLUDED.is_active").
+		Set("config = EXCLUDED.config").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+
+	return err
+}
+
+// Delete 削除
+func (r *PostgresShiftRuleRepository) Delete(ctx context.Context, id sharedDomain.ID) error {
+	_, err := r.db.NewDelete().Model((*ShiftRuleModel)(nil)).Where("id = ?", id).Exec(ctx)
+	return err
+}
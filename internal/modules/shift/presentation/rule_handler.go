@@ -0,0 +1,340 @@
+// Package presentation シフトプレゼンテーション層
+package presentation
+
+import (
+	"encoding/json"
+	"errors"
+	"html"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"shiftmaster/internal/modules/shift/application"
+	sharedDomain "shiftmaster/internal/shared/domain"
+	"shiftmaster/internal/web"
+)
+
+// ShiftRuleHandler シフトルールHTTPハンドラー
+type ShiftRuleHandler struct {
+	useCase   *application.ShiftRuleUseCase
+	templates *web.TemplateEngine
+	logger    *slog.Logger
+}
+
+// NewShiftRuleHandler ハンドラー生成
+func NewShiftRuleHandler(
+	useCase *application.ShiftRuleUseCase,
+	templates *web.TemplateEngine,
+	logger *slog.Logger,
+) *ShiftRuleHandler {
+	return &ShiftRuleHandler{
+		useCase:   useCase,
+		templates: templates,
+		logger:    logger,
+	}
+}
+
+// getOrganizationID コンテキストから組織IDを取得
+func (h *ShiftRuleHandler) getOrganizationID(r *http.Request) string {
+	claims := web.GetClaimsFromContext(r.Context())
+	if claims != nil && claims.OrganizationID != nil {
+		return claims.OrganizationID.String()
+	}
+	return ""
+}
+
+// List シフトルール一覧ページ
+func (h *ShiftRuleHandler) List(w http.ResponseWriter, r *http.Request) {
+	orgID := h.getOrganizationID(r)
+	if orgID == "" {
+		data := map[string]any{
+			"Title":            "シフトルール一覧",
+			"ShiftRules":       []any{},
+			"Total":            0,
+			"NoOrgSelected":    true,
+			"NoOrgSelectedMsg": "組織を選択してください",
+		}
+		if err := h.templates.Render(w, "pages/shifts/rules/list.html", data); err != nil {
+			h.logger.Error("テンプレートレンダリング失敗", "error", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	result, err := h.useCase.ListByOrganization(r.Context(), orgID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	data := map[string]any{
+		"Title":      "シフトルール一覧",
+		"ShiftRules": result.ShiftRules,
+		"Total":      result.Total,
+	}
+
+	if err := h.templates.Render(w, "pages/shifts/rules/list.html", data); err != nil {
+		h.logger.Error("テンプレートレンダリング失敗", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// New 新規作成フォーム
+func (h *ShiftRuleHandler) New(w http.ResponseWriter, r *http.Request) {
+	data := map[string]any{
+		"Title":           "シフトルール追加",
+		"RuleTypeOptions": application.GetRuleTypeOptions(),
+	}
+
+	if err := h.templates.Render(w, "pages/shifts/rules/form.html", data); err != nil {
+		h.logger.Error("テンプレートレンダリング失敗", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// Edit 編集フォーム
+func (h *ShiftRuleHandler) Edit(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	rule, err := h.useCase.GetByID(r.Context(), id)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	data := map[string]any{
+		"Title":           "シフトルール編集",
+		"ShiftRule":       rule,
+		"RuleTypeOptions": application.GetRuleTypeOptions(),
+	}
+
+	if err := h.templates.Render(w, "pages/shifts/rules/form.html", data); err != nil {
+		h.logger.Error("テンプレートレンダリング失敗", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// Create シフトルール作成
+func (h *ShiftRuleHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	priority, _ := strconv.Atoi(r.FormValue("priority"))
+	isActive := isChecked(r.FormValue("is_active"))
+
+	input := &application.CreateShiftRuleInput{
+		OrganizationID: h.getOrganizationID(r),
+		Name:           r.FormValue("name"),
+		Description:    r.FormValue("description"),
+		RuleType:       r.FormValue("rule_type"),
+		Priority:       priority,
+		IsActive:       &isActive,
+		Config:         json.RawMessage(r.FormValue("config")),
+	}
+
+	if _, err := h.useCase.Create(r.Context(), input); err != nil {
+		h.handleFormError(w, r, err)
+		return
+	}
+
+	h.redirectToList(w, r)
+}
+
+// Update シフトルール更新
+func (h *ShiftRuleHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	priority, _ := strconv.Atoi(r.FormValue("priority"))
+
+	input := &application.UpdateShiftRuleInput{
+		ID:          id,
+		Name:        r.FormValue("name"),
+		Description: r.FormValue("description"),
+		RuleType:    r.FormValue("rule_type"),
+		Priority:    priority,
+		IsActive:    isChecked(r.FormValue("is_active")),
+		Config:      json.RawMessage(r.FormValue("config")),
+	}
+
+	if _, err := h.useCase.Update(r.Context(), input); err != nil {
+		h.handleFormError(w, r, err)
+		return
+	}
+
+	h.redirectToList(w, r)
+}
+
+// Delete シフトルール削除
+func (h *ShiftRuleHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	if err := h.useCase.Delete(r.Context(), id); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	if r.Header.Get("HX-Request") == "true" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	http.Redirect(w, r, "/shifts/rules", http.StatusSeeOther)
+}
+
+// ListJSON シフトルール一覧JSON
+func (h *ShiftRuleHandler) ListJSON(w http.ResponseWriter, r *http.Request) {
+	result, err := h.useCase.ListByOrganization(r.Context(), h.getOrganizationID(r))
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, result)
+}
+
+// ShowJSON シフトルール詳細JSON
+func (h *ShiftRuleHandler) ShowJSON(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	rule, err := h.useCase.GetByID(r.Context(), id)
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, rule)
+}
+
+// CreateJSON シフトルール作成JSON
+func (h *ShiftRuleHandler) CreateJSON(w http.ResponseWriter, r *http.Request) {
+	var input application.CreateShiftRuleInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "リクエストボディが不正です"})
+		return
+	}
+	// 組織は認証情報から決定する
+	input.OrganizationID = h.getOrganizationID(r)
+
+	rule, err := h.useCase.Create(r.Context(), &input)
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, rule)
+}
+
+// UpdateJSON シフトルール更新JSON
+func (h *ShiftRuleHandler) UpdateJSON(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	var input application.UpdateShiftRuleInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "リクエストボディが不正です"})
+		return
+	}
+	input.ID = id
+
+	rule, err := h.useCase.Update(r.Context(), &input)
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, rule)
+}
+
+// DeleteJSON シフトルール削除JSON
+func (h *ShiftRuleHandler) DeleteJSON(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	if err := h.useCase.Delete(r.Context(), id); err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// redirectToList 一覧ページへリダイレクト HTMX対応
+func (h *ShiftRuleHandler) redirectToList(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("HX-Request") == "true" {
+		w.Header().Set("HX-Redirect", "/shifts/rules")
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	http.Redirect(w, r, "/shifts/rules", http.StatusSeeOther)
+}
+
+// handleFormError フォーム送信エラーハンドリング 検証エラーはフォーム上に表示
+func (h *ShiftRuleHandler) handleFormError(w http.ResponseWriter, r *http.Request, err error) {
+	var domainErr *sharedDomain.DomainError
+	if r.Header.Get("HX-Request") == "true" && errors.As(err, &domainErr) && domainErr.Code == sharedDomain.ErrCodeValidation {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`<p class="text-sm text-red-400">` + html.EscapeString(domainErr.Message) + `</p>`))
+		return
+	}
+
+	h.handleError(w, err)
+}
+
+// handleError エラーハンドリング
+func (h *ShiftRuleHandler) handleError(w http.ResponseWriter, err error) {
+	var domainErr *sharedDomain.DomainError
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code {
+		case sharedDomain.ErrCodeNotFound:
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		case sharedDomain.ErrCodeValidation:
+			http.Error(w, domainErr.Message, http.StatusBadRequest)
+			return
+		}
+	}
+
+	h.logger.Error("ハンドラーエラー", "error", err)
+	http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+}
+
+// handleJSONError JSONエラーハンドリング
+func (h *ShiftRuleHandler) handleJSONError(w http.ResponseWriter, err error) {
+	var domainErr *sharedDomain.DomainError
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code {
+		case sharedDomain.ErrCodeNotFound:
+			h.writeJSON(w, http.StatusNotFound, map[string]string{"error": domainErr.Message})
+			return
+		case sharedDomain.ErrCodeValidation:
+			h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": domainErr.Message})
+			return
+		}
+	}
+
+	h.logger.Error("ハンドラーエラー", "error", err)
+	h.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "内部エラーが発生しました"})
+}
+
+// writeJSON JSONレスポンス書き込み
+func (h *ShiftRuleHandler) writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		h.logger.Error("JSONエンコード失敗", "error", err)
+	}
+}
+
+// isChecked チェックボックスの送信値を判定
+func isChecked(value string) bool {
+	value = strings.ToLower(value)
+	return value == "true" || value == "on"
+}
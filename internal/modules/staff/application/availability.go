@@ -0,0 +1,371 @@
+// Package application スタッフアプリケーション層
+package application
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"shiftmaster/internal/modules/staff/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+// availabilityWeekdays 勤務可能条件の表示順 月曜日から
+var availabilityWeekdays = []time.Weekday{
+	time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday,
+}
+
+// StaffAvailabilityUseCase 勤務可能条件ユースケース
+// 同じ組織なら誰でも参照でき、編集は管理者かスタッフ本人（メールアドレスが一致するユーザー）に限る
+type StaffAvailabilityUseCase struct {
+	availabilityRepo domain.StaffAvailabilityRepository
+	staffRepo        domain.StaffRepository
+	teamRepo         domain.TeamRepository
+	deptRepo         domain.DepartmentRepository
+	shiftTypeFinder  domain.ShiftTypeOptionFinder
+	logger           *slog.Logger
+}
+
+// NewStaffAvailabilityUseCase 勤務可能条件ユースケース生成
+func NewStaffAvailabilityUseCase(
+	availabilityRepo domain.StaffAvailabilityRepository,
+	staffRepo domain.StaffRepository,
+	teamRepo domain.TeamRepository,
+	deptRepo domain.DepartmentRepository,
+	shiftTypeFinder domain.ShiftTypeOptionFinder,
+	logger *slog.Logger,
+) *StaffAvailabilityUseCase {
+	return &StaffAvailabilityUseCase{
+		availabilityRepo: availabilityRepo,
+		staffRepo:        staffRepo,
+		teamRepo:         teamRepo,
+		deptRepo:         deptRepo,
+		shiftTypeFinder:  shiftTypeFinder,
+		logger:           logger,
+	}
+}
+
+// Get 勤務可能条件取得 未登録は制限なしを返す
+func (u *StaffAvailabilityUseCase) Get(ctx context.Context, orgID, staffID string, actor AvailabilityActor) (*StaffAvailabilityOutput, error) {
+	staff, availability, err := u.load(ctx, orgID, staffID)
+	if err != nil {
+		return nil, err
+	}
+	editable, err := u.canEdit(ctx, orgID, staff, actor)
+	if err != nil {
+		return nil, err
+	}
+	out, err := u.output(ctx, orgID, staff, availability)
+	if err != nil {
+		return nil, err
+	}
+	out.Editable = editable
+	return out, nil
+}
+
+// Update 曜日ごとの条件・シフト種別・週の上限勤務日数を更新
+func (u *StaffAvailabilityUseCase) Update(ctx context.Context, input *UpdateStaffAvailabilityInput) (*StaffAvailabilityOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+	staff, availability, err := u.loadEditable(ctx, input.OrganizationID, input.StaffID, input.Actor)
+	if err != nil {
+		return nil, err
+	}
+
+	weekly := make([]domain.WeeklyAvailability, 0, len(input.Weekly))
+	for _, w := range input.Weekly {
+		from, err := parseAvailabilityTime(w.From)
+		if err != nil {
+			return nil, err
+		}
+		until, err := parseAvailabilityTime(w.Until)
+		if err != nil {
+			return nil, err
+		}
+		// 制限のない曜日は保存しない
+		if !w.Unavailable && from == nil && until == nil {
+			continue
+		}
+		weekly = append(weekly, domain.WeeklyAvailability{
+			Weekday:     time.Weekday(w.Weekday),
+			Unavailable: w.Unavailable,
+			From:        from,
+			Until:       until,
+		})
+	}
+
+	allowed, err := u.allowedShiftTypes(ctx, input.OrganizationID, input.AllowedShiftTypeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := availability.Update(weekly, allowed, input.MaxDaysPerWeek, input.Note, input.Actor.Email); err != nil {
+		return nil, err
+	}
+	if err := u.availabilityRepo.Save(ctx, availability); err != nil {
+		u.logger.Error("勤務可能条件更新失敗", "error", err)
+		return nil, err
+	}
+
+	u.logger.Info("勤務可能条件更新完了", "staff_id", staff.ID, "user", input.Actor.Email)
+	return u.editableOutput(ctx, input.OrganizationID, staff, availability)
+}
+
+// AddException 期間例外を追加
+func (u *StaffAvailabilityUseCase) AddException(ctx context.Context, input *AddAvailabilityExceptionInput) (*StaffAvailabilityOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+	startDate, err := time.Parse("2006-01-02", input.StartDate)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "開始日の形式が不正です")
+	}
+	endDate := startDate
+	if input.EndDate != "" {
+		endDate, err = time.Parse("2006-01-02", input.EndDate)
+		if err != nil {
+			return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "終了日の形式が不正です")
+		}
+	}
+
+	staff, availability, err := u.loadEditable(ctx, input.OrganizationID, input.StaffID, input.Actor)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := availability.AddException(startDate, endDate, input.Available, input.Reason, input.Actor.Email); err != nil {
+		return nil, err
+	}
+	if err := u.availabilityRepo.Save(ctx, availability); err != nil {
+		u.logger.Error("勤務可能条件の期間例外追加失敗", "error", err)
+		return nil, err
+	}
+
+	u.logger.Info("勤務可能条件の期間例外追加完了", "staff_id", staff.ID, "start_date", input.StartDate, "user", input.Actor.Email)
+	return u.editableOutput(ctx, input.OrganizationID, staff, availability)
+}
+
+// RemoveException 期間例外を削除
+func (u *StaffAvailabilityUseCase) RemoveException(ctx context.Context, orgID, staffID, exceptionID string, actor AvailabilityActor) (*StaffAvailabilityOutput, error) {
+	id, err := sharedDomain.ParseID(exceptionID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "期間例外IDが不正です")
+	}
+	staff, availability, err := u.loadEditable(ctx, orgID, staffID, actor)
+	if err != nil {
+		return nil, err
+	}
+	if err := availability.RemoveException(id); err != nil {
+		return nil, err
+	}
+	if err := u.availabilityRepo.Save(ctx, availability); err != nil {
+		u.logger.Error("勤務可能条件の期間例外削除失敗", "error", err)
+		return nil, err
+	}
+
+	u.logger.Info("勤務可能条件の期間例外削除完了", "staff_id", staff.ID, "exception_id", id, "user", actor.Email)
+	return u.editableOutput(ctx, orgID, staff, availability)
+}
+
+// load スタッフと勤務可能条件を取得 組織外のスタッフは拒否し、未登録は制限なしの条件を返す
+func (u *StaffAvailabilityUseCase) load(ctx context.Context, orgID, staffID string) (*domain.Staff, *domain.StaffAvailability, error) {
+	organizationID, err := sharedDomain.ParseID(orgID)
+	if err != nil {
+		return nil, nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDが不正です")
+	}
+	id, err := sharedDomain.ParseID(staffID)
+	if err != nil {
+		return nil, nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "スタッフIDが不正です")
+	}
+
+	staff, err := u.staffRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if staff == nil {
+		return nil, nil, sharedDomain.ErrNotFound
+	}
+	if err := verifyStaffOrganization(ctx, u.teamRepo, u.deptRepo, staff, organizationID); err != nil {
+		return nil, nil, err
+	}
+
+	availability, err := u.availabilityRepo.FindByStaffID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if availability == nil {
+		availability = domain.NewStaffAvailability(id)
+	}
+	return staff, availability, nil
+}
+
+// loadEditable 編集対象のスタッフと勤務可能条件を取得 管理者とスタッフ本人以外は拒否する
+func (u *StaffAvailabilityUseCase) loadEditable(ctx context.Context, orgID, staffID string, actor AvailabilityActor) (*domain.Staff, *domain.StaffAvailability, error) {
+	staff, availability, err := u.load(ctx, orgID, staffID)
+	if err != nil {
+		return nil, nil, err
+	}
+	editable, err := u.canEdit(ctx, orgID, staff, actor)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !editable {
+		return nil, nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeForbidden, "自分の勤務可能条件のみ編集できます")
+	}
+	return staff, availability, nil
+}
+
+// canEdit 操作ユーザーが編集できるか 管理者以外はメールアドレスが一致する組織のスタッフを本人とみなす
+func (u *StaffAvailabilityUseCase) canEdit(ctx context.Context, orgID string, staff *domain.Staff, actor AvailabilityActor) (bool, error) {
+	if _, err := sharedDomain.ParseID(actor.UserID); err != nil {
+		return false, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "操作ユーザーが不正です")
+	}
+	if actor.IsManager {
+		return true, nil
+	}
+	if actor.Email == "" {
+		return false, nil
+	}
+	organizationID, err := sharedDomain.ParseID(orgID)
+	if err != nil {
+		return false, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDが不正です")
+	}
+	staffs, err := u.staffRepo.FindActiveByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return false, err
+	}
+	for i := range staffs {
+		if strings.EqualFold(staffs[i].Email, actor.Email) {
+			return staffs[i].ID == staff.ID, nil
+		}
+	}
+	return false, nil
+}
+
+// editableOutput 編集後の勤務可能条件出力 編集できた操作ユーザー向け
+func (u *StaffAvailabilityUseCase) editableOutput(ctx context.Context, orgID string, staff *domain.Staff, availability *domain.StaffAvailability) (*StaffAvailabilityOutput, error) {
+	out, err := u.output(ctx, orgID, staff, availability)
+	if err != nil {
+		return nil, err
+	}
+	out.Editable = true
+	return out, nil
+}
+
+// allowedShiftTypes 勤務可能なシフト種別IDを検証 組織のシフト種別以外は受け付けない
+func (u *StaffAvailabilityUseCase) allowedShiftTypes(ctx context.Context, orgID string, ids []string) ([]sharedDomain.ID, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	options, err := u.shiftTypeOptions(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[sharedDomain.ID]bool, len(options))
+	for _, o := range options {
+		known[o.ID] = true
+	}
+
+	result := make([]sharedDomain.ID, 0, len(ids))
+	seen := make(map[sharedDomain.ID]bool, len(ids))
+	for _, s := range ids {
+		id, err := sharedDomain.ParseID(s)
+		if err != nil || !known[id] {
+			return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "シフト種別が不正です")
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result, nil
+}
+
+// shiftTypeOptions 組織のシフト種別の選択肢
+func (u *StaffAvailabilityUseCase) shiftTypeOptions(ctx context.Context, orgID string) ([]domain.ShiftTypeOption, error) {
+	organizationID, err := sharedDomain.ParseID(orgID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDが不正です")
+	}
+	return u.shiftTypeFinder.FindByOrganizationID(ctx, organizationID)
+}
+
+// output 勤務可能条件出力を組み立てる
+func (u *StaffAvailabilityUseCase) output(ctx context.Context, orgID string, staff *domain.Staff, availability *domain.StaffAvailability) (*StaffAvailabilityOutput, error) {
+	options, err := u.shiftTypeOptions(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &StaffAvailabilityOutput{
+		StaffID:             staff.ID.String(),
+		StaffName:           staff.FullName(),
+		Weekly:              make([]WeeklyAvailabilityOutput, 0, len(availabilityWeekdays)),
+		ShiftTypes:          make([]AvailabilityShiftTypeOutput, 0, len(options)),
+		AllowedShiftTypeIDs: make([]string, 0, len(availability.AllowedShiftTypeIDs)),
+		MaxDaysPerWeek:      availability.MaxDaysPerWeek,
+		Exceptions:          make([]AvailabilityExceptionOutput, 0, len(availability.Exceptions)),
+		Note:                availability.Note,
+		UpdatedBy:           availability.UpdatedBy,
+	}
+	if availability.UpdatedBy != "" {
+		out.UpdatedAt = availability.UpdatedAt.Format(time.RFC3339)
+	}
+
+	for _, weekday := range availabilityWeekdays {
+		w := WeeklyAvailabilityOutput{Weekday: int(weekday), WeekdayName: domain.WeekdayName(weekday)}
+		if rule := availability.WeeklyOn(weekday); rule != nil {
+			w.Unavailable = rule.Unavailable
+			if rule.From != nil {
+				w.From = rule.From.String()
+			}
+			if rule.Until != nil {
+				w.Until = rule.Until.String()
+			}
+		}
+		out.Weekly = append(out.Weekly, w)
+	}
+
+	for _, id := range availability.AllowedShiftTypeIDs {
+		out.AllowedShiftTypeIDs = append(out.AllowedShiftTypeIDs, id.String())
+	}
+	for _, o := range options {
+		out.ShiftTypes = append(out.ShiftTypes, AvailabilityShiftTypeOutput{
+			ID:        o.ID.String(),
+			Name:      o.Name,
+			TimeRange: o.TimeRange,
+			Allowed:   len(availability.AllowedShiftTypeIDs) > 0 && availability.AllowsShiftType(o.ID),
+		})
+	}
+
+	for _, e := range availability.Exceptions {
+		label := "勤務不可"
+		if e.Available {
+			label = "勤務可"
+		}
+		out.Exceptions = append(out.Exceptions, AvailabilityExceptionOutput{
+			ID:        e.ID.String(),
+			StartDate: e.StartDate.Format("2006-01-02"),
+			EndDate:   e.EndDate.Format("2006-01-02"),
+			Available: e.Available,
+			KindLabel: label,
+			Reason:    e.Reason,
+			CreatedBy: e.CreatedBy,
+		})
+	}
+	return out, nil
+}
+
+// parseAvailabilityTime 時刻の入力を解析 空は制限なし
+func parseAvailabilityTime(s string) (*sharedDomain.TimeOfDay, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := sharedDomain.ParseTimeOfDay(s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,234 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"shiftmaster/internal/modules/staff/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+type mockStaffAvailabilityRepository struct {
+	availabilities map[sharedDomain.ID]*domain.StaffAvailability
+	saves          int
+}
+
+func (m *mockStaffAvailabilityRepository) FindByStaffID(_ context.Context, staffID sharedDomain.ID) (*domain.StaffAvailability, error) {
+	a, ok := m.availabilities[staffID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *a
+	copied.Exceptions = append([]domain.AvailabilityException(nil), a.Exceptions...)
+	return &copied, nil
+}
+
+func (m *mockStaffAvailabilityRepository) FindByStaffIDs(_ context.Context, staffIDs []sharedDomain.ID) ([]domain.StaffAvailability, error) {
+	var result []domain.StaffAvailability
+	for _, id := range staffIDs {
+		if a, ok := m.availabilities[id]; ok {
+			result = append(result, *a)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockStaffAvailabilityRepository) Save(_ context.Context, availability *domain.StaffAvailability) error {
+	m.availabilities[availability.StaffID] = availability
+	m.saves++
+	return nil
+}
+
+type mockShiftTypeOptionFinder struct {
+	options []domain.ShiftTypeOption
+}
+
+func (m *mockShiftTypeOptionFinder) FindByOrganizationID(_ context.Context, _ sharedDomain.ID) ([]domain.ShiftTypeOption, error) {
+	return m.options, nil
+}
+
+type staffAvailabilityFixture struct {
+	useCase *StaffAvailabilityUseCase
+	repo    *mockStaffAvailabilityRepository
+	orgID   sharedDomain.ID
+	staff   *domain.Staff
+	other   *domain.Staff
+	day     domain.ShiftTypeOption
+	night   domain.ShiftTypeOption
+}
+
+func newStaffAvailabilityFixture() *staffAvailabilityFixture {
+	f := &staffAvailabilityFixture{
+		repo:  &mockStaffAvailabilityRepository{availabilities: make(map[sharedDomain.ID]*domain.StaffAvailability)},
+		orgID: sharedDomain.NewID(),
+		day:   domain.ShiftTypeOption{ID: sharedDomain.NewID(), Name: "日勤", TimeRange: "09:00-17:00"},
+		night: domain.ShiftTypeOption{ID: sharedDomain.NewID(), Name: "夜勤", TimeRange: "16:00-09:00"},
+	}
+	dept := &domain.Department{ID: sharedDomain.NewID(), OrganizationID: f.orgID, Name: "看護部"}
+	team := &domain.Team{ID: sharedDomain.NewID(), DepartmentID: dept.ID, Name: "3階東病棟"}
+	f.staff = &domain.Staff{ID: sharedDomain.NewID(), TeamID: team.ID, LastName: "山田", FirstName: "花子", Email: "hanako@example.com", IsActive: true}
+	f.other = &domain.Staff{ID: sharedDomain.NewID(), TeamID: team.ID, LastName: "佐藤", FirstName: "太郎", Email: "taro@example.com", IsActive: true}
+
+	staffRepo := newMockStaffRepository()
+	staffRepo.staffs[f.staff.ID] = f.staff
+	staffRepo.staffs[f.other.ID] = f.other
+	teamRepo := newMockTeamRepository()
+	teamRepo.teams[team.ID] = team
+	deptRepo := newMockDepartmentRepository()
+	deptRepo.departments[dept.ID] = dept
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	f.useCase = NewStaffAvailabilityUseCase(
+		f.repo, staffRepo, teamRepo, deptRepo,
+		&mockShiftTypeOptionFinder{options: []domain.ShiftTypeOption{f.day, f.night}},
+		logger,
+	)
+	return f
+}
+
+// actor メールアドレスで操作ユーザーを作る
+func (f *staffAvailabilityFixture) actor(email string, isManager bool) AvailabilityActor {
+	return AvailabilityActor{UserID: sharedDomain.NewID().String(), Email: email, IsManager: isManager}
+}
+
+func TestStaffAvailabilityUseCase_Update(t *testing.T) {
+	f := newStaffAvailabilityFixture()
+	ctx := context.Background()
+
+	got, err := f.useCase.Get(ctx, f.orgID.String(), f.staff.ID.String(), f.actor("hanako@example.com", false))
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if len(got.Weekly) != 7 || got.Weekly[0].WeekdayName != "月" || got.MaxDaysPerWeek != 0 || !got.Editable || f.repo.saves != 0 {
+		t.Fatalf("未登録の勤務可能条件 = %+v", got)
+	}
+
+	input := &UpdateStaffAvailabilityInput{
+		OrganizationID: f.orgID.String(),
+		StaffID:        f.staff.ID.String(),
+		Weekly: []WeeklyAvailabilityInput{
+			{Weekday: 1},
+			{Weekday: 2, Unavailable: true},
+			{Weekday: 3, Until: "17:00"},
+		},
+		AllowedShiftTypeIDs: []string{f.day.ID.String()},
+		MaxDaysPerWeek:      3,
+		Note:                "学生",
+		Actor:               f.actor("Hanako@example.com", false),
+	}
+	got, err = f.useCase.Update(ctx, input)
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	saved := f.repo.availabilities[f.staff.ID]
+	if len(saved.Weekly) != 2 {
+		t.Errorf("制限のない曜日は保存しない: len(Weekly) = %d, want 2", len(saved.Weekly))
+	}
+	if got.Weekly[1].Unavailable != true || got.Weekly[2].Until != "17:00" || got.MaxDaysPerWeek != 3 {
+		t.Errorf("Weekly = %+v", got.Weekly)
+	}
+	if !got.ShiftTypes[0].Allowed || got.ShiftTypes[1].Allowed {
+		t.Errorf("ShiftTypes = %+v", got.ShiftTypes)
+	}
+	if got.UpdatedBy != "Hanako@example.com" {
+		t.Errorf("UpdatedBy = %q", got.UpdatedBy)
+	}
+
+	input.AllowedShiftTypeIDs = []string{sharedDomain.NewID().String()}
+	if _, err := f.useCase.Update(ctx, input); err == nil {
+		t.Error("組織外のシフト種別でエラーにならない")
+	}
+}
+
+func TestStaffAvailabilityUseCase_Exceptions(t *testing.T) {
+	f := newStaffAvailabilityFixture()
+	ctx := context.Background()
+
+	got, err := f.useCase.AddException(ctx, &AddAvailabilityExceptionInput{
+		OrganizationID: f.orgID.String(),
+		StaffID:        f.staff.ID.String(),
+		StartDate:      "2026-08-10",
+		EndDate:        "2026-08-20",
+		Reason:         "帰省",
+		Actor:          f.actor("manager@example.com", true),
+	})
+	if err != nil {
+		t.Fatalf("AddException() error = %v", err)
+	}
+	if len(got.Exceptions) != 1 || got.Exceptions[0].KindLabel != "勤務不可" || got.Exceptions[0].EndDate != "2026-08-20" {
+		t.Fatalf("Exceptions = %+v", got.Exceptions)
+	}
+
+	got, err = f.useCase.RemoveException(ctx, f.orgID.String(), f.staff.ID.String(), got.Exceptions[0].ID, f.actor("hanako@example.com", false))
+	if err != nil {
+		t.Fatalf("RemoveException() error = %v", err)
+	}
+	if len(got.Exceptions) != 0 || len(f.repo.availabilities[f.staff.ID].Exceptions) != 0 {
+		t.Errorf("Exceptions = %+v", got.Exceptions)
+	}
+}
+
+func TestStaffAvailabilityUseCase_OtherOrganization(t *testing.T) {
+	f := newStaffAvailabilityFixture()
+
+	_, err := f.useCase.Get(context.Background(), sharedDomain.NewID().String(), f.staff.ID.String(), f.actor("manager@example.com", true))
+	var domainErr *sharedDomain.DomainError
+	if !errors.As(err, &domainErr) || domainErr.Code != sharedDomain.ErrCodeForbidden {
+		t.Errorf("Get() error = %v, want forbidden", err)
+	}
+}
+
+func TestStaffAvailabilityUseCase_Permission(t *testing.T) {
+	f := newStaffAvailabilityFixture()
+	ctx := context.Background()
+
+	got, err := f.useCase.Get(ctx, f.orgID.String(), f.staff.ID.String(), f.actor("taro@example.com", false))
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Editable {
+		t.Error("他のスタッフの条件は編集できない")
+	}
+
+	for _, actor := range []AvailabilityActor{
+		f.actor("taro@example.com", false),
+		f.actor("unknown@example.com", false),
+	} {
+		_, err := f.useCase.Update(ctx, &UpdateStaffAvailabilityInput{
+			OrganizationID: f.orgID.String(),
+			StaffID:        f.staff.ID.String(),
+			MaxDaysPerWeek: 3,
+			Actor:          actor,
+		})
+		var domainErr *sharedDomain.DomainError
+		if !errors.As(err, &domainErr) || domainErr.Code != sharedDomain.ErrCodeForbidden {
+			t.Errorf("Update(%s) error = %v, want forbidden", actor.Email, err)
+		}
+		_, err = f.useCase.AddException(ctx, &AddAvailabilityExceptionInput{
+			OrganizationID: f.orgID.String(),
+			StaffID:        f.staff.ID.String(),
+			StartDate:      "2026-08-10",
+			Actor:          actor,
+		})
+		if !errors.As(err, &domainErr) || domainErr.Code != sharedDomain.ErrCodeForbidden {
+			t.Errorf("AddException(%s) error = %v, want forbidden", actor.Email, err)
+		}
+	}
+	if f.repo.saves != 0 {
+		t.Errorf("saves = %d, want 0", f.repo.saves)
+	}
+
+	got, err = f.useCase.Update(ctx, &UpdateStaffAvailabilityInput{
+		OrganizationID: f.orgID.String(),
+		StaffID:        f.staff.ID.String(),
+		MaxDaysPerWeek: 3,
+		Actor:          f.actor("manager@example.com", true),
+	})
+	if err != nil || !got.Editable {
+		t.Errorf("管理者は他のスタッフの条件を編集できる: %+v, %v", got, err)
+	}
+}
@@ -177,3 +177,197 @@ type StaffListOutput struct {
 	// PerPage 1ページあたり件数
 	PerPage int `json:"per_page"`
 }
+
+// ImportStaffsInput スタッフ一括登録入力
+type ImportStaffsInput struct {
+	// OrganizationID 組織ID
+	OrganizationID string
+	// Data CSVまたはxlsxの内容
+	Data []byte
+	// DryRun 検証のみ行い保存しない
+	DryRun bool
+}
+
+// Validate 入力検証
+func (i *ImportStaffsInput) Validate() error {
+	if i.OrganizationID == "" {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDは必須です")
+	}
+	if len(i.Data) == 0 {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "ファイルが空です")
+	}
+	return nil
+}
+
+// ImportStaffsOutput スタッフ一括登録結果
+type ImportStaffsOutput struct {
+	// DryRun 検証のみ
+	DryRun bool `json:"dry_run"`
+	// Applied 保存済み
+	Applied bool `json:"applied"`
+	// TotalRows データ行数
+	TotalRows int `json:"total_rows"`
+	// CreatedCount 登録するスタッフ数
+	CreatedCount int `json:"created_count"`
+	// Errors 行ごとのエラー
+	Errors []ImportStaffRowErrorOutput `json:"errors"`
+}
+
+// ImportStaffRowErrorOutput スタッフ一括登録の行エラー出力
+type ImportStaffRowErrorOutput struct {
+	// Line ファイルの行番号 ヘッダーが1行目
+	Line int `json:"line"`
+	// EmployeeCode 社員番号
+	EmployeeCode string `json:"employee_code"`
+	// Column 項目名
+	Column string `json:"column"`
+	// Message エラー内容
+	Message string `json:"message"`
+}
+
+// UpdateStaffAvailabilityInput 勤務可能条件更新入力
+type UpdateStaffAvailabilityInput struct {
+	// OrganizationID 組織ID
+	OrganizationID string `json:"-"`
+	// StaffID スタッフID
+	StaffID string `json:"-"`
+	// Weekly 曜日ごとの条件 制限のない曜日は省略できる
+	Weekly []WeeklyAvailabilityInput `json:"weekly"`
+	// AllowedShiftTypeIDs 勤務可能なシフト種別ID 空は制限なし
+	AllowedShiftTypeIDs []string `json:"allowed_shift_type_ids"`
+	// MaxDaysPerWeek 週の上限勤務日数 0は制限なし
+	MaxDaysPerWeek int `json:"max_days_per_week"`
+	// Note 備考
+	Note string `json:"note"`
+	// Actor 操作ユーザー
+	Actor AvailabilityActor `json:"-"`
+}
+
+// AvailabilityActor 勤務可能条件の操作ユーザー
+type AvailabilityActor struct {
+	// UserID 操作ユーザーID
+	UserID string
+	// Email 操作ユーザーのメールアドレス 同じメールアドレスのスタッフを本人とみなす
+	Email string
+	// IsManager 操作ユーザーがマネージャー以上 他のスタッフの条件も編集できる
+	IsManager bool
+}
+
+// WeeklyAvailabilityInput 曜日ごとの勤務可能条件入力
+type WeeklyAvailabilityInput struct {
+	// Weekday 曜日 0が日曜日
+	Weekday int `json:"weekday"`
+	// Unavailable 終日勤務不可
+	Unavailable bool `json:"unavailable"`
+	// From この時刻以降に始まる勤務のみ可（HH:MM形式）空は制限なし
+	From string `json:"from"`
+	// Until この時刻までに終わる勤務のみ可（HH:MM形式）空は制限なし
+	Until string `json:"until"`
+}
+
+// Validate 入力検証
+func (i *UpdateStaffAvailabilityInput) Validate() error {
+	if i.StaffID == "" {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "スタッフIDは必須です")
+	}
+	return nil
+}
+
+// AddAvailabilityExceptionInput 勤務可能条件の期間例外追加入力
+type AddAvailabilityExceptionInput struct {
+	// OrganizationID 組織ID
+	OrganizationID string `json:"-"`
+	// StaffID スタッフID
+	StaffID string `json:"-"`
+	// StartDate 開始日（YYYY-MM-DD形式）
+	StartDate string `json:"start_date"`
+	// EndDate 終了日（YYYY-MM-DD形式）省略時は開始日と同じ
+	EndDate string `json:"end_date"`
+	// Available 勤務可の例外 falseは勤務不可
+	Available bool `json:"available"`
+	// Reason 理由
+	Reason string `json:"reason"`
+	// Actor 操作ユーザー
+	Actor AvailabilityActor `json:"-"`
+}
+
+// Validate 入力検証
+func (i *AddAvailabilityExceptionInput) Validate() error {
+	if i.StaffID == "" {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "スタッフIDは必須です")
+	}
+	if i.StartDate == "" {
+		return sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "開始日は必須です")
+	}
+	return nil
+}
+
+// StaffAvailabilityOutput 勤務可能条件出力
+type StaffAvailabilityOutput struct {
+	// StaffID スタッフID
+	StaffID string `json:"staff_id"`
+	// StaffName スタッフ名
+	StaffName string `json:"staff_name"`
+	// Weekly 曜日ごとの条件 月曜日から日曜日の7件
+	Weekly []WeeklyAvailabilityOutput `json:"weekly"`
+	// ShiftTypes 選択できるシフト種別 Allowedで勤務可能か示す
+	ShiftTypes []AvailabilityShiftTypeOutput `json:"shift_types"`
+	// AllowedShiftTypeIDs 勤務可能なシフト種別ID 空は制限なし
+	AllowedShiftTypeIDs []string `json:"allowed_shift_type_ids"`
+	// MaxDaysPerWeek 週の上限勤務日数 0は制限なし
+	MaxDaysPerWeek int `json:"max_days_per_week"`
+	// Exceptions 期間例外 開始日順
+	Exceptions []AvailabilityExceptionOutput `json:"exceptions"`
+	// Note 備考
+	Note string `json:"note"`
+	// UpdatedBy 更新したユーザーのメールアドレス 未登録は空
+	UpdatedBy string `json:"updated_by,omitempty"`
+	// UpdatedAt 更新日時 未登録は空
+	UpdatedAt string `json:"updated_at,omitempty"`
+	// Editable 操作ユーザーが編集できるか 管理者またはスタッフ本人
+	Editable bool `json:"editable"`
+}
+
+// WeeklyAvailabilityOutput 曜日ごとの勤務可能条件出力
+type WeeklyAvailabilityOutput struct {
+	// Weekday 曜日 0が日曜日
+	Weekday int `json:"weekday"`
+	// WeekdayName 曜日の表示名
+	WeekdayName string `json:"weekday_name"`
+	// Unavailable 終日勤務不可
+	Unavailable bool `json:"unavailable"`
+	// From この時刻以降に始まる勤務のみ可 制限なしは空
+	From string `json:"from,omitempty"`
+	// Until この時刻までに終わる勤務のみ可 制限なしは空
+	Until string `json:"until,omitempty"`
+}
+
+// AvailabilityShiftTypeOutput 勤務可能条件のシフト種別出力
+type AvailabilityShiftTypeOutput struct {
+	// ID シフト種別ID
+	ID string `json:"id"`
+	// Name 名称
+	Name string `json:"name"`
+	// TimeRange 勤務時間
+	TimeRange string `json:"time_range"`
+	// Allowed 勤務可能
+	Allowed bool `json:"allowed"`
+}
+
+// AvailabilityExceptionOutput 勤務可能条件の期間例外出力
+type AvailabilityExceptionOutput struct {
+	// ID 期間例外ID
+	ID string `json:"id"`
+	// StartDate 開始日
+	StartDate string `json:"start_date"`
+	// EndDate 終了日
+	EndDate string `json:"end_date"`
+	// Available 勤務可の例外
+	Available bool `json:"available"`
+	// KindLabel 種類ラベル
+	KindLabel string `json:"kind_label"`
+	// Reason 理由
+	Reason string `json:"reason"`
+	// CreatedBy 登録したユーザーのメールアドレス
+	CreatedBy string `json:"created_by,omitempty"`
+}
@@ -0,0 +1,448 @@
+// Package application スタッフアプリケーション層
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"shiftmaster/internal/modules/staff/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+	"shiftmaster/internal/shared/infrastructure"
+)
+
+// maxStaffImportRows 1ファイルで登録できるスタッフ数の上限
+const maxStaffImportRows = 2000
+
+// スタッフ一括登録の列
+const (
+	importColEmployeeCode   = "employee_code"
+	importColLastName       = "last_name"
+	importColFirstName      = "first_name"
+	importColEmail          = "email"
+	importColPhone          = "phone"
+	importColHireDate       = "hire_date"
+	importColEmploymentType = "employment_type"
+	importColTeam           = "team_code"
+	importColJobType        = "job_type_code"
+	importColPosition       = "position_code"
+)
+
+// StaffImportHeader テンプレートの見出し行
+var StaffImportHeader = []string{
+	importColEmployeeCode, importColLastName, importColFirstName, importColEmail, importColPhone,
+	importColHireDate, importColEmploymentType, importColTeam, importColJobType, importColPosition,
+}
+
+// staffImportRequiredColumns 必須の列
+var staffImportRequiredColumns = []string{importColEmployeeCode, importColLastName, importColFirstName, importColTeam}
+
+// staffImportColumnAliases 見出しの別名 日本語の見出しでも取り込めるようにする
+var staffImportColumnAliases = map[string]string{
+	"社員番号":    importColEmployeeCode,
+	"職員番号":    importColEmployeeCode,
+	"姓":       importColLastName,
+	"名":       importColFirstName,
+	"メールアドレス": importColEmail,
+	"電話番号":    importColPhone,
+	"入社日":     importColHireDate,
+	"雇用形態":    importColEmploymentType,
+	"チーム":     importColTeam,
+	"チームコード":  importColTeam,
+	"職種":      importColJobType,
+	"職種コード":   importColJobType,
+	"職位":      importColPosition,
+	"職位コード":   importColPosition,
+}
+
+// staffImportDateLayouts 入社日として受け付ける形式
+var staffImportDateLayouts = []string{"2006-01-02", "2006/01/02", "2006/1/2"}
+
+// excelEpoch Excelのシリアル値の起点
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// StaffImportUseCase スタッフ一括登録ユースケース
+type StaffImportUseCase struct {
+	importRepo   domain.StaffImportRepository
+	teamRepo     domain.TeamRepository
+	jobTypeRepo  domain.JobTypeRepository
+	positionRepo domain.PositionRepository
+	logger       *slog.Logger
+}
+
+// NewStaffImportUseCase スタッフ一括登録ユースケース生成
+func NewStaffImportUseCase(
+	importRepo domain.StaffImportRepository,
+	teamRepo domain.TeamRepository,
+	jobTypeRepo domain.JobTypeRepository,
+	positionRepo domain.PositionRepository,
+	logger *slog.Logger,
+) *StaffImportUseCase {
+	return &StaffImportUseCase{
+		importRepo:   importRepo,
+		teamRepo:     teamRepo,
+		jobTypeRepo:  jobTypeRepo,
+		positionRepo: positionRepo,
+		logger:       logger,
+	}
+}
+
+// Import CSVまたはxlsxからスタッフを一括登録 1行でもエラーがあれば何も登録しない
+func (u *StaffImportUseCase) Import(ctx context.Context, input *ImportStaffsInput) (*ImportStaffsOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+	orgID, err := sharedDomain.ParseID(input.OrganizationID)
+	if err != nil {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織IDが不正です")
+	}
+
+	rows, err := infrastructure.ReadSpreadsheet(input.Data)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrInvalidSpreadsheet) {
+			return nil, sharedDomain.WrapDomainError(sharedDomain.ErrCodeValidation, "ファイルを読み込めません。CSVまたはExcel(xlsx)形式で指定してください", err)
+		}
+		return nil, err
+	}
+	header, records, err := splitStaffImportRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) > maxStaffImportRows {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation,
+			fmt.Sprintf("一度に登録できるのは%d行までです", maxStaffImportRows))
+	}
+
+	refs, err := u.loadImportReferences(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ImportStaffsOutput{DryRun: input.DryRun, TotalRows: len(records), Errors: []ImportStaffRowErrorOutput{}}
+	staffs := make([]domain.Staff, 0, len(records))
+	assignments := make([]domain.StaffAssignment, 0, len(records))
+	seen := make(map[string]int, len(records))
+	now := time.Now()
+
+	for _, row := range records {
+		rec := staffImportRecord{header: header, cells: row.Cells}
+		rowErr := func(col, message string) {
+			output.Errors = append(output.Errors, ImportStaffRowErrorOutput{
+				Line: row.Line, EmployeeCode: rec.get(importColEmployeeCode), Column: header.label(col), Message: message,
+			})
+		}
+
+		code, err := sharedDomain.NewEmployeeCode(rec.get(importColEmployeeCode))
+		if err != nil {
+			rowErr(importColEmployeeCode, domainErrorMessage(err))
+		} else if refs.employeeCodes[code.String()] {
+			rowErr(importColEmployeeCode, "この社員番号は登録済みです")
+		} else if line, ok := seen[code.String()]; ok {
+			rowErr(importColEmployeeCode, fmt.Sprintf("同じ社員番号が%d行目にもあります", line))
+		} else {
+			seen[code.String()] = row.Line
+		}
+
+		name, err := sharedDomain.NewPersonName(rec.get(importColLastName), rec.get(importColFirstName))
+		if err != nil {
+			// メッセージが「姓」「名」のどちらで始まるかで列を判別する
+			col := importColLastName
+			if strings.HasPrefix(domainErrorMessage(err), "名") {
+				col = importColFirstName
+			}
+			rowErr(col, domainErrorMessage(err))
+		}
+
+		var email sharedDomain.Email
+		if v := rec.get(importColEmail); v != "" {
+			if email, err = sharedDomain.NewEmail(v); err != nil {
+				rowErr(importColEmail, domainErrorMessage(err))
+			}
+		}
+
+		phone, err := sharedDomain.NewPhoneNumber(rec.get(importColPhone))
+		if err != nil {
+			rowErr(importColPhone, domainErrorMessage(err))
+		}
+
+		hireDate, ok := parseStaffImportDate(rec.get(importColHireDate))
+		if !ok {
+			rowErr(importColHireDate, "入社日の形式が不正です")
+		}
+
+		empType, ok := parseEmploymentType(rec.get(importColEmploymentType))
+		if !ok {
+			rowErr(importColEmploymentType, "雇用形態が不正です")
+		}
+
+		teamID, ok := refs.teams.lookup(rec.get(importColTeam))
+		if !ok {
+			if rec.get(importColTeam) == "" {
+				rowErr(importColTeam, "チームは必須です")
+			} else {
+				rowErr(importColTeam, "チームが見つかりません")
+			}
+		}
+
+		var jobTypeID, positionID *sharedDomain.ID
+		if v := rec.get(importColJobType); v != "" {
+			if id, ok := refs.jobTypes.lookup(v); ok {
+				jobTypeID = &id
+			} else {
+				rowErr(importColJobType, "職種が見つかりません")
+			}
+		}
+		if v := rec.get(importColPosition); v != "" {
+			if id, ok := refs.positions.lookup(v); ok {
+				positionID = &id
+			} else {
+				rowErr(importColPosition, "職位が見つかりません")
+			}
+		}
+
+		if len(output.Errors) > 0 {
+			// エラーがあれば保存しないため以降の行は検証のみ行う
+			continue
+		}
+
+		staff := domain.Staff{
+			ID:             sharedDomain.NewID(),
+			TeamID:         teamID,
+			EmployeeCode:   code.String(),
+			FirstName:      name.FirstName(),
+			LastName:       name.LastName(),
+			Email:          email.String(),
+			Phone:          phone.Formatted(),
+			HireDate:       hireDate,
+			EmploymentType: empType,
+			IsActive:       true,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+		assignment, err := domain.NewStaffAssignment(staff.ID, &teamID, jobTypeID, positionID, true)
+		if err != nil {
+			return nil, err
+		}
+		if err := assignment.SetDateRange(hireDate, nil); err != nil {
+			return nil, err
+		}
+		staffs = append(staffs, staff)
+		assignments = append(assignments, *assignment)
+	}
+
+	if len(output.Errors) > 0 {
+		return output, nil
+	}
+	output.CreatedCount = len(staffs)
+	if input.DryRun || len(staffs) == 0 {
+		return output, nil
+	}
+
+	if err := u.importRepo.CreateAll(ctx, staffs, assignments); err != nil {
+		u.logger.Error("スタッフ一括登録失敗", "error", err)
+		return nil, err
+	}
+	output.Applied = true
+
+	u.logger.Info("スタッフ一括登録完了", "organization_id", orgID, "count", len(staffs))
+	return output, nil
+}
+
+// staffImportReferences 行の検証に使う組織内のマスタ
+type staffImportReferences struct {
+	employeeCodes map[string]bool
+	teams         *codeNameIndex
+	jobTypes      *codeNameIndex
+	positions     *codeNameIndex
+}
+
+// loadImportReferences 組織内の社員番号・チーム・職種・職位を取得
+func (u *StaffImportUseCase) loadImportReferences(ctx context.Context, orgID sharedDomain.ID) (*staffImportReferences, error) {
+	codes, err := u.importRepo.FindEmployeeCodes(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	teams, err := u.teamRepo.FindByOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	jobTypes, err := u.jobTypeRepo.FindByOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	positions, err := u.positionRepo.FindByOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := &staffImportReferences{
+		employeeCodes: make(map[string]bool, len(codes)),
+		teams:         newCodeNameIndex(),
+		jobTypes:      newCodeNameIndex(),
+		positions:     newCodeNameIndex(),
+	}
+	for _, c := range codes {
+		refs.employeeCodes[strings.ToUpper(strings.TrimSpace(c))] = true
+	}
+	for _, t := range teams {
+		refs.teams.add(t.ID, t.Code, t.Name)
+	}
+	for _, j := range jobTypes {
+		refs.jobTypes.add(j.ID, j.Code, j.Name)
+	}
+	for _, p := range positions {
+		refs.positions.add(p.ID, p.Code, p.Name)
+	}
+	return refs, nil
+}
+
+// codeNameIndex コードまたは名称からIDを引く索引 名称は一意な場合のみ使う
+type codeNameIndex struct {
+	byCode map[string]sharedDomain.ID
+	byName map[string][]sharedDomain.ID
+}
+
+// newCodeNameIndex 索引生成
+func newCodeNameIndex() *codeNameIndex {
+	return &codeNameIndex{byCode: map[string]sharedDomain.ID{}, byName: map[string][]sharedDomain.ID{}}
+}
+
+// add 登録 コードは大文字小文字を区別しない
+func (x *codeNameIndex) add(id sharedDomain.ID, code, name string) {
+	if code != "" {
+		x.byCode[strings.ToUpper(code)] = id
+	}
+	if name != "" {
+		x.byName[name] = append(x.byName[name], id)
+	}
+}
+
+// lookup コード一致を優先し、なければ名称が一意に一致するものを返す
+func (x *codeNameIndex) lookup(value string) (sharedDomain.ID, bool) {
+	if value == "" {
+		return sharedDomain.ID{}, false
+	}
+	if id, ok := x.byCode[strings.ToUpper(value)]; ok {
+		return id, true
+	}
+	if ids := x.byName[value]; len(ids) == 1 {
+		return ids[0], true
+	}
+	return sharedDomain.ID{}, false
+}
+
+// staffImportHeader 見出し行 列名から列位置と元の見出しを引く
+type staffImportHeader struct {
+	index  map[string]int
+	labels map[string]string
+}
+
+// label エラー表示用の列名 ファイルの見出しをそのまま使う
+func (h staffImportHeader) label(col string) string {
+	if l, ok := h.labels[col]; ok {
+		return l
+	}
+	return col
+}
+
+// staffImportRecord データ行
+type staffImportRecord struct {
+	header staffImportHeader
+	cells  []string
+}
+
+// get 列の値 列がなければ空
+func (r staffImportRecord) get(col string) string {
+	i, ok := r.header.index[col]
+	if !ok || i >= len(r.cells) {
+		return ""
+	}
+	return r.cells[i]
+}
+
+// splitStaffImportRows 先頭の空でない行を見出しとして解釈し、データ行と分ける
+func splitStaffImportRows(rows []infrastructure.SpreadsheetRow) (staffImportHeader, []infrastructure.SpreadsheetRow, error) {
+	header := staffImportHeader{index: map[string]int{}, labels: map[string]string{}}
+	start := 0
+	for start < len(rows) && rows[start].IsBlank() {
+		start++
+	}
+	if start == len(rows) {
+		return header, nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "ファイルにデータがありません")
+	}
+
+	for i, cell := range rows[start].Cells {
+		col := strings.ToLower(cell)
+		if alias, ok := staffImportColumnAliases[cell]; ok {
+			col = alias
+		}
+		if _, dup := header.index[col]; dup || col == "" {
+			continue
+		}
+		header.index[col] = i
+		header.labels[col] = cell
+	}
+	var missing []string
+	for _, col := range staffImportRequiredColumns {
+		if _, ok := header.index[col]; !ok {
+			missing = append(missing, col)
+		}
+	}
+	if len(missing) > 0 {
+		return header, nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation,
+			"必須の列がありません: "+strings.Join(missing, ", "))
+	}
+
+	records := make([]infrastructure.SpreadsheetRow, 0, len(rows)-start-1)
+	for _, row := range rows[start+1:] {
+		if !row.IsBlank() {
+			records = append(records, row)
+		}
+	}
+	return header, records, nil
+}
+
+// parseStaffImportDate 入社日の解釈 Excelの日付シリアル値も受け付ける 空は未設定
+func parseStaffImportDate(value string) (*time.Time, bool) {
+	if value == "" {
+		return nil, true
+	}
+	for _, layout := range staffImportDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t, true
+		}
+	}
+	if serial, err := strconv.ParseFloat(value, 64); err == nil && serial >= 1 && serial < 2958466 {
+		t := excelEpoch.AddDate(0, 0, int(serial))
+		return &t, true
+	}
+	return nil, false
+}
+
+// parseEmploymentType 雇用形態の解釈 コードと表示ラベルのどちらも受け付ける 空は正社員
+func parseEmploymentType(value string) (domain.EmploymentType, bool) {
+	if value == "" {
+		return domain.EmploymentFullTime, true
+	}
+	for _, t := range []domain.EmploymentType{
+		domain.EmploymentFullTime, domain.EmploymentPartTime, domain.EmploymentContract, domain.EmploymentTemporary,
+	} {
+		if strings.EqualFold(value, t.String()) || value == t.Label() {
+			return t, true
+		}
+	}
+	return "", false
+}
+
+// domainErrorMessage ドメインエラーのメッセージ取得
+func domainErrorMessage(err error) string {
+	var domainErr *sharedDomain.DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr.Message
+	}
+	return err.Error()
+}
@@ -0,0 +1,256 @@
+// Package application スタッフ一括登録テスト
+package application
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"shiftmaster/internal/modules/staff/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+)
+
+type mockStaffImportRepository struct {
+	codes       []string
+	staffs      []domain.Staff
+	assignments []domain.StaffAssignment
+	calls       int
+}
+
+func (m *mockStaffImportRepository) FindEmployeeCodes(_ context.Context, _ sharedDomain.ID) ([]string, error) {
+	return m.codes, nil
+}
+
+func (m *mockStaffImportRepository) CreateAll(_ context.Context, staffs []domain.Staff, assignments []domain.StaffAssignment) error {
+	m.calls++
+	m.staffs = append(m.staffs, staffs...)
+	m.assignments = append(m.assignments, assignments...)
+	return nil
+}
+
+type mockJobTypeRepository struct {
+	jobTypes []domain.JobType
+}
+
+func (m *mockJobTypeRepository) FindByID(_ interface{}, _ sharedDomain.ID) (*domain.JobType, error) {
+	return nil, nil
+}
+
+func (m *mockJobTypeRepository) FindByOrganizationID(_ interface{}, _ sharedDomain.ID) ([]domain.JobType, error) {
+	return m.jobTypes, nil
+}
+
+func (m *mockJobTypeRepository) FindByCode(_ interface{}, _ sharedDomain.ID, _ string) (*domain.JobType, error) {
+	return nil, nil
+}
+
+func (m *mockJobTypeRepository) Save(_ interface{}, _ *domain.JobType) error {
+	return nil
+}
+
+func (m *mockJobTypeRepository) Delete(_ interface{}, _ sharedDomain.ID) error {
+	return nil
+}
+
+type mockPositionRepository struct {
+	positions []domain.Position
+}
+
+func (m *mockPositionRepository) FindByID(_ interface{}, _ sharedDomain.ID) (*domain.Position, error) {
+	return nil, nil
+}
+
+func (m *mockPositionRepository) FindByOrganizationID(_ interface{}, _ sharedDomain.ID) ([]domain.Position, error) {
+	return m.positions, nil
+}
+
+func (m *mockPositionRepository) FindByCode(_ interface{}, _ sharedDomain.ID, _ string) (*domain.Position, error) {
+	return nil, nil
+}
+
+func (m *mockPositionRepository) Save(_ interface{}, _ *domain.Position) error {
+	return nil
+}
+
+func (m *mockPositionRepository) Delete(_ interface{}, _ sharedDomain.ID) error {
+	return nil
+}
+
+// staffImportFixture 一括登録テスト用のマスタ
+type staffImportFixture struct {
+	useCase  *StaffImportUseCase
+	repo     *mockStaffImportRepository
+	orgID    sharedDomain.ID
+	ward     domain.Team
+	nurse    domain.JobType
+	chief    domain.Position
+	teamRepo *mockTeamRepository
+}
+
+func newStaffImportFixture() *staffImportFixture {
+	f := &staffImportFixture{
+		repo:     &mockStaffImportRepository{codes: []string{"n900"}},
+		orgID:    sharedDomain.NewID(),
+		ward:     domain.Team{ID: sharedDomain.NewID(), Name: "3階東病棟", Code: "W3E"},
+		nurse:    domain.JobType{ID: sharedDomain.NewID(), Name: "看護師", Code: "NS"},
+		chief:    domain.Position{ID: sharedDomain.NewID(), Name: "主任", Code: "CHIEF"},
+		teamRepo: newMockTeamRepository(),
+	}
+	f.teamRepo.teams[f.ward.ID] = &f.ward
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	f.useCase = NewStaffImportUseCase(
+		f.repo,
+		f.teamRepo,
+		&mockJobTypeRepository{jobTypes: []domain.JobType{f.nurse}},
+		&mockPositionRepository{positions: []domain.Position{f.chief}},
+		logger,
+	)
+	return f
+}
+
+func TestStaffImportUseCase_Import(t *testing.T) {
+	f := newStaffImportFixture()
+	csv := "\xEF\xBB\xBF社員番号,姓,名,メールアドレス,電話番号,入社日,雇用形態,チーム,職種,職位\n" +
+		"n001,山田,花子,Hanako@Example.com,090-1234-5678,2024/4/1,パートタイム,W3E,NS,CHIEF\n" +
+		"\n" +
+		"N002,佐藤,次郎,,,45383,contract,3階東病棟,,\n"
+
+	input := &ImportStaffsInput{OrganizationID: f.orgID.String(), Data: []byte(csv), DryRun: true}
+	output, err := f.useCase.Import(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Import(dry run) error = %v", err)
+	}
+	if len(output.Errors) != 0 || output.Applied || output.TotalRows != 2 || output.CreatedCount != 2 {
+		t.Fatalf("dry run output = %+v", output)
+	}
+	if f.repo.calls != 0 {
+		t.Fatal("dry run should not save staffs")
+	}
+
+	input.DryRun = false
+	output, err = f.useCase.Import(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if !output.Applied || f.repo.calls != 1 || len(f.repo.staffs) != 2 || len(f.repo.assignments) != 2 {
+		t.Fatalf("output = %+v, saved %d staffs", output, len(f.repo.staffs))
+	}
+
+	hanako := f.repo.staffs[0]
+	if hanako.EmployeeCode != "N001" || hanako.LastName != "山田" || hanako.FirstName != "花子" {
+		t.Errorf("staff = %+v", hanako)
+	}
+	if hanako.Email != "hanako@example.com" || hanako.Phone != "090-1234-5678" {
+		t.Errorf("email/phone = %s / %s", hanako.Email, hanako.Phone)
+	}
+	if hanako.EmploymentType != domain.EmploymentPartTime || hanako.TeamID != f.ward.ID || !hanako.IsActive {
+		t.Errorf("staff = %+v", hanako)
+	}
+	if hanako.HireDate == nil || !hanako.HireDate.Equal(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("HireDate = %v", hanako.HireDate)
+	}
+
+	assignment := f.repo.assignments[0]
+	if assignment.StaffID != hanako.ID || !assignment.IsPrimary || *assignment.TeamID != f.ward.ID ||
+		*assignment.JobTypeID != f.nurse.ID || *assignment.PositionID != f.chief.ID {
+		t.Errorf("assignment = %+v", assignment)
+	}
+
+	jiro := f.repo.staffs[1]
+	if jiro.TeamID != f.ward.ID || jiro.EmploymentType != domain.EmploymentContract || jiro.Email != "" {
+		t.Errorf("チーム名での指定が解決されていません: %+v", jiro)
+	}
+	// Excelの日付シリアル値 45383 = 2024-04-01
+	if jiro.HireDate == nil || jiro.HireDate.Format("2006-01-02") != "2024-04-01" {
+		t.Errorf("HireDate = %v", jiro.HireDate)
+	}
+	if f.repo.assignments[1].JobTypeID != nil || f.repo.assignments[1].PositionID != nil {
+		t.Errorf("assignment = %+v", f.repo.assignments[1])
+	}
+}
+
+func TestStaffImportUseCase_Import_RowErrors(t *testing.T) {
+	f := newStaffImportFixture()
+	csv := "employee_code,last_name,first_name,email,phone,hire_date,employment_type,team_code,job_type_code,position_code\n" +
+		"N001,山田,花子,,,,,W3E,,\n" +
+		"N900,鈴木,一郎,,,,,W3E,,\n" +
+		"n001,田中,三郎,,,,,W3E,,\n" +
+		"N_004,高橋,四郎,,,,,W3E,,\n" +
+		"N005,,五郎,mail@,123,2024-13-01,正職員,W9,DR,BOSS\n" +
+		"N006,伊藤,,,,,,,,\n"
+
+	output, err := f.useCase.Import(context.Background(), &ImportStaffsInput{OrganizationID: f.orgID.String(), Data: []byte(csv)})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if output.Applied || f.repo.calls != 0 {
+		t.Fatal("エラーがある場合は何も登録しない")
+	}
+
+	want := []struct {
+		line   int
+		column string
+	}{
+		{3, "employee_code"},
+		{4, "employee_code"},
+		{5, "employee_code"},
+		{6, "last_name"},
+		{6, "email"},
+		{6, "phone"},
+		{6, "hire_date"},
+		{6, "employment_type"},
+		{6, "team_code"},
+		{6, "job_type_code"},
+		{6, "position_code"},
+		{7, "first_name"},
+		{7, "team_code"},
+	}
+	if len(output.Errors) != len(want) {
+		t.Fatalf("Errors = %+v", output.Errors)
+	}
+	for i, w := range want {
+		if output.Errors[i].Line != w.line || output.Errors[i].Column != w.column {
+			t.Errorf("Errors[%d] = %+v, want line %d column %s", i, output.Errors[i], w.line, w.column)
+		}
+	}
+	if !strings.Contains(output.Errors[1].Message, "2行目") {
+		t.Errorf("重複行のエラーに元の行番号がありません: %s", output.Errors[1].Message)
+	}
+	if output.Errors[0].EmployeeCode != "N900" {
+		t.Errorf("EmployeeCode = %s", output.Errors[0].EmployeeCode)
+	}
+}
+
+func TestStaffImportUseCase_Import_Rejected(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		orgID string
+	}{
+		{name: "空ファイル", data: ""},
+		{name: "組織ID不正", data: "employee_code\n", orgID: "invalid"},
+		{name: "見出しのみ空行", data: "\n\n"},
+		{name: "必須列不足", data: "employee_code,last_name,first_name\nN001,山田,花子\n"},
+		{name: "形式不正", data: "employee_code,last_name,first_name,team_code\nN001,\"山田,花子,W3E\n"},
+		{name: "壊れたxlsx", data: "PK\x03\x04broken"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newStaffImportFixture()
+			orgID := tt.orgID
+			if orgID == "" {
+				orgID = f.orgID.String()
+			}
+			_, err := f.useCase.Import(context.Background(), &ImportStaffsInput{OrganizationID: orgID, Data: []byte(tt.data)})
+			var domainErr *sharedDomain.DomainError
+			if !errors.As(err, &domainErr) || domainErr.Code != sharedDomain.ErrCodeValidation {
+				t.Errorf("error = %v, want validation error", err)
+			}
+		})
+	}
+}
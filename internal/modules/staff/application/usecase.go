@@ -36,7 +36,12 @@ func NewStaffUseCase(
 
 // verifyStaffBelongsToOrganization スタッフが指定組織に属しているか検証
 func (u *StaffUseCase) verifyStaffBelongsToOrganization(ctx context.Context, staff *domain.Staff, orgID sharedDomain.ID) error {
-	team, err := u.teamRepo.FindByID(ctx, staff.TeamID)
+	return verifyStaffOrganization(ctx, u.teamRepo, u.deptRepo, staff, orgID)
+}
+
+// verifyStaffOrganization スタッフの所属チームの部署が指定組織に属しているか検証
+func verifyStaffOrganization(ctx context.Context, teamRepo domain.TeamRepository, deptRepo domain.DepartmentRepository, staff *domain.Staff, orgID sharedDomain.ID) error {
+	team, err := teamRepo.FindByID(ctx, staff.TeamID)
 	if err != nil {
 		return err
 	}
@@ -44,7 +49,7 @@ func (u *StaffUseCase) verifyStaffBelongsToOrganization(ctx context.Context, sta
 		return sharedDomain.NewDomainError(sharedDomain.ErrCodeNotFound, "チームが見つかりません")
 	}
 
-	dept, err := u.deptRepo.FindByID(ctx, team.DepartmentID)
+	dept, err := deptRepo.FindByID(ctx, team.DepartmentID)
 	if err != nil {
 		return err
 	}
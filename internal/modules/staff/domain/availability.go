@@ -0,0 +1,294 @@
+// Package domain スタッフドメイン層
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"shiftmaster/internal/shared/domain"
+)
+
+const (
+	// MaxAvailabilityNoteLength 勤務可能条件の備考の最大文字数
+	MaxAvailabilityNoteLength = 200
+	// MaxAvailabilityExceptionDays 期間例外の最大日数
+	MaxAvailabilityExceptionDays = 366
+)
+
+// weekdayNames 曜日の表示名 time.Weekday順
+var weekdayNames = []string{"日", "月", "火", "水", "木", "金", "土"}
+
+// WeekdayName 曜日の表示名
+func WeekdayName(w time.Weekday) string {
+	if w < time.Sunday || w > time.Saturday {
+		return ""
+	}
+	return weekdayNames[w]
+}
+
+// StaffAvailability スタッフの勤務可能条件
+// 曜日ごとの勤務可否と時間帯、勤務可能なシフト種別、週の上限勤務日数を定め、期間例外で上書きする
+type StaffAvailability struct {
+	// StaffID スタッフID
+	StaffID domain.ID
+	// Weekly 曜日ごとの条件 指定のない曜日は制限なし
+	Weekly []WeeklyAvailability
+	// AllowedShiftTypeIDs 勤務可能なシフト種別 空は制限なし
+	AllowedShiftTypeIDs []domain.ID
+	// MaxDaysPerWeek 週（月曜始まり）の上限勤務日数 0は制限なし
+	MaxDaysPerWeek int
+	// Exceptions 期間例外 開始日順
+	Exceptions []AvailabilityException
+	// Note 備考
+	Note string
+	// UpdatedBy 更新したユーザーのメールアドレス
+	UpdatedBy string
+	// CreatedAt 作成日時
+	CreatedAt time.Time
+	// UpdatedAt 更新日時
+	UpdatedAt time.Time
+}
+
+// WeeklyAvailability 曜日ごとの勤務可能条件
+type WeeklyAvailability struct {
+	// Weekday 曜日
+	Weekday time.Weekday
+	// Unavailable 終日勤務不可
+	Unavailable bool
+	// From この時刻以降に始まる勤務のみ可 nilは制限なし
+	From *domain.TimeOfDay
+	// Until この時刻までに終わる勤務のみ可 nilは制限なし
+	Until *domain.TimeOfDay
+}
+
+// AvailabilityException 勤務可能条件の期間例外
+// 勤務不可の例外は期間中終日勤務不可、勤務可の例外は期間中曜日ごとの条件を適用しない
+type AvailabilityException struct {
+	// ID 一意識別子
+	ID domain.ID
+	// StaffID スタッフID
+	StaffID domain.ID
+	// StartDate 開始日
+	StartDate time.Time
+	// EndDate 終了日
+	EndDate time.Time
+	// Available 勤務可の例外
+	Available bool
+	// Reason 理由
+	Reason string
+	// CreatedBy 登録したユーザーのメールアドレス
+	CreatedBy string
+	// CreatedAt 作成日時
+	CreatedAt time.Time
+}
+
+// Covers 指定日が期間内か判定
+func (e *AvailabilityException) Covers(date time.Time) bool {
+	d := dateOnly(date)
+	return !d.Before(dateOnly(e.StartDate)) && !d.After(dateOnly(e.EndDate))
+}
+
+// NewStaffAvailability 勤務可能条件生成 制限なしで作成する
+func NewStaffAvailability(staffID domain.ID) *StaffAvailability {
+	now := time.Now()
+	return &StaffAvailability{
+		StaffID:   staffID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Update 曜日ごとの条件・シフト種別・週の上限勤務日数を更新
+func (a *StaffAvailability) Update(weekly []WeeklyAvailability, allowedShiftTypeIDs []domain.ID, maxDaysPerWeek int, note, updatedBy string) error {
+	seen := make(map[time.Weekday]bool, len(weekly))
+	for _, w := range weekly {
+		if w.Weekday < time.Sunday || w.Weekday > time.Saturday {
+			return domain.NewDomainError(domain.ErrCodeValidation, "曜日が不正です")
+		}
+		if seen[w.Weekday] {
+			return domain.NewDomainError(domain.ErrCodeValidation, fmt.Sprintf("%s曜日の条件が重複しています", WeekdayName(w.Weekday)))
+		}
+		seen[w.Weekday] = true
+		if w.From != nil && w.Until != nil && w.From.ToMinutes() >= w.Until.ToMinutes() {
+			return domain.NewDomainError(domain.ErrCodeValidation, fmt.Sprintf("%s曜日の勤務可能時間は開始より後に終了を設定してください", WeekdayName(w.Weekday)))
+		}
+	}
+	if maxDaysPerWeek < 0 || maxDaysPerWeek > 7 {
+		return domain.NewDomainError(domain.ErrCodeValidation, "週の上限勤務日数は0から7の範囲で指定してください")
+	}
+	if len([]rune(note)) > MaxAvailabilityNoteLength {
+		return domain.NewDomainError(domain.ErrCodeValidation, fmt.Sprintf("備考は%d文字以内で入力してください", MaxAvailabilityNoteLength))
+	}
+
+	a.Weekly = weekly
+	a.AllowedShiftTypeIDs = allowedShiftTypeIDs
+	a.MaxDaysPerWeek = maxDaysPerWeek
+	a.Note = note
+	a.UpdatedBy = updatedBy
+	a.UpdatedAt = time.Now()
+	return nil
+}
+
+// AddException 期間例外を追加 期間が重複する例外は登録できない
+func (a *StaffAvailability) AddException(startDate, endDate time.Time, available bool, reason, createdBy string) (*AvailabilityException, error) {
+	startDate = dateOnly(startDate)
+	endDate = dateOnly(endDate)
+	if endDate.Before(startDate) {
+		return nil, domain.NewDomainError(domain.ErrCodeValidation, "終了日は開始日以降に設定してください")
+	}
+	if int(endDate.Sub(startDate).Hours()/24)+1 > MaxAvailabilityExceptionDays {
+		return nil, domain.NewDomainError(domain.ErrCodeValidation, fmt.Sprintf("期間は%d日以内で指定してください", MaxAvailabilityExceptionDays))
+	}
+	if len([]rune(reason)) > MaxAvailabilityNoteLength {
+		return nil, domain.NewDomainError(domain.ErrCodeValidation, fmt.Sprintf("理由は%d文字以内で入力してください", MaxAvailabilityNoteLength))
+	}
+	for i := range a.Exceptions {
+		e := &a.Exceptions[i]
+		if !startDate.After(dateOnly(e.EndDate)) && !endDate.Before(dateOnly(e.StartDate)) {
+			return nil, domain.NewDomainError(domain.ErrCodeConflict, "期間が重複する例外が登録されています")
+		}
+	}
+
+	exception := AvailabilityException{
+		ID:        domain.NewID(),
+		StaffID:   a.StaffID,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Available: available,
+		Reason:    reason,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+	}
+	pos := len(a.Exceptions)
+	for i := range a.Exceptions {
+		if startDate.Before(a.Exceptions[i].StartDate) {
+			pos = i
+			break
+		}
+	}
+	a.Exceptions = append(a.Exceptions, AvailabilityException{})
+	copy(a.Exceptions[pos+1:], a.Exceptions[pos:])
+	a.Exceptions[pos] = exception
+	a.UpdatedAt = time.Now()
+	return &a.Exceptions[pos], nil
+}
+
+// RemoveException 期間例外を削除
+func (a *StaffAvailability) RemoveException(id domain.ID) error {
+	for i := range a.Exceptions {
+		if a.Exceptions[i].ID == id {
+			a.Exceptions = append(a.Exceptions[:i], a.Exceptions[i+1:]...)
+			a.UpdatedAt = time.Now()
+			return nil
+		}
+	}
+	return domain.NewDomainError(domain.ErrCodeNotFound, "期間例外が見つかりません")
+}
+
+// WeeklyOn 指定曜日の条件 指定のない曜日はnil
+func (a *StaffAvailability) WeeklyOn(weekday time.Weekday) *WeeklyAvailability {
+	for i := range a.Weekly {
+		if a.Weekly[i].Weekday == weekday {
+			return &a.Weekly[i]
+		}
+	}
+	return nil
+}
+
+// ExceptionOn 指定日を含む期間例外 該当なしはnil
+func (a *StaffAvailability) ExceptionOn(date time.Time) *AvailabilityException {
+	for i := range a.Exceptions {
+		if a.Exceptions[i].Covers(date) {
+			return &a.Exceptions[i]
+		}
+	}
+	return nil
+}
+
+// AllowsShiftType 勤務可能なシフト種別か判定
+func (a *StaffAvailability) AllowsShiftType(shiftTypeID domain.ID) bool {
+	if len(a.AllowedShiftTypeIDs) == 0 {
+		return true
+	}
+	for _, id := range a.AllowedShiftTypeIDs {
+		if id == shiftTypeID {
+			return true
+		}
+	}
+	return false
+}
+
+// ExceedsDaysPerWeek 週の勤務日数が上限を超えるか判定
+func (a *StaffAvailability) ExceedsDaysPerWeek(days int) bool {
+	return a.MaxDaysPerWeek > 0 && days > a.MaxDaysPerWeek
+}
+
+// ConflictOn 指定日の勤務が勤務可能条件に反する理由 反しない場合は空文字
+// startMinutes・endMinutesは勤務日の0時からの分数 日をまたぐ勤務の終了は1440以上になる
+func (a *StaffAvailability) ConflictOn(date time.Time, shiftTypeID domain.ID, startMinutes, endMinutes int) string {
+	exception := a.ExceptionOn(date)
+	if exception != nil && !exception.Available {
+		if exception.Reason != "" {
+			return fmt.Sprintf("勤務不可の期間です（%s）", exception.Reason)
+		}
+		return "勤務不可の期間です"
+	}
+	if !a.AllowsShiftType(shiftTypeID) {
+		return "勤務可能なシフト種別ではありません"
+	}
+	if exception != nil {
+		return ""
+	}
+
+	w := a.WeeklyOn(date.Weekday())
+	if w == nil {
+		return ""
+	}
+	name := WeekdayName(w.Weekday)
+	switch {
+	case w.Unavailable:
+		return fmt.Sprintf("%s曜日は勤務不可です", name)
+	case w.From != nil && startMinutes < w.From.ToMinutes():
+		return fmt.Sprintf("%s曜日は%s以降に始まる勤務のみ可能です", name, w.From)
+	case w.Until != nil && endMinutes > w.Until.ToMinutes():
+		return fmt.Sprintf("%s曜日は%sまでに終わる勤務のみ可能です", name, w.Until)
+	}
+	return ""
+}
+
+// IsUnrestricted 制限が何も設定されていないか判定
+func (a *StaffAvailability) IsUnrestricted() bool {
+	return len(a.Weekly) == 0 && len(a.AllowedShiftTypeIDs) == 0 && a.MaxDaysPerWeek == 0 && len(a.Exceptions) == 0
+}
+
+// dateOnly 時刻を切り捨てた日付
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// StaffAvailabilityRepository 勤務可能条件リポジトリインターフェース
+type StaffAvailabilityRepository interface {
+	// FindByStaffID スタッフIDで検索 未登録はnil
+	FindByStaffID(ctx context.Context, staffID domain.ID) (*StaffAvailability, error)
+	// FindByStaffIDs 複数スタッフIDで検索 未登録のスタッフは含まない
+	FindByStaffIDs(ctx context.Context, staffIDs []domain.ID) ([]StaffAvailability, error)
+	// Save 保存 期間例外は置き換える
+	Save(ctx context.Context, availability *StaffAvailability) error
+}
+
+// ShiftTypeOption 勤務可能条件で選択するシフト種別
+type ShiftTypeOption struct {
+	// ID シフト種別ID
+	ID domain.ID
+	// Name 名称
+	Name string
+	// TimeRange 勤務時間 表示用
+	TimeRange string
+}
+
+// ShiftTypeOptionFinder 勤務可能条件で選択するシフト種別の取得 休日扱いのシフト種別は含まない
+type ShiftTypeOptionFinder interface {
+	// FindByOrganizationID 組織IDで検索
+	FindByOrganizationID(ctx context.Context, orgID domain.ID) ([]ShiftTypeOption, error)
+}
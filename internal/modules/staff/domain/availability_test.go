@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"shiftmaster/internal/shared/domain"
+)
+
+func availabilityTime(s string) *domain.TimeOfDay {
+	t := domain.MustParseTimeOfDay(s)
+	return &t
+}
+
+func TestStaffAvailability_Update(t *testing.T) {
+	tests := []struct {
+		name    string
+		weekly  []WeeklyAvailability
+		maxDays int
+		note    string
+		wantErr bool
+	}{
+		{"正常系_制限なし", nil, 0, "", false},
+		{"正常系_曜日と時間帯", []WeeklyAvailability{
+			{Weekday: time.Tuesday, Unavailable: true},
+			{Weekday: time.Wednesday, From: availabilityTime("09:00"), Until: availabilityTime("17:00")},
+		}, 3, "学生", false},
+		{"異常系_曜日の重複", []WeeklyAvailability{{Weekday: time.Monday}, {Weekday: time.Monday}}, 0, "", true},
+		{"異常系_不正な曜日", []WeeklyAvailability{{Weekday: time.Weekday(7)}}, 0, "", true},
+		{"異常系_終了が開始以前", []WeeklyAvailability{
+			{Weekday: time.Monday, From: availabilityTime("17:00"), Until: availabilityTime("09:00")},
+		}, 0, "", true},
+		{"異常系_上限日数が範囲外", nil, 8, "", true},
+		{"異常系_上限日数が負", nil, -1, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewStaffAvailability(domain.NewID())
+			err := a.Update(tt.weekly, nil, tt.maxDays, tt.note, "staff@example.com")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Update() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && a.MaxDaysPerWeek != tt.maxDays {
+				t.Errorf("MaxDaysPerWeek = %d, want %d", a.MaxDaysPerWeek, tt.maxDays)
+			}
+		})
+	}
+}
+
+func TestStaffAvailability_AddException(t *testing.T) {
+	a := NewStaffAvailability(domain.NewID())
+	date := func(d int) time.Time { return time.Date(2026, 8, d, 0, 0, 0, 0, time.UTC) }
+
+	if _, err := a.AddException(date(10), date(20), false, "帰省", "staff@example.com"); err != nil {
+		t.Fatalf("AddException() error = %v", err)
+	}
+	if _, err := a.AddException(date(1), date(3), true, "", "staff@example.com"); err != nil {
+		t.Fatalf("AddException() error = %v", err)
+	}
+	if a.Exceptions[0].StartDate != date(1) {
+		t.Errorf("Exceptions[0].StartDate = %v, want %v", a.Exceptions[0].StartDate, date(1))
+	}
+
+	var domainErr *domain.DomainError
+	_, err := a.AddException(date(20), date(22), false, "", "")
+	if !errors.As(err, &domainErr) || domainErr.Code != domain.ErrCodeConflict {
+		t.Errorf("重複する期間 error = %v, want conflict", err)
+	}
+	if _, err := a.AddException(date(5), date(4), false, "", ""); err == nil {
+		t.Error("終了日が開始日より前の期間でエラーにならない")
+	}
+	if _, err := a.AddException(date(1), date(1).AddDate(1, 1, 0), false, "", ""); err == nil {
+		t.Error("366日を超える期間でエラーにならない")
+	}
+
+	id := a.Exceptions[0].ID
+	if err := a.RemoveException(id); err != nil {
+		t.Fatalf("RemoveException() error = %v", err)
+	}
+	if len(a.Exceptions) != 1 {
+		t.Errorf("len(Exceptions) = %d, want 1", len(a.Exceptions))
+	}
+	if err := a.RemoveException(id); !errors.As(err, &domainErr) || domainErr.Code != domain.ErrCodeNotFound {
+		t.Errorf("削除済みの例外 error = %v, want not found", err)
+	}
+}
+
+func TestStaffAvailability_ConflictOn(t *testing.T) {
+	dayShift := domain.NewID()
+	nightShift := domain.NewID()
+
+	a := NewStaffAvailability(domain.NewID())
+	if err := a.Update([]WeeklyAvailability{
+		{Weekday: time.Tuesday, Unavailable: true},
+		{Weekday: time.Wednesday, Until: availabilityTime("17:00")},
+		{Weekday: time.Thursday, From: availabilityTime("10:00")},
+	}, []domain.ID{dayShift}, 3, "", ""); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	// 2026-08-04は火曜日
+	tuesday := time.Date(2026, 8, 4, 0, 0, 0, 0, time.UTC)
+	if _, err := a.AddException(tuesday.AddDate(0, 0, 7), tuesday.AddDate(0, 0, 7), true, "", ""); err != nil {
+		t.Fatalf("AddException() error = %v", err)
+	}
+	if _, err := a.AddException(tuesday.AddDate(0, 0, -1), tuesday.AddDate(0, 0, -1), false, "通院", ""); err != nil {
+		t.Fatalf("AddException() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		date      time.Time
+		shiftType domain.ID
+		start     int
+		end       int
+		want      bool
+	}{
+		{"勤務不可の曜日", tuesday, dayShift, 540, 1020, true},
+		{"終了時刻以内", tuesday.AddDate(0, 0, 1), dayShift, 540, 1020, false},
+		{"終了時刻を超える", tuesday.AddDate(0, 0, 1), dayShift, 540, 1080, true},
+		{"開始時刻より前", tuesday.AddDate(0, 0, 2), dayShift, 540, 1020, true},
+		{"開始時刻以降", tuesday.AddDate(0, 0, 2), dayShift, 600, 1020, false},
+		{"条件のない曜日", tuesday.AddDate(0, 0, 3), dayShift, 0, 1440, false},
+		{"勤務可能でないシフト種別", tuesday.AddDate(0, 0, 3), nightShift, 960, 1980, true},
+		{"勤務可の例外で曜日の条件を適用しない", tuesday.AddDate(0, 0, 7), dayShift, 540, 1020, false},
+		{"勤務可の例外でもシフト種別は制限する", tuesday.AddDate(0, 0, 7), nightShift, 960, 1980, true},
+		{"勤務不可の例外", tuesday.AddDate(0, 0, -1), dayShift, 540, 1020, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := a.ConflictOn(tt.date, tt.shiftType, tt.start, tt.end)
+			if (got != "") != tt.want {
+				t.Errorf("ConflictOn() = %q, want conflict %v", got, tt.want)
+			}
+		})
+	}
+
+	if !a.ExceedsDaysPerWeek(4) || a.ExceedsDaysPerWeek(3) {
+		t.Error("ExceedsDaysPerWeek() 上限3日の判定が不正")
+	}
+}
@@ -52,6 +52,16 @@ func (s *Staff) HasSkill(skillID domain.ID) bool {
 	return false
 }
 
+// HasSkillAtLevel 指定スキルを指定レベル以上で保有しているか判定
+func (s *Staff) HasSkillAtLevel(skillID domain.ID, minLevel int) bool {
+	for _, skill := range s.Skills {
+		if skill.SkillID == skillID {
+			return skill.Level >= minLevel
+		}
+	}
+	return false
+}
+
 // EmploymentType 雇用形態
 type EmploymentType string
 
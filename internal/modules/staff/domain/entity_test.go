@@ -108,6 +108,31 @@ func TestStaff_HasSkill(t *testing.T) {
 	})
 }
 
+func TestStaff_HasSkillAtLevel(t *testing.T) {
+	skillID := sharedDomain.NewID()
+	staff := &Staff{Skills: []StaffSkill{{SkillID: skillID, Level: 3}}}
+
+	tests := []struct {
+		name     string
+		skillID  sharedDomain.ID
+		minLevel int
+		want     bool
+	}{
+		{"レベル不問", skillID, 0, true},
+		{"必要レベルと同じ", skillID, 3, true},
+		{"必要レベル未満", skillID, 4, false},
+		{"未保有スキル", sharedDomain.NewID(), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := staff.HasSkillAtLevel(tt.skillID, tt.minLevel); got != tt.want {
+				t.Errorf("HasSkillAtLevel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestStaff_Structure(t *testing.T) {
 	t.Run("Staff構造体の完全な初期化", func(t *testing.T) {
 		staffID := sharedDomain.NewID()
@@ -95,6 +95,11 @@ func (p *Position) IsEqualOrHigherThan(other *Position) bool {
 	return p.Level <= other.Level
 }
 
+// IsAtLevelOrHigher 指定レベルと同等以上の職位か判定 数値が小さいほど上位
+func (p *Position) IsAtLevelOrHigher(level int) bool {
+	return p.Level <= level
+}
+
 // Deactivate 無効化
 func (p *Position) Deactivate() {
 	p.IsActive = false
@@ -135,6 +135,28 @@ func TestPosition_IsEqualOrHigherThan(t *testing.T) {
 	}
 }
 
+func TestPosition_IsAtLevelOrHigher(t *testing.T) {
+	manager, _ := NewPosition(domain.NewID(), "主任", "MGR", 2)
+
+	tests := []struct {
+		name  string
+		level int
+		want  bool
+	}{
+		{"下位レベル指定", 3, true},
+		{"同じレベル", 2, true},
+		{"上位レベル指定", 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := manager.IsAtLevelOrHigher(tt.level); got != tt.want {
+				t.Errorf("IsAtLevelOrHigher() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestPosition_Deactivate(t *testing.T) {
 	orgID := domain.NewID()
 	pos, _ := NewPosition(orgID, "師長", "DIR", 1)
@@ -83,3 +83,11 @@ type SkillRepository interface {
 	// Delete 削除
 	Delete(ctx context.Context, id sharedDomain.ID) error
 }
+
+// StaffImportRepository スタッフ一括登録リポジトリインターフェース
+type StaffImportRepository interface {
+	// FindEmployeeCodes 組織内の登録済み社員番号 無効スタッフも含む
+	FindEmployeeCodes(ctx context.Context, organizationID sharedDomain.ID) ([]string, error)
+	// CreateAll スタッフと所属を1トランザクションで作成 失敗時は何も保存しない
+	CreateAll(ctx context.Context, staffs []Staff, assignments []StaffAssignment) error
+}
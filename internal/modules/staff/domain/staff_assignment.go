@@ -105,6 +105,8 @@ type StaffAssignmentRepository interface {
 	FindByID(ctx interface{}, id domain.ID) (*StaffAssignment, error)
 	// FindByStaffID スタッフID検索
 	FindByStaffID(ctx interface{}, staffID domain.ID) ([]StaffAssignment, error)
+	// FindByStaffIDs 複数スタッフID検索
+	FindByStaffIDs(ctx interface{}, staffIDs []domain.ID) ([]StaffAssignment, error)
 	// FindActiveByStaffID スタッフIDで有効な所属を検索
 	FindActiveByStaffID(ctx interface{}, staffID domain.ID, date time.Time) ([]StaffAssignment, error)
 	// FindByTeamID チームID検索
@@ -0,0 +1,254 @@
+// Package infrastructure スタッフインフラストラクチャ層
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"shiftmaster/internal/modules/staff/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+	"shiftmaster/internal/shared/infrastructure"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// StaffAvailabilityModel 勤務可能条件DBモデル
+type StaffAvailabilityModel struct {
+	bun.BaseModel `bun:"table:staff_availabilities"`
+
+	StaffID             uuid.UUID                `bun:"staff_id,pk,type:uuid"`
+	Weekly              []WeeklyAvailabilityJSON `bun:"weekly,type:jsonb,notnull"`
+	AllowedShiftTypeIDs []string                 `bun:"allowed_shift_type_ids,type:jsonb,notnull"`
+	MaxDaysPerWeek      int                      `bun:"max_days_per_week,notnull"`
+	Note                string                   `bun:"note,notnull"`
+	UpdatedBy           string                   `bun:"updated_by,notnull"`
+	CreatedAt           time.Time                `bun:"created_at,notnull"`
+	UpdatedAt           time.Time                `bun:"updated_at,notnull"`
+}
+
+// WeeklyAvailabilityJSON 曜日ごとの勤務可能条件JSON
+type WeeklyAvailabilityJSON struct {
+	Weekday     int    `json:"weekday"`
+	Unavailable bool   `json:"unavailable"`
+	From        string `json:"from,omitempty"`
+	Until       string `json:"until,omitempty"`
+}
+
+// StaffAvailabilityExceptionModel 勤務可能条件の期間例外DBモデル
+type StaffAvailabilityExceptionModel struct {
+	bun.BaseModel `bun:"table:staff_availability_exceptions"`
+
+	ID        uuid.UUID `bun:"id,pk,type:uuid"`
+	StaffID   uuid.UUID `bun:"staff_id,type:uuid,notnull"`
+	StartDate time.Time `bun:"start_date,type:date,notnull"`
+	EndDate   time.Time `bun:"end_date,type:date,notnull"`
+	Available bool      `bun:"available,notnull"`
+	Reason    string    `bun:"reason,notnull"`
+	CreatedBy string    `bun:"created_by,notnull"`
+	CreatedAt time.Time `bun:"created_at,notnull"`
+}
+
+// ToDomain DBモデルからドメインエンティティへ変換
+func (m *StaffAvailabilityModel) ToDomain(exceptions []StaffAvailabilityExceptionModel) *domain.StaffAvailability {
+	weekly := make([]domain.WeeklyAvailability, 0, len(m.Weekly))
+	for _, w := range m.Weekly {
+		weekly = append(weekly, domain.WeeklyAvailability{
+			Weekday:     time.Weekday(w.Weekday),
+			Unavailable: w.Unavailable,
+			From:        parseOptionalTime(w.From),
+			Until:       parseOptionalTime(w.Until),
+		})
+	}
+	allowed := make([]sharedDomain.ID, 0, len(m.AllowedShiftTypeIDs))
+	for _, s := range m.AllowedShiftTypeIDs {
+		id, err := sharedDomain.ParseID(s)
+		if err != nil {
+			continue
+		}
+		allowed = append(allowed, id)
+	}
+	result := make([]domain.AvailabilityException, len(exceptions))
+	for i, e := range exceptions {
+		result[i] = domain.AvailabilityException{
+			ID:        e.ID,
+			StaffID:   e.StaffID,
+			StartDate: e.StartDate,
+			EndDate:   e.EndDate,
+			Available: e.Available,
+			Reason:    e.Reason,
+			CreatedBy: e.CreatedBy,
+			CreatedAt: e.CreatedAt,
+		}
+	}
+
+	return &domain.StaffAvailability{
+		StaffID:             m.StaffID,
+		Weekly:              weekly,
+		AllowedShiftTypeIDs: allowed,
+		MaxDaysPerWeek:      m.MaxDaysPerWeek,
+		Exceptions:          result,
+		Note:                m.Note,
+		UpdatedBy:           m.UpdatedBy,
+		CreatedAt:           m.CreatedAt,
+		UpdatedAt:           m.UpdatedAt,
+	}
+}
+
+// FromDomain ドメインエンティティからDBモデルへ変換
+func (m *StaffAvailabilityModel) FromDomain(a *domain.StaffAvailability) {
+	m.StaffID = a.StaffID
+	m.Weekly = make([]WeeklyAvailabilityJSON, len(a.Weekly))
+	for i, w := range a.Weekly {
+		m.Weekly[i] = WeeklyAvailabilityJSON{Weekday: int(w.Weekday), Unavailable: w.Unavailable}
+		if w.From != nil {
+			m.Weekly[i].From = w.From.String()
+		}
+		if w.Until != nil {
+			m.Weekly[i].Until = w.Until.String()
+		}
+	}
+	m.AllowedShiftTypeIDs = make([]string, len(a.AllowedShiftTypeIDs))
+	for i, id := range a.AllowedShiftTypeIDs {
+		m.AllowedShiftTypeIDs[i] = id.String()
+	}
+	m.MaxDaysPerWeek = a.MaxDaysPerWeek
+	m.Note = a.Note
+	m.UpdatedBy = a.UpdatedBy
+	m.CreatedAt = a.CreatedAt
+	m.UpdatedAt = a.UpdatedAt
+}
+
+// parseOptionalTime 空文字はnil 不正な時刻は制限なしとして扱う
+func parseOptionalTime(s string) *sharedDomain.TimeOfDay {
+	if s == "" {
+		return nil
+	}
+	t, err := sharedDomain.ParseTimeOfDay(s)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// PostgresStaffAvailabilityRepository PostgreSQL勤務可能条件リポジトリ
+type PostgresStaffAvailabilityRepository struct {
+	db *bun.DB
+}
+
+// NewPostgresStaffAvailabilityRepository リポジトリ生成
+func NewPostgresStaffAvailabilityRepository(db *bun.DB) *PostgresStaffAvailabilityRepository {
+	return &PostgresStaffAvailabilityRepository{db: db}
+}
+
+// FindByStaffID スタッフIDで検索 未登録はnil
+func (r *PostgresStaffAvailabilityRepository) FindByStaffID(ctx context.Context, staffID sharedDomain.ID) (*domain.StaffAvailability, error) {
+	var model StaffAvailabilityModel
+	err := r.db.NewSelect().Model(&model).Where("staff_id = ?", staffID).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var exceptions []StaffAvailabilityExceptionModel
+	err = r.db.NewSelect().
+		Model(&exceptions).
+		Where("staff_id = ?", staffID).
+		Order("start_date ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return model.ToDomain(exceptions), nil
+}
+
+// FindByStaffIDs 複数スタッフIDで検索 未登録のスタッフは含まない
+func (r *PostgresStaffAvailabilityRepository) FindByStaffIDs(ctx context.Context, staffIDs []sharedDomain.ID) ([]domain.StaffAvailability, error) {
+	if len(staffIDs) == 0 {
+		return []domain.StaffAvailability{}, nil
+	}
+
+	var models []StaffAvailabilityModel
+	err := r.db.NewSelect().
+		Model(&models).
+		Where("staff_id IN (?)", bun.In(staffIDs)).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(models) == 0 {
+		return []domain.StaffAvailability{}, nil
+	}
+
+	var exceptions []StaffAvailabilityExceptionModel
+	err = r.db.NewSelect().
+		Model(&exceptions).
+		Where("staff_id IN (?)", bun.In(staffIDs)).
+		Order("staff_id ASC", "start_date ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byStaff := make(map[uuid.UUID][]StaffAvailabilityExceptionModel)
+	for _, e := range exceptions {
+		byStaff[e.StaffID] = append(byStaff[e.StaffID], e)
+	}
+
+	result := make([]domain.StaffAvailability, len(models))
+	for i := range models {
+		result[i] = *models[i].ToDomain(byStaff[models[i].StaffID])
+	}
+	return result, nil
+}
+
+// Save 保存 勤務可能条件を更新し期間例外を置き換える
+func (r *PostgresStaffAvailabilityRepository) Save(ctx context.Context, availability *domain.StaffAvailability) error {
+	model := &StaffAvailabilityModel{}
+	model.FromDomain(availability)
+	exceptions := make([]StaffAvailabilityExceptionModel, len(availability.Exceptions))
+	for i, e := range availability.Exceptions {
+		exceptions[i] = StaffAvailabilityExceptionModel{
+			ID:        e.ID,
+			StaffID:   availability.StaffID,
+			StartDate: e.StartDate,
+			EndDate:   e.EndDate,
+			Available: e.Available,
+			Reason:    e.Reason,
+			CreatedBy: e.CreatedBy,
+			CreatedAt: e.CreatedAt,
+		}
+	}
+
+	return infrastructure.RunInTransaction(ctx, r.db, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewInsert().
+			Model(model).
+			On("CONFLICT (staff_id) DO UPDATE").
+			Set("weekly = EXCLUDED.weekly").
+			Set("allowed_shift_type_ids = EXCLUDED.allowed_shift_type_ids").
+			Set("max_days_per_week = EXCLUDED.max_days_per_week").
+			Set("note = EXCLUDED.note").
+			Set("updated_by = EXCLUDED.updated_by").
+			Set("updated_at = EXCLUDED.updated_at").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.NewDelete().
+			Model((*StaffAvailabilityExceptionModel)(nil)).
+			Where("staff_id = ?", availability.StaffID).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if len(exceptions) == 0 {
+			return nil
+		}
+		_, err = tx.NewInsert().Model(&exceptions).Exec(ctx)
+		return err
+	})
+}
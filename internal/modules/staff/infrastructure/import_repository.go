@@ -0,0 +1,63 @@
+// Package infrastructure スタッフインフラストラクチャ層
+package infrastructure
+
+import (
+	"context"
+
+	"shiftmaster/internal/modules/staff/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+	"shiftmaster/internal/shared/infrastructure"
+
+	"github.com/uptrace/bun"
+)
+
+// PostgresStaffImportRepository PostgreSQLスタッフ一括登録リポジトリ
+type PostgresStaffImportRepository struct {
+	db *bun.DB
+}
+
+// NewPostgresStaffImportRepository リポジトリ生成
+func NewPostgresStaffImportRepository(db *bun.DB) *PostgresStaffImportRepository {
+	return &PostgresStaffImportRepository{db: db}
+}
+
+// FindEmployeeCodes 組織内の登録済み社員番号 無効スタッフも含む
+func (r *PostgresStaffImportRepository) FindEmployeeCodes(ctx context.Context, organizationID sharedDomain.ID) ([]string, error) {
+	var codes []string
+	err := r.db.NewSelect().
+		TableExpr("staffs AS s").
+		ColumnExpr("s.employee_code").
+		Join("INNER JOIN teams AS t ON t.id = s.team_id").
+		Join("INNER JOIN departments AS d ON d.id = t.department_id").
+		Where("d.organization_id = ?", organizationID).
+		Where("s.employee_code IS NOT NULL AND s.employee_code <> ''").
+		Scan(ctx, &codes)
+	return codes, err
+}
+
+// CreateAll スタッフと所属を1トランザクションで作成 失敗時は何も保存しない
+func (r *PostgresStaffImportRepository) CreateAll(ctx context.Context, staffs []domain.Staff, assignments []domain.StaffAssignment) error {
+	if len(staffs) == 0 {
+		return nil
+	}
+
+	staffModels := make([]StaffModel, len(staffs))
+	for i := range staffs {
+		staffModels[i].FromDomain(&staffs[i])
+	}
+	assignmentModels := make([]StaffAssignmentModel, len(assignments))
+	for i := range assignments {
+		assignmentModels[i].FromDomain(&assignments[i])
+	}
+
+	return infrastructure.RunInTransaction(ctx, r.db, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(&staffModels).Exec(ctx); err != nil {
+			return err
+		}
+		if len(assignmentModels) == 0 {
+			return nil
+		}
+		_, err := tx.NewInsert().Model(&assignmentModels).Exec(ctx)
+		return err
+	})
+}
@@ -67,6 +67,26 @@ func (m *StaffModel) FromDomain(staff *domain.Staff) {
 	m.UpdatedAt = staff.UpdatedAt
 }
 
+// StaffSkillModel スタッフスキルDBモデル
+type StaffSkillModel struct {
+	bun.BaseModel `bun:"table:staff_skills"`
+
+	StaffID    uuid.UUID  `bun:"staff_id,pk,type:uuid"`
+	SkillID    uuid.UUID  `bun:"skill_id,pk,type:uuid"`
+	Level      int        `bun:"level,notnull"`
+	AcquiredAt *time.Time `bun:"acquired_at,type:date"`
+}
+
+// ToDomain DBモデルからドメインエンティティへ変換
+func (m *StaffSkillModel) ToDomain() domain.StaffSkill {
+	return domain.StaffSkill{
+		StaffID:    m.StaffID,
+		SkillID:    m.SkillID,
+		Level:      m.Level,
+		AcquiredAt: m.AcquiredAt,
+	}
+}
+
 // PostgresStaffRepository PostgreSQLスタッフリポジトリ
 type PostgresStaffRepository struct {
 	db *bun.DB
@@ -87,7 +107,13 @@ func (r *PostgresStaffRepository) FindByID(ctx context.Context, id sharedDomain.
 		}
 		return nil, err
 	}
-	return model.ToDomain(), nil
+
+	staff := model.ToDomain()
+	staffs := []domain.Staff{*staff}
+	if err := r.loadSkills(ctx, staffs); err != nil {
+		return nil, err
+	}
+	return &staffs[0], nil
 }
 
 // FindAll 全件取得
@@ -180,6 +206,10 @@ func (r *PostgresStaffRepository) FindActive(ctx context.Context) ([]domain.Staf
 		staffs[i] = *m.ToDomain()
 	}
 
+	if err := r.loadSkills(ctx, staffs); err != nil {
+		return nil, err
+	}
+
 	return staffs, nil
 }
 
@@ -209,6 +239,10 @@ func (r *PostgresStaffRepository) FindActiveByOrganizationID(ctx context.Context
 		staffs[i] = *m.ToDomain()
 	}
 
+	if err := r.loadSkills(ctx, staffs); err != nil {
+		return nil, err
+	}
+
 	return staffs, nil
 }
 
@@ -241,6 +275,37 @@ func (r *PostgresStaffRepository) Delete(ctx context.Context, id sharedDomain.ID
 	return err
 }
 
+// loadSkills スタッフの保有スキルを一括で読み込む
+func (r *PostgresStaffRepository) loadSkills(ctx context.Context, staffs []domain.Staff) error {
+	if len(staffs) == 0 {
+		return nil
+	}
+
+	ids := make([]sharedDomain.ID, len(staffs))
+	for i, s := range staffs {
+		ids[i] = s.ID
+	}
+
+	var models []StaffSkillModel
+	err := r.db.NewSelect().
+		Model(&models).
+		Where("staff_id IN (?)", bun.In(ids)).
+		Scan(ctx)
+	if err != nil {
+		return err
+	}
+
+	skillsByStaff := make(map[sharedDomain.ID][]domain.StaffSkill)
+	for _, m := range models {
+		skillsByStaff[m.StaffID] = append(skillsByStaff[m.StaffID], m.ToDomain())
+	}
+	for i := range staffs {
+		staffs[i].Skills = skillsByStaff[staffs[i].ID]
+	}
+
+	return nil
+}
+
 // TeamModel チームDBモデル
 type TeamModel struct {
 	bun.BaseModel `bun:"table:teams"`
@@ -0,0 +1,135 @@
+// Package infrastructure スタッフインフラストラクチャ層
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"shiftmaster/internal/modules/staff/domain"
+	sharedDomain "shiftmaster/internal/shared/domain"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// SkillModel スキルDBモデル
+type SkillModel struct {
+	bun.BaseModel `bun:"table:skills"`
+
+	ID             uuid.UUID `bun:"id,pk,type:uuid"`
+	OrganizationID uuid.UUID `bun:"organization_id,type:uuid,notnull"`
+	Name           string    `bun:"name,notnull"`
+	Description    string    `bun:"description"`
+	Color          string    `bun:"color"`
+	CreatedAt      time.Time `bun:"created_at,notnull"`
+	UpdatedAt      time.Time `bun:"updated_at,notnull"`
+}
+
+// ToDomain DBモデルからドメインエンティティへ変換
+func (m *SkillModel) ToDomain() *domain.Skill {
+	return &domain.Skill{
+		ID:             m.ID,
+		OrganizationID: m.OrganizationID,
+		Name:           m.Name,
+		Description:    m.Description,
+		Color:          m.Color,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
+
+// FromDomain ドメインエンティティからDBモデルへ変換
+func (m *SkillModel) FromDomain(s *domain.Skill) {
+	m.ID = s.ID
+	m.OrganizationID = s.OrganizationID
+	m.Name = s.Name
+	m.Description = s.Description
+	m.Color = s.Color
+	m.CreatedAt = s.CreatedAt
+	m.UpdatedAt = s.UpdatedAt
+}
+
+// PostgresSkillRepository PostgreSQLスキルリポジトリ
+type PostgresSkillRepository struct {
+	db *bun.DB
+}
+
+// NewPostgresSkillRepository リポジトリ生成
+func NewPostgresSkillRepository(db *bun.DB) *PostgresSkillRepository {
+	return &PostgresSkillRepository{db: db}
+}
+
+// FindByID IDで検索
+func (r *PostgresSkillRepository) FindByID(ctx context.Context, id sharedDomain.ID) (*domain.Skill, error) {
+	model := &SkillModel{}
+	err := r.db.NewSelect().Model(model).Where("id = ?", id).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return model.ToDomain(), nil
+}
+
+// FindAll 全件取得
+func (r *PostgresSkillRepository) FindAll(ctx context.Context) ([]domain.Skill, error) {
+	var models []SkillModel
+	err := r.db.NewSelect().
+		Model(&models).
+		Order("name ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	skills := make([]domain.Skill, len(models))
+	for i, m := range models {
+		skills[i] = *m.ToDomain()
+	}
+	return skills, nil
+}
+
+// FindByOrganizationID 組織IDで検索
+func (r *PostgresSkillRepository) FindByOrganizationID(ctx context.Context, organizationID sharedDomain.ID) ([]domain.Skill, error) {
+	var models []SkillModel
+	err := r.db.NewSelect().
+		Model(&models).
+		Where("organization_id = ?", organizationID).
+		Order("name ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	skills := make([]domain.Skill, len(models))
+	for i, m := range models {
+		skills[i] = *m.ToDomain()
+	}
+	return skills, nil
+}
+
+// Save 保存
+func (r *PostgresSkillRepository) Save(ctx context.Context, skill *domain.Skill) error {
+	model := &SkillModel{}
+	model.FromDomain(skill)
+
+	_, err := r.db.NewInsert().
+		Model(model).
+		On("CONFLICT (id) DO UPDATE").
+		Set("name = EXCLUDED.name").
+		Set("description = EXCLUDED.description").
+		Set("color = EXCLUDED.color").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+
+	return err
+}
+
+// Delete 削除
+func (r *PostgresSkillRepository) Delete(ctx context.Context, id sharedDomain.ID) error {
+	_, err := r.db.NewDelete().Model((*SkillModel)(nil)).Where("id = ?", id).Exec(ctx)
+	return err
+}
@@ -127,6 +127,30 @@ func (r *PostgresStaffAssignmentRepository) FindByStaffID(ctx interface{}, staff
 	return result, nil
 }
 
+// FindByStaffIDs 複数スタッフID検索
+func (r *PostgresStaffAssignmentRepository) FindByStaffIDs(ctx interface{}, staffIDs []sharedDomain.ID) ([]domain.StaffAssignment, error) {
+	if len(staffIDs) == 0 {
+		return []domain.StaffAssignment{}, nil
+	}
+
+	c := ctx.(context.Context)
+	var models []StaffAssignmentModel
+	err := r.db.NewSelect().
+		Model(&models).
+		Where("staff_id IN (?)", bun.In(staffIDs)).
+		Order("staff_id ASC", "is_primary DESC", "created_at ASC").
+		Scan(c)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.StaffAssignment, len(models))
+	for i, m := range models {
+		result[i] = *m.ToDomain()
+	}
+	return result, nil
+}
+
 // FindActiveByStaffID スタッフIDで有効な所属を検索
 func (r *PostgresStaffAssignmentRepository) FindActiveByStaffID(ctx interface{}, staffID sharedDomain.ID, date time.Time) ([]domain.StaffAssignment, error) {
 	c := ctx.(context.Context)
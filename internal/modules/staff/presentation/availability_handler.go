@@ -0,0 +1,306 @@
+// Package presentation スタッフプレゼンテーション層
+package presentation
+
+import (
+	"encoding/json"
+	"errors"
+	"html"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"shiftmaster/internal/modules/staff/application"
+	sharedDomain "shiftmaster/internal/shared/domain"
+	"shiftmaster/internal/web"
+)
+
+// AvailabilityHandler 勤務可能条件HTTPハンドラー
+type AvailabilityHandler struct {
+	useCase   *application.StaffAvailabilityUseCase
+	templates *web.TemplateEngine
+	logger    *slog.Logger
+}
+
+// NewAvailabilityHandler ハンドラー生成
+func NewAvailabilityHandler(useCase *application.StaffAvailabilityUseCase, templates *web.TemplateEngine, logger *slog.Logger) *AvailabilityHandler {
+	return &AvailabilityHandler{
+		useCase:   useCase,
+		templates: templates,
+		logger:    logger,
+	}
+}
+
+// Show 勤務可能条件ページ 編集フォームは管理者とスタッフ本人のみ表示する
+func (h *AvailabilityHandler) Show(w http.ResponseWriter, r *http.Request) {
+	availability, err := h.useCase.Get(r.Context(), h.getOrganizationID(r), r.PathValue("id"), h.getActor(r))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	data := map[string]any{
+		"Title":        availability.StaffName + "の勤務可能条件",
+		"Availability": availability,
+	}
+	if err := h.templates.Render(w, "pages/staffs/availability.html", data); err != nil {
+		h.logger.Error("テンプレートレンダリング失敗", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// Update 勤務可能条件更新 フォーム送信
+// 曜日ごとの入力は unavailable_N・from_N・until_N（Nは0が日曜日）で受け取る
+func (h *AvailabilityHandler) Update(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	maxDays := 0
+	if v := r.FormValue("max_days_per_week"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			h.handleFormError(w, r, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "週の上限勤務日数は数値で入力してください"))
+			return
+		}
+		maxDays = n
+	}
+
+	input := &application.UpdateStaffAvailabilityInput{
+		OrganizationID:      h.getOrganizationID(r),
+		StaffID:             r.PathValue("id"),
+		Weekly:              make([]application.WeeklyAvailabilityInput, 0, 7),
+		AllowedShiftTypeIDs: r.Form["allowed_shift_type_ids"],
+		MaxDaysPerWeek:      maxDays,
+		Note:                r.FormValue("note"),
+		Actor:               h.getActor(r),
+	}
+	for weekday := 0; weekday < 7; weekday++ {
+		n := strconv.Itoa(weekday)
+		input.Weekly = append(input.Weekly, application.WeeklyAvailabilityInput{
+			Weekday:     weekday,
+			Unavailable: r.FormValue("unavailable_"+n) == "true",
+			From:        r.FormValue("from_" + n),
+			Until:       r.FormValue("until_" + n),
+		})
+	}
+
+	availability, err := h.useCase.Update(r.Context(), input)
+	if err != nil {
+		h.handleFormError(w, r, err)
+		return
+	}
+
+	h.redirect(w, r, "/staffs/"+availability.StaffID+"/availability")
+}
+
+// AddException 期間例外追加 フォーム送信
+func (h *AvailabilityHandler) AddException(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	input := &application.AddAvailabilityExceptionInput{
+		OrganizationID: h.getOrganizationID(r),
+		StaffID:        r.PathValue("id"),
+		StartDate:      r.FormValue("start_date"),
+		EndDate:        r.FormValue("end_date"),
+		Available:      r.FormValue("available") == "true",
+		Reason:         r.FormValue("reason"),
+		Actor:          h.getActor(r),
+	}
+
+	availability, err := h.useCase.AddException(r.Context(), input)
+	if err != nil {
+		h.handleFormError(w, r, err)
+		return
+	}
+
+	h.redirect(w, r, "/staffs/"+availability.StaffID+"/availability")
+}
+
+// RemoveException 期間例外削除
+func (h *AvailabilityHandler) RemoveException(w http.ResponseWriter, r *http.Request) {
+	availability, err := h.useCase.RemoveException(r.Context(), h.getOrganizationID(r), r.PathValue("id"), r.PathValue("exception_id"), h.getActor(r))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	h.redirect(w, r, "/staffs/"+availability.StaffID+"/availability")
+}
+
+// ShowJSON 勤務可能条件JSON
+func (h *AvailabilityHandler) ShowJSON(w http.ResponseWriter, r *http.Request) {
+	availability, err := h.useCase.Get(r.Context(), h.getOrganizationID(r), r.PathValue("id"), h.getActor(r))
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, availability)
+}
+
+// UpdateJSON 勤務可能条件更新JSON
+func (h *AvailabilityHandler) UpdateJSON(w http.ResponseWriter, r *http.Request) {
+	var input application.UpdateStaffAvailabilityInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "リクエストボディが不正です"})
+		return
+	}
+	input.OrganizationID = h.getOrganizationID(r)
+	input.StaffID = r.PathValue("id")
+	input.Actor = h.getActor(r)
+
+	availability, err := h.useCase.Update(r.Context(), &input)
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, availability)
+}
+
+// AddExceptionJSON 期間例外追加JSON
+func (h *AvailabilityHandler) AddExceptionJSON(w http.ResponseWriter, r *http.Request) {
+	var input application.AddAvailabilityExceptionInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "リクエストボディが不正です"})
+		return
+	}
+	input.OrganizationID = h.getOrganizationID(r)
+	input.StaffID = r.PathValue("id")
+	input.Actor = h.getActor(r)
+
+	availability, err := h.useCase.AddException(r.Context(), &input)
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, availability)
+}
+
+// RemoveExceptionJSON 期間例外削除JSON
+func (h *AvailabilityHandler) RemoveExceptionJSON(w http.ResponseWriter, r *http.Request) {
+	availability, err := h.useCase.RemoveException(r.Context(), h.getOrganizationID(r), r.PathValue("id"), r.PathValue("exception_id"), h.getActor(r))
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, availability)
+}
+
+// getOrganizationID コンテキストから組織IDを取得
+func (h *AvailabilityHandler) getOrganizationID(r *http.Request) string {
+	claims := web.GetClaimsFromContext(r.Context())
+	if claims != nil && claims.OrganizationID != nil {
+		return claims.OrganizationID.String()
+	}
+	return ""
+}
+
+// getActor コンテキストから操作ユーザーを取得
+func (h *AvailabilityHandler) getActor(r *http.Request) application.AvailabilityActor {
+	var actor application.AvailabilityActor
+	if claims := web.GetClaimsFromContext(r.Context()); claims != nil {
+		actor.UserID = claims.UserID.String()
+		actor.Email = claims.Email
+		actor.IsManager = claims.IsManager()
+	}
+	return actor
+}
+
+// redirect 指定ページへリダイレクト HTMX対応
+func (h *AvailabilityHandler) redirect(w http.ResponseWriter, r *http.Request, redirectTo string) {
+	if isHTMXRequest(r) {
+		w.Header().Set("HX-Redirect", redirectTo)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	http.Redirect(w, r, redirectTo, http.StatusSeeOther)
+}
+
+// handleFormError フォーム送信エラーハンドリング 検証エラーと競合はフォーム上に表示
+func (h *AvailabilityHandler) handleFormError(w http.ResponseWriter, r *http.Request, err error) {
+	var domainErr *sharedDomain.DomainError
+	if isHTMXRequest(r) && errors.As(err, &domainErr) &&
+		(domainErr.Code == sharedDomain.ErrCodeValidation || domainErr.Code == sharedDomain.ErrCodeConflict) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`<p class="text-sm text-red-400">` + html.EscapeString(domainErr.Message) + `</p>`))
+		return
+	}
+
+	h.handleError(w, err)
+}
+
+// handleError エラーハンドリング
+func (h *AvailabilityHandler) handleError(w http.ResponseWriter, err error) {
+	if errors.Is(err, sharedDomain.ErrNotFound) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+
+	var domainErr *sharedDomain.DomainError
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code {
+		case sharedDomain.ErrCodeNotFound:
+			http.Error(w, domainErr.Message, http.StatusNotFound)
+			return
+		case sharedDomain.ErrCodeValidation:
+			http.Error(w, domainErr.Message, http.StatusBadRequest)
+			return
+		case sharedDomain.ErrCodeConflict:
+			http.Error(w, domainErr.Message, http.StatusConflict)
+			return
+		case sharedDomain.ErrCodeForbidden:
+			http.Error(w, domainErr.Message, http.StatusForbidden)
+			return
+		}
+	}
+
+	h.logger.Error("ハンドラーエラー", "error", err)
+	http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+}
+
+// handleJSONError JSONエラーハンドリング
+func (h *AvailabilityHandler) handleJSONError(w http.ResponseWriter, err error) {
+	if errors.Is(err, sharedDomain.ErrNotFound) {
+		h.writeJSON(w, http.StatusNotFound, map[string]string{"error": "見つかりません"})
+		return
+	}
+
+	var domainErr *sharedDomain.DomainError
+	if errors.As(err, &domainErr) {
+		switch domainErr.Code {
+		case sharedDomain.ErrCodeNotFound:
+			h.writeJSON(w, http.StatusNotFound, map[string]string{"error": domainErr.Message})
+			return
+		case sharedDomain.ErrCodeValidation:
+			h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": domainErr.Message})
+			return
+		case sharedDomain.ErrCodeConflict:
+			h.writeJSON(w, http.StatusConflict, map[string]string{"error": domainErr.Message})
+			return
+		case sharedDomain.ErrCodeForbidden:
+			h.writeJSON(w, http.StatusForbidden, map[string]string{"error": domainErr.Message})
+			return
+		}
+	}
+
+	h.logger.Error("ハンドラーエラー", "error", err)
+	h.writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "内部エラーが発生しました"})
+}
+
+// writeJSON JSONレスポンス書き込み
+func (h *AvailabilityHandler) writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		h.logger.Error("JSONエンコード失敗", "error", err)
+	}
+}
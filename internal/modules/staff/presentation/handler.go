@@ -2,8 +2,12 @@
 package presentation
 
 import (
+	"bytes"
+	"encoding/csv"
 	"encoding/json"
+	"io"
 	"log/slog"
+	"mime"
 	"net/http"
 	"strconv"
 
@@ -15,24 +19,27 @@ import (
 
 // StaffHandler スタッフHTTPハンドラー
 type StaffHandler struct {
-	useCase   *application.StaffUseCase
-	teamRepo  domain.TeamRepository
-	templates *web.TemplateEngine
-	logger    *slog.Logger
+	useCase       *application.StaffUseCase
+	importUseCase *application.StaffImportUseCase
+	teamRepo      domain.TeamRepository
+	templates     *web.TemplateEngine
+	logger        *slog.Logger
 }
 
 // NewStaffHandler ハンドラー生成
 func NewStaffHandler(
 	useCase *application.StaffUseCase,
+	importUseCase *application.StaffImportUseCase,
 	teamRepo domain.TeamRepository,
 	templates *web.TemplateEngine,
 	logger *slog.Logger,
 ) *StaffHandler {
 	return &StaffHandler{
-		useCase:   useCase,
-		teamRepo:  teamRepo,
-		templates: templates,
-		logger:    logger,
+		useCase:       useCase,
+		importUseCase: importUseCase,
+		teamRepo:      teamRepo,
+		templates:     templates,
+		logger:        logger,
 	}
 }
 
@@ -40,6 +47,9 @@ func NewStaffHandler(
 func (h *StaffHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /staffs", h.List)
 	mux.HandleFunc("GET /staffs/new", h.New)
+	mux.HandleFunc("GET /staffs/import", h.ImportPage)
+	mux.HandleFunc("GET /staffs/import/template.csv", h.ImportTemplate)
+	mux.HandleFunc("POST /staffs/import", h.Import)
 	mux.HandleFunc("GET /staffs/{id}", h.Show)
 	mux.HandleFunc("GET /staffs/{id}/edit", h.Edit)
 	mux.HandleFunc("POST /staffs", h.Create)
@@ -52,6 +62,7 @@ func (h *StaffHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("POST /api/staffs", h.CreateJSON)
 	mux.HandleFunc("PUT /api/staffs/{id}", h.UpdateJSON)
 	mux.HandleFunc("DELETE /api/staffs/{id}", h.DeleteJSON)
+	mux.HandleFunc("POST /api/staffs/import", h.ImportJSON)
 }
 
 // getOrganizationID コンテキストから組織IDを取得
@@ -406,6 +417,123 @@ func (h *StaffHandler) DeleteJSON(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// ImportPage 一括登録ページ
+func (h *StaffHandler) ImportPage(w http.ResponseWriter, r *http.Request) {
+	data := map[string]any{
+		"Title": "スタッフ一括登録",
+	}
+	if h.getOrganizationID(r) == "" {
+		data["NoOrgSelected"] = true
+		data["NoOrgSelectedMsg"] = "組織を選択してください"
+	}
+
+	if err := h.templates.Render(w, "pages/staffs/import.html", data); err != nil {
+		h.logger.Error("テンプレートレンダリング失敗", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// ImportTemplate 一括登録用のCSVテンプレート
+func (h *StaffHandler) ImportTemplate(w http.ResponseWriter, r *http.Request) {
+	var buf bytes.Buffer
+	// Excelで文字化けしないようBOMを付ける
+	buf.WriteString("\uFEFF")
+	cw := csv.NewWriter(&buf)
+	_ = cw.Write(application.StaffImportHeader)
+	cw.Flush()
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": "staff-import-template.csv"}))
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		h.logger.Error("レスポンス書き込み失敗", "error", err)
+	}
+}
+
+// Import 一括登録 フォーム送信 結果はHTMX用の部分テンプレートで返す
+func (h *StaffHandler) Import(w http.ResponseWriter, r *http.Request) {
+	// 先にファイルを読み込みサイズ上限を適用してからフォーム値を参照する
+	file, err := readImportUpload(w, r)
+	if err != nil {
+		h.renderImportResult(w, map[string]any{"ErrorMessage": "ファイルの読み込みに失敗しました"})
+		return
+	}
+
+	result, err := h.runImport(r, file, r.FormValue("dry_run") == "true")
+	if de, ok := err.(*sharedDomain.DomainError); ok && de.Code == sharedDomain.ErrCodeValidation {
+		h.renderImportResult(w, map[string]any{"ErrorMessage": de.Message})
+		return
+	}
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	h.renderImportResult(w, map[string]any{"Result": result})
+}
+
+// renderImportResult 一括登録結果の部分テンプレート出力
+func (h *StaffHandler) renderImportResult(w http.ResponseWriter, data map[string]any) {
+	if err := h.templates.RenderPartial(w, "pages/staffs/import.html", "import-result", data); err != nil {
+		h.logger.Error("テンプレートレンダリング失敗", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// ImportJSON 一括登録JSON dry_run=trueで検証のみ
+func (h *StaffHandler) ImportJSON(w http.ResponseWriter, r *http.Request) {
+	file, err := readImportUpload(w, r)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "ファイルの読み込みに失敗しました"})
+		return
+	}
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run"))
+
+	result, err := h.runImport(r, file, dryRun)
+	if err != nil {
+		h.handleJSONError(w, err)
+		return
+	}
+
+	status := http.StatusOK
+	if len(result.Errors) > 0 && !result.DryRun {
+		status = http.StatusBadRequest
+	}
+	h.writeJSON(w, status, result)
+}
+
+// maxImportUploadBytes 一括登録ファイルの最大サイズ
+const maxImportUploadBytes = 10 << 20
+
+// runImport ログイン中の組織に一括登録を実行
+func (h *StaffHandler) runImport(r *http.Request, data []byte, dryRun bool) (*application.ImportStaffsOutput, error) {
+	orgID := h.getOrganizationID(r)
+	if orgID == "" {
+		return nil, sharedDomain.NewDomainError(sharedDomain.ErrCodeValidation, "組織を選択してください")
+	}
+
+	return h.importUseCase.Import(r.Context(), &application.ImportStaffsInput{
+		OrganizationID: orgID,
+		Data:           data,
+		DryRun:         dryRun,
+	})
+}
+
+// readImportUpload リクエストから一括登録ファイルを読み込む
+func readImportUpload(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportUploadBytes)
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		return io.ReadAll(r.Body)
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+	return io.ReadAll(file)
+}
+
 // handleError エラーハンドリング
 func (h *StaffHandler) handleError(w http.ResponseWriter, r *http.Request, err error) {
 	var domainErr *sharedDomain.DomainError
@@ -0,0 +1,176 @@
+// Package domain 共有ドメイン型定義
+package domain
+
+import "time"
+
+// 祝日名
+const (
+	holidaySubstitute = "振替休日"
+	holidayCitizens   = "国民の休日"
+)
+
+// JapaneseHolidayName 国民の祝日名を返す 祝日でない場合は空文字
+// 2007年以降の祝日法（振替休日・国民の休日を含む）に基づき計算する 2019年の即位関連の休日は対象外
+func JapaneseHolidayName(date time.Time) string {
+	date = truncateDay(date)
+
+	if name := baseHolidayName(date); name != "" {
+		return name
+	}
+
+	// 振替休日 日曜の祝日から祝日が連続した後の最初の平日
+	for d := date.AddDate(0, 0, -1); baseHolidayName(d) != ""; d = d.AddDate(0, 0, -1) {
+		if d.Weekday() == time.Sunday {
+			return holidaySubstitute
+		}
+	}
+
+	// 国民の休日 前日と翌日が祝日に挟まれた平日
+	if date.Weekday() != time.Sunday &&
+		baseHolidayName(date.AddDate(0, 0, -1)) != "" &&
+		baseHolidayName(date.AddDate(0, 0, 1)) != "" {
+		return holidayCitizens
+	}
+
+	return ""
+}
+
+// IsJapaneseHoliday 国民の祝日・振替休日・国民の休日かどうか
+func IsJapaneseHoliday(date time.Time) bool {
+	return JapaneseHolidayName(date) != ""
+}
+
+// IsWeekendOrHoliday 土日または祝日かどうか
+func IsWeekendOrHoliday(date time.Time) bool {
+	if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+		return true
+	}
+	return IsJapaneseHoliday(date)
+}
+
+// baseHolidayName 振替休日・国民の休日を除く祝日名
+func baseHolidayName(date time.Time) string {
+	year := date.Year()
+	day := date.Day()
+
+	switch date.Month() {
+	case time.January:
+		if day == 1 {
+			return "元日"
+		}
+		if day == nthMonday(year, time.January, 2) {
+			return "成人の日"
+		}
+	case time.February:
+		if day == 11 {
+			return "建国記念の日"
+		}
+		if day == 23 && year >= 2020 {
+			return "天皇誕生日"
+		}
+	case time.March:
+		if day == vernalEquinoxDay(year) {
+			return "春分の日"
+		}
+	case time.April:
+		if day == 29 {
+			return "昭和の日"
+		}
+	case time.May:
+		switch day {
+		case 3:
+			return "憲法記念日"
+		case 4:
+			return "みどりの日"
+		case 5:
+			return "こどもの日"
+		}
+	case time.July:
+		if day == marineDay(year) {
+			return "海の日"
+		}
+		if (year == 2020 && day == 24) || (year == 2021 && day == 23) {
+			return "スポーツの日"
+		}
+	case time.August:
+		if day == mountainDay(year) {
+			return "山の日"
+		}
+	case time.September:
+		if day == nthMonday(year, time.September, 3) {
+			return "敬老の日"
+		}
+		if day == autumnalEquinoxDay(year) {
+			return "秋分の日"
+		}
+	case time.October:
+		if year == 2020 || year == 2021 {
+			return ""
+		}
+		if day == nthMonday(year, time.October, 2) {
+			if year >= 2020 {
+				return "スポーツの日"
+			}
+			return "体育の日"
+		}
+	case time.November:
+		if day == 3 {
+			return "文化の日"
+		}
+		if day == 23 {
+			return "勤労感謝の日"
+		}
+	case time.December:
+		if day == 23 && year <= 2018 {
+			return "天皇誕生日"
+		}
+	}
+
+	return ""
+}
+
+// marineDay 海の日 東京五輪の特例年を考慮
+func marineDay(year int) int {
+	switch year {
+	case 2020:
+		return 23
+	case 2021:
+		return 22
+	}
+	return nthMonday(year, time.July, 3)
+}
+
+// mountainDay 山の日 2016年施行 東京五輪の特例年を考慮
+func mountainDay(year int) int {
+	switch {
+	case year < 2016:
+		return 0
+	case year == 2020:
+		return 10
+	case year == 2021:
+		return 8
+	}
+	return 11
+}
+
+// nthMonday 指定月の第n月曜日の日付
+func nthMonday(year int, month time.Month, n int) int {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	offset := (int(time.Monday) - int(first.Weekday()) + 7) % 7
+	return 1 + offset + (n-1)*7
+}
+
+// vernalEquinoxDay 春分日 1980-2099年の近似式
+func vernalEquinoxDay(year int) int {
+	return int(20.8431+0.242194*float64(year-1980)) - (year-1980)/4
+}
+
+// autumnalEquinoxDay 秋分日 1980-2099年の近似式
+func autumnalEquinoxDay(year int) int {
+	return int(23.2488+0.242194*float64(year-1980)) - (year-1980)/4
+}
+
+// truncateDay 日付部分のみに丸める
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
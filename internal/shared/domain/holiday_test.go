@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJapaneseHolidayName(t *testing.T) {
+	tests := []struct {
+		name string
+		date string
+		want string
+	}{
+		{"元日", "2025-01-01", "元日"},
+		{"成人の日_第2月曜", "2025-01-13", "成人の日"},
+		{"建国記念の日", "2025-02-11", "建国記念の日"},
+		{"天皇誕生日", "2025-02-23", "天皇誕生日"},
+		{"振替休日_天皇誕生日が日曜", "2025-02-24", "振替休日"},
+		{"春分の日", "2025-03-20", "春分の日"},
+		{"昭和の日", "2025-04-29", "昭和の日"},
+		{"こどもの日_日曜", "2025-05-05", "こどもの日"},
+		{"振替休日_祝日連続後", "2025-05-06", "振替休日"},
+		{"海の日_第3月曜", "2025-07-21", "海の日"},
+		{"山の日", "2025-08-11", "山の日"},
+		{"敬老の日_第3月曜", "2025-09-15", "敬老の日"},
+		{"秋分の日", "2025-09-23", "秋分の日"},
+		{"スポーツの日_第2月曜", "2025-10-13", "スポーツの日"},
+		{"文化の日", "2025-11-03", "文化の日"},
+		{"勤労感謝の日_日曜", "2025-11-23", "勤労感謝の日"},
+		{"振替休日_勤労感謝の日", "2025-11-24", "振替休日"},
+		{"国民の休日_敬老の日と秋分の日の間", "2026-09-22", "国民の休日"},
+		{"東京五輪特例_海の日", "2021-07-22", "海の日"},
+		{"東京五輪特例_10月は祝日なし", "2021-10-11", ""},
+		{"平日", "2025-03-14", ""},
+		{"土曜", "2025-03-15", ""},
+		{"旧天皇誕生日", "2018-12-23", "天皇誕生日"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date, err := time.Parse("2006-01-02", tt.date)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := JapaneseHolidayName(date); got != tt.want {
+				t.Errorf("JapaneseHolidayName(%s) = %q, want %q", tt.date, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsWeekendOrHoliday(t *testing.T) {
+	tests := []struct {
+		date string
+		want bool
+	}{
+		{"2025-03-14", false}, // 金曜
+		{"2025-03-15", true},  // 土曜
+		{"2025-03-16", true},  // 日曜
+		{"2025-03-20", true},  // 春分の日 木曜
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.date, func(t *testing.T) {
+			date, _ := time.Parse("2006-01-02", tt.date)
+			if got := IsWeekendOrHoliday(date); got != tt.want {
+				t.Errorf("IsWeekendOrHoliday(%s) = %v, want %v", tt.date, got, tt.want)
+			}
+		})
+	}
+}
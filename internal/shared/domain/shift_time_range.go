@@ -95,6 +95,16 @@ func (r ShiftTimeRange) OverlapsOnConsecutiveDays(other ShiftTimeRange) bool {
 	return other.start.ToMinutes() < r.end.ToMinutes()
 }
 
+// RestMinutesUntil 休息時間（分）
+// rの勤務終了からdays日後に始まるnextの勤務開始までの実時間 日跨ぎシフトは翌日の終了時刻から数える
+func (r ShiftTimeRange) RestMinutesUntil(next ShiftTimeRange, days int) int {
+	end := r.end.ToMinutes()
+	if r.CrossesDate() {
+		end += 1440
+	}
+	return days*1440 + next.start.ToMinutes() - end
+}
+
 // Contains 指定時刻が範囲内か判定
 func (r ShiftTimeRange) Contains(t TimeOfDay) bool {
 	if r.CrossesDate() {
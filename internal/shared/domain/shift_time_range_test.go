@@ -183,6 +183,32 @@ func TestShiftTimeRange_OverlapsOnConsecutiveDays(t *testing.T) {
 	}
 }
 
+func TestShiftTimeRange_RestMinutesUntil(t *testing.T) {
+	tests := []struct {
+		name string
+		r1   [2]string // 前のシフト
+		r2   [2]string // 後のシフト
+		days int
+		want int
+	}{
+		{"遅番後の早番", [2]string{"13:00", "22:00"}, [2]string{"07:00", "16:00"}, 1, 540},
+		{"日勤後の日勤", [2]string{"09:00", "17:00"}, [2]string{"09:00", "17:00"}, 1, 960},
+		{"夜勤明けの遅番", [2]string{"22:00", "07:00"}, [2]string{"13:00", "22:00"}, 1, 360},
+		{"夜勤明け翌々日の夜勤", [2]string{"17:00", "09:00"}, [2]string{"17:00", "09:00"}, 2, 1920},
+		{"同日の2回勤務", [2]string{"06:00", "10:00"}, [2]string{"18:00", "22:00"}, 0, 480},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r1, _ := NewShiftTimeRangeFromStrings(tt.r1[0], tt.r1[1])
+			r2, _ := NewShiftTimeRangeFromStrings(tt.r2[0], tt.r2[1])
+			if got := r1.RestMinutesUntil(r2, tt.days); got != tt.want {
+				t.Errorf("RestMinutesUntil() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestShiftTimeRange_Contains(t *testing.T) {
 	tests := []struct {
 		name string
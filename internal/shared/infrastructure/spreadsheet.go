@@ -0,0 +1,240 @@
+// Package infrastructure 共有インフラストラクチャ層
+package infrastructure
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// maxSpreadsheetPartBytes xlsx内の1ファイルを展開する上限 圧縮爆弾対策
+const maxSpreadsheetPartBytes = 32 << 20
+
+// maxSpreadsheetColumns xlsxの列数上限 XFD列まで
+const maxSpreadsheetColumns = 16384
+
+// ErrInvalidSpreadsheet 表形式ファイルとして読めない
+var ErrInvalidSpreadsheet = errors.New("invalid spreadsheet")
+
+// SpreadsheetRow 表形式ファイルの1行
+type SpreadsheetRow struct {
+	// Line 行番号 1始まり
+	Line int
+	// Cells セルの値 前後の空白を除去済み
+	Cells []string
+}
+
+// IsBlank 空行か
+func (r SpreadsheetRow) IsBlank() bool {
+	for _, c := range r.Cells {
+		if c != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// ReadSpreadsheet CSVまたはxlsxを行に分解 xlsxは先頭のシートのみ読む
+func ReadSpreadsheet(data []byte) ([]SpreadsheetRow, error) {
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		return readXLSX(data)
+	}
+	return readCSV(data)
+}
+
+// readCSV BOM付きUTF-8にも対応したCSV読み込み
+func readCSV(data []byte) ([]SpreadsheetRow, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte{0xEF, 0xBB, 0xBF})))
+	r.FieldsPerRecord = -1
+
+	var rows []SpreadsheetRow
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSpreadsheet, err)
+		}
+		line, _ := r.FieldPos(0)
+		for i := range record {
+			record[i] = strings.TrimSpace(record[i])
+		}
+		rows = append(rows, SpreadsheetRow{Line: line, Cells: record})
+	}
+}
+
+// xlsxRelationships workbook.xml.rels
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// xlsxWorkbookXML workbook.xml
+type xlsxWorkbookXML struct {
+	Sheets []struct {
+		RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+// xlsxText 文字列要素 書式付き文字列は連結する
+type xlsxText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+// String 文字列取得
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var sb strings.Builder
+	for _, r := range t.Runs {
+		sb.WriteString(r.T)
+	}
+	return sb.String()
+}
+
+// xlsxSharedStrings sharedStrings.xml
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+// xlsxSheetXML シートXML
+type xlsxSheetXML struct {
+	Rows []struct {
+		R     int `xml:"r,attr"`
+		Cells []struct {
+			R      string   `xml:"r,attr"`
+			T      string   `xml:"t,attr"`
+			V      string   `xml:"v"`
+			Inline xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readXLSX xlsxの先頭シートを読み込む 数値・日付はセルの値をそのまま返す
+func readXLSX(data []byte) ([]SpreadsheetRow, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSpreadsheet, err)
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	decode := func(name string, v any) (bool, error) {
+		f, ok := files[name]
+		if !ok {
+			return false, nil
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return true, err
+		}
+		defer func() { _ = rc.Close() }()
+		if err := xml.NewDecoder(io.LimitReader(rc, maxSpreadsheetPartBytes)).Decode(v); err != nil {
+			return true, fmt.Errorf("%w: %s: %v", ErrInvalidSpreadsheet, name, err)
+		}
+		return true, nil
+	}
+
+	sheetPath := "xl/worksheets/sheet1.xml"
+	var workbook xlsxWorkbookXML
+	var rels xlsxRelationships
+	if ok, err := decode("xl/workbook.xml", &workbook); err != nil {
+		return nil, err
+	} else if ok && len(workbook.Sheets) > 0 {
+		if _, err := decode("xl/_rels/workbook.xml.rels", &rels); err != nil {
+			return nil, err
+		}
+		for _, rel := range rels.Relationships {
+			if rel.ID == workbook.Sheets[0].RelID {
+				sheetPath = xlsxPartPath(rel.Target)
+				break
+			}
+		}
+	}
+
+	var shared xlsxSharedStrings
+	if _, err := decode("xl/sharedStrings.xml", &shared); err != nil {
+		return nil, err
+	}
+
+	var sheet xlsxSheetXML
+	ok, err := decode(sheetPath, &sheet)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: worksheet not found", ErrInvalidSpreadsheet)
+	}
+
+	rows := make([]SpreadsheetRow, 0, len(sheet.Rows))
+	for i, row := range sheet.Rows {
+		line := row.R
+		if line == 0 {
+			line = i + 1
+		}
+		var cells []string
+		for j, c := range row.Cells {
+			col := xlsxColumnIndex(c.R)
+			if col < 0 {
+				col = j
+			}
+			if col >= maxSpreadsheetColumns {
+				return nil, fmt.Errorf("%w: cell %q", ErrInvalidSpreadsheet, c.R)
+			}
+			var value string
+			switch c.T {
+			case "s":
+				idx, err := strconv.Atoi(c.V)
+				if err != nil || idx < 0 || idx >= len(shared.Items) {
+					return nil, fmt.Errorf("%w: shared string %q", ErrInvalidSpreadsheet, c.V)
+				}
+				value = shared.Items[idx].String()
+			case "inlineStr":
+				value = c.Inline.String()
+			default:
+				value = c.V
+			}
+			for len(cells) <= col {
+				cells = append(cells, "")
+			}
+			cells[col] = strings.TrimSpace(value)
+		}
+		rows = append(rows, SpreadsheetRow{Line: line, Cells: cells})
+	}
+	return rows, nil
+}
+
+// xlsxPartPath 関係ファイルのTargetをzip内のパスに変換
+func xlsxPartPath(target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return path.Join("xl", target)
+}
+
+// xlsxColumnIndex セル参照の列番号 A1→0
+func xlsxColumnIndex(ref string) int {
+	col := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A'+1)
+	}
+	return col - 1
+}
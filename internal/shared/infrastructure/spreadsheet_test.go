@@ -0,0 +1,100 @@
+// Package infrastructure 表形式ファイル読み込みテスト
+package infrastructure
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// buildTestXLSX テスト用の最小構成のxlsxを生成
+func buildTestXLSX(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadSpreadsheet_CSV(t *testing.T) {
+	data := "\xEF\xBB\xBFcode, name \n\nN001,\"山田, 花子\"\n"
+	rows, err := ReadSpreadsheet([]byte(data))
+	if err != nil {
+		t.Fatalf("ReadSpreadsheet() error = %v", err)
+	}
+	want := []SpreadsheetRow{
+		{Line: 1, Cells: []string{"code", "name"}},
+		{Line: 3, Cells: []string{"N001", "山田, 花子"}},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %+v, want %+v", rows, want)
+	}
+
+	if _, err := ReadSpreadsheet([]byte("a,\"b\n")); !errors.Is(err, ErrInvalidSpreadsheet) {
+		t.Errorf("error = %v, want ErrInvalidSpreadsheet", err)
+	}
+}
+
+func TestReadSpreadsheet_XLSX(t *testing.T) {
+	data := buildTestXLSX(t, map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+			xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+			<sheets><sheet name="職員" sheetId="1" r:id="rId2"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+			<Relationship Id="rId1" Target="worksheets/sheet1.xml"/>
+			<Relationship Id="rId2" Target="worksheets/staff.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<sst><si><t>code</t></si><si><r><t>山田</t></r><r><t> 花子</t></r></si></sst>`,
+		"xl/worksheets/staff.xml": `<worksheet><sheetData>
+			<row r="1"><c r="A1" t="s"><v>0</v></c><c r="C1" t="inlineStr"><is><t>hire_date</t></is></c></row>
+			<row r="3"><c r="B3" t="s"><v>1</v></c><c r="C3"><v>45383</v></c></row>
+			</sheetData></worksheet>`,
+	})
+
+	rows, err := ReadSpreadsheet(data)
+	if err != nil {
+		t.Fatalf("ReadSpreadsheet() error = %v", err)
+	}
+	want := []SpreadsheetRow{
+		{Line: 1, Cells: []string{"code", "", "hire_date"}},
+		{Line: 3, Cells: []string{"", "山田 花子", "45383"}},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %+v, want %+v", rows, want)
+	}
+}
+
+func TestReadSpreadsheet_InvalidXLSX(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+	}{
+		{name: "シートなし", files: map[string]string{"xl/workbook.xml": `<workbook/>`}},
+		{name: "共有文字列の範囲外", files: map[string]string{
+			"xl/worksheets/sheet1.xml": `<worksheet><sheetData><row r="1"><c r="A1" t="s"><v>3</v></c></row></sheetData></worksheet>`,
+		}},
+		{name: "列参照が範囲外", files: map[string]string{
+			"xl/worksheets/sheet1.xml": `<worksheet><sheetData><row r="1"><c r="ZZZZ1"><v>1</v></c></row></sheetData></worksheet>`,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadSpreadsheet(buildTestXLSX(t, tt.files)); !errors.Is(err, ErrInvalidSpreadsheet) {
+				t.Errorf("error = %v, want ErrInvalidSpreadsheet", err)
+			}
+		})
+	}
+}
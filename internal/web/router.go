@@ -27,22 +27,50 @@ type OrganizationInfo struct {
 	Code string
 }
 
+// OvertimeAlertFinder 36協定警告検索インターフェース
+type OvertimeAlertFinder interface {
+	FindCurrentByOrganizationID(ctx context.Context, orgID sharedDomain.ID) ([]OvertimeAlertInfo, error)
+}
+
+// OvertimeAlertInfo 36協定警告情報
+type OvertimeAlertInfo struct {
+	StaffName  string
+	Message    string
+	IsExceeded bool
+}
+
+// LeaveAlertFinder 年5日の取得義務の警告検索インターフェース
+type LeaveAlertFinder interface {
+	FindByOrganizationID(ctx context.Context, orgID sharedDomain.ID) ([]LeaveAlertInfo, error)
+}
+
+// LeaveAlertInfo 年5日の取得義務の警告情報
+type LeaveAlertInfo struct {
+	StaffName string
+	Message   string
+	IsMissed  bool
+}
+
 // RouterDeps ルーター依存関係
 type RouterDeps struct {
-	Logger        *slog.Logger
-	Templates     *TemplateEngine
-	HealthChecker HealthChecker
-	OrgFinder     OrganizationFinder
-	Mux           *http.ServeMux
+	Logger              *slog.Logger
+	Templates           *TemplateEngine
+	HealthChecker       HealthChecker
+	OrgFinder           OrganizationFinder
+	OvertimeAlertFinder OvertimeAlertFinder
+	LeaveAlertFinder    LeaveAlertFinder
+	Mux                 *http.ServeMux
 }
 
 // Router HTTPルーター
 type Router struct {
-	mux       *http.ServeMux
-	logger    *slog.Logger
-	templates *TemplateEngine
-	health    HealthChecker
-	orgFinder OrganizationFinder
+	mux         *http.ServeMux
+	logger      *slog.Logger
+	templates   *TemplateEngine
+	health      HealthChecker
+	orgFinder   OrganizationFinder
+	alerts      OvertimeAlertFinder
+	leaveAlerts LeaveAlertFinder
 }
 
 // NewRouter ルーター生成
@@ -53,11 +81,13 @@ func NewRouter(deps RouterDeps) *Router {
 	}
 
 	r := &Router{
-		mux:       mux,
-		logger:    deps.Logger,
-		templates: deps.Templates,
-		health:    deps.HealthChecker,
-		orgFinder: deps.OrgFinder,
+		mux:         mux,
+		logger:      deps.Logger,
+		templates:   deps.Templates,
+		health:      deps.HealthChecker,
+		orgFinder:   deps.OrgFinder,
+		alerts:      deps.OvertimeAlertFinder,
+		leaveAlerts: deps.LeaveAlertFinder,
 	}
 	r.setupBaseRoutes()
 	return r
@@ -130,6 +160,8 @@ func (r *Router) DashboardHandler(w http.ResponseWriter, req *http.Request) {
 
 				if selectedOrgID != nil {
 					data["SelectedOrganizationID"] = *selectedOrgID
+					r.setOvertimeAlerts(req.Context(), data, *selectedOrgID)
+					r.setLeaveAlerts(req.Context(), data, *selectedOrgID)
 					org, err := r.orgFinder.FindByID(req.Context(), *selectedOrgID)
 					if err == nil {
 						data["SelectedOrganizationName"] = org.Name
@@ -143,6 +175,8 @@ func (r *Router) DashboardHandler(w http.ResponseWriter, req *http.Request) {
 			if err == nil {
 				data["OrganizationName"] = org.Name
 			}
+			r.setOvertimeAlerts(req.Context(), data, *claims.OrganizationID)
+			r.setLeaveAlerts(req.Context(), data, *claims.OrganizationID)
 		}
 	}
 
@@ -152,6 +186,32 @@ func (r *Router) DashboardHandler(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// setOvertimeAlerts 当月の36協定警告を設定 取得失敗時はダッシュボード表示を優先しログのみ
+func (r *Router) setOvertimeAlerts(ctx context.Context, data map[string]any, orgID sharedDomain.ID) {
+	if r.alerts == nil {
+		return
+	}
+	alerts, err := r.alerts.FindCurrentByOrganizationID(ctx, orgID)
+	if err != nil {
+		r.logger.Error("36協定警告取得失敗", "error", err)
+		return
+	}
+	data["OvertimeAlerts"] = alerts
+}
+
+// setLeaveAlerts 年5日の取得義務の警告を設定 取得失敗時はダッシュボード表示を優先しログのみ
+func (r *Router) setLeaveAlerts(ctx context.Context, data map[string]any, orgID sharedDomain.ID) {
+	if r.leaveAlerts == nil {
+		return
+	}
+	alerts, err := r.leaveAlerts.FindByOrganizationID(ctx, orgID)
+	if err != nil {
+		r.logger.Error("年5日の取得義務の警告取得失敗", "error", err)
+		return
+	}
+	data["LeaveAlerts"] = alerts
+}
+
 // getSelectedOrganizationID Cookieから選択中の組織ID取得
 func (r *Router) getSelectedOrganizationID(req *http.Request) *sharedDomain.ID {
 	cookie, err := req.Cookie("selected_organization_id")
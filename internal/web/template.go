@@ -3,6 +3,7 @@ package web
 
 import (
 	"bytes"
+	"fmt"
 	"html/template"
 	"io"
 	"io/fs"
@@ -264,6 +265,18 @@ func defaultFuncMap() template.FuncMap {
 				return ""
 			}
 		},
+		// 分数を時間表記 H:MM に変換 0は空文字
+		"formatMinutes": func(minutes int) string {
+			if minutes == 0 {
+				return ""
+			}
+			sign := ""
+			if minutes < 0 {
+				sign = "-"
+				minutes = -minutes
+			}
+			return fmt.Sprintf("%s%d:%02d", sign, minutes/60, minutes%60)
+		},
 		// 数値加算
 		"add": func(a, b int) int {
 			return a + b
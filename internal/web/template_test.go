@@ -100,6 +100,7 @@ func TestDefaultFuncMap(t *testing.T) {
 			"formatDate",
 			"formatDateInput",
 			"formatDateTime",
+			"formatMinutes",
 			"add",
 			"sub",
 			"mul",
@@ -248,6 +249,30 @@ func TestFuncMap_FormatDateTime(t *testing.T) {
 	})
 }
 
+func TestFuncMap_FormatMinutes(t *testing.T) {
+	funcMap := defaultFuncMap()
+	formatMinutesFunc := funcMap["formatMinutes"].(func(int) string)
+
+	tests := []struct {
+		name     string
+		minutes  int
+		expected string
+	}{
+		{"ゼロは空文字", 0, ""},
+		{"1時間未満", 45, "0:45"},
+		{"時間と分", 490, "8:10"},
+		{"負の数", -90, "-1:30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := formatMinutesFunc(tt.minutes); result != tt.expected {
+				t.Errorf("formatMinutes(%d) = %q, want %q", tt.minutes, result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestFuncMap_Add(t *testing.T) {
 	funcMap := defaultFuncMap()
 	addFunc := funcMap["add"].(func(int, int) int)
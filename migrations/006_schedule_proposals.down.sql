@@ -0,0 +1,3 @@
+-- 勤務表自動作成案テーブル削除
+DROP TRIGGER IF EXISTS update_schedule_proposals_updated_at ON schedule_proposals;
+DROP TABLE IF EXISTS schedule_proposals;
@@ -0,0 +1,19 @@
+-- 勤務表自動作成案テーブル
+-- 自動作成結果を承認まで保持し、承認時にschedule_entriesへ反映する
+CREATE TABLE IF NOT EXISTS schedule_proposals (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    schedule_id UUID NOT NULL REFERENCES schedules(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    generator VARCHAR(20) NOT NULL,
+    score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    entries JSONB NOT NULL DEFAULT '[]',
+    violations JSONB NOT NULL DEFAULT '[]',
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    applied_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_schedule_proposals_schedule ON schedule_proposals(schedule_id);
+
+CREATE TRIGGER update_schedule_proposals_updated_at BEFORE UPDATE ON schedule_proposals FOR EACH ROW EXECUTE FUNCTION update_updated_at();
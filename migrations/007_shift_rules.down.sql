@@ -0,0 +1,3 @@
+-- シフトルールテーブル削除
+DROP TRIGGER IF EXISTS update_shift_rules_updated_at ON shift_rules;
+DROP TABLE IF EXISTS shift_rules;
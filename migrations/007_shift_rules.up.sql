@@ -0,0 +1,19 @@
+-- シフトルールテーブル
+-- 勤務表の検証・自動作成で使用する組織ごとの制約ルール
+CREATE TABLE IF NOT EXISTS shift_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    rule_type VARCHAR(50) NOT NULL,
+    priority INTEGER NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    config JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_shift_rules_organization_id ON shift_rules(organization_id);
+CREATE INDEX idx_shift_rules_active ON shift_rules(organization_id, is_active);
+
+CREATE TRIGGER update_shift_rules_updated_at BEFORE UPDATE ON shift_rules FOR EACH ROW EXECUTE FUNCTION update_updated_at();
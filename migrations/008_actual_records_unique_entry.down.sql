@@ -0,0 +1,3 @@
+-- 勤務実績エントリ一意制約削除
+DROP INDEX IF EXISTS idx_actual_records_entry;
+CREATE INDEX idx_actual_records_entry ON actual_records(schedule_entry_id);
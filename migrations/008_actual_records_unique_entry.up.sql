@@ -0,0 +1,3 @@
+-- 勤務実績はエントリごとに1件
+DROP INDEX IF EXISTS idx_actual_records_entry;
+CREATE UNIQUE INDEX idx_actual_records_entry ON actual_records(schedule_entry_id);
@@ -0,0 +1,6 @@
+-- 勤務実績の区分別時間外労働削除
+ALTER TABLE actual_records
+    DROP COLUMN IF EXISTS holiday_work_minutes,
+    DROP COLUMN IF EXISTS late_night_minutes,
+    DROP COLUMN IF EXISTS over_legal_overtime_minutes,
+    DROP COLUMN IF EXISTS within_legal_overtime_minutes;
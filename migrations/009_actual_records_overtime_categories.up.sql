@@ -0,0 +1,6 @@
+-- 勤務実績の区分別時間外労働
+ALTER TABLE actual_records
+    ADD COLUMN within_legal_overtime_minutes INT NOT NULL DEFAULT 0,
+    ADD COLUMN over_legal_overtime_minutes INT NOT NULL DEFAULT 0,
+    ADD COLUMN late_night_minutes INT NOT NULL DEFAULT 0,
+    ADD COLUMN holiday_work_minutes INT NOT NULL DEFAULT 0;
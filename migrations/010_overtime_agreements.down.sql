@@ -0,0 +1,3 @@
+-- 36協定テーブル削除
+DROP TRIGGER IF EXISTS update_overtime_agreements_updated_at ON overtime_agreements;
+DROP TABLE IF EXISTS overtime_agreements;
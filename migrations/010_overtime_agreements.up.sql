@@ -0,0 +1,15 @@
+-- 36協定テーブル
+-- 組織ごとの時間外労働の上限 未登録の組織は法定の限度時間で判定する
+CREATE TABLE IF NOT EXISTS overtime_agreements (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID NOT NULL UNIQUE REFERENCES organizations(id) ON DELETE CASCADE,
+    monthly_limit_minutes INT NOT NULL DEFAULT 2700,
+    yearly_limit_minutes INT NOT NULL DEFAULT 21600,
+    multi_month_average_limit_minutes INT NOT NULL DEFAULT 4800,
+    warning_percent INT NOT NULL DEFAULT 80,
+    start_month INT NOT NULL DEFAULT 4 CHECK (start_month BETWEEN 1 AND 12),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER update_overtime_agreements_updated_at BEFORE UPDATE ON overtime_agreements FOR EACH ROW EXECUTE FUNCTION update_updated_at();
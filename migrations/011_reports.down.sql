@@ -0,0 +1,3 @@
+-- レポートテーブル削除
+DROP TRIGGER IF EXISTS update_reports_updated_at ON reports;
+DROP TABLE IF EXISTS reports;
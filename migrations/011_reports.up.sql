@@ -0,0 +1,19 @@
+-- レポートテーブル
+-- 生成したファイルはレポート保存先に置き、file_pathに保存先パスを記録する
+CREATE TABLE IF NOT EXISTS reports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    target_year INT NOT NULL,
+    target_month INT NOT NULL CHECK (target_month BETWEEN 1 AND 12),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    generated_at TIMESTAMPTZ,
+    file_path VARCHAR(500),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_reports_organization ON reports(organization_id, created_at DESC);
+
+CREATE TRIGGER update_reports_updated_at BEFORE UPDATE ON reports FOR EACH ROW EXECUTE FUNCTION update_updated_at();
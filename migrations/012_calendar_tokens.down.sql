@@ -0,0 +1,2 @@
+-- 勤務予定カレンダー購読トークンテーブル削除
+DROP TABLE IF EXISTS calendar_tokens;
@@ -0,0 +1,8 @@
+-- 勤務予定カレンダー購読トークンテーブル
+-- スタッフごとに1件 URLに含めるトークンはハッシュのみ保存する
+CREATE TABLE IF NOT EXISTS calendar_tokens (
+    staff_id UUID PRIMARY KEY REFERENCES staffs(id) ON DELETE CASCADE,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,3 @@
+-- 勤務交換申請テーブル削除
+DROP TRIGGER IF EXISTS update_shift_swaps_updated_at ON shift_swaps;
+DROP TABLE IF EXISTS shift_swaps;
@@ -0,0 +1,24 @@
+-- 勤務交換申請テーブル
+-- 公開済み勤務表のエントリをスタッフ間で交換する申請 相手の承諾と管理者の承認後にschedule_entriesへ反映する
+-- 申請時点のシフト種別を保持し、承認時に勤務表が変更されていないか確認する
+CREATE TABLE IF NOT EXISTS shift_swaps (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    requester_staff_id UUID NOT NULL REFERENCES staffs(id) ON DELETE CASCADE,
+    requester_entry_id UUID NOT NULL REFERENCES schedule_entries(id) ON DELETE CASCADE,
+    requester_date DATE NOT NULL,
+    requester_shift_type_id UUID,
+    counterpart_staff_id UUID NOT NULL REFERENCES staffs(id) ON DELETE CASCADE,
+    counterpart_entry_id UUID NOT NULL REFERENCES schedule_entries(id) ON DELETE CASCADE,
+    counterpart_date DATE NOT NULL,
+    counterpart_shift_type_id UUID,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    reason TEXT NOT NULL DEFAULT '',
+    history JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_shift_swaps_organization ON shift_swaps(organization_id, created_at DESC);
+
+CREATE TRIGGER update_shift_swaps_updated_at BEFORE UPDATE ON shift_swaps FOR EACH ROW EXECUTE FUNCTION update_updated_at();
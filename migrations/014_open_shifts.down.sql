@@ -0,0 +1,3 @@
+-- 募集シフトテーブル削除
+DROP TRIGGER IF EXISTS update_open_shifts_updated_at ON open_shifts;
+DROP TABLE IF EXISTS open_shifts;
@@ -0,0 +1,28 @@
+-- 募集シフトテーブル
+-- 急な欠勤などで空いた勤務表エントリの枠を募集し、条件を満たすスタッフの応募で埋める
+-- 埋まった時点で応募者のエントリにシフトを割り当て、元のスタッフのエントリを未割当に戻す
+CREATE TABLE IF NOT EXISTS open_shifts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    schedule_id UUID NOT NULL REFERENCES schedules(id) ON DELETE CASCADE,
+    source_entry_id UUID REFERENCES schedule_entries(id) ON DELETE SET NULL,
+    source_staff_id UUID REFERENCES staffs(id) ON DELETE SET NULL,
+    target_date DATE NOT NULL,
+    shift_type_id UUID NOT NULL REFERENCES shift_types(id) ON DELETE CASCADE,
+    required_skill_id UUID REFERENCES skills(id) ON DELETE SET NULL,
+    required_job_type_id UUID REFERENCES job_types(id) ON DELETE SET NULL,
+    fill_mode VARCHAR(20) NOT NULL DEFAULT 'first_come',
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    note TEXT NOT NULL DEFAULT '',
+    claims JSONB NOT NULL DEFAULT '[]',
+    filled_staff_id UUID REFERENCES staffs(id) ON DELETE SET NULL,
+    filled_by VARCHAR(255) NOT NULL DEFAULT '',
+    filled_at TIMESTAMPTZ,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_open_shifts_organization ON open_shifts(organization_id, status, target_date);
+
+CREATE TRIGGER update_open_shifts_updated_at BEFORE UPDATE ON open_shifts FOR EACH ROW EXECUTE FUNCTION update_updated_at();
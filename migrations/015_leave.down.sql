@@ -0,0 +1,4 @@
+-- 休暇管理テーブル削除
+DROP TRIGGER IF EXISTS update_leave_requests_updated_at ON leave_requests;
+DROP TABLE IF EXISTS leave_requests;
+DROP TABLE IF EXISTS leave_grants;
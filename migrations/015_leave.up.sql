@@ -0,0 +1,45 @@
+-- 年次有給休暇付与テーブル
+-- 入社日に基づく法定付与と管理者の手動付与 付与日から2年間有効
+CREATE TABLE IF NOT EXISTS leave_grants (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    staff_id UUID NOT NULL REFERENCES staffs(id) ON DELETE CASCADE,
+    grant_date DATE NOT NULL,
+    expires_on DATE NOT NULL,
+    days DOUBLE PRECISION NOT NULL,
+    source VARCHAR(20) NOT NULL DEFAULT 'statutory',
+    note TEXT NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_leave_grants_staff ON leave_grants(staff_id, grant_date);
+CREATE INDEX idx_leave_grants_organization ON leave_grants(organization_id);
+-- 法定付与の重複登録防止
+CREATE UNIQUE INDEX idx_leave_grants_statutory ON leave_grants(staff_id, grant_date) WHERE source = 'statutory';
+
+-- 休暇申請テーブル
+-- 承認すると期間中のschedule_entriesを休日扱いのシフト種別にする 勤務表がない月は作成後に配置する
+CREATE TABLE IF NOT EXISTS leave_requests (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    staff_id UUID NOT NULL REFERENCES staffs(id) ON DELETE CASCADE,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    shift_type_id UUID NOT NULL REFERENCES shift_types(id),
+    reason TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    requested_by VARCHAR(255) NOT NULL DEFAULT '',
+    decided_by VARCHAR(255) NOT NULL DEFAULT '',
+    decided_at TIMESTAMPTZ,
+    decision_comment TEXT NOT NULL DEFAULT '',
+    placed_dates JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT leave_requests_period CHECK (end_date >= start_date)
+);
+
+CREATE INDEX idx_leave_requests_organization ON leave_requests(organization_id, status, start_date);
+CREATE INDEX idx_leave_requests_staff ON leave_requests(staff_id, start_date);
+
+CREATE TRIGGER update_leave_requests_updated_at BEFORE UPDATE ON leave_requests FOR EACH ROW EXECUTE FUNCTION update_updated_at();
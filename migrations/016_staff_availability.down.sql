@@ -0,0 +1,4 @@
+-- スタッフ勤務可能条件テーブル削除
+DROP TABLE IF EXISTS staff_availability_exceptions;
+DROP TRIGGER IF EXISTS update_staff_availabilities_updated_at ON staff_availabilities;
+DROP TABLE IF EXISTS staff_availabilities;
@@ -0,0 +1,31 @@
+-- スタッフ勤務可能条件テーブル
+-- 曜日ごとの勤務可否・時間帯、勤務可能なシフト種別、週の上限勤務日数をスタッフごとに1件保持する
+CREATE TABLE IF NOT EXISTS staff_availabilities (
+    staff_id UUID PRIMARY KEY REFERENCES staffs(id) ON DELETE CASCADE,
+    weekly JSONB NOT NULL DEFAULT '[]',
+    allowed_shift_type_ids JSONB NOT NULL DEFAULT '[]',
+    max_days_per_week INT NOT NULL DEFAULT 0,
+    note TEXT NOT NULL DEFAULT '',
+    updated_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT staff_availabilities_max_days CHECK (max_days_per_week BETWEEN 0 AND 7)
+);
+
+CREATE TRIGGER update_staff_availabilities_updated_at BEFORE UPDATE ON staff_availabilities FOR EACH ROW EXECUTE FUNCTION update_updated_at();
+
+-- 勤務可能条件の期間例外テーブル
+-- 期間中は曜日ごとの条件に代えて終日勤務不可または勤務可とする
+CREATE TABLE IF NOT EXISTS staff_availability_exceptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    staff_id UUID NOT NULL REFERENCES staffs(id) ON DELETE CASCADE,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    available BOOLEAN NOT NULL DEFAULT FALSE,
+    reason TEXT NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT staff_availability_exceptions_period CHECK (end_date >= start_date)
+);
+
+CREATE INDEX idx_staff_availability_exceptions_staff ON staff_availability_exceptions(staff_id, start_date);
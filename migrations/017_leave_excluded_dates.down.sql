@@ -0,0 +1,3 @@
+-- 休暇申請の取得しない日削除
+ALTER TABLE leave_requests
+    DROP COLUMN IF EXISTS excluded_dates;
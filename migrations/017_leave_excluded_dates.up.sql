@@ -0,0 +1,3 @@
+-- 休暇申請の期間中で休暇を取得しない日 公休日など 取得日数に数えず勤務表にも配置しない
+ALTER TABLE leave_requests
+    ADD COLUMN excluded_dates JSONB NOT NULL DEFAULT '[]';